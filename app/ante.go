@@ -0,0 +1,47 @@
+package app
+
+import (
+	wasmkeeper "github.com/CosmWasm/wasmd/x/wasm/keeper"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth/ante"
+	"github.com/cosmos/cosmos-sdk/x/auth/signing"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+)
+
+// NewAnteHandler returns an AnteHandler that checks and increments sequence
+// numbers, checks signatures & account numbers, and deducts fees from the first
+// signer. In addition to the SDK default decorators, wasm specific mempool checks
+// are applied. Permissioned chains reject the wasm messages that are allowed via
+// governance only before any fees are deducted.
+func NewAnteHandler(
+	ak ante.AccountKeeper,
+	bankKeeper authtypes.BankKeeper,
+	sigGasConsumer ante.SignatureVerificationGasConsumer,
+	signModeHandler signing.SignModeHandler,
+	wasmKeeper wasmkeeper.Keeper,
+	permissioned bool,
+) sdk.AnteHandler {
+	decorators := []sdk.AnteDecorator{
+		ante.NewSetUpContextDecorator(), // outermost AnteDecorator. SetUpContext must be called first
+		ante.NewRejectExtensionOptionsDecorator(),
+	}
+	if permissioned {
+		decorators = append(decorators, wasmkeeper.NewGovOnlyDecorator())
+	}
+	decorators = append(decorators,
+		ante.NewMempoolFeeDecorator(),
+		ante.NewValidateBasicDecorator(),
+		ante.TxTimeoutHeightDecorator{},
+		ante.NewValidateMemoDecorator(ak),
+		ante.NewConsumeGasForTxSizeDecorator(ak),
+		ante.NewRejectFeeGranterDecorator(),
+		ante.NewSetPubKeyDecorator(ak), // SetPubKeyDecorator must be called before all signature verification decorators
+		ante.NewValidateSigCountDecorator(ak),
+		ante.NewDeductFeeDecorator(ak, bankKeeper),
+		ante.NewSigGasConsumeDecorator(ak, sigGasConsumer),
+		ante.NewSigVerificationDecorator(ak, signModeHandler),
+		wasmkeeper.NewLimitWasmCodeSizeDecorator(wasmKeeper), // unpacks gzipped code, run after fees and signatures are checked
+		ante.NewIncrementSequenceDecorator(ak),
+	)
+	return sdk.ChainAnteDecorators(decorators...)
+}
@@ -20,6 +20,7 @@ import (
 
 	"github.com/CosmWasm/wasmd/x/wasm"
 	wasmclient "github.com/CosmWasm/wasmd/x/wasm/client"
+	wasmkeeper "github.com/CosmWasm/wasmd/x/wasm/keeper"
 	"github.com/cosmos/cosmos-sdk/baseapp"
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/rpc"
@@ -94,6 +95,9 @@ import (
 
 const appName = "WasmApp"
 
+// WasmV2UpgradeName is the name of the upgrade plan that migrates the wasm module state from version 1 to 2
+const WasmV2UpgradeName = "wasm-v2"
+
 // We pull these out so we can set them with LDFLAGS in the Makefile
 var (
 	NodeDir      = ".wasmd"
@@ -106,6 +110,9 @@ var (
 	// of "EnableAllProposals" (takes precedence over ProposalsEnabled)
 	// https://github.com/CosmWasm/wasmd/blob/02a54d33ff2c064f3539ae12d75d027d9c665f05/x/wasm/internal/types/proposal.go#L28-L34
 	EnableSpecificProposals = ""
+	// If "true", code can only be stored and contracts can only be instantiated via governance proposals.
+	// The MsgStoreCode and MsgInstantiateContract messages are rejected.
+	PermissionedWasm = "false"
 )
 
 // GetEnabledProposals parses the ProposalsEnabled / EnableSpecificProposals values to
@@ -125,6 +132,14 @@ func GetEnabledProposals() []wasm.ProposalType {
 	return proposals
 }
 
+// NewWasmAppModule returns the wasm app module for the PermissionedWasm setting
+func NewWasmAppModule(cdc codec.Marshaler, k *wasm.Keeper, validatorSetSource wasmkeeper.ValidatorSetSource) wasm.AppModule {
+	if PermissionedWasm == "true" {
+		return wasm.NewPermissionedAppModule(cdc, k, validatorSetSource)
+	}
+	return wasm.NewAppModule(cdc, k, validatorSetSource)
+}
+
 // These constants are derived from the above variables.
 // These are the ones we will want to use in the code, based on
 // any overrides above
@@ -182,6 +197,7 @@ var (
 		govtypes.ModuleName:            {authtypes.Burner},
 		ibctransfertypes.ModuleName:    {authtypes.Minter, authtypes.Burner},
 		wasm.ModuleName:                {authtypes.Burner},
+		wasm.DepositEscrowName:         {authtypes.Burner},
 	}
 
 	// module accounts that are allowed to receive tokens
@@ -352,10 +368,23 @@ func NewWasmApp(logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest b
 	if err != nil {
 		panic("error while reading wasm config: " + err.Error())
 	}
+	// the binary is switched for a planned upgrade when the upgrade info is written
+	upgradeInfo, err := app.upgradeKeeper.ReadUpgradeInfoFromDisk()
+	if err != nil {
+		panic("error while reading upgrade info: " + err.Error())
+	}
+	wasmConfig.UpgradeName = upgradeInfo.Name
 
 	// The last arguments can contain custom message handlers, and custom query handlers,
 	// if we want to allow any custom callbacks
 	supportedFeatures := "staking,stargate"
+	// the byte code stream loads the code with ABCI queries on the committed state of the app
+	wasmOpts = append(wasmOpts, wasmkeeper.WithCodeStream(app.BaseApp))
+	// the funds of bricked contracts can be recovered by gov proposals with a long voting period only.
+	// The gov keeper is set below and read when a proposal is executed.
+	wasmOpts = append(wasmOpts, wasmkeeper.WithContractFundsRecovery(&app.govKeeper, wasmkeeper.DefaultRecoverContractFundsMinVotingPeriod))
+	// the open channels of a contract are read with the prefix of its port from the IBC store
+	wasmOpts = append(wasmOpts, wasmkeeper.WithPortChannelSource(wasmkeeper.NewIBCPortChannelSource(keys[ibchost.StoreKey], appCodec)))
 	app.wasmKeeper = wasm.NewKeeper(
 		appCodec,
 		keys[wasm.StoreKey],
@@ -380,6 +409,8 @@ func NewWasmApp(logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest b
 	if len(enabledProposals) != 0 {
 		govRouter.AddRoute(wasm.RouterKey, wasm.NewWasmProposalHandler(app.wasmKeeper, enabledProposals))
 	}
+	// the wasm IBC handler can be wrapped by IBC middleware here. Use the `wasm.WithICS4Wrapper` option
+	// so that outgoing contract packets are passed through the middleware stack, too.
 	ibcRouter.AddRoute(wasm.ModuleName, wasm.NewIBCHandler(app.wasmKeeper, app.ibcKeeper.ChannelKeeper))
 	app.ibcKeeper.SetRouter(ibcRouter)
 
@@ -392,6 +423,11 @@ func NewWasmApp(logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest b
 		&stakingKeeper,
 		govRouter,
 	)
+	app.upgradeKeeper.SetUpgradeHandler(WasmV2UpgradeName, func(ctx sdk.Context, plan upgradetypes.Plan) {
+		if err := wasmkeeper.NewMigrator(app.wasmKeeper).Migrate1to2(ctx); err != nil {
+			panic(err)
+		}
+	})
 	/****  Module Options ****/
 
 	// NOTE: we may consider parsing `appOpts` inside module constructors. For the moment
@@ -416,7 +452,7 @@ func NewWasmApp(logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest b
 		distr.NewAppModule(appCodec, app.distrKeeper, app.accountKeeper, app.bankKeeper, app.stakingKeeper),
 		staking.NewAppModule(appCodec, app.stakingKeeper, app.accountKeeper, app.bankKeeper),
 		upgrade.NewAppModule(app.upgradeKeeper),
-		wasm.NewAppModule(appCodec, &app.wasmKeeper, app.stakingKeeper),
+		NewWasmAppModule(appCodec, &app.wasmKeeper, app.stakingKeeper),
 		evidence.NewAppModule(app.evidenceKeeper),
 		ibc.NewAppModule(app.ibcKeeper),
 		params.NewAppModule(app.paramsKeeper),
@@ -431,7 +467,7 @@ func NewWasmApp(logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest b
 		upgradetypes.ModuleName, minttypes.ModuleName, distrtypes.ModuleName, slashingtypes.ModuleName,
 		evidencetypes.ModuleName, stakingtypes.ModuleName, ibchost.ModuleName,
 	)
-	app.mm.SetOrderEndBlockers(crisistypes.ModuleName, govtypes.ModuleName, stakingtypes.ModuleName)
+	app.mm.SetOrderEndBlockers(crisistypes.ModuleName, govtypes.ModuleName, stakingtypes.ModuleName, wasm.ModuleName)
 
 	// NOTE: The genutils module must occur after staking so that pools are
 	// properly initialized with tokens from genesis accounts.
@@ -469,7 +505,7 @@ func NewWasmApp(logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest b
 		distr.NewAppModule(appCodec, app.distrKeeper, app.accountKeeper, app.bankKeeper, app.stakingKeeper),
 		slashing.NewAppModule(appCodec, app.slashingKeeper, app.accountKeeper, app.bankKeeper, app.stakingKeeper),
 		params.NewAppModule(app.paramsKeeper),
-		wasm.NewAppModule(appCodec, &app.wasmKeeper, app.stakingKeeper),
+		NewWasmAppModule(appCodec, &app.wasmKeeper, app.stakingKeeper),
 		evidence.NewAppModule(app.evidenceKeeper),
 		ibc.NewAppModule(app.ibcKeeper),
 		transferModule,
@@ -486,9 +522,9 @@ func NewWasmApp(logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest b
 	app.SetInitChainer(app.InitChainer)
 	app.SetBeginBlocker(app.BeginBlocker)
 	app.SetAnteHandler(
-		ante.NewAnteHandler(
+		NewAnteHandler(
 			app.accountKeeper, app.bankKeeper, ante.DefaultSigVerificationGasConsumer,
-			encodingConfig.TxConfig.SignModeHandler(),
+			encodingConfig.TxConfig.SignModeHandler(), app.wasmKeeper, PermissionedWasm == "true",
 		),
 	)
 	app.SetEndBlocker(app.EndBlocker)
@@ -614,6 +650,11 @@ func (app *WasmApp) AppCodec() codec.JSONMarshaler {
 	return app.appCodec
 }
 
+// WasmKeeper returns the wasm keeper
+func (app *WasmApp) WasmKeeper() wasm.Keeper {
+	return app.wasmKeeper
+}
+
 // RegisterSwaggerAPI registers swagger route with API Server
 func RegisterSwaggerAPI(_ client.Context, rtr *mux.Router) {
 	statikFS, err := fs.New()
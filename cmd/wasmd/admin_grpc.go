@@ -0,0 +1,94 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"net"
+
+	"github.com/cosmos/cosmos-sdk/server"
+	servertypes "github.com/cosmos/cosmos-sdk/server/types"
+	"github.com/spf13/cobra"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+	"google.golang.org/grpc"
+
+	"github.com/CosmWasm/wasmd/app"
+	"github.com/CosmWasm/wasmd/x/wasm"
+	wasmkeeper "github.com/CosmWasm/wasmd/x/wasm/keeper"
+)
+
+// wasmAdminGRPC runs the node local wasm admin gRPC server with the start command. It is disabled unless the
+// `wasm.admin_grpc_address` is set.
+type wasmAdminGRPC struct {
+	lis   net.Listener
+	token string
+	srv   *grpc.Server
+}
+
+// wrapStartCmd opens the admin listener before the node is started so that a misconfiguration fails the start
+// command. The server is stopped when the node shuts down.
+func (a *wasmAdminGRPC) wrapStartCmd(rootCmd *cobra.Command) {
+	for _, c := range rootCmd.Commands() {
+		if c.Name() != "start" {
+			continue
+		}
+		runE := c.RunE
+		c.RunE = func(cmd *cobra.Command, args []string) error {
+			wasmConfig, err := wasm.ReadWasmConfig(server.GetServerContextFromCmd(cmd).Viper)
+			if err != nil {
+				return err
+			}
+			if len(wasmConfig.AdminGRPCAddress) == 0 {
+				return runE(cmd, args)
+			}
+			if err := a.listen(wasmConfig); err != nil {
+				return err
+			}
+			defer a.stop()
+			return runE(cmd, args)
+		}
+		return
+	}
+}
+
+func (a *wasmAdminGRPC) listen(wasmConfig wasm.Config) error {
+	if len(wasmConfig.AdminGRPCToken) == 0 {
+		return errors.New("wasm admin grpc server requires a token")
+	}
+	lis, err := wasmkeeper.ListenAdminGRPC(wasmConfig.AdminGRPCAddress)
+	if err != nil {
+		return err
+	}
+	a.lis, a.token = lis, wasmConfig.AdminGRPCToken
+	return nil
+}
+
+// appCreator serves the admin service with the keeper of the app when the listener was opened
+func (a *wasmAdminGRPC) appCreator(appCreator servertypes.AppCreator) servertypes.AppCreator {
+	return func(logger log.Logger, db dbm.DB, traceStore io.Writer, appOpts servertypes.AppOptions) servertypes.Application {
+		result := appCreator(logger, db, traceStore, appOpts)
+		if a.lis == nil {
+			return result
+		}
+		k := result.(*app.WasmApp).WasmKeeper()
+		srv, err := wasmkeeper.NewAdminGRPCServer(&k, a.token)
+		if err != nil {
+			panic("error while creating wasm admin grpc server: " + err.Error())
+		}
+		a.srv = srv
+		go func() {
+			if err := srv.Serve(a.lis); err != nil {
+				logger.Error("wasm admin grpc server", "error", err.Error())
+			}
+		}()
+		return result
+	}
+}
+
+func (a *wasmAdminGRPC) stop() {
+	if a.srv != nil {
+		a.srv.Stop()
+		return
+	}
+	_ = a.lis.Close()
+}
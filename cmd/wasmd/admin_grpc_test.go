@@ -0,0 +1,63 @@
+package main
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm"
+)
+
+func TestWasmAdminGRPCListen(t *testing.T) {
+	inUse, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer inUse.Close()
+
+	specs := map[string]struct {
+		address string
+		token   string
+		expErr  bool
+	}{
+		"free address": {
+			address: "127.0.0.1:0",
+			token:   "secret",
+		},
+		"address in use": {
+			address: inUse.Addr().String(),
+			token:   "secret",
+			expErr:  true,
+		},
+		"non loopback address": {
+			address: "0.0.0.0:0",
+			token:   "secret",
+			expErr:  true,
+		},
+		"empty token": {
+			address: "127.0.0.1:0",
+			expErr:  true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var a wasmAdminGRPC
+			cfg := wasm.DefaultWasmConfig()
+			cfg.AdminGRPCAddress, cfg.AdminGRPCToken = spec.address, spec.token
+
+			err := a.listen(cfg)
+			if spec.expErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			// the listener is closed on stop
+			addr := a.lis.Addr().String()
+			a.stop()
+			lis, err := net.Listen("tcp", addr)
+			require.NoError(t, err)
+			lis.Close()
+		})
+	}
+}
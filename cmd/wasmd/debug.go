@@ -0,0 +1,722 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	wasmvm "github.com/CosmWasm/wasmvm"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	"github.com/cosmos/cosmos-sdk/client/debug"
+	"github.com/cosmos/cosmos-sdk/server"
+	servertypes "github.com/cosmos/cosmos-sdk/server/types"
+	"github.com/cosmos/cosmos-sdk/store/iavl"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	"github.com/cosmos/cosmos-sdk/store/rootmulti"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkkv "github.com/cosmos/cosmos-sdk/types/kv"
+	"github.com/spf13/cobra"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/node"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/state/txindex/kv"
+	tmstore "github.com/tendermint/tendermint/store"
+	tmtypes "github.com/tendermint/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/CosmWasm/wasmd/app"
+	"github.com/CosmWasm/wasmd/x/wasm"
+	"github.com/CosmWasm/wasmd/x/wasm/client/utils"
+	wasmkeeper "github.com/CosmWasm/wasmd/x/wasm/keeper"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// debugCommand returns the sdk debug command extended with wasm sub commands
+func debugCommand() *cobra.Command {
+	cmd := debug.Cmd()
+	cmd.AddCommand(TraceTxCmd(), CheckWasmCacheCmd(), ImportWasmCacheCmd(), ContractStateDiffCmd())
+	return cmd
+}
+
+// TraceTxCmd replays a transaction against the historical state of the local node with contract debug mode enabled.
+func TraceTxCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "trace-tx [tx_hash_hex]",
+		Short: "Replay a transaction against the local historic state and trace the contract executions",
+		Long: `Replay a transaction against the local historic state and trace the contract executions.
+The state of the block before the transaction height is loaded from the node home directory and the
+transactions of the block are executed in order. For the requested transaction the contract entry points
+with gas used, the store reads and writes and the messages dispatched by contracts are printed.
+Contract debug mode is enabled.
+
+The node must be stopped, the transaction must be found in the local tx index and the state
+of the previous height must not be pruned. Nothing is persisted.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			hash, err := hex.DecodeString(args[0])
+			if err != nil {
+				return fmt.Errorf("tx hash: %s", err)
+			}
+			serverCtx := server.GetServerContextFromCmd(cmd)
+			cfg := serverCtx.Config
+
+			txIndexDB, err := node.DefaultDBProvider(&node.DBContext{ID: "tx_index", Config: cfg})
+			if err != nil {
+				return err
+			}
+			defer txIndexDB.Close()
+			txResult, err := kv.NewTxIndex(txIndexDB).Get(hash)
+			if err != nil {
+				return err
+			}
+			if txResult == nil {
+				return fmt.Errorf("tx %X not found in local tx index", hash)
+			}
+			if txResult.Height < 2 {
+				return fmt.Errorf("can not trace tx at height %d", txResult.Height)
+			}
+
+			blockStoreDB, err := node.DefaultDBProvider(&node.DBContext{ID: "blockstore", Config: cfg})
+			if err != nil {
+				return err
+			}
+			defer blockStoreDB.Close()
+			block := tmstore.NewBlockStore(blockStoreDB).LoadBlock(txResult.Height)
+			if block == nil {
+				return fmt.Errorf("block %d not found in local block store", txResult.Height)
+			}
+
+			stateDB, err := node.DefaultDBProvider(&node.DBContext{ID: "state", Config: cfg})
+			if err != nil {
+				return err
+			}
+			defer stateDB.Close()
+			lastCommitInfo, err := lastCommitInfo(block, sm.NewStore(stateDB))
+			if err != nil {
+				return err
+			}
+
+			appDB, err := sdk.NewLevelDB("application", filepath.Join(cfg.RootDir, "data"))
+			if err != nil {
+				return err
+			}
+			defer appDB.Close()
+
+			out := &traceWriter{w: cmd.OutOrStdout()}
+			wasmOpts := []wasm.Option{
+				wasmkeeper.WithWasmEngineDecorator(func(old types.WasmerEngine) types.WasmerEngine {
+					return &tracingWasmEngine{WasmerEngine: old, out: out}
+				}),
+				wasmkeeper.WithMessageHandlerDecorator(func(old wasmkeeper.Messenger) wasmkeeper.Messenger {
+					return &tracingMessenger{Messenger: old, out: out}
+				}),
+			}
+			wasmApp := app.NewWasmApp(serverCtx.Logger, appDB, out, false, map[int64]bool{}, cfg.RootDir, 0,
+				app.GetEnabledProposals(), contractDebugAppOptions{serverCtx.Viper}, wasmOpts)
+			if err := wasmApp.LoadHeight(txResult.Height - 1); err != nil {
+				return err
+			}
+
+			var byzantineValidators []abci.Evidence
+			for _, ev := range block.Evidence.Evidence {
+				byzantineValidators = append(byzantineValidators, ev.ABCI()...)
+			}
+			wasmApp.BeginBlock(abci.RequestBeginBlock{
+				Hash:                block.Hash(),
+				Header:              *block.Header.ToProto(),
+				LastCommitInfo:      lastCommitInfo,
+				ByzantineValidators: byzantineValidators,
+			})
+			// replay all txs before the requested one in the block to get the same state
+			for i, tx := range block.Data.Txs {
+				if uint32(i) != txResult.Index {
+					wasmApp.DeliverTx(abci.RequestDeliverTx{Tx: tx})
+					continue
+				}
+				out.enabled = true
+				res := wasmApp.DeliverTx(abci.RequestDeliverTx{Tx: tx})
+				out.enabled = false
+				return printTraceResult(cmd.OutOrStdout(), res)
+			}
+			return fmt.Errorf("tx index %d not found in block %d", txResult.Index, txResult.Height)
+		},
+	}
+	return cmd
+}
+
+const flagRepairFrom = "repair-from"
+
+// CheckWasmCacheCmd verifies the wasm code in the local wasmvm cache against the code infos in the state.
+func CheckWasmCacheCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "check-wasm-cache",
+		Short: "Verify the wasm code stored on disk against the code hashes in the local state",
+		Long: `Verify the wasm code stored on disk against the code hashes in the local state.
+For every code id in the latest state the wasm code is loaded from the wasmvm cache in the node home directory,
+hashed and compared to the code hash of the code info. The VM code validation is run again.
+
+The wasm code is not part of the chain state. With --repair-from a directory of trusted wasm files, for example
+a copy of the wasm cache of another node or extracted from a genesis file, is used to replace missing or corrupted
+codes. Files can be gzip compressed and are matched by their hash. The node must be stopped.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			serverCtx := server.GetServerContextFromCmd(cmd)
+			var repair func(codeHash []byte) []byte
+			if repairDir, err := cmd.Flags().GetString(flagRepairFrom); err != nil {
+				return err
+			} else if repairDir != "" {
+				codes, err := loadWasmCodes(repairDir)
+				if err != nil {
+					return err
+				}
+				repair = func(codeHash []byte) []byte {
+					return codes[string(codeHash)]
+				}
+			}
+
+			wasmApp, closeDB, err := loadLatestWasmApp(serverCtx)
+			if err != nil {
+				return err
+			}
+			defer closeDB()
+			ctx := wasmApp.NewUncachedContext(true, tmproto.Header{})
+
+			var total, failed int
+			out := cmd.OutOrStdout()
+			wasmApp.WasmKeeper().CheckWasmCache(ctx, repair, func(res wasmkeeper.CodeCheckResult) {
+				total++
+				switch {
+				case res.Err == nil:
+					fmt.Fprintf(out, "code %d %X: ok\n", res.CodeID, res.CodeHash)
+				case res.Repaired:
+					fmt.Fprintf(out, "code %d %X: repaired: %s\n", res.CodeID, res.CodeHash, res.Err)
+				default:
+					failed++
+					fmt.Fprintf(out, "code %d %X: failed: %s\n", res.CodeID, res.CodeHash, res.Err)
+				}
+			})
+			if failed != 0 {
+				return fmt.Errorf("%d of %d codes failed the check", failed, total)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().String(flagRepairFrom, "", "Directory with wasm files to repair missing or corrupted codes")
+	return cmd
+}
+
+const flagWithModules = "with-modules"
+
+// ImportWasmCacheCmd imports the wasm code and compiled modules from the wasm cache of another node.
+func ImportWasmCacheCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import-wasm-cache [source_home]",
+		Short: "Import the wasm code of all codes in the local state from the home directory of another node",
+		Long: `Import the wasm code of all codes in the local state from the home directory of another node.
+For every code id in the latest state that is missing or corrupted in the local wasmvm cache, the wasm code
+is read from the wasmvm cache of the source node home directory. It is imported only when it matches the
+code hash and passes the VM code validation.
+
+With --with-modules the compiled modules of the verified codes are copied, too, so that they do not need to be
+compiled again. Compiled modules can not be verified against the code hash and are loaded by the VM without
+further checks. Only use them from a trusted node running the same wasmvm version. The node must be stopped.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			serverCtx := server.GetServerContextFromCmd(cmd)
+			withModules, err := cmd.Flags().GetBool(flagWithModules)
+			if err != nil {
+				return err
+			}
+			srcDir, dstDir := wasmVMDataDir(args[0]), wasmVMDataDir(serverCtx.Config.RootDir)
+			if _, err := os.Stat(srcDir); err != nil {
+				return fmt.Errorf("source wasm cache: %s", err)
+			}
+
+			wasmApp, closeDB, err := loadLatestWasmApp(serverCtx)
+			if err != nil {
+				return err
+			}
+			defer closeDB()
+			ctx := wasmApp.NewUncachedContext(true, tmproto.Header{})
+
+			repair := func(codeHash []byte) []byte {
+				code, err := ioutil.ReadFile(filepath.Join(srcDir, "wasm", hex.EncodeToString(codeHash)))
+				if err != nil {
+					return nil
+				}
+				return code
+			}
+			var total, imported, modules, failed int
+			var copyErr error
+			out := cmd.OutOrStdout()
+			wasmApp.WasmKeeper().CheckWasmCache(ctx, repair, func(res wasmkeeper.CodeCheckResult) {
+				total++
+				switch {
+				case res.Err == nil:
+				case res.Repaired:
+					imported++
+					fmt.Fprintf(out, "code %d %X: imported\n", res.CodeID, res.CodeHash)
+				default:
+					failed++
+					fmt.Fprintf(out, "code %d %X: failed: %s\n", res.CodeID, res.CodeHash, res.Err)
+					return
+				}
+				if !withModules || copyErr != nil {
+					return
+				}
+				n, err := copyCompiledModules(srcDir, dstDir, res.CodeHash)
+				if err != nil {
+					copyErr = fmt.Errorf("code %d: compiled module: %s", res.CodeID, err)
+				}
+				modules += n
+			})
+			fmt.Fprintf(out, "codes: %d imported: %d compiled modules copied: %d failed: %d\n", total, imported, modules, failed)
+			if copyErr != nil {
+				return copyErr
+			}
+			if failed != 0 {
+				return fmt.Errorf("%d of %d codes could not be imported", failed, total)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().Bool(flagWithModules, false, "Copy the compiled modules of the imported codes from the trusted source node")
+	return cmd
+}
+
+// UnsafeResetWasmCacheCmd removes the compiled modules from the local wasmvm cache. The wasm code is kept.
+func UnsafeResetWasmCacheCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "unsafe-reset-wasm-cache",
+		Short: "Remove the compiled modules from the local wasmvm cache, keeping the wasm code",
+		Long: `Remove the compiled modules from the local wasmvm cache, keeping the wasm code.
+Use it to recover from a libwasmvm upgrade that changed the format of the compiled modules. The stored wasm
+code is not touched. Modules are compiled again from the wasm code on first use and the pinned codes on node
+start, which makes the first contract calls slower. The node must be stopped.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			serverCtx := server.GetServerContextFromCmd(cmd)
+			modulesDir := filepath.Join(wasmVMDataDir(serverCtx.Config.RootDir), "modules")
+			if _, err := os.Stat(modulesDir); os.IsNotExist(err) {
+				fmt.Fprintf(cmd.OutOrStdout(), "no compiled modules in %s\n", modulesDir)
+				return nil
+			}
+			if err := os.RemoveAll(modulesDir); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "removed compiled modules in %s\n", modulesDir)
+			return nil
+		},
+	}
+}
+
+// wasmVMDataDir returns the wasmvm data directory within a node home directory
+func wasmVMDataDir(home string) string {
+	return filepath.Join(home, "wasm", "wasm")
+}
+
+// copyCompiledModules copies the compiled modules of a code from the modules cache of the source wasmvm data
+// directory for all module versions that do not exist in the destination, yet. It returns the number of modules copied.
+func copyCompiledModules(srcDir, dstDir string, codeHash []byte) (int, error) {
+	versions, err := ioutil.ReadDir(filepath.Join(srcDir, "modules"))
+	switch {
+	case os.IsNotExist(err):
+		return 0, nil
+	case err != nil:
+		return 0, err
+	}
+	var n int
+	for _, v := range versions {
+		if !v.IsDir() {
+			continue
+		}
+		name := hex.EncodeToString(codeHash)
+		src := filepath.Join(srcDir, "modules", v.Name(), name)
+		dst := filepath.Join(dstDir, "modules", v.Name(), name)
+		if _, err := os.Stat(src); os.IsNotExist(err) {
+			continue
+		}
+		if _, err := os.Stat(dst); err == nil {
+			continue
+		}
+		bz, err := ioutil.ReadFile(src)
+		if err != nil {
+			return n, err
+		}
+		if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+			return n, err
+		}
+		// write to a temporary file first so that the VM never loads a partial module
+		if err := ioutil.WriteFile(dst+".tmp", bz, 0o644); err != nil {
+			return n, err
+		}
+		if err := os.Rename(dst+".tmp", dst); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, nil
+}
+
+// loadLatestWasmApp loads the app with the latest state from the node home directory without initializing the VM
+// cache with the pinned codes. The returned function closes the database.
+func loadLatestWasmApp(serverCtx *server.Context) (*app.WasmApp, func(), error) {
+	appDB, err := sdk.NewLevelDB("application", filepath.Join(serverCtx.Config.RootDir, "data"))
+	if err != nil {
+		return nil, nil, err
+	}
+	wasmApp := app.NewWasmApp(serverCtx.Logger, appDB, nil, false, map[int64]bool{}, serverCtx.Config.RootDir, 0,
+		app.GetEnabledProposals(), serverCtx.Viper, nil)
+	if err := wasmApp.LoadLatestVersion(); err != nil {
+		appDB.Close()
+		return nil, nil, err
+	}
+	return wasmApp, func() { appDB.Close() }, nil
+}
+
+// ContractStateDiffCmd prints the changes of the store of a contract between two heights of the local state.
+func ContractStateDiffCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "contract-state-diff [bech32_address] [from_height] [to_height]",
+		Short: "Print the changes of the store of a contract between two heights of the local state as json",
+		Long: `Print the changes of the store of a contract between two heights of the local state as json.
+The store of the contract is loaded at both heights from the node home directory and the keys that were
+added, removed or changed are printed with hex encoded keys and base64 encoded values.
+
+The node must be stopped and the state of both heights must not be pruned, for example on an archive node.`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			contractAddr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return fmt.Errorf("contract: %s", err)
+			}
+			fromHeight, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("from height: %s", err)
+			}
+			toHeight, err := strconv.ParseInt(args[2], 10, 64)
+			if err != nil {
+				return fmt.Errorf("to height: %s", err)
+			}
+			if fromHeight < 1 || toHeight <= fromHeight {
+				return fmt.Errorf("invalid heights: %d to %d", fromHeight, toHeight)
+			}
+
+			appDB, err := sdk.NewLevelDB("application", filepath.Join(server.GetServerContextFromCmd(cmd).Config.RootDir, "data"))
+			if err != nil {
+				return err
+			}
+			defer appDB.Close()
+			cms, key, err := openWasmStore(appDB)
+			if err != nil {
+				return err
+			}
+			from, err := loadContractState(cms, key, contractAddr, fromHeight)
+			if err != nil {
+				return err
+			}
+			to, err := loadContractState(cms, key, contractAddr, toHeight)
+			if err != nil {
+				return err
+			}
+
+			diff := diffContractState(from, to)
+			diff.Contract, diff.FromHeight, diff.ToHeight = contractAddr.String(), fromHeight, toHeight
+			bz, err := json.MarshalIndent(diff, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(bz))
+			return nil
+		},
+	}
+	return cmd
+}
+
+// contractStateDiff contains the changes of a contract store between two heights
+type contractStateDiff struct {
+	Contract   string                `json:"contract"`
+	FromHeight int64                 `json:"from_height"`
+	ToHeight   int64                 `json:"to_height"`
+	Added      []types.Model         `json:"added"`
+	Removed    []types.Model         `json:"removed"`
+	Changed    []contractStateChange `json:"changed"`
+}
+
+// contractStateChange is a key with different values at both heights
+type contractStateChange struct {
+	Key      tmbytes.HexBytes `json:"key"`
+	OldValue []byte           `json:"old_value"`
+	NewValue []byte           `json:"new_value"`
+}
+
+// openWasmStore loads the latest committed multi store of the app database with the wasm store mounted only
+func openWasmStore(db dbm.DB) (*rootmulti.Store, sdk.StoreKey, error) {
+	key := sdk.NewKVStoreKey(types.StoreKey)
+	cms := rootmulti.NewStore(db)
+	cms.MountStoreWithDB(key, sdk.StoreTypeIAVL, nil)
+	if err := cms.LoadLatestVersion(); err != nil {
+		return nil, nil, err
+	}
+	return cms, key, nil
+}
+
+// loadContractState returns the store of the contract at the height ordered by key. The keys are relative to the
+// contract store prefix. The state of heights that do not exist or were pruned can not be loaded.
+func loadContractState(cms *rootmulti.Store, key sdk.StoreKey, contractAddr sdk.AccAddress, height int64) ([]sdkkv.Pair, error) {
+	// the versioned iavl store is empty for unknown versions
+	if !cms.GetCommitKVStore(key).(*iavl.Store).VersionExists(height) {
+		return nil, fmt.Errorf("height %d: state not found", height)
+	}
+	ms, err := cms.CacheMultiStoreWithVersion(height)
+	if err != nil {
+		return nil, fmt.Errorf("height %d: %s", height, err)
+	}
+	iter := prefix.NewStore(ms.GetKVStore(key), types.GetContractStorePrefix(contractAddr)).Iterator(nil, nil)
+	defer iter.Close()
+	var pairs []sdkkv.Pair
+	for ; iter.Valid(); iter.Next() {
+		pairs = append(pairs, sdkkv.Pair{Key: iter.Key(), Value: iter.Value()})
+	}
+	return pairs, nil
+}
+
+// diffContractState merges the ordered key value pairs of both heights
+func diffContractState(from, to []sdkkv.Pair) contractStateDiff {
+	diff := contractStateDiff{Added: []types.Model{}, Removed: []types.Model{}, Changed: []contractStateChange{}}
+	var i, j int
+	for i < len(from) || j < len(to) {
+		var c int
+		switch {
+		case i == len(from):
+			c = 1
+		case j == len(to):
+			c = -1
+		default:
+			c = bytes.Compare(from[i].Key, to[j].Key)
+		}
+		switch {
+		case c < 0:
+			diff.Removed = append(diff.Removed, types.Model{Key: from[i].Key, Value: from[i].Value})
+			i++
+		case c > 0:
+			diff.Added = append(diff.Added, types.Model{Key: to[j].Key, Value: to[j].Value})
+			j++
+		default:
+			if !bytes.Equal(from[i].Value, to[j].Value) {
+				diff.Changed = append(diff.Changed, contractStateChange{Key: from[i].Key, OldValue: from[i].Value, NewValue: to[j].Value})
+			}
+			i++
+			j++
+		}
+	}
+	return diff
+}
+
+// loadWasmCodes reads all files in the directory and returns the uncompressed content by sha256 hash
+func loadWasmCodes(dir string) (map[string][]byte, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	codes := make(map[string][]byte, len(files))
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+		code, err := ioutil.ReadFile(filepath.Join(dir, f.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if utils.IsGzip(code) {
+			r, err := gzip.NewReader(bytes.NewReader(code))
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s", f.Name(), err)
+			}
+			if code, err = ioutil.ReadAll(r); err != nil {
+				return nil, fmt.Errorf("%s: %s", f.Name(), err)
+			}
+		}
+		codeHash := sha256.Sum256(code)
+		codes[string(codeHash[:])] = code
+	}
+	return codes, nil
+}
+
+// lastCommitInfo builds the commit info for the begin blocker like tendermint does on block execution
+func lastCommitInfo(block *tmtypes.Block, store sm.Store) (abci.LastCommitInfo, error) {
+	lastValSet, err := store.LoadValidators(block.Height - 1)
+	if err != nil {
+		return abci.LastCommitInfo{}, err
+	}
+	if block.LastCommit.Size() != len(lastValSet.Validators) {
+		return abci.LastCommitInfo{}, fmt.Errorf("commit size %d does not match validator set length %d", block.LastCommit.Size(), len(lastValSet.Validators))
+	}
+	voteInfos := make([]abci.VoteInfo, len(lastValSet.Validators))
+	for i, val := range lastValSet.Validators {
+		voteInfos[i] = abci.VoteInfo{
+			Validator:       tmtypes.TM2PB.Validator(val),
+			SignedLastBlock: !block.LastCommit.Signatures[i].Absent(),
+		}
+	}
+	return abci.LastCommitInfo{Round: block.LastCommit.Round, Votes: voteInfos}, nil
+}
+
+func printTraceResult(w io.Writer, res abci.ResponseDeliverTx) error {
+	_, err := fmt.Fprintf(w, "result: code=%d codespace=%q gas_wanted=%d gas_used=%d\nlog: %s\n", res.Code, res.Codespace, res.GasWanted, res.GasUsed, res.Log)
+	if err != nil {
+		return err
+	}
+	for _, e := range res.Events {
+		attrs := make([]string, len(e.Attributes))
+		for i, a := range e.Attributes {
+			attrs[i] = fmt.Sprintf("%s=%s", a.Key, a.Value)
+		}
+		if _, err := fmt.Fprintf(w, "event: %s %s\n", e.Type, strings.Join(attrs, " ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// contractDebugAppOptions enables the contract debug mode
+type contractDebugAppOptions struct {
+	servertypes.AppOptions
+}
+
+func (o contractDebugAppOptions) Get(key string) interface{} {
+	if key == server.FlagTrace {
+		return true
+	}
+	return o.AppOptions.Get(key)
+}
+
+// traceWriter forwards the output only when enabled so that the replay of other txs is not printed
+type traceWriter struct {
+	w       io.Writer
+	enabled bool
+}
+
+func (t *traceWriter) Write(p []byte) (int, error) {
+	if !t.enabled {
+		return len(p), nil
+	}
+	return t.w.Write(p)
+}
+
+func (t *traceWriter) printf(format string, args ...interface{}) {
+	_, _ = fmt.Fprintf(t, format, args...)
+}
+
+// tracingMessenger prints all messages dispatched by contracts
+type tracingMessenger struct {
+	wasmkeeper.Messenger
+	out *traceWriter
+}
+
+func (m tracingMessenger) DispatchMsg(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) ([]sdk.Event, [][]byte, error) {
+	bz, _ := json.Marshal(msg)
+	m.out.printf("dispatch: contract=%s msg=%s\n", contractAddr, string(bz))
+	events, data, err := m.Messenger.DispatchMsg(ctx, contractAddr, contractIBCPortID, msg)
+	if err != nil {
+		m.out.printf("dispatch: contract=%s error=%q\n", contractAddr, err)
+	}
+	return events, data, err
+}
+
+// tracingWasmEngine prints the gas used by the contract entry points. Gas values are in wasmvm gas.
+type tracingWasmEngine struct {
+	types.WasmerEngine
+	out *traceWriter
+}
+
+func (e tracingWasmEngine) trace(entryPoint string, env wasmvmtypes.Env, gasLimit, gasUsed uint64, err error) {
+	if err != nil {
+		e.out.printf("entry point: %s contract=%s gas_limit=%d gas_used=%d error=%q\n", entryPoint, env.Contract.Address, gasLimit, gasUsed, err)
+		return
+	}
+	e.out.printf("entry point: %s contract=%s gas_limit=%d gas_used=%d\n", entryPoint, env.Contract.Address, gasLimit, gasUsed)
+}
+
+func (e tracingWasmEngine) Instantiate(code wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, initMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.Response, uint64, error) {
+	res, gasUsed, err := e.WasmerEngine.Instantiate(code, env, info, initMsg, store, goapi, querier, gasMeter, gasLimit)
+	e.trace("instantiate", env, gasLimit, gasUsed, err)
+	return res, gasUsed, err
+}
+
+func (e tracingWasmEngine) Execute(code wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.Response, uint64, error) {
+	res, gasUsed, err := e.WasmerEngine.Execute(code, env, info, executeMsg, store, goapi, querier, gasMeter, gasLimit)
+	e.trace("execute", env, gasLimit, gasUsed, err)
+	return res, gasUsed, err
+}
+
+func (e tracingWasmEngine) Query(code wasmvm.Checksum, env wasmvmtypes.Env, queryMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) ([]byte, uint64, error) {
+	res, gasUsed, err := e.WasmerEngine.Query(code, env, queryMsg, store, goapi, querier, gasMeter, gasLimit)
+	e.trace("query", env, gasLimit, gasUsed, err)
+	return res, gasUsed, err
+}
+
+func (e tracingWasmEngine) Migrate(code wasmvm.Checksum, env wasmvmtypes.Env, migrateMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.Response, uint64, error) {
+	res, gasUsed, err := e.WasmerEngine.Migrate(code, env, migrateMsg, store, goapi, querier, gasMeter, gasLimit)
+	e.trace("migrate", env, gasLimit, gasUsed, err)
+	return res, gasUsed, err
+}
+
+func (e tracingWasmEngine) Sudo(code wasmvm.Checksum, env wasmvmtypes.Env, sudoMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.Response, uint64, error) {
+	res, gasUsed, err := e.WasmerEngine.Sudo(code, env, sudoMsg, store, goapi, querier, gasMeter, gasLimit)
+	e.trace("sudo", env, gasLimit, gasUsed, err)
+	return res, gasUsed, err
+}
+
+func (e tracingWasmEngine) Reply(code wasmvm.Checksum, env wasmvmtypes.Env, reply wasmvmtypes.Reply, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.Response, uint64, error) {
+	res, gasUsed, err := e.WasmerEngine.Reply(code, env, reply, store, goapi, querier, gasMeter, gasLimit)
+	e.trace("reply", env, gasLimit, gasUsed, err)
+	return res, gasUsed, err
+}
+
+func (e tracingWasmEngine) IBCChannelOpen(code wasmvm.Checksum, env wasmvmtypes.Env, channel wasmvmtypes.IBCChannel, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (uint64, error) {
+	gasUsed, err := e.WasmerEngine.IBCChannelOpen(code, env, channel, store, goapi, querier, gasMeter, gasLimit)
+	e.trace("ibc_channel_open", env, gasLimit, gasUsed, err)
+	return gasUsed, err
+}
+
+func (e tracingWasmEngine) IBCChannelConnect(code wasmvm.Checksum, env wasmvmtypes.Env, channel wasmvmtypes.IBCChannel, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.IBCBasicResponse, uint64, error) {
+	res, gasUsed, err := e.WasmerEngine.IBCChannelConnect(code, env, channel, store, goapi, querier, gasMeter, gasLimit)
+	e.trace("ibc_channel_connect", env, gasLimit, gasUsed, err)
+	return res, gasUsed, err
+}
+
+func (e tracingWasmEngine) IBCChannelClose(code wasmvm.Checksum, env wasmvmtypes.Env, channel wasmvmtypes.IBCChannel, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.IBCBasicResponse, uint64, error) {
+	res, gasUsed, err := e.WasmerEngine.IBCChannelClose(code, env, channel, store, goapi, querier, gasMeter, gasLimit)
+	e.trace("ibc_channel_close", env, gasLimit, gasUsed, err)
+	return res, gasUsed, err
+}
+
+func (e tracingWasmEngine) IBCPacketReceive(code wasmvm.Checksum, env wasmvmtypes.Env, packet wasmvmtypes.IBCPacket, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.IBCReceiveResponse, uint64, error) {
+	res, gasUsed, err := e.WasmerEngine.IBCPacketReceive(code, env, packet, store, goapi, querier, gasMeter, gasLimit)
+	e.trace("ibc_packet_receive", env, gasLimit, gasUsed, err)
+	return res, gasUsed, err
+}
+
+func (e tracingWasmEngine) IBCPacketAck(code wasmvm.Checksum, env wasmvmtypes.Env, ack wasmvmtypes.IBCAcknowledgement, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.IBCBasicResponse, uint64, error) {
+	res, gasUsed, err := e.WasmerEngine.IBCPacketAck(code, env, ack, store, goapi, querier, gasMeter, gasLimit)
+	e.trace("ibc_packet_ack", env, gasLimit, gasUsed, err)
+	return res, gasUsed, err
+}
+
+func (e tracingWasmEngine) IBCPacketTimeout(code wasmvm.Checksum, env wasmvmtypes.Env, packet wasmvmtypes.IBCPacket, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.IBCBasicResponse, uint64, error) {
+	res, gasUsed, err := e.WasmerEngine.IBCPacketTimeout(code, env, packet, store, goapi, querier, gasMeter, gasLimit)
+	e.trace("ibc_packet_timeout", env, gasLimit, gasUsed, err)
+	return res, gasUsed, err
+}
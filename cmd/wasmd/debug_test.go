@@ -0,0 +1,322 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/server"
+	"github.com/cosmos/cosmos-sdk/store/rootmulti"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmcfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/CosmWasm/wasmd/app"
+	"github.com/CosmWasm/wasmd/x/wasm"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestContractStateDiff(t *testing.T) {
+	db := dbm.NewMemDB()
+	key := sdk.NewKVStoreKey(types.StoreKey)
+	cms := rootmulti.NewStore(db)
+	cms.MountStoreWithDB(key, sdk.StoreTypeIAVL, nil)
+	require.NoError(t, cms.LoadLatestVersion())
+
+	myContract := sdk.AccAddress(bytes.Repeat([]byte{1}, sdk.AddrLen))
+	otherContract := sdk.AccAddress(bytes.Repeat([]byte{2}, sdk.AddrLen))
+	store := cms.GetKVStore(key)
+	prefixed := func(contract sdk.AccAddress, k string) []byte {
+		return append(types.GetContractStorePrefix(contract), k...)
+	}
+	// height 1
+	store.Set(prefixed(myContract, "changed"), []byte("old"))
+	store.Set(prefixed(myContract, "removed"), []byte("gone"))
+	store.Set(prefixed(myContract, "same"), []byte("same"))
+	store.Set(prefixed(otherContract, "other"), []byte("other"))
+	cms.Commit()
+	// height 2
+	store.Set(prefixed(myContract, "added"), []byte("new"))
+	store.Set(prefixed(myContract, "changed"), []byte("new"))
+	store.Delete(prefixed(myContract, "removed"))
+	store.Set(prefixed(otherContract, "other"), []byte("changed"))
+	cms.Commit()
+
+	// when loaded from the database
+	gotCMS, gotKey, err := openWasmStore(db)
+	require.NoError(t, err)
+	from, err := loadContractState(gotCMS, gotKey, myContract, 1)
+	require.NoError(t, err)
+	to, err := loadContractState(gotCMS, gotKey, myContract, 2)
+	require.NoError(t, err)
+
+	// then
+	exp := contractStateDiff{
+		Added:   []types.Model{{Key: []byte("added"), Value: []byte("new")}},
+		Removed: []types.Model{{Key: []byte("removed"), Value: []byte("gone")}},
+		Changed: []contractStateChange{{Key: []byte("changed"), OldValue: []byte("old"), NewValue: []byte("new")}},
+	}
+	assert.Equal(t, exp, diffContractState(from, to))
+
+	// and an unknown height
+	_, err = loadContractState(gotCMS, gotKey, myContract, 3)
+	assert.Error(t, err)
+}
+
+func TestCheckWasmCacheCmd(t *testing.T) {
+	wasmCode, err := ioutil.ReadFile("../../x/wasm/keeper/testdata/hackatom.wasm")
+	require.NoError(t, err)
+	codeHash := sha256.Sum256(wasmCode)
+
+	specs := map[string]struct {
+		corrupt   bool
+		repairDir bool
+		expErr    bool
+		expOut    string
+	}{
+		"all codes ok": {
+			expOut: "ok",
+		},
+		"corrupted code": {
+			corrupt: true,
+			expErr:  true,
+			expOut:  "failed",
+		},
+		"corrupted code repaired": {
+			corrupt:   true,
+			repairDir: true,
+			expOut:    "repaired",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			homeDir := setupNodeHome(t, wasmCode)
+			codeFile := filepath.Join(wasmVMDataDir(homeDir), "wasm", hex.EncodeToString(codeHash[:]))
+			if spec.corrupt {
+				require.NoError(t, ioutil.WriteFile(codeFile, []byte("corrupted"), 0o644))
+			}
+			cmd := CheckWasmCacheCmd()
+			if spec.repairDir {
+				repairDir := t.TempDir()
+				require.NoError(t, ioutil.WriteFile(filepath.Join(repairDir, "hackatom.wasm"), wasmCode, 0o644))
+				cmd.SetArgs([]string{"--" + flagRepairFrom, repairDir})
+			}
+
+			// when
+			out, err := executeDebugCmd(t, homeDir, cmd)
+
+			// then
+			if spec.expErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Contains(t, out, fmt.Sprintf("code 1 %X: %s", codeHash, spec.expOut))
+			if spec.repairDir {
+				bz, err := ioutil.ReadFile(codeFile)
+				require.NoError(t, err)
+				assert.Equal(t, wasmCode, bz)
+			}
+		})
+	}
+}
+
+// setupNodeHome creates a node home directory with the state of a chain that has the wasm code stored with code id 1
+func setupNodeHome(t *testing.T, wasmCode []byte) string {
+	t.Helper()
+	homeDir := t.TempDir()
+	db, err := sdk.NewLevelDB("application", filepath.Join(homeDir, "data"))
+	require.NoError(t, err)
+	defer db.Close()
+	wasmApp := app.NewWasmApp(log.NewNopLogger(), db, nil, true, map[int64]bool{}, homeDir, 0, wasm.EnableAllProposals, app.EmptyBaseAppOptions{}, nil)
+
+	genesisState := app.NewDefaultGenesisState()
+	wasmGenesis := types.GenesisState{
+		Params: types.DefaultParams(),
+		GenMsgs: []types.GenesisState_GenMsgs{{Sum: &types.GenesisState_GenMsgs_StoreCode{StoreCode: &types.MsgStoreCode{
+			Sender:       sdk.AccAddress(bytes.Repeat([]byte{1}, sdk.AddrLen)).String(),
+			WASMByteCode: wasmCode,
+		}}}},
+	}
+	genesisState[types.ModuleName] = wasmApp.AppCodec().MustMarshalJSON(&wasmGenesis)
+	stateBytes, err := json.Marshal(genesisState)
+	require.NoError(t, err)
+	wasmApp.InitChain(abci.RequestInitChain{
+		Validators:      []abci.ValidatorUpdate{},
+		ConsensusParams: app.DefaultConsensusParams,
+		AppStateBytes:   stateBytes,
+	})
+	wasmApp.Commit()
+	return homeDir
+}
+
+// executeDebugCmd runs the command with the node home directory and returns the output
+func executeDebugCmd(t *testing.T, homeDir string, cmd *cobra.Command) (string, error) {
+	t.Helper()
+	cfg := tmcfg.DefaultConfig()
+	cfg.SetRoot(homeDir)
+	serverCtx := server.NewContext(viper.New(), cfg, log.NewNopLogger())
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(ioutil.Discard)
+	err := cmd.ExecuteContext(context.WithValue(context.Background(), server.ServerContextKey, serverCtx))
+	return out.String(), err
+}
+
+func TestImportWasmCacheCmd(t *testing.T) {
+	wasmCode, err := ioutil.ReadFile("../../x/wasm/keeper/testdata/hackatom.wasm")
+	require.NoError(t, err)
+	codeHash := sha256.Sum256(wasmCode)
+	srcHome := setupNodeHome(t, wasmCode)
+	// the VM compiles the verified code again, so only module versions that it does not write can be copied
+	writeFiles(t, wasmVMDataDir(srcHome), map[string]string{"modules/v0/" + hex.EncodeToString(codeHash[:]): "compiled"})
+	emptyHome := t.TempDir()
+	require.NoError(t, os.MkdirAll(wasmVMDataDir(emptyHome), 0o755))
+
+	specs := map[string]struct {
+		srcHome     string
+		corrupt     bool
+		withModules bool
+		expErr      bool
+		expOut      string
+	}{
+		"corrupted code imported": {
+			srcHome: srcHome,
+			corrupt: true,
+			expOut:  "codes: 1 imported: 1 compiled modules copied: 0 failed: 0",
+		},
+		"compiled module copied": {
+			srcHome:     srcHome,
+			withModules: true,
+			expOut:      "codes: 1 imported: 0 compiled modules copied: 1 failed: 0",
+		},
+		"compiled modules not copied by default": {
+			srcHome: srcHome,
+			expOut:  "codes: 1 imported: 0 compiled modules copied: 0 failed: 0",
+		},
+		"code not in source": {
+			srcHome: emptyHome,
+			corrupt: true,
+			expErr:  true,
+			expOut:  "codes: 1 imported: 0 compiled modules copied: 0 failed: 1",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			homeDir := setupNodeHome(t, wasmCode)
+			codeFile := filepath.Join(wasmVMDataDir(homeDir), "wasm", hex.EncodeToString(codeHash[:]))
+			if spec.corrupt {
+				require.NoError(t, ioutil.WriteFile(codeFile, []byte("corrupted"), 0o644))
+			}
+			require.NoError(t, os.RemoveAll(filepath.Join(wasmVMDataDir(homeDir), "modules")))
+			cmd := ImportWasmCacheCmd()
+			args := []string{spec.srcHome}
+			if spec.withModules {
+				args = append(args, "--"+flagWithModules)
+			}
+			cmd.SetArgs(args)
+
+			// when
+			out, err := executeDebugCmd(t, homeDir, cmd)
+
+			// then
+			if spec.expErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Contains(t, out, spec.expOut)
+			if spec.expErr {
+				return
+			}
+			bz, err := ioutil.ReadFile(codeFile)
+			require.NoError(t, err)
+			assert.Equal(t, wasmCode, bz)
+			_, err = os.Stat(filepath.Join(wasmVMDataDir(homeDir), "modules", "v0", hex.EncodeToString(codeHash[:])))
+			assert.Equal(t, spec.withModules, err == nil)
+		})
+	}
+}
+
+func TestCopyCompiledModules(t *testing.T) {
+	codeHash := bytes.Repeat([]byte{1}, 32)
+	moduleName := hex.EncodeToString(codeHash)
+
+	specs := map[string]struct {
+		srcFiles map[string]string
+		dstFiles map[string]string
+		expN     int
+		expFiles map[string]string
+	}{
+		"all versions copied": {
+			srcFiles: map[string]string{"modules/v1/" + moduleName: "one", "modules/v2/" + moduleName: "two"},
+			expN:     2,
+			expFiles: map[string]string{"modules/v1/" + moduleName: "one", "modules/v2/" + moduleName: "two"},
+		},
+		"existing module skipped": {
+			srcFiles: map[string]string{"modules/v1/" + moduleName: "one", "modules/v2/" + moduleName: "two"},
+			dstFiles: map[string]string{"modules/v1/" + moduleName: "existing"},
+			expN:     1,
+			expFiles: map[string]string{"modules/v1/" + moduleName: "existing", "modules/v2/" + moduleName: "two"},
+		},
+		"other codes and files ignored": {
+			srcFiles: map[string]string{"modules/v1/" + hex.EncodeToString(bytes.Repeat([]byte{2}, 32)): "other", "modules/file": "other"},
+			expN:     0,
+			expFiles: map[string]string{},
+		},
+		"missing source modules dir": {
+			expN:     0,
+			expFiles: map[string]string{},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			srcDir, dstDir := t.TempDir(), t.TempDir()
+			writeFiles(t, srcDir, spec.srcFiles)
+			writeFiles(t, dstDir, spec.dstFiles)
+
+			// when
+			n, err := copyCompiledModules(srcDir, dstDir, codeHash)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, spec.expN, n)
+			gotFiles := make(map[string]string)
+			require.NoError(t, filepath.Walk(dstDir, func(path string, info os.FileInfo, err error) error {
+				if err != nil || info.IsDir() {
+					return err
+				}
+				bz, err := ioutil.ReadFile(path)
+				if err != nil {
+					return err
+				}
+				rel, err := filepath.Rel(dstDir, path)
+				gotFiles[filepath.ToSlash(rel)] = string(bz)
+				return err
+			}))
+			assert.Equal(t, spec.expFiles, gotFiles)
+		})
+	}
+}
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), 0o644))
+	}
+}
@@ -22,7 +22,6 @@ import (
 	"github.com/CosmWasm/wasmd/app"
 	"github.com/cosmos/cosmos-sdk/baseapp"
 	"github.com/cosmos/cosmos-sdk/client"
-	"github.com/cosmos/cosmos-sdk/client/debug"
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/client/keys"
 	"github.com/cosmos/cosmos-sdk/client/rpc"
@@ -90,10 +89,13 @@ func initRootCmd(rootCmd *cobra.Command, encodingConfig app.EncodingConfig) {
 		AddGenesisWasmMsgCmd(app.DefaultNodeHome),
 		tmcli.NewCompletionCmd(rootCmd, true),
 		// testnetCmd(app.ModuleBasics, banktypes.GenesisBalancesIterator{}),
-		debug.Cmd(),
+		debugCommand(),
 	)
 
-	server.AddCommands(rootCmd, app.DefaultNodeHome, newApp, createWasmAppAndExport, addModuleInitFlags)
+	var adminGRPC wasmAdminGRPC
+	server.AddCommands(rootCmd, app.DefaultNodeHome, adminGRPC.appCreator(newApp), createWasmAppAndExport, addModuleInitFlags)
+	adminGRPC.wrapStartCmd(rootCmd)
+	addTendermintCommands(rootCmd)
 
 	// add keybase, auxiliary RPC, query, and tx child commands
 	rootCmd.AddCommand(
@@ -104,6 +106,16 @@ func initRootCmd(rootCmd *cobra.Command, encodingConfig app.EncodingConfig) {
 	)
 }
 
+// addTendermintCommands extends the tendermint command of the sdk server with wasm sub commands
+func addTendermintCommands(rootCmd *cobra.Command) {
+	for _, c := range rootCmd.Commands() {
+		if c.Name() == "tendermint" {
+			c.AddCommand(UnsafeResetWasmCacheCmd())
+			return
+		}
+	}
+}
+
 func addModuleInitFlags(startCmd *cobra.Command) {
 	crisis.AddModuleInitFlags(startCmd)
 	wasm.AddModuleInitFlags(startCmd)
@@ -14,6 +14,8 @@ echo "* Download code"
 TMPDIR=$(mktemp -t wasmdXXXXXX)
 wasmd q wasm code "$CODE_ID" "$TMPDIR"
 rm -f "$TMPDIR"
+echo "* Code info"
+wasmd q wasm code-info "$CODE_ID" -o json | jq
 echo "-----------------------"
 echo "## List code"
 wasmd query wasm list-code --node=http://localhost:26657 --chain-id=testing -o json | jq
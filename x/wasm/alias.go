@@ -14,6 +14,7 @@ const (
 	firstCodeID                     = 1
 	DefaultParamspace               = types.DefaultParamspace
 	ModuleName                      = types.ModuleName
+	DepositEscrowName               = types.DepositEscrowName
 	StoreKey                        = types.StoreKey
 	TStoreKey                       = types.TStoreKey
 	QuerierRoute                    = types.QuerierRoute
@@ -77,6 +78,7 @@ var (
 	NewQuerier                = keeper.Querier
 	ContractFromPortID        = keeper.ContractFromPortID
 	WithWasmEngine            = keeper.WithWasmEngine
+	WithICS4Wrapper           = keeper.WithICS4Wrapper
 
 	// variable aliases
 	ModuleCdc            = types.ModuleCdc
@@ -117,6 +119,8 @@ type (
 	MsgClearAdmin                  = types.MsgClearAdmin
 	MsgWasmIBCCall                 = types.MsgIBCSend
 	MsgClearAdminResponse          = types.MsgClearAdminResponse
+	MsgUpdateDeniedDenoms          = types.MsgUpdateDeniedDenoms
+	MsgUpdateDeniedDenomsResponse  = types.MsgUpdateDeniedDenomsResponse
 	MsgServer                      = types.MsgServer
 	Model                          = types.Model
 	CodeInfo                       = types.CodeInfo
@@ -0,0 +1,336 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strconv"
+
+	wasmUtils "github.com/CosmWasm/wasmd/x/wasm/client/utils"
+	"github.com/CosmWasm/wasmd/x/wasm/keeper"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/spf13/cobra"
+)
+
+const flagPlan = "plan"
+
+var (
+	// deployRefPattern is the format of the names that the steps of a deploy plan are referenced by
+	deployRefPattern = regexp.MustCompile(`^[a-zA-Z0-9_\-]+$`)
+	// deployPlaceholderPattern matches the `${ref}` placeholders in a deploy plan
+	deployPlaceholderPattern = regexp.MustCompile(`\$\{([a-zA-Z0-9_\-]+)\}`)
+)
+
+// DeployCmd builds a single transaction from a deploy plan so that all contracts of a protocol are stored,
+// instantiated and set up atomically.
+func DeployCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "deploy --plan [plan_json_file]",
+		Short: "Store, instantiate and set up contracts from a deploy plan in a single transaction",
+		Long: `Store, instantiate and set up contracts from a deploy plan in a single transaction.
+The plan contains the steps that are executed in order:
+{"steps": [
+  {"store": {"ref": "token_code", "wasm_file": "cw20.wasm"}},
+  {"instantiate": {"ref": "token", "code": "${token_code}", "label": "token", "msg": {"minter": "${token}"}}},
+  {"execute": {"contract": "${token}", "msg": {"mint": {}}, "amount": "100stake"}}
+]}
+The "${ref}" placeholders are replaced with the code id of a store step or the address of an instantiate step.
+A JSON string that consists of a code id placeholder only becomes a number. The code ids and addresses are
+predicted from the current chain state with the default contract address format. Wasm files are relative to the plan.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			planFile, err := cmd.Flags().GetString(flagPlan)
+			if err != nil {
+				return err
+			}
+			bz, err := ioutil.ReadFile(planFile)
+			if err != nil {
+				return err
+			}
+			var plan deployPlan
+			if err := json.Unmarshal(bz, &plan); err != nil {
+				return sdkerrors.Wrap(err, "plan")
+			}
+			nextCodeID, err := queryNextSequence(clientCtx, types.KeyLastCodeID)
+			if err != nil {
+				return sdkerrors.Wrap(err, "code sequence")
+			}
+			nextInstanceID, err := queryNextSequence(clientCtx, types.KeyLastInstanceID)
+			if err != nil {
+				return sdkerrors.Wrap(err, "instance sequence")
+			}
+			planDir := filepath.Dir(planFile)
+			readFile := func(name string) ([]byte, error) {
+				if !filepath.IsAbs(name) {
+					name = filepath.Join(planDir, name)
+				}
+				return ioutil.ReadFile(name)
+			}
+			msgs, refs, err := plan.buildMsgs(clientCtx.GetFromAddress(), nextCodeID, nextInstanceID, readFile)
+			if err != nil {
+				return err
+			}
+			for _, r := range refs {
+				fmt.Fprintf(cmd.ErrOrStderr(), "%s: %s\n", r.name, r.value)
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msgs...)
+		},
+	}
+	cmd.Flags().String(flagPlan, "", "Path to the deploy plan JSON file")
+	_ = cmd.MarkFlagRequired(flagPlan)
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// queryNextSequence returns the id that the next stored code or instantiated contract gets
+func queryNextSequence(clientCtx client.Context, key []byte) (uint64, error) {
+	bz, _, err := clientCtx.QueryStore(key, types.StoreKey)
+	if err != nil {
+		return 0, err
+	}
+	if len(bz) == 0 {
+		return 1, nil
+	}
+	if len(bz) != 8 {
+		return 0, sdkerrors.Wrapf(types.ErrInvalid, "sequence length: %d", len(bz))
+	}
+	return binary.BigEndian.Uint64(bz), nil
+}
+
+// deployPlan are the steps of a deployment that are executed in order within a single transaction
+type deployPlan struct {
+	Steps []deployStep `json:"steps"`
+}
+
+// deployStep contains exactly one operation
+type deployStep struct {
+	Store       *deployStoreStep       `json:"store,omitempty"`
+	Instantiate *deployInstantiateStep `json:"instantiate,omitempty"`
+	Execute     *deployExecuteStep     `json:"execute,omitempty"`
+}
+
+type deployStoreStep struct {
+	// Ref is the name that the code id is referenced by in later steps
+	Ref      string `json:"ref"`
+	WasmFile string `json:"wasm_file"`
+	Source   string `json:"source,omitempty"`
+	Builder  string `json:"builder,omitempty"`
+}
+
+type deployInstantiateStep struct {
+	// Ref is the name that the contract address is referenced by in later steps
+	Ref string `json:"ref"`
+	// Code is the code id or a placeholder for the code of a store step
+	Code   string          `json:"code"`
+	Label  string          `json:"label"`
+	Admin  string          `json:"admin,omitempty"`
+	Msg    json.RawMessage `json:"msg"`
+	Amount string          `json:"amount,omitempty"`
+}
+
+type deployExecuteStep struct {
+	// Contract is the contract address or a placeholder for the contract of an instantiate step
+	Contract string          `json:"contract"`
+	Msg      json.RawMessage `json:"msg"`
+	Amount   string          `json:"amount,omitempty"`
+}
+
+// deployRef is a resolved placeholder value
+type deployRef struct {
+	name  string
+	value string
+	// isCodeID is true for the refs of store steps
+	isCodeID bool
+}
+
+type deployRefs []deployRef
+
+func (r deployRefs) get(name string) (deployRef, bool) {
+	for _, v := range r {
+		if v.name == name {
+			return v, true
+		}
+	}
+	return deployRef{}, false
+}
+
+// buildMsgs returns the messages of the plan and the resolved refs in plan order. The code ids and instance ids
+// are assigned from the given start values in the same order as the chain does.
+func (p deployPlan) buildMsgs(sender sdk.AccAddress, nextCodeID, nextInstanceID uint64, readFile func(string) ([]byte, error)) ([]sdk.Msg, deployRefs, error) {
+	if len(p.Steps) == 0 {
+		return nil, nil, sdkerrors.Wrap(types.ErrEmpty, "steps")
+	}
+	var refs deployRefs
+	addRef := func(ref deployRef) error {
+		if !deployRefPattern.MatchString(ref.name) {
+			return sdkerrors.Wrapf(types.ErrInvalid, "ref %q", ref.name)
+		}
+		if _, exists := refs.get(ref.name); exists {
+			return sdkerrors.Wrapf(types.ErrDuplicate, "ref %q", ref.name)
+		}
+		refs = append(refs, ref)
+		return nil
+	}
+	msgs := make([]sdk.Msg, 0, len(p.Steps))
+	for i, s := range p.Steps {
+		var msg sdk.Msg
+		switch {
+		case s.Store != nil && s.Instantiate == nil && s.Execute == nil:
+			wasm, err := readFile(s.Store.WasmFile)
+			if err != nil {
+				return nil, nil, sdkerrors.Wrapf(err, "step %d", i)
+			}
+			if wasmUtils.IsWasm(wasm) {
+				if wasm, err = wasmUtils.GzipIt(wasm); err != nil {
+					return nil, nil, sdkerrors.Wrapf(err, "step %d", i)
+				}
+			} else if !wasmUtils.IsGzip(wasm) {
+				return nil, nil, sdkerrors.Wrapf(types.ErrInvalid, "step %d: use wasm binary or gzip", i)
+			}
+			if err := addRef(deployRef{name: s.Store.Ref, value: strconv.FormatUint(nextCodeID, 10), isCodeID: true}); err != nil {
+				return nil, nil, sdkerrors.Wrapf(err, "step %d", i)
+			}
+			nextCodeID++
+			msg = &types.MsgStoreCode{
+				Sender:       sender.String(),
+				WASMByteCode: wasm,
+				Source:       s.Store.Source,
+				Builder:      s.Store.Builder,
+			}
+		case s.Instantiate != nil && s.Store == nil && s.Execute == nil:
+			rawCodeID, err := resolveDeployPlaceholders(s.Instantiate.Code, refs)
+			if err != nil {
+				return nil, nil, sdkerrors.Wrapf(err, "step %d", i)
+			}
+			codeID, err := strconv.ParseUint(rawCodeID, 10, 64)
+			if err != nil {
+				return nil, nil, sdkerrors.Wrapf(types.ErrInvalid, "step %d: code %q", i, s.Instantiate.Code)
+			}
+			contractAddr := keeper.BuildContractAddress(codeID, nextInstanceID)
+			nextInstanceID++
+			// the contract can reference itself in the init message, for example as minter
+			if err := addRef(deployRef{name: s.Instantiate.Ref, value: contractAddr.String()}); err != nil {
+				return nil, nil, sdkerrors.Wrapf(err, "step %d", i)
+			}
+			admin, err := resolveDeployPlaceholders(s.Instantiate.Admin, refs)
+			if err != nil {
+				return nil, nil, sdkerrors.Wrapf(err, "step %d", i)
+			}
+			initMsg, err := resolveDeployMsg(s.Instantiate.Msg, refs)
+			if err != nil {
+				return nil, nil, sdkerrors.Wrapf(err, "step %d", i)
+			}
+			amount, err := sdk.ParseCoinsNormalized(s.Instantiate.Amount)
+			if err != nil {
+				return nil, nil, sdkerrors.Wrapf(err, "step %d: amount", i)
+			}
+			msg = &types.MsgInstantiateContract{
+				Sender:  sender.String(),
+				Admin:   admin,
+				CodeID:  codeID,
+				Label:   s.Instantiate.Label,
+				InitMsg: initMsg,
+				Funds:   amount,
+			}
+		case s.Execute != nil && s.Store == nil && s.Instantiate == nil:
+			contract, err := resolveDeployPlaceholders(s.Execute.Contract, refs)
+			if err != nil {
+				return nil, nil, sdkerrors.Wrapf(err, "step %d", i)
+			}
+			execMsg, err := resolveDeployMsg(s.Execute.Msg, refs)
+			if err != nil {
+				return nil, nil, sdkerrors.Wrapf(err, "step %d", i)
+			}
+			amount, err := sdk.ParseCoinsNormalized(s.Execute.Amount)
+			if err != nil {
+				return nil, nil, sdkerrors.Wrapf(err, "step %d: amount", i)
+			}
+			msg = &types.MsgExecuteContract{
+				Sender:   sender.String(),
+				Contract: contract,
+				Msg:      execMsg,
+				Funds:    amount,
+			}
+		default:
+			return nil, nil, sdkerrors.Wrapf(types.ErrInvalid, "step %d: exactly one of store, instantiate or execute required", i)
+		}
+		if err := msg.ValidateBasic(); err != nil {
+			return nil, nil, sdkerrors.Wrapf(err, "step %d", i)
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, refs, nil
+}
+
+// resolveDeployPlaceholders replaces all `${ref}` placeholders with the ref values
+func resolveDeployPlaceholders(s string, refs deployRefs) (string, error) {
+	var err error
+	r := deployPlaceholderPattern.ReplaceAllStringFunc(s, func(m string) string {
+		ref, ok := refs.get(deployPlaceholderPattern.FindStringSubmatch(m)[1])
+		if !ok {
+			err = sdkerrors.Wrapf(types.ErrNotFound, "ref %q", m)
+			return m
+		}
+		return ref.value
+	})
+	return r, err
+}
+
+// resolveDeployMsg replaces the placeholders in all JSON strings of the message. A string that is a code id
+// placeholder only is replaced with the code id as number.
+func resolveDeployMsg(msg json.RawMessage, refs deployRefs) ([]byte, error) {
+	if len(msg) == 0 {
+		return nil, sdkerrors.Wrap(types.ErrEmpty, "msg")
+	}
+	dec := json.NewDecoder(bytes.NewReader(msg))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, sdkerrors.Wrap(types.ErrInvalid, "msg")
+	}
+	var resolve func(interface{}) (interface{}, error)
+	resolve = func(v interface{}) (interface{}, error) {
+		switch x := v.(type) {
+		case string:
+			if m := deployPlaceholderPattern.FindStringSubmatch(x); m != nil && m[0] == x {
+				if ref, ok := refs.get(m[1]); ok && ref.isCodeID {
+					return json.Number(ref.value), nil
+				}
+			}
+			return resolveDeployPlaceholders(x, refs)
+		case []interface{}:
+			for i := range x {
+				var err error
+				if x[i], err = resolve(x[i]); err != nil {
+					return nil, err
+				}
+			}
+		case map[string]interface{}:
+			for k := range x {
+				var err error
+				if x[k], err = resolve(x[k]); err != nil {
+					return nil, err
+				}
+			}
+		}
+		return v, nil
+	}
+	v, err := resolve(v)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
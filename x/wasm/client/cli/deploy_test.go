@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeployPlanBuildMsgs(t *testing.T) {
+	sender := sdk.AccAddress(make([]byte, sdk.AddrLen))
+	wasmCode := []byte("\x00asm\x01\x00\x00\x00")
+	readFile := func(name string) ([]byte, error) {
+		if name != "my.wasm" {
+			return nil, errors.New("not found")
+		}
+		return wasmCode, nil
+	}
+	tokenAddr := keeper.BuildContractAddress(7, 3).String()
+	otherAddr := keeper.BuildContractAddress(1, 4).String()
+
+	specs := map[string]struct {
+		src     string
+		expRefs deployRefs
+		expMsgs func(t *testing.T, msgs []sdk.Msg)
+		expErr  *sdkerrors.Error
+	}{
+		"store, instantiate and execute": {
+			src: `{"steps": [
+				{"store": {"ref": "token_code", "wasm_file": "my.wasm", "source": "https://example.com"}},
+				{"instantiate": {"ref": "token", "code": "${token_code}", "label": "token", "admin": "${token}", "msg": {"minter": "${token}", "code_id": "${token_code}"}, "amount": "1stake"}},
+				{"instantiate": {"ref": "other", "code": "1", "label": "other", "msg": {"token": "${token}", "codes": ["${token_code}", 1]}}},
+				{"execute": {"contract": "${token}", "msg": {"mint": {"recipient": "${other}", "memo": "code ${token_code}"}}, "amount": "2stake"}}
+			]}`,
+			expRefs: deployRefs{
+				{name: "token_code", value: "7", isCodeID: true},
+				{name: "token", value: tokenAddr},
+				{name: "other", value: otherAddr},
+			},
+			expMsgs: func(t *testing.T, msgs []sdk.Msg) {
+				require.Len(t, msgs, 4)
+				store, ok := msgs[0].(*types.MsgStoreCode)
+				require.True(t, ok)
+				assert.Equal(t, sender.String(), store.Sender)
+				assert.Equal(t, "https://example.com", store.Source)
+				assert.NotEqual(t, wasmCode, store.WASMByteCode, "gzipped")
+
+				inst, ok := msgs[1].(*types.MsgInstantiateContract)
+				require.True(t, ok)
+				assert.Equal(t, uint64(7), inst.CodeID)
+				assert.Equal(t, tokenAddr, inst.Admin)
+				assert.JSONEq(t, `{"minter":"`+tokenAddr+`","code_id":7}`, string(inst.InitMsg))
+				assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 1)), inst.Funds)
+
+				other, ok := msgs[2].(*types.MsgInstantiateContract)
+				require.True(t, ok)
+				assert.Equal(t, uint64(1), other.CodeID)
+				assert.JSONEq(t, `{"token":"`+tokenAddr+`","codes":[7,1]}`, string(other.InitMsg))
+
+				exec, ok := msgs[3].(*types.MsgExecuteContract)
+				require.True(t, ok)
+				assert.Equal(t, tokenAddr, exec.Contract)
+				assert.JSONEq(t, `{"mint":{"recipient":"`+otherAddr+`","memo":"code 7"}}`, string(exec.Msg))
+				assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 2)), exec.Funds)
+			},
+		},
+		"unknown ref": {
+			src:    `{"steps": [{"execute": {"contract": "${token}", "msg": {}}}]}`,
+			expErr: types.ErrNotFound,
+		},
+		"ref of later step": {
+			src: `{"steps": [
+				{"execute": {"contract": "${token}", "msg": {}}},
+				{"instantiate": {"ref": "token", "code": "1", "label": "token", "msg": {}}}
+			]}`,
+			expErr: types.ErrNotFound,
+		},
+		"duplicate ref": {
+			src: `{"steps": [
+				{"store": {"ref": "code", "wasm_file": "my.wasm"}},
+				{"instantiate": {"ref": "code", "code": "1", "label": "token", "msg": {}}}
+			]}`,
+			expErr: types.ErrDuplicate,
+		},
+		"invalid ref": {
+			src:    `{"steps": [{"store": {"ref": "my code", "wasm_file": "my.wasm"}}]}`,
+			expErr: types.ErrInvalid,
+		},
+		"multiple operations in step": {
+			src:    `{"steps": [{"store": {"ref": "code", "wasm_file": "my.wasm"}, "execute": {"contract": "x", "msg": {}}}]}`,
+			expErr: types.ErrInvalid,
+		},
+		"invalid code": {
+			src:    `{"steps": [{"instantiate": {"ref": "token", "code": "one", "label": "token", "msg": {}}}]}`,
+			expErr: types.ErrInvalid,
+		},
+		"empty msg": {
+			src:    `{"steps": [{"instantiate": {"ref": "token", "code": "1", "label": "token"}}]}`,
+			expErr: types.ErrEmpty,
+		},
+		"empty plan": {
+			src:    `{"steps": []}`,
+			expErr: types.ErrEmpty,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			var plan deployPlan
+			require.NoError(t, json.Unmarshal([]byte(spec.src), &plan))
+
+			// when
+			gotMsgs, gotRefs, err := plan.buildMsgs(sender, 7, 3, readFile)
+
+			// then
+			if spec.expErr != nil {
+				assert.True(t, spec.expErr.Is(err), "got %+v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, spec.expRefs, gotRefs)
+			spec.expMsgs(t, gotMsgs)
+		})
+	}
+}
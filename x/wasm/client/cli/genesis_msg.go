@@ -85,7 +85,7 @@ func GenesisStoreCodeCmd(defaultNodeHome string, genesisMutator GenesisMutator)
 // that is executed on block 0.
 func GenesisInstantiateContractCmd(defaultNodeHome string, genesisMutator GenesisMutator) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "instantiate-contract [code_id_int64] [json_encoded_init_args] --label [text] --run-as [address] --admin [address,optional] --amount [coins,optional]",
+		Use:   "instantiate-contract [code_id_int64] [json_encoded_init_args] --label [text] --run-as [address] --admin [address,me] | --no-admin --amount [coins,optional]",
 		Short: "Instantiate a wasm contract",
 		Args:  cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -94,7 +94,7 @@ func GenesisInstantiateContractCmd(defaultNodeHome string, genesisMutator Genesi
 				return err
 			}
 
-			msg, err := parseInstantiateArgs(args[0], args[1], senderAddr, cmd.Flags())
+			msg, err := parseInstantiateArgs(args[0], args[1], senderAddr, cmd.Flags(), nil)
 			if err != nil {
 				return err
 			}
@@ -139,8 +139,10 @@ func GenesisInstantiateContractCmd(defaultNodeHome string, genesisMutator Genesi
 	}
 	cmd.Flags().String(flagAmount, "", "Coins to send to the contract during instantiation")
 	cmd.Flags().String(flagLabel, "", "A human-readable name for this contract in lists")
-	cmd.Flags().String(flagAdmin, "", "Address of an admin")
+	cmd.Flags().String(flagAdmin, "", `Address of an admin or "me" for the run-as address`)
+	cmd.Flags().Bool(flagNoAdmin, false, "Instantiate without an admin. The contract can never be migrated")
 	cmd.Flags().String(flagRunAs, "", "The address that pays the init funds. It is the creator of the contract.")
+	cmd.Flags().Bool(flagHexMsg, false, "Decode the init args from hex and send them as binary payload")
 
 	cmd.Flags().String(flags.FlagHome, defaultNodeHome, "The application home directory")
 	cmd.Flags().String(flags.FlagKeyringBackend, flags.DefaultKeyringBackend, "Select keyring's backend (os|file|kwallet|pass|test)")
@@ -191,6 +193,7 @@ func GenesisExecuteContractCmd(defaultNodeHome string, genesisMutator GenesisMut
 	}
 	cmd.Flags().String(flagAmount, "", "Coins to send to the contract along with command")
 	cmd.Flags().String(flagRunAs, "", "The address that pays the funds.")
+	cmd.Flags().Bool(flagHexMsg, false, "Decode the send args from hex and send them as binary payload")
 
 	cmd.Flags().String(flags.FlagHome, defaultNodeHome, "The application home directory")
 	cmd.Flags().String(flags.FlagKeyringBackend, flags.DefaultKeyringBackend, "Select keyring's backend (os|file|kwallet|pass|test)")
@@ -152,6 +152,7 @@ func TestInstantiateContractCmd(t *testing.T) {
 				cmd.SetArgs([]string{"1", `{}`})
 				flagSet := cmd.Flags()
 				flagSet.Set("label", "testing")
+				flagSet.Set("no-admin", "true")
 				flagSet.Set("run-as", myWellFundedAccount)
 			},
 			expMsgCount: 1,
@@ -167,6 +168,7 @@ func TestInstantiateContractCmd(t *testing.T) {
 				cmd.SetArgs([]string{"1", `{}`})
 				flagSet := cmd.Flags()
 				flagSet.Set("label", "testing")
+				flagSet.Set("no-admin", "true")
 				flagSet.Set("run-as", myWellFundedAccount)
 			},
 			expMsgCount: 2,
@@ -185,6 +187,7 @@ func TestInstantiateContractCmd(t *testing.T) {
 				cmd.SetArgs([]string{"100", `{}`})
 				flagSet := cmd.Flags()
 				flagSet.Set("label", "testing")
+				flagSet.Set("no-admin", "true")
 				flagSet.Set("run-as", myWellFundedAccount)
 			},
 			expMsgCount: 2,
@@ -195,6 +198,7 @@ func TestInstantiateContractCmd(t *testing.T) {
 				cmd.SetArgs([]string{"2", `{}`})
 				flagSet := cmd.Flags()
 				flagSet.Set("label", "testing")
+				flagSet.Set("no-admin", "true")
 				flagSet.Set("run-as", myWellFundedAccount)
 			},
 			expError: true,
@@ -212,6 +216,7 @@ func TestInstantiateContractCmd(t *testing.T) {
 				cmd.SetArgs([]string{"1", `{}`})
 				flagSet := cmd.Flags()
 				flagSet.Set("label", "testing")
+				flagSet.Set("no-admin", "true")
 				flagSet.Set("run-as", myWellFundedAccount)
 			},
 			expError: true,
@@ -237,6 +242,7 @@ func TestInstantiateContractCmd(t *testing.T) {
 				cmd.SetArgs([]string{"1", `{}`})
 				flagSet := cmd.Flags()
 				flagSet.Set("label", "testing")
+				flagSet.Set("no-admin", "true")
 				flagSet.Set("run-as", keeper.RandomBech32AccountAddress(t))
 			},
 			expMsgCount: 1,
@@ -262,6 +268,7 @@ func TestInstantiateContractCmd(t *testing.T) {
 				cmd.SetArgs([]string{"1", `{}`})
 				flagSet := cmd.Flags()
 				flagSet.Set("label", "testing")
+				flagSet.Set("no-admin", "true")
 				flagSet.Set("run-as", myWellFundedAccount)
 				flagSet.Set("amount", "100stake")
 			},
@@ -288,6 +295,7 @@ func TestInstantiateContractCmd(t *testing.T) {
 				cmd.SetArgs([]string{"1", `{}`})
 				flagSet := cmd.Flags()
 				flagSet.Set("label", "testing")
+				flagSet.Set("no-admin", "true")
 				flagSet.Set("run-as", keeper.RandomBech32AccountAddress(t))
 				flagSet.Set("amount", "10stake")
 			},
@@ -1,7 +1,11 @@
 package cli
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"strings"
 
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 	"github.com/cosmos/cosmos-sdk/client"
@@ -17,6 +21,7 @@ func ProposalStoreCodeCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "wasm-store [wasm file] --source [source] --builder [builder] --title [text] --description [text] --run-as [address]",
 		Short: "Submit a wasm binary proposal",
+		Long:  "Submit a wasm binary proposal. The wasm file is gzipped unless it is compressed already.",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, err := client.GetClientTxContext(cmd)
@@ -32,9 +37,6 @@ func ProposalStoreCodeCmd() *cobra.Command {
 			if err != nil {
 				return fmt.Errorf("run-as: %s", err)
 			}
-			if len(runAs) == 0 {
-				return errors.New("run-as address is required")
-			}
 			proposalTitle, err := cmd.Flags().GetString(cli.FlagTitle)
 			if err != nil {
 				return fmt.Errorf("proposal title: %s", err)
@@ -76,7 +78,7 @@ func ProposalStoreCodeCmd() *cobra.Command {
 
 	cmd.Flags().String(flagSource, "", "A valid URI reference to the contract's source code, optional")
 	cmd.Flags().String(flagBuilder, "", "A valid docker tag for the build system, optional")
-	cmd.Flags().String(flagRunAs, "", "The address that is stored as code creator")
+	cmd.Flags().String(flagRunAs, "", "The address that is stored as code creator. Defaults to the gov module account")
 	cmd.Flags().String(flagInstantiateByEverybody, "", "Everybody can instantiate a contract from the code, optional")
 	cmd.Flags().String(flagInstantiateByAddress, "", "Only this address can instantiate a contract instance from the code, optional")
 
@@ -92,16 +94,22 @@ func ProposalStoreCodeCmd() *cobra.Command {
 
 func ProposalInstantiateContractCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "instantiate-contract [code_id_int64] [json_encoded_init_args] --label [text] --title [text] --description [text] --run-as [address] --admin [address,optional] --amount [coins,optional]",
-		Short: "Submit an instantiate wasm contract proposal",
-		Args:  cobra.ExactArgs(2),
+		Use:     "instantiate-contract [code_id_int64] [json_encoded_init_args] --label [text] --title [text] --description [text] --run-as [address] --admin [address,me] | --no-admin --amount [coins,optional] --funds-from-community-pool [bool,optional]",
+		Short:   "Submit an instantiate wasm contract proposal",
+		Long:    "Submit an instantiate wasm contract proposal. The init args can be read from a JSON file with @[file].",
+		Aliases: []string{"instantiate"},
+		Args:    cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, err := client.GetClientTxContext(cmd)
 			if err != nil {
 				return err
 			}
 
-			src, err := parseInstantiateArgs(args[0], args[1], clientCtx.FromAddress, cmd.Flags())
+			initMsg, err := readJSONArg(args[1])
+			if err != nil {
+				return fmt.Errorf("init args: %s", err)
+			}
+			src, err := parseInstantiateArgs(args[0], initMsg, clientCtx.FromAddress, cmd.Flags(), noAdminConfirmation(clientCtx))
 			if err != nil {
 				return err
 			}
@@ -110,9 +118,6 @@ func ProposalInstantiateContractCmd() *cobra.Command {
 			if err != nil {
 				return fmt.Errorf("run-as: %s", err)
 			}
-			if len(runAs) == 0 {
-				return errors.New("run-as address is required")
-			}
 			proposalTitle, err := cmd.Flags().GetString(cli.FlagTitle)
 			if err != nil {
 				return fmt.Errorf("proposal title: %s", err)
@@ -129,6 +134,10 @@ func ProposalInstantiateContractCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			fromCommunityPool, err := cmd.Flags().GetBool(flagFundsFromCommunityPool)
+			if err != nil {
+				return fmt.Errorf("funds from community pool: %s", err)
+			}
 
 			content := types.InstantiateContractProposal{
 				Title:       proposalTitle,
@@ -139,6 +148,8 @@ func ProposalInstantiateContractCmd() *cobra.Command {
 				Label:       src.Label,
 				InitMsg:     src.InitMsg,
 				Funds:       src.Funds,
+
+				FundsFromCommunityPool: fromCommunityPool,
 			}
 
 			msg, err := govtypes.NewMsgSubmitProposal(&content, deposit, clientCtx.GetFromAddress())
@@ -154,8 +165,10 @@ func ProposalInstantiateContractCmd() *cobra.Command {
 	}
 	cmd.Flags().String(flagAmount, "", "Coins to send to the contract during instantiation")
 	cmd.Flags().String(flagLabel, "", "A human-readable name for this contract in lists")
-	cmd.Flags().String(flagAdmin, "", "Address of an admin")
-	cmd.Flags().String(flagRunAs, "", "The address that pays the init funds. It is the creator of the contract and passed to the contract as sender on proposal execution")
+	cmd.Flags().String(flagAdmin, "", `Address of an admin or "me" for the proposer`)
+	cmd.Flags().Bool(flagNoAdmin, false, "Instantiate without an admin. The contract can never be migrated")
+	cmd.Flags().String(flagRunAs, "", "The address that pays the init funds. It is the creator of the contract and passed to the contract as sender on proposal execution. Defaults to the gov module account and is required for init funds")
+	cmd.Flags().Bool(flagFundsFromCommunityPool, false, "Draw the init funds from the community pool on proposal execution, optional")
 
 	// proposal flags
 	cmd.Flags().String(cli.FlagTitle, "", "Title of proposal")
@@ -169,16 +182,22 @@ func ProposalInstantiateContractCmd() *cobra.Command {
 
 func ProposalMigrateContractCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "migrate-contract [contract_addr_bech32] [new_code_id_int64] [json_encoded_migration_args]",
-		Short: "Submit a migrate wasm contract to a new code version proposal",
-		Args:  cobra.ExactArgs(3),
+		Use:     "migrate-contract [contract_addr_bech32] [new_code_id_int64] [json_encoded_migration_args]",
+		Short:   "Submit a migrate wasm contract to a new code version proposal",
+		Long:    "Submit a migrate wasm contract to a new code version proposal. The migration args can be read from a JSON file with @[file].",
+		Aliases: []string{"migrate"},
+		Args:    cobra.ExactArgs(3),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, err := client.GetClientTxContext(cmd)
 			if err != nil {
 				return err
 			}
 
-			src, err := parseMigrateContractArgs(args, clientCtx)
+			migrateMsg, err := readJSONArg(args[2])
+			if err != nil {
+				return fmt.Errorf("migration args: %s", err)
+			}
+			src, err := parseMigrateContractArgs([]string{args[0], args[1], migrateMsg}, clientCtx)
 			if err != nil {
 				return err
 			}
@@ -241,9 +260,10 @@ func ProposalMigrateContractCmd() *cobra.Command {
 
 func ProposalUpdateContractAdminCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "set-contract-admin [contract_addr_bech32] [new_admin_addr_bech32]",
-		Short: "Submit a new admin for a contract proposal",
-		Args:  cobra.ExactArgs(2),
+		Use:     "set-contract-admin [contract_addr_bech32] [new_admin_addr_bech32]",
+		Short:   "Submit a new admin for a contract proposal",
+		Aliases: []string{"update-admin"},
+		Args:    cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, err := client.GetClientTxContext(cmd)
 			if err != nil {
@@ -302,9 +322,10 @@ func ProposalUpdateContractAdminCmd() *cobra.Command {
 
 func ProposalClearContractAdminCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "clear-contract-admin [contract_addr_bech32]",
-		Short: "Submit a clear admin for a contract to prevent further migrations proposal",
-		Args:  cobra.ExactArgs(1),
+		Use:     "clear-contract-admin [contract_addr_bech32]",
+		Short:   "Submit a clear admin for a contract to prevent further migrations proposal",
+		Aliases: []string{"clear-admin"},
+		Args:    cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, err := client.GetClientTxContext(cmd)
 			if err != nil {
@@ -354,3 +375,21 @@ func ProposalClearContractAdminCmd() *cobra.Command {
 	cmd.Flags().String(flagProposalType, "", "Permission of proposal, types: store-code/instantiate/migrate/update-admin/clear-admin/text/parameter_change/software_upgrade")
 	return cmd
 }
+
+// readJSONArg returns the JSON message of a proposal argument. An argument that starts with `@` is the path of a file
+// with the message so that large messages don't need to be escaped on the command line.
+func readJSONArg(arg string) (string, error) {
+	if !strings.HasPrefix(arg, "@") {
+		return arg, nil
+	}
+	file := strings.TrimPrefix(arg, "@")
+	bz, err := ioutil.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+	bz = bytes.TrimSpace(bz)
+	if !json.Valid(bz) {
+		return "", fmt.Errorf("invalid json in file: %s", file)
+	}
+	return string(bz), nil
+}
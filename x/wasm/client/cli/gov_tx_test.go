@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadJSONArg(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wasm-proposal")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	validFile := filepath.Join(dir, "valid.json")
+	require.NoError(t, ioutil.WriteFile(validFile, []byte("{\"foo\":\"bar\"}\n"), 0600))
+	invalidFile := filepath.Join(dir, "invalid.json")
+	require.NoError(t, ioutil.WriteFile(invalidFile, []byte("not json"), 0600))
+
+	specs := map[string]struct {
+		src    string
+		exp    string
+		expErr bool
+	}{
+		"inline json": {
+			src: `{"foo":"bar"}`,
+			exp: `{"foo":"bar"}`,
+		},
+		"json file": {
+			src: "@" + validFile,
+			exp: `{"foo":"bar"}`,
+		},
+		"invalid json file": {
+			src:    "@" + invalidFile,
+			expErr: true,
+		},
+		"unknown file": {
+			src:    "@" + filepath.Join(dir, "unknown.json"),
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := readJSONArg(spec.src)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"strconv"
+	"strings"
 
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 	"github.com/cosmos/cosmos-sdk/client"
@@ -112,3 +113,70 @@ func ClearContractAdminCmd() *cobra.Command {
 	flags.AddTxFlagsToCmd(cmd)
 	return cmd
 }
+
+// UpdateDeniedDenomsCmd sets the denoms that a contract refuses to receive
+func UpdateDeniedDenomsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "set-denied-denoms [contract_addr_bech32] [comma_separated_denoms]",
+		Short:   "Set the denoms that a contract refuses to receive. An empty list accepts all denoms",
+		Aliases: []string{"deny-denoms"},
+		Args:    cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg := parseUpdateDeniedDenomsArgs(args, clientCtx)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+func parseUpdateDeniedDenomsArgs(args []string, cliCtx client.Context) types.MsgUpdateDeniedDenoms {
+	var denoms []string
+	if len(args) > 1 && args[1] != "" {
+		denoms = strings.Split(args[1], ",")
+	}
+	return types.MsgUpdateDeniedDenoms{
+		Sender:   cliCtx.GetFromAddress().String(),
+		Contract: args[0],
+		Denoms:   denoms,
+	}
+}
+
+// UpdateReentrancyGuardCmd enables or disables the reentrancy guard of a contract
+func UpdateReentrancyGuardCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-reentrancy-guard [contract_addr_bech32] [true|false]",
+		Short: "Enable or disable the rejection of nested calls into a contract while it is on the call stack",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			enabled, err := strconv.ParseBool(args[1])
+			if err != nil {
+				return sdkerrors.Wrap(err, "enabled")
+			}
+			msg := types.MsgUpdateReentrancyGuard{
+				Sender:   clientCtx.GetFromAddress().String(),
+				Contract: args[0],
+				Enabled:  enabled,
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
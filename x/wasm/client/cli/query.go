@@ -13,9 +13,15 @@ import (
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmcrypto "github.com/tendermint/tendermint/proto/tendermint/crypto"
+	tmtypes "github.com/tendermint/tendermint/types"
 )
 
 func GetQueryCmd() *cobra.Command {
@@ -30,9 +36,23 @@ func GetQueryCmd() *cobra.Command {
 		GetCmdListCode(),
 		GetCmdListContractByCode(),
 		GetCmdQueryCode(),
+		GetCmdQueryCodeInfo(),
 		GetCmdGetContractInfo(),
 		GetCmdGetContractHistory(),
 		GetCmdGetContractState(),
+		GetCmdGetContractStoreAuditLog(),
+		GetCmdWasmCacheStatus(),
+		GetCmdContractByPortID(),
+		GetCmdCodeExecutionStats(),
+		GetCmdContractDeniedDenoms(),
+		GetCmdContractBalance(),
+		GetCmdContractGasHints(),
+		GetCmdInterchainQueries(),
+		GetCmdJobs(),
+		GetCmdGasCosts(),
+		GetCmdContractTxs(),
+		GetCmdSimulateProposal(),
+		GetCmdModuleVersion(),
 	)
 	return queryCmd
 }
@@ -156,6 +176,41 @@ func GetCmdQueryCode() *cobra.Command {
 	return cmd
 }
 
+// GetCmdQueryCodeInfo prints the metadata of a code without downloading the byte code
+func GetCmdQueryCodeInfo() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "code-info [code_id]",
+		Short: "Prints out metadata of a code id",
+		Long:  "Prints out metadata of a code id without the wasm bytecode",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			codeID, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.CodeInfo(
+				context.Background(),
+				&types.QueryCodeInfoRequest{
+					CodeId: codeID,
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
 // GetCmdGetContractInfo gets details about a given contract
 func GetCmdGetContractInfo() *cobra.Command {
 	cmd := &cobra.Command{
@@ -192,6 +247,374 @@ func GetCmdGetContractInfo() *cobra.Command {
 	return cmd
 }
 
+// GetCmdContractDeniedDenoms prints the denoms that a contract refuses to receive
+func GetCmdContractDeniedDenoms() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "denied-denoms [bech32_address]",
+		Short:   "Prints out the denoms that a contract refuses to receive",
+		Long:    "Prints out the denoms that a contract refuses to receive",
+		Aliases: []string{"denied"},
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			_, err = sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.ContractDeniedDenoms(
+				context.Background(),
+				&types.QueryContractDeniedDenomsRequest{
+					Address: args[0],
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdContractBalance prints the bank balance of a contract with the balance that the contract expects to hold
+func GetCmdContractBalance() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "balance [bech32_address]",
+		Short: "Prints out the bank balance of a contract with the balance that the contract expects to hold",
+		Long: `Prints out the bank balance of a contract with the balance that the contract expects to hold.
+The expected balance is reported by the contract for the {"expected_balance":{}} smart query. Funds above it
+are unaccounted, for example from direct bank transfers.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			_, err = sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.ContractBalance(
+				context.Background(),
+				&types.QueryContractBalanceRequest{
+					Address: args[0],
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdContractGasHints prints the recommended gas for common actions that the contract declares
+func GetCmdContractGasHints() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gas-hints [bech32_address]",
+		Short: "Prints out the recommended gas for common actions that the contract declares",
+		Long: `Prints out the recommended gas for common actions that the contract declares.
+The hints are reported by the contract for the {"gas_hints":{}} smart query and cached by the node for a number of blocks.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			_, err = sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.ContractGasHints(
+				context.Background(),
+				&types.QueryContractGasHintsRequest{
+					Address: args[0],
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdContractByPortID prints the address of the contract that is bound to the IBC port
+// GetCmdInterchainQueries lists the registered interchain queries, optionally of a single contract
+func GetCmdInterchainQueries() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "interchain-queries [optional owner contract bech32 address]",
+		Short:   "List the interchain queries that contracts registered",
+		Long:    "List the interchain queries that contracts registered, optionally of a single contract",
+		Aliases: []string{"icq"},
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			var owner string
+			if len(args) == 1 {
+				if _, err := sdk.AccAddressFromBech32(args[0]); err != nil {
+					return err
+				}
+				owner = args[0]
+			}
+
+			pageReq, err := client.ReadPageRequest(withPageKeyDecoded(cmd.Flags()))
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.InterchainQueries(
+				context.Background(),
+				&types.QueryInterchainQueriesRequest{
+					Owner:      owner,
+					Pagination: pageReq,
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddPaginationFlagsToCmd(cmd, "list interchain queries")
+	return cmd
+}
+
+// GetCmdJobs lists the pending end blocker jobs, optionally of a single type
+func GetCmdJobs() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "jobs [optional job type]",
+		Short: "List the pending jobs that the end blocker continues",
+		Long:  "List the pending jobs that the end blocker continues, optionally of a single type",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			var jobType string
+			if len(args) == 1 {
+				jobType = args[0]
+			}
+
+			pageReq, err := client.ReadPageRequest(withPageKeyDecoded(cmd.Flags()))
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.Jobs(
+				context.Background(),
+				&types.QueryJobsRequest{
+					Type:       jobType,
+					Pagination: pageReq,
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddPaginationFlagsToCmd(cmd, "list jobs")
+	return cmd
+}
+
+// GetCmdGasCosts gets the active gas cost table of the wasm module
+func GetCmdGasCosts() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gas-costs",
+		Short: "Get the gas cost table of the wasm module",
+		Long:  "Get the gas costs that the wasm module charges for contract instances, code uploads, messages, events and address conversions, in SDK gas",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.GasCosts(
+				context.Background(),
+				&types.QueryGasCostsRequest{},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func GetCmdContractByPortID() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "contract-by-port [port_id]",
+		Short:   "Prints out the address of the contract that is bound to the IBC port",
+		Long:    "Prints out the address of the contract that is bound to the IBC port",
+		Aliases: []string{"port"},
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.ContractByPortID(
+				context.Background(),
+				&types.QueryContractByPortIDRequest{
+					PortId: args[0],
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdModuleVersion prints the version and the capabilities of the wasm module
+func GetCmdModuleVersion() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "module-version",
+		Short:   "Prints out the version and the capabilities of the wasm module",
+		Long:    "Prints out the consensus version of the wasm module, the wasmvm version and the features that are supported for contracts",
+		Aliases: []string{"version"},
+		Args:    cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.ModuleVersion(
+				context.Background(),
+				&types.QueryModuleVersionRequest{},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdSimulateProposal executes a wasm proposal against the current state without persisting the changes
+func GetCmdSimulateProposal() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "simulate-proposal [proposal_json_file]",
+		Short: "Executes a wasm proposal against the current state and prints out the result",
+		Long: `Executes a wasm proposal against the current state and prints out the result. Nothing is persisted.
+The file contains the proposal content as JSON with the "@type" field, for example the content of the
+message that is printed with "tx gov submit-proposal ... --generate-only".
+The execution is limited by the smart query gas limit of the node. Store code, pin and unpin codes proposals
+can not be simulated.`,
+		Aliases: []string{"simulate"},
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			bz, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			req, err := parseSimulateProposalRequest(clientCtx.JSONMarshaler, bz)
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.SimulateProposal(context.Background(), req)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// parseSimulateProposalRequest decodes the JSON proposal content with the "@type" field
+func parseSimulateProposalRequest(cdc codec.JSONMarshaler, bz []byte) (*types.QuerySimulateProposalRequest, error) {
+	var any codectypes.Any
+	if err := cdc.UnmarshalJSON(bz, &any); err != nil {
+		return nil, sdkerrors.Wrap(err, "proposal")
+	}
+	return &types.QuerySimulateProposalRequest{Proposal: &any}, nil
+}
+
+// GetCmdCodeExecutionStats lists the contract execution statistics of a code by epoch
+func GetCmdCodeExecutionStats() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "code-stats [code_id]",
+		Short:   "List the contract execution statistics for given code id by epoch",
+		Long:    "List the number of contract executions and the gas consumed for given code id by epoch",
+		Aliases: []string{"stats"},
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			codeID, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			pageReq, err := client.ReadPageRequest(withPageKeyDecoded(cmd.Flags()))
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.CodeExecutionStats(
+				context.Background(),
+				&types.QueryCodeExecutionStatsRequest{
+					CodeId:     codeID,
+					Pagination: pageReq,
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddPaginationFlagsToCmd(cmd, "code execution stats")
+	return cmd
+}
+
 // GetCmdGetContractState dumps full internal state of a given contract
 func GetCmdGetContractState() *cobra.Command {
 	cmd := &cobra.Command{
@@ -256,15 +679,16 @@ func GetCmdGetContractStateRaw() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "raw [bech32_address] [key]",
 		Short: "Prints out internal state for key of a contract given its address",
-		Long:  "Prints out internal state for of a contract given its address",
-		Args:  cobra.ExactArgs(2),
+		Long: `Prints out internal state for of a contract given its address. With --prove the Merkle proof of the
+value is included. The proof of a query at height h is verified against the app hash of the block at h+1.`,
+		Args: cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, err := client.GetClientQueryContext(cmd)
 			if err != nil {
 				return err
 			}
 
-			_, err = sdk.AccAddressFromBech32(args[0])
+			contractAddr, err := sdk.AccAddressFromBech32(args[0])
 			if err != nil {
 				return err
 			}
@@ -272,6 +696,27 @@ func GetCmdGetContractStateRaw() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			prove, err := cmd.Flags().GetBool(flagProve)
+			if err != nil {
+				return err
+			}
+			if prove {
+				res, err := clientCtx.QueryABCI(abci.RequestQuery{
+					Path:   types.RawContractStateQueryPath,
+					Data:   types.GetRawContractStateKey(contractAddr, queryData),
+					Height: clientCtx.Height,
+					Prove:  true,
+				})
+				if err != nil {
+					return err
+				}
+				return clientCtx.PrintObjectLegacy(RawContractStateWithProof{
+					Data:   res.Value,
+					Key:    res.Key,
+					Height: res.Height,
+					Proof:  res.ProofOps,
+				})
+			}
 
 			queryClient := types.NewQueryClient(clientCtx)
 			res, err := queryClient.RawContractState(
@@ -288,10 +733,19 @@ func GetCmdGetContractStateRaw() *cobra.Command {
 		},
 	}
 	decoder.RegisterFlags(cmd.PersistentFlags(), "key argument")
+	cmd.Flags().Bool(flagProve, false, "Include the Merkle proof of the value")
 	flags.AddQueryFlagsToCmd(cmd)
 	return cmd
 }
 
+// RawContractStateWithProof is a raw contract state value with the Merkle proof against the app hash
+type RawContractStateWithProof struct {
+	Data   []byte             `json:"data"`
+	Key    []byte             `json:"key"`
+	Height int64              `json:"height"`
+	Proof  *tmcrypto.ProofOps `json:"proof"`
+}
+
 func GetCmdGetContractStateSmart() *cobra.Command {
 	decoder := newArgDecoder(asciiDecodeString)
 	cmd := &cobra.Command{
@@ -358,6 +812,14 @@ func GetCmdGetContractHistory() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			operation, err := parseHistoryOperationFlag(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			codeID, err := cmd.Flags().GetUint64(flagCodeID)
+			if err != nil {
+				return err
+			}
 
 			pageReq, err := client.ReadPageRequest(withPageKeyDecoded(cmd.Flags()))
 			if err != nil {
@@ -369,6 +831,8 @@ func GetCmdGetContractHistory() *cobra.Command {
 				&types.QueryContractHistoryRequest{
 					Address:    args[0],
 					Pagination: pageReq,
+					Operation:  operation,
+					CodeId:     codeID,
 				},
 			)
 			if err != nil {
@@ -379,11 +843,76 @@ func GetCmdGetContractHistory() *cobra.Command {
 		},
 	}
 
+	cmd.Flags().String(flagOperation, "", "Only entries of the operation type: init, migrate or genesis")
+	cmd.Flags().Uint64(flagCodeID, 0, "Only entries of the code id")
 	flags.AddQueryFlagsToCmd(cmd)
 	flags.AddPaginationFlagsToCmd(cmd, "contract history")
 	return cmd
 }
 
+const (
+	flagOperation = "operation"
+	flagCodeID    = "code-id"
+)
+
+// parseHistoryOperationFlag returns the contract history operation type of the flag or unspecified when not set
+func parseHistoryOperationFlag(flags *flag.FlagSet) (types.ContractCodeHistoryOperationType, error) {
+	operation, err := flags.GetString(flagOperation)
+	if err != nil {
+		return types.ContractCodeHistoryOperationTypeUnspecified, err
+	}
+	switch operation {
+	case "":
+		return types.ContractCodeHistoryOperationTypeUnspecified, nil
+	case "init":
+		return types.ContractCodeHistoryOperationTypeInit, nil
+	case "migrate":
+		return types.ContractCodeHistoryOperationTypeMigrate, nil
+	case "genesis":
+		return types.ContractCodeHistoryOperationTypeGenesis, nil
+	default:
+		return types.ContractCodeHistoryOperationTypeUnspecified, fmt.Errorf("unknown operation type: %s", operation)
+	}
+}
+
+// GetCmdGetContractStoreAuditLog prints the recorded store operations of the latest contract executions
+func GetCmdGetContractStoreAuditLog() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "store-audit-log [bech32_address]",
+		Short:   "Prints out the store reads and writes of the latest executions of a contract",
+		Long:    "Prints out the store reads and writes of the latest executions of a contract. This debug query must be enabled on the node.",
+		Aliases: []string{"audit"},
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			_, err = sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.ContractStoreAuditLog(
+				context.Background(),
+				&types.QueryContractStoreAuditLogRequest{
+					Address: args[0],
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
 type argumentDecoder struct {
 	// dec is the default decoder
 	dec                func(string) ([]byte, error)
@@ -440,3 +969,142 @@ func withPageKeyDecoded(flagSet *flag.FlagSet) *flag.FlagSet {
 	flagSet.Set(flags.FlagPageKey, string(raw))
 	return flagSet
 }
+
+// GetCmdWasmCacheStatus prints the pinned codes and the wasm VM cache metrics of the node
+func GetCmdWasmCacheStatus() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "cache-status",
+		Short:   "Prints out the pinned codes and the wasm VM cache metrics of the node",
+		Long:    "Prints out the pinned codes and the wasm VM cache metrics of the node. This operator query must be enabled on the node.",
+		Aliases: []string{"cache"},
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.WasmCacheStatus(
+				context.Background(),
+				&types.QueryWasmCacheStatusRequest{},
+			)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+const (
+	flagMinHeight = "min-height"
+	flagMaxHeight = "max-height"
+	flagProve     = "prove"
+)
+
+// ContractTx is a tx that called a contract
+type ContractTx struct {
+	Hash   string           `json:"hash"`
+	Height int64            `json:"height"`
+	Proof  *tmtypes.TxProof `json:"proof,omitempty"`
+}
+
+// ContractTxsResponse is the result of a contract txs search
+type ContractTxsResponse struct {
+	TotalCount int          `json:"total_count"`
+	Txs        []ContractTx `json:"txs"`
+}
+
+// GetCmdContractTxs prints the txs that called a contract with their Merkle proofs
+func GetCmdContractTxs() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "contract-txs [bech32_address]",
+		Short: "Prints out the txs that called a contract within a height range",
+		Long: fmt.Sprintf(`Prints out the hashes of the txs that called a contract within a height range together with
+their Merkle proofs against the data hash of the block. The node must index the %q events, which can be ensured
+with the --wasm.contract_tx_index start flag.`, types.EventKeyContractAddr),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			contractAddr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			minHeight, err := cmd.Flags().GetInt64(flagMinHeight)
+			if err != nil {
+				return err
+			}
+			maxHeight, err := cmd.Flags().GetInt64(flagMaxHeight)
+			if err != nil {
+				return err
+			}
+			query, err := contractTxsQuery(contractAddr, minHeight, maxHeight)
+			if err != nil {
+				return err
+			}
+			prove, err := cmd.Flags().GetBool(flagProve)
+			if err != nil {
+				return err
+			}
+			page, err := cmd.Flags().GetInt(flags.FlagPage)
+			if err != nil {
+				return err
+			}
+			limit, err := cmd.Flags().GetInt(flags.FlagLimit)
+			if err != nil {
+				return err
+			}
+
+			node, err := clientCtx.GetNode()
+			if err != nil {
+				return err
+			}
+			res, err := node.TxSearch(context.Background(), query, prove, &page, &limit, "asc")
+			if err != nil {
+				return err
+			}
+			out := ContractTxsResponse{TotalCount: res.TotalCount, Txs: make([]ContractTx, len(res.Txs))}
+			for i, tx := range res.Txs {
+				out.Txs[i] = ContractTx{Hash: tx.Hash.String(), Height: tx.Height}
+				if prove {
+					proof := tx.Proof
+					out.Txs[i].Proof = &proof
+				}
+			}
+			return clientCtx.PrintObjectLegacy(out)
+		},
+	}
+	cmd.Flags().Int64(flagMinHeight, 0, "Lowest block height to include. 0 for no lower bound")
+	cmd.Flags().Int64(flagMaxHeight, 0, "Highest block height to include. 0 for no upper bound")
+	cmd.Flags().Bool(flagProve, true, "Include the Merkle proofs of the txs")
+	cmd.Flags().Int(flags.FlagPage, 1, "Query a specific page of paginated results")
+	cmd.Flags().Int(flags.FlagLimit, 30, "Query number of transactions results per page returned")
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// contractTxsQuery builds the tx search query for the contract events within the height range
+func contractTxsQuery(contractAddr sdk.AccAddress, minHeight, maxHeight int64) (string, error) {
+	if minHeight < 0 || maxHeight < 0 {
+		return "", errors.New("height must not be negative")
+	}
+	if maxHeight != 0 && maxHeight < minHeight {
+		return "", errors.New("max height must not be lower than min height")
+	}
+	query := fmt.Sprintf("%s='%s'", types.EventKeyContractAddr, contractAddr.String())
+	if minHeight != 0 {
+		query += fmt.Sprintf(" AND tx.height>=%d", minHeight)
+	}
+	if maxHeight != 0 {
+		query += fmt.Sprintf(" AND tx.height<=%d", maxHeight)
+	}
+	return query, nil
+}
@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContractTxsQuery(t *testing.T) {
+	contractAddr := sdk.AccAddress(make([]byte, sdk.AddrLen))
+	specs := map[string]struct {
+		srcMin, srcMax int64
+		exp            string
+		expErr         bool
+	}{
+		"no height range": {
+			exp: "wasm.contract_address='" + contractAddr.String() + "'",
+		},
+		"min height": {
+			srcMin: 10,
+			exp:    "wasm.contract_address='" + contractAddr.String() + "' AND tx.height>=10",
+		},
+		"max height": {
+			srcMax: 20,
+			exp:    "wasm.contract_address='" + contractAddr.String() + "' AND tx.height<=20",
+		},
+		"height range": {
+			srcMin: 10,
+			srcMax: 10,
+			exp:    "wasm.contract_address='" + contractAddr.String() + "' AND tx.height>=10 AND tx.height<=10",
+		},
+		"max below min": {
+			srcMin: 10,
+			srcMax: 9,
+			expErr: true,
+		},
+		"negative height": {
+			srcMin: -1,
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			got, err := contractTxsQuery(contractAddr, spec.srcMin, spec.srcMax)
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
+
+func TestParseSimulateProposalRequest(t *testing.T) {
+	cdc := keeper.MakeEncodingConfig(t).Marshaler
+	myProposal := types.InstantiateContractProposalFixture()
+	req, err := types.NewQuerySimulateProposalRequest(myProposal)
+	require.NoError(t, err)
+	bz, err := cdc.MarshalJSON(req.Proposal)
+	require.NoError(t, err)
+
+	// when
+	gotReq, err := parseSimulateProposalRequest(cdc, bz)
+
+	// then
+	require.NoError(t, err)
+	var gotProposal govtypes.Content
+	require.NoError(t, cdc.UnpackAny(gotReq.Proposal, &gotProposal))
+	assert.Equal(t, myProposal, gotProposal)
+
+	// and when invalid
+	_, err = parseSimulateProposalRequest(cdc, []byte(`{"title":"foo"}`))
+	assert.Error(t, err)
+}
@@ -1,15 +1,19 @@
 package cli
 
 import (
+	"bufio"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"os"
 	"strconv"
 
 	wasmUtils "github.com/CosmWasm/wasmd/x/wasm/client/utils"
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/input"
 	"github.com/cosmos/cosmos-sdk/client/tx"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
@@ -23,12 +27,18 @@ const (
 	flagBuilder                = "builder"
 	flagLabel                  = "label"
 	flagAdmin                  = "admin"
+	flagNoAdmin                = "no-admin"
 	flagRunAs                  = "run-as"
 	flagInstantiateByEverybody = "instantiate-everybody"
 	flagInstantiateByAddress   = "instantiate-only-address"
 	flagProposalType           = "type"
+	flagFundsFromCommunityPool = "funds-from-community-pool"
+	flagHexMsg                 = "hex-msg"
 )
 
+// adminIsSender is the value of the admin flag that sets the sender as admin
+const adminIsSender = "me"
+
 // GetTxCmd returns the transaction commands for this module
 func GetTxCmd() *cobra.Command {
 	txCmd := &cobra.Command{
@@ -45,6 +55,9 @@ func GetTxCmd() *cobra.Command {
 		MigrateContractCmd(),
 		UpdateContractAdminCmd(),
 		ClearContractAdminCmd(),
+		UpdateDeniedDenomsCmd(),
+		UpdateReentrancyGuardCmd(),
+		DeployCmd(),
 	)
 	return txCmd
 }
@@ -145,7 +158,7 @@ func parseStoreCodeArgs(file string, sender sdk.AccAddress, flags *flag.FlagSet)
 // InstantiateContractCmd will instantiate a contract from previously uploaded code.
 func InstantiateContractCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "instantiate [code_id_int64] [json_encoded_init_args] --label [text] --admin [address,optional] --amount [coins,optional]",
+		Use:     "instantiate [code_id_int64] [json_encoded_init_args] --label [text] --admin [address,me] | --no-admin --amount [coins,optional]",
 		Short:   "Instantiate a wasm contract",
 		Aliases: []string{"start", "init", "inst", "i"},
 		Args:    cobra.ExactArgs(2),
@@ -153,7 +166,7 @@ func InstantiateContractCmd() *cobra.Command {
 
 			clientCtx, err := client.GetClientTxContext(cmd)
 
-			msg, err := parseInstantiateArgs(args[0], args[1], clientCtx.GetFromAddress(), cmd.Flags())
+			msg, err := parseInstantiateArgs(args[0], args[1], clientCtx.GetFromAddress(), cmd.Flags(), noAdminConfirmation(clientCtx))
 			if err != nil {
 				return err
 			}
@@ -166,12 +179,16 @@ func InstantiateContractCmd() *cobra.Command {
 
 	cmd.Flags().String(flagAmount, "", "Coins to send to the contract during instantiation")
 	cmd.Flags().String(flagLabel, "", "A human-readable name for this contract in lists")
-	cmd.Flags().String(flagAdmin, "", "Address of an admin")
+	cmd.Flags().String(flagAdmin, "", `Address of an admin or "me" for the sender`)
+	cmd.Flags().Bool(flagNoAdmin, false, "Instantiate without an admin. The contract can never be migrated")
+	cmd.Flags().Bool(flagHexMsg, false, "Decode the init args from hex and send them as binary payload")
 	flags.AddTxFlagsToCmd(cmd)
 	return cmd
 }
 
-func parseInstantiateArgs(rawCodeID, initMsg string, sender sdk.AccAddress, flags *flag.FlagSet) (types.MsgInstantiateContract, error) {
+// parseInstantiateArgs builds the instantiate message from the cli args and flags. When neither an admin nor the
+// no admin flag is set, the optional confirmation is asked before a contract without admin is instantiated.
+func parseInstantiateArgs(rawCodeID, initMsg string, sender sdk.AccAddress, flags *flag.FlagSet, confirmNoAdmin func() (bool, error)) (types.MsgInstantiateContract, error) {
 	// get the id of the code to instantiate
 	codeID, err := strconv.ParseUint(rawCodeID, 10, 64)
 	if err != nil {
@@ -197,15 +214,41 @@ func parseInstantiateArgs(rawCodeID, initMsg string, sender sdk.AccAddress, flag
 	if err != nil {
 		return types.MsgInstantiateContract{}, fmt.Errorf("admin: %s", err)
 	}
+	noAdmin, err := flags.GetBool(flagNoAdmin)
+	if err != nil {
+		return types.MsgInstantiateContract{}, fmt.Errorf("no admin: %s", err)
+	}
+	// a contract without admin can not be migrated so that it must not be the default by accident
+	switch {
+	case adminStr != "" && noAdmin:
+		return types.MsgInstantiateContract{}, errors.New("admin and no admin flags are mutually exclusive")
+	case adminStr == "" && !noAdmin:
+		var confirmed bool
+		if confirmNoAdmin != nil {
+			if confirmed, err = confirmNoAdmin(); err != nil {
+				return types.MsgInstantiateContract{}, fmt.Errorf("no admin confirmation: %s", err)
+			}
+		}
+		if !confirmed {
+			return types.MsgInstantiateContract{}, fmt.Errorf("admin is required: set --%s or --%s explicitly", flagAdmin, flagNoAdmin)
+		}
+	case adminStr == adminIsSender:
+		adminStr = sender.String()
+	}
+	msgBz, encoding, err := parseMsgPayload(initMsg, flags)
+	if err != nil {
+		return types.MsgInstantiateContract{}, fmt.Errorf("init args: %s", err)
+	}
 
 	// build and sign the transaction, then broadcast to Tendermint
 	msg := types.MsgInstantiateContract{
-		Sender:  sender.String(),
-		CodeID:  codeID,
-		Label:   label,
-		Funds:   amount,
-		InitMsg: []byte(initMsg),
-		Admin:   adminStr,
+		Sender:      sender.String(),
+		CodeID:      codeID,
+		Label:       label,
+		Funds:       amount,
+		InitMsg:     msgBz,
+		Admin:       adminStr,
+		MsgEncoding: encoding,
 	}
 	return msg, nil
 }
@@ -232,6 +275,7 @@ func ExecuteContractCmd() *cobra.Command {
 	}
 
 	cmd.Flags().String(flagAmount, "", "Coins to send to the contract along with command")
+	cmd.Flags().Bool(flagHexMsg, false, "Decode the send args from hex and send them as binary payload")
 	flags.AddTxFlagsToCmd(cmd)
 	return cmd
 }
@@ -246,11 +290,45 @@ func parseExecuteArgs(contractAddr string, execMsg string, sender sdk.AccAddress
 	if err != nil {
 		return types.MsgExecuteContract{}, err
 	}
+	msgBz, encoding, err := parseMsgPayload(execMsg, flags)
+	if err != nil {
+		return types.MsgExecuteContract{}, fmt.Errorf("send args: %s", err)
+	}
 
 	return types.MsgExecuteContract{
-		Sender:   sender.String(),
-		Contract: contractAddr,
-		Funds:    amount,
-		Msg:      []byte(execMsg),
+		Sender:      sender.String(),
+		Contract:    contractAddr,
+		Funds:       amount,
+		Msg:         msgBz,
+		MsgEncoding: encoding,
 	}, nil
 }
+
+// noAdminConfirmation asks the user on the client input to confirm the instantiation without admin. The skip
+// confirmation flag does not apply so that scripts have to set the no admin flag explicitly.
+func noAdminConfirmation(clientCtx client.Context) func() (bool, error) {
+	return func() (bool, error) {
+		buf := bufio.NewReader(clientCtx.Input)
+		return input.GetConfirmation("No admin set: the contract can never be migrated. Continue?", buf, os.Stderr)
+	}
+}
+
+// parseMsgPayload returns the json message as it is or the hex decoded binary payload when the hex flag is set.
+// Commands that do not support binary payloads do not define the flag.
+func parseMsgPayload(rawMsg string, flags *flag.FlagSet) ([]byte, types.MessageEncoding, error) {
+	if flags.Lookup(flagHexMsg) == nil {
+		return []byte(rawMsg), types.MessageEncodingJSON, nil
+	}
+	isHex, err := flags.GetBool(flagHexMsg)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !isHex {
+		return []byte(rawMsg), types.MessageEncodingJSON, nil
+	}
+	bz, err := hex.DecodeString(rawMsg)
+	if err != nil {
+		return nil, 0, err
+	}
+	return bz, types.MessageEncodingBinary, nil
+}
@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseInstantiateArgsAdmin(t *testing.T) {
+	sender := keeper.RandomAccountAddress(t)
+	otherAddr := keeper.RandomBech32AccountAddress(t)
+	specs := map[string]struct {
+		srcFlags map[string]string
+		confirm  func() (bool, error)
+		expAdmin string
+		expErr   bool
+	}{
+		"admin address": {
+			srcFlags: map[string]string{"admin": otherAddr},
+			expAdmin: otherAddr,
+		},
+		"admin is sender": {
+			srcFlags: map[string]string{"admin": "me"},
+			expAdmin: sender.String(),
+		},
+		"no admin": {
+			srcFlags: map[string]string{"no-admin": "true"},
+		},
+		"admin not set": {
+			expErr: true,
+		},
+		"admin not set and no admin confirmed": {
+			confirm: func() (bool, error) { return true, nil },
+		},
+		"admin not set and no admin rejected": {
+			confirm: func() (bool, error) { return false, nil },
+			expErr:  true,
+		},
+		"admin not set and confirmation failed": {
+			confirm: func() (bool, error) { return true, errors.New("testing") },
+			expErr:  true,
+		},
+		"no admin without confirmation": {
+			srcFlags: map[string]string{"no-admin": "true"},
+			confirm:  func() (bool, error) { panic("must not be called") },
+		},
+		"admin and no admin": {
+			srcFlags: map[string]string{"admin": otherAddr, "no-admin": "true"},
+			expErr:   true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			flagSet := InstantiateContractCmd().Flags()
+			require.NoError(t, flagSet.Set("label", "testing"))
+			for k, v := range spec.srcFlags {
+				require.NoError(t, flagSet.Set(k, v))
+			}
+
+			// when
+			msg, err := parseInstantiateArgs("1", `{}`, sender, flagSet, spec.confirm)
+
+			// then
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, spec.expAdmin, msg.Admin)
+			assert.False(t, msg.AdminIsSender)
+			assert.NoError(t, msg.ValidateBasic())
+		})
+	}
+}
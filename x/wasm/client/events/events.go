@@ -0,0 +1,126 @@
+// Package events provides subscriptions to the wasm events of contracts over the Tendermint RPC websocket.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// Filter selects the wasm events of a subscription. Empty fields match all events.
+type Filter struct {
+	// ContractAddress is the bech32 address of the contract that emitted the event
+	ContractAddress string
+	// Action is the value of the `action` attribute of the event
+	Action string
+}
+
+// Query returns the Tendermint event query for the filter
+func (f Filter) Query() string {
+	q := []string{fmt.Sprintf("%s='%s'", tmtypes.EventTypeKey, tmtypes.EventTx)}
+	if f.ContractAddress != "" {
+		q = append(q, fmt.Sprintf("%s.%s='%s'", types.CustomEventType, types.AttributeKeyContractAddr, f.ContractAddress))
+	}
+	if f.Action != "" {
+		q = append(q, fmt.Sprintf("%s.%s='%s'", types.CustomEventType, sdk.AttributeKeyAction, f.Action))
+	}
+	return strings.Join(q, " AND ")
+}
+
+// Matches returns true when the attributes match the filter
+func (f Filter) Matches(attrs map[string]string) bool {
+	if f.ContractAddress != "" && attrs[types.AttributeKeyContractAddr] != f.ContractAddress {
+		return false
+	}
+	if f.Action != "" && attrs[sdk.AttributeKeyAction] != f.Action {
+		return false
+	}
+	return true
+}
+
+// Event is a wasm event that was emitted by a contract in a transaction
+type Event struct {
+	Height          int64
+	TxHash          []byte
+	ContractAddress string
+	// Attributes contains the event attributes by key. For duplicate keys the last value is set.
+	Attributes map[string]string
+}
+
+// Decode decodes the attributes into the given struct. The attribute keys are matched by the json field names. All
+// attribute values are strings so that other field types need the json `string` option, like `json:"amount,string"`.
+func (e Event) Decode(out interface{}) error {
+	bz, err := json.Marshal(e.Attributes)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(bz, out)
+}
+
+// Subscribe subscribes to the wasm events that match the filter. The events are sent to the returned channel that is
+// closed when the context is done or the node closes the subscription. The subscription is removed then.
+func Subscribe(ctx context.Context, c rpcclient.EventsClient, subscriber string, f Filter) (<-chan Event, error) {
+	query := f.Query()
+	in, err := c.Subscribe(ctx, subscriber, query)
+	if err != nil {
+		return nil, err
+	}
+	out := make(chan Event)
+	go func() {
+		defer close(out)
+		defer func() { _ = c.Unsubscribe(context.Background(), subscriber, query) }()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case res, ok := <-in:
+				if !ok {
+					return
+				}
+				data, ok := res.Data.(tmtypes.EventDataTx)
+				if !ok {
+					continue
+				}
+				for _, e := range WasmEvents(data.TxResult, f) {
+					select {
+					case out <- e:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}
+	}()
+	return out, nil
+}
+
+// WasmEvents returns the wasm events of the transaction result that match the filter
+func WasmEvents(txResult abci.TxResult, f Filter) []Event {
+	var events []Event
+	for _, ev := range txResult.Result.Events {
+		if ev.Type != types.CustomEventType {
+			continue
+		}
+		attrs := make(map[string]string, len(ev.Attributes))
+		for _, a := range ev.Attributes {
+			attrs[string(a.Key)] = string(a.Value)
+		}
+		if !f.Matches(attrs) {
+			continue
+		}
+		events = append(events, Event{
+			Height:          txResult.Height,
+			TxHash:          tmtypes.Tx(txResult.Tx).Hash(),
+			ContractAddress: attrs[types.AttributeKeyContractAddr],
+			Attributes:      attrs,
+		})
+	}
+	return events
+}
@@ -0,0 +1,187 @@
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+const (
+	myContract    = "cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr"
+	otherContract = "cosmos1ghekyjucln7y67ntx7cf27m9dpuxxemn4c8g4r"
+)
+
+func TestFilterQuery(t *testing.T) {
+	specs := map[string]struct {
+		src Filter
+		exp string
+	}{
+		"all": {
+			exp: "tm.event='Tx'",
+		},
+		"contract": {
+			src: Filter{ContractAddress: myContract},
+			exp: "tm.event='Tx' AND wasm.contract_address='" + myContract + "'",
+		},
+		"contract and action": {
+			src: Filter{ContractAddress: myContract, Action: "release"},
+			exp: "tm.event='Tx' AND wasm.contract_address='" + myContract + "' AND wasm.action='release'",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, spec.exp, spec.src.Query())
+		})
+	}
+}
+
+func TestWasmEvents(t *testing.T) {
+	txResult := myTxResult()
+	specs := map[string]struct {
+		src Filter
+		exp []string
+	}{
+		"all": {
+			exp: []string{myContract, otherContract},
+		},
+		"contract": {
+			src: Filter{ContractAddress: myContract},
+			exp: []string{myContract},
+		},
+		"action": {
+			src: Filter{Action: "transfer"},
+			exp: []string{otherContract},
+		},
+		"no match": {
+			src: Filter{ContractAddress: myContract, Action: "transfer"},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			events := WasmEvents(txResult, spec.src)
+			var got []string
+			for _, e := range events {
+				assert.Equal(t, int64(7), e.Height)
+				assert.Equal(t, tmtypes.Tx("my tx").Hash(), e.TxHash)
+				got = append(got, e.ContractAddress)
+			}
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
+
+func TestEventDecode(t *testing.T) {
+	e := Event{Attributes: map[string]string{"action": "release", "amount": "100", "other": "foo"}}
+	var got struct {
+		Action string `json:"action"`
+		Amount uint64 `json:"amount,string"`
+	}
+	require.NoError(t, e.Decode(&got))
+	assert.Equal(t, "release", got.Action)
+	assert.Equal(t, uint64(100), got.Amount)
+}
+
+func TestSubscribe(t *testing.T) {
+	in := make(chan ctypes.ResultEvent, 2)
+	client := &mockEventsClient{out: in}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	events, err := Subscribe(ctx, client, "my-bot", Filter{ContractAddress: myContract})
+	require.NoError(t, err)
+	assert.Equal(t, "tm.event='Tx' AND wasm.contract_address='"+myContract+"'", client.subscribed)
+
+	// non tx events are skipped
+	in <- ctypes.ResultEvent{Data: tmtypes.EventDataNewBlock{}}
+	in <- ctypes.ResultEvent{Data: tmtypes.EventDataTx{TxResult: myTxResult()}}
+	select {
+	case e := <-events:
+		assert.Equal(t, myContract, e.ContractAddress)
+		assert.Equal(t, "release", e.Attributes["action"])
+	case <-time.After(time.Second):
+		t.Fatal("timeout")
+	}
+
+	// the channel is closed and the subscription removed when the context is done
+	cancel()
+	select {
+	case _, ok := <-events:
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("timeout")
+	}
+	assert.Eventually(t, func() bool { return client.unsubscribed() }, time.Second, 10*time.Millisecond)
+}
+
+func TestSubscribeClosedByNode(t *testing.T) {
+	in := make(chan ctypes.ResultEvent)
+	client := &mockEventsClient{out: in}
+
+	events, err := Subscribe(context.Background(), client, "my-bot", Filter{})
+	require.NoError(t, err)
+
+	// the channel is closed when the node closes the subscription
+	close(in)
+	select {
+	case _, ok := <-events:
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("timeout")
+	}
+	assert.Eventually(t, func() bool { return client.unsubscribed() }, time.Second, 10*time.Millisecond)
+}
+
+func myTxResult() abci.TxResult {
+	return abci.TxResult{
+		Height: 7,
+		Tx:     []byte("my tx"),
+		Result: abci.ResponseDeliverTx{
+			Events: []abci.Event{
+				{Type: "message", Attributes: []abci.EventAttribute{{Key: []byte("module"), Value: []byte("wasm")}}},
+				{Type: "wasm", Attributes: []abci.EventAttribute{
+					{Key: []byte("contract_address"), Value: []byte(myContract)},
+					{Key: []byte("action"), Value: []byte("release")},
+				}},
+				{Type: "wasm", Attributes: []abci.EventAttribute{
+					{Key: []byte("contract_address"), Value: []byte(otherContract)},
+					{Key: []byte("action"), Value: []byte("transfer")},
+				}},
+			},
+		},
+	}
+}
+
+type mockEventsClient struct {
+	out          chan ctypes.ResultEvent
+	subscribed   string
+	unsubscribeC chan struct{}
+}
+
+func (m *mockEventsClient) Subscribe(ctx context.Context, subscriber, query string, outCapacity ...int) (<-chan ctypes.ResultEvent, error) {
+	m.subscribed = query
+	m.unsubscribeC = make(chan struct{})
+	return m.out, nil
+}
+
+func (m *mockEventsClient) Unsubscribe(ctx context.Context, subscriber, query string) error {
+	close(m.unsubscribeC)
+	return nil
+}
+
+func (m *mockEventsClient) UnsubscribeAll(ctx context.Context, subscriber string) error {
+	panic("not expected to be called")
+}
+
+func (m *mockEventsClient) unsubscribed() bool {
+	select {
+	case <-m.unsubscribeC:
+		return true
+	default:
+		return false
+	}
+}
@@ -82,6 +82,8 @@ type InstantiateProposalJsonReq struct {
 	Label   string          `json:"label" yaml:"label"`
 	InitMsg json.RawMessage `json:"init_msg" yaml:"init_msg"`
 	Funds   sdk.Coins       `json:"funds" yaml:"funds"`
+	// FundsFromCommunityPool draws the funds from the community pool on execution
+	FundsFromCommunityPool bool `json:"funds_from_community_pool,omitempty" yaml:"funds_from_community_pool"`
 }
 
 func (s InstantiateProposalJsonReq) Content() govtypes.Content {
@@ -94,6 +96,8 @@ func (s InstantiateProposalJsonReq) Content() govtypes.Content {
 		Label:       s.Label,
 		InitMsg:     s.InitMsg,
 		Funds:       s.Funds,
+
+		FundsFromCommunityPool: s.FundsFromCommunityPool,
 	}
 }
 func (s InstantiateProposalJsonReq) GetProposer() string {
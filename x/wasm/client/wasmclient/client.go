@@ -0,0 +1,238 @@
+// Package wasmclient provides a Go client to build, sign and broadcast the wasm messages and to run contract queries.
+// It reuses the codecs and the tx configuration of the client context and can be used by integration tests or backend
+// services.
+package wasmclient
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+
+	wasmUtils "github.com/CosmWasm/wasmd/x/wasm/client/utils"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/gogo/protobuf/proto"
+)
+
+// Client builds, signs and broadcasts wasm messages with the `from` account of the client context and runs contract
+// queries against the node of the client context.
+type Client struct {
+	clientCtx   client.Context
+	txf         tx.Factory
+	queryClient types.QueryClient
+}
+
+// NewClient constructor. The client context must have the codecs, the tx config, the keyring and the `from` account
+// set. The factory is used for the fees, the gas settings and the keyring.
+func NewClient(clientCtx client.Context, txf tx.Factory) *Client {
+	return &Client{
+		clientCtx:   clientCtx,
+		txf:         txf,
+		queryClient: types.NewQueryClient(clientCtx),
+	}
+}
+
+// StoreCode uploads the wasm byte code. Uncompressed code is gzipped before. The code id is returned when the
+// transaction was committed in block broadcast mode.
+func (c *Client) StoreCode(wasm []byte, permission *types.AccessConfig) (uint64, *sdk.TxResponse, error) {
+	msg, err := NewStoreCodeMsg(c.clientCtx.GetFromAddress(), wasm, permission)
+	if err != nil {
+		return 0, nil, err
+	}
+	res, err := c.BroadcastTx(&msg)
+	if err != nil {
+		return 0, res, err
+	}
+	var rsp types.MsgStoreCodeResponse
+	if err := msgResponse(res, 0, &rsp); err != nil {
+		return 0, res, err
+	}
+	return rsp.CodeID, res, nil
+}
+
+// Instantiate creates a new contract instance. The init message is JSON encoded unless it is of type []byte or
+// json.RawMessage. The contract address is returned when the transaction was committed in block broadcast mode.
+func (c *Client) Instantiate(codeID uint64, label string, initMsg interface{}, funds sdk.Coins, admin sdk.AccAddress) (string, *sdk.TxResponse, error) {
+	msg, err := NewInstantiateMsg(c.clientCtx.GetFromAddress(), codeID, label, initMsg, funds, admin)
+	if err != nil {
+		return "", nil, err
+	}
+	res, err := c.BroadcastTx(&msg)
+	if err != nil {
+		return "", res, err
+	}
+	var rsp types.MsgInstantiateContractResponse
+	if err := msgResponse(res, 0, &rsp); err != nil {
+		return "", res, err
+	}
+	return rsp.Address, res, nil
+}
+
+// Execute executes the contract. The execute message is JSON encoded unless it is of type []byte or json.RawMessage.
+// The data returned by the contract is returned when the transaction was committed in block broadcast mode.
+func (c *Client) Execute(contract string, execMsg interface{}, funds sdk.Coins) ([]byte, *sdk.TxResponse, error) {
+	msg, err := NewExecuteMsg(c.clientCtx.GetFromAddress(), contract, execMsg, funds)
+	if err != nil {
+		return nil, nil, err
+	}
+	res, err := c.BroadcastTx(&msg)
+	if err != nil {
+		return nil, res, err
+	}
+	var rsp types.MsgExecuteContractResponse
+	if err := msgResponse(res, 0, &rsp); err != nil {
+		return nil, res, err
+	}
+	return rsp.Data, res, nil
+}
+
+// BroadcastTx signs the messages with the `from` account of the client context and broadcasts them in a single
+// transaction with the broadcast mode of the client context. Account number and sequence are queried when not set
+// and the gas is estimated when the factory is set to simulate. A transaction that failed with an abci error code is
+// returned with an error.
+func (c *Client) BroadcastTx(msgs ...sdk.Msg) (*sdk.TxResponse, error) {
+	txf, err := tx.PrepareFactory(c.clientCtx, c.txf)
+	if err != nil {
+		return nil, err
+	}
+	if txf.SimulateAndExecute() {
+		_, adjusted, err := tx.CalculateGas(c.clientCtx.QueryWithData, txf, msgs...)
+		if err != nil {
+			return nil, err
+		}
+		txf = txf.WithGas(adjusted)
+	}
+	txBuilder, err := tx.BuildUnsignedTx(txf, msgs...)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Sign(txf, c.clientCtx.GetFromName(), txBuilder, true); err != nil {
+		return nil, err
+	}
+	txBytes, err := c.clientCtx.TxConfig.TxEncoder()(txBuilder.GetTx())
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.clientCtx.BroadcastTx(txBytes)
+	if err != nil {
+		return res, err
+	}
+	if res.Code != 0 {
+		return res, sdkerrors.ABCIError(res.Codespace, res.Code, res.RawLog)
+	}
+	return res, nil
+}
+
+// QuerySmart runs a smart query against the contract and decodes the JSON response into the given pointer, like
+// json.Unmarshal. The query is JSON encoded unless it is of type []byte or json.RawMessage.
+func (c *Client) QuerySmart(ctx context.Context, contract string, query interface{}, response interface{}) error {
+	queryData, err := toJSON(query)
+	if err != nil {
+		return sdkerrors.Wrap(err, "query")
+	}
+	res, err := c.queryClient.SmartContractState(ctx, &types.QuerySmartContractStateRequest{
+		Address:   contract,
+		QueryData: queryData,
+	})
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(res.Data, response)
+}
+
+// QueryRaw returns the raw contract store value for the key. Nil is returned when the key does not exist.
+func (c *Client) QueryRaw(ctx context.Context, contract string, key []byte) ([]byte, error) {
+	res, err := c.queryClient.RawContractState(ctx, &types.QueryRawContractStateRequest{
+		Address:   contract,
+		QueryData: key,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.Data, nil
+}
+
+// NewStoreCodeMsg returns a validated store code message. Uncompressed wasm code is gzipped.
+func NewStoreCodeMsg(sender sdk.AccAddress, wasm []byte, permission *types.AccessConfig) (types.MsgStoreCode, error) {
+	if wasmUtils.IsWasm(wasm) {
+		var err error
+		if wasm, err = wasmUtils.GzipIt(wasm); err != nil {
+			return types.MsgStoreCode{}, err
+		}
+	} else if !wasmUtils.IsGzip(wasm) {
+		return types.MsgStoreCode{}, sdkerrors.Wrap(types.ErrCreateFailed, "invalid input file. Use wasm binary or gzip")
+	}
+	msg := types.MsgStoreCode{
+		Sender:                sender.String(),
+		WASMByteCode:          wasm,
+		InstantiatePermission: permission,
+	}
+	return msg, msg.ValidateBasic()
+}
+
+// NewInstantiateMsg returns a validated instantiate message. The init message is JSON encoded unless it is of type
+// []byte or json.RawMessage. The admin is optional.
+func NewInstantiateMsg(sender sdk.AccAddress, codeID uint64, label string, initMsg interface{}, funds sdk.Coins, admin sdk.AccAddress) (types.MsgInstantiateContract, error) {
+	bz, err := toJSON(initMsg)
+	if err != nil {
+		return types.MsgInstantiateContract{}, sdkerrors.Wrap(err, "init msg")
+	}
+	msg := types.MsgInstantiateContract{
+		Sender:  sender.String(),
+		CodeID:  codeID,
+		Label:   label,
+		InitMsg: bz,
+		Funds:   funds,
+	}
+	if admin != nil {
+		msg.Admin = admin.String()
+	}
+	return msg, msg.ValidateBasic()
+}
+
+// NewExecuteMsg returns a validated execute message. The execute message is JSON encoded unless it is of type []byte
+// or json.RawMessage.
+func NewExecuteMsg(sender sdk.AccAddress, contract string, execMsg interface{}, funds sdk.Coins) (types.MsgExecuteContract, error) {
+	bz, err := toJSON(execMsg)
+	if err != nil {
+		return types.MsgExecuteContract{}, sdkerrors.Wrap(err, "execute msg")
+	}
+	msg := types.MsgExecuteContract{
+		Sender:   sender.String(),
+		Contract: contract,
+		Msg:      bz,
+		Funds:    funds,
+	}
+	return msg, msg.ValidateBasic()
+}
+
+func toJSON(o interface{}) ([]byte, error) {
+	switch v := o.(type) {
+	case []byte:
+		return v, nil
+	case json.RawMessage:
+		return v, nil
+	default:
+		return json.Marshal(o)
+	}
+}
+
+// msgResponse decodes the response of the message at the given position from the hex encoded tx data. The data is
+// empty for transactions that were not committed yet.
+func msgResponse(res *sdk.TxResponse, pos int, rsp proto.Message) error {
+	bz, err := hex.DecodeString(res.Data)
+	if err != nil {
+		return sdkerrors.Wrap(err, "tx data")
+	}
+	var data sdk.TxMsgData
+	if err := proto.Unmarshal(bz, &data); err != nil {
+		return sdkerrors.Wrap(err, "tx data")
+	}
+	if len(data.Data) <= pos {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "no message response, tx not committed in block broadcast mode?")
+	}
+	return proto.Unmarshal(data.Data[pos].Data, rsp)
+}
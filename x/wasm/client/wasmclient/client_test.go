@@ -0,0 +1,179 @@
+package wasmclient
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	wasmUtils "github.com/CosmWasm/wasmd/x/wasm/client/utils"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+var (
+	mySender   = sdk.AccAddress(make([]byte, 20))
+	myContract = sdk.AccAddress(append(make([]byte, 19), 1)).String()
+)
+
+func TestNewStoreCodeMsg(t *testing.T) {
+	wasmCode, err := ioutil.ReadFile("../../keeper/testdata/hackatom.wasm")
+	require.NoError(t, err)
+	gzipped, err := wasmUtils.GzipIt(wasmCode)
+	require.NoError(t, err)
+
+	specs := map[string]struct {
+		src    []byte
+		expErr bool
+	}{
+		"wasm code gzipped": {
+			src: wasmCode,
+		},
+		"gzipped code": {
+			src: gzipped,
+		},
+		"other content": {
+			src:    []byte("not wasm"),
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			msg, err := NewStoreCodeMsg(mySender, spec.src, &types.AllowEverybody)
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, mySender.String(), msg.Sender)
+			assert.Equal(t, gzipped, msg.WASMByteCode)
+			assert.Equal(t, &types.AllowEverybody, msg.InstantiatePermission)
+		})
+	}
+}
+
+func TestNewInstantiateMsg(t *testing.T) {
+	specs := map[string]struct {
+		src    interface{}
+		admin  sdk.AccAddress
+		exp    []byte
+		expErr bool
+	}{
+		"struct": {
+			src: struct {
+				Verifier string `json:"verifier"`
+			}{Verifier: "foo"},
+			exp: []byte(`{"verifier":"foo"}`),
+		},
+		"bytes": {
+			src: []byte(`{"verifier":"foo"}`),
+			exp: []byte(`{"verifier":"foo"}`),
+		},
+		"raw message with admin": {
+			src:   json.RawMessage(`{}`),
+			admin: mySender,
+			exp:   []byte(`{}`),
+		},
+		"invalid json bytes": {
+			src:    []byte(`not json`),
+			expErr: true,
+		},
+		"not json encodable": {
+			src:    make(chan int),
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			msg, err := NewInstantiateMsg(mySender, 1, "my label", spec.src, sdk.NewCoins(sdk.NewInt64Coin("denom", 1)), spec.admin)
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, spec.exp, msg.InitMsg)
+			assert.Equal(t, uint64(1), msg.CodeID)
+			if spec.admin != nil {
+				assert.Equal(t, spec.admin.String(), msg.Admin)
+			} else {
+				assert.Empty(t, msg.Admin)
+			}
+		})
+	}
+}
+
+func TestNewExecuteMsg(t *testing.T) {
+	msg, err := NewExecuteMsg(mySender, myContract, map[string]interface{}{"release": struct{}{}}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []byte(`{"release":{}}`), []byte(msg.Msg))
+	assert.Equal(t, myContract, msg.Contract)
+
+	_, err = NewExecuteMsg(mySender, "invalid", []byte(`{}`), nil)
+	require.Error(t, err)
+}
+
+func TestQuerySmart(t *testing.T) {
+	mock := &mockQueryClient{rsp: []byte(`{"verifier":"foo"}`)}
+	c := Client{queryClient: mock}
+
+	var got struct {
+		Verifier string `json:"verifier"`
+	}
+	err := c.QuerySmart(context.Background(), myContract, map[string]interface{}{"verifier": struct{}{}}, &got)
+	require.NoError(t, err)
+	assert.Equal(t, "foo", got.Verifier)
+	assert.Equal(t, myContract, mock.gotReq.Address)
+	assert.Equal(t, []byte(`{"verifier":{}}`), []byte(mock.gotReq.QueryData))
+}
+
+func TestMsgResponse(t *testing.T) {
+	rspBz, err := proto.Marshal(&types.MsgStoreCodeResponse{CodeID: 7})
+	require.NoError(t, err)
+	dataBz, err := proto.Marshal(&sdk.TxMsgData{Data: []*sdk.MsgData{{MsgType: "store-code", Data: rspBz}}})
+	require.NoError(t, err)
+
+	specs := map[string]struct {
+		src    sdk.TxResponse
+		expErr bool
+	}{
+		"committed": {
+			src: sdk.TxResponse{Data: hex.EncodeToString(dataBz)},
+		},
+		"no data": {
+			src:    sdk.TxResponse{},
+			expErr: true,
+		},
+		"invalid hex": {
+			src:    sdk.TxResponse{Data: "not hex"},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var got types.MsgStoreCodeResponse
+			err := msgResponse(&spec.src, 0, &got)
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, uint64(7), got.CodeID)
+		})
+	}
+}
+
+type mockQueryClient struct {
+	types.QueryClient
+	rsp    []byte
+	gotReq *types.QuerySmartContractStateRequest
+}
+
+func (m *mockQueryClient) SmartContractState(ctx context.Context, in *types.QuerySmartContractStateRequest, opts ...grpc.CallOption) (*types.QuerySmartContractStateResponse, error) {
+	m.gotReq = in
+	return &types.QuerySmartContractStateResponse{Data: m.rsp}, nil
+}
@@ -0,0 +1,53 @@
+package conformance
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/CosmWasm/wasmd/x/wasm/client/wasmclient"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var _ Chain = &ClientChain{}
+
+// ClientChain runs the conformance suite against a live node. The transactions are signed with the `from` account of
+// the client context and must be broadcast in block mode so that the results can be queried right after.
+type ClientChain struct {
+	client *wasmclient.Client
+	sender sdk.AccAddress
+}
+
+// NewClientChain constructor. See wasmclient.NewClient for the requirements on the client context and the factory.
+func NewClientChain(clientCtx client.Context, txf tx.Factory) *ClientChain {
+	return &ClientChain{
+		client: wasmclient.NewClient(clientCtx, txf),
+		sender: clientCtx.GetFromAddress(),
+	}
+}
+
+func (c ClientChain) Sender() sdk.AccAddress {
+	return c.sender
+}
+
+func (c ClientChain) StoreCode(wasm []byte) (uint64, error) {
+	codeID, _, err := c.client.StoreCode(wasm, nil)
+	return codeID, err
+}
+
+func (c ClientChain) Instantiate(codeID uint64, initMsg []byte, funds sdk.Coins) (string, error) {
+	addr, _, err := c.client.Instantiate(codeID, "conformance", initMsg, funds, nil)
+	return addr, err
+}
+
+func (c ClientChain) Execute(contract string, execMsg []byte, funds sdk.Coins) error {
+	_, _, err := c.client.Execute(contract, execMsg, funds)
+	return err
+}
+
+func (c ClientChain) QuerySmart(contract string, query []byte) ([]byte, error) {
+	var rsp json.RawMessage
+	err := c.client.QuerySmart(context.Background(), contract, query, &rsp)
+	return rsp, err
+}
@@ -0,0 +1,428 @@
+// Package conformance exercises the message encoders and query plugins of a chain end-to-end through the reflect
+// contract. The reflect contract dispatches the cosmos messages and chain queries it is given, so that forks can
+// certify that their plugin wiring works the same way as in wasmd. The suite runs in process against a keeper setup
+// or against a live node with the wasmclient package.
+package conformance
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Chain is the chain that the reflect contract is deployed to
+type Chain interface {
+	// Sender is the account that instantiates and executes the contracts
+	Sender() sdk.AccAddress
+	// StoreCode uploads the wasm byte code and returns the code id
+	StoreCode(wasm []byte) (uint64, error)
+	// Instantiate creates a contract instance and returns the contract address
+	Instantiate(codeID uint64, initMsg []byte, funds sdk.Coins) (string, error)
+	// Execute executes the contract
+	Execute(contract string, execMsg []byte, funds sdk.Coins) error
+	// QuerySmart returns the result of the smart query
+	QuerySmart(contract string, query []byte) ([]byte, error)
+}
+
+// Config for the conformance suite. Cases that need an optional value are skipped when it is not set.
+type Config struct {
+	// ReflectCode is the byte code of the reflect contract, like x/wasm/keeper/testdata/reflect.wasm
+	ReflectCode []byte
+	// Denom that the contract is funded with. The sender must own at least 100 of it.
+	Denom string
+	// Validator is the operator address of a bonded validator that accepts delegations in Denom. The staking and
+	// distribution cases require it.
+	Validator string
+	// IBCChannelID is an open ICS-20 transfer channel. The ibc transfer case requires it.
+	IBCChannelID string
+	// Custom enables the custom message and query cases. They require an encoder and a query plugin for the custom
+	// formats of the reflect contract, like in the keeper reflect tests.
+	Custom bool
+}
+
+// contractFunds are given to the reflect contract on instantiation and spent by the message cases
+const contractFunds = 100
+
+// Run deploys the reflect contract and runs all cases as sub tests
+func Run(t *testing.T, chain Chain, cfg Config) {
+	require.NotEmpty(t, cfg.ReflectCode, "reflect code")
+	require.NotEmpty(t, cfg.Denom, "denom")
+	codeID, err := chain.StoreCode(cfg.ReflectCode)
+	require.NoError(t, err)
+	contract, err := chain.Instantiate(codeID, []byte(`{}`), sdk.NewCoins(sdk.NewInt64Coin(cfg.Denom, contractFunds)))
+	require.NoError(t, err)
+	s := suite{chain: chain, cfg: cfg, codeID: codeID, contract: contract}
+
+	// queries run first as they assert on the initial state of the contract
+	t.Run("query bank balance", s.queryBankBalance)
+	t.Run("query bank all balances", s.queryBankAllBalances)
+	t.Run("query staking bonded denom", s.queryStakingBondedDenom)
+	t.Run("query staking validators", s.queryStakingValidators)
+	t.Run("query wasm smart", s.queryWasmSmart)
+	t.Run("query wasm raw", s.queryWasmRaw)
+	t.Run("query stargate", s.queryStargate)
+	t.Run("query ibc", s.queryIBC)
+	t.Run("query custom", s.queryCustom)
+
+	t.Run("msg bank send", s.msgBankSend)
+	t.Run("msg wasm instantiate and execute", s.msgWasmInstantiateAndExecute)
+	t.Run("msg stargate", s.msgStargate)
+	t.Run("msg staking and distribution", s.msgStakingAndDistribution)
+	t.Run("msg ibc transfer", s.msgIBCTransfer)
+	t.Run("msg custom", s.msgCustom)
+}
+
+type suite struct {
+	chain    Chain
+	cfg      Config
+	codeID   uint64
+	contract string
+}
+
+func (s suite) queryBankBalance(t *testing.T) {
+	var rsp wasmvmtypes.BalanceResponse
+	s.chainQuery(t, wasmvmtypes.QueryRequest{Bank: &wasmvmtypes.BankQuery{Balance: &wasmvmtypes.BalanceQuery{
+		Address: s.contract,
+		Denom:   s.cfg.Denom,
+	}}}, &rsp)
+	assert.Equal(t, wasmvmtypes.NewCoin(contractFunds, s.cfg.Denom), rsp.Amount)
+}
+
+func (s suite) queryBankAllBalances(t *testing.T) {
+	var rsp wasmvmtypes.AllBalancesResponse
+	s.chainQuery(t, wasmvmtypes.QueryRequest{Bank: &wasmvmtypes.BankQuery{AllBalances: &wasmvmtypes.AllBalancesQuery{
+		Address: s.contract,
+	}}}, &rsp)
+	assert.Equal(t, wasmvmtypes.Coins{wasmvmtypes.NewCoin(contractFunds, s.cfg.Denom)}, rsp.Amount)
+}
+
+func (s suite) queryStakingBondedDenom(t *testing.T) {
+	var rsp wasmvmtypes.BondedDenomResponse
+	s.chainQuery(t, wasmvmtypes.QueryRequest{Staking: &wasmvmtypes.StakingQuery{BondedDenom: &struct{}{}}}, &rsp)
+	assert.NotEmpty(t, rsp.Denom)
+}
+
+func (s suite) queryStakingValidators(t *testing.T) {
+	var allRsp wasmvmtypes.AllValidatorsResponse
+	s.chainQuery(t, wasmvmtypes.QueryRequest{Staking: &wasmvmtypes.StakingQuery{AllValidators: &wasmvmtypes.AllValidatorsQuery{}}}, &allRsp)
+	if s.cfg.Validator == "" {
+		t.Skip("no validator configured")
+	}
+	var found bool
+	for _, v := range allRsp.Validators {
+		found = found || v.Address == s.cfg.Validator
+	}
+	assert.True(t, found, "validator %s not in %v", s.cfg.Validator, allRsp.Validators)
+
+	var rsp wasmvmtypes.ValidatorResponse
+	s.chainQuery(t, wasmvmtypes.QueryRequest{Staking: &wasmvmtypes.StakingQuery{Validator: &wasmvmtypes.ValidatorQuery{
+		Address: s.cfg.Validator,
+	}}}, &rsp)
+	require.NotNil(t, rsp.Validator)
+	assert.Equal(t, s.cfg.Validator, rsp.Validator.Address)
+}
+
+func (s suite) queryWasmSmart(t *testing.T) {
+	var rsp ownerResponse
+	s.chainQuery(t, wasmvmtypes.QueryRequest{Wasm: &wasmvmtypes.WasmQuery{Smart: &wasmvmtypes.SmartQuery{
+		ContractAddr: s.contract,
+		Msg:          []byte(`{"owner":{}}`),
+	}}}, &rsp)
+	assert.Equal(t, s.chain.Sender().String(), rsp.Owner)
+}
+
+func (s suite) queryWasmRaw(t *testing.T) {
+	// cosmwasm_storage::Singleton prefixes the key with its 2 byte big-endian length
+	configKey := append([]byte{0, 6}, []byte("config")...)
+	var rsp ownerResponse
+	s.chainQuery(t, wasmvmtypes.QueryRequest{Wasm: &wasmvmtypes.WasmQuery{Raw: &wasmvmtypes.RawQuery{
+		ContractAddr: s.contract,
+		Key:          configKey,
+	}}}, &rsp)
+	assert.Equal(t, s.chain.Sender().String(), rsp.Owner)
+
+	// a missing key returns empty data
+	data := s.chainQueryData(t, wasmvmtypes.QueryRequest{Wasm: &wasmvmtypes.WasmQuery{Raw: &wasmvmtypes.RawQuery{
+		ContractAddr: s.contract,
+		Key:          []byte("missing"),
+	}}})
+	assert.Empty(t, data)
+}
+
+func (s suite) queryStargate(t *testing.T) {
+	bz, err := proto.Marshal(&banktypes.QueryBalanceRequest{Address: s.contract, Denom: s.cfg.Denom})
+	require.NoError(t, err)
+	data := s.chainQueryData(t, wasmvmtypes.QueryRequest{Stargate: &wasmvmtypes.StargateQuery{
+		Path: "/cosmos.bank.v1beta1.Query/Balance",
+		Data: bz,
+	}})
+	var rsp banktypes.QueryBalanceResponse
+	require.NoError(t, proto.Unmarshal(data, &rsp))
+	require.NotNil(t, rsp.Balance)
+	assert.Equal(t, sdk.NewInt64Coin(s.cfg.Denom, contractFunds), *rsp.Balance)
+}
+
+func (s suite) queryIBC(t *testing.T) {
+	// the reflect contract has no ibc entry points and therefore no port
+	var portRsp wasmvmtypes.PortIDResponse
+	s.chainQuery(t, wasmvmtypes.QueryRequest{IBC: &wasmvmtypes.IBCQuery{PortID: &wasmvmtypes.PortIDQuery{}}}, &portRsp)
+	assert.Empty(t, portRsp.PortID)
+
+	var rsp wasmvmtypes.ListChannelsResponse
+	s.chainQuery(t, wasmvmtypes.QueryRequest{IBC: &wasmvmtypes.IBCQuery{ListChannels: &wasmvmtypes.ListChannelsQuery{}}}, &rsp)
+	if s.cfg.IBCChannelID == "" {
+		return
+	}
+	var found bool
+	for _, c := range rsp.Channels {
+		found = found || c.Endpoint.ChannelID == s.cfg.IBCChannelID
+	}
+	assert.True(t, found, "channel %s not in %v", s.cfg.IBCChannelID, rsp.Channels)
+}
+
+func (s suite) queryCustom(t *testing.T) {
+	if !s.cfg.Custom {
+		t.Skip("custom cases not enabled")
+	}
+	bz, err := s.chain.QuerySmart(s.contract, []byte(`{"capitalized":{"text":"conformance"}}`))
+	require.NoError(t, err)
+	var rsp struct {
+		Text string `json:"text"`
+	}
+	require.NoError(t, json.Unmarshal(bz, &rsp))
+	assert.Equal(t, "CONFORMANCE", rsp.Text)
+}
+
+func (s suite) msgBankSend(t *testing.T) {
+	recipient := randomAddress()
+	s.reflect(t, wasmvmtypes.CosmosMsg{Bank: &wasmvmtypes.BankMsg{Send: &wasmvmtypes.SendMsg{
+		ToAddress: recipient,
+		Amount:    wasmvmtypes.Coins{wasmvmtypes.NewCoin(1, s.cfg.Denom)},
+	}}})
+	s.assertBalance(t, recipient, 1)
+}
+
+func (s suite) msgWasmInstantiateAndExecute(t *testing.T) {
+	// the contract instantiates a child contract in a sub message to learn its address from the reply
+	const replyID = 1
+	s.execute(t, reflectHandleMsg{ReflectSubCall: &reflectSubPayload{Msgs: []wasmvmtypes.SubMsg{{
+		ID: replyID,
+		Msg: wasmvmtypes.CosmosMsg{Wasm: &wasmvmtypes.WasmMsg{Instantiate: &wasmvmtypes.InstantiateMsg{
+			CodeID: s.codeID,
+			Msg:    []byte(`{}`),
+			Send:   wasmvmtypes.Coins{wasmvmtypes.NewCoin(1, s.cfg.Denom)},
+			Label:  "reflect conformance child",
+		}}},
+		ReplyOn: wasmvmtypes.ReplySuccess,
+	}}}})
+	bz, err := s.chain.QuerySmart(s.contract, mustMarshal(t, reflectQueryMsg{SubCallResult: &subCall{ID: replyID}}))
+	require.NoError(t, err)
+	var reply wasmvmtypes.Reply
+	require.NoError(t, json.Unmarshal(bz, &reply))
+	require.NotNil(t, reply.Result.Ok, reply.Result.Err)
+	child := eventAttribute(reply.Result.Ok.Events, types.EventTypeInstantiateFunds, types.AttributeKeyContractAddr)
+	require.NotEmpty(t, child, "no contract address in %v", reply.Result.Ok.Events)
+	s.assertBalance(t, child, 1)
+
+	// the contract is the owner of the child and makes it send its funds
+	recipient := randomAddress()
+	s.reflect(t, wasmvmtypes.CosmosMsg{Wasm: &wasmvmtypes.WasmMsg{Execute: &wasmvmtypes.ExecuteMsg{
+		ContractAddr: child,
+		Msg: mustMarshal(t, reflectHandleMsg{Reflect: &reflectPayload{Msgs: []wasmvmtypes.CosmosMsg{{
+			Bank: &wasmvmtypes.BankMsg{Send: &wasmvmtypes.SendMsg{
+				ToAddress: recipient,
+				Amount:    wasmvmtypes.Coins{wasmvmtypes.NewCoin(1, s.cfg.Denom)},
+			}},
+		}}}}),
+	}}})
+	s.assertBalance(t, recipient, 1)
+	s.assertBalance(t, child, 0)
+}
+
+func (s suite) msgStargate(t *testing.T) {
+	recipient := randomAddress()
+	bz, err := proto.Marshal(&banktypes.MsgSend{
+		FromAddress: s.contract,
+		ToAddress:   recipient,
+		Amount:      sdk.NewCoins(sdk.NewInt64Coin(s.cfg.Denom, 1)),
+	})
+	require.NoError(t, err)
+	s.reflect(t, wasmvmtypes.CosmosMsg{Stargate: &wasmvmtypes.StargateMsg{
+		TypeURL: "/cosmos.bank.v1beta1.MsgSend",
+		Value:   bz,
+	}})
+	s.assertBalance(t, recipient, 1)
+}
+
+func (s suite) msgStakingAndDistribution(t *testing.T) {
+	if s.cfg.Validator == "" {
+		t.Skip("no validator configured")
+	}
+	s.reflect(t, wasmvmtypes.CosmosMsg{Staking: &wasmvmtypes.StakingMsg{Delegate: &wasmvmtypes.DelegateMsg{
+		Validator: s.cfg.Validator,
+		Amount:    wasmvmtypes.NewCoin(2, s.cfg.Denom),
+	}}})
+	s.assertDelegation(t, 2)
+
+	s.reflect(t, wasmvmtypes.CosmosMsg{Distribution: &wasmvmtypes.DistributionMsg{WithdrawDelegatorReward: &wasmvmtypes.WithdrawDelegatorRewardMsg{
+		Validator: s.cfg.Validator,
+	}}})
+
+	s.reflect(t, wasmvmtypes.CosmosMsg{Staking: &wasmvmtypes.StakingMsg{Undelegate: &wasmvmtypes.UndelegateMsg{
+		Validator: s.cfg.Validator,
+		Amount:    wasmvmtypes.NewCoin(1, s.cfg.Denom),
+	}}})
+	s.assertDelegation(t, 1)
+}
+
+func (s suite) msgIBCTransfer(t *testing.T) {
+	if s.cfg.IBCChannelID == "" {
+		t.Skip("no ibc channel configured")
+	}
+	s.reflect(t, wasmvmtypes.CosmosMsg{IBC: &wasmvmtypes.IBCMsg{Transfer: &wasmvmtypes.TransferMsg{
+		ChannelID: s.cfg.IBCChannelID,
+		ToAddress: randomAddress(),
+		Amount:    wasmvmtypes.NewCoin(1, s.cfg.Denom),
+		Timeout:   wasmvmtypes.IBCTimeout{Timestamp: 1 << 62},
+	}}})
+}
+
+func (s suite) msgCustom(t *testing.T) {
+	if !s.cfg.Custom {
+		t.Skip("custom cases not enabled")
+	}
+	recipient := randomAddress()
+	// the raw custom message of the reflect contract contains a json encoded Any
+	raw, err := json.Marshal(map[string]interface{}{
+		"@type":        "/cosmos.bank.v1beta1.MsgSend",
+		"from_address": s.contract,
+		"to_address":   recipient,
+		"amount":       sdk.NewCoins(sdk.NewInt64Coin(s.cfg.Denom, 1)),
+	})
+	require.NoError(t, err)
+	s.reflect(t, wasmvmtypes.CosmosMsg{Custom: mustMarshal(t, reflectCustomMsg{Raw: raw})})
+	s.assertBalance(t, recipient, 1)
+}
+
+// reflect executes the contract with the messages to dispatch
+func (s suite) reflect(t *testing.T, msgs ...wasmvmtypes.CosmosMsg) {
+	s.execute(t, reflectHandleMsg{Reflect: &reflectPayload{Msgs: msgs}})
+}
+
+func (s suite) execute(t *testing.T, msg reflectHandleMsg) {
+	require.NoError(t, s.chain.Execute(s.contract, mustMarshal(t, msg), nil))
+}
+
+func (s suite) assertBalance(t *testing.T, addr string, exp uint64) {
+	var rsp wasmvmtypes.BalanceResponse
+	s.chainQuery(t, wasmvmtypes.QueryRequest{Bank: &wasmvmtypes.BankQuery{Balance: &wasmvmtypes.BalanceQuery{
+		Address: addr,
+		Denom:   s.cfg.Denom,
+	}}}, &rsp)
+	assert.Equal(t, wasmvmtypes.NewCoin(exp, s.cfg.Denom), rsp.Amount)
+}
+
+func (s suite) assertDelegation(t *testing.T, exp uint64) {
+	var rsp wasmvmtypes.DelegationResponse
+	s.chainQuery(t, wasmvmtypes.QueryRequest{Staking: &wasmvmtypes.StakingQuery{Delegation: &wasmvmtypes.DelegationQuery{
+		Delegator: s.contract,
+		Validator: s.cfg.Validator,
+	}}}, &rsp)
+	require.NotNil(t, rsp.Delegation)
+	assert.Equal(t, wasmvmtypes.NewCoin(exp, s.cfg.Denom), rsp.Delegation.Amount)
+
+	var allRsp wasmvmtypes.AllDelegationsResponse
+	s.chainQuery(t, wasmvmtypes.QueryRequest{Staking: &wasmvmtypes.StakingQuery{AllDelegations: &wasmvmtypes.AllDelegationsQuery{
+		Delegator: s.contract,
+	}}}, &allRsp)
+	require.Len(t, allRsp.Delegations, 1)
+	assert.Equal(t, wasmvmtypes.NewCoin(exp, s.cfg.Denom), allRsp.Delegations[0].Amount)
+}
+
+// chainQuery lets the reflect contract run the query and decodes the JSON result into the given pointer
+func (s suite) chainQuery(t *testing.T, req wasmvmtypes.QueryRequest, rsp interface{}) {
+	require.NoError(t, json.Unmarshal(s.chainQueryData(t, req), rsp))
+}
+
+// chainQueryData lets the reflect contract run the query and returns the raw result
+func (s suite) chainQueryData(t *testing.T, req wasmvmtypes.QueryRequest) []byte {
+	bz, err := s.chain.QuerySmart(s.contract, mustMarshal(t, reflectQueryMsg{Chain: &chainQuery{Request: &req}}))
+	require.NoError(t, err)
+	var rsp chainResponse
+	require.NoError(t, json.Unmarshal(bz, &rsp))
+	return rsp.Data
+}
+
+// eventAttribute returns the value of the first attribute with the key in an event of the type
+func eventAttribute(events wasmvmtypes.Events, eventType, key string) string {
+	for _, e := range events {
+		if e.Type != eventType {
+			continue
+		}
+		for _, a := range e.Attributes {
+			if a.Key == key {
+				return a.Value
+			}
+		}
+	}
+	return ""
+}
+
+func randomAddress() string {
+	return sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address()).String()
+}
+
+func mustMarshal(t *testing.T, o interface{}) []byte {
+	bz, err := json.Marshal(o)
+	require.NoError(t, err)
+	return bz
+}
+
+// reflectHandleMsg is the execute message of the reflect contract
+type reflectHandleMsg struct {
+	Reflect        *reflectPayload    `json:"reflect_msg,omitempty"`
+	ReflectSubCall *reflectSubPayload `json:"reflect_sub_call,omitempty"`
+}
+
+type reflectPayload struct {
+	Msgs []wasmvmtypes.CosmosMsg `json:"msgs"`
+}
+
+type reflectSubPayload struct {
+	Msgs []wasmvmtypes.SubMsg `json:"msgs"`
+}
+
+// reflectCustomMsg is the custom message of the reflect contract
+type reflectCustomMsg struct {
+	Raw []byte `json:"raw,omitempty"`
+}
+
+// reflectQueryMsg is the query message of the reflect contract
+type reflectQueryMsg struct {
+	Chain         *chainQuery `json:"chain,omitempty"`
+	SubCallResult *subCall    `json:"sub_call_result,omitempty"`
+}
+
+type subCall struct {
+	ID uint64 `json:"id"`
+}
+
+type chainQuery struct {
+	Request *wasmvmtypes.QueryRequest `json:"request"`
+}
+
+type chainResponse struct {
+	Data []byte `json:"data"`
+}
+
+type ownerResponse struct {
+	Owner string `json:"owner"`
+}
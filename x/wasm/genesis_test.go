@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/CosmWasm/wasmd/x/wasm/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -95,3 +97,35 @@ func TestInitGenesis(t *testing.T) {
 		Funder:      creator.String(),
 	})
 }
+
+func TestInitGenesisGenMsgEvents(t *testing.T) {
+	data := setupTest(t)
+	creator := createFakeFundedAccount(t, data.ctx, data.acctKeeper, data.bankKeeper, sdk.NewCoins(sdk.NewInt64Coin("denom", 100000)))
+	_, _, bob := keyPubAddr()
+	initMsgBz, err := json.Marshal(initMsg{Verifier: creator, Beneficiary: bob})
+	require.NoError(t, err)
+	genState := GenesisState{
+		Params: DefaultParams(),
+		GenMsgs: []types.GenesisState_GenMsgs{
+			{Sum: &types.GenesisState_GenMsgs_StoreCode{StoreCode: &MsgStoreCode{Sender: creator.String(), WASMByteCode: testContract}}},
+			{Sum: &types.GenesisState_GenMsgs_InstantiateContract{InstantiateContract: &MsgInstantiateContract{Sender: creator.String(), CodeID: firstCodeID, Label: "demo contract", InitMsg: initMsgBz}}},
+		},
+	}
+	em := sdk.NewEventManager()
+
+	// when
+	_, err = InitGenesis(data.ctx.WithEventManager(em), &data.keeper, genState, data.stakingKeeper, data.module.Route().Handler())
+
+	// then
+	require.NoError(t, err)
+	var instantiated bool
+	for _, e := range em.Events() {
+		if e.Type != sdk.EventTypeMessage || len(e.Attributes) != 8 {
+			continue
+		}
+		instantiated = true
+		assertAttribute(t, "admin", "", e.Attributes[6])
+		assertAttribute(t, "immutable", "true", e.Attributes[7])
+	}
+	assert.True(t, instantiated, prettyEvents(em.ABCIEvents()))
+}
@@ -1,50 +1,76 @@
 package wasm
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"github.com/CosmWasm/wasmd/x/wasm/keeper"
 	"github.com/CosmWasm/wasmd/x/wasm/types"
+	gogogrpc "github.com/gogo/protobuf/grpc"
 	"github.com/gogo/protobuf/proto"
 	abci "github.com/tendermint/tendermint/abci/types"
+	"google.golang.org/grpc"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 )
 
-// NewHandler returns a handler for "wasm" type messages.
+// NewHandler returns a handler for "wasm" type messages. It is a compatibility shim for the legacy message routing
+// that dispatches to the Msg service methods so that a message is implemented in the Msg server only.
 func NewHandler(k types.ContractOpsKeeper) sdk.Handler {
-	msgServer := keeper.NewMsgServerImpl(k)
+	routes := make(legacyMsgRoutes)
+	types.RegisterMsgServer(routes, keeper.NewMsgServerImpl(k))
 
 	return func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
-		ctx = ctx.WithEventManager(sdk.NewEventManager())
-
-		var (
-			res proto.Message
-			err error
-		)
-		switch msg := msg.(type) {
-		case *MsgStoreCode:
-			res, err = msgServer.StoreCode(sdk.WrapSDKContext(ctx), msg)
-		case *MsgInstantiateContract:
-			res, err = msgServer.InstantiateContract(sdk.WrapSDKContext(ctx), msg)
-		case *MsgExecuteContract:
-			res, err = msgServer.ExecuteContract(sdk.WrapSDKContext(ctx), msg)
-		case *MsgMigrateContract:
-			res, err = msgServer.MigrateContract(sdk.WrapSDKContext(ctx), msg)
-		case *MsgUpdateAdmin:
-			res, err = msgServer.UpdateAdmin(sdk.WrapSDKContext(ctx), msg)
-		case *MsgClearAdmin:
-			res, err = msgServer.ClearAdmin(sdk.WrapSDKContext(ctx), msg)
-		default:
+		route, ok := routes[proto.MessageName(msg)]
+		if !ok {
 			errMsg := fmt.Sprintf("unrecognized wasm message type: %T", msg)
 			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, errMsg)
 		}
+		ctx = ctx.WithEventManager(sdk.NewEventManager())
+		res, err := route(ctx, msg)
 
 		ctx = ctx.WithEventManager(filterMessageEvents(ctx))
 		return sdk.WrapServiceResult(ctx, res, err)
 	}
 }
 
+// errRequestTypeCaptured stops a Msg service method call once the request type is known
+var errRequestTypeCaptured = errors.New("request type captured")
+
+// legacyMsgRoutes maps the request type names to the Msg service methods. It implements the gRPC server
+// registration so that the routes are set up from the generated service description.
+type legacyMsgRoutes map[string]func(ctx sdk.Context, msg sdk.Msg) (proto.Message, error)
+
+var _ gogogrpc.Server = legacyMsgRoutes{}
+
+// RegisterService adds a route for each method of the service
+func (r legacyMsgRoutes) RegisterService(sd *grpc.ServiceDesc, srv interface{}) {
+	for _, method := range sd.Methods {
+		methodHandler := method.Handler
+		var requestTypeName string
+		_, _ = methodHandler(srv, context.Background(), func(req interface{}) error {
+			requestTypeName = proto.MessageName(req.(proto.Message))
+			return errRequestTypeCaptured
+		}, nil)
+		if _, exists := r[requestTypeName]; exists {
+			panic(fmt.Sprintf("duplicate route for %s", requestTypeName))
+		}
+		r[requestTypeName] = func(ctx sdk.Context, msg sdk.Msg) (proto.Message, error) {
+			interceptor := func(goCtx context.Context, _ interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+				return handler(goCtx, msg)
+			}
+			res, err := methodHandler(srv, sdk.WrapSDKContext(ctx), noopDecoder, interceptor)
+			if err != nil {
+				return nil, err
+			}
+			return res.(proto.Message), nil
+		}
+	}
+}
+
+func noopDecoder(_ interface{}) error { return nil }
+
 // filterMessageEvents returns the same events with all of type == EventTypeMessage removed except
 // for wasm message types.
 // this is so only our top-level message event comes through
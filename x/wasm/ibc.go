@@ -38,7 +38,7 @@ func (i IBCHandler) OnChanOpenInit(
 	if err := ValidateChannelParams(channelID); err != nil {
 		return err
 	}
-	contractAddr, err := ContractFromPortID(portID)
+	contractAddr, err := i.keeper.ContractByPortID(ctx, portID)
 	if err != nil {
 		return sdkerrors.Wrapf(err, "contract port id")
 	}
@@ -60,7 +60,9 @@ func (i IBCHandler) OnChanOpenInit(
 	return nil
 }
 
-// OnChanOpenTry implements the IBCModule interface
+// OnChanOpenTry implements the IBCModule interface. The contract receives the proposed version and the counterparty
+// version and can only accept or reject them by returning an error. Proposing a different version is not supported by
+// the wasmvm and IBC core versions in use.
 func (i IBCHandler) OnChanOpenTry(
 	ctx sdk.Context,
 	order channeltypes.Order,
@@ -75,7 +77,7 @@ func (i IBCHandler) OnChanOpenTry(
 		return err
 	}
 
-	contractAddr, err := ContractFromPortID(portID)
+	contractAddr, err := i.keeper.ContractByPortID(ctx, portID)
 	if err != nil {
 		return sdkerrors.Wrapf(err, "contract port id")
 	}
@@ -104,13 +106,14 @@ func (i IBCHandler) OnChanOpenTry(
 	return nil
 }
 
-// OnChanOpenAck implements the IBCModule interface
+// OnChanOpenAck implements the IBCModule interface. The counterparty version that was negotiated on the other chain is
+// passed to the contract with the channel so that it can reject the channel on a version mismatch.
 func (i IBCHandler) OnChanOpenAck(
 	ctx sdk.Context,
 	portID, channelID string,
 	counterpartyVersion string,
 ) error {
-	contractAddr, err := ContractFromPortID(portID)
+	contractAddr, err := i.keeper.ContractByPortID(ctx, portID)
 	if err != nil {
 		return sdkerrors.Wrapf(err, "contract port id")
 	}
@@ -123,7 +126,7 @@ func (i IBCHandler) OnChanOpenAck(
 
 // OnChanOpenConfirm implements the IBCModule interface
 func (i IBCHandler) OnChanOpenConfirm(ctx sdk.Context, portID, channelID string) error {
-	contractAddr, err := ContractFromPortID(portID)
+	contractAddr, err := i.keeper.ContractByPortID(ctx, portID)
 	if err != nil {
 		return sdkerrors.Wrapf(err, "contract port id")
 	}
@@ -136,7 +139,7 @@ func (i IBCHandler) OnChanOpenConfirm(ctx sdk.Context, portID, channelID string)
 
 // OnChanCloseInit implements the IBCModule interface
 func (i IBCHandler) OnChanCloseInit(ctx sdk.Context, portID, channelID string) error {
-	contractAddr, err := ContractFromPortID(portID)
+	contractAddr, err := i.keeper.ContractByPortID(ctx, portID)
 	if err != nil {
 		return sdkerrors.Wrapf(err, "contract port id")
 	}
@@ -157,7 +160,7 @@ func (i IBCHandler) OnChanCloseInit(ctx sdk.Context, portID, channelID string) e
 // OnChanCloseConfirm implements the IBCModule interface
 func (i IBCHandler) OnChanCloseConfirm(ctx sdk.Context, portID, channelID string) error {
 	// counterparty has closed the channel
-	contractAddr, err := ContractFromPortID(portID)
+	contractAddr, err := i.keeper.ContractByPortID(ctx, portID)
 	if err != nil {
 		return sdkerrors.Wrapf(err, "contract port id")
 	}
@@ -191,7 +194,7 @@ func (i IBCHandler) OnRecvPacket(
 	ctx sdk.Context,
 	packet channeltypes.Packet,
 ) (*sdk.Result, []byte, error) {
-	contractAddr, err := ContractFromPortID(packet.DestinationPort)
+	contractAddr, err := i.keeper.ContractByPortID(ctx, packet.DestinationPort)
 	if err != nil {
 		return nil, nil, sdkerrors.Wrapf(err, "contract port id")
 	}
@@ -207,7 +210,7 @@ func (i IBCHandler) OnRecvPacket(
 
 // OnAcknowledgementPacket implements the IBCModule interface
 func (i IBCHandler) OnAcknowledgementPacket(ctx sdk.Context, packet channeltypes.Packet, acknowledgement []byte) (*sdk.Result, error) {
-	contractAddr, err := ContractFromPortID(packet.SourcePort)
+	contractAddr, err := i.keeper.ContractByPortID(ctx, packet.SourcePort)
 	if err != nil {
 		return nil, sdkerrors.Wrapf(err, "contract port id")
 	}
@@ -228,7 +231,7 @@ func (i IBCHandler) OnAcknowledgementPacket(ctx sdk.Context, packet channeltypes
 
 // OnTimeoutPacket implements the IBCModule interface
 func (i IBCHandler) OnTimeoutPacket(ctx sdk.Context, packet channeltypes.Packet) (*sdk.Result, error) {
-	contractAddr, err := ContractFromPortID(packet.SourcePort)
+	contractAddr, err := i.keeper.ContractByPortID(ctx, packet.SourcePort)
 	if err != nil {
 		return nil, sdkerrors.Wrapf(err, "contract port id")
 	}
@@ -79,3 +79,22 @@ func IBCPacketFixture(mutators ...func(p *channeltypes.Packet)) channeltypes.Pac
 	}
 	return r
 }
+
+func TestToWasmVMChannel(t *testing.T) {
+	channelInfo := channeltypes.Channel{
+		State:          channeltypes.TRYOPEN,
+		Ordering:       channeltypes.UNORDERED,
+		Counterparty:   channeltypes.NewCounterparty("otherPortID", "otherChannelID"),
+		ConnectionHops: []string{"myConnectionID"},
+		Version:        "my-version",
+	}
+	exp := wasmvmtypes.IBCChannel{
+		Endpoint:             wasmvmtypes.IBCEndpoint{PortID: "myPortID", ChannelID: "myChannelID"},
+		CounterpartyEndpoint: wasmvmtypes.IBCEndpoint{PortID: "otherPortID", ChannelID: "otherChannelID"},
+		Order:                channeltypes.UNORDERED.String(),
+		Version:              "my-version",
+		CounterpartyVersion:  "other-version",
+		ConnectionID:         "myConnectionID",
+	}
+	assert.Equal(t, exp, toWasmVMChannel("myPortID", "myChannelID", channelInfo, "other-version"))
+}
@@ -359,6 +359,59 @@ func (coord *Coordinator) IncrementTimeBy(increment time.Duration) {
 	}
 }
 
+// AdvanceTime moves the block time of all chains forward by the given duration and commits a block on the
+// provided chains so that the new time is part of their last header and can be proven to a counterparty.
+func (coord *Coordinator) AdvanceTime(d time.Duration, chains ...*TestChain) {
+	coord.IncrementTimeBy(d)
+	coord.CommitBlock(chains...)
+}
+
+// AdvanceBlockHeight commits blocks on the chain until the last committed block height is at least the
+// given height.
+func (coord *Coordinator) AdvanceBlockHeight(chain *TestChain, height uint64) {
+	for chain.LastHeader.GetHeight().GetRevisionHeight() < height {
+		coord.CommitBlock(chain)
+	}
+}
+
+// TimeoutPendingPackets advances the counterparty chain beyond the timeouts of all packets pending on the source
+// chain and relays them back as timeouts so that the source side can revert any operation. It fails for packets
+// that can not timeout on the counterparty chain.
+func (coord *Coordinator) TimeoutPendingPackets(src, dest *TestChain, srcClientID, dstClientID string) error {
+	toTimeout := src.PendingSendPackets
+	src.PendingSendPackets = nil
+	for _, packet := range toTimeout {
+		if err := coord.advancePastTimeout(dest, packet); err != nil {
+			return err
+		}
+		if err := coord.UpdateClient(src, dest, srcClientID, exported.Tendermint); err != nil {
+			return err
+		}
+		if err := coord.TimeoutPacket(src, dest, dstClientID, packet); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// advancePastTimeout commits blocks on the chain until the packet's timeout height and timestamp are reached.
+func (coord *Coordinator) advancePastTimeout(chain *TestChain, packet channeltypes.Packet) error {
+	if packet.TimeoutHeight.IsZero() && packet.TimeoutTimestamp == 0 {
+		return fmt.Errorf("packet %d has no timeout", packet.Sequence)
+	}
+	if !packet.TimeoutHeight.IsZero() {
+		lastHeight := chain.LastHeader.GetHeight()
+		if packet.TimeoutHeight.GetRevisionNumber() != lastHeight.GetRevisionNumber() {
+			return fmt.Errorf("packet %d timeout height %s not reachable from %s", packet.Sequence, packet.TimeoutHeight, lastHeight)
+		}
+		coord.AdvanceBlockHeight(chain, packet.TimeoutHeight.GetRevisionHeight())
+	}
+	if now := uint64(chain.LastHeader.GetTime().UnixNano()); now < packet.TimeoutTimestamp {
+		coord.AdvanceTime(time.Duration(packet.TimeoutTimestamp-now), chain)
+	}
+	return nil
+}
+
 // SendMsg delivers a single provided message to the chain. The counterparty
 // client is update with the new source consensus state.
 func (coord *Coordinator) SendMsg(source, counterparty *TestChain, counterpartyClientID string, msg sdk.Msg) error {
@@ -0,0 +1,39 @@
+package keeper
+
+import (
+	"crypto/sha256"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// AddressGenerator returns the address for a new contract instance. The instance id is unique for the chain.
+type AddressGenerator func(ctx sdk.Context, codeID, instanceID uint64) sdk.AccAddress
+
+// ClassicAddressGenerator is the default generator of the 20 byte contract addresses, see BuildContractAddress
+func ClassicAddressGenerator() AddressGenerator {
+	return func(_ sdk.Context, codeID, instanceID uint64) sdk.AccAddress {
+		return BuildContractAddress(codeID, instanceID)
+	}
+}
+
+// ModuleAccountAddressGenerator generates 32 byte contract addresses, see BuildModuleAccountContractAddress
+func ModuleAccountAddressGenerator() AddressGenerator {
+	return func(_ sdk.Context, codeID, instanceID uint64) sdk.AccAddress {
+		return BuildModuleAccountContractAddress(codeID, instanceID)
+	}
+}
+
+// BuildModuleAccountContractAddress builds a 32 byte contract address the way module account addresses are derived:
+// `sha256(sha256("module") | "wasm" | 0x0 | codeID | instanceID)` with the ids as 8 byte big endian. The addresses are
+// collision resistant with the accounts of other address types.
+func BuildModuleAccountContractAddress(codeID, instanceID uint64) sdk.AccAddress {
+	typ := sha256.Sum256([]byte("module"))
+	h := sha256.New()
+	h.Write(typ[:])
+	h.Write([]byte(types.ModuleName))
+	h.Write([]byte{0})
+	h.Write(sdk.Uint64ToBigEndian(codeID))
+	h.Write(sdk.Uint64ToBigEndian(instanceID))
+	return h.Sum(nil)
+}
@@ -0,0 +1,72 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withLongAddressVerifier sets the address verifier of the sdk config to accept 32 byte addresses until the test
+// is completed
+func withLongAddressVerifier(t testing.TB) {
+	config := sdk.GetConfig()
+	prev := config.GetAddressVerifier()
+	config.SetAddressVerifier(types.VerifyAddressLen())
+	t.Cleanup(func() { config.SetAddressVerifier(prev) })
+}
+
+func TestBuildModuleAccountContractAddress(t *testing.T) {
+	addr := BuildModuleAccountContractAddress(1, 1)
+	assert.Len(t, addr, types.ContractAddrLen)
+	assert.Equal(t, addr, BuildModuleAccountContractAddress(1, 1))
+	assert.NotEqual(t, addr, BuildModuleAccountContractAddress(1, 2))
+	assert.NotEqual(t, addr, BuildModuleAccountContractAddress(2, 1))
+	// ids are not concatenated ambiguously
+	assert.NotEqual(t, BuildModuleAccountContractAddress(1<<32, 0), BuildModuleAccountContractAddress(0, 1<<32))
+}
+
+func TestInstantiateWithModuleAccountAddressGenerator(t *testing.T) {
+	withLongAddressVerifier(t)
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithAddressGenerator(ModuleAccountAddressGenerator()))
+	k := keepers.WasmKeeper
+	mock := wasmtesting.MockWasmer{}
+	wasmtesting.MakeIBCInstantiable(&mock)
+
+	// when
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+
+	// then
+	contractAddr := example.Contract
+	require.Len(t, contractAddr, types.ContractAddrLen)
+	assert.Equal(t, BuildModuleAccountContractAddress(example.CodeID, 1), contractAddr)
+	contractInfo := k.GetContractInfo(ctx, contractAddr)
+	require.NotNil(t, contractInfo)
+	assert.Len(t, k.GetContractHistory(ctx, contractAddr), 1)
+	var listed []sdk.AccAddress
+	k.IterateContractsByCode(ctx, example.CodeID, func(addr sdk.AccAddress) bool {
+		listed = append(listed, addr)
+		return false
+	})
+	assert.Equal(t, []sdk.AccAddress{contractAddr}, listed)
+
+	// and the address can be used by clients
+	rsp, err := Querier(k).ContractInfo(sdk.WrapSDKContext(ctx), &types.QueryContractInfoRequest{Address: contractAddr.String()})
+	require.NoError(t, err)
+	assert.Equal(t, contractAddr.String(), rsp.Address)
+	// and contracts
+	human, _, err := humanAddress(contractAddr)
+	require.NoError(t, err)
+	canon, _, err := canonicalAddress(human)
+	require.NoError(t, err)
+	assert.Equal(t, []byte(contractAddr), canon)
+
+	// and the ibc port is bound
+	assert.Equal(t, PortIDForContract(contractAddr), contractInfo.IBCPortID)
+	gotAddr, err := k.ContractByPortID(ctx, contractInfo.IBCPortID)
+	require.NoError(t, err)
+	assert.Equal(t, contractAddr, gotAddr)
+}
@@ -0,0 +1,83 @@
+package keeper
+
+import (
+	"context"
+	"crypto/subtle"
+	"net"
+	"strings"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// adminTokenHeader is the gRPC metadata key that carries the admin token as `Bearer <token>`
+const adminTokenHeader = "authorization"
+
+var _ types.AdminServer = adminServer{}
+
+type adminServer struct {
+	keeper *Keeper
+}
+
+// NewAdminServer returns the node local admin service. The changes apply to this node only.
+func NewAdminServer(k *Keeper) types.AdminServer {
+	return adminServer{keeper: k}
+}
+
+func (a adminServer) SmartQueryGasLimit(ctx context.Context, req *types.AdminSmartQueryGasLimitRequest) (*types.AdminSmartQueryGasLimitResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	return &types.AdminSmartQueryGasLimitResponse{GasLimit: a.keeper.QueryGasLimit()}, nil
+}
+
+func (a adminServer) SetSmartQueryGasLimit(ctx context.Context, req *types.AdminSetSmartQueryGasLimitRequest) (*types.AdminSetSmartQueryGasLimitResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if req.GasLimit == 0 {
+		return nil, status.Error(codes.InvalidArgument, "gas limit must not be 0")
+	}
+	prev := a.keeper.SetQueryGasLimit(req.GasLimit)
+	return &types.AdminSetSmartQueryGasLimitResponse{PreviousGasLimit: prev}, nil
+}
+
+// NewAdminGRPCServer returns a gRPC server with the admin service registered. All calls must carry the token as
+// `authorization: Bearer <token>` metadata.
+func NewAdminGRPCServer(k *Keeper, token string) (*grpc.Server, error) {
+	if len(token) == 0 {
+		return nil, sdkerrors.Wrap(types.ErrEmpty, "admin token")
+	}
+	srv := grpc.NewServer(grpc.UnaryInterceptor(adminTokenInterceptor(token)))
+	types.RegisterAdminServer(srv, NewAdminServer(k))
+	return srv, nil
+}
+
+// ListenAdminGRPC opens the listener for the admin gRPC server. Only loopback addresses are accepted so that the
+// service can not be reached from outside the node.
+func ListenAdminGRPC(address string) (net.Listener, error) {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, sdkerrors.Wrap(types.ErrInvalid, err.Error())
+	}
+	if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		return nil, sdkerrors.Wrapf(types.ErrInvalid, "admin address must be a loopback address: %s", address)
+	}
+	return net.Listen("tcp", address)
+}
+
+func adminTokenInterceptor(token string) grpc.UnaryServerInterceptor {
+	exp := []byte("Bearer " + token)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		got := strings.Join(md.Get(adminTokenHeader), "")
+		if subtle.ConstantTimeCompare([]byte(got), exp) != 1 {
+			return nil, status.Error(codes.Unauthenticated, "invalid admin token")
+		}
+		return handler(ctx, req)
+	}
+}
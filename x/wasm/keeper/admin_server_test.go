@@ -0,0 +1,126 @@
+package keeper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestAdminGRPCServer(t *testing.T) {
+	_, keepers := CreateTestInput(t, false, SupportedFeatures)
+	k := keepers.WasmKeeper
+	srv, err := NewAdminGRPCServer(k, "my-token")
+	require.NoError(t, err)
+	lis, err := ListenAdminGRPC("127.0.0.1:0")
+	require.NoError(t, err)
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+	client := types.NewAdminClient(conn)
+
+	specs := map[string]struct {
+		token   string
+		expCode codes.Code
+	}{
+		"valid token": {
+			token:   "Bearer my-token",
+			expCode: codes.OK,
+		},
+		"invalid token": {
+			token:   "Bearer other-token",
+			expCode: codes.Unauthenticated,
+		},
+		"token without bearer": {
+			token:   "my-token",
+			expCode: codes.Unauthenticated,
+		},
+		"no token": {
+			expCode: codes.Unauthenticated,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			ctx := context.Background()
+			if spec.token != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, adminTokenHeader, spec.token)
+			}
+			_, err := client.SmartQueryGasLimit(ctx, &types.AdminSmartQueryGasLimitRequest{})
+			assert.Equal(t, spec.expCode, status.Code(err), "got %+v", err)
+		})
+	}
+
+	// when set
+	ctx := metadata.AppendToOutgoingContext(context.Background(), adminTokenHeader, "Bearer my-token")
+	rsp, err := client.SetSmartQueryGasLimit(ctx, &types.AdminSetSmartQueryGasLimitRequest{GasLimit: 1234})
+	require.NoError(t, err)
+
+	// then
+	assert.Equal(t, types.DefaultWasmConfig().SmartQueryGasLimit, rsp.PreviousGasLimit)
+	assert.Equal(t, uint64(1234), k.QueryGasLimit())
+	assert.Equal(t, uint64(1234), Querier(k).queryGasLimit.get())
+	gotRsp, err := client.SmartQueryGasLimit(ctx, &types.AdminSmartQueryGasLimitRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1234), gotRsp.GasLimit)
+
+	// and 0 is rejected
+	_, err = client.SetSmartQueryGasLimit(ctx, &types.AdminSetSmartQueryGasLimitRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "got %+v", err)
+	assert.Equal(t, uint64(1234), k.QueryGasLimit())
+}
+
+func TestNewAdminGRPCServerRequiresToken(t *testing.T) {
+	_, keepers := CreateTestInput(t, false, SupportedFeatures)
+	_, err := NewAdminGRPCServer(keepers.WasmKeeper, "")
+	assert.True(t, types.ErrEmpty.Is(err), "got %+v", err)
+}
+
+func TestListenAdminGRPC(t *testing.T) {
+	specs := map[string]struct {
+		src    string
+		expErr bool
+	}{
+		"loopback ip": {
+			src: "127.0.0.1:0",
+		},
+		"localhost": {
+			src: "localhost:0",
+		},
+		"all interfaces": {
+			src:    "0.0.0.0:0",
+			expErr: true,
+		},
+		"empty host": {
+			src:    ":0",
+			expErr: true,
+		},
+		"other host": {
+			src:    "example.com:0",
+			expErr: true,
+		},
+		"no port": {
+			src:    "127.0.0.1",
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			lis, err := ListenAdminGRPC(spec.src)
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, lis.Close())
+		})
+	}
+}
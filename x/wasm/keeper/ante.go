@@ -0,0 +1,82 @@
+package keeper
+
+import (
+	"errors"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// maxWasmCodeSizeSource is a subset of the keeper that provides the max wasm code size param
+type maxWasmCodeSizeSource interface {
+	GetMaxWasmCodeSize(ctx sdk.Context) uint64
+}
+
+// LimitWasmCodeSizeDecorator ante decorator to reject oversized wasm code in the mempool.
+// The raw wasm byte code and the uncompressed content of gzipped code must not exceed the
+// `MaxWasmCodeSize` param. This check is only executed in CheckTx so that the node can drop
+// cheap spam transactions with giant blobs before they enter the mempool. The decorator should be
+// chained after the fee deduction and signature verification so that only paid and signed txs are unpacked.
+type LimitWasmCodeSizeDecorator struct {
+	source maxWasmCodeSizeSource
+}
+
+// NewLimitWasmCodeSizeDecorator constructor
+func NewLimitWasmCodeSizeDecorator(source maxWasmCodeSizeSource) *LimitWasmCodeSizeDecorator {
+	if source == nil {
+		panic("source must not be nil")
+	}
+	return &LimitWasmCodeSizeDecorator{source: source}
+}
+
+// AnteHandle rejects MsgStoreCode with wasm code exceeding the max size
+func (d LimitWasmCodeSizeDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	if !ctx.IsCheckTx() {
+		return next(ctx, tx, simulate)
+	}
+	var maxSize uint64
+	for _, m := range tx.GetMsgs() {
+		msg, ok := m.(*types.MsgStoreCode)
+		if !ok {
+			continue
+		}
+		if maxSize == 0 {
+			maxSize = d.source.GetMaxWasmCodeSize(ctx)
+		}
+		// payloads that exceed the limit already in their compressed form are rejected without unpacking
+		if uint64(len(msg.WASMByteCode)) > maxSize {
+			return ctx, sdkerrors.Wrapf(types.ErrLimit, "wasm code exceeds max size of %d bytes", maxSize)
+		}
+		switch _, err := uncompress(msg.WASMByteCode, maxSize); {
+		case errors.Is(err, types.ErrLimit):
+			return ctx, sdkerrors.Wrapf(types.ErrLimit, "wasm code exceeds max size of %d bytes", maxSize)
+		case err != nil:
+			return ctx, sdkerrors.Wrap(types.ErrInvalid, err.Error())
+		}
+	}
+	return next(ctx, tx, simulate)
+}
+
+// GovOnlyDecorator ante decorator for permissioned chains that rejects txs with a MsgStoreCode or
+// MsgInstantiateContract before fees are deducted. Code can only be stored and contracts can only be instantiated
+// via governance proposals, see GovOnlyAuthorizationPolicy that guards the messages that contracts dispatch.
+type GovOnlyDecorator struct{}
+
+// NewGovOnlyDecorator constructor
+func NewGovOnlyDecorator() *GovOnlyDecorator {
+	return &GovOnlyDecorator{}
+}
+
+// AnteHandle rejects MsgStoreCode and MsgInstantiateContract
+func (d GovOnlyDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	for _, m := range tx.GetMsgs() {
+		switch m.(type) {
+		case *types.MsgStoreCode:
+			return ctx, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "can not create code")
+		case *types.MsgInstantiateContract:
+			return ctx, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "can not instantiate")
+		}
+	}
+	return next(ctx, tx, simulate)
+}
@@ -0,0 +1,152 @@
+package keeper
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+func TestLimitWasmCodeSizeDecorator(t *testing.T) {
+	const maxSize = 1000
+	specs := map[string]struct {
+		msgs       []sdk.Msg
+		checkTx    bool
+		expErr     *sdkerrors.Error
+		expNextRun bool
+	}{
+		"raw code within limit": {
+			msgs:       []sdk.Msg{&types.MsgStoreCode{WASMByteCode: bytes.Repeat([]byte{0x1}, maxSize)}},
+			checkTx:    true,
+			expNextRun: true,
+		},
+		"raw code exceeds limit": {
+			msgs:    []sdk.Msg{&types.MsgStoreCode{WASMByteCode: bytes.Repeat([]byte{0x1}, maxSize+1)}},
+			checkTx: true,
+			expErr:  types.ErrLimit,
+		},
+		"gzipped code within limit": {
+			msgs:       []sdk.Msg{&types.MsgStoreCode{WASMByteCode: asGzip(bytes.Repeat([]byte{0x1}, maxSize))}},
+			checkTx:    true,
+			expNextRun: true,
+		},
+		"gzipped code expands beyond limit": {
+			msgs:    []sdk.Msg{&types.MsgStoreCode{WASMByteCode: asGzip(bytes.Repeat([]byte{0x1}, maxSize+1))}},
+			checkTx: true,
+			expErr:  types.ErrLimit,
+		},
+		"broken gzip": {
+			msgs:    []sdk.Msg{&types.MsgStoreCode{WASMByteCode: append(gzipIdent, byte(0x1))}},
+			checkTx: true,
+			expErr:  types.ErrInvalid,
+		},
+		"broken gzip exceeds limit": {
+			msgs:    []sdk.Msg{&types.MsgStoreCode{WASMByteCode: append(gzipIdent, bytes.Repeat([]byte{0x1}, maxSize)...)}},
+			checkTx: true,
+			expErr:  types.ErrLimit,
+		},
+		"any oversized store code in tx": {
+			msgs: []sdk.Msg{
+				&types.MsgExecuteContract{},
+				&types.MsgStoreCode{WASMByteCode: []byte{0x1}},
+				&types.MsgStoreCode{WASMByteCode: bytes.Repeat([]byte{0x1}, maxSize+1)},
+			},
+			checkTx: true,
+			expErr:  types.ErrLimit,
+		},
+		"other messages ignored": {
+			msgs:       []sdk.Msg{&types.MsgExecuteContract{Msg: bytes.Repeat([]byte{0x1}, maxSize+1)}},
+			checkTx:    true,
+			expNextRun: true,
+		},
+		"skipped in deliver tx": {
+			msgs:       []sdk.Msg{&types.MsgStoreCode{WASMByteCode: bytes.Repeat([]byte{0x1}, maxSize+1)}},
+			expNextRun: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx := sdk.NewContext(nil, tmproto.Header{}, spec.checkTx, nil)
+			var nextCalled bool
+			next := func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+				nextCalled = true
+				return ctx, nil
+			}
+			source := maxWasmCodeSizeSourceFn(func(sdk.Context) uint64 { return maxSize })
+			_, gotErr := NewLimitWasmCodeSizeDecorator(source).AnteHandle(ctx, mockTx{msgs: spec.msgs}, false, next)
+			if spec.expErr != nil {
+				require.Error(t, gotErr)
+				assert.True(t, spec.expErr.Is(gotErr), "got %#+v", gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expNextRun, nextCalled)
+		})
+	}
+}
+
+func TestGovOnlyDecorator(t *testing.T) {
+	specs := map[string]struct {
+		msgs       []sdk.Msg
+		expErr     bool
+		expNextRun bool
+	}{
+		"store code": {
+			msgs:   []sdk.Msg{&types.MsgStoreCode{}},
+			expErr: true,
+		},
+		"instantiate": {
+			msgs:   []sdk.Msg{&types.MsgInstantiateContract{}},
+			expErr: true,
+		},
+		"any rejected msg in tx": {
+			msgs:   []sdk.Msg{&types.MsgExecuteContract{}, &types.MsgInstantiateContract{}},
+			expErr: true,
+		},
+		"other messages": {
+			msgs:       []sdk.Msg{&types.MsgExecuteContract{}, &types.MsgMigrateContract{}},
+			expNextRun: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx := sdk.NewContext(nil, tmproto.Header{}, false, nil)
+			var nextCalled bool
+			next := func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+				nextCalled = true
+				return ctx, nil
+			}
+			_, gotErr := NewGovOnlyDecorator().AnteHandle(ctx, mockTx{msgs: spec.msgs}, false, next)
+			if spec.expErr {
+				assert.True(t, sdkerrors.ErrUnauthorized.Is(gotErr), "got %#+v", gotErr)
+				assert.False(t, nextCalled)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expNextRun, nextCalled)
+		})
+	}
+}
+
+type maxWasmCodeSizeSourceFn func(ctx sdk.Context) uint64
+
+func (f maxWasmCodeSizeSourceFn) GetMaxWasmCodeSize(ctx sdk.Context) uint64 {
+	return f(ctx)
+}
+
+type mockTx struct {
+	msgs []sdk.Msg
+}
+
+func (m mockTx) GetMsgs() []sdk.Msg {
+	return m.msgs
+}
+
+func (m mockTx) ValidateBasic() error {
+	return nil
+}
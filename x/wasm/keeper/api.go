@@ -19,8 +19,8 @@ var (
 )
 
 func humanAddress(canon []byte) (string, uint64, error) {
-	if len(canon) != sdk.AddrLen {
-		return "", costHumanize, fmt.Errorf("Expected %d byte address", sdk.AddrLen)
+	if err := sdk.VerifyAddressFormat(canon); err != nil {
+		return "", costHumanize, err
 	}
 	return sdk.AccAddress(canon).String(), costHumanize, nil
 }
@@ -30,6 +30,20 @@ func canonicalAddress(human string) ([]byte, uint64, error) {
 	return bz, costCanonical, err
 }
 
+// strictCanonicalAddress accepts the human address in the normalized form only, that is with the bech32 account
+// prefix of the chain and in lower case. Contracts that store or compare the human address strings would otherwise
+// see different strings for the same account.
+func strictCanonicalAddress(human string) ([]byte, uint64, error) {
+	bz, err := sdk.AccAddressFromBech32(human)
+	if err != nil {
+		return nil, costCanonical, err
+	}
+	if exp := sdk.AccAddress(bz).String(); exp != human {
+		return nil, costCanonical, fmt.Errorf("address %s is not in the normalized form %s", human, exp)
+	}
+	return bz, costCanonical, nil
+}
+
 var cosmwasmAPI = wasmvm.GoAPI{
 	HumanAddress:     humanAddress,
 	CanonicalAddress: canonicalAddress,
@@ -0,0 +1,116 @@
+package keeper
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withAccountPrefix sets the bech32 account prefix of the sdk config until the test is completed
+func withAccountPrefix(t *testing.T, prefix string) {
+	config := sdk.GetConfig()
+	prevAddr, prevPub := config.GetBech32AccountAddrPrefix(), config.GetBech32AccountPubPrefix()
+	config.SetBech32PrefixForAccount(prefix, prefix+"pub")
+	t.Cleanup(func() { config.SetBech32PrefixForAccount(prevAddr, prevPub) })
+}
+
+func TestAddressConversionWithChainPrefix(t *testing.T) {
+	for _, prefix := range []string{"cosmos", "wasm", "juno"} {
+		t.Run(prefix, func(t *testing.T) {
+			withAccountPrefix(t, prefix)
+			myAddr := RandomAccountAddress(t)
+
+			human, _, err := humanAddress(myAddr)
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(human, prefix+"1"), human)
+
+			specs := map[string]struct {
+				src       string
+				expErr    bool
+				expStrict bool
+			}{
+				"normalized": {
+					src:       human,
+					expStrict: true,
+				},
+				"upper case": {
+					src: strings.ToUpper(human),
+				},
+				"other prefix": {
+					src:    sdk.MustBech32ifyAddressBytes("other", myAddr),
+					expErr: true,
+				},
+				"empty": {
+					expErr: true,
+				},
+			}
+			for msg, spec := range specs {
+				t.Run(msg, func(t *testing.T) {
+					gotCanon, _, err := canonicalAddress(spec.src)
+					if spec.expErr {
+						assert.Error(t, err)
+					} else {
+						require.NoError(t, err)
+						assert.Equal(t, []byte(myAddr), gotCanon)
+					}
+					gotCanon, _, err = strictCanonicalAddress(spec.src)
+					if !spec.expStrict {
+						assert.Error(t, err)
+						return
+					}
+					require.NoError(t, err)
+					assert.Equal(t, []byte(myAddr), gotCanon)
+				})
+			}
+		})
+	}
+}
+
+func TestContractAddressesWithChainPrefix(t *testing.T) {
+	withAccountPrefix(t, "juno")
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	k := keepers.WasmKeeper
+	mock := wasmtesting.MockWasmer{}
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+
+	// then events
+	var found bool
+	for _, e := range ctx.EventManager().Events() {
+		for _, a := range e.Attributes {
+			if string(a.Key) == types.AttributeKeyContractAddr {
+				found = true
+				assert.True(t, strings.HasPrefix(string(a.Value), "juno1"), string(a.Value))
+			}
+		}
+	}
+	assert.True(t, found)
+	// and queries
+	rsp, err := Querier(k).ContractInfo(sdk.WrapSDKContext(ctx), &types.QueryContractInfoRequest{Address: example.Contract.String()})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(rsp.Address, "juno1"), rsp.Address)
+	assert.True(t, strings.HasPrefix(rsp.Creator, "juno1"), rsp.Creator)
+}
+
+func TestWithStrictAddressValidation(t *testing.T) {
+	myAddr := RandomAccountAddress(t)
+	upperCaseAddr := strings.ToUpper(myAddr.String())
+
+	_, keepers := CreateTestInput(t, false, SupportedFeatures)
+	_, _, err := keepers.WasmKeeper.cosmwasmAPI.CanonicalAddress(upperCaseAddr)
+	assert.NoError(t, err)
+
+	_, keepers = CreateTestInput(t, false, SupportedFeatures, WithStrictAddressValidation())
+	_, _, err = keepers.WasmKeeper.cosmwasmAPI.CanonicalAddress(upperCaseAddr)
+	assert.Error(t, err)
+	_, _, err = keepers.WasmKeeper.cosmwasmAPI.CanonicalAddress(myAddr.String())
+	assert.NoError(t, err)
+	// and the default api is not modified
+	_, _, err = cosmwasmAPI.CanonicalAddress(upperCaseAddr)
+	assert.NoError(t, err)
+}
@@ -11,6 +11,63 @@ type AuthorizationPolicy interface {
 	CanModifyContract(admin, actor sdk.AccAddress) bool
 }
 
+// CommunityPoolAuthorizationPolicy is an optional extension of the AuthorizationPolicy. Policies that do not
+// implement it never allow funding from the community pool.
+type CommunityPoolAuthorizationPolicy interface {
+	CanFundFromCommunityPool() bool
+}
+
+func canFundFromCommunityPool(p AuthorizationPolicy) bool {
+	x, ok := p.(CommunityPoolAuthorizationPolicy)
+	return ok && x.CanFundFromCommunityPool()
+}
+
+// ExecuteAuthorizationPolicy is an optional extension of the AuthorizationPolicy. Policies that do not implement it
+// behave like the DefaultAuthorizationPolicy.
+type ExecuteAuthorizationPolicy interface {
+	CanExecuteContract(allowlisted bool, admin, actor sdk.AccAddress) bool
+}
+
+func canExecuteContract(p AuthorizationPolicy, allowlisted bool, admin, actor sdk.AccAddress) bool {
+	if x, ok := p.(ExecuteAuthorizationPolicy); ok {
+		return x.CanExecuteContract(allowlisted, admin, actor)
+	}
+	return DefaultAuthorizationPolicy{}.CanExecuteContract(allowlisted, admin, actor)
+}
+
+// ContractFundsRecoveryAuthorizationPolicy is an optional extension of the AuthorizationPolicy. Policies that do not
+// implement it never allow to recover contract funds.
+type ContractFundsRecoveryAuthorizationPolicy interface {
+	CanRecoverContractFunds() bool
+}
+
+func canRecoverContractFunds(p AuthorizationPolicy) bool {
+	x, ok := p.(ContractFundsRecoveryAuthorizationPolicy)
+	return ok && x.CanRecoverContractFunds()
+}
+
+// ContractVestingAuthorizationPolicy is an optional extension of the AuthorizationPolicy. Policies that do not
+// implement it never allow to set a contract vesting.
+type ContractVestingAuthorizationPolicy interface {
+	CanSetContractVesting() bool
+}
+
+func canSetContractVesting(p AuthorizationPolicy) bool {
+	x, ok := p.(ContractVestingAuthorizationPolicy)
+	return ok && x.CanSetContractVesting()
+}
+
+// MigrateAllContractsAuthorizationPolicy is an optional extension of the AuthorizationPolicy. Policies that do not
+// implement it never allow to migrate all contracts of a code.
+type MigrateAllContractsAuthorizationPolicy interface {
+	CanMigrateAllContracts() bool
+}
+
+func canMigrateAllContracts(p AuthorizationPolicy) bool {
+	x, ok := p.(MigrateAllContractsAuthorizationPolicy)
+	return ok && x.CanMigrateAllContracts()
+}
+
 type DefaultAuthorizationPolicy struct {
 }
 
@@ -26,6 +83,26 @@ func (p DefaultAuthorizationPolicy) CanModifyContract(admin, actor sdk.AccAddres
 	return admin != nil && admin.Equals(actor)
 }
 
+func (p DefaultAuthorizationPolicy) CanExecuteContract(allowlisted bool, admin, actor sdk.AccAddress) bool {
+	return allowlisted || admin != nil && admin.Equals(actor)
+}
+
+func (p DefaultAuthorizationPolicy) CanFundFromCommunityPool() bool {
+	return false
+}
+
+func (p DefaultAuthorizationPolicy) CanRecoverContractFunds() bool {
+	return false
+}
+
+func (p DefaultAuthorizationPolicy) CanSetContractVesting() bool {
+	return false
+}
+
+func (p DefaultAuthorizationPolicy) CanMigrateAllContracts() bool {
+	return false
+}
+
 type GovAuthorizationPolicy struct {
 }
 
@@ -40,3 +117,37 @@ func (p GovAuthorizationPolicy) CanInstantiateContract(types.AccessConfig, sdk.A
 func (p GovAuthorizationPolicy) CanModifyContract(sdk.AccAddress, sdk.AccAddress) bool {
 	return true
 }
+
+func (p GovAuthorizationPolicy) CanExecuteContract(bool, sdk.AccAddress, sdk.AccAddress) bool {
+	return true
+}
+
+func (p GovAuthorizationPolicy) CanFundFromCommunityPool() bool {
+	return true
+}
+
+func (p GovAuthorizationPolicy) CanRecoverContractFunds() bool {
+	return true
+}
+
+func (p GovAuthorizationPolicy) CanSetContractVesting() bool {
+	return true
+}
+
+func (p GovAuthorizationPolicy) CanMigrateAllContracts() bool {
+	return true
+}
+
+// GovOnlyAuthorizationPolicy is the DefaultAuthorizationPolicy for permissioned chains where code can only be
+// stored and contracts can only be instantiated via governance proposals
+type GovOnlyAuthorizationPolicy struct {
+	DefaultAuthorizationPolicy
+}
+
+func (p GovOnlyAuthorizationPolicy) CanCreateCode(types.AccessConfig, sdk.AccAddress) bool {
+	return false
+}
+
+func (p GovOnlyAuthorizationPolicy) CanInstantiateContract(types.AccessConfig, sdk.AccAddress) bool {
+	return false
+}
@@ -0,0 +1,104 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// minimalAuthorizationPolicy implements only the methods that are required by the AuthorizationPolicy interface
+type minimalAuthorizationPolicy struct{}
+
+func (minimalAuthorizationPolicy) CanCreateCode(types.AccessConfig, sdk.AccAddress) bool {
+	return true
+}
+
+func (minimalAuthorizationPolicy) CanInstantiateContract(types.AccessConfig, sdk.AccAddress) bool {
+	return true
+}
+
+func (minimalAuthorizationPolicy) CanModifyContract(sdk.AccAddress, sdk.AccAddress) bool {
+	return true
+}
+
+func TestOptionalAuthorizationPolicies(t *testing.T) {
+	specs := map[string]struct {
+		policy    AuthorizationPolicy
+		expAllows bool
+	}{
+		"default policy": {
+			policy: DefaultAuthorizationPolicy{},
+		},
+		"gov policy": {
+			policy:    GovAuthorizationPolicy{},
+			expAllows: true,
+		},
+		"gov only policy": {
+			policy: GovOnlyAuthorizationPolicy{},
+		},
+		"policy without extensions": {
+			policy: minimalAuthorizationPolicy{},
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			assert.Equal(t, spec.expAllows, canFundFromCommunityPool(spec.policy))
+			assert.Equal(t, spec.expAllows, canMigrateAllContracts(spec.policy))
+			assert.Equal(t, spec.expAllows, canSetContractVesting(spec.policy))
+			assert.Equal(t, spec.expAllows, canRecoverContractFunds(spec.policy))
+		})
+	}
+}
+
+func TestCanExecuteContract(t *testing.T) {
+	admin, other := RandomAccountAddress(t), RandomAccountAddress(t)
+	specs := map[string]struct {
+		policy      AuthorizationPolicy
+		allowlisted bool
+		actor       sdk.AccAddress
+		exp         bool
+	}{
+		"default policy allowlisted": {
+			policy:      DefaultAuthorizationPolicy{},
+			allowlisted: true,
+			actor:       other,
+			exp:         true,
+		},
+		"default policy admin": {
+			policy: DefaultAuthorizationPolicy{},
+			actor:  admin,
+			exp:    true,
+		},
+		"default policy other": {
+			policy: DefaultAuthorizationPolicy{},
+			actor:  other,
+		},
+		"gov policy": {
+			policy: GovAuthorizationPolicy{},
+			actor:  other,
+			exp:    true,
+		},
+		"policy without extension allowlisted": {
+			policy:      minimalAuthorizationPolicy{},
+			allowlisted: true,
+			actor:       other,
+			exp:         true,
+		},
+		"policy without extension admin": {
+			policy: minimalAuthorizationPolicy{},
+			actor:  admin,
+			exp:    true,
+		},
+		"policy without extension other": {
+			policy: minimalAuthorizationPolicy{},
+			actor:  other,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			assert.Equal(t, spec.exp, canExecuteContract(spec.policy, spec.allowlisted, admin, spec.actor))
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package keeper
 
 import (
+	"fmt"
+
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 	"github.com/stretchr/testify/require"
 	"github.com/syndtr/goleveldb/leveldb/opt"
@@ -53,3 +55,49 @@ func BenchmarkExecution(b *testing.B) {
 		})
 	}
 }
+
+func BenchmarkContractStore(b *testing.B) {
+	specs := map[string]struct {
+		buffered bool
+		ops      int
+	}{
+		"direct, 1 read and write": {
+			ops: 1,
+		},
+		"buffered, 1 read and write": {
+			ops:      1,
+			buffered: true,
+		},
+		"direct, 100 reads and writes": {
+			ops: 100,
+		},
+		"buffered, 100 reads and writes": {
+			ops:      100,
+			buffered: true,
+		},
+	}
+	for name, spec := range specs {
+		b.Run(name, func(b *testing.B) {
+			ctx, keepers := createTestInput(b, false, SupportedFeatures, types.DefaultWasmConfig(), dbm.NewMemDB())
+			k := keepers.WasmKeeper
+			contractAddr := RandomAccountAddress(b)
+			keys := make([][]byte, spec.ops)
+			for i := range keys {
+				keys[i] = []byte(fmt.Sprintf("key-%d", i))
+			}
+			value := []byte("value")
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				store, commit := newContractStore(ctx, k.storeKey, contractAddr), func() {}
+				if spec.buffered {
+					_, store, commit = k.bufferedContractStore(ctx, contractAddr)
+				}
+				for _, key := range keys {
+					store.Get(key)
+					store.Set(key, value)
+				}
+				commit()
+			}
+		})
+	}
+}
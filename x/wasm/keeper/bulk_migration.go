@@ -0,0 +1,157 @@
+package keeper
+
+import (
+	"strconv"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+const (
+	// BulkMigrationJobType is the type of the end blocker jobs that migrate all contracts of a code
+	BulkMigrationJobType = "bulk_migration"
+	// DefaultBulkMigrationGasLimit is the gas limit for the migration of a single contract by the end blocker
+	DefaultBulkMigrationGasLimit uint64 = 10000000
+)
+
+// migrateAllContracts migrates all contracts of the code to the new code. With a zero contractsPerBlock all contracts
+// are migrated immediately and any failing migration fails the whole operation. Otherwise a bulk migration job is
+// queued that the end blocker continues with up to the given number of contracts per block. Returns the number of
+// contracts that were migrated immediately.
+func (k Keeper) migrateAllContracts(ctx sdk.Context, codeID, newCodeID uint64, caller sdk.AccAddress, msg []byte, contractsPerBlock uint32, authZ AuthorizationPolicy) (uint64, error) {
+	if !canMigrateAllContracts(authZ) {
+		return 0, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "can not migrate all contracts")
+	}
+	if codeID == newCodeID {
+		return 0, sdkerrors.Wrap(types.ErrInvalid, "new code id must not be the code id")
+	}
+	if k.GetCodeInfo(ctx, codeID) == nil {
+		return 0, sdkerrors.Wrapf(types.ErrNotFound, "code id: %d", codeID)
+	}
+	if k.GetCodeInfo(ctx, newCodeID) == nil {
+		return 0, sdkerrors.Wrapf(types.ErrNotFound, "new code id: %d", newCodeID)
+	}
+	if contractsPerBlock != 0 {
+		if k.GetBulkMigration(ctx, codeID) != nil {
+			return 0, sdkerrors.Wrapf(types.ErrDuplicate, "bulk migration for code id: %d", codeID)
+		}
+		payload, err := k.cdc.MarshalBinaryBare(&types.BulkMigration{
+			CodeID:     codeID,
+			NewCodeID:  newCodeID,
+			RunAs:      caller.String(),
+			MigrateMsg: msg,
+		})
+		if err != nil {
+			return 0, sdkerrors.Wrap(err, "bulk migration")
+		}
+		if _, err := k.QueueJob(ctx, BulkMigrationJobType, payload, contractsPerBlock); err != nil {
+			return 0, err
+		}
+		return 0, nil
+	}
+	// collect first as the migrations modify the index
+	var contracts []sdk.AccAddress
+	k.IterateContractsByCode(ctx, codeID, func(addr sdk.AccAddress) bool {
+		contracts = append(contracts, addr)
+		return false
+	})
+	for _, addr := range contracts {
+		if _, err := k.migrate(ctx, addr, caller, newCodeID, msg, authZ); err != nil {
+			return 0, sdkerrors.Wrapf(err, "contract: %s", addr)
+		}
+	}
+	return uint64(len(contracts)), nil
+}
+
+// bulkMigrationJobHandler migrates one contract of a bulk migration per step. Every migration runs with a dedicated gas
+// limit; a failing contract is skipped and keeps the old code. The job is done when there are no contracts left.
+type bulkMigrationJobHandler struct {
+	keeper Keeper
+}
+
+func (h bulkMigrationJobHandler) Step(ctx sdk.Context, payload []byte) ([]byte, bool, error) {
+	k := h.keeper
+	var m types.BulkMigration
+	if err := k.cdc.UnmarshalBinaryBare(payload, &m); err != nil {
+		return nil, false, sdkerrors.Wrap(err, "payload")
+	}
+	runAs, err := sdk.AccAddressFromBech32(m.RunAs)
+	if err != nil {
+		return nil, false, sdkerrors.Wrap(err, "run as")
+	}
+	if key, ok := k.nextContractOfCode(ctx, m.CodeID, m.NextKey); ok {
+		contractAddr := contractAddressFromCodeIndexKey(key)
+		cacheCtx, commit := ctx.CacheContext()
+		err := withGasLimit(cacheCtx, DefaultBulkMigrationGasLimit, "bulk migration", func(ctx sdk.Context) error {
+			_, err := k.migrate(ctx, contractAddr, runAs, m.NewCodeID, m.MigrateMsg, GovAuthorizationPolicy{})
+			return err
+		})
+		if err == nil {
+			commit()
+			ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+			m.Migrated++
+		} else {
+			k.Logger(ctx).Error("bulk migration", "contract", contractAddr.String(), "code_id", m.NewCodeID, "error", err.Error())
+			m.Failed++
+		}
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeBulkMigrateContract,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+			sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String()),
+			sdk.NewAttribute(types.AttributeKeyCodeID, strconv.FormatUint(m.NewCodeID, 10)),
+			sdk.NewAttribute(types.AttributeKeySuccess, strconv.FormatBool(err == nil)),
+		))
+		// the index entries of migrated contracts are removed, so that the key of the last contract is the lower
+		// bound of the next step
+		m.NextKey = append(key, 0)
+	}
+	_, more := k.nextContractOfCode(ctx, m.CodeID, m.NextKey)
+	if !more {
+		ctx.EventManager().EmitEvent(newMigrateAllContractsEvent(m.CodeID, m.NewCodeID, m.Migrated, m.Failed))
+	}
+	bz, err := k.cdc.MarshalBinaryBare(&m)
+	return bz, !more, err
+}
+
+// nextContractOfCode returns the first key in the contracts by code index of the code that is not before the start key
+func (k Keeper) nextContractOfCode(ctx sdk.Context, codeID uint64, start []byte) ([]byte, bool) {
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.GetContractByCodeIDSecondaryIndexPrefix(codeID))
+	iter := prefixStore.Iterator(start, nil)
+	defer iter.Close()
+	if !iter.Valid() {
+		return nil, false
+	}
+	return append([]byte{}, iter.Key()...), true
+}
+
+// newMigrateAllContractsEvent returns the event for the completed migration of all contracts of a code
+func newMigrateAllContractsEvent(codeID, newCodeID, migrated, failed uint64) sdk.Event {
+	return sdk.NewEvent(
+		types.EventTypeMigrateAllContracts,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(types.AttributeKeyCodeID, strconv.FormatUint(codeID, 10)),
+		sdk.NewAttribute(types.AttributeKeyNewCodeID, strconv.FormatUint(newCodeID, 10)),
+		sdk.NewAttribute(types.AttributeKeyMigrated, strconv.FormatUint(migrated, 10)),
+		sdk.NewAttribute(types.AttributeKeyFailed, strconv.FormatUint(failed, 10)),
+	)
+}
+
+// GetBulkMigration returns the pending migration of all contracts of the code or nil when not found
+func (k Keeper) GetBulkMigration(ctx sdk.Context, codeID uint64) *types.BulkMigration {
+	var r *types.BulkMigration
+	k.IterateJobs(ctx, func(job types.Job) bool {
+		if job.Type != BulkMigrationJobType {
+			return false
+		}
+		var m types.BulkMigration
+		k.cdc.MustUnmarshalBinaryBare(job.Payload, &m)
+		if m.CodeID == codeID {
+			r = &m
+			return true
+		}
+		return false
+	})
+	return r
+}
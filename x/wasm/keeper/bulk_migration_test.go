@@ -0,0 +1,174 @@
+package keeper
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	wasmvm "github.com/CosmWasm/wasmvm"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateAllContracts(t *testing.T) {
+	specs := map[string]struct {
+		codeID      uint64
+		newCodeID   uint64
+		failing     bool
+		authZ       AuthorizationPolicy
+		expMigrated uint64
+		expErr      *sdkerrors.Error
+	}{
+		"all good": {
+			codeID:      1,
+			newCodeID:   2,
+			authZ:       GovAuthorizationPolicy{},
+			expMigrated: 3,
+		},
+		"failing migration": {
+			codeID:    1,
+			newCodeID: 2,
+			failing:   true,
+			authZ:     GovAuthorizationPolicy{},
+			expErr:    types.ErrMigrationFailed,
+		},
+		"unknown code": {
+			codeID:    99,
+			newCodeID: 2,
+			authZ:     GovAuthorizationPolicy{},
+			expErr:    types.ErrNotFound,
+		},
+		"unknown new code": {
+			codeID:    1,
+			newCodeID: 99,
+			authZ:     GovAuthorizationPolicy{},
+			expErr:    types.ErrNotFound,
+		},
+		"same code": {
+			codeID:    1,
+			newCodeID: 1,
+			authZ:     GovAuthorizationPolicy{},
+			expErr:    types.ErrInvalid,
+		},
+		"unauthorized": {
+			codeID:    1,
+			newCodeID: 2,
+			authZ:     DefaultAuthorizationPolicy{},
+			expErr:    sdkerrors.ErrUnauthorized,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			mock := wasmtesting.MockWasmer{}
+			wasmtesting.MakeInstantiable(&mock)
+			mock.MigrateFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, migrateMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.Response, uint64, error) {
+				if spec.failing {
+					return nil, 0, errors.New("testing")
+				}
+				return &wasmvmtypes.Response{}, 0, nil
+			}
+			ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithWasmEngine(&mock))
+			k := keepers.WasmKeeper
+			contracts := seedContractsOfCode(t, ctx, keepers, &mock, 3)
+			StoreRandomContract(t, ctx, keepers, &mock)
+			caller := RandomAccountAddress(t)
+
+			// when
+			gotMigrated, gotErr := NewPermissionedKeeper(k, spec.authZ).MigrateAllContracts(ctx, spec.codeID, spec.newCodeID, caller, []byte(`{}`), 0)
+
+			// then
+			if spec.expErr != nil {
+				assert.True(t, spec.expErr.Is(gotErr), "got %+v", gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expMigrated, gotMigrated)
+			for _, c := range contracts {
+				assert.Equal(t, spec.newCodeID, k.GetContractInfo(ctx, c).CodeID)
+			}
+		})
+	}
+}
+
+func TestBulkMigrationJob(t *testing.T) {
+	mock := wasmtesting.MockWasmer{}
+	wasmtesting.MakeInstantiable(&mock)
+	var failingContract sdk.AccAddress
+	mock.MigrateFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, migrateMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.Response, uint64, error) {
+		if env.Contract.Address == failingContract.String() {
+			return nil, 0, errors.New("testing")
+		}
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithWasmEngine(&mock))
+	k := keepers.WasmKeeper
+	contracts := seedContractsOfCode(t, ctx, keepers, &mock, 5)
+	failingContract = contracts[1]
+	StoreRandomContract(t, ctx, keepers, &mock)
+	caller := RandomAccountAddress(t)
+	govKeeper := NewGovPermissionKeeper(k)
+
+	// when the bulk migration is stored
+	gotMigrated, err := govKeeper.MigrateAllContracts(ctx, 1, 2, caller, []byte(`{}`), 2)
+	require.NoError(t, err)
+
+	// then nothing is migrated yet
+	assert.Equal(t, uint64(0), gotMigrated)
+	_, err = govKeeper.MigrateAllContracts(ctx, 1, 2, caller, []byte(`{}`), 2)
+	assert.True(t, types.ErrDuplicate.Is(err), "got %+v", err)
+	assertCodeIDs := func(exp ...uint64) {
+		t.Helper()
+		for i, c := range contracts {
+			assert.Equal(t, exp[i], k.GetContractInfo(ctx, c).CodeID, "contract %d", i)
+		}
+	}
+	assertCodeIDs(1, 1, 1, 1, 1)
+
+	// and when the end blocker continues
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+	k.ProcessJobs(ctx)
+	assertCodeIDs(2, 1, 1, 1, 1)
+	exp := &types.BulkMigration{CodeID: 1, NewCodeID: 2, RunAs: caller.String(), MigrateMsg: []byte(`{}`), Migrated: 1, Failed: 1}
+	got := k.GetBulkMigration(ctx, 1)
+	require.NotNil(t, got)
+	got.NextKey = nil
+	assert.Equal(t, exp, got)
+
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+	k.ProcessJobs(ctx)
+	assertCodeIDs(2, 1, 2, 2, 1)
+
+	// and the last chunk
+	em := sdk.NewEventManager()
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+	k.ProcessJobs(ctx.WithEventManager(em))
+
+	// then
+	assertCodeIDs(2, 1, 2, 2, 2)
+	assert.Nil(t, k.GetBulkMigration(ctx, 1))
+	assert.Contains(t, em.Events(), newMigrateAllContractsEvent(1, 2, 4, 1))
+	var remaining []sdk.AccAddress
+	k.IterateContractsByCode(ctx, 1, func(addr sdk.AccAddress) bool {
+		remaining = append(remaining, addr)
+		return false
+	})
+	assert.Equal(t, []sdk.AccAddress{failingContract}, remaining)
+}
+
+// seedContractsOfCode stores a random code with id 1 and instantiates the given number of contracts
+func seedContractsOfCode(t *testing.T, ctx sdk.Context, keepers TestKeepers, mock types.WasmerEngine, n int) []sdk.AccAddress {
+	t.Helper()
+	example := StoreRandomContract(t, ctx, keepers, mock)
+	var contracts []sdk.AccAddress
+	for i := 0; i < n; i++ {
+		ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+		addr, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, example.CreatorAddr, example.CreatorAddr, []byte(`{}`), "", nil)
+		require.NoError(t, err)
+		contracts = append(contracts, addr)
+	}
+	return contracts
+}
@@ -0,0 +1,67 @@
+package keeper
+
+import (
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+type callOriginKey struct{}
+
+// CallOrigin describes who started the current chain of contract calls. The sender in the `MessageInfo` is always
+// the direct caller, which is the calling contract for nested calls.
+type CallOrigin struct {
+	// Origin is the external account that started the call chain. It is empty when the chain was started without
+	// a sender, for example by a sudo call or an IBC callback.
+	Origin sdk.AccAddress
+	// Depth is the number of nested contract calls, starting with 1 for the first contract
+	Depth uint32
+}
+
+// withCallOrigin returns the context for a contract call. The caller is kept as origin for the first call only.
+func withCallOrigin(ctx sdk.Context, caller sdk.AccAddress) sdk.Context {
+	origin, ok := CallOriginFromContext(ctx)
+	if !ok {
+		origin = CallOrigin{Origin: caller}
+	}
+	origin.Depth++
+	return ctx.WithValue(callOriginKey{}, origin)
+}
+
+// CallOriginFromContext returns the call origin of the current contract call
+func CallOriginFromContext(ctx sdk.Context) (CallOrigin, bool) {
+	origin, ok := ctx.Value(callOriginKey{}).(CallOrigin)
+	return origin, ok
+}
+
+// CallOriginCustomQuery is the custom query envelope to query the call origin
+type CallOriginCustomQuery struct {
+	CallOrigin *struct{} `json:"call_origin,omitempty"`
+}
+
+type CallOriginResponse struct {
+	// Origin is the bech32 address of the external account that started the call chain
+	Origin string `json:"origin,omitempty"`
+	// Depth is 1 for a direct call to the contract
+	Depth uint32 `json:"depth"`
+}
+
+// NewCallOriginQuerier handles the call origin custom query. A contract can compare the origin with the sender in
+// the `MessageInfo` to distinguish direct user calls from contract-initiated calls.
+// All other custom queries are passed to the next querier.
+func NewCallOriginQuerier(next CustomQuerier) CustomQuerier {
+	return func(ctx sdk.Context, request json.RawMessage) ([]byte, error) {
+		var customQuery CallOriginCustomQuery
+		if err := json.Unmarshal(request, &customQuery); err != nil || customQuery.CallOrigin == nil {
+			return next(ctx, request)
+		}
+		var rsp CallOriginResponse
+		if origin, ok := CallOriginFromContext(ctx); ok {
+			rsp.Depth = origin.Depth
+			if !origin.Origin.Empty() {
+				rsp.Origin = origin.Origin.String()
+			}
+		}
+		return json.Marshal(rsp)
+	}
+}
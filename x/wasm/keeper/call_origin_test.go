@@ -0,0 +1,99 @@
+package keeper
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	wasmvm "github.com/CosmWasm/wasmvm"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallOriginForNestedCalls(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithCallOriginQuerier())
+	mock := wasmtesting.MockWasmer{}
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	otherContract, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, example.CreatorAddr, nil, []byte(`{}`), "other", nil)
+	require.NoError(t, err)
+
+	type capturedCall struct {
+		sender string
+		origin CallOriginResponse
+	}
+	captured := make(map[string]capturedCall)
+	mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.Response, uint64, error) {
+		bz, err := querier.Query(wasmvmtypes.QueryRequest{Custom: []byte(`{"call_origin":{}}`)}, gasLimit)
+		require.NoError(t, err)
+		var origin CallOriginResponse
+		require.NoError(t, json.Unmarshal(bz, &origin))
+		captured[env.Contract.Address] = capturedCall{sender: info.Sender, origin: origin}
+
+		if env.Contract.Address != example.Contract.String() {
+			return &wasmvmtypes.Response{}, 0, nil
+		}
+		// first contract calls the other contract
+		return &wasmvmtypes.Response{
+			Messages: []wasmvmtypes.CosmosMsg{{
+				Wasm: &wasmvmtypes.WasmMsg{Execute: &wasmvmtypes.ExecuteMsg{ContractAddr: otherContract.String(), Msg: []byte(`{}`)}},
+			}},
+		}, 0, nil
+	}
+	user := RandomAccountAddress(t)
+
+	// when
+	_, err = keepers.ContractKeeper.Execute(ctx, example.Contract, user, []byte(`{}`), nil)
+
+	// then
+	require.NoError(t, err)
+	exp := map[string]capturedCall{
+		example.Contract.String(): {sender: user.String(), origin: CallOriginResponse{Origin: user.String(), Depth: 1}},
+		otherContract.String():    {sender: example.Contract.String(), origin: CallOriginResponse{Origin: user.String(), Depth: 2}},
+	}
+	assert.Equal(t, exp, captured)
+}
+
+func TestCallOriginQuerier(t *testing.T) {
+	next := func(ctx sdk.Context, request json.RawMessage) ([]byte, error) {
+		return []byte(`"next"`), nil
+	}
+	anyAddr := RandomAccountAddress(t)
+	emptyCtx := sdk.Context{}.WithContext(context.Background())
+	specs := map[string]struct {
+		srcCtx  sdk.Context
+		srcReq  string
+		expResp string
+	}{
+		"direct call": {
+			srcCtx:  withCallOrigin(emptyCtx, anyAddr),
+			srcReq:  `{"call_origin":{}}`,
+			expResp: `{"origin":"` + anyAddr.String() + `","depth":1}`,
+		},
+		"nested call": {
+			srcCtx:  withCallOrigin(withCallOrigin(emptyCtx, anyAddr), RandomAccountAddress(t)),
+			srcReq:  `{"call_origin":{}}`,
+			expResp: `{"origin":"` + anyAddr.String() + `","depth":2}`,
+		},
+		"without sender": {
+			srcCtx:  withCallOrigin(emptyCtx, nil),
+			srcReq:  `{"call_origin":{}}`,
+			expResp: `{"depth":1}`,
+		},
+		"other custom query": {
+			srcCtx:  emptyCtx,
+			srcReq:  `{"foo":{}}`,
+			expResp: `"next"`,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			gotResp, gotErr := NewCallOriginQuerier(next)(spec.srcCtx, []byte(spec.srcReq))
+			require.NoError(t, gotErr)
+			assert.JSONEq(t, spec.expResp, string(gotResp))
+		})
+	}
+}
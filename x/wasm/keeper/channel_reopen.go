@@ -0,0 +1,149 @@
+package keeper
+
+import (
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	channeltypes "github.com/cosmos/cosmos-sdk/x/ibc/core/04-channel/types"
+	host "github.com/cosmos/cosmos-sdk/x/ibc/core/24-host"
+)
+
+// DefaultChannelTimeoutClosedSudoGasLimit is the gas limit for a single channel timeout close notification of a contract
+const DefaultChannelTimeoutClosedSudoGasLimit uint64 = 1000000
+
+// IBCChannelTimeoutClosedSudoMsg is sent to the contract via sudo when one of its ordered channels was closed because
+// a packet timed out. The contract can start a new handshake with the same counterparty with a MsgReopenChannel.
+type IBCChannelTimeoutClosedSudoMsg struct {
+	IBCChannelTimeoutClosed *IBCChannelTimeoutClosed `json:"ibc_channel_timeout_closed"`
+}
+
+// IBCChannelTimeoutClosed contains the closed channel
+type IBCChannelTimeoutClosed struct {
+	Channel wasmvmtypes.IBCChannel `json:"channel"`
+}
+
+// onTimeoutClosedChannel handles an ordered contract channel that IBC core closes after the timeout callback. When
+// enabled, the channel is stored so that the contract is notified in the end blocker. The packet reply references
+// are kept as the other packets in flight are still timed out on the closed channel.
+func (k Keeper) onTimeoutClosedChannel(ctx sdk.Context, portID, channelID string) {
+	if !k.notifyTimeoutClosedChannels {
+		return
+	}
+	channel, ok := k.channelKeeper.GetChannel(ctx, portID, channelID)
+	if !ok || channel.Ordering != channeltypes.ORDERED {
+		return
+	}
+	ctx.KVStore(k.storeKey).Set(types.GetTimeoutClosedChannelKey(portID, channelID), []byte{1})
+}
+
+// NotifyTimeoutClosedChannels notifies the contracts via sudo about their ordered channels that were closed by a packet
+// timeout. Each channel is notified once. A contract that fails to handle the message does not block the others, its
+// state changes are reverted and the failure is logged. This is a noop unless the keeper was set up with
+// WithChannelTimeoutCloseNotifications.
+func (k Keeper) NotifyTimeoutClosedChannels(ctx sdk.Context) {
+	if !k.notifyTimeoutClosedChannels {
+		return
+	}
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.TimeoutClosedChannelPrefix)
+	var paths []string
+	iter := prefixStore.Iterator(nil, nil)
+	for ; iter.Valid(); iter.Next() {
+		paths = append(paths, string(iter.Key()))
+	}
+	iter.Close()
+
+	for _, path := range paths {
+		prefixStore.Delete([]byte(path))
+		portID, channelID, err := host.ParseChannelPath(path)
+		if err != nil {
+			continue
+		}
+		channel, ok := k.channelKeeper.GetChannel(ctx, portID, channelID)
+		if !ok || channel.State != channeltypes.CLOSED {
+			continue
+		}
+		contractAddr, err := k.ContractByPortID(ctx, portID)
+		if err != nil {
+			continue
+		}
+		msg := IBCChannelTimeoutClosedSudoMsg{IBCChannelTimeoutClosed: &IBCChannelTimeoutClosed{Channel: wasmvmtypes.IBCChannel{
+			Endpoint:             wasmvmtypes.IBCEndpoint{PortID: portID, ChannelID: channelID},
+			CounterpartyEndpoint: wasmvmtypes.IBCEndpoint{PortID: channel.Counterparty.PortId, ChannelID: channel.Counterparty.ChannelId},
+			Order:                channel.Ordering.String(),
+			Version:              channel.Version,
+			ConnectionID:         channel.ConnectionHops[0],
+		}}}
+		if _, err := k.sudoWithGasLimit(ctx, contractAddr, msg, DefaultChannelTimeoutClosedSudoGasLimit, "channel timeout close notification"); err != nil {
+			k.Logger(ctx).Error("channel timeout close notification", "contract", contractAddr.String(), "channel", channelID, "error", err.Error())
+		}
+	}
+}
+
+// reopenChannel starts a new channel handshake with the connection, counterparty port, order and version of a closed
+// ordered channel of the contract. The handshake is started with a MsgChannelOpenInit on behalf of the contract so
+// that it runs through IBC core and the `ibc_channel_open` callback of the contract like a handshake that was started
+// by a relayer. Returns the id of the new channel.
+func (k Keeper) reopenChannel(ctx sdk.Context, contractAddr sdk.AccAddress, channelID string) (string, error) {
+	contractInfo := k.GetContractInfo(ctx, contractAddr)
+	if contractInfo == nil {
+		return "", sdkerrors.Wrap(types.ErrNotFound, "contract")
+	}
+	portID := contractInfo.IBCPortID
+	if portID == "" {
+		return "", sdkerrors.Wrap(types.ErrInvalid, "contract has no ibc port")
+	}
+	channel, ok := k.channelKeeper.GetChannel(ctx, portID, channelID)
+	if !ok {
+		return "", sdkerrors.Wrapf(types.ErrNotFound, "channel: %s", channelID)
+	}
+	if channel.Ordering != channeltypes.ORDERED {
+		return "", sdkerrors.Wrap(types.ErrInvalid, "not an ordered channel")
+	}
+	if channel.State != channeltypes.CLOSED {
+		return "", sdkerrors.Wrapf(types.ErrInvalid, "channel state: %s", channel.State)
+	}
+
+	openInit := channeltypes.NewMsgChannelOpenInit(portID, channel.Version, channel.Ordering, channel.ConnectionHops, channel.Counterparty.PortId, contractAddr)
+	any, err := codectypes.NewAnyWithValue(openInit)
+	if err != nil {
+		return "", sdkerrors.Wrap(err, "channel open init")
+	}
+	events, _, err := k.messenger.DispatchMsg(ctx, contractAddr, portID, wasmvmtypes.CosmosMsg{
+		Stargate: &wasmvmtypes.StargateMsg{TypeURL: any.TypeUrl, Value: any.Value},
+	})
+	if err != nil {
+		return "", sdkerrors.Wrap(err, "channel open init")
+	}
+	ctx.EventManager().EmitEvents(events)
+	newChannelID := openInitChannelID(events)
+	if newChannelID == "" {
+		return "", sdkerrors.Wrap(types.ErrInvalid, "no channel id in channel open init events")
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeReopenChannel,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String()),
+		sdk.NewAttribute(types.AttributeKeyChannelID, channelID),
+		sdk.NewAttribute(types.AttributeKeyNewChannelID, newChannelID),
+	))
+	return newChannelID, nil
+}
+
+// openInitChannelID returns the channel id of the first channel open init event
+func openInitChannelID(events []sdk.Event) string {
+	for _, e := range events {
+		if e.Type != channeltypes.EventTypeChannelOpenInit {
+			continue
+		}
+		for _, a := range e.Attributes {
+			if string(a.Key) == channeltypes.AttributeKeyChannelID {
+				return string(a.Value)
+			}
+		}
+	}
+	return ""
+}
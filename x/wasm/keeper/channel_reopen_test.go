@@ -0,0 +1,210 @@
+package keeper
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	wasmvm "github.com/CosmWasm/wasmvm"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	channeltypes "github.com/cosmos/cosmos-sdk/x/ibc/core/04-channel/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReopenChannel(t *testing.T) {
+	closedOrdered := channeltypes.Channel{
+		State:          channeltypes.CLOSED,
+		Ordering:       channeltypes.ORDERED,
+		Counterparty:   channeltypes.NewCounterparty("counterparty-port", "channel-7"),
+		ConnectionHops: []string{"connection-0"},
+		Version:        "my-version",
+	}
+	openInitEvent := sdk.NewEvent(channeltypes.EventTypeChannelOpenInit, sdk.NewAttribute(channeltypes.AttributeKeyChannelID, "channel-1"))
+	specs := map[string]struct {
+		srcChannel     *channeltypes.Channel
+		noPort         bool
+		dispatchEvents []sdk.Event
+		dispatchErr    error
+		expErr         *sdkerrors.Error
+	}{
+		"all good": {
+			srcChannel:     &closedOrdered,
+			dispatchEvents: []sdk.Event{openInitEvent},
+		},
+		"open channel": {
+			srcChannel: func() *channeltypes.Channel {
+				c := closedOrdered
+				c.State = channeltypes.OPEN
+				return &c
+			}(),
+			expErr: types.ErrInvalid,
+		},
+		"unordered channel": {
+			srcChannel: func() *channeltypes.Channel {
+				c := closedOrdered
+				c.Ordering = channeltypes.UNORDERED
+				return &c
+			}(),
+			expErr: types.ErrInvalid,
+		},
+		"unknown channel": {
+			expErr: types.ErrNotFound,
+		},
+		"contract without port": {
+			srcChannel: &closedOrdered,
+			noPort:     true,
+			expErr:     types.ErrInvalid,
+		},
+		"open init fails": {
+			srcChannel:  &closedOrdered,
+			dispatchErr: errors.New("testing"),
+		},
+		"no open init event": {
+			srcChannel: &closedOrdered,
+			expErr:     types.ErrInvalid,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var captured []wasmvmtypes.CosmosMsg
+			messenger := &wasmtesting.MockMessageHandler{
+				DispatchMsgFn: func(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) ([]sdk.Event, [][]byte, error) {
+					captured = append(captured, msg)
+					return spec.dispatchEvents, nil, spec.dispatchErr
+				},
+			}
+			ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithMessageHandler(messenger))
+			k := keepers.WasmKeeper
+			mock := wasmtesting.MockWasmer{}
+			wasmtesting.MakeInstantiable(&mock)
+			example := SeedNewContractInstance(t, ctx, keepers, &mock)
+			portID := PortIDForContract(example.Contract)
+			if !spec.noPort {
+				info := k.GetContractInfo(ctx, example.Contract)
+				info.IBCPortID = portID
+				k.storeContractInfo(ctx, example.Contract, info)
+			}
+			k.channelKeeper = &wasmtesting.MockChannelKeeper{
+				GetChannelFn: func(ctx sdk.Context, srcPort, srcChan string) (channeltypes.Channel, bool) {
+					if spec.srcChannel == nil || srcPort != portID || srcChan != "channel-0" {
+						return channeltypes.Channel{}, false
+					}
+					return *spec.srcChannel, true
+				},
+			}
+			em := sdk.NewEventManager()
+
+			// when
+			gotChannelID, gotErr := keepers.ContractKeeper.ReopenChannel(ctx.WithEventManager(em), example.Contract, "channel-0")
+
+			// then
+			if spec.dispatchErr != nil {
+				require.Error(t, gotErr)
+				return
+			}
+			if spec.expErr != nil {
+				assert.True(t, spec.expErr.Is(gotErr), "got %+v", gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, "channel-1", gotChannelID)
+			require.Len(t, captured, 1)
+			require.NotNil(t, captured[0].Stargate)
+			var gotMsg sdk.Msg
+			require.NoError(t, k.cdc.UnpackAny(&codectypes.Any{TypeUrl: captured[0].Stargate.TypeURL, Value: captured[0].Stargate.Value}, &gotMsg))
+			exp := channeltypes.NewMsgChannelOpenInit(portID, "my-version", channeltypes.ORDERED, []string{"connection-0"}, "counterparty-port", example.Contract)
+			assert.Equal(t, exp, gotMsg)
+			assert.Contains(t, em.Events(), openInitEvent)
+			expEvt := sdk.NewEvent(types.EventTypeReopenChannel,
+				sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+				sdk.NewAttribute(types.AttributeKeyContractAddr, example.Contract.String()),
+				sdk.NewAttribute(types.AttributeKeyChannelID, "channel-0"),
+				sdk.NewAttribute(types.AttributeKeyNewChannelID, "channel-1"),
+			)
+			assert.Contains(t, em.Events(), expEvt)
+		})
+	}
+}
+
+func TestNotifyTimeoutClosedChannels(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithChannelTimeoutCloseNotifications())
+	k := keepers.WasmKeeper
+	mock := wasmtesting.MockWasmer{}
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	portID := PortIDForContract(example.Contract)
+	info := k.GetContractInfo(ctx, example.Contract)
+	info.IBCPortID = portID
+	k.storeContractInfo(ctx, example.Contract, info)
+
+	channels := map[string]*channeltypes.Channel{
+		"channel-0": {
+			State:          channeltypes.OPEN,
+			Ordering:       channeltypes.ORDERED,
+			Counterparty:   channeltypes.NewCounterparty("counterparty-port", "channel-7"),
+			ConnectionHops: []string{"connection-0"},
+			Version:        "my-version",
+		},
+		"channel-1": {
+			State:          channeltypes.OPEN,
+			Ordering:       channeltypes.UNORDERED,
+			Counterparty:   channeltypes.NewCounterparty("counterparty-port", "channel-8"),
+			ConnectionHops: []string{"connection-0"},
+			Version:        "my-version",
+		},
+	}
+	k.channelKeeper = &wasmtesting.MockChannelKeeper{
+		GetChannelFn: func(ctx sdk.Context, srcPort, srcChan string) (channeltypes.Channel, bool) {
+			c, ok := channels[srcChan]
+			if !ok || srcPort != portID {
+				return channeltypes.Channel{}, false
+			}
+			return *c, true
+		},
+	}
+	mock.IBCPacketTimeoutFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, packet wasmvmtypes.IBCPacket, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.IBCBasicResponse, uint64, error) {
+		return &wasmvmtypes.IBCBasicResponse{}, 0, nil
+	}
+	var captured []IBCChannelTimeoutClosedSudoMsg
+	mock.SudoFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, sudoMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.Response, uint64, error) {
+		var msg IBCChannelTimeoutClosedSudoMsg
+		require.NoError(t, json.Unmarshal(sudoMsg, &msg))
+		captured = append(captured, msg)
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+
+	// when packets on both channels timed out
+	for _, channelID := range []string{"channel-0", "channel-1"} {
+		packet := wasmvmtypes.IBCPacket{Src: wasmvmtypes.IBCEndpoint{PortID: portID, ChannelID: channelID}, Sequence: 1}
+		require.NoError(t, k.OnTimeoutPacket(ctx, example.Contract, packet))
+	}
+	// and IBC core closed the ordered channel
+	channels["channel-0"].State = channeltypes.CLOSED
+	k.NotifyTimeoutClosedChannels(ctx)
+
+	// then
+	exp := []IBCChannelTimeoutClosedSudoMsg{{IBCChannelTimeoutClosed: &IBCChannelTimeoutClosed{Channel: wasmvmtypes.IBCChannel{
+		Endpoint:             wasmvmtypes.IBCEndpoint{PortID: portID, ChannelID: "channel-0"},
+		CounterpartyEndpoint: wasmvmtypes.IBCEndpoint{PortID: "counterparty-port", ChannelID: "channel-7"},
+		Order:                channeltypes.ORDERED.String(),
+		Version:              "my-version",
+		ConnectionID:         "connection-0",
+	}}}}
+	assert.Equal(t, exp, captured)
+
+	// and channels are notified once
+	captured = nil
+	k.NotifyTimeoutClosedChannels(ctx)
+	assert.Empty(t, captured)
+}
+
+func TestNotifyTimeoutClosedChannelsDisabled(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	assert.NotPanics(t, func() { keepers.WasmKeeper.NotifyTimeoutClosedChannels(ctx) })
+}
@@ -0,0 +1,195 @@
+package keeper
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	channeltypes "github.com/cosmos/cosmos-sdk/x/ibc/core/04-channel/types"
+	host "github.com/cosmos/cosmos-sdk/x/ibc/core/24-host"
+	ibcexported "github.com/cosmos/cosmos-sdk/x/ibc/core/exported"
+)
+
+// DefaultClientFrozenSudoGasLimit is the gas limit for a single client frozen notification of a contract
+const DefaultClientFrozenSudoGasLimit uint64 = 1000000
+
+// DefaultClientFreezeChannelsPerBlock is the max number of contract channels that are checked for a frozen client in
+// a block
+const DefaultClientFreezeChannelsPerBlock = 20
+
+// ChannelClientStateSource is the subset of the IBC channel keeper that is used to load the clients of the contract
+// channels
+type ChannelClientStateSource interface {
+	GetChannelClientState(ctx sdk.Context, portID, channelID string) (string, ibcexported.ClientState, error)
+}
+
+// IBCClientFrozenSudoMsg is sent to the contract via sudo when the light client of one of its channels was frozen
+// for misbehaviour. The counterparty chain can not be trusted anymore.
+type IBCClientFrozenSudoMsg struct {
+	IBCClientFrozen *IBCClientFrozen `json:"ibc_client_frozen"`
+}
+
+// IBCClientFrozen contains the frozen client and the channels of the contract on it
+type IBCClientFrozen struct {
+	ClientID string                    `json:"client_id"`
+	Channels []wasmvmtypes.IBCEndpoint `json:"channels"`
+}
+
+// frozenClientChannels are the contract channels of a frozen client, grouped by contract in iteration order
+type frozenClientChannels struct {
+	clientID  string
+	contracts []string
+	channels  map[string][]wasmvmtypes.IBCEndpoint
+}
+
+// NotifyFrozenClients notifies the contracts via sudo about the clients of their channels that were frozen for
+// misbehaviour. The contract channels are checked in chunks of DefaultClientFreezeChannelsPerBlock per block, starting
+// after the channel of the previous block so that the work of a block does not grow with the number of channels.
+// A contract is notified once per channel. A contract that fails to handle the message does not block the others,
+// its state changes are reverted and the failure is logged. The channels of a failed notification are notified
+// again in the next round. This is a noop unless the keeper was set up with WithClientFreezeNotifications.
+func (k Keeper) NotifyFrozenClients(ctx sdk.Context) {
+	if k.channelClients == nil || k.contractChannels == nil {
+		return
+	}
+	store := ctx.KVStore(k.storeKey)
+	for _, f := range k.newFrozenClients(ctx) {
+		for _, contract := range f.contracts {
+			msg := IBCClientFrozenSudoMsg{IBCClientFrozen: &IBCClientFrozen{ClientID: f.clientID, Channels: f.channels[contract]}}
+			if err := k.sudoClientFrozen(ctx, contract, msg); err != nil {
+				k.Logger(ctx).Error("client frozen notification", "contract", contract, "client", f.clientID, "error", err.Error())
+				continue
+			}
+			for _, c := range f.channels[contract] {
+				store.Set(types.GetFrozenClientNotifiedKey(f.clientID, c.PortID, c.ChannelID), []byte{1})
+			}
+		}
+	}
+}
+
+// newFrozenClients returns the frozen clients of the next chunk of open contract channels that were not notified
+// before. The position of the last checked channel is stored so that the next block continues after it. The chunks
+// start from the first channel again when the end was reached.
+func (k Keeper) newFrozenClients(ctx sdk.Context) []*frozenClientChannels {
+	store := ctx.KVStore(k.storeKey)
+	var afterPortID, afterChannelID string
+	if bz := store.Get(types.FrozenClientScanCursorKey); bz != nil {
+		afterPortID, afterChannelID, _ = host.ParseChannelPath(string(bz))
+	}
+	var (
+		result  []*frozenClientChannels
+		checked int
+		last    string
+	)
+	byClient := make(map[string]*frozenClientChannels)
+	k.contractChannels.IterateContractChannels(ctx, afterPortID, afterChannelID, func(c channeltypes.IdentifiedChannel) bool {
+		checked++
+		last = host.ChannelPath(c.PortId, c.ChannelId)
+		clientID, contractAddr, ok := k.unnotifiedFrozenClient(ctx, c)
+		if !ok {
+			return checked == DefaultClientFreezeChannelsPerBlock
+		}
+		f, ok := byClient[clientID]
+		if !ok {
+			f = &frozenClientChannels{clientID: clientID, channels: make(map[string][]wasmvmtypes.IBCEndpoint)}
+			byClient[clientID] = f
+			result = append(result, f)
+		}
+		contract := contractAddr.String()
+		if _, ok := f.channels[contract]; !ok {
+			f.contracts = append(f.contracts, contract)
+		}
+		f.channels[contract] = append(f.channels[contract], wasmvmtypes.IBCEndpoint{PortID: c.PortId, ChannelID: c.ChannelId})
+		return checked == DefaultClientFreezeChannelsPerBlock
+	})
+	if checked == DefaultClientFreezeChannelsPerBlock {
+		store.Set(types.FrozenClientScanCursorKey, []byte(last))
+	} else {
+		store.Delete(types.FrozenClientScanCursorKey)
+	}
+	return result
+}
+
+// IterateFrozenClientNotifications iterates the contract channels on frozen clients that the contracts were notified
+// about. The stop flag of the callback aborts the iteration.
+func (k Keeper) IterateFrozenClientNotifications(ctx sdk.Context, cb func(types.FrozenClientNotification) bool) {
+	iter := prefix.NewStore(ctx.KVStore(k.storeKey), types.FrozenClientNotifiedPrefix).Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		ids := strings.SplitN(string(iter.Key()), "/", 3)
+		if len(ids) != 3 {
+			continue
+		}
+		if cb(types.FrozenClientNotification{ClientID: ids[0], PortID: ids[1], ChannelID: ids[2]}) {
+			return
+		}
+	}
+}
+
+// importFrozenClientNotification stores a contract channel on a frozen client that the contract was notified about
+// from genesis
+func (k Keeper) importFrozenClientNotification(ctx sdk.Context, n types.FrozenClientNotification) {
+	ctx.KVStore(k.storeKey).Set(types.GetFrozenClientNotifiedKey(n.ClientID, n.PortID, n.ChannelID), []byte{1})
+}
+
+// unnotifiedFrozenClient returns the client and the contract of an active channel when the client is frozen and the
+// contract was not notified about the channel before
+func (k Keeper) unnotifiedFrozenClient(ctx sdk.Context, c channeltypes.IdentifiedChannel) (string, sdk.AccAddress, bool) {
+	if c.State == channeltypes.CLOSED || c.State == channeltypes.UNINITIALIZED {
+		return "", nil, false
+	}
+	clientID, clientState, err := k.channelClients.GetChannelClientState(ctx, c.PortId, c.ChannelId)
+	if err != nil || !clientState.IsFrozen() || ctx.KVStore(k.storeKey).Has(types.GetFrozenClientNotifiedKey(clientID, c.PortId, c.ChannelId)) {
+		return "", nil, false
+	}
+	contractAddr, err := k.ContractByPortID(ctx, c.PortId)
+	if err != nil {
+		return "", nil, false
+	}
+	return clientID, contractAddr, true
+}
+
+// sudoClientFrozen calls the contract in a cache context with a dedicated gas limit. The state changes are only
+// committed on success.
+func (k Keeper) sudoClientFrozen(ctx sdk.Context, contract string, msg IBCClientFrozenSudoMsg) error {
+	contractAddr, err := sdk.AccAddressFromBech32(contract)
+	if err != nil {
+		return err
+	}
+	_, err = k.sudoWithGasLimit(ctx, contractAddr, msg, DefaultClientFrozenSudoGasLimit, "client frozen notification")
+	return err
+}
+
+// sudoWithGasLimit calls the contract with the JSON encoded message in a cache context with its own gas meter. The
+// state changes and events are only committed on success. The gas consumed is returned also on failure. Panics are
+// recovered and returned as error so that a contract can not halt the chain when called from the end blocker.
+func (k Keeper) sudoWithGasLimit(ctx sdk.Context, contractAddr sdk.AccAddress, msg interface{}, gasLimit uint64, descriptor string) (gasUsed uint64, err error) {
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		return 0, sdkerrors.Wrap(err, "sudo msg")
+	}
+	cacheCtx, commit := ctx.CacheContext()
+	cacheCtx = cacheCtx.WithGasMeter(sdk.NewGasMeter(gasLimit))
+	defer func() {
+		gasUsed = cacheCtx.GasMeter().GasConsumedToLimit()
+		if r := recover(); r != nil {
+			if _, ok := r.(sdk.ErrorOutOfGas); ok {
+				err = sdkerrors.Wrapf(sdkerrors.ErrOutOfGas, "%s gas limit", descriptor)
+				return
+			}
+			k.Logger(ctx).Error("recovered panic", "descriptor", descriptor, "contract", contractAddr.String(), "panic", fmt.Sprintf("%v", r))
+			err = sdkerrors.Wrapf(sdkerrors.ErrPanic, "%s: %v", descriptor, r)
+		}
+	}()
+	if _, err := k.Sudo(cacheCtx, contractAddr, bz); err != nil {
+		return 0, err
+	}
+	commit()
+	ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+	return 0, nil
+}
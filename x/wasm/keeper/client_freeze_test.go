@@ -0,0 +1,222 @@
+package keeper
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	wasmvm "github.com/CosmWasm/wasmvm"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	channelkeeper "github.com/cosmos/cosmos-sdk/x/ibc/core/04-channel/keeper"
+	channeltypes "github.com/cosmos/cosmos-sdk/x/ibc/core/04-channel/types"
+	host "github.com/cosmos/cosmos-sdk/x/ibc/core/24-host"
+	ibcexported "github.com/cosmos/cosmos-sdk/x/ibc/core/exported"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ ChannelClientStateSource = channelkeeper.Keeper{}
+
+func TestNotifyFrozenClients(t *testing.T) {
+	source := &mockChannelClientStateSource{clients: map[string]bool{"frozen-client": true, "healthy-client": false}}
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithClientFreezeNotifications(source, source))
+	k := keepers.WasmKeeper
+
+	mock := wasmtesting.MockWasmer{}
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	failingContract, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, example.CreatorAddr, nil, []byte(`{}`), "other", nil)
+	require.NoError(t, err)
+
+	myPort, failingPort := PortIDForContract(example.Contract), PortIDForContract(failingContract)
+	source.channels = []channelClient{
+		{channel: channeltypes.IdentifiedChannel{State: channeltypes.OPEN, PortId: myPort, ChannelId: "channel-0"}, clientID: "frozen-client"},
+		{channel: channeltypes.IdentifiedChannel{State: channeltypes.OPEN, PortId: failingPort, ChannelId: "channel-1"}, clientID: "frozen-client"},
+		{channel: channeltypes.IdentifiedChannel{State: channeltypes.TRYOPEN, PortId: myPort, ChannelId: "channel-2"}, clientID: "frozen-client"},
+		{channel: channeltypes.IdentifiedChannel{State: channeltypes.OPEN, PortId: myPort, ChannelId: "channel-3"}, clientID: "healthy-client"},
+		{channel: channeltypes.IdentifiedChannel{State: channeltypes.CLOSED, PortId: myPort, ChannelId: "channel-4"}, clientID: "frozen-client"},
+		{channel: channeltypes.IdentifiedChannel{State: channeltypes.OPEN, PortId: "wasm.unknown", ChannelId: "channel-5"}, clientID: "frozen-client"},
+	}
+
+	captured := make(map[string]IBCClientFrozenSudoMsg)
+	mock.SudoFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, sudoMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.Response, uint64, error) {
+		var msg IBCClientFrozenSudoMsg
+		require.NoError(t, json.Unmarshal(sudoMsg, &msg))
+		captured[env.Contract.Address] = msg
+		store.Set([]byte("paused"), []byte("true"))
+		if env.Contract.Address == failingContract.String() {
+			return nil, 0, errors.New("testing")
+		}
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+
+	// when
+	k.NotifyFrozenClients(ctx)
+
+	// then
+	exp := map[string]IBCClientFrozenSudoMsg{
+		example.Contract.String(): {IBCClientFrozen: &IBCClientFrozen{
+			ClientID: "frozen-client",
+			Channels: []wasmvmtypes.IBCEndpoint{{PortID: myPort, ChannelID: "channel-0"}, {PortID: myPort, ChannelID: "channel-2"}},
+		}},
+		failingContract.String(): {IBCClientFrozen: &IBCClientFrozen{
+			ClientID: "frozen-client",
+			Channels: []wasmvmtypes.IBCEndpoint{{PortID: failingPort, ChannelID: "channel-1"}},
+		}},
+	}
+	assert.Equal(t, exp, captured)
+	// state changes of failed notifications are reverted
+	assert.Equal(t, []byte("true"), k.QueryRaw(ctx, example.Contract, []byte("paused")))
+	assert.Nil(t, k.QueryRaw(ctx, failingContract, []byte("paused")))
+
+	// and the channels of the failed notification are notified again
+	captured = make(map[string]IBCClientFrozenSudoMsg)
+	k.NotifyFrozenClients(ctx)
+	assert.Equal(t, map[string]IBCClientFrozenSudoMsg{failingContract.String(): exp[failingContract.String()]}, captured)
+
+	// and the channels of a successful notification are notified once
+	mock.SudoFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, sudoMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.Response, uint64, error) {
+		captured[env.Contract.Address] = IBCClientFrozenSudoMsg{}
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+	captured = make(map[string]IBCClientFrozenSudoMsg)
+	k.NotifyFrozenClients(ctx)
+	assert.Len(t, captured, 1)
+	assert.Contains(t, captured, failingContract.String())
+	captured = make(map[string]IBCClientFrozenSudoMsg)
+	k.NotifyFrozenClients(ctx)
+	assert.Empty(t, captured)
+
+	// and the notified channels are exported
+	var notified []types.FrozenClientNotification
+	k.IterateFrozenClientNotifications(ctx, func(n types.FrozenClientNotification) bool {
+		notified = append(notified, n)
+		return false
+	})
+	assert.ElementsMatch(t, []types.FrozenClientNotification{
+		{ClientID: "frozen-client", PortID: myPort, ChannelID: "channel-0"},
+		{ClientID: "frozen-client", PortID: failingPort, ChannelID: "channel-1"},
+		{ClientID: "frozen-client", PortID: myPort, ChannelID: "channel-2"},
+	}, notified)
+}
+
+func TestNotifyFrozenClientsChannelsPerBlock(t *testing.T) {
+	source := &mockChannelClientStateSource{clients: map[string]bool{"frozen-client": true}}
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithClientFreezeNotifications(source, source))
+	k := keepers.WasmKeeper
+
+	mock := wasmtesting.MockWasmer{}
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	myPort := PortIDForContract(example.Contract)
+	const channelCount = DefaultClientFreezeChannelsPerBlock + 5
+	for i := 0; i < channelCount; i++ {
+		source.channels = append(source.channels, channelClient{
+			channel:  channeltypes.IdentifiedChannel{State: channeltypes.OPEN, PortId: myPort, ChannelId: fmt.Sprintf("channel-%03d", i)},
+			clientID: "frozen-client",
+		})
+	}
+	var notifiedChannels []int
+	mock.SudoFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, sudoMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.Response, uint64, error) {
+		var msg IBCClientFrozenSudoMsg
+		require.NoError(t, json.Unmarshal(sudoMsg, &msg))
+		notifiedChannels = append(notifiedChannels, len(msg.IBCClientFrozen.Channels))
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+
+	// when
+	k.NotifyFrozenClients(ctx)
+	// then the channels of the first chunk are notified
+	assert.Equal(t, []int{DefaultClientFreezeChannelsPerBlock}, notifiedChannels)
+	// and the next block continues with the remaining channels
+	k.NotifyFrozenClients(ctx)
+	assert.Equal(t, []int{DefaultClientFreezeChannelsPerBlock, 5}, notifiedChannels)
+	// and starts from the first channel again
+	k.NotifyFrozenClients(ctx)
+	k.NotifyFrozenClients(ctx)
+	assert.Equal(t, []int{DefaultClientFreezeChannelsPerBlock, 5}, notifiedChannels)
+}
+
+func TestNotifyFrozenClientsRecoversPanics(t *testing.T) {
+	source := &mockChannelClientStateSource{clients: map[string]bool{"frozen-client": true}}
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithClientFreezeNotifications(source, source))
+	k := keepers.WasmKeeper
+
+	mock := wasmtesting.MockWasmer{}
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	myPort := PortIDForContract(example.Contract)
+	source.channels = []channelClient{
+		{channel: channeltypes.IdentifiedChannel{State: channeltypes.OPEN, PortId: myPort, ChannelId: "channel-0"}, clientID: "frozen-client"},
+	}
+	mock.SudoFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, sudoMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.Response, uint64, error) {
+		store.Set([]byte("paused"), []byte("true"))
+		panic("testing")
+	}
+
+	// when
+	require.NotPanics(t, func() { k.NotifyFrozenClients(ctx) })
+
+	// then the state changes are reverted and the channel is not marked as notified
+	assert.Nil(t, k.QueryRaw(ctx, example.Contract, []byte("paused")))
+	assert.False(t, ctx.KVStore(k.storeKey).Has(types.GetFrozenClientNotifiedKey("frozen-client", myPort, "channel-0")))
+}
+
+func TestNotifyFrozenClientsDisabled(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	assert.NotPanics(t, func() { keepers.WasmKeeper.NotifyFrozenClients(ctx) })
+}
+
+type channelClient struct {
+	channel  channeltypes.IdentifiedChannel
+	clientID string
+}
+
+type mockChannelClientStateSource struct {
+	channels []channelClient
+	// clients contains the frozen state by client id
+	clients map[string]bool
+}
+
+// IterateContractChannels iterates the channels in the key order of the IBC store
+func (m *mockChannelClientStateSource) IterateContractChannels(ctx sdk.Context, afterPortID, afterChannelID string, cb func(channeltypes.IdentifiedChannel) bool) {
+	channels := make([]channeltypes.IdentifiedChannel, len(m.channels))
+	for i, c := range m.channels {
+		channels[i] = c.channel
+	}
+	sort.Slice(channels, func(i, j int) bool {
+		return host.ChannelPath(channels[i].PortId, channels[i].ChannelId) < host.ChannelPath(channels[j].PortId, channels[j].ChannelId)
+	})
+	after := host.ChannelPath(afterPortID, afterChannelID)
+	for _, c := range channels {
+		if afterPortID != "" && host.ChannelPath(c.PortId, c.ChannelId) <= after {
+			continue
+		}
+		if cb(c) {
+			return
+		}
+	}
+}
+
+func (m *mockChannelClientStateSource) GetChannelClientState(ctx sdk.Context, portID, channelID string) (string, ibcexported.ClientState, error) {
+	for _, c := range m.channels {
+		if c.channel.PortId == portID && c.channel.ChannelId == channelID {
+			return c.clientID, mockClientState{frozen: m.clients[c.clientID]}, nil
+		}
+	}
+	return "", nil, channeltypes.ErrChannelNotFound
+}
+
+type mockClientState struct {
+	ibcexported.ClientState
+	frozen bool
+}
+
+func (m mockClientState) IsFrozen() bool {
+	return m.frozen
+}
@@ -0,0 +1,59 @@
+package keeper
+
+import (
+	"bytes"
+	"crypto/sha256"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// CodeCheckResult is the result of the integrity check of the wasm code stored in the wasmvm cache for a code id
+type CodeCheckResult struct {
+	CodeID   uint64
+	CodeHash []byte
+	// Err is set when the stored wasm code is missing, does not match the code hash or fails the VM validation
+	Err error
+	// Repaired is set when the stored wasm code was replaced by a verified copy
+	Repaired bool
+}
+
+// CheckWasmCache loads the wasm code for every code info from the wasmvm cache, verifies it against the code hash
+// and runs the VM code validation again. The result is passed to the callback for each code.
+// The wasm code is not part of the chain state. A failed code is repaired when the optional repair function returns
+// a copy that matches the code hash and passes the validation. The copy is stored in the wasmvm cache.
+func (k Keeper) CheckWasmCache(ctx sdk.Context, repair func(codeHash []byte) []byte, cb func(CodeCheckResult)) {
+	k.IterateCodeInfos(ctx, func(codeID uint64, info types.CodeInfo) bool {
+		res := CodeCheckResult{CodeID: codeID, CodeHash: info.CodeHash}
+		code, err := k.wasmVM.GetCode(info.CodeHash)
+		if err != nil {
+			res.Err = sdkerrors.Wrap(types.ErrNotFound, err.Error())
+		} else {
+			res.Err = k.verifyWasmCode(info.CodeHash, code)
+		}
+		if res.Err != nil && repair != nil {
+			if code := repair(info.CodeHash); code != nil && k.verifyWasmCode(info.CodeHash, code) == nil {
+				res.Repaired = true
+			}
+		}
+		cb(res)
+		return false
+	})
+}
+
+// verifyWasmCode checks the wasm code against the code hash and runs the VM code validation.
+// The code is stored in the wasmvm cache when it passes the validation.
+func (k Keeper) verifyWasmCode(codeHash []byte, code []byte) error {
+	if checksum := sha256.Sum256(code); !bytes.Equal(checksum[:], codeHash) {
+		return sdkerrors.Wrapf(types.ErrInvalid, "code hash mismatch: got %X", checksum)
+	}
+	checksum, err := k.wasmVM.Create(code)
+	if err != nil {
+		return sdkerrors.Wrap(types.ErrCreateFailed, err.Error())
+	}
+	if !bytes.Equal(checksum, codeHash) {
+		return sdkerrors.Wrap(types.ErrInvalid, "code hashes not same")
+	}
+	return nil
+}
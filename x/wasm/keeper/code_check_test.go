@@ -0,0 +1,133 @@
+package keeper
+
+import (
+	"crypto/sha256"
+	"errors"
+	"testing"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	wasmvm "github.com/CosmWasm/wasmvm"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckWasmCache(t *testing.T) {
+	validCode, invalidCode := []byte("valid"), []byte("invalid")
+	checksum := func(code []byte) []byte {
+		c := sha256.Sum256(code)
+		return c[:]
+	}
+	specs := map[string]struct {
+		codeHash    []byte
+		storedCode  []byte
+		repairCode  []byte
+		expErr      *sdkerrors.Error
+		expRepaired bool
+		expStored   []byte
+	}{
+		"valid": {
+			codeHash:   checksum(validCode),
+			storedCode: validCode,
+			expStored:  validCode,
+		},
+		"missing": {
+			codeHash: checksum(validCode),
+			expErr:   types.ErrNotFound,
+		},
+		"corrupted": {
+			codeHash:   checksum(validCode),
+			storedCode: []byte("corrupted"),
+			expErr:     types.ErrInvalid,
+			expStored:  []byte("corrupted"),
+		},
+		"fails validation": {
+			codeHash:   checksum(invalidCode),
+			storedCode: invalidCode,
+			expErr:     types.ErrCreateFailed,
+			expStored:  invalidCode,
+		},
+		"corrupted - repaired": {
+			codeHash:    checksum(validCode),
+			storedCode:  []byte("corrupted"),
+			repairCode:  validCode,
+			expErr:      types.ErrInvalid,
+			expRepaired: true,
+			expStored:   validCode,
+		},
+		"missing - repaired": {
+			codeHash:    checksum(validCode),
+			repairCode:  validCode,
+			expErr:      types.ErrNotFound,
+			expRepaired: true,
+			expStored:   validCode,
+		},
+		"corrupted - repair with other code": {
+			codeHash:   checksum(validCode),
+			storedCode: []byte("corrupted"),
+			repairCode: []byte("other"),
+			expErr:     types.ErrInvalid,
+			expStored:  []byte("corrupted"),
+		},
+		"fails validation - repair fails validation": {
+			codeHash:   checksum(invalidCode),
+			storedCode: invalidCode,
+			repairCode: invalidCode,
+			expErr:     types.ErrCreateFailed,
+			expStored:  invalidCode,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			files := make(map[string][]byte)
+			if spec.storedCode != nil {
+				files[string(spec.codeHash)] = spec.storedCode
+			}
+			mock := &wasmtesting.MockWasmer{
+				GetCodeFn: func(checksum wasmvm.Checksum) (wasmvm.WasmCode, error) {
+					code, ok := files[string(checksum)]
+					if !ok {
+						return nil, errors.New("file not found")
+					}
+					return code, nil
+				},
+				CreateFn: func(code wasmvm.WasmCode) (wasmvm.Checksum, error) {
+					if string(code) == string(invalidCode) {
+						return nil, errors.New("validation failed")
+					}
+					files[string(checksum(code))] = code
+					return checksum(code), nil
+				},
+			}
+			ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithWasmEngine(mock))
+			k := keepers.WasmKeeper
+			k.storeCodeInfo(ctx, 1, types.CodeInfoFixture(func(info *types.CodeInfo) { info.CodeHash = spec.codeHash }))
+			var repair func([]byte) []byte
+			if spec.repairCode != nil {
+				repair = func(codeHash []byte) []byte {
+					assert.Equal(t, spec.codeHash, codeHash)
+					return spec.repairCode
+				}
+			}
+
+			// when
+			var results []CodeCheckResult
+			k.CheckWasmCache(ctx, repair, func(r CodeCheckResult) {
+				results = append(results, r)
+			})
+
+			// then
+			require.Len(t, results, 1)
+			assert.Equal(t, uint64(1), results[0].CodeID)
+			assert.Equal(t, spec.codeHash, results[0].CodeHash)
+			if spec.expErr == nil {
+				assert.NoError(t, results[0].Err)
+			} else {
+				assert.True(t, spec.expErr.Is(results[0].Err), "got %+v", results[0].Err)
+			}
+			assert.Equal(t, spec.expRepaired, results[0].Repaired)
+			assert.Equal(t, spec.expStored, files[string(spec.codeHash)])
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package keeper
+
+import (
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// codeInfoSource is the subset of the keeper methods required for the code info custom query
+type codeInfoSource interface {
+	GetCodeInfo(ctx sdk.Context, codeID uint64) *types.CodeInfo
+}
+
+// CodeInfoCustomQuery is the custom query envelope to query the info of a code
+type CodeInfoCustomQuery struct {
+	CodeInfo *CodeInfoQuery `json:"code_info,omitempty"`
+}
+
+type CodeInfoQuery struct {
+	CodeID uint64 `json:"code_id"`
+}
+
+type CodeInfoQueryResponse struct {
+	CodeID  uint64 `json:"code_id"`
+	Creator string `json:"creator"`
+	// Checksum is the hex encoded sha256 hash of the wasm byte code
+	Checksum string `json:"checksum"`
+}
+
+// NewCodeInfoQuerier handles the code info custom query. Together with the `contract_info` query a factory contract
+// can verify that a contract was instantiated from an expected code hash.
+// All other custom queries are passed to the next querier.
+func NewCodeInfoQuerier(k codeInfoSource, next CustomQuerier) CustomQuerier {
+	return func(ctx sdk.Context, request json.RawMessage) ([]byte, error) {
+		var customQuery CodeInfoCustomQuery
+		if err := json.Unmarshal(request, &customQuery); err != nil || customQuery.CodeInfo == nil {
+			return next(ctx, request)
+		}
+		codeID := customQuery.CodeInfo.CodeID
+		info := k.GetCodeInfo(ctx, codeID)
+		if info == nil {
+			return nil, sdkerrors.Wrapf(types.ErrNotFound, "code id %d", codeID)
+		}
+		return json.Marshal(CodeInfoQueryResponse{
+			CodeID:   codeID,
+			Creator:  info.Creator,
+			Checksum: hex.EncodeToString(info.CodeHash),
+		})
+	}
+}
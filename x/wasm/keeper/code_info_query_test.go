@@ -0,0 +1,102 @@
+package keeper
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	wasmvm "github.com/CosmWasm/wasmvm"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodeInfoQueryFromContract(t *testing.T) {
+	mock := wasmtesting.MockWasmer{}
+	wasmtesting.MakeInstantiable(&mock)
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithWasmEngine(&mock), WithContractInfoQuerier(), WithCodeInfoQuerier())
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	child, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, example.CreatorAddr, nil, []byte(`{}`), "child", nil)
+	require.NoError(t, err)
+
+	var captured CodeInfoQueryResponse
+	mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.Response, uint64, error) {
+		// factory pattern: resolve the code of the claimed child first
+		bz, err := querier.Query(wasmvmtypes.QueryRequest{Custom: []byte(`{"contract_info":{"contract_addr":"` + child.String() + `"}}`)}, gasLimit)
+		require.NoError(t, err)
+		var childInfo ContractInfoQueryResponse
+		require.NoError(t, json.Unmarshal(bz, &childInfo))
+
+		bz, err = querier.Query(wasmvmtypes.QueryRequest{Custom: []byte(`{"code_info":{"code_id":` + strconv.FormatUint(childInfo.CodeID, 10) + `}}`)}, gasLimit)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(bz, &captured))
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+
+	// when
+	_, err = keepers.ContractKeeper.Execute(ctx, example.Contract, RandomAccountAddress(t), []byte(`{}`), nil)
+
+	// then
+	require.NoError(t, err)
+	codeInfo := keepers.WasmKeeper.GetCodeInfo(ctx, example.CodeID)
+	exp := CodeInfoQueryResponse{
+		CodeID:   example.CodeID,
+		Creator:  codeInfo.Creator,
+		Checksum: hex.EncodeToString(codeInfo.CodeHash),
+	}
+	assert.Equal(t, exp, captured)
+}
+
+type codeInfoSourceMock map[uint64]types.CodeInfo
+
+func (m codeInfoSourceMock) GetCodeInfo(ctx sdk.Context, codeID uint64) *types.CodeInfo {
+	info, ok := m[codeID]
+	if !ok {
+		return nil
+	}
+	return &info
+}
+
+func TestCodeInfoQuerier(t *testing.T) {
+	next := func(ctx sdk.Context, request json.RawMessage) ([]byte, error) {
+		return []byte(`"next"`), nil
+	}
+	creatorAddr := RandomBech32AccountAddress(t)
+	src := codeInfoSourceMock{
+		1: {CodeHash: []byte{0x1, 0x2, 0xab}, Creator: creatorAddr},
+	}
+	specs := map[string]struct {
+		srcReq  string
+		expResp string
+		expErr  *sdkerrors.Error
+	}{
+		"known code": {
+			srcReq:  `{"code_info":{"code_id":1}}`,
+			expResp: `{"code_id":1,"creator":"` + creatorAddr + `","checksum":"0102ab"}`,
+		},
+		"unknown code": {
+			srcReq: `{"code_info":{"code_id":2}}`,
+			expErr: types.ErrNotFound,
+		},
+		"other custom query": {
+			srcReq:  `{"foo":{}}`,
+			expResp: `"next"`,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			gotResp, gotErr := NewCodeInfoQuerier(src, next)(sdk.Context{}, []byte(spec.srcReq))
+			if spec.expErr != nil {
+				assert.True(t, spec.expErr.Is(gotErr), "got %+v", gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.JSONEq(t, spec.expResp, string(gotResp))
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package keeper
+
+import (
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+const (
+	// CodeStatsPruningJobType is the type of the end blocker jobs that delete the code execution statistics of old
+	// epochs
+	CodeStatsPruningJobType = "code_stats_pruning"
+	// codeStatsPruningBatchSize is the max number of statistics that a pruning job step iterates
+	codeStatsPruningBatchSize = 100
+)
+
+// recordCodeExecution adds a contract execution and the wasm VM gas used to the statistics of the code in the
+// current epoch. Only calls of the execute entry point are counted. This is a noop unless the keeper was set up with
+// WithCodeExecutionStats.
+func (k Keeper) recordCodeExecution(ctx sdk.Context, codeID uint64, wasmVMGasUsed uint64) {
+	if k.codeStatsEpochLength == 0 {
+		return
+	}
+	epoch := uint64(ctx.BlockHeight()) / k.codeStatsEpochLength
+	stats := k.GetCodeExecutionStats(ctx, codeID, epoch)
+	stats.Executions++
+	stats.GasUsed += k.gasRegister.FromWasmVMGas(wasmVMGasUsed)
+	k.storeCodeExecutionStats(ctx, codeID, stats)
+}
+
+func (k Keeper) storeCodeExecutionStats(ctx sdk.Context, codeID uint64, stats types.CodeExecutionStats) {
+	ctx.KVStore(k.storeKey).Set(types.GetCodeExecutionStatsKey(codeID, stats.Epoch), k.cdc.MustMarshalBinaryBare(&stats))
+}
+
+// GetCodeExecutionStats returns the execution statistics of the code in the epoch. The counters are zero when the
+// code was not executed or the statistics are not enabled.
+func (k Keeper) GetCodeExecutionStats(ctx sdk.Context, codeID, epoch uint64) types.CodeExecutionStats {
+	stats := types.CodeExecutionStats{Epoch: epoch}
+	bz := ctx.KVStore(k.storeKey).Get(types.GetCodeExecutionStatsKey(codeID, epoch))
+	if bz != nil {
+		k.cdc.MustUnmarshalBinaryBare(bz, &stats)
+	}
+	return stats
+}
+
+// IterateCodeExecutionStats iterates the execution statistics of all codes ordered by code id and epoch.
+// When the callback returns true the iteration is stopped.
+func (k Keeper) IterateCodeExecutionStats(ctx sdk.Context, cb func(codeID uint64, stats types.CodeExecutionStats) bool) {
+	iter := prefix.NewStore(ctx.KVStore(k.storeKey), types.CodeExecutionStatsPrefix).Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var stats types.CodeExecutionStats
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &stats)
+		if cb(sdk.BigEndianToUint64(iter.Key()[:8]), stats) {
+			return
+		}
+	}
+}
+
+// importCodeExecutionStats stores the execution statistics of a code from genesis. The code must exist.
+func (k Keeper) importCodeExecutionStats(ctx sdk.Context, s types.CodeStats) error {
+	if k.GetCodeInfo(ctx, s.CodeID) == nil {
+		return sdkerrors.Wrapf(types.ErrNotFound, "code id: %d", s.CodeID)
+	}
+	if ctx.KVStore(k.storeKey).Has(types.GetCodeExecutionStatsKey(s.CodeID, s.Stats.Epoch)) {
+		return sdkerrors.Wrapf(types.ErrDuplicate, "code id %d epoch %d", s.CodeID, s.Stats.Epoch)
+	}
+	k.storeCodeExecutionStats(ctx, s.CodeID, s.Stats)
+	return nil
+}
+
+// QueueCodeStatsPruning queues a job at the start of an epoch that deletes the execution statistics of all codes that
+// are older than the kept epochs, see WithCodeExecutionStats. No job is queued while a pruning job is pending.
+// It is called in the end blocker.
+func (k Keeper) QueueCodeStatsPruning(ctx sdk.Context) {
+	if k.codeStatsEpochLength == 0 || k.codeStatsKeepEpochs == 0 {
+		return
+	}
+	height := uint64(ctx.BlockHeight())
+	epoch := height / k.codeStatsEpochLength
+	if height%k.codeStatsEpochLength != 0 || epoch < k.codeStatsKeepEpochs {
+		return
+	}
+	var pending bool
+	k.IterateJobs(ctx, func(job types.Job) bool {
+		pending = job.Type == CodeStatsPruningJobType
+		return pending
+	})
+	if pending {
+		return
+	}
+	payload := k.cdc.MustMarshalBinaryBare(&types.CodeStatsPruning{BeforeEpoch: epoch + 1 - k.codeStatsKeepEpochs})
+	if _, err := k.QueueJob(ctx, CodeStatsPruningJobType, payload, 0); err != nil {
+		k.Logger(ctx).Error("queue code stats pruning", "error", err.Error())
+	}
+}
+
+// codeStatsPruningJobHandler deletes the execution statistics of old epochs. A step iterates a batch of the
+// statistics of all codes. The job is done when there are no statistics left.
+type codeStatsPruningJobHandler struct {
+	keeper Keeper
+}
+
+func (h codeStatsPruningJobHandler) Step(ctx sdk.Context, payload []byte) ([]byte, bool, error) {
+	k := h.keeper
+	var p types.CodeStatsPruning
+	if err := k.cdc.UnmarshalBinaryBare(payload, &p); err != nil {
+		return nil, false, sdkerrors.Wrap(err, "payload")
+	}
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.CodeExecutionStatsPrefix)
+	iter := prefixStore.Iterator(p.NextKey, nil)
+	var expired [][]byte
+	for i := 0; iter.Valid() && i < codeStatsPruningBatchSize; i++ {
+		// key: <codeID><epoch>
+		key := append([]byte{}, iter.Key()...)
+		if sdk.BigEndianToUint64(key[8:]) < p.BeforeEpoch {
+			expired = append(expired, key)
+		}
+		p.NextKey = append(key, 0)
+		iter.Next()
+	}
+	more := iter.Valid()
+	iter.Close()
+	// delete after the iteration is closed
+	for _, key := range expired {
+		prefixStore.Delete(key)
+	}
+	bz, err := k.cdc.MarshalBinaryBare(&p)
+	return bz, !more, err
+}
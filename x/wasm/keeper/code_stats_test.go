@@ -0,0 +1,107 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	wasmvm "github.com/CosmWasm/wasmvm"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodeExecutionStats(t *testing.T) {
+	specs := map[string]struct {
+		srcOpts  []Option
+		expStats []types.CodeExecutionStats
+	}{
+		"enabled": {
+			srcOpts: []Option{WithCodeExecutionStats(10, 0)},
+			expStats: []types.CodeExecutionStats{
+				{Epoch: 0, Executions: 2, GasUsed: 2},
+				{Epoch: 1, Executions: 1, GasUsed: 1},
+			},
+		},
+		"disabled": {
+			expStats: []types.CodeExecutionStats{},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, keepers := CreateTestInput(t, false, SupportedFeatures, spec.srcOpts...)
+			mock := wasmtesting.MockWasmer{}
+			wasmtesting.MakeInstantiable(&mock)
+			example := SeedNewContractInstance(t, ctx, keepers, &mock)
+			mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.Response, uint64, error) {
+				return &wasmvmtypes.Response{}, DefaultGasMultiplier, nil
+			}
+
+			// when executed in different epochs
+			for _, height := range []int64{1, 9, 10} {
+				_, err := keepers.ContractKeeper.Execute(ctx.WithBlockHeight(height), example.Contract, example.CreatorAddr, []byte(`{}`), nil)
+				require.NoError(t, err)
+			}
+
+			// then
+			q := Querier(keepers.WasmKeeper)
+			rsp, err := q.CodeExecutionStats(sdk.WrapSDKContext(ctx), &types.QueryCodeExecutionStatsRequest{CodeId: example.CodeID, Pagination: &query.PageRequest{}})
+			require.NoError(t, err)
+			assert.Equal(t, spec.expStats, rsp.Stats)
+		})
+	}
+}
+
+func TestQueryCodeExecutionStatsInvalidRequest(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithCodeExecutionStats(10, 0))
+	q := Querier(keepers.WasmKeeper)
+	_, err := q.CodeExecutionStats(sdk.WrapSDKContext(ctx), &types.QueryCodeExecutionStatsRequest{})
+	assert.True(t, types.ErrInvalid.Is(err), "got %+v", err)
+}
+
+func TestCodeStatsPruning(t *testing.T) {
+	const epochLength, keepEpochs = 10, 2
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithCodeExecutionStats(epochLength, keepEpochs))
+	k := keepers.WasmKeeper
+	// more statistics than a job step iterates
+	for codeID := uint64(1); codeID <= 3; codeID++ {
+		for epoch := uint64(0); epoch < 50; epoch++ {
+			k.storeCodeExecutionStats(ctx, codeID, types.CodeExecutionStats{Epoch: epoch, Executions: 1})
+		}
+	}
+
+	// not the start of an epoch
+	ctx = ctx.WithBlockHeight(epochLength*49 + 1)
+	k.QueueCodeStatsPruning(ctx)
+	k.IterateJobs(ctx, func(job types.Job) bool {
+		t.Fatalf("unexpected job: %s", job.Type)
+		return true
+	})
+
+	// when
+	ctx = ctx.WithBlockHeight(epochLength * 49)
+	k.QueueCodeStatsPruning(ctx)
+	k.QueueCodeStatsPruning(ctx) // a second job is not queued while one is pending
+	var jobs int
+	k.IterateJobs(ctx, func(job types.Job) bool {
+		assert.Equal(t, CodeStatsPruningJobType, job.Type)
+		jobs++
+		return false
+	})
+	assert.Equal(t, 1, jobs)
+	k.ProcessJobs(ctx)
+
+	// then
+	var got []uint64
+	k.IterateCodeExecutionStats(ctx, func(codeID uint64, stats types.CodeExecutionStats) bool {
+		got = append(got, codeID, stats.Epoch)
+		return false
+	})
+	assert.Equal(t, []uint64{1, 48, 1, 49, 2, 48, 2, 49, 3, 48, 3, 49}, got)
+	k.IterateJobs(ctx, func(job types.Job) bool {
+		t.Fatalf("unexpected job: %s", job.Type)
+		return true
+	})
+}
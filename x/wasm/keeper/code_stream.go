@@ -0,0 +1,70 @@
+package keeper
+
+import (
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// DefaultCodeStreamChunkSize is the number of byte code bytes per message when the request does not set one
+	DefaultCodeStreamChunkSize = 64 * 1024
+	// MaxCodeStreamChunkSize is the max number of byte code bytes per message. It is well below the default gRPC
+	// message size limit of 4MB.
+	MaxCodeStreamChunkSize = 1024 * 1024
+)
+
+// codeQueryPath is the ABCI query path of the Query/Code gRPC method
+const codeQueryPath = "/cosmwasm.wasm.v1beta1.Query/Code"
+
+// ABCIQuerier runs ABCI queries against the latest committed state. The BaseApp of the chain is an ABCIQuerier.
+type ABCIQuerier interface {
+	Query(req abci.RequestQuery) abci.ResponseQuery
+}
+
+// CodeStream sends the byte code in chunks. Streams are not routed through ABCI by the SDK so that the byte code
+// is loaded with an ABCI query on the committed state, see WithCodeStream.
+func (q grpcQuerier) CodeStream(req *types.QueryCodeStreamRequest, stream types.Query_CodeStreamServer) error {
+	if req == nil {
+		return status.Error(codes.InvalidArgument, "empty request")
+	}
+	if q.abciQuerier == nil {
+		return status.Error(codes.Unimplemented, "code stream not enabled")
+	}
+	if req.CodeId == 0 {
+		return sdkerrors.Wrap(types.ErrInvalid, "code id")
+	}
+	chunkSize := int(req.ChunkSize)
+	switch {
+	case chunkSize == 0:
+		chunkSize = DefaultCodeStreamChunkSize
+	case chunkSize > MaxCodeStreamChunkSize:
+		return sdkerrors.Wrapf(types.ErrLimit, "chunk size must not exceed %d", MaxCodeStreamChunkSize)
+	}
+
+	bz, err := q.cdc.MarshalBinaryBare(&types.QueryCodeRequest{CodeId: req.CodeId})
+	if err != nil {
+		return sdkerrors.Wrap(err, "code request")
+	}
+	res := q.abciQuerier.Query(abci.RequestQuery{Path: codeQueryPath, Data: bz})
+	if !res.IsOK() {
+		return sdkerrors.ABCIError(res.Codespace, res.Code, res.Log)
+	}
+	var rsp types.QueryCodeResponse
+	if err := q.cdc.UnmarshalBinaryBare(res.Value, &rsp); err != nil {
+		return sdkerrors.Wrap(err, "code response")
+	}
+
+	for pos := 0; pos < len(rsp.Data); pos += chunkSize {
+		end := pos + chunkSize
+		if end > len(rsp.Data) {
+			end = len(rsp.Data)
+		}
+		if err := stream.Send(&types.QueryCodeStreamResponse{Data: rsp.Data[pos:end]}); err != nil {
+			return err
+		}
+	}
+	return stream.Send(&types.QueryCodeStreamResponse{Checksum: rsp.DataHash})
+}
@@ -0,0 +1,132 @@
+package keeper
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"io/ioutil"
+	"testing"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCodeStream(t *testing.T) {
+	wasmCode, err := ioutil.ReadFile("./testdata/hackatom.wasm")
+	require.NoError(t, err)
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	k := keepers.WasmKeeper
+	require.NoError(t, k.importCode(ctx, 1, types.CodeInfoFixture(types.WithSHA256CodeHash(wasmCode)), wasmCode))
+
+	router := baseapp.NewGRPCQueryRouter()
+	types.RegisterQueryServer(router, Querier(k))
+	k.codeStreamQuerier = routerABCIQuerier{ctx: ctx, router: router}
+
+	specs := map[string]struct {
+		src          *types.QueryCodeStreamRequest
+		expChunkSize int
+		expErr       *sdkerrors.Error
+	}{
+		"default chunk size": {
+			src:          &types.QueryCodeStreamRequest{CodeId: 1},
+			expChunkSize: DefaultCodeStreamChunkSize,
+		},
+		"custom chunk size": {
+			src:          &types.QueryCodeStreamRequest{CodeId: 1, ChunkSize: 1000},
+			expChunkSize: 1000,
+		},
+		"chunk size larger than code": {
+			src:          &types.QueryCodeStreamRequest{CodeId: 1, ChunkSize: MaxCodeStreamChunkSize},
+			expChunkSize: len(wasmCode),
+		},
+		"chunk size exceeds max": {
+			src:    &types.QueryCodeStreamRequest{CodeId: 1, ChunkSize: MaxCodeStreamChunkSize + 1},
+			expErr: types.ErrLimit,
+		},
+		"code not found": {
+			src:    &types.QueryCodeStreamRequest{CodeId: 2},
+			expErr: types.ErrNotFound,
+		},
+		"invalid code id": {
+			src:    &types.QueryCodeStreamRequest{},
+			expErr: types.ErrInvalid,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			stream := &mockCodeStream{ctx: context.Background()}
+			// when
+			gotErr := Querier(k).CodeStream(spec.src, stream)
+			// then
+			if spec.expErr != nil {
+				require.True(t, spec.expErr.Is(gotErr), "but got %+v", gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			require.NotEmpty(t, stream.sent)
+			last := stream.sent[len(stream.sent)-1]
+			assert.Empty(t, last.Data)
+			expChecksum := sha256.Sum256(wasmCode)
+			assert.Equal(t, expChecksum[:], []byte(last.Checksum))
+
+			var got bytes.Buffer
+			for i, msg := range stream.sent[:len(stream.sent)-1] {
+				assert.Empty(t, msg.Checksum)
+				if i < len(stream.sent)-2 {
+					assert.Len(t, msg.Data, spec.expChunkSize)
+				}
+				got.Write(msg.Data)
+			}
+			assert.Equal(t, wasmCode, got.Bytes())
+		})
+	}
+}
+
+func TestCodeStreamNotEnabled(t *testing.T) {
+	_, keepers := CreateTestInput(t, false, SupportedFeatures)
+	err := Querier(keepers.WasmKeeper).CodeStream(&types.QueryCodeStreamRequest{CodeId: 1}, &mockCodeStream{ctx: context.Background()})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+// routerABCIQuerier routes the ABCI queries to the gRPC query router like the BaseApp
+type routerABCIQuerier struct {
+	ctx    sdk.Context
+	router *baseapp.GRPCQueryRouter
+}
+
+func (r routerABCIQuerier) Query(req abci.RequestQuery) abci.ResponseQuery {
+	handler := r.router.Route(req.Path)
+	if handler == nil {
+		return sdkerrors.QueryResult(sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, req.Path))
+	}
+	res, err := handler(r.ctx, req)
+	if err != nil {
+		return sdkerrors.QueryResult(err)
+	}
+	return res
+}
+
+var _ types.Query_CodeStreamServer = &mockCodeStream{}
+
+type mockCodeStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []*types.QueryCodeStreamResponse
+}
+
+func (m *mockCodeStream) Context() context.Context {
+	return m.ctx
+}
+
+func (m *mockCodeStream) Send(rsp *types.QueryCodeStreamResponse) error {
+	m.sent = append(m.sent, rsp)
+	return nil
+}
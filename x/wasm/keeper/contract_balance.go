@@ -0,0 +1,21 @@
+package keeper
+
+import (
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ExpectedBalanceQuery is the smart query convention for contracts to report the funds that they account for
+type ExpectedBalanceQuery struct {
+	ExpectedBalance *struct{} `json:"expected_balance"`
+}
+
+// ExpectedBalanceResponse is the response to the ExpectedBalanceQuery
+type ExpectedBalanceResponse struct {
+	Amount wasmvmtypes.Coins `json:"amount"`
+}
+
+// GetContractBalance returns the bank balance of the contract
+func (k Keeper) GetContractBalance(ctx sdk.Context, contractAddress sdk.AccAddress) sdk.Coins {
+	return k.bankViewKeeper.GetAllBalances(ctx, contractAddress)
+}
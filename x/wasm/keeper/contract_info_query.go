@@ -0,0 +1,62 @@
+package keeper
+
+import (
+	"encoding/json"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// contractInfoSource is the subset of the keeper methods required for the contract info custom query
+type contractInfoSource interface {
+	contractMetaDataSource
+	IsPinnedCode(ctx sdk.Context, codeID uint64) bool
+}
+
+// ContractInfoCustomQuery is the custom query envelope to query the info of a contract
+type ContractInfoCustomQuery struct {
+	ContractInfo *ContractInfoQuery `json:"contract_info,omitempty"`
+}
+
+type ContractInfoQuery struct {
+	// ContractAddr is the bech32 address of any contract, including the querying one
+	ContractAddr string `json:"contract_addr"`
+}
+
+type ContractInfoQueryResponse struct {
+	CodeID  uint64 `json:"code_id"`
+	Creator string `json:"creator"`
+	// Admin is empty when the contract can not be migrated
+	Admin  string `json:"admin,omitempty"`
+	Pinned bool   `json:"pinned"`
+	// IBCPort is empty when the contract is not IBC enabled
+	IBCPort string `json:"ibc_port,omitempty"`
+}
+
+// NewContractInfoQuerier handles the contract info custom query. The `Wasm` query of wasmvm has no contract info
+// variant in this version so the info is provided as custom query.
+// All other custom queries are passed to the next querier.
+func NewContractInfoQuerier(k contractInfoSource, next CustomQuerier) CustomQuerier {
+	return func(ctx sdk.Context, request json.RawMessage) ([]byte, error) {
+		var customQuery ContractInfoCustomQuery
+		if err := json.Unmarshal(request, &customQuery); err != nil || customQuery.ContractInfo == nil {
+			return next(ctx, request)
+		}
+		addr, err := sdk.AccAddressFromBech32(customQuery.ContractInfo.ContractAddr)
+		if err != nil {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, customQuery.ContractInfo.ContractAddr)
+		}
+		info := k.GetContractInfo(ctx, addr)
+		if info == nil {
+			return nil, sdkerrors.Wrap(types.ErrNotFound, "contract")
+		}
+		return json.Marshal(ContractInfoQueryResponse{
+			CodeID:  info.CodeID,
+			Creator: info.Creator,
+			Admin:   info.Admin,
+			Pinned:  k.IsPinnedCode(ctx, info.CodeID),
+			IBCPort: info.IBCPortID,
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package keeper
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	wasmvm "github.com/CosmWasm/wasmvm"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContractInfoQueryFromContract(t *testing.T) {
+	mock := wasmtesting.MockWasmer{}
+	wasmtesting.MakeInstantiable(&mock)
+	mock.PinFn = func(checksum wasmvm.Checksum) error { return nil }
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithWasmEngine(&mock), WithContractInfoQuerier())
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	require.NoError(t, keepers.ContractKeeper.PinCode(ctx, example.CodeID))
+
+	var captured ContractInfoQueryResponse
+	mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.Response, uint64, error) {
+		bz, err := querier.Query(wasmvmtypes.QueryRequest{Custom: []byte(`{"contract_info":{"contract_addr":"` + env.Contract.Address + `"}}`)}, gasLimit)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(bz, &captured))
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+
+	// when
+	_, err := keepers.ContractKeeper.Execute(ctx, example.Contract, RandomAccountAddress(t), []byte(`{}`), nil)
+
+	// then
+	require.NoError(t, err)
+	exp := ContractInfoQueryResponse{
+		CodeID:  example.CodeID,
+		Creator: example.CreatorAddr.String(),
+		Admin:   example.CreatorAddr.String(),
+		Pinned:  true,
+	}
+	assert.Equal(t, exp, captured)
+}
+
+type contractInfoSourceMock struct {
+	contracts map[string]types.ContractInfo
+	pinned    map[uint64]bool
+}
+
+func (m contractInfoSourceMock) GetContractInfo(ctx sdk.Context, contractAddress sdk.AccAddress) *types.ContractInfo {
+	info, ok := m.contracts[contractAddress.String()]
+	if !ok {
+		return nil
+	}
+	return &info
+}
+
+func (m contractInfoSourceMock) IsPinnedCode(ctx sdk.Context, codeID uint64) bool {
+	return m.pinned[codeID]
+}
+
+func TestContractInfoQuerier(t *testing.T) {
+	next := func(ctx sdk.Context, request json.RawMessage) ([]byte, error) {
+		return []byte(`"next"`), nil
+	}
+	myAddr, ibcAddr, creatorAddr := RandomAccountAddress(t), RandomAccountAddress(t), RandomAccountAddress(t)
+	src := contractInfoSourceMock{
+		contracts: map[string]types.ContractInfo{
+			myAddr.String():  {CodeID: 1, Creator: creatorAddr.String(), Admin: creatorAddr.String()},
+			ibcAddr.String(): {CodeID: 2, Creator: creatorAddr.String(), IBCPortID: "wasm." + ibcAddr.String()},
+		},
+		pinned: map[uint64]bool{2: true},
+	}
+	specs := map[string]struct {
+		srcReq  string
+		expResp string
+		expErr  *sdkerrors.Error
+	}{
+		"contract with admin": {
+			srcReq:  `{"contract_info":{"contract_addr":"` + myAddr.String() + `"}}`,
+			expResp: `{"code_id":1,"creator":"` + creatorAddr.String() + `","admin":"` + creatorAddr.String() + `","pinned":false}`,
+		},
+		"pinned ibc contract": {
+			srcReq:  `{"contract_info":{"contract_addr":"` + ibcAddr.String() + `"}}`,
+			expResp: `{"code_id":2,"creator":"` + creatorAddr.String() + `","pinned":true,"ibc_port":"wasm.` + ibcAddr.String() + `"}`,
+		},
+		"unknown contract": {
+			srcReq: `{"contract_info":{"contract_addr":"` + RandomBech32AccountAddress(t) + `"}}`,
+			expErr: types.ErrNotFound,
+		},
+		"invalid address": {
+			srcReq: `{"contract_info":{"contract_addr":"invalid"}}`,
+			expErr: sdkerrors.ErrInvalidAddress,
+		},
+		"other custom query": {
+			srcReq:  `{"foo":{}}`,
+			expResp: `"next"`,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			gotResp, gotErr := NewContractInfoQuerier(src, next)(sdk.Context{}, []byte(spec.srcReq))
+			if spec.expErr != nil {
+				assert.True(t, spec.expErr.Is(gotErr), "got %+v", gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.JSONEq(t, spec.expResp, string(gotResp))
+		})
+	}
+}
@@ -3,20 +3,42 @@ package keeper
 import (
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	clienttypes "github.com/cosmos/cosmos-sdk/x/ibc/core/02-client/types"
 )
 
 var _ types.ContractOpsKeeper = PermissionedKeeper{}
+var _ types.CommunityPoolOpsKeeper = PermissionedKeeper{}
+var _ types.ExecuteWithCallbackOpsKeeper = PermissionedKeeper{}
+var _ types.DeniedDenomsOpsKeeper = PermissionedKeeper{}
+var _ types.ContractFundsRecoveryOpsKeeper = PermissionedKeeper{}
+var _ types.StoreCodeDepositOpsKeeper = PermissionedKeeper{}
+var _ types.ContractVestingOpsKeeper = PermissionedKeeper{}
+var _ types.InterchainQueryOpsKeeper = PermissionedKeeper{}
+var _ types.ChannelReopenOpsKeeper = PermissionedKeeper{}
+var _ types.MigrateAllContractsOpsKeeper = PermissionedKeeper{}
+var _ types.ReentrancyGuardOpsKeeper = PermissionedKeeper{}
 
 // decoratedKeeper contains a subset of the wasm keeper that are already or can be guarded by an authorization policy in the future
 type decoratedKeeper interface {
 	create(ctx sdk.Context, creator sdk.AccAddress, wasmCode []byte, source string, builder string, instantiateAccess *types.AccessConfig, authZ AuthorizationPolicy) (codeID uint64, err error)
 	instantiate(ctx sdk.Context, codeID uint64, creator, admin sdk.AccAddress, initMsg []byte, label string, deposit sdk.Coins, authZ AuthorizationPolicy) (sdk.AccAddress, []byte, error)
 	migrate(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, newCodeID uint64, msg []byte, authZ AuthorizationPolicy) ([]byte, error)
+	migrateAllContracts(ctx sdk.Context, codeID, newCodeID uint64, caller sdk.AccAddress, msg []byte, contractsPerBlock uint32, authZ AuthorizationPolicy) (uint64, error)
 	setContractAdmin(ctx sdk.Context, contractAddress, caller, newAdmin sdk.AccAddress, authZ AuthorizationPolicy) error
+	setDeniedDenoms(ctx sdk.Context, contractAddress, caller sdk.AccAddress, denoms []string, authZ AuthorizationPolicy) error
+	setReentrancyGuard(ctx sdk.Context, contractAddress, caller sdk.AccAddress, enabled bool, authZ AuthorizationPolicy) error
+	collectStoreCodeDeposit(ctx sdk.Context, depositor sdk.AccAddress) error
 	pinCode(ctx sdk.Context, codeID uint64) error
 	unpinCode(ctx sdk.Context, codeID uint64) error
-	execute(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, msg []byte, coins sdk.Coins) ([]byte, error)
+	execute(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, msg []byte, coins sdk.Coins, authZ AuthorizationPolicy) ([]byte, error)
 	setContractInfoExtension(ctx sdk.Context, contract sdk.AccAddress, extra types.ContractInfoExtension) error
+	fundFromCommunityPool(ctx sdk.Context, recipient sdk.AccAddress, amount sdk.Coins, authZ AuthorizationPolicy) error
+	recoverContractFunds(ctx sdk.Context, contractAddress, recipient sdk.AccAddress, amount sdk.Coins, authZ AuthorizationPolicy) error
+	setContractVesting(ctx sdk.Context, contractAddress sdk.AccAddress, schedule types.ContractVestingSchedule, authZ AuthorizationPolicy) error
+	registerInterchainQuery(ctx sdk.Context, owner sdk.AccAddress, connectionID string, keys []types.InterchainQueryKey, updatePeriod uint64) (uint64, error)
+	removeInterchainQuery(ctx sdk.Context, owner sdk.AccAddress, queryID uint64) error
+	submitInterchainQueryResult(ctx sdk.Context, queryID uint64, height clienttypes.Height, results []types.InterchainQueryResult) error
+	reopenChannel(ctx sdk.Context, contractAddr sdk.AccAddress, channelID string) (string, error)
 }
 
 type PermissionedKeeper struct {
@@ -45,13 +67,34 @@ func (p PermissionedKeeper) Instantiate(ctx sdk.Context, codeID uint64, creator,
 }
 
 func (p PermissionedKeeper) Execute(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, msg []byte, coins sdk.Coins) ([]byte, error) {
-	return p.nested.execute(ctx, contractAddress, caller, msg, coins)
+	return p.nested.execute(ctx, contractAddress, caller, msg, coins, p.authZPolicy)
+}
+
+// ExecuteWithCallback executes the contract in a cache context. The state changes and events are only committed
+// when the execution succeeds. The callback is then called with the outcome in the parent context.
+func (p PermissionedKeeper) ExecuteWithCallback(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, msg []byte, coins sdk.Coins, cb types.OnWasmResult) error {
+	em := sdk.NewEventManager()
+	cacheCtx, commit := ctx.CacheContext()
+	data, err := p.nested.execute(cacheCtx.WithEventManager(em), contractAddress, caller, msg, coins, p.authZPolicy)
+	result := types.WasmResult{Data: data, Err: err}
+	if err == nil {
+		commit()
+		result.Events = em.Events()
+		ctx.EventManager().EmitEvents(result.Events)
+	}
+	return cb(ctx, result)
 }
 
 func (p PermissionedKeeper) Migrate(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, newCodeID uint64, msg []byte) ([]byte, error) {
 	return p.nested.migrate(ctx, contractAddress, caller, newCodeID, msg, p.authZPolicy)
 }
 
+// MigrateAllContracts migrates all contracts of the code to the new code, in chunks by the end blocker when
+// contractsPerBlock is not zero. Requires a policy that allows to migrate all contracts.
+func (p PermissionedKeeper) MigrateAllContracts(ctx sdk.Context, codeID, newCodeID uint64, caller sdk.AccAddress, msg []byte, contractsPerBlock uint32) (uint64, error) {
+	return p.nested.migrateAllContracts(ctx, codeID, newCodeID, caller, msg, contractsPerBlock, p.authZPolicy)
+}
+
 func (p PermissionedKeeper) UpdateContractAdmin(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, newAdmin sdk.AccAddress) error {
 	return p.nested.setContractAdmin(ctx, contractAddress, caller, newAdmin, p.authZPolicy)
 }
@@ -60,6 +103,18 @@ func (p PermissionedKeeper) ClearContractAdmin(ctx sdk.Context, contractAddress
 	return p.nested.setContractAdmin(ctx, contractAddress, caller, nil, p.authZPolicy)
 }
 
+func (p PermissionedKeeper) UpdateDeniedDenoms(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, denoms []string) error {
+	return p.nested.setDeniedDenoms(ctx, contractAddress, caller, denoms, p.authZPolicy)
+}
+
+func (p PermissionedKeeper) UpdateReentrancyGuard(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, enabled bool) error {
+	return p.nested.setReentrancyGuard(ctx, contractAddress, caller, enabled, p.authZPolicy)
+}
+
+func (p PermissionedKeeper) CollectStoreCodeDeposit(ctx sdk.Context, depositor sdk.AccAddress) error {
+	return p.nested.collectStoreCodeDeposit(ctx, depositor)
+}
+
 func (p PermissionedKeeper) PinCode(ctx sdk.Context, codeID uint64) error {
 	return p.nested.pinCode(ctx, codeID)
 }
@@ -72,3 +127,36 @@ func (p PermissionedKeeper) UnpinCode(ctx sdk.Context, codeID uint64) error {
 func (p PermissionedKeeper) SetContractInfoExtension(ctx sdk.Context, contract sdk.AccAddress, extra types.ContractInfoExtension) error {
 	return p.nested.setContractInfoExtension(ctx, contract, extra)
 }
+
+// FundFromCommunityPool sends the given amount from the community pool to the recipient. Requires a policy that allows community pool spends.
+func (p PermissionedKeeper) FundFromCommunityPool(ctx sdk.Context, recipient sdk.AccAddress, amount sdk.Coins) error {
+	return p.nested.fundFromCommunityPool(ctx, recipient, amount, p.authZPolicy)
+}
+
+// RecoverContractFunds sends the given amount from a bricked contract to the recipient. Requires a policy that allows
+// the recovery.
+func (p PermissionedKeeper) RecoverContractFunds(ctx sdk.Context, contractAddress, recipient sdk.AccAddress, amount sdk.Coins) error {
+	return p.nested.recoverContractFunds(ctx, contractAddress, recipient, amount, p.authZPolicy)
+}
+
+// SetContractVesting locks the contract funds with the vesting schedule. Requires a policy that allows to set the
+// vesting.
+func (p PermissionedKeeper) SetContractVesting(ctx sdk.Context, contractAddress sdk.AccAddress, schedule types.ContractVestingSchedule) error {
+	return p.nested.setContractVesting(ctx, contractAddress, schedule, p.authZPolicy)
+}
+
+func (p PermissionedKeeper) RegisterInterchainQuery(ctx sdk.Context, contractAddress sdk.AccAddress, connectionID string, keys []types.InterchainQueryKey, updatePeriod uint64) (uint64, error) {
+	return p.nested.registerInterchainQuery(ctx, contractAddress, connectionID, keys, updatePeriod)
+}
+
+func (p PermissionedKeeper) RemoveInterchainQuery(ctx sdk.Context, contractAddress sdk.AccAddress, queryID uint64) error {
+	return p.nested.removeInterchainQuery(ctx, contractAddress, queryID)
+}
+
+func (p PermissionedKeeper) SubmitInterchainQueryResult(ctx sdk.Context, queryID uint64, height clienttypes.Height, results []types.InterchainQueryResult) error {
+	return p.nested.submitInterchainQueryResult(ctx, queryID, height, results)
+}
+
+func (p PermissionedKeeper) ReopenChannel(ctx sdk.Context, contractAddress sdk.AccAddress, channelID string) (string, error) {
+	return p.nested.reopenChannel(ctx, contractAddress, channelID)
+}
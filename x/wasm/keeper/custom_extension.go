@@ -0,0 +1,63 @@
+package keeper
+
+import (
+	"encoding/json"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// CustomExtension bundles the custom contract messages and queries of a chain module. They are addressed by a top
+// level JSON key with the extension name, like `{"<name>":{...}}`, and the encoder and querier receive the content
+// of this key only.
+type CustomExtension struct {
+	// Name is the JSON key of the custom messages and queries
+	Name string
+	// Encoder is optional and encodes the custom message content into sdk messages
+	Encoder CustomEncoder
+	// Querier is optional and handles the custom query content
+	Querier CustomQuerier
+	// RegisterInterfaces is optional and registers the sdk message types returned by the encoder with the interface
+	// registry so that they can be packed into and unpacked from Any
+	RegisterInterfaces func(registry codectypes.InterfaceRegistry)
+}
+
+// NewCustomExtensionEncoder returns a custom encoder that passes the messages addressed to the extension to its
+// encoder. Other custom messages are passed to the next encoder.
+func NewCustomExtensionEncoder(ext CustomExtension, next CustomEncoder) CustomEncoder {
+	if ext.Encoder == nil {
+		return next
+	}
+	return func(sender sdk.AccAddress, msg json.RawMessage) ([]sdk.Msg, error) {
+		content, ok := customExtensionContent(ext.Name, msg)
+		if !ok {
+			return next(sender, msg)
+		}
+		return ext.Encoder(sender, content)
+	}
+}
+
+// NewCustomExtensionQuerier returns a custom querier that passes the queries addressed to the extension to its
+// querier. Other custom queries are passed to the next querier.
+func NewCustomExtensionQuerier(ext CustomExtension, next CustomQuerier) CustomQuerier {
+	if ext.Querier == nil {
+		return next
+	}
+	return func(ctx sdk.Context, request json.RawMessage) ([]byte, error) {
+		content, ok := customExtensionContent(ext.Name, request)
+		if !ok {
+			return next(ctx, request)
+		}
+		return ext.Querier(ctx, content)
+	}
+}
+
+// customExtensionContent returns the content of the JSON object when it has the extension name as only key
+func customExtensionContent(name string, msg json.RawMessage) (json.RawMessage, bool) {
+	var envelope map[string]json.RawMessage
+	if err := json.Unmarshal(msg, &envelope); err != nil || len(envelope) != 1 {
+		return nil, false
+	}
+	content, ok := envelope[name]
+	return content, ok
+}
@@ -0,0 +1,135 @@
+package keeper
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomExtension(t *testing.T) {
+	var myMsg = &types.MsgClearAdmin{Sender: RandomBech32AccountAddress(t), Contract: RandomBech32AccountAddress(t)}
+	ext := CustomExtension{
+		Name: "my_ext",
+		Encoder: func(sender sdk.AccAddress, msg json.RawMessage) ([]sdk.Msg, error) {
+			if string(msg) != `{"foo":"bar"}` {
+				return nil, errors.New("unexpected content")
+			}
+			return []sdk.Msg{myMsg}, nil
+		},
+		Querier: func(ctx sdk.Context, request json.RawMessage) ([]byte, error) {
+			if string(request) != `{"foo":"bar"}` {
+				return nil, errors.New("unexpected content")
+			}
+			return []byte(`"my response"`), nil
+		},
+	}
+	nextEncoder := func(sender sdk.AccAddress, msg json.RawMessage) ([]sdk.Msg, error) {
+		return nil, types.ErrUnknownMsg
+	}
+	nextQuerier := func(ctx sdk.Context, request json.RawMessage) ([]byte, error) {
+		return nil, types.ErrUnknownMsg
+	}
+
+	specs := map[string]struct {
+		src     string
+		expNext bool
+	}{
+		"addressed to extension": {
+			src: `{"my_ext":{"foo":"bar"}}`,
+		},
+		"other extension": {
+			src:     `{"other_ext":{"foo":"bar"}}`,
+			expNext: true,
+		},
+		"multiple keys": {
+			src:     `{"my_ext":{"foo":"bar"},"other_ext":{}}`,
+			expNext: true,
+		},
+		"no object": {
+			src:     `"my_ext"`,
+			expNext: true,
+		},
+		"invalid json": {
+			src:     `not json`,
+			expNext: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			gotMsgs, gotEncErr := NewCustomExtensionEncoder(ext, nextEncoder)(RandomAccountAddress(t), []byte(spec.src))
+			gotRsp, gotQueryErr := NewCustomExtensionQuerier(ext, nextQuerier)(sdk.Context{}, []byte(spec.src))
+			if spec.expNext {
+				assert.True(t, types.ErrUnknownMsg.Is(gotEncErr), "got %+v", gotEncErr)
+				assert.True(t, types.ErrUnknownMsg.Is(gotQueryErr), "got %+v", gotQueryErr)
+				return
+			}
+			require.NoError(t, gotEncErr)
+			require.NoError(t, gotQueryErr)
+			assert.Equal(t, []sdk.Msg{myMsg}, gotMsgs)
+			assert.Equal(t, []byte(`"my response"`), gotRsp)
+		})
+	}
+}
+
+func TestWithCustomExtensions(t *testing.T) {
+	var registered bool
+	exts := []CustomExtension{
+		{
+			Name: "message_only",
+			Encoder: func(sender sdk.AccAddress, msg json.RawMessage) ([]sdk.Msg, error) {
+				return []sdk.Msg{&types.MsgClearAdmin{}}, nil
+			},
+			RegisterInterfaces: func(registry codectypes.InterfaceRegistry) {
+				registered = registry != nil
+			},
+		},
+		{
+			Name: "query_only",
+			Querier: func(ctx sdk.Context, request json.RawMessage) ([]byte, error) {
+				return []byte(`{}`), nil
+			},
+		},
+	}
+	_, keepers := CreateTestInput(t, false, SupportedFeatures, WithCustomExtensions(exts...))
+	k := keepers.WasmKeeper
+	assert.True(t, registered)
+
+	s := k.messenger.(*MessageHandlerChain).handlers[1].(SDKMessageHandler)
+	encoder := s.encoders.(MessageEncoders).Custom
+	msgs, err := encoder(RandomAccountAddress(t), []byte(`{"message_only":{}}`))
+	require.NoError(t, err)
+	assert.Len(t, msgs, 1)
+	_, err = encoder(RandomAccountAddress(t), []byte(`{"query_only":{}}`))
+	assert.True(t, types.ErrUnknownMsg.Is(err), "got %+v", err)
+
+	querier := k.wasmVMQueryHandler.(QueryPlugins).Custom
+	rsp, err := querier(sdk.Context{}, []byte(`{"query_only":{}}`))
+	require.NoError(t, err)
+	assert.Equal(t, []byte(`{}`), rsp)
+	_, err = querier(sdk.Context{}, []byte(`{"message_only":{}}`))
+	assert.Equal(t, wasmvmtypes.UnsupportedRequest{Kind: "custom"}, err)
+}
+
+func TestWithCustomExtensionsInvalid(t *testing.T) {
+	specs := map[string][]CustomExtension{
+		"empty name": {{}},
+		"duplicate name": {
+			{Name: "my_ext"},
+			{Name: "my_ext"},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			assert.Panics(t, func() {
+				CreateTestInput(t, false, SupportedFeatures, WithCustomExtensions(spec...))
+			})
+		})
+	}
+}
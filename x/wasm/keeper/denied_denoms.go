@@ -0,0 +1,56 @@
+package keeper
+
+import (
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// setDeniedDenoms replaces the denoms that the contract refuses to receive. Only the contract admin can modify them.
+func (k Keeper) setDeniedDenoms(ctx sdk.Context, contractAddress, caller sdk.AccAddress, denoms []string, authZ AuthorizationPolicy) error {
+	contractInfo := k.GetContractInfo(ctx, contractAddress)
+	if contractInfo == nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "unknown contract")
+	}
+	if !authZ.CanModifyContract(contractInfo.AdminAddr(), caller) {
+		return sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "can not modify contract")
+	}
+	if err := types.ValidateDeniedDenoms(denoms); err != nil {
+		return err
+	}
+	k.storeDeniedDenoms(ctx, contractAddress, denoms)
+	return nil
+}
+
+func (k Keeper) storeDeniedDenoms(ctx sdk.Context, contractAddress sdk.AccAddress, denoms []string) {
+	store := ctx.KVStore(k.storeKey)
+	for _, d := range k.GetDeniedDenoms(ctx, contractAddress) {
+		store.Delete(types.GetContractDeniedDenomKey(contractAddress, d))
+	}
+	for _, d := range denoms {
+		store.Set(types.GetContractDeniedDenomKey(contractAddress, d), []byte{1})
+	}
+}
+
+// GetDeniedDenoms returns the denoms that the contract refuses to receive in ascending order
+func (k Keeper) GetDeniedDenoms(ctx sdk.Context, contractAddress sdk.AccAddress) []string {
+	var r []string
+	iter := prefix.NewStore(ctx.KVStore(k.storeKey), types.GetContractDeniedDenomsPrefix(contractAddress)).Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		r = append(r, string(iter.Key()))
+	}
+	return r
+}
+
+// assertReceivableFunds returns an error when the funds contain a denom that the contract refuses to receive
+func (k Keeper) assertReceivableFunds(ctx sdk.Context, contractAddress sdk.AccAddress, funds sdk.Coins) error {
+	store := ctx.KVStore(k.storeKey)
+	for _, c := range funds {
+		if store.Has(types.GetContractDeniedDenomKey(contractAddress, c.Denom)) {
+			return sdkerrors.Wrapf(sdkerrors.ErrInvalidCoins, "contract does not accept denom %q", c.Denom)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package keeper
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateDeniedDenoms(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	accKeeper, bankKeeper := keepers.AccountKeeper, keepers.BankKeeper
+	example := InstantiateHackatomExampleContract(t, ctx, keepers)
+	_, _, otherAddr := keyPubAddr()
+
+	specs := map[string]struct {
+		caller    sdk.AccAddress
+		contract  sdk.AccAddress
+		denoms    []string
+		expDenoms []string
+		expErr    *sdkerrors.Error
+	}{
+		"all good": {
+			caller:    example.CreatorAddr,
+			contract:  example.Contract,
+			denoms:    []string{"uatom", "ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2"},
+			expDenoms: []string{"ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2", "uatom"},
+		},
+		"empty list clears": {
+			caller:   example.CreatorAddr,
+			contract: example.Contract,
+		},
+		"non admin": {
+			caller:   otherAddr,
+			contract: example.Contract,
+			denoms:   []string{"uatom"},
+			expErr:   sdkerrors.ErrUnauthorized,
+		},
+		"unknown contract": {
+			caller:   example.CreatorAddr,
+			contract: otherAddr,
+			denoms:   []string{"uatom"},
+			expErr:   sdkerrors.ErrInvalidRequest,
+		},
+		"duplicate denoms": {
+			caller:   example.CreatorAddr,
+			contract: example.Contract,
+			denoms:   []string{"uatom", "uatom"},
+			expErr:   types.ErrDuplicate,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			ctx, _ := ctx.CacheContext()
+			keepers.WasmKeeper.storeDeniedDenoms(ctx, example.Contract, []string{"other"})
+
+			err := keepers.ContractKeeper.UpdateDeniedDenoms(ctx, spec.contract, spec.caller, spec.denoms)
+			if spec.expErr != nil {
+				assert.True(t, spec.expErr.Is(err), "got %+v", err)
+				assert.Equal(t, []string{"other"}, keepers.WasmKeeper.GetDeniedDenoms(ctx, example.Contract))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, spec.expDenoms, keepers.WasmKeeper.GetDeniedDenoms(ctx, example.Contract))
+		})
+	}
+
+	// when denied
+	require.NoError(t, keepers.ContractKeeper.UpdateDeniedDenoms(ctx, example.Contract, example.CreatorAddr, []string{"denied"}))
+	sender := createFakeFundedAccount(t, ctx, accKeeper, bankKeeper, sdk.NewCoins(sdk.NewInt64Coin("denom", 100), sdk.NewInt64Coin("denied", 100)))
+	releaseMsg, err := json.Marshal(map[string]interface{}{"release": struct{}{}})
+	require.NoError(t, err)
+
+	// then funds with the denied denom are rejected
+	_, err = keepers.ContractKeeper.Execute(ctx, example.Contract, sender, releaseMsg, sdk.NewCoins(sdk.NewInt64Coin("denom", 1), sdk.NewInt64Coin("denied", 1)))
+	assert.True(t, sdkerrors.ErrInvalidCoins.Is(err), "got %+v", err)
+	// and other denoms are accepted
+	_, err = keepers.ContractKeeper.Execute(ctx, example.Contract, example.VerifierAddr, releaseMsg, nil)
+	require.NoError(t, err)
+}
+
+func TestQueryContractDeniedDenoms(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	example := InstantiateHackatomExampleContract(t, ctx, keepers)
+	q := Querier(keepers.WasmKeeper)
+
+	rsp, err := q.ContractDeniedDenoms(sdk.WrapSDKContext(ctx), &types.QueryContractDeniedDenomsRequest{Address: example.Contract.String()})
+	require.NoError(t, err)
+	assert.Equal(t, []string{}, rsp.Denoms)
+
+	keepers.WasmKeeper.storeDeniedDenoms(ctx, example.Contract, []string{"uatom"})
+	rsp, err = q.ContractDeniedDenoms(sdk.WrapSDKContext(ctx), &types.QueryContractDeniedDenomsRequest{Address: example.Contract.String()})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"uatom"}, rsp.Denoms)
+
+	_, _, otherAddr := keyPubAddr()
+	_, err = q.ContractDeniedDenoms(sdk.WrapSDKContext(ctx), &types.QueryContractDeniedDenomsRequest{Address: otherAddr.String()})
+	assert.True(t, types.ErrNotFound.Is(err), "got %+v", err)
+}
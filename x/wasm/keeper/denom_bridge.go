@@ -0,0 +1,143 @@
+package keeper
+
+import (
+	"encoding/json"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// DenomBridgeHooks is the integration point for chains to designate the CW20 contracts that are bridged 1:1 to a
+// native bank denom.
+type DenomBridgeHooks interface {
+	// BridgedDenom returns the native denom for the contract and true when the contract is designated
+	BridgedDenom(ctx sdk.Context, contractAddr sdk.AccAddress) (string, bool)
+}
+
+var _ DenomBridgeHooks = DenomBridgeHooksFunc(nil)
+
+// DenomBridgeHooksFunc is a helper to construct function based denom bridge hooks
+type DenomBridgeHooksFunc func(ctx sdk.Context, contractAddr sdk.AccAddress) (string, bool)
+
+func (f DenomBridgeHooksFunc) BridgedDenom(ctx sdk.Context, contractAddr sdk.AccAddress) (string, bool) {
+	return f(ctx, contractAddr)
+}
+
+// DenomBridgeBankKeeper is the subset of the sdk bank keeper methods that are used by the denom bridge
+type DenomBridgeBankKeeper interface {
+	types.Minter
+	types.Burner
+}
+
+// DenomBridgeCustomMsg is the custom message envelope that is sent by a bridged CW20 contract
+type DenomBridgeCustomMsg struct {
+	DenomBridge *DenomBridgeMsg `json:"denom_bridge,omitempty"`
+}
+
+// DenomBridgeMsg mints or burns the native denom of the sending contract
+type DenomBridgeMsg struct {
+	// MintNative mints new tokens to the recipient. The contract is expected to burn the same amount of CW20 tokens.
+	MintNative *MintNativeMsg `json:"mint_native,omitempty"`
+	// BurnNative burns tokens from the contract account. The contract is expected to mint the same amount of
+	// CW20 tokens.
+	BurnNative *BurnNativeMsg `json:"burn_native,omitempty"`
+}
+
+type MintNativeMsg struct {
+	Recipient string `json:"recipient"`
+	Amount    string `json:"amount"`
+}
+
+type BurnNativeMsg struct {
+	Amount string `json:"amount"`
+}
+
+// NewDenomBridgeMessageHandler handles the custom denom bridge messages of designated CW20 contracts.
+// All other messages are passed to the next handler in the chain.
+func NewDenomBridgeMessageHandler(bankKeeper DenomBridgeBankKeeper, hooks DenomBridgeHooks) MessageHandlerFunc {
+	return func(ctx sdk.Context, contractAddr sdk.AccAddress, _ string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, err error) {
+		if msg.Custom == nil {
+			return nil, nil, types.ErrUnknownMsg
+		}
+		var customMsg DenomBridgeCustomMsg
+		if err := json.Unmarshal(msg.Custom, &customMsg); err != nil || customMsg.DenomBridge == nil {
+			return nil, nil, types.ErrUnknownMsg
+		}
+		denom, ok := hooks.BridgedDenom(ctx, contractAddr)
+		if !ok {
+			return nil, nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "not a bridged contract")
+		}
+		switch m := customMsg.DenomBridge; {
+		case m.MintNative != nil:
+			return mintNative(ctx, bankKeeper, contractAddr, denom, *m.MintNative)
+		case m.BurnNative != nil:
+			return burnNative(ctx, bankKeeper, contractAddr, denom, *m.BurnNative)
+		default:
+			return nil, nil, sdkerrors.Wrap(types.ErrInvalidMsg, "unknown denom bridge variant")
+		}
+	}
+}
+
+func mintNative(ctx sdk.Context, bankKeeper DenomBridgeBankKeeper, contractAddr sdk.AccAddress, denom string, msg MintNativeMsg) ([]sdk.Event, [][]byte, error) {
+	recipient, err := sdk.AccAddressFromBech32(msg.Recipient)
+	if err != nil {
+		return nil, nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Recipient)
+	}
+	coins, err := bridgedCoins(denom, msg.Amount)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := bankKeeper.MintCoins(ctx, types.ModuleName, coins); err != nil {
+		return nil, nil, sdkerrors.Wrap(err, "mint coins")
+	}
+	if err := bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, recipient, coins); err != nil {
+		return nil, nil, sdkerrors.Wrap(err, "transfer to recipient")
+	}
+	return []sdk.Event{sdk.NewEvent(
+		types.EventTypeMintNative,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String()),
+		sdk.NewAttribute(types.AttributeKeyRecipient, recipient.String()),
+		sdk.NewAttribute(sdk.AttributeKeyAmount, coins.String()),
+	)}, nil, nil
+}
+
+func burnNative(ctx sdk.Context, bankKeeper DenomBridgeBankKeeper, contractAddr sdk.AccAddress, denom string, msg BurnNativeMsg) ([]sdk.Event, [][]byte, error) {
+	coins, err := bridgedCoins(denom, msg.Amount)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := bankKeeper.SendCoinsFromAccountToModule(ctx, contractAddr, types.ModuleName, coins); err != nil {
+		return nil, nil, sdkerrors.Wrap(err, "transfer to module")
+	}
+	if err := bankKeeper.BurnCoins(ctx, types.ModuleName, coins); err != nil {
+		return nil, nil, sdkerrors.Wrap(err, "burn coins")
+	}
+	return []sdk.Event{sdk.NewEvent(
+		types.EventTypeBurnNative,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String()),
+		sdk.NewAttribute(sdk.AttributeKeyAmount, coins.String()),
+	)}, nil, nil
+}
+
+func bridgedCoins(denom, rawAmount string) (sdk.Coins, error) {
+	coin, err := parsePositiveCoin(denom, rawAmount)
+	if err != nil {
+		return nil, err
+	}
+	return sdk.NewCoins(coin), nil
+}
+
+// parsePositiveCoin converts the decimal amount string from a contract into a coin
+func parsePositiveCoin(denom, rawAmount string) (sdk.Coin, error) {
+	amount, ok := sdk.NewIntFromString(rawAmount)
+	if !ok || !amount.IsPositive() {
+		return sdk.Coin{}, sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "amount")
+	}
+	coin := sdk.Coin{Denom: denom, Amount: amount}
+	if err := coin.Validate(); err != nil {
+		return sdk.Coin{}, err
+	}
+	return coin, nil
+}
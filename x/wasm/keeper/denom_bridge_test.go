@@ -0,0 +1,108 @@
+package keeper
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDenomBridgeMessageHandler(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	bankKeeper := keepers.BankKeeper
+
+	bridgedContract := RandomAccountAddress(t)
+	recipient := RandomAccountAddress(t)
+	bridgedDenom := "cw20/" + bridgedContract.String()
+	hooks := DenomBridgeHooksFunc(func(ctx sdk.Context, contractAddr sdk.AccAddress) (string, bool) {
+		return bridgedDenom, contractAddr.Equals(bridgedContract)
+	})
+	// the bridged contract starts with some native tokens
+	initialCoins := sdk.NewCoins(sdk.NewInt64Coin(bridgedDenom, 100))
+	require.NoError(t, bankKeeper.MintCoins(parentCtx, types.ModuleName, initialCoins))
+	require.NoError(t, bankKeeper.SendCoinsFromModuleToAccount(parentCtx, types.ModuleName, bridgedContract, initialCoins))
+
+	customMsg := func(s string) wasmvmtypes.CosmosMsg {
+		return wasmvmtypes.CosmosMsg{Custom: []byte(s)}
+	}
+	specs := map[string]struct {
+		sender          sdk.AccAddress
+		msg             wasmvmtypes.CosmosMsg
+		expErr          *sdkerrors.Error
+		expContractBal  int64
+		expRecipientBal int64
+		expEvent        string
+	}{
+		"mint native": {
+			sender:          bridgedContract,
+			msg:             customMsg(fmt.Sprintf(`{"denom_bridge":{"mint_native":{"recipient":%q,"amount":"20"}}}`, recipient.String())),
+			expContractBal:  100,
+			expRecipientBal: 20,
+			expEvent:        types.EventTypeMintNative,
+		},
+		"burn native": {
+			sender:         bridgedContract,
+			msg:            customMsg(`{"denom_bridge":{"burn_native":{"amount":"40"}}}`),
+			expContractBal: 60,
+			expEvent:       types.EventTypeBurnNative,
+		},
+		"burn more than balance": {
+			sender: bridgedContract,
+			msg:    customMsg(`{"denom_bridge":{"burn_native":{"amount":"101"}}}`),
+			expErr: sdkerrors.ErrInsufficientFunds,
+		},
+		"non positive amount": {
+			sender: bridgedContract,
+			msg:    customMsg(`{"denom_bridge":{"burn_native":{"amount":"0"}}}`),
+			expErr: sdkerrors.ErrInvalidCoins,
+		},
+		"invalid recipient": {
+			sender: bridgedContract,
+			msg:    customMsg(`{"denom_bridge":{"mint_native":{"recipient":"invalid","amount":"1"}}}`),
+			expErr: sdkerrors.ErrInvalidAddress,
+		},
+		"empty variant": {
+			sender: bridgedContract,
+			msg:    customMsg(`{"denom_bridge":{}}`),
+			expErr: types.ErrInvalidMsg,
+		},
+		"contract not designated": {
+			sender: RandomAccountAddress(t),
+			msg:    customMsg(fmt.Sprintf(`{"denom_bridge":{"mint_native":{"recipient":%q,"amount":"20"}}}`, recipient.String())),
+			expErr: sdkerrors.ErrUnauthorized,
+		},
+		"other custom message": {
+			sender: bridgedContract,
+			msg:    customMsg(`{"foo":{}}`),
+			expErr: types.ErrUnknownMsg,
+		},
+		"non custom message": {
+			sender: bridgedContract,
+			msg:    wasmvmtypes.CosmosMsg{Bank: &wasmvmtypes.BankMsg{}},
+			expErr: types.ErrUnknownMsg,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			h := NewDenomBridgeMessageHandler(bankKeeper, hooks)
+			gotEvents, _, gotErr := h.DispatchMsg(ctx, spec.sender, "", spec.msg)
+			if spec.expErr != nil {
+				assert.True(t, errors.Is(gotErr, spec.expErr), "got %+v", gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			require.Len(t, gotEvents, 1)
+			assert.Equal(t, spec.expEvent, gotEvents[0].Type)
+			assert.Equal(t, sdk.NewInt(spec.expContractBal), bankKeeper.GetBalance(ctx, bridgedContract, bridgedDenom).Amount)
+			assert.Equal(t, sdk.NewInt(spec.expRecipientBal), bankKeeper.GetBalance(ctx, recipient, bridgedDenom).Amount)
+			assert.Equal(t, sdk.NewInt(spec.expContractBal+spec.expRecipientBal), bankKeeper.GetSupply(ctx).GetTotal().AmountOf(bridgedDenom))
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package keeper
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	"github.com/cosmos/cosmos-sdk/codec/legacy"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// executeAllowlistCache keeps the decoded execute allowlist param in memory so that the allowlist check is a single
+// lookup. The set is rebuilt when the raw param value differs from the cached one, for example after a governance
+// param change.
+type executeAllowlistCache struct {
+	mu  sync.Mutex
+	raw []byte
+	set map[string]struct{}
+}
+
+func newExecuteAllowlistCache() *executeAllowlistCache {
+	return &executeAllowlistCache{set: make(map[string]struct{})}
+}
+
+// contains returns true when the decoded allowlist of the raw param value contains the contract address
+func (c *executeAllowlistCache) contains(raw []byte, contractAddress string) bool {
+	if c == nil {
+		_, ok := decodeExecuteAllowlist(raw)[contractAddress]
+		return ok
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.set == nil || !bytes.Equal(c.raw, raw) {
+		c.raw, c.set = raw, decodeExecuteAllowlist(raw)
+	}
+	_, ok := c.set[contractAddress]
+	return ok
+}
+
+// decodeExecuteAllowlist returns the addresses of the raw param value as set. An empty set is returned when the value
+// can not be decoded.
+func decodeExecuteAllowlist(raw []byte) map[string]struct{} {
+	var allowlist []string
+	if len(raw) != 0 {
+		if err := legacy.Cdc.UnmarshalJSON(raw, &allowlist); err != nil {
+			return map[string]struct{}{}
+		}
+	}
+	set := make(map[string]struct{}, len(allowlist))
+	for _, a := range allowlist {
+		set[a] = struct{}{}
+	}
+	return set
+}
+
+// isExecuteAllowlisted returns true when the execute allowlist is disabled or contains the contract. The allowlist
+// is read only when the feature is enabled. A flat read cost is charged for the allowlist so that the gas costs of an
+// execution do not depend on the size of the list.
+func (k Keeper) isExecuteAllowlisted(ctx sdk.Context, contractAddress sdk.AccAddress) bool {
+	var enabled bool
+	k.paramSpace.GetIfExists(ctx, types.ParamStoreKeyExecuteAllowlistEnabled, &enabled)
+	if !enabled {
+		return true
+	}
+	raw := k.paramSpace.GetRaw(ctx.WithGasMeter(sdk.NewInfiniteGasMeter()), types.ParamStoreKeyExecuteAllowlist)
+	ctx.GasMeter().ConsumeGas(kvGasConfig(ctx).ReadCostFlat, "execute allowlist")
+	return k.executeAllowlist.contains(raw, contractAddress.String())
+}
@@ -0,0 +1,49 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsExecuteAllowlisted(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	k := keepers.WasmKeeper
+	myContract := RandomAccountAddress(t)
+	otherContract := RandomAccountAddress(t)
+
+	var gasConsumed []sdk.Gas
+	for _, allowlist := range [][]string{
+		{myContract.String()},
+		{RandomBech32AccountAddress(t), RandomBech32AccountAddress(t), RandomBech32AccountAddress(t), myContract.String()},
+	} {
+		ctx, _ := parentCtx.CacheContext()
+		params := types.DefaultParams()
+		params.ExecuteAllowlistEnabled = true
+		params.ExecuteAllowlist = allowlist
+		k.setParams(ctx, params)
+
+		ctx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+		assert.True(t, k.isExecuteAllowlisted(ctx, myContract))
+		gasConsumed = append(gasConsumed, ctx.GasMeter().GasConsumed())
+		assert.False(t, k.isExecuteAllowlisted(ctx, otherContract))
+	}
+	// gas costs do not depend on the size of the allowlist
+	assert.Equal(t, gasConsumed[0], gasConsumed[1])
+
+	// and a modified allowlist is applied
+	ctx, _ := parentCtx.CacheContext()
+	params := types.DefaultParams()
+	params.ExecuteAllowlistEnabled = true
+	params.ExecuteAllowlist = []string{otherContract.String()}
+	k.setParams(ctx, params)
+	assert.False(t, k.isExecuteAllowlisted(ctx, myContract))
+	assert.True(t, k.isExecuteAllowlisted(ctx, otherContract))
+
+	// and all contracts are allowed when disabled
+	params.ExecuteAllowlistEnabled = false
+	k.setParams(ctx, params)
+	assert.True(t, k.isExecuteAllowlisted(ctx, myContract))
+}
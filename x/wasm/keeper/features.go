@@ -0,0 +1,121 @@
+package keeper
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// requiredFeaturePrefix is the name prefix of the marker functions that a contract exports for each feature it requires
+const requiredFeaturePrefix = "requires_"
+
+// assertSupportedFeatures fails when the code requires features that are not enabled on this chain. This can be the
+// case for codes that were stored before the supported features of the chain were reduced.
+func (k Keeper) assertSupportedFeatures(codeInfo types.CodeInfo) error {
+	for _, f := range codeInfo.RequiredFeatures {
+		if _, ok := k.supportedFeatures[f]; !ok {
+			return sdkerrors.Wrapf(types.ErrUnsupportedFeatures, "code requires %q", f)
+		}
+	}
+	return nil
+}
+
+// GetSupportedFeatures returns the capabilities that this chain enables for contracts in ascending order
+func (k Keeper) GetSupportedFeatures() []string {
+	r := make([]string, 0, len(k.supportedFeatures))
+	for f := range k.supportedFeatures {
+		r = append(r, f)
+	}
+	sort.Strings(r)
+	return r
+}
+
+// parseFeatures splits the comma separated features into a sorted list without empty elements
+func parseFeatures(features string) []string {
+	var r []string
+	for _, f := range strings.Split(features, ",") {
+		if f = strings.TrimSpace(f); len(f) != 0 {
+			r = append(r, f)
+		}
+	}
+	sort.Strings(r)
+	return r
+}
+
+// requiredFeatures returns the sorted features that the wasm code requires from the chain. They are declared by
+// exported `requires_<feature>` marker functions. The code was validated by the wasm VM before so that reading
+// stops at malformed content and returns the features found so far.
+func requiredFeatures(wasmCode []byte) []string {
+	const (
+		headerLength    = 8 // magic number and version
+		exportSectionID = 7
+		funcExportKind  = 0
+	)
+	if len(wasmCode) < headerLength {
+		return nil
+	}
+	r := bytes.NewReader(wasmCode[headerLength:])
+	var features []string
+	for {
+		sectionID, err := r.ReadByte()
+		if err != nil {
+			break
+		}
+		size, err := binary.ReadUvarint(r)
+		if err != nil || size > uint64(r.Len()) {
+			break
+		}
+		if sectionID != exportSectionID {
+			if _, err := r.Seek(int64(size), io.SeekCurrent); err != nil {
+				break
+			}
+			continue
+		}
+		count, err := binary.ReadUvarint(r)
+		if err != nil {
+			break
+		}
+		for i := uint64(0); i < count; i++ {
+			nameLen, err := binary.ReadUvarint(r)
+			if err != nil || nameLen > uint64(r.Len()) {
+				return sortedUnique(features)
+			}
+			name := make([]byte, nameLen)
+			if _, err := io.ReadFull(r, name); err != nil {
+				return sortedUnique(features)
+			}
+			kind, err := r.ReadByte()
+			if err != nil {
+				return sortedUnique(features)
+			}
+			if _, err := binary.ReadUvarint(r); err != nil {
+				return sortedUnique(features)
+			}
+			if kind == funcExportKind && strings.HasPrefix(string(name), requiredFeaturePrefix) {
+				features = append(features, strings.TrimPrefix(string(name), requiredFeaturePrefix))
+			}
+		}
+		// there is only one export section
+		break
+	}
+	return sortedUnique(features)
+}
+
+func sortedUnique(s []string) []string {
+	sort.Strings(s)
+	r := s[:0]
+	for i, v := range s {
+		if i == 0 || v != s[i-1] {
+			r = append(r, v)
+		}
+	}
+	if len(r) == 0 {
+		return nil
+	}
+	return r
+}
@@ -0,0 +1,80 @@
+package keeper
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequiredFeatures(t *testing.T) {
+	reflectCode, err := ioutil.ReadFile("./testdata/reflect.wasm")
+	require.NoError(t, err)
+	hackatomCode, err := ioutil.ReadFile("./testdata/hackatom.wasm")
+	require.NoError(t, err)
+
+	specs := map[string]struct {
+		src []byte
+		exp []string
+	}{
+		"with features": {
+			src: reflectCode,
+			exp: []string{"staking", "stargate"},
+		},
+		"without features": {
+			src: hackatomCode,
+		},
+		"truncated after export section": {
+			src: reflectCode[:len(reflectCode)/2],
+			exp: []string{"staking", "stargate"},
+		},
+		"header only": {
+			src: reflectCode[:8],
+		},
+		"empty": {},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			assert.Equal(t, spec.exp, requiredFeatures(spec.src))
+		})
+	}
+}
+
+func TestParseFeatures(t *testing.T) {
+	assert.Equal(t, []string{"iterator", "staking", "stargate"}, parseFeatures("staking, stargate,,iterator"))
+	assert.Nil(t, parseFeatures(""))
+}
+
+func TestFeaturesRecordedAndChecked(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	k := keepers.WasmKeeper
+	creator := createFakeFundedAccount(t, ctx, keepers.AccountKeeper, keepers.BankKeeper, nil)
+	reflectCode, err := ioutil.ReadFile("./testdata/reflect.wasm")
+	require.NoError(t, err)
+
+	// when stored
+	codeID, err := keepers.ContractKeeper.Create(ctx, creator, reflectCode, "", "", nil)
+	require.NoError(t, err)
+
+	// then
+	codeInfo := k.GetCodeInfo(ctx, codeID)
+	require.NotNil(t, codeInfo)
+	assert.Equal(t, []string{"staking", "stargate"}, codeInfo.RequiredFeatures)
+	contractAddr, _, err := keepers.ContractKeeper.Instantiate(ctx, codeID, creator, creator, []byte("{}"), "reflect", nil)
+	require.NoError(t, err)
+
+	// and when a feature is not supported anymore
+	delete(k.supportedFeatures, "stargate")
+
+	// then calls fail
+	_, _, err = keepers.ContractKeeper.Instantiate(ctx, codeID, creator, nil, []byte("{}"), "reflect", nil)
+	assert.True(t, types.ErrUnsupportedFeatures.Is(err), "got %+v", err)
+	_, err = keepers.ContractKeeper.Execute(ctx, contractAddr, creator, []byte(`{"change_owner":{"owner":"`+creator.String()+`"}}`), nil)
+	assert.True(t, types.ErrUnsupportedFeatures.Is(err), "got %+v", err)
+	_, err = k.QuerySmart(ctx, contractAddr, []byte(`{"owner":{}}`))
+	assert.True(t, types.ErrUnsupportedFeatures.Is(err), "got %+v", err)
+	_, err = keepers.ContractKeeper.Migrate(ctx, contractAddr, creator, codeID, []byte("{}"))
+	assert.True(t, types.ErrUnsupportedFeatures.Is(err), "got %+v", err)
+}
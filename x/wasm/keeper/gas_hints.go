@@ -0,0 +1,113 @@
+package keeper
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// DefaultGasHintsCacheBlocks is the number of blocks that the gas hints of a contract are cached for
+	DefaultGasHintsCacheBlocks = 100
+	// DefaultGasHintsCacheSize is the max number of contracts that gas hints are cached for
+	DefaultGasHintsCacheSize = 1000
+	// maxGasHints is the max number of hints that are accepted from a contract
+	maxGasHints = 100
+)
+
+// GasHintsQuery is the smart query convention for contracts to declare the recommended gas for common actions
+type GasHintsQuery struct {
+	GasHints *struct{} `json:"gas_hints"`
+}
+
+// GasHintsResponse is the response to the GasHintsQuery
+type GasHintsResponse struct {
+	Hints []GasHintResponse `json:"hints"`
+}
+
+// GasHintResponse is the recommended gas for a single contract action
+type GasHintResponse struct {
+	Action string `json:"action"`
+	Gas    uint64 `json:"gas"`
+}
+
+// parseGasHints decodes and validates the gas hints of a contract
+func parseGasHints(bz []byte) ([]types.GasHint, error) {
+	var rsp GasHintsResponse
+	if err := json.Unmarshal(bz, &rsp); err != nil {
+		return nil, fmt.Errorf("invalid gas hints response: %s", err)
+	}
+	if len(rsp.Hints) > maxGasHints {
+		return nil, fmt.Errorf("more than %d gas hints", maxGasHints)
+	}
+	hints := make([]types.GasHint, 0, len(rsp.Hints))
+	seen := make(map[string]struct{}, len(rsp.Hints))
+	for _, h := range rsp.Hints {
+		if h.Action == "" {
+			return nil, fmt.Errorf("empty gas hint action")
+		}
+		if _, ok := seen[h.Action]; ok {
+			return nil, fmt.Errorf("duplicate gas hint action: %s", h.Action)
+		}
+		if h.Gas == 0 {
+			return nil, fmt.Errorf("zero gas for action: %s", h.Action)
+		}
+		seen[h.Action] = struct{}{}
+		hints = append(hints, types.GasHint{Action: h.Action, Gas: h.Gas})
+	}
+	return hints, nil
+}
+
+// GasHintsCache keeps the gas hints query results of the contracts in memory so that the smart query is not executed
+// for every wallet request. An entry expires after a number of blocks or when the contract was migrated to a new
+// code. When the cache is full, the oldest entry is dropped.
+// The cache is node local and not part of the consensus state. It is safe for concurrent use.
+type GasHintsCache struct {
+	mu      sync.Mutex
+	blocks  int64
+	size    int
+	entries map[string]gasHintsCacheEntry
+	// order contains the cached contract addresses from oldest to newest
+	order []string
+}
+
+type gasHintsCacheEntry struct {
+	codeID uint64
+	rsp    types.QueryContractGasHintsResponse
+}
+
+// NewGasHintsCache constructor. Blocks and size must be greater than 0.
+func NewGasHintsCache(blocks int64, size int) *GasHintsCache {
+	if blocks <= 0 || size <= 0 {
+		panic("blocks and size must be greater than 0")
+	}
+	return &GasHintsCache{blocks: blocks, size: size, entries: make(map[string]gasHintsCacheEntry, size)}
+}
+
+// get returns the cached response for the contract when it was queried within the cache blocks for the same code
+func (c *GasHintsCache) get(ctx sdk.Context, contractAddr sdk.AccAddress, codeID uint64) (types.QueryContractGasHintsResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[string(contractAddr)]
+	if !ok || e.codeID != codeID || ctx.BlockHeight()-e.rsp.Height >= c.blocks || ctx.BlockHeight() < e.rsp.Height {
+		return types.QueryContractGasHintsResponse{}, false
+	}
+	return e.rsp, true
+}
+
+func (c *GasHintsCache) set(contractAddr sdk.AccAddress, codeID uint64, rsp types.QueryContractGasHintsResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := string(contractAddr)
+	if _, ok := c.entries[key]; !ok {
+		if len(c.order) >= c.size {
+			delete(c.entries, c.order[0])
+			c.order = c.order[1:]
+		}
+		c.order = append(c.order, key)
+	}
+	c.entries[key] = gasHintsCacheEntry{codeID: codeID, rsp: rsp}
+}
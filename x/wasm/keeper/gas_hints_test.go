@@ -0,0 +1,140 @@
+package keeper
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	cosmwasm "github.com/CosmWasm/wasmvm"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryContractGasHints(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	k := keepers.WasmKeeper
+	mock := wasmtesting.MockWasmer{}
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+
+	specs := map[string]struct {
+		srcQueryRsp string
+		srcQueryErr error
+		expHints    []types.GasHint
+		expErrMsg   bool
+	}{
+		"hints": {
+			srcQueryRsp: `{"hints":[{"action":"transfer","gas":150000},{"action":"mint","gas":200000}]}`,
+			expHints:    []types.GasHint{{Action: "transfer", Gas: 150000}, {Action: "mint", Gas: 200000}},
+		},
+		"no hints": {
+			srcQueryRsp: `{"hints":[]}`,
+			expHints:    []types.GasHint{},
+		},
+		"query not supported": {
+			srcQueryErr: errors.New("unknown variant"),
+			expHints:    []types.GasHint{},
+			expErrMsg:   true,
+		},
+		"invalid response": {
+			srcQueryRsp: `{"hints":"many"}`,
+			expHints:    []types.GasHint{},
+			expErrMsg:   true,
+		},
+		"empty action": {
+			srcQueryRsp: `{"hints":[{"action":"","gas":1}]}`,
+			expHints:    []types.GasHint{},
+			expErrMsg:   true,
+		},
+		"duplicate action": {
+			srcQueryRsp: `{"hints":[{"action":"transfer","gas":1},{"action":"transfer","gas":2}]}`,
+			expHints:    []types.GasHint{},
+			expErrMsg:   true,
+		},
+		"zero gas": {
+			srcQueryRsp: `{"hints":[{"action":"transfer","gas":0}]}`,
+			expHints:    []types.GasHint{},
+			expErrMsg:   true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			mock.QueryFn = func(checksum cosmwasm.Checksum, env wasmvmtypes.Env, queryMsg []byte, store cosmwasm.KVStore, goapi cosmwasm.GoAPI, querier cosmwasm.Querier, gasMeter cosmwasm.GasMeter, gasLimit uint64) ([]byte, uint64, error) {
+				assert.JSONEq(t, `{"gas_hints":{}}`, string(queryMsg))
+				return []byte(spec.srcQueryRsp), 0, spec.srcQueryErr
+			}
+			q := Querier(k)
+			q.gasHintsCache = nil
+			rsp, err := q.ContractGasHints(sdk.WrapSDKContext(ctx), &types.QueryContractGasHintsRequest{Address: example.Contract.String()})
+			require.NoError(t, err)
+			assert.Equal(t, spec.expHints, rsp.Hints)
+			assert.Equal(t, ctx.BlockHeight(), rsp.Height)
+			assert.Equal(t, spec.expErrMsg, rsp.HintsError != "", rsp.HintsError)
+		})
+	}
+
+	// and unknown contracts
+	_, err := Querier(k).ContractGasHints(sdk.WrapSDKContext(ctx), &types.QueryContractGasHintsRequest{Address: RandomBech32AccountAddress(t)})
+	assert.True(t, types.ErrNotFound.Is(err), "got %+v", err)
+}
+
+func TestQueryContractGasHintsCached(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	k := keepers.WasmKeeper
+	mock := wasmtesting.MockWasmer{}
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	var queryCount int
+	mock.QueryFn = func(checksum cosmwasm.Checksum, env wasmvmtypes.Env, queryMsg []byte, store cosmwasm.KVStore, goapi cosmwasm.GoAPI, querier cosmwasm.Querier, gasMeter cosmwasm.GasMeter, gasLimit uint64) ([]byte, uint64, error) {
+		queryCount++
+		return []byte(`{"hints":[{"action":"transfer","gas":150000}]}`), 0, nil
+	}
+	q := Querier(k)
+	q.gasHintsCache = NewGasHintsCache(10, 1)
+	queryAt := func(ctx sdk.Context) *types.QueryContractGasHintsResponse {
+		rsp, err := q.ContractGasHints(sdk.WrapSDKContext(ctx), &types.QueryContractGasHintsRequest{Address: example.Contract.String()})
+		require.NoError(t, err)
+		return rsp
+	}
+	height := ctx.BlockHeight()
+
+	rsp := queryAt(ctx)
+	assert.Equal(t, 1, queryCount)
+	// cached within the blocks
+	assert.Equal(t, rsp, queryAt(ctx.WithBlockHeight(height+9)))
+	assert.Equal(t, 1, queryCount)
+	// expired after the blocks
+	rsp = queryAt(ctx.WithBlockHeight(height + 10))
+	assert.Equal(t, 2, queryCount)
+	assert.Equal(t, height+10, rsp.Height)
+
+	// expired on migration to a new code
+	newCode := StoreRandomContract(t, ctx, keepers, &mock)
+	info := k.GetContractInfo(ctx, example.Contract)
+	info.CodeID = newCode.CodeID
+	k.storeContractInfo(ctx, example.Contract, info)
+	queryAt(ctx.WithBlockHeight(height + 10))
+	assert.Equal(t, 3, queryCount)
+}
+
+func TestGasHintsCacheEviction(t *testing.T) {
+	ctx := sdk.Context{}.WithBlockHeight(1)
+	c := NewGasHintsCache(10, 2)
+	addrs := []sdk.AccAddress{RandomAccountAddress(t), RandomAccountAddress(t), RandomAccountAddress(t)}
+	for _, a := range addrs {
+		c.set(a, 1, types.QueryContractGasHintsResponse{Height: 1})
+	}
+	_, ok := c.get(ctx, addrs[0], 1)
+	assert.False(t, ok, "oldest entry dropped")
+	for _, a := range addrs[1:] {
+		_, ok := c.get(ctx, a, 1)
+		assert.True(t, ok)
+	}
+	// updates of cached entries do not drop others
+	c.set(addrs[1], 1, types.QueryContractGasHintsResponse{Height: 1})
+	_, ok = c.get(ctx, addrs[2], 1)
+	assert.True(t, ok)
+}
@@ -31,6 +31,10 @@ const (
 	DefaultPerAttributeCost uint64 = 10
 	// DefaultEventAttributeDataFreeTier number of bytes of total attribute data we do not charge.
 	DefaultEventAttributeDataFreeTier = 100
+	// DefaultPinnedEventAttributeDataFreeTier number of bytes of total attribute data we do not charge for pinned contracts.
+	DefaultPinnedEventAttributeDataFreeTier = DefaultEventAttributeDataFreeTier
+	// DefaultPinnedCostsDiscount is the percentage of the event and reply costs that pinned contracts do not pay.
+	DefaultPinnedCostsDiscount uint64 = 0
 )
 
 // GasRegister abstract source for gas costs
@@ -51,6 +55,36 @@ type GasRegister interface {
 	FromWasmVMGas(source uint64) sdk.Gas
 }
 
+// GasCostsRegister is an optional extension of the GasRegister that exposes the cost table for the gas costs query
+type GasCostsRegister interface {
+	// GasCosts returns the active gas cost table
+	GasCosts() types.GasCosts
+}
+
+// QueryResultCostsRegister is an optional extension of the GasRegister that charges the data returned to a contract by
+// a smart query to another contract. Without it, the query result is not charged.
+type QueryResultCostsRegister interface {
+	// QueryResultCosts costs for the data returned to a contract by a smart query to another contract
+	QueryResultCosts(resultLen int) sdk.Gas
+}
+
+// ResultDataCostsRegister is an optional extension of the GasRegister that charges the result data or IBC
+// acknowledgement returned by a contract. Without it, the result data is not charged.
+type ResultDataCostsRegister interface {
+	// ResultDataCosts costs for the result data or IBC acknowledgement returned by a contract
+	ResultDataCosts(dataLen int) sdk.Gas
+}
+
+// PinnedEventCostsRegister is an optional extension of the GasRegister for dedicated event costs of pinned contracts.
+// Without it, pinned contracts are charged the EventCosts. The IBC callbacks read the pinned state of the contract for
+// the event costs only when the register charges pinned contracts differently.
+type PinnedEventCostsRegister interface {
+	// HasPinnedEventCosts returns true when the event costs of pinned contracts differ from the others
+	HasPinnedEventCosts() bool
+	// PinnedEventCosts costs to persist an event of a pinned contract
+	PinnedEventCosts(evts []wasmvmtypes.EventAttribute) sdk.Gas
+}
+
 // WasmGasRegisterConfig config type
 type WasmGasRegisterConfig struct {
 	// InstanceCost costs when interacting with a wasm contract
@@ -71,6 +105,12 @@ type WasmGasRegisterConfig struct {
 	// ContractMessageDataCost SDK gas charged *per byte* of the message that goes to the contract
 	// This is used with len(msg)
 	ContractMessageDataCost sdk.Gas
+	// PinnedEventAttributeDataFreeTier number of bytes of total attribute data that is free of charge for pinned
+	// contracts. Pinned contracts get at least the EventAttributeDataFreeTier.
+	PinnedEventAttributeDataFreeTier int
+	// PinnedCostsDiscount percentage of the event and reply costs that is not charged for pinned contracts.
+	// Must be within 0-100
+	PinnedCostsDiscount uint64
 }
 
 // DefaultGasRegisterConfig default values
@@ -83,9 +123,19 @@ func DefaultGasRegisterConfig() WasmGasRegisterConfig {
 		EventAttributeDataCost:     DefaultEventAttributeDataCost,
 		EventAttributeDataFreeTier: DefaultEventAttributeDataFreeTier,
 		ContractMessageDataCost:    DefaultContractMessageDataCost,
+
+		PinnedEventAttributeDataFreeTier: DefaultPinnedEventAttributeDataFreeTier,
+		PinnedCostsDiscount:              DefaultPinnedCostsDiscount,
 	}
 }
 
+var (
+	_ PinnedEventCostsRegister = WasmGasRegister{}
+	_ QueryResultCostsRegister = WasmGasRegister{}
+	_ ResultDataCostsRegister  = WasmGasRegister{}
+	_ GasCostsRegister         = WasmGasRegister{}
+)
+
 // WasmGasRegister implements GasRegister interface
 type WasmGasRegister struct {
 	c WasmGasRegisterConfig
@@ -101,6 +151,9 @@ func NewWasmGasRegister(c WasmGasRegisterConfig) WasmGasRegister {
 	if c.GasMultiplier == 0 {
 		panic(sdkerrors.Wrap(sdkerrors.ErrLogic, "GasMultiplier can not be 0"))
 	}
+	if c.PinnedCostsDiscount > 100 {
+		panic(sdkerrors.Wrap(sdkerrors.ErrLogic, "PinnedCostsDiscount can not exceed 100"))
+	}
 	return WasmGasRegister{
 		c: c,
 	}
@@ -140,16 +193,41 @@ func (g WasmGasRegister) ReplyCosts(pinned bool, reply wasmvmtypes.Reply) sdk.Ga
 		var attrs []wasmvmtypes.EventAttribute
 		for _, e := range reply.Result.Ok.Events {
 			msgLen += len(e.Type)
-			attrs = append(e.Attributes)
+			attrs = append(attrs, e.Attributes...)
 		}
 		// apply free tier on the whole set not per event
-		eventGas += g.EventCosts(attrs)
+		eventGas += g.eventCosts(pinned, attrs)
+	}
+	return eventGas + g.pinnedDiscount(pinned, g.InstantiateContractCosts(pinned, msgLen))
+}
+
+// QueryResultCosts costs for the data returned to a contract by a smart query to another contract
+func (g WasmGasRegister) QueryResultCosts(resultLen int) sdk.Gas {
+	if resultLen < 0 {
+		panic(sdkerrors.Wrap(types.ErrInvalid, "negative length"))
 	}
-	return eventGas + g.InstantiateContractCosts(pinned, msgLen)
+	return sdk.Gas(resultLen) * g.c.ContractMessageDataCost
+}
+
+// ResultDataCosts costs for the result data or IBC acknowledgement returned by a contract
+func (g WasmGasRegister) ResultDataCosts(dataLen int) sdk.Gas {
+	if dataLen < 0 {
+		panic(sdkerrors.Wrap(types.ErrInvalid, "negative length"))
+	}
+	return sdk.Gas(dataLen) * g.c.ContractMessageDataCost
 }
 
 // EventCosts costs to persist an event
 func (g WasmGasRegister) EventCosts(evts []wasmvmtypes.EventAttribute) sdk.Gas {
+	return g.eventCosts(false, evts)
+}
+
+// PinnedEventCosts costs to persist an event of a pinned contract
+func (g WasmGasRegister) PinnedEventCosts(evts []wasmvmtypes.EventAttribute) sdk.Gas {
+	return g.eventCosts(true, evts)
+}
+
+func (g WasmGasRegister) eventCosts(pinned bool, evts []wasmvmtypes.EventAttribute) sdk.Gas {
 	if len(evts) == 0 {
 		return 0
 	}
@@ -158,10 +236,14 @@ func (g WasmGasRegister) EventCosts(evts []wasmvmtypes.EventAttribute) sdk.Gas {
 		storedBytes += len(l.Key) + len(l.Value)
 	}
 	// apply free tier
-	if storedBytes <= g.c.EventAttributeDataFreeTier {
+	freeTier := g.c.EventAttributeDataFreeTier
+	if pinned && g.c.PinnedEventAttributeDataFreeTier > freeTier {
+		freeTier = g.c.PinnedEventAttributeDataFreeTier
+	}
+	if storedBytes <= freeTier {
 		storedBytes = 0
 	} else {
-		storedBytes -= g.c.EventAttributeDataFreeTier
+		storedBytes -= freeTier
 	}
 	// total Length * costs + attribute count * costs
 	r := sdk.NewIntFromUint64(g.c.EventAttributeDataCost).Mul(sdk.NewIntFromUint64(uint64(storedBytes))).
@@ -169,7 +251,21 @@ func (g WasmGasRegister) EventCosts(evts []wasmvmtypes.EventAttribute) sdk.Gas {
 	if !r.IsUint64() {
 		panic(sdk.ErrorOutOfGas{Descriptor: "overflow"})
 	}
-	return r.Uint64()
+	return g.pinnedDiscount(pinned, r.Uint64())
+}
+
+// HasPinnedEventCosts returns true when a free tier or discount for pinned contracts is configured
+func (g WasmGasRegister) HasPinnedEventCosts() bool {
+	return g.c.PinnedEventAttributeDataFreeTier > g.c.EventAttributeDataFreeTier || g.c.PinnedCostsDiscount != 0
+}
+
+// pinnedDiscount reduces the costs by the configured discount for pinned contracts
+func (g WasmGasRegister) pinnedDiscount(pinned bool, costs sdk.Gas) sdk.Gas {
+	if !pinned || g.c.PinnedCostsDiscount == 0 {
+		return costs
+	}
+	charged := 100 - g.c.PinnedCostsDiscount
+	return costs/100*charged + costs%100*charged/100
 }
 
 // ToWasmVMGas convert to wasmVM contract runtime gas unit
@@ -185,3 +281,20 @@ func (g WasmGasRegister) ToWasmVMGas(source storetypes.Gas) uint64 {
 func (g WasmGasRegister) FromWasmVMGas(source uint64) sdk.Gas {
 	return source / g.c.GasMultiplier
 }
+
+// GasCosts returns the configured costs and the costs of the address conversions by contracts
+func (g WasmGasRegister) GasCosts() types.GasCosts {
+	return types.GasCosts{
+		InstanceCost:                     g.c.InstanceCost,
+		CompileCost:                      g.c.CompileCost,
+		GasMultiplier:                    g.c.GasMultiplier,
+		ContractMessageDataCost:          g.c.ContractMessageDataCost,
+		EventPerAttributeCost:            g.c.EventPerAttributeCost,
+		EventAttributeDataCost:           g.c.EventAttributeDataCost,
+		EventAttributeDataFreeTier:       uint64(g.c.EventAttributeDataFreeTier),
+		PinnedEventAttributeDataFreeTier: uint64(g.c.PinnedEventAttributeDataFreeTier),
+		PinnedCostsDiscount:              g.c.PinnedCostsDiscount,
+		HumanAddressCost:                 g.FromWasmVMGas(costHumanize),
+		CanonicalAddressCost:             g.FromWasmVMGas(costCanonical),
+	}
+}
@@ -1,6 +1,7 @@
 package keeper
 
 import (
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
 	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
 	storetypes "github.com/cosmos/cosmos-sdk/store/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -225,6 +226,21 @@ func TestReplyCost(t *testing.T) {
 			pinned:    true,
 			exp:       sdk.Gas(3 + 10 + 6), // len("foo") + 1 * DefaultPerAttributeCost + len("myData")
 		},
+		"subcall response with multiple events - pinned": {
+			src: wasmvmtypes.Reply{
+				Result: wasmvmtypes.SubcallResult{
+					Ok: &wasmvmtypes.SubcallResponse{
+						Events: []wasmvmtypes.Event{
+							{Type: "foo", Attributes: []wasmvmtypes.EventAttribute{{Key: "myKey", Value: "myData"}}},
+							{Type: "bar", Attributes: []wasmvmtypes.EventAttribute{{Key: "myKey", Value: "myData"}, {Key: "myOtherKey", Value: "myData"}}},
+						},
+					},
+				},
+			},
+			srcConfig: DefaultGasRegisterConfig(),
+			pinned:    true,
+			exp:       sdk.Gas(3 + 3 + 3*10), // len("foo") + len("bar") + 3 * DefaultPerAttributeCost
+		},
 		"subcall response error - pinned": {
 			src: wasmvmtypes.Reply{
 				Result: wasmvmtypes.SubcallResult{
@@ -275,6 +291,25 @@ func TestReplyCost(t *testing.T) {
 			srcConfig: DefaultGasRegisterConfig(),
 			exp:       sdk.Gas(40_000 + 3 + 10 + 6), // DefaultInstanceCost + len("foo") + 1 * DefaultPerAttributeCost + len("myData")
 		},
+		"subcall response with events and data - pinned with discount": {
+			src: wasmvmtypes.Reply{
+				Result: wasmvmtypes.SubcallResult{
+					Ok: &wasmvmtypes.SubcallResponse{
+						Events: []wasmvmtypes.Event{
+							{Type: "foo", Attributes: []wasmvmtypes.EventAttribute{{Key: "myKey", Value: "myData"}}},
+						},
+						Data: []byte{0x1},
+					},
+				},
+			},
+			srcConfig: func() WasmGasRegisterConfig {
+				c := DefaultGasRegisterConfig()
+				c.PinnedCostsDiscount = 50
+				return c
+			}(),
+			pinned: true,
+			exp:    sdk.Gas(5 + 2), // (1 * DefaultPerAttributeCost) / 2 + (len("foo") + len(data)) / 2
+		},
 		"subcall response error - unpinned": {
 			src: wasmvmtypes.Reply{
 				Result: wasmvmtypes.SubcallResult{
@@ -299,6 +334,218 @@ func TestReplyCost(t *testing.T) {
 	}
 }
 
+func TestEventCosts(t *testing.T) {
+	// most cases are covered in TestReplyCost already
+	pinnedConfig := func(freeTier int, discount uint64) WasmGasRegisterConfig {
+		c := DefaultGasRegisterConfig()
+		c.PinnedEventAttributeDataFreeTier = freeTier
+		c.PinnedCostsDiscount = discount
+		return c
+	}
+	aboveFreeTier := []wasmvmtypes.EventAttribute{{Key: strings.Repeat("x", DefaultEventAttributeDataFreeTier), Value: "myData"}}
+	specs := map[string]struct {
+		srcAttrs  []wasmvmtypes.EventAttribute
+		srcConfig WasmGasRegisterConfig
+		pinned    bool
+		exp       sdk.Gas
+		expPanic  bool
+	}{
+		"empty events": {
+			srcConfig: pinnedConfig(0, 50),
+			pinned:    true,
+			exp:       sdk.Gas(0),
+		},
+		"pinned with larger free tier": {
+			srcAttrs:  aboveFreeTier,
+			srcConfig: pinnedConfig(DefaultEventAttributeDataFreeTier+6, 0),
+			pinned:    true,
+			exp:       sdk.Gas(10), // 1 * DefaultPerAttributeCost
+		},
+		"unpinned with larger pinned free tier": {
+			srcAttrs:  aboveFreeTier,
+			srcConfig: pinnedConfig(DefaultEventAttributeDataFreeTier+6, 0),
+			exp:       sdk.Gas(10 + 6), // 1 * DefaultPerAttributeCost + len("myData")
+		},
+		"pinned with smaller free tier": {
+			srcAttrs:  aboveFreeTier,
+			srcConfig: pinnedConfig(0, 0),
+			pinned:    true,
+			exp:       sdk.Gas(10 + 6), // regular free tier applies
+		},
+		"pinned with discount": {
+			srcAttrs:  aboveFreeTier,
+			srcConfig: pinnedConfig(0, 25),
+			pinned:    true,
+			exp:       sdk.Gas(12), // (1 * DefaultPerAttributeCost + len("myData")) * 75%
+		},
+		"pinned with full discount": {
+			srcAttrs:  aboveFreeTier,
+			srcConfig: pinnedConfig(0, 100),
+			pinned:    true,
+			exp:       sdk.Gas(0),
+		},
+		"unpinned with discount": {
+			srcAttrs:  aboveFreeTier,
+			srcConfig: pinnedConfig(0, 25),
+			exp:       sdk.Gas(10 + 6),
+		},
+		"discount exceeds 100": {
+			srcAttrs:  aboveFreeTier,
+			srcConfig: pinnedConfig(0, 101),
+			pinned:    true,
+			expPanic:  true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			eventCosts := func(evts []wasmvmtypes.EventAttribute) sdk.Gas {
+				if spec.pinned {
+					return NewWasmGasRegister(spec.srcConfig).PinnedEventCosts(evts)
+				}
+				return NewWasmGasRegister(spec.srcConfig).EventCosts(evts)
+			}
+			if spec.expPanic {
+				assert.Panics(t, func() {
+					eventCosts(spec.srcAttrs)
+				})
+				return
+			}
+			gotGas := eventCosts(spec.srcAttrs)
+			assert.Equal(t, spec.exp, gotGas)
+		})
+	}
+}
+
+func TestKeeperEventCosts(t *testing.T) {
+	myAttrs := []wasmvmtypes.EventAttribute{{Key: "foo", Value: "bar"}}
+	custom := wasmtesting.MockGasRegister{EventCostsFn: func(evts []wasmvmtypes.EventAttribute) sdk.Gas {
+		return 1
+	}}
+	cfg := DefaultGasRegisterConfig()
+	cfg.PinnedEventAttributeDataFreeTier = 0
+	cfg.PinnedCostsDiscount = 100
+	specs := map[string]struct {
+		gasRegister GasRegister
+		pinned      bool
+		exp         sdk.Gas
+	}{
+		"custom register": {
+			gasRegister: custom,
+			exp:         1,
+		},
+		"custom register - pinned": {
+			gasRegister: custom,
+			pinned:      true,
+			exp:         1,
+		},
+		"pinned event costs register": {
+			gasRegister: NewWasmGasRegister(cfg),
+			exp:         DefaultPerAttributeCost,
+		},
+		"pinned event costs register - pinned": {
+			gasRegister: NewWasmGasRegister(cfg),
+			pinned:      true,
+			exp:         0,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			k := Keeper{gasRegister: spec.gasRegister}
+			assert.Equal(t, spec.exp, k.eventCosts(spec.pinned, myAttrs))
+		})
+	}
+}
+
+func TestQueryResultCosts(t *testing.T) {
+	specs := map[string]struct {
+		srcLen    int
+		srcConfig WasmGasRegisterConfig
+		exp       sdk.Gas
+		expPanic  bool
+	}{
+		"result data": {
+			srcLen:    10,
+			srcConfig: DefaultGasRegisterConfig(),
+			exp:       sdk.Gas(10), // 10 * DefaultContractMessageDataCost
+		},
+		"custom data cost": {
+			srcLen: 10,
+			srcConfig: WasmGasRegisterConfig{
+				GasMultiplier:           DefaultGasMultiplier,
+				ContractMessageDataCost: 2,
+			},
+			exp: sdk.Gas(20),
+		},
+		"empty result": {
+			srcLen:    0,
+			srcConfig: DefaultGasRegisterConfig(),
+			exp:       sdk.Gas(0),
+		},
+		"negative len": {
+			srcLen:    -1,
+			srcConfig: DefaultGasRegisterConfig(),
+			expPanic:  true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			if spec.expPanic {
+				assert.Panics(t, func() {
+					NewWasmGasRegister(spec.srcConfig).QueryResultCosts(spec.srcLen)
+				})
+				return
+			}
+			gotGas := NewWasmGasRegister(spec.srcConfig).QueryResultCosts(spec.srcLen)
+			assert.Equal(t, spec.exp, gotGas)
+		})
+	}
+}
+
+func TestResultDataCosts(t *testing.T) {
+	specs := map[string]struct {
+		srcLen    int
+		srcConfig WasmGasRegisterConfig
+		exp       sdk.Gas
+		expPanic  bool
+	}{
+		"result data": {
+			srcLen:    10,
+			srcConfig: DefaultGasRegisterConfig(),
+			exp:       sdk.Gas(10), // 10 * DefaultContractMessageDataCost
+		},
+		"custom data cost": {
+			srcLen: 10,
+			srcConfig: WasmGasRegisterConfig{
+				GasMultiplier:           DefaultGasMultiplier,
+				ContractMessageDataCost: 2,
+			},
+			exp: sdk.Gas(20),
+		},
+		"empty data": {
+			srcLen:    0,
+			srcConfig: DefaultGasRegisterConfig(),
+			exp:       sdk.Gas(0),
+		},
+		"negative len": {
+			srcLen:    -1,
+			srcConfig: DefaultGasRegisterConfig(),
+			expPanic:  true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			if spec.expPanic {
+				assert.Panics(t, func() {
+					NewWasmGasRegister(spec.srcConfig).ResultDataCosts(spec.srcLen)
+				})
+				return
+			}
+			gotGas := NewWasmGasRegister(spec.srcConfig).ResultDataCosts(spec.srcLen)
+			assert.Equal(t, spec.exp, gotGas)
+		})
+	}
+}
+
 func TestToWasmVMGasConversion(t *testing.T) {
 	specs := map[string]struct {
 		src       storetypes.Gas
@@ -383,3 +630,32 @@ func TestFromWasmVMGasConversion(t *testing.T) {
 		})
 	}
 }
+
+func TestHasPinnedEventCosts(t *testing.T) {
+	specs := map[string]struct {
+		srcConfig func(*WasmGasRegisterConfig)
+		exp       bool
+	}{
+		"default": {
+			srcConfig: func(c *WasmGasRegisterConfig) {},
+		},
+		"pinned free tier": {
+			srcConfig: func(c *WasmGasRegisterConfig) { c.PinnedEventAttributeDataFreeTier = c.EventAttributeDataFreeTier + 1 },
+			exp:       true,
+		},
+		"pinned free tier below free tier": {
+			srcConfig: func(c *WasmGasRegisterConfig) { c.PinnedEventAttributeDataFreeTier = c.EventAttributeDataFreeTier - 1 },
+		},
+		"pinned discount": {
+			srcConfig: func(c *WasmGasRegisterConfig) { c.PinnedCostsDiscount = 10 },
+			exp:       true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			cfg := DefaultGasRegisterConfig()
+			spec.srcConfig(&cfg)
+			assert.Equal(t, spec.exp, NewWasmGasRegister(cfg).HasPinnedEventCosts())
+		})
+	}
+}
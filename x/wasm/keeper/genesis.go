@@ -44,9 +44,20 @@ func InitGenesis(ctx sdk.Context, keeper *Keeper, data types.GenesisState, staki
 		if err != nil {
 			return nil, sdkerrors.Wrapf(err, "contract number %d", i)
 		}
+		keeper.storeDeniedDenoms(ctx, contractAddr, contract.DeniedDenoms)
 		maxContractID = i + 1 // not ideal but max(contractID) is not persisted otherwise
 	}
 
+	var maxInterchainQueryID uint64
+	for i, q := range data.InterchainQueries {
+		if err := keeper.importInterchainQuery(ctx, q); err != nil {
+			return nil, sdkerrors.Wrapf(err, "interchain query number %d", i)
+		}
+		if q.ID > maxInterchainQueryID {
+			maxInterchainQueryID = q.ID
+		}
+	}
+
 	for i, seq := range data.Sequences {
 		err := keeper.importAutoIncrementID(ctx, seq.IDKey, seq.Value)
 		if err != nil {
@@ -54,6 +65,32 @@ func InitGenesis(ctx sdk.Context, keeper *Keeper, data types.GenesisState, staki
 		}
 	}
 
+	for i, d := range data.StoreCodeDeposits {
+		if err := keeper.importStoreCodeDeposit(ctx, d); err != nil {
+			return nil, sdkerrors.Wrapf(err, "store code deposit number %d", i)
+		}
+	}
+
+	var maxJobID uint64
+	for i, job := range data.Jobs {
+		if err := keeper.importJob(ctx, job); err != nil {
+			return nil, sdkerrors.Wrapf(err, "job number %d", i)
+		}
+		if job.ID > maxJobID {
+			maxJobID = job.ID
+		}
+	}
+
+	for _, n := range data.FrozenClientNotifications {
+		keeper.importFrozenClientNotification(ctx, n)
+	}
+
+	for i, s := range data.CodeStats {
+		if err := keeper.importCodeExecutionStats(ctx, s); err != nil {
+			return nil, sdkerrors.Wrapf(err, "code stats number %d", i)
+		}
+	}
+
 	// sanity check seq values
 	if keeper.peekAutoIncrementID(ctx, types.KeyLastCodeID) <= maxCodeID {
 		return nil, sdkerrors.Wrapf(types.ErrInvalid, "seq %s must be greater %d ", string(types.KeyLastCodeID), maxCodeID)
@@ -61,6 +98,12 @@ func InitGenesis(ctx sdk.Context, keeper *Keeper, data types.GenesisState, staki
 	if keeper.peekAutoIncrementID(ctx, types.KeyLastInstanceID) <= uint64(maxContractID) {
 		return nil, sdkerrors.Wrapf(types.ErrInvalid, "seq %s must be greater %d ", string(types.KeyLastInstanceID), maxContractID)
 	}
+	if keeper.peekAutoIncrementID(ctx, types.KeyLastInterchainQueryID) <= maxInterchainQueryID {
+		return nil, sdkerrors.Wrapf(types.ErrInvalid, "seq %s must be greater %d ", string(types.KeyLastInterchainQueryID), maxInterchainQueryID)
+	}
+	if keeper.peekAutoIncrementID(ctx, types.KeyLastJobID) <= maxJobID {
+		return nil, sdkerrors.Wrapf(types.ErrInvalid, "seq %s must be greater %d ", string(types.KeyLastJobID), maxJobID)
+	}
 
 	if len(data.GenMsgs) == 0 {
 		return nil, nil
@@ -70,10 +113,13 @@ func InitGenesis(ctx sdk.Context, keeper *Keeper, data types.GenesisState, staki
 		if msg == nil {
 			return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidType, "unknown message")
 		}
-		_, err := msgHandler(ctx, msg)
+		res, err := msgHandler(ctx, msg)
 		if err != nil {
 			return nil, sdkerrors.Wrap(err, "genesis")
 		}
+		if res != nil {
+			ctx.EventManager().EmitEvents(res.GetEvents())
+		}
 	}
 	return stakingKeeper.ApplyAndReturnValidatorSetUpdates(ctx)
 }
@@ -114,12 +160,38 @@ func ExportGenesis(ctx sdk.Context, keeper *Keeper) *types.GenesisState {
 			ContractAddress: addr.String(),
 			ContractInfo:    contract,
 			ContractState:   state,
+			DeniedDenoms:    keeper.GetDeniedDenoms(ctx, addr),
 		})
 
 		return false
 	})
 
-	for _, k := range [][]byte{types.KeyLastCodeID, types.KeyLastInstanceID} {
+	keeper.IterateStoreCodeDeposits(ctx, func(d types.StoreCodeDeposit) bool {
+		genState.StoreCodeDeposits = append(genState.StoreCodeDeposits, d)
+		return false
+	})
+
+	keeper.IterateInterchainQueries(ctx, func(q types.InterchainQuery) bool {
+		genState.InterchainQueries = append(genState.InterchainQueries, q)
+		return false
+	})
+
+	keeper.IterateJobs(ctx, func(job types.Job) bool {
+		genState.Jobs = append(genState.Jobs, job)
+		return false
+	})
+
+	keeper.IterateFrozenClientNotifications(ctx, func(n types.FrozenClientNotification) bool {
+		genState.FrozenClientNotifications = append(genState.FrozenClientNotifications, n)
+		return false
+	})
+
+	keeper.IterateCodeExecutionStats(ctx, func(codeID uint64, stats types.CodeExecutionStats) bool {
+		genState.CodeStats = append(genState.CodeStats, types.CodeStats{CodeID: codeID, Stats: stats})
+		return false
+	})
+
+	for _, k := range [][]byte{types.KeyLastCodeID, types.KeyLastInstanceID, types.KeyLastInterchainQueryID, types.KeyLastJobID} {
 		genState.Sequences = append(genState.Sequences, types.Sequence{
 			IDKey: k,
 			Value: keeper.peekAutoIncrementID(ctx, k),
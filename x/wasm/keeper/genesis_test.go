@@ -84,6 +84,43 @@ func TestGenesisExportImport(t *testing.T) {
 		wasmKeeper.appendToContractHistory(srcCtx, contractAddr, history...)
 		wasmKeeper.importContractState(srcCtx, contractAddr, stateModels)
 	}
+	var queryOwner sdk.AccAddress
+	wasmKeeper.IterateContractInfo(srcCtx, func(addr sdk.AccAddress, _ types.ContractInfo) bool {
+		queryOwner = addr
+		return true
+	})
+	wasmKeeper.setInterchainQuery(srcCtx, types.InterchainQuery{
+		ID:                       wasmKeeper.autoIncrementID(srcCtx, types.KeyLastInterchainQueryID),
+		Owner:                    queryOwner.String(),
+		ConnectionID:             "connection-0",
+		Keys:                     []types.InterchainQueryKey{{Path: "bank", Key: []byte("myKey")}},
+		UpdatePeriod:             10,
+		LastSubmittedHeight:      5,
+		LastRemoteRevisionNumber: 1,
+		LastRemoteRevisionHeight: 100,
+	})
+	bulkMigration, err := wasmKeeper.cdc.MarshalBinaryBare(&types.BulkMigration{
+		CodeID:     1,
+		NewCodeID:  2,
+		RunAs:      queryOwner.String(),
+		MigrateMsg: []byte(`{}`),
+		NextKey:    []byte("myKey"),
+		Migrated:   3,
+		Failed:     1,
+	})
+	require.NoError(t, err)
+	_, err = wasmKeeper.QueueJob(srcCtx, BulkMigrationJobType, bulkMigration, 10)
+	require.NoError(t, err)
+	wasmKeeper.setStoreCodeDeposit(srcCtx, types.StoreCodeDeposit{
+		CodeID:       1,
+		Depositor:    RandomBech32AccountAddress(t),
+		Amount:       sdk.NewCoins(sdk.NewInt64Coin("denom", 1)),
+		RefundHeight: 10,
+	})
+
+	wasmKeeper.importFrozenClientNotification(srcCtx, types.FrozenClientNotification{ClientID: "07-tendermint-0", PortID: "wasm.myport", ChannelID: "channel-0"})
+	wasmKeeper.storeCodeExecutionStats(srcCtx, 1, types.CodeExecutionStats{Epoch: 2, Executions: 3, GasUsed: 4})
+
 	var wasmParams types.Params
 	f.NilChance(0).Fuzz(&wasmParams)
 	wasmKeeper.setParams(srcCtx, wasmParams)
@@ -44,6 +44,29 @@ func NewDefaultMessageHandler(
 	)
 }
 
+// NewDefaultMessageHandlerWithBalanceCheck is the default message handler that checks the spendable contract balance
+// of bank sends and IBC transfers first, see NewSpendableBalanceCheckHandler. It is used by the keeper.
+func NewDefaultMessageHandlerWithBalanceCheck(
+	router sdk.Router,
+	channelKeeper types.ChannelKeeper,
+	capabilityKeeper types.CapabilityKeeper,
+	bankKeeper types.BankKeeper,
+	unpacker codectypes.AnyUnpacker,
+	portSource types.ICS20TransferPortSource,
+	customEncoders ...*MessageEncoders,
+) Messenger {
+	encoders := DefaultEncoders(unpacker, portSource)
+	for _, e := range customEncoders {
+		encoders = encoders.Merge(e)
+	}
+	return NewMessageHandlerChain(
+		NewSpendableBalanceCheckHandler(bankKeeper),
+		NewSDKMessageHandler(router, encoders),
+		NewIBCRawPacketHandler(channelKeeper, capabilityKeeper),
+		NewBurnCoinMessageHandler(bankKeeper),
+	)
+}
+
 func NewSDKMessageHandler(router sdk.Router, encoders msgEncoder) SDKMessageHandler {
 	return SDKMessageHandler{
 		router:   router,
@@ -130,14 +153,18 @@ func (m MessageHandlerChain) DispatchMsg(ctx sdk.Context, contractAddr sdk.AccAd
 // IBCRawPacketHandler handels IBC.SendPacket messages which are published to an IBC channel.
 type IBCRawPacketHandler struct {
 	channelKeeper    types.ChannelKeeper
+	ics4Wrapper      types.ICS4Wrapper
 	capabilityKeeper types.CapabilityKeeper
 }
 
+// NewIBCRawPacketHandler constructor. Packets are sent via the channel keeper by default.
 func NewIBCRawPacketHandler(chk types.ChannelKeeper, cak types.CapabilityKeeper) *IBCRawPacketHandler {
-	return &IBCRawPacketHandler{channelKeeper: chk, capabilityKeeper: cak}
+	return &IBCRawPacketHandler{channelKeeper: chk, ics4Wrapper: chk, capabilityKeeper: cak}
 }
 
-// DispatchMsg publishes a raw IBC packet onto the channel.
+// DispatchMsg publishes a raw IBC packet onto the channel. The packet sequence is returned in the
+// protobuf encoded `MsgIBCSendResponse` as data so that contracts can correlate the packet with the
+// acknowledgement later.
 func (h IBCRawPacketHandler) DispatchMsg(ctx sdk.Context, _ sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, err error) {
 	if msg.IBC == nil || msg.IBC.SendPacket == nil {
 		return nil, nil, types.ErrUnknownMsg
@@ -175,7 +202,14 @@ func (h IBCRawPacketHandler) DispatchMsg(ctx sdk.Context, _ sdk.AccAddress, cont
 		convertWasmIBCTimeoutHeightToCosmosHeight(msg.IBC.SendPacket.Timeout.Block),
 		msg.IBC.SendPacket.Timeout.Timestamp,
 	)
-	return nil, nil, h.channelKeeper.SendPacket(ctx, channelCap, packet)
+	if err := h.ics4Wrapper.SendPacket(ctx, channelCap, packet); err != nil {
+		return nil, nil, err
+	}
+	rsp, err := (&types.MsgIBCSendResponse{Sequence: sequence}).Marshal()
+	if err != nil {
+		return nil, nil, sdkerrors.Wrap(err, "marshal response")
+	}
+	return nil, [][]byte{rsp}, nil
 }
 
 var _ Messenger = MessageHandlerFunc(nil)
@@ -207,3 +241,41 @@ func NewBurnCoinMessageHandler(burner types.Burner) MessageHandlerFunc {
 		return nil, nil, types.ErrUnknownMsg
 	}
 }
+
+// NewSpendableBalanceCheckHandler rejects bank send and IBC transfer messages that exceed the spendable balance of the
+// contract before they reach the downstream keepers. The error names the missing amount so that a contract can make
+// sense of it in a submessage reply. All other messages are passed on to the next handler.
+func NewSpendableBalanceCheckHandler(bankKeeper types.BankViewKeeper) MessageHandlerFunc {
+	return func(ctx sdk.Context, contractAddr sdk.AccAddress, _ string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, err error) {
+		var amount []wasmvmtypes.Coin
+		switch {
+		case msg.Bank != nil && msg.Bank.Send != nil:
+			amount = msg.Bank.Send.Amount
+		case msg.IBC != nil && msg.IBC.Transfer != nil:
+			amount = []wasmvmtypes.Coin{msg.IBC.Transfer.Amount}
+		default:
+			return nil, nil, types.ErrUnknownMsg
+		}
+		// invalid coins are rejected by the encoders
+		coins, err := convertWasmCoinsToSdkCoins(amount)
+		if err != nil {
+			return nil, nil, types.ErrUnknownMsg
+		}
+		required := make(map[string]sdk.Int)
+		var denoms []string
+		for _, c := range coins {
+			if _, ok := required[c.Denom]; !ok {
+				required[c.Denom] = sdk.ZeroInt()
+				denoms = append(denoms, c.Denom)
+			}
+			required[c.Denom] = required[c.Denom].Add(c.Amount)
+		}
+		spendable := bankKeeper.SpendableCoins(ctx, contractAddr)
+		for _, denom := range denoms {
+			if have := spendable.AmountOf(denom); have.LT(required[denom]) {
+				return nil, nil, sdkerrors.Wrapf(sdkerrors.ErrInsufficientFunds, "%s%s is smaller than %s%s", have, denom, required[denom], denom)
+			}
+		}
+		return nil, nil, types.ErrUnknownMsg
+	}
+}
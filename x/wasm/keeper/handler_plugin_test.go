@@ -9,6 +9,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/baseapp"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	bankkeeper "github.com/cosmos/cosmos-sdk/x/bank/keeper"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	capabilitytypes "github.com/cosmos/cosmos-sdk/x/capability/types"
 	clienttypes "github.com/cosmos/cosmos-sdk/x/ibc/core/02-client/types"
@@ -296,15 +297,18 @@ func TestIBCRawPacketHandler(t *testing.T) {
 			capturedPacket = nil
 			// when
 			h := NewIBCRawPacketHandler(spec.chanKeeper, spec.capKeeper)
-			data, evts, gotErr := h.DispatchMsg(ctx, RandomAccountAddress(t), ibcPort, wasmvmtypes.CosmosMsg{IBC: &wasmvmtypes.IBCMsg{SendPacket: &spec.srcMsg}})
+			evts, data, gotErr := h.DispatchMsg(ctx, RandomAccountAddress(t), ibcPort, wasmvmtypes.CosmosMsg{IBC: &wasmvmtypes.IBCMsg{SendPacket: &spec.srcMsg}})
 			// then
 			require.True(t, spec.expErr.Is(gotErr), "exp %v but got %#+v", spec.expErr, gotErr)
 			if spec.expErr != nil {
 				return
 			}
-			assert.Nil(t, data)
 			assert.Nil(t, evts)
 			assert.Equal(t, spec.expPacketSent, capturedPacket)
+			require.Len(t, data, 1)
+			var gotRsp types.MsgIBCSendResponse
+			require.NoError(t, gotRsp.Unmarshal(data[0]))
+			assert.Equal(t, capturedPacket.GetSequence(), gotRsp.Sequence)
 		})
 	}
 }
@@ -372,7 +376,7 @@ func TestBurnCoinMessageHandlerIntegration(t *testing.T) {
 			}}
 
 			// when
-			_, err = k.execute(ctx, example.Contract, example.CreatorAddr, nil, nil)
+			_, err = k.execute(ctx, example.Contract, example.CreatorAddr, nil, nil, DefaultAuthorizationPolicy{})
 
 			// then
 			if spec.expErr {
@@ -392,3 +396,85 @@ func TestBurnCoinMessageHandlerIntegration(t *testing.T) {
 	// test cases:
 	// not enough money to burn
 }
+
+func TestSpendableBalanceCheckHandler(t *testing.T) {
+	myContractAddr := RandomAccountAddress(t)
+	bankSend := func(coins ...wasmvmtypes.Coin) wasmvmtypes.CosmosMsg {
+		return wasmvmtypes.CosmosMsg{Bank: &wasmvmtypes.BankMsg{Send: &wasmvmtypes.SendMsg{ToAddress: RandomBech32AccountAddress(t), Amount: coins}}}
+	}
+	specs := map[string]struct {
+		srcMsg    wasmvmtypes.CosmosMsg
+		spendable sdk.Coins
+		expErr    *sdkerrors.Error
+	}{
+		"bank send within balance": {
+			srcMsg:    bankSend(wasmvmtypes.NewCoin(100, "denom"), wasmvmtypes.NewCoin(1, "other")),
+			spendable: sdk.NewCoins(sdk.NewInt64Coin("denom", 100), sdk.NewInt64Coin("other", 1)),
+			expErr:    types.ErrUnknownMsg,
+		},
+		"bank send exceeds balance": {
+			srcMsg:    bankSend(wasmvmtypes.NewCoin(101, "denom")),
+			spendable: sdk.NewCoins(sdk.NewInt64Coin("denom", 100)),
+			expErr:    sdkerrors.ErrInsufficientFunds,
+		},
+		"bank send with duplicate denoms exceeds balance": {
+			srcMsg:    bankSend(wasmvmtypes.NewCoin(60, "denom"), wasmvmtypes.NewCoin(60, "denom")),
+			spendable: sdk.NewCoins(sdk.NewInt64Coin("denom", 100)),
+			expErr:    sdkerrors.ErrInsufficientFunds,
+		},
+		"bank send of unknown denom": {
+			srcMsg:    bankSend(wasmvmtypes.NewCoin(1, "other")),
+			spendable: sdk.NewCoins(sdk.NewInt64Coin("denom", 100)),
+			expErr:    sdkerrors.ErrInsufficientFunds,
+		},
+		"bank send with invalid coins": {
+			srcMsg: bankSend(wasmvmtypes.Coin{Denom: "denom", Amount: "invalid"}),
+			expErr: types.ErrUnknownMsg,
+		},
+		"ibc transfer within balance": {
+			srcMsg:    wasmvmtypes.CosmosMsg{IBC: &wasmvmtypes.IBCMsg{Transfer: &wasmvmtypes.TransferMsg{Amount: wasmvmtypes.NewCoin(1, "denom")}}},
+			spendable: sdk.NewCoins(sdk.NewInt64Coin("denom", 1)),
+			expErr:    types.ErrUnknownMsg,
+		},
+		"ibc transfer exceeds balance": {
+			srcMsg: wasmvmtypes.CosmosMsg{IBC: &wasmvmtypes.IBCMsg{Transfer: &wasmvmtypes.TransferMsg{Amount: wasmvmtypes.NewCoin(1, "denom")}}},
+			expErr: sdkerrors.ErrInsufficientFunds,
+		},
+		"other message": {
+			srcMsg: wasmvmtypes.CosmosMsg{Bank: &wasmvmtypes.BankMsg{Burn: &wasmvmtypes.BurnMsg{Amount: wasmvmtypes.Coins{wasmvmtypes.NewCoin(1, "denom")}}}},
+			expErr: types.ErrUnknownMsg,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			bank := mockBankViewKeeper{spendable: map[string]sdk.Coins{myContractAddr.String(): spec.spendable}}
+			h := NewSpendableBalanceCheckHandler(bank)
+			_, _, gotErr := h.DispatchMsg(sdk.Context{}, myContractAddr, "", spec.srcMsg)
+			assert.True(t, spec.expErr.Is(gotErr), "exp %v got %+v", spec.expErr, gotErr)
+		})
+	}
+}
+
+type mockBankViewKeeper struct {
+	types.BankViewKeeper
+	spendable map[string]sdk.Coins
+}
+
+func (m mockBankViewKeeper) SpendableCoins(ctx sdk.Context, addr sdk.AccAddress) sdk.Coins {
+	return m.spendable[addr.String()]
+}
+
+func TestDefaultMessageHandlers(t *testing.T) {
+	// without the balance check
+	h := NewDefaultMessageHandler(nil, &wasmtesting.MockChannelKeeper{}, wasmtesting.MockCapabilityKeeper{}, bankkeeper.BaseKeeper{}, nil, nil)
+	handlers := h.(*MessageHandlerChain).handlers
+	require.Len(t, handlers, 3)
+	assert.IsType(t, SDKMessageHandler{}, handlers[0])
+
+	// with the balance check first
+	h = NewDefaultMessageHandlerWithBalanceCheck(nil, &wasmtesting.MockChannelKeeper{}, wasmtesting.MockCapabilityKeeper{}, bankkeeper.BaseKeeper{}, nil, nil)
+	handlers = h.(*MessageHandlerChain).handlers
+	require.Len(t, handlers, 4)
+	assert.IsType(t, MessageHandlerFunc(nil), handlers[0])
+	assert.IsType(t, SDKMessageHandler{}, handlers[1])
+}
@@ -1,12 +1,16 @@
 package keeper
 
 import (
+	"encoding/base32"
 	"strings"
 
 	"github.com/CosmWasm/wasmd/x/wasm/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	capabilitytypes "github.com/cosmos/cosmos-sdk/x/capability/types"
+	channeltypes "github.com/cosmos/cosmos-sdk/x/ibc/core/04-channel/types"
 	host "github.com/cosmos/cosmos-sdk/x/ibc/core/24-host"
 )
 
@@ -24,15 +28,133 @@ func (k Keeper) bindIbcPort(ctx sdk.Context, portID string) error {
 // (lack of permissions or someone else has it)
 func (k Keeper) ensureIbcPort(ctx sdk.Context, contractAddr sdk.AccAddress) (string, error) {
 	portID := PortIDForContract(contractAddr)
-	if _, ok := k.capabilityKeeper.GetCapability(ctx, host.PortPath(portID)); ok {
-		return portID, nil
+	if _, ok := k.capabilityKeeper.GetCapability(ctx, host.PortPath(portID)); !ok {
+		if err := k.bindIbcPort(ctx, portID); err != nil {
+			return portID, err
+		}
+	}
+	k.storePortIDMapping(ctx, portID, contractAddr)
+	return portID, nil
+}
+
+// storePortIDMapping stores the contract address for the port so that the contract can be found without decoding
+// the port id
+func (k Keeper) storePortIDMapping(ctx sdk.Context, portID string, contractAddr sdk.AccAddress) {
+	ctx.KVStore(k.storeKey).Set(types.GetPortIDToContractKey(portID), contractAddr)
+}
+
+// ContractByPortID returns the address of the contract that is bound to the port. Ports that were bound before the
+// mapping was stored fall back to decoding the address from the port id.
+func (k Keeper) ContractByPortID(ctx sdk.Context, portID string) (sdk.AccAddress, error) {
+	if bz := ctx.KVStore(k.storeKey).Get(types.GetPortIDToContractKey(portID)); bz != nil {
+		return bz, nil
+	}
+	return ContractFromPortID(portID)
+}
+
+// GetOpenChannelIDs returns the ids of the open channels on the IBC port. Without a PortChannelSource all channels
+// are iterated.
+func (k Keeper) GetOpenChannelIDs(ctx sdk.Context, portID string) []string {
+	var channelIDs []string
+	cb := func(ch channeltypes.IdentifiedChannel) bool {
+		if ch.PortId == portID && ch.State == channeltypes.OPEN {
+			channelIDs = append(channelIDs, ch.ChannelId)
+		}
+		return false
+	}
+	if k.portChannels != nil {
+		k.portChannels.IteratePortChannels(ctx, portID, cb)
+	} else {
+		k.channelKeeper.IterateChannels(ctx, cb)
+	}
+	return channelIDs
+}
+
+// PortChannelSource iterates the channels of a single IBC port
+type PortChannelSource interface {
+	IteratePortChannels(ctx sdk.Context, portID string, cb func(channeltypes.IdentifiedChannel) bool)
+}
+
+var _ PortChannelSource = ibcPortChannelSource{}
+
+type ibcPortChannelSource struct {
+	storeKey sdk.StoreKey
+	cdc      codec.BinaryMarshaler
+}
+
+// NewIBCPortChannelSource returns a PortChannelSource that reads the channel ends of the port from the IBC store. The
+// IBC channel keeper can only iterate the channels of all ports.
+func NewIBCPortChannelSource(ibcStoreKey sdk.StoreKey, cdc codec.BinaryMarshaler) PortChannelSource {
+	return ibcPortChannelSource{storeKey: ibcStoreKey, cdc: cdc}
+}
+
+// IteratePortChannels iterates the channel ends with the key prefix of the port. The stop flag of the callback aborts
+// the iteration.
+func (s ibcPortChannelSource) IteratePortChannels(ctx sdk.Context, portID string, cb func(channeltypes.IdentifiedChannel) bool) {
+	// the channel path with an empty channel id is the prefix of all channel ends of the port
+	store := prefix.NewStore(ctx.KVStore(s.storeKey), host.ChannelKey(portID, ""))
+	iter := store.Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var channel channeltypes.Channel
+		s.cdc.MustUnmarshalBinaryBare(iter.Value(), &channel)
+		if cb(channeltypes.NewIdentifiedChannel(portID, string(iter.Key()), channel)) {
+			return
+		}
+	}
+}
+
+// ContractChannelSource iterates the channels of all contract ports
+type ContractChannelSource interface {
+	// IterateContractChannels iterates the channels of the contract ports in key order. The iteration starts after the
+	// given port and channel id or with the first channel when they are empty. The stop flag of the callback aborts
+	// the iteration.
+	IterateContractChannels(ctx sdk.Context, afterPortID, afterChannelID string, cb func(channeltypes.IdentifiedChannel) bool)
+}
+
+var _ ContractChannelSource = ibcPortChannelSource{}
+
+// NewIBCContractChannelSource returns a ContractChannelSource that reads the channel ends of the contract ports from
+// the IBC store.
+func NewIBCContractChannelSource(ibcStoreKey sdk.StoreKey, cdc codec.BinaryMarshaler) ContractChannelSource {
+	return ibcPortChannelSource{storeKey: ibcStoreKey, cdc: cdc}
+}
+
+// IterateContractChannels iterates the channel ends with the key prefix of the contract ports. All contract port ids
+// share the same prefix so that their channel ends are stored in a single key range of the IBC store.
+func (s ibcPortChannelSource) IterateContractChannels(ctx sdk.Context, afterPortID, afterChannelID string, cb func(channeltypes.IdentifiedChannel) bool) {
+	contractChannelsPrefix := []byte(host.KeyChannelEndPrefix + "/" + host.KeyPortPrefix + "/" + portIDPrefix)
+	start := contractChannelsPrefix
+	if afterPortID != "" {
+		start = append(host.ChannelKey(afterPortID, afterChannelID), 0)
+	}
+	iter := ctx.KVStore(s.storeKey).Iterator(start, sdk.PrefixEndBytes(contractChannelsPrefix))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		portID, channelID, err := host.ParseChannelPath(string(iter.Key()))
+		if err != nil {
+			continue
+		}
+		var channel channeltypes.Channel
+		s.cdc.MustUnmarshalBinaryBare(iter.Value(), &channel)
+		if cb(channeltypes.NewIdentifiedChannel(portID, channelID, channel)) {
+			return
+		}
 	}
-	return portID, k.bindIbcPort(ctx, portID)
 }
 
 const portIDPrefix = "wasm."
 
+// portIDAddressEncoding encodes the contract addresses that are longer than 20 bytes in the port id. The bech32
+// string would exceed the max length of IBC identifiers.
+var portIDAddressEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+// PortIDForContract returns the IBC port id of the contract. Contract addresses longer than 20 bytes are base32
+// encoded without the bech32 prefix and checksum.
 func PortIDForContract(addr sdk.AccAddress) string {
+	if len(addr) > sdk.AddrLen {
+		return portIDPrefix + portIDAddressEncoding.EncodeToString(addr)
+	}
 	return portIDPrefix + addr.String()
 }
 
@@ -40,7 +162,15 @@ func ContractFromPortID(portID string) (sdk.AccAddress, error) {
 	if !strings.HasPrefix(portID, portIDPrefix) {
 		return nil, sdkerrors.Wrapf(types.ErrInvalid, "without prefix")
 	}
-	return sdk.AccAddressFromBech32(portID[len(portIDPrefix):])
+	encoded := portID[len(portIDPrefix):]
+	// a bech32 string contains the separator `1` that is not in the base32 alphabet
+	if bz, err := portIDAddressEncoding.DecodeString(encoded); err == nil && len(bz) > sdk.AddrLen {
+		if err := sdk.VerifyAddressFormat(bz); err != nil {
+			return nil, sdkerrors.Wrap(types.ErrInvalid, err.Error())
+		}
+		return bz, nil
+	}
+	return sdk.AccAddressFromBech32(encoded)
 }
 
 // AuthenticateCapability wraps the scopedKeeper's AuthenticateCapability function
@@ -0,0 +1,55 @@
+//go:build go1.18
+// +build go1.18
+
+package keeper
+
+import (
+	"strings"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	host "github.com/cosmos/cosmos-sdk/x/ibc/core/24-host"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// FuzzPortIDForContract ensures that the port id of any valid contract address is a valid IBC identifier that
+// decodes to the same address
+func FuzzPortIDForContract(f *testing.F) {
+	withLongAddressVerifier(f)
+	f.Add([]byte(BuildContractAddress(1, 100)))
+	f.Add([]byte(BuildModuleAccountContractAddress(1, 100)))
+	f.Add([]byte{})
+	f.Fuzz(func(t *testing.T, bz []byte) {
+		addr := sdk.AccAddress(bz)
+		if sdk.VerifyAddressFormat(addr) != nil {
+			return
+		}
+		portID := PortIDForContract(addr)
+		require.NoError(t, host.PortIdentifierValidator(portID))
+		gotAddr, err := ContractFromPortID(portID)
+		require.NoError(t, err)
+		assert.Equal(t, addr, gotAddr)
+	})
+}
+
+// FuzzContractFromPortID ensures that any port id that is accepted decodes to a valid address with a port id that
+// round-trips. Port ids are chosen by the counterparty chain and must not crash the node.
+func FuzzContractFromPortID(f *testing.F) {
+	withLongAddressVerifier(f)
+	f.Add(PortIDForContract(BuildContractAddress(1, 100)))
+	f.Add(PortIDForContract(BuildModuleAccountContractAddress(1, 100)))
+	f.Add(strings.ToUpper(PortIDForContract(BuildContractAddress(1, 100))))
+	f.Add("wasm.")
+	f.Add("wasm.foobar")
+	f.Fuzz(func(t *testing.T, portID string) {
+		addr, err := ContractFromPortID(portID)
+		if err != nil {
+			return
+		}
+		require.NoError(t, sdk.VerifyAddressFormat(addr))
+		gotAddr, err := ContractFromPortID(PortIDForContract(addr))
+		require.NoError(t, err)
+		assert.Equal(t, addr, gotAddr)
+	})
+}
@@ -3,6 +3,8 @@ package keeper
 import (
 	"fmt"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	channeltypes "github.com/cosmos/cosmos-sdk/x/ibc/core/04-channel/types"
+	host "github.com/cosmos/cosmos-sdk/x/ibc/core/24-host"
 	"github.com/stretchr/testify/assert"
 	"testing"
 
@@ -80,3 +82,126 @@ func TestContractFromPortID(t *testing.T) {
 	}
 
 }
+
+func TestPortIDForLongContractAddress(t *testing.T) {
+	withLongAddressVerifier(t)
+	contractAddr := BuildModuleAccountContractAddress(1, 100)
+
+	// when
+	portID := PortIDForContract(contractAddr)
+
+	// then
+	require.NoError(t, host.PortIdentifierValidator(portID))
+	gotAddr, err := ContractFromPortID(portID)
+	require.NoError(t, err)
+	assert.Equal(t, contractAddr, gotAddr)
+	// and classic addresses are not changed
+	classicAddr := BuildContractAddress(1, 100)
+	assert.Equal(t, "wasm."+classicAddr.String(), PortIDForContract(classicAddr))
+}
+
+func TestContractByPortID(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	k := keepers.WasmKeeper
+	example := InstantiateIBCReflectContract(t, ctx, keepers)
+	portID := k.GetContractInfo(ctx, example.Contract).IBCPortID
+
+	// stored mapping
+	gotAddr, err := k.ContractByPortID(ctx, portID)
+	require.NoError(t, err)
+	assert.Equal(t, example.Contract, gotAddr)
+
+	// the stored mapping has precedence over the decoded address
+	otherAddr := RandomAccountAddress(t)
+	k.storePortIDMapping(ctx, portID, otherAddr)
+	gotAddr, err = k.ContractByPortID(ctx, portID)
+	require.NoError(t, err)
+	assert.Equal(t, otherAddr, gotAddr)
+
+	// fallback for ports without stored mapping
+	gotAddr, err = k.ContractByPortID(ctx, PortIDForContract(otherAddr))
+	require.NoError(t, err)
+	assert.Equal(t, otherAddr, gotAddr)
+
+	_, err = k.ContractByPortID(ctx, "wasm.foobar")
+	require.Error(t, err)
+}
+
+func TestIBCPortChannelSource(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	channels := []channeltypes.IdentifiedChannel{
+		{PortId: "wasm.myport", ChannelId: "channel-0", State: channeltypes.OPEN},
+		{PortId: "wasm.myport", ChannelId: "channel-1", State: channeltypes.CLOSED},
+		{PortId: "wasm.myport", ChannelId: "channel-2", State: channeltypes.OPEN},
+		{PortId: "wasm.myportx", ChannelId: "channel-3", State: channeltypes.OPEN},
+		{PortId: "transfer", ChannelId: "channel-4", State: channeltypes.OPEN},
+	}
+	for _, c := range channels {
+		keepers.IBCKeeper.ChannelKeeper.SetChannel(ctx, c.PortId, c.ChannelId, channeltypes.Channel{
+			State:          c.State,
+			Ordering:       channeltypes.UNORDERED,
+			Counterparty:   channeltypes.NewCounterparty("other", "channel-9"),
+			ConnectionHops: []string{"connection-0"},
+		})
+	}
+	src := keepers.WasmKeeper.portChannels // the IBC store source of the test setup
+	require.IsType(t, ibcPortChannelSource{}, src)
+
+	// when all channels of the port are iterated
+	var got []string
+	src.IteratePortChannels(ctx, "wasm.myport", func(c channeltypes.IdentifiedChannel) bool {
+		assert.Equal(t, "wasm.myport", c.PortId)
+		got = append(got, c.ChannelId+":"+c.State.String())
+		return false
+	})
+	// then other ports are not included
+	assert.Equal(t, []string{"channel-0:STATE_OPEN", "channel-1:STATE_CLOSED", "channel-2:STATE_OPEN"}, got)
+
+	// when the iteration is stopped
+	got = nil
+	src.IteratePortChannels(ctx, "wasm.myport", func(c channeltypes.IdentifiedChannel) bool {
+		got = append(got, c.ChannelId)
+		return true
+	})
+	assert.Equal(t, []string{"channel-0"}, got)
+
+	// and the open channels are the same as with the iteration of all channels
+	k := keepers.WasmKeeper
+	assert.Equal(t, []string{"channel-0", "channel-2"}, k.GetOpenChannelIDs(ctx, "wasm.myport"))
+	k.portChannels = nil
+	assert.Equal(t, []string{"channel-0", "channel-2"}, k.GetOpenChannelIDs(ctx, "wasm.myport"))
+}
+
+func TestIBCContractChannelSource(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	for _, c := range []channeltypes.IdentifiedChannel{
+		{PortId: "wasm.otherport", ChannelId: "channel-3"},
+		{PortId: "wasm.myport", ChannelId: "channel-0"},
+		{PortId: "transfer", ChannelId: "channel-4"},
+		{PortId: "wasm.myport", ChannelId: "channel-1"},
+	} {
+		keepers.IBCKeeper.ChannelKeeper.SetChannel(ctx, c.PortId, c.ChannelId, channeltypes.Channel{
+			State:          channeltypes.OPEN,
+			Ordering:       channeltypes.UNORDERED,
+			Counterparty:   channeltypes.NewCounterparty("other", "channel-9"),
+			ConnectionHops: []string{"connection-0"},
+		})
+	}
+	src := NewIBCContractChannelSource(keepers.WasmKeeper.portChannels.(ibcPortChannelSource).storeKey, keepers.WasmKeeper.cdc)
+	iterate := func(afterPortID, afterChannelID string, stopAfter int) []string {
+		var got []string
+		src.IterateContractChannels(ctx, afterPortID, afterChannelID, func(c channeltypes.IdentifiedChannel) bool {
+			assert.Equal(t, channeltypes.OPEN, c.State)
+			got = append(got, c.PortId+"/"+c.ChannelId)
+			return len(got) == stopAfter
+		})
+		return got
+	}
+	// when all channels are iterated then other ports are not included
+	assert.Equal(t, []string{"wasm.myport/channel-0", "wasm.myport/channel-1", "wasm.otherport/channel-3"}, iterate("", "", 0))
+	// when the iteration is stopped
+	assert.Equal(t, []string{"wasm.myport/channel-0"}, iterate("", "", 1))
+	// when the iteration continues after a channel
+	assert.Equal(t, []string{"wasm.myport/channel-1", "wasm.otherport/channel-3"}, iterate("wasm.myport", "channel-0", 0))
+	assert.Empty(t, iterate("wasm.otherport", "channel-3", 0))
+}
@@ -0,0 +1,238 @@
+package keeper
+
+import (
+	"strconv"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	clienttypes "github.com/cosmos/cosmos-sdk/x/ibc/core/02-client/types"
+	connectiontypes "github.com/cosmos/cosmos-sdk/x/ibc/core/03-connection/types"
+	commitmenttypes "github.com/cosmos/cosmos-sdk/x/ibc/core/23-commitment/types"
+)
+
+// DefaultInterchainQuerySudoGasLimit is the gas limit for a single interchain query result callback of a contract
+const DefaultInterchainQuerySudoGasLimit uint64 = 1000000
+
+// ConnectionSource is the subset of the IBC connection keeper that is used to find the client of an interchain query
+// connection
+type ConnectionSource interface {
+	GetConnection(ctx sdk.Context, connectionID string) (connectiontypes.ConnectionEnd, bool)
+}
+
+// InterchainQueryResultSudoMsg is sent to the contract via sudo with the verified result of one of its interchain
+// queries
+type InterchainQueryResultSudoMsg struct {
+	InterchainQueryResult *InterchainQueryResultData `json:"interchain_query_result"`
+}
+
+// InterchainQueryResultData contains the values of the query keys on the counterparty chain at the height
+type InterchainQueryResultData struct {
+	QueryID      uint64 `json:"query_id"`
+	ConnectionID string `json:"connection_id"`
+	// Height of the client consensus state that the proofs were verified against
+	Height ProofHeight `json:"height"`
+	// Values are in the same order as the query keys
+	Values []InterchainQueryValue `json:"values"`
+}
+
+// InterchainQueryValue is the value of a query key on the counterparty chain
+type InterchainQueryValue struct {
+	Path string `json:"path"`
+	Key  []byte `json:"key"`
+	// Value is empty when the key does not exist
+	Value []byte `json:"value"`
+	// Proof is the verified protobuf encoded ICS-23 merkle proof
+	Proof []byte `json:"proof"`
+}
+
+// registerInterchainQuery stores a new periodic query of the contract against the counterparty chain of the connection.
+// Relayers find the query by the event or the Query/InterchainQueries gRPC method.
+func (k Keeper) registerInterchainQuery(ctx sdk.Context, owner sdk.AccAddress, connectionID string, keys []types.InterchainQueryKey, updatePeriod uint64) (uint64, error) {
+	if k.interchainQueryConnections == nil {
+		return 0, sdkerrors.Wrap(types.ErrInvalid, "interchain queries not enabled")
+	}
+	if !k.HasContractInfo(ctx, owner) {
+		return 0, sdkerrors.Wrap(types.ErrNotFound, "contract")
+	}
+	if err := types.ValidateInterchainQueryKeys(keys); err != nil {
+		return 0, sdkerrors.Wrap(err, "keys")
+	}
+	if updatePeriod == 0 {
+		return 0, sdkerrors.Wrap(types.ErrEmpty, "update period")
+	}
+	if _, ok := k.interchainQueryConnections.GetConnection(ctx, connectionID); !ok {
+		return 0, sdkerrors.Wrapf(types.ErrNotFound, "connection: %s", connectionID)
+	}
+	q := types.InterchainQuery{
+		ID:           k.autoIncrementID(ctx, types.KeyLastInterchainQueryID),
+		Owner:        owner.String(),
+		ConnectionID: connectionID,
+		Keys:         keys,
+		UpdatePeriod: updatePeriod,
+	}
+	k.setInterchainQuery(ctx, q)
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeRegisterInterchainQuery,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(types.AttributeKeyQueryID, strconv.FormatUint(q.ID, 10)),
+		sdk.NewAttribute(types.AttributeKeyContractAddr, q.Owner),
+		sdk.NewAttribute(types.AttributeKeyConnectionID, connectionID),
+	))
+	return q.ID, nil
+}
+
+// removeInterchainQuery deletes the query. Only the owner contract can remove it.
+func (k Keeper) removeInterchainQuery(ctx sdk.Context, owner sdk.AccAddress, queryID uint64) error {
+	q := k.GetInterchainQuery(ctx, queryID)
+	if q == nil {
+		return sdkerrors.Wrap(types.ErrNotFound, "interchain query")
+	}
+	if q.Owner != owner.String() {
+		return sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "not the owner of the interchain query")
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetInterchainQueryKey(queryID))
+	store.Delete(types.GetInterchainQueryByOwnerKey(owner, queryID))
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeRemoveInterchainQuery,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(types.AttributeKeyQueryID, strconv.FormatUint(queryID, 10)),
+		sdk.NewAttribute(types.AttributeKeyContractAddr, q.Owner),
+	))
+	return nil
+}
+
+// submitInterchainQueryResult verifies the proofs of the result against the consensus state of the connection client
+// at the height and passes the values to the owner contract via sudo. A result is accepted once per update period and
+// must be for a greater height than the last result. The contract callback runs with a dedicated gas limit and the gas
+// is charged to the submitter. A failing callback does not reject the result, so that a relayer can not be blocked by
+// the contract, but its state changes are reverted.
+func (k Keeper) submitInterchainQueryResult(ctx sdk.Context, queryID uint64, height clienttypes.Height, results []types.InterchainQueryResult) error {
+	if k.interchainQueryConnections == nil || k.interchainQueryClients == nil {
+		return sdkerrors.Wrap(types.ErrInvalid, "interchain queries not enabled")
+	}
+	q := k.GetInterchainQuery(ctx, queryID)
+	if q == nil {
+		return sdkerrors.Wrap(types.ErrNotFound, "interchain query")
+	}
+	if q.LastSubmittedHeight != 0 && ctx.BlockHeight() < q.LastSubmittedHeight+int64(q.UpdatePeriod) {
+		return sdkerrors.Wrapf(types.ErrInvalid, "next result not before height %d", q.LastSubmittedHeight+int64(q.UpdatePeriod))
+	}
+	lastHeight := clienttypes.NewHeight(q.LastRemoteRevisionNumber, q.LastRemoteRevisionHeight)
+	if !height.GT(lastHeight) {
+		return sdkerrors.Wrapf(types.ErrInvalid, "height must be greater than %s", lastHeight)
+	}
+	if len(results) != len(q.Keys) {
+		return sdkerrors.Wrapf(types.ErrInvalid, "expected %d results but got %d", len(q.Keys), len(results))
+	}
+	connection, ok := k.interchainQueryConnections.GetConnection(ctx, q.ConnectionID)
+	if !ok {
+		return sdkerrors.Wrapf(types.ErrNotFound, "connection: %s", q.ConnectionID)
+	}
+	proofHeight := ProofHeight{RevisionNumber: height.RevisionNumber, RevisionHeight: height.RevisionHeight}
+	values := make([]InterchainQueryValue, len(results))
+	for i, r := range results {
+		key := q.Keys[i]
+		proof, root, path, err := proofVerificationArgs(ctx, k.interchainQueryClients, VerifyNonMembershipQuery{
+			ClientID: connection.ClientId,
+			Height:   proofHeight,
+			Proof:    r.Proof,
+			KeyPath:  [][]byte{[]byte(key.Path), key.Key},
+		})
+		if err != nil {
+			return sdkerrors.Wrapf(err, "result %d", i)
+		}
+		if len(r.Value) == 0 {
+			err = proof.VerifyNonMembership(commitmenttypes.GetSDKSpecs(), root, path)
+		} else {
+			err = proof.VerifyMembership(commitmenttypes.GetSDKSpecs(), root, path, r.Value)
+		}
+		if err != nil {
+			return sdkerrors.Wrapf(types.ErrInvalid, "proof of result %d: %s", i, err)
+		}
+		values[i] = InterchainQueryValue{Path: key.Path, Key: key.Key, Value: r.Value, Proof: r.Proof}
+	}
+
+	q.LastSubmittedHeight = ctx.BlockHeight()
+	q.LastRemoteRevisionNumber = height.RevisionNumber
+	q.LastRemoteRevisionHeight = height.RevisionHeight
+	k.setInterchainQuery(ctx, *q)
+
+	ownerAddr, err := sdk.AccAddressFromBech32(q.Owner)
+	if err != nil {
+		return sdkerrors.Wrap(err, "owner")
+	}
+	msg := InterchainQueryResultSudoMsg{InterchainQueryResult: &InterchainQueryResultData{
+		QueryID:      q.ID,
+		ConnectionID: q.ConnectionID,
+		Height:       proofHeight,
+		Values:       values,
+	}}
+	gasUsed, sudoErr := k.sudoWithGasLimit(ctx, ownerAddr, msg, DefaultInterchainQuerySudoGasLimit, "interchain query result")
+	ctx.GasMeter().ConsumeGas(gasUsed, "interchain query result callback")
+	if sudoErr != nil {
+		k.Logger(ctx).Error("interchain query result callback", "contract", q.Owner, "query_id", q.ID, "error", sudoErr.Error())
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeInterchainQueryResult,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(types.AttributeKeyQueryID, strconv.FormatUint(q.ID, 10)),
+		sdk.NewAttribute(types.AttributeKeyContractAddr, q.Owner),
+		sdk.NewAttribute(types.AttributeKeyRemoteHeight, height.String()),
+		sdk.NewAttribute(types.AttributeKeySuccess, strconv.FormatBool(sudoErr == nil)),
+	))
+	return nil
+}
+
+func (k Keeper) setInterchainQuery(ctx sdk.Context, q types.InterchainQuery) {
+	ownerAddr, err := sdk.AccAddressFromBech32(q.Owner)
+	if err != nil { // should never happen as the owner is a stored contract
+		panic(err.Error())
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetInterchainQueryKey(q.ID), k.cdc.MustMarshalBinaryBare(&q))
+	store.Set(types.GetInterchainQueryByOwnerKey(ownerAddr, q.ID), []byte{})
+}
+
+// importInterchainQuery stores an interchain query from genesis. The owner contract must exist.
+func (k Keeper) importInterchainQuery(ctx sdk.Context, q types.InterchainQuery) error {
+	if ctx.KVStore(k.storeKey).Has(types.GetInterchainQueryKey(q.ID)) {
+		return sdkerrors.Wrapf(types.ErrDuplicate, "interchain query id %d", q.ID)
+	}
+	ownerAddr, err := sdk.AccAddressFromBech32(q.Owner)
+	if err != nil {
+		return sdkerrors.Wrap(err, "owner")
+	}
+	if !k.HasContractInfo(ctx, ownerAddr) {
+		return sdkerrors.Wrapf(types.ErrNotFound, "contract: %s", q.Owner)
+	}
+	k.setInterchainQuery(ctx, q)
+	return nil
+}
+
+// GetInterchainQuery returns the interchain query or nil when not found
+func (k Keeper) GetInterchainQuery(ctx sdk.Context, queryID uint64) *types.InterchainQuery {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetInterchainQueryKey(queryID))
+	if bz == nil {
+		return nil
+	}
+	var q types.InterchainQuery
+	k.cdc.MustUnmarshalBinaryBare(bz, &q)
+	return &q
+}
+
+// IterateInterchainQueries iterates the interchain queries ordered by id.
+// When the callback returns true the iteration is stopped.
+func (k Keeper) IterateInterchainQueries(ctx sdk.Context, cb func(types.InterchainQuery) bool) {
+	iter := prefix.NewStore(ctx.KVStore(k.storeKey), types.InterchainQueryPrefix).Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var q types.InterchainQuery
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &q)
+		if cb(q) {
+			return
+		}
+	}
+}
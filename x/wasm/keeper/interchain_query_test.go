@@ -0,0 +1,357 @@
+package keeper
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	wasmvm "github.com/CosmWasm/wasmvm"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	"github.com/cosmos/cosmos-sdk/store/rootmulti"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	clienttypes "github.com/cosmos/cosmos-sdk/x/ibc/core/02-client/types"
+	connectionkeeper "github.com/cosmos/cosmos-sdk/x/ibc/core/03-connection/keeper"
+	connectiontypes "github.com/cosmos/cosmos-sdk/x/ibc/core/03-connection/types"
+	commitmenttypes "github.com/cosmos/cosmos-sdk/x/ibc/core/23-commitment/types"
+	ibcexported "github.com/cosmos/cosmos-sdk/x/ibc/core/exported"
+	ibctmtypes "github.com/cosmos/cosmos-sdk/x/ibc/light-clients/07-tendermint/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tm-db"
+)
+
+var _ ConnectionSource = connectionkeeper.Keeper{}
+
+func TestRegisterInterchainQuery(t *testing.T) {
+	myKeys := []types.InterchainQueryKey{{Path: "bank", Key: []byte("myKey")}}
+	specs := map[string]struct {
+		srcOwner        func(contractAddr sdk.AccAddress) sdk.AccAddress
+		srcConnectionID string
+		srcKeys         []types.InterchainQueryKey
+		srcPeriod       uint64
+		disabled        bool
+		expErr          *sdkerrors.Error
+	}{
+		"all good": {
+			srcConnectionID: "connection-0",
+			srcKeys:         myKeys,
+			srcPeriod:       10,
+		},
+		"unknown contract": {
+			srcOwner: func(sdk.AccAddress) sdk.AccAddress {
+				return RandomAccountAddress(t)
+			},
+			srcConnectionID: "connection-0",
+			srcKeys:         myKeys,
+			srcPeriod:       10,
+			expErr:          types.ErrNotFound,
+		},
+		"unknown connection": {
+			srcConnectionID: "connection-1",
+			srcKeys:         myKeys,
+			srcPeriod:       10,
+			expErr:          types.ErrNotFound,
+		},
+		"empty keys": {
+			srcConnectionID: "connection-0",
+			srcPeriod:       10,
+			expErr:          types.ErrEmpty,
+		},
+		"duplicate keys": {
+			srcConnectionID: "connection-0",
+			srcKeys:         append(myKeys, myKeys...),
+			srcPeriod:       10,
+			expErr:          types.ErrDuplicate,
+		},
+		"zero update period": {
+			srcConnectionID: "connection-0",
+			srcKeys:         myKeys,
+			expErr:          types.ErrEmpty,
+		},
+		"disabled": {
+			srcConnectionID: "connection-0",
+			srcKeys:         myKeys,
+			srcPeriod:       10,
+			disabled:        true,
+			expErr:          types.ErrInvalid,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var opts []Option
+			if !spec.disabled {
+				connections := mockConnectionSource{"connection-0": {ClientId: "my-client"}}
+				opts = append(opts, WithInterchainQueries(mockClientConsensusStateSource{}, connections))
+			}
+			ctx, keepers := CreateTestInput(t, false, SupportedFeatures, opts...)
+			mock := wasmtesting.MockWasmer{}
+			wasmtesting.MakeInstantiable(&mock)
+			example := SeedNewContractInstance(t, ctx, keepers, &mock)
+			owner := example.Contract
+			if spec.srcOwner != nil {
+				owner = spec.srcOwner(owner)
+			}
+
+			// when
+			gotID, gotErr := keepers.ContractKeeper.RegisterInterchainQuery(ctx, owner, spec.srcConnectionID, spec.srcKeys, spec.srcPeriod)
+
+			// then
+			if spec.expErr != nil {
+				assert.True(t, spec.expErr.Is(gotErr), "got %+v", gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, uint64(1), gotID)
+			exp := &types.InterchainQuery{
+				ID:           1,
+				Owner:        owner.String(),
+				ConnectionID: spec.srcConnectionID,
+				Keys:         spec.srcKeys,
+				UpdatePeriod: spec.srcPeriod,
+			}
+			assert.Equal(t, exp, keepers.WasmKeeper.GetInterchainQuery(ctx, gotID))
+		})
+	}
+}
+
+func TestRemoveInterchainQuery(t *testing.T) {
+	connections := mockConnectionSource{"connection-0": {ClientId: "my-client"}}
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithInterchainQueries(mockClientConsensusStateSource{}, connections))
+	k := keepers.WasmKeeper
+	mock := wasmtesting.MockWasmer{}
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	otherContract, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, example.CreatorAddr, nil, []byte(`{}`), "other", nil)
+	require.NoError(t, err)
+	queryID, err := keepers.ContractKeeper.RegisterInterchainQuery(ctx, example.Contract, "connection-0", []types.InterchainQueryKey{{Path: "bank", Key: []byte("myKey")}}, 10)
+	require.NoError(t, err)
+
+	// when removed by another contract
+	err = keepers.ContractKeeper.RemoveInterchainQuery(ctx, otherContract, queryID)
+	// then
+	assert.True(t, sdkerrors.ErrUnauthorized.Is(err), "got %+v", err)
+	require.NotNil(t, k.GetInterchainQuery(ctx, queryID))
+
+	// when removed by the owner
+	require.NoError(t, keepers.ContractKeeper.RemoveInterchainQuery(ctx, example.Contract, queryID))
+	// then
+	assert.Nil(t, k.GetInterchainQuery(ctx, queryID))
+	assert.False(t, ctx.KVStore(k.storeKey).Has(types.GetInterchainQueryByOwnerKey(example.Contract, queryID)))
+
+	// and can not be removed again
+	err = keepers.ContractKeeper.RemoveInterchainQuery(ctx, example.Contract, queryID)
+	assert.True(t, types.ErrNotFound.Is(err), "got %+v", err)
+}
+
+func TestSubmitInterchainQueryResult(t *testing.T) {
+	// counterparty chain state
+	ms := rootmulti.NewStore(dbm.NewMemDB())
+	storeKey := sdk.NewKVStoreKey("bank")
+	ms.MountStoreWithDB(storeKey, storetypes.StoreTypeIAVL, nil)
+	require.NoError(t, ms.LoadLatestVersion())
+	ms.GetKVStore(storeKey).Set([]byte("foo"), []byte("bar"))
+	commitID := ms.Commit()
+	prove := func(key string) []byte {
+		res := ms.Query(abci.RequestQuery{Path: "/bank/key", Data: []byte(key), Prove: true})
+		require.Equal(t, uint32(0), res.Code, res.Log)
+		proof, err := commitmenttypes.ConvertProofs(res.ProofOps)
+		require.NoError(t, err)
+		bz, err := proof.Marshal()
+		require.NoError(t, err)
+		return bz
+	}
+	existingProof, absentProof := prove("foo"), prove("other")
+	clients := mockClientConsensusStateSource{
+		clients: map[string]ibcexported.ClientState{"my-client": &ibctmtypes.ClientState{}},
+		consensusStates: map[string]ibcexported.ConsensusState{
+			"my-client": ibctmtypes.NewConsensusState(time.Now(), commitmenttypes.NewMerkleRoot(commitID.Hash), nil),
+		},
+	}
+	connections := mockConnectionSource{"connection-0": {ClientId: "my-client"}}
+	validResults := []types.InterchainQueryResult{{Value: []byte("bar"), Proof: existingProof}, {Proof: absentProof}}
+
+	specs := map[string]struct {
+		srcHeight       clienttypes.Height
+		srcResults      []types.InterchainQueryResult
+		srcLastHeight   int64
+		callbackErr     error
+		expErr          *sdkerrors.Error
+		expCallbackFail bool
+	}{
+		"all good": {
+			srcHeight:  clienttypes.NewHeight(0, 1),
+			srcResults: validResults,
+		},
+		"callback fails": {
+			srcHeight:       clienttypes.NewHeight(0, 1),
+			srcResults:      validResults,
+			callbackErr:     errors.New("testing"),
+			expCallbackFail: true,
+		},
+		"other value": {
+			srcHeight:  clienttypes.NewHeight(0, 1),
+			srcResults: []types.InterchainQueryResult{{Value: []byte("baz"), Proof: existingProof}, {Proof: absentProof}},
+			expErr:     types.ErrInvalid,
+		},
+		"absent value with existence proof": {
+			srcHeight:  clienttypes.NewHeight(0, 1),
+			srcResults: []types.InterchainQueryResult{{Proof: existingProof}, {Proof: absentProof}},
+			expErr:     types.ErrInvalid,
+		},
+		"missing result": {
+			srcHeight:  clienttypes.NewHeight(0, 1),
+			srcResults: validResults[:1],
+			expErr:     types.ErrInvalid,
+		},
+		"unknown consensus state": {
+			srcHeight:  clienttypes.NewHeight(0, 2),
+			srcResults: validResults,
+			expErr:     types.ErrNotFound,
+		},
+		"zero height": {
+			srcResults: validResults,
+			expErr:     types.ErrInvalid,
+		},
+		"before update period": {
+			srcHeight:     clienttypes.NewHeight(0, 1),
+			srcResults:    validResults,
+			srcLastHeight: 95,
+			expErr:        types.ErrInvalid,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithInterchainQueries(clients, connections))
+			ctx = ctx.WithBlockHeight(100)
+			k := keepers.WasmKeeper
+			mock := wasmtesting.MockWasmer{}
+			wasmtesting.MakeInstantiable(&mock)
+			example := SeedNewContractInstance(t, ctx, keepers, &mock)
+			queryKeys := []types.InterchainQueryKey{{Path: "bank", Key: []byte("foo")}, {Path: "bank", Key: []byte("other")}}
+			queryID, err := keepers.ContractKeeper.RegisterInterchainQuery(ctx, example.Contract, "connection-0", queryKeys, 10)
+			require.NoError(t, err)
+			if spec.srcLastHeight != 0 {
+				q := k.GetInterchainQuery(ctx, queryID)
+				q.LastSubmittedHeight = spec.srcLastHeight
+				k.setInterchainQuery(ctx, *q)
+			}
+
+			var captured *InterchainQueryResultSudoMsg
+			mock.SudoFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, sudoMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.Response, uint64, error) {
+				require.NoError(t, json.Unmarshal(sudoMsg, &captured))
+				store.Set([]byte("price"), []byte("bar"))
+				return &wasmvmtypes.Response{}, 0, spec.callbackErr
+			}
+			em := sdk.NewEventManager()
+
+			// when
+			gotErr := keepers.ContractKeeper.SubmitInterchainQueryResult(ctx.WithEventManager(em), queryID, spec.srcHeight, spec.srcResults)
+
+			// then
+			if spec.expErr != nil {
+				assert.True(t, spec.expErr.Is(gotErr), "got %+v", gotErr)
+				assert.Nil(t, captured)
+				return
+			}
+			require.NoError(t, gotErr)
+			exp := &InterchainQueryResultSudoMsg{InterchainQueryResult: &InterchainQueryResultData{
+				QueryID:      queryID,
+				ConnectionID: "connection-0",
+				Height:       ProofHeight{RevisionHeight: 1},
+				Values: []InterchainQueryValue{
+					{Path: "bank", Key: []byte("foo"), Value: []byte("bar"), Proof: existingProof},
+					{Path: "bank", Key: []byte("other"), Proof: absentProof},
+				},
+			}}
+			assert.Equal(t, exp, captured)
+			gotQuery := k.GetInterchainQuery(ctx, queryID)
+			assert.Equal(t, int64(100), gotQuery.LastSubmittedHeight)
+			assert.Equal(t, uint64(1), gotQuery.LastRemoteRevisionHeight)
+			// state changes of a failed callback are reverted
+			if spec.expCallbackFail {
+				assert.Nil(t, k.QueryRaw(ctx, example.Contract, []byte("price")))
+			} else {
+				assert.Equal(t, []byte("bar"), k.QueryRaw(ctx, example.Contract, []byte("price")))
+			}
+			expEvt := sdk.NewEvent(types.EventTypeInterchainQueryResult,
+				sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+				sdk.NewAttribute(types.AttributeKeyQueryID, "1"),
+				sdk.NewAttribute(types.AttributeKeyContractAddr, example.Contract.String()),
+				sdk.NewAttribute(types.AttributeKeyRemoteHeight, "0-1"),
+				sdk.NewAttribute(types.AttributeKeySuccess, map[bool]string{true: "false", false: "true"}[spec.expCallbackFail]),
+			)
+			assert.Contains(t, em.Events(), expEvt)
+
+			// and the same height is not accepted again
+			err = keepers.ContractKeeper.SubmitInterchainQueryResult(ctx.WithBlockHeight(200), queryID, spec.srcHeight, spec.srcResults)
+			assert.True(t, types.ErrInvalid.Is(err), "got %+v", err)
+		})
+	}
+}
+
+func TestQueryInterchainQueries(t *testing.T) {
+	connections := mockConnectionSource{"connection-0": {ClientId: "my-client"}}
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithInterchainQueries(mockClientConsensusStateSource{}, connections))
+	mock := wasmtesting.MockWasmer{}
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	otherContract, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, example.CreatorAddr, nil, []byte(`{}`), "other", nil)
+	require.NoError(t, err)
+	myKeys := []types.InterchainQueryKey{{Path: "bank", Key: []byte("myKey")}}
+	for _, owner := range []sdk.AccAddress{example.Contract, otherContract, example.Contract} {
+		_, err := keepers.ContractKeeper.RegisterInterchainQuery(ctx, owner, "connection-0", myKeys, 10)
+		require.NoError(t, err)
+	}
+	k := keepers.WasmKeeper
+	q := NewGrpcQuerier(k.cdc, k.storeKey, k, k.QueryGasLimit())
+
+	specs := map[string]struct {
+		srcOwner string
+		expIDs   []uint64
+		expErr   bool
+	}{
+		"all": {
+			expIDs: []uint64{1, 2, 3},
+		},
+		"by owner": {
+			srcOwner: example.Contract.String(),
+			expIDs:   []uint64{1, 3},
+		},
+		"by owner without queries": {
+			srcOwner: RandomBech32AccountAddress(t),
+			expIDs:   []uint64{},
+		},
+		"invalid owner": {
+			srcOwner: "invalid",
+			expErr:   true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, err := q.InterchainQueries(sdk.WrapSDKContext(ctx), &types.QueryInterchainQueriesRequest{Owner: spec.srcOwner})
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			gotIDs := make([]uint64, len(got.Queries))
+			for i, v := range got.Queries {
+				gotIDs[i] = v.ID
+			}
+			assert.Equal(t, spec.expIDs, gotIDs)
+		})
+	}
+}
+
+type mockConnectionSource map[string]connectiontypes.ConnectionEnd
+
+func (m mockConnectionSource) GetConnection(ctx sdk.Context, connectionID string) (connectiontypes.ConnectionEnd, bool) {
+	c, ok := m[connectionID]
+	return c, ok
+}
@@ -0,0 +1,204 @@
+package keeper
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// DefaultJobGasLimit is the gas budget of the end blocker for the steps of all jobs in a block
+const DefaultJobGasLimit uint64 = 50000000
+
+// JobStepGasCost is the minimum gas that every job step consumes from the budget of the block. It bounds the number of
+// steps in a block for jobs without a steps per block limit, also when the handler consumes no gas.
+const JobStepGasCost uint64 = 1000
+
+// JobHandler continues the jobs of a type step by step
+type JobHandler interface {
+	// Step executes the next step of the job with the payload. It returns the new payload and true when the job is
+	// done. The step runs in a cached context with the remaining gas budget of the block. State changes are discarded,
+	// and the job is aborted, when an error is returned.
+	Step(ctx sdk.Context, payload []byte) (newPayload []byte, done bool, err error)
+}
+
+// JobHandlerFn is a function that implements the JobHandler interface
+type JobHandlerFn func(ctx sdk.Context, payload []byte) ([]byte, bool, error)
+
+// Step calls the function
+func (f JobHandlerFn) Step(ctx sdk.Context, payload []byte) ([]byte, bool, error) {
+	return f(ctx, payload)
+}
+
+// builtinJobHandler returns the handler of the wasm job types
+func (k Keeper) builtinJobHandler(jobType string) (JobHandler, bool) {
+	switch jobType {
+	case BulkMigrationJobType:
+		return bulkMigrationJobHandler{keeper: k}, true
+	case CodeStatsPruningJobType:
+		return codeStatsPruningJobHandler{keeper: k}, true
+	default:
+		return nil, false
+	}
+}
+
+func (k Keeper) getJobHandler(jobType string) (JobHandler, bool) {
+	if h, ok := k.builtinJobHandler(jobType); ok {
+		return h, true
+	}
+	h, ok := k.jobHandlers[jobType]
+	return h, ok
+}
+
+// QueueJob persists a new job that the end blocker continues step by step until it is done. With stepsPerBlock the
+// steps of the job in a block are limited in addition to the gas budget. The type must have a handler.
+func (k Keeper) QueueJob(ctx sdk.Context, jobType string, payload []byte, stepsPerBlock uint32) (uint64, error) {
+	if _, ok := k.getJobHandler(jobType); !ok {
+		return 0, sdkerrors.Wrapf(types.ErrNotFound, "handler for job type: %s", jobType)
+	}
+	job := types.Job{
+		ID:            k.autoIncrementID(ctx, types.KeyLastJobID),
+		Type:          jobType,
+		Payload:       payload,
+		StepsPerBlock: stepsPerBlock,
+		CreatedHeight: ctx.BlockHeight(),
+	}
+	k.setJob(ctx, job)
+	return job.ID, nil
+}
+
+// ProcessJobs continues the pending jobs in the order of their ids within the gas budget of the block. It is called in
+// the end blocker. A job that does not get through its steps in a block is continued in the next block. A step that
+// fails, or does not fit into the full gas budget, aborts the job.
+func (k Keeper) ProcessJobs(ctx sdk.Context) {
+	var jobs []types.Job
+	k.IterateJobs(ctx, func(j types.Job) bool {
+		jobs = append(jobs, j)
+		return false
+	})
+	budget := sdk.NewGasMeter(k.jobGasLimit)
+	for _, j := range jobs {
+		if !k.processJob(ctx, j, budget) {
+			return
+		}
+	}
+}
+
+// processJob runs the steps of the job for this block. Returns false when the gas budget is used up.
+func (k Keeper) processJob(ctx sdk.Context, job types.Job, budget sdk.GasMeter) bool {
+	h, ok := k.getJobHandler(job.Type)
+	if !ok {
+		k.completeJob(ctx, job, sdkerrors.Wrapf(types.ErrNotFound, "handler for job type: %s", job.Type))
+		return true
+	}
+	for steps := uint32(0); job.StepsPerBlock == 0 || steps < job.StepsPerBlock; steps++ {
+		remaining := budget.Limit() - budget.GasConsumedToLimit()
+		if remaining == 0 {
+			k.setJob(ctx, job)
+			return false
+		}
+		payload, done, gasUsed, err := k.runJobStep(ctx, h, job.Payload, remaining)
+		budget.ConsumeGas(gasUsed, "job step")
+		switch {
+		case sdkerrors.ErrOutOfGas.Is(err) && remaining != budget.Limit():
+			// try again with the full budget in the next block
+			k.setJob(ctx, job)
+			return false
+		case err != nil:
+			k.completeJob(ctx, job, err)
+			return budget.GasConsumedToLimit() < budget.Limit()
+		case done:
+			k.completeJob(ctx, job, nil)
+			return true
+		}
+		job.Payload = payload
+	}
+	k.setJob(ctx, job)
+	return true
+}
+
+// runJobStep executes the step with the gas limit. State changes and events are only committed on success. Panics are
+// recovered and returned as error.
+func (k Keeper) runJobStep(ctx sdk.Context, h JobHandler, payload []byte, gasLimit uint64) (newPayload []byte, done bool, gasUsed uint64, err error) {
+	cacheCtx, commit := ctx.CacheContext()
+	cacheCtx = cacheCtx.WithGasMeter(sdk.NewGasMeter(gasLimit))
+	defer func() {
+		gasUsed = cacheCtx.GasMeter().GasConsumedToLimit()
+		if r := recover(); r != nil {
+			if _, ok := r.(sdk.ErrorOutOfGas); ok {
+				err = sdkerrors.Wrap(sdkerrors.ErrOutOfGas, "job step")
+				return
+			}
+			// any other panic aborts the job as it would halt the chain in every block otherwise
+			k.Logger(ctx).Error("recovered panic", "descriptor", "job step", "panic", fmt.Sprintf("%v", r))
+			err = sdkerrors.Wrapf(sdkerrors.ErrPanic, "job step: %v", r)
+		}
+	}()
+	cacheCtx.GasMeter().ConsumeGas(JobStepGasCost, "job step")
+	newPayload, done, err = h.Step(cacheCtx, payload)
+	if err != nil {
+		return nil, false, 0, err
+	}
+	commit()
+	ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+	return newPayload, done, 0, nil
+}
+
+// completeJob removes the job. A job that failed is logged.
+func (k Keeper) completeJob(ctx sdk.Context, job types.Job, err error) {
+	ctx.KVStore(k.storeKey).Delete(types.GetJobKey(job.ID))
+	if err != nil {
+		k.Logger(ctx).Error("job aborted", "job_id", job.ID, "job_type", job.Type, "error", err.Error())
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeJobCompleted,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(types.AttributeKeyJobID, strconv.FormatUint(job.ID, 10)),
+		sdk.NewAttribute(types.AttributeKeyJobType, job.Type),
+		sdk.NewAttribute(types.AttributeKeySuccess, strconv.FormatBool(err == nil)),
+	))
+}
+
+func (k Keeper) setJob(ctx sdk.Context, job types.Job) {
+	ctx.KVStore(k.storeKey).Set(types.GetJobKey(job.ID), k.cdc.MustMarshalBinaryBare(&job))
+}
+
+// importJob stores a pending job from genesis. The type must have a handler.
+func (k Keeper) importJob(ctx sdk.Context, job types.Job) error {
+	if ctx.KVStore(k.storeKey).Has(types.GetJobKey(job.ID)) {
+		return sdkerrors.Wrapf(types.ErrDuplicate, "job id %d", job.ID)
+	}
+	if _, ok := k.getJobHandler(job.Type); !ok {
+		return sdkerrors.Wrapf(types.ErrNotFound, "handler for job type: %s", job.Type)
+	}
+	k.setJob(ctx, job)
+	return nil
+}
+
+// GetJob returns the pending job or nil when not found
+func (k Keeper) GetJob(ctx sdk.Context, jobID uint64) *types.Job {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetJobKey(jobID))
+	if bz == nil {
+		return nil
+	}
+	var job types.Job
+	k.cdc.MustUnmarshalBinaryBare(bz, &job)
+	return &job
+}
+
+// IterateJobs iterates the pending jobs ordered by id.
+// When the callback returns true the iteration is stopped.
+func (k Keeper) IterateJobs(ctx sdk.Context, cb func(types.Job) bool) {
+	iter := prefix.NewStore(ctx.KVStore(k.storeKey), types.JobPrefix).Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var job types.Job
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &job)
+		if cb(job) {
+			return
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package keeper
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessJobs(t *testing.T) {
+	const handlerGas = 1000
+	const stepGas = handlerGas + JobStepGasCost
+	// counterJob counts the steps in the payload and is done after 5 steps
+	counterJob := func(failAt uint64) JobHandlerFn {
+		return func(ctx sdk.Context, payload []byte) ([]byte, bool, error) {
+			counter := sdk.BigEndianToUint64(payload) + 1
+			ctx.GasMeter().ConsumeGas(handlerGas, "testing")
+			if counter == failAt {
+				return nil, false, errors.New("testing")
+			}
+			return sdk.Uint64ToBigEndian(counter), counter == 5, nil
+		}
+	}
+	specs := map[string]struct {
+		handler       JobHandler
+		stepsPerBlock uint32
+		gasLimit      uint64
+		// expCounters are the counters of the pending job after each block
+		expCounters []uint64
+		expSuccess  bool
+	}{
+		"all steps in one block": {
+			handler:    counterJob(0),
+			gasLimit:   DefaultJobGasLimit,
+			expSuccess: true,
+		},
+		"steps per block": {
+			handler:       counterJob(0),
+			stepsPerBlock: 2,
+			gasLimit:      DefaultJobGasLimit,
+			expCounters:   []uint64{2, 4},
+			expSuccess:    true,
+		},
+		"gas budget per block": {
+			handler:     counterJob(0),
+			gasLimit:    2*stepGas + stepGas/2,
+			expCounters: []uint64{2, 4},
+			expSuccess:  true,
+		},
+		"step fails": {
+			handler:  counterJob(3),
+			gasLimit: DefaultJobGasLimit,
+		},
+		"step exceeds gas budget": {
+			handler:  counterJob(0),
+			gasLimit: stepGas - 1,
+		},
+		"step panics": {
+			handler: JobHandlerFn(func(ctx sdk.Context, payload []byte) ([]byte, bool, error) {
+				panic("testing")
+			}),
+			gasLimit: DefaultJobGasLimit,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithJobHandler("counter", spec.handler), WithJobGasLimit(spec.gasLimit))
+			k := keepers.WasmKeeper
+			jobID, err := k.QueueJob(ctx, "counter", sdk.Uint64ToBigEndian(0), spec.stepsPerBlock)
+			require.NoError(t, err)
+
+			// when processed block by block
+			var gotCounters []uint64
+			em := sdk.NewEventManager()
+			for i := 0; i < 10; i++ {
+				ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+				k.ProcessJobs(ctx.WithEventManager(em))
+				job := k.GetJob(ctx, jobID)
+				if job == nil {
+					break
+				}
+				gotCounters = append(gotCounters, sdk.BigEndianToUint64(job.Payload))
+			}
+
+			// then
+			assert.Equal(t, spec.expCounters, gotCounters)
+			expEvt := sdk.NewEvent(types.EventTypeJobCompleted,
+				sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+				sdk.NewAttribute(types.AttributeKeyJobID, strconv.FormatUint(jobID, 10)),
+				sdk.NewAttribute(types.AttributeKeyJobType, "counter"),
+				sdk.NewAttribute(types.AttributeKeySuccess, strconv.FormatBool(spec.expSuccess)),
+			)
+			assert.Contains(t, em.Events(), expEvt)
+		})
+	}
+}
+
+func TestProcessJobsBoundsStepsWithoutGas(t *testing.T) {
+	// a job that never finishes and consumes no gas
+	var steps uint64
+	handler := JobHandlerFn(func(ctx sdk.Context, payload []byte) ([]byte, bool, error) {
+		steps++
+		return nil, false, nil
+	})
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithJobHandler("endless", handler), WithJobGasLimit(10*JobStepGasCost))
+	k := keepers.WasmKeeper
+	jobID, err := k.QueueJob(ctx, "endless", nil, 0)
+	require.NoError(t, err)
+
+	// when
+	k.ProcessJobs(ctx)
+
+	// then
+	assert.Equal(t, uint64(10), steps)
+	assert.NotNil(t, k.GetJob(ctx, jobID))
+}
+
+func TestProcessJobsInOrder(t *testing.T) {
+	var gotJobs []string
+	handler := func(name string) JobHandlerFn {
+		return func(ctx sdk.Context, payload []byte) ([]byte, bool, error) {
+			gotJobs = append(gotJobs, name)
+			return nil, true, nil
+		}
+	}
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithJobHandler("first", handler("first")), WithJobHandler("second", handler("second")))
+	k := keepers.WasmKeeper
+	_, err := k.QueueJob(ctx, "second", nil, 0)
+	require.NoError(t, err)
+	_, err = k.QueueJob(ctx, "first", nil, 0)
+	require.NoError(t, err)
+
+	// when
+	k.ProcessJobs(ctx)
+
+	// then
+	assert.Equal(t, []string{"second", "first"}, gotJobs)
+	k.IterateJobs(ctx, func(job types.Job) bool {
+		t.Fatalf("unexpected job: %d", job.ID)
+		return false
+	})
+}
+
+func TestQueueJobUnknownType(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	_, err := keepers.WasmKeeper.QueueJob(ctx, "unknown", nil, 0)
+	assert.True(t, types.ErrNotFound.Is(err), "got %+v", err)
+}
+
+func TestWithJobHandlerReservedType(t *testing.T) {
+	assert.Panics(t, func() {
+		CreateTestInput(t, false, SupportedFeatures, WithJobHandler(BulkMigrationJobType, JobHandlerFn(func(ctx sdk.Context, payload []byte) ([]byte, bool, error) {
+			return nil, true, nil
+		})))
+	})
+}
@@ -3,6 +3,7 @@ package keeper
 import (
 	"bytes"
 	"encoding/binary"
+	"encoding/hex"
 	"fmt"
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 	wasmvm "github.com/CosmWasm/wasmvm"
@@ -12,11 +13,15 @@ import (
 	"github.com/cosmos/cosmos-sdk/telemetry"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
 	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/tmhash"
 	"github.com/tendermint/tendermint/libs/log"
 	"math"
 	"path/filepath"
+	"strconv"
 	"time"
 )
 
@@ -59,16 +64,71 @@ type Keeper struct {
 	cdc                   codec.Marshaler
 	accountKeeper         types.AccountKeeper
 	bank                  CoinTransferrer
+	bankViewKeeper        types.BankViewKeeper
+	bankKeeper            types.BankKeeper
+	distKeeper            types.DistributionKeeper
 	portKeeper            types.PortKeeper
+	channelKeeper         types.ChannelKeeper
 	capabilityKeeper      types.CapabilityKeeper
 	wasmVM                types.WasmerEngine
 	wasmVMQueryHandler    WasmVMQueryHandler
 	wasmVMResponseHandler WasmVMResponseHandler
 	messenger             Messenger
 	// queryGasLimit is the max wasmvm gas that can be spent on executing a query with a contract
-	queryGasLimit uint64
+	queryGasLimit *runtimeGasLimit
 	paramSpace    paramtypes.Subspace
 	gasRegister   GasRegister
+	// executeAllowlist keeps the decoded execute allowlist param
+	executeAllowlist *executeAllowlistCache
+	// storeAuditLog is optional and records the contract store operations for debugging
+	storeAuditLog *StoreAuditLog
+	// queryLimiter is optional and bounds the number of concurrent smart queries on the gRPC query server
+	queryLimiter *QueryLimiter
+	// cacheStatusQuery enables the operator query for the wasm VM cache status
+	cacheStatusQuery bool
+	// supportedFeatures are the capabilities that this chain enables for contracts
+	supportedFeatures map[string]struct{}
+	// legacyQuerierProtoJSON makes the legacy querier return proto JSON
+	legacyQuerierProtoJSON bool
+	// subQueryGasPercent is the share of the calling contract's remaining gas that a sub-query can consume
+	subQueryGasPercent uint64
+	// channelClients and contractChannels are optional and enable the client frozen notifications of the contracts
+	channelClients   ChannelClientStateSource
+	contractChannels ContractChannelSource
+	// portChannels is optional and limits the channel lookups of a contract to the channels of its port
+	portChannels PortChannelSource
+	// codeStatsEpochLength is the number of blocks of a code execution statistics epoch. 0 disables the statistics.
+	codeStatsEpochLength uint64
+	// codeStatsKeepEpochs is the number of epochs that the statistics are kept for. 0 keeps all.
+	codeStatsKeepEpochs uint64
+	// cosmwasmAPI converts the addresses for the contracts
+	cosmwasmAPI wasmvm.GoAPI
+	// addressGenerator builds the addresses of new contract instances
+	addressGenerator AddressGenerator
+	// proposals is optional and enables the contract funds recovery proposals
+	proposals ProposalSource
+	// recoveryMinVotingPeriod is the min gov voting period for contract funds recovery proposals
+	recoveryMinVotingPeriod time.Duration
+	// executionMiddlewares are called around the contract calls of the wasm VM
+	executionMiddlewares []ExecutionMiddleware
+	// codeStreamQuerier is optional and enables the chunked byte code download on the gRPC query server
+	codeStreamQuerier ABCIQuerier
+	// simulateExecuteGasLimit is the gas cap of the execute simulation query. 0 disables the query.
+	simulateExecuteGasLimit uint64
+	// simulateExecuteLimiter is optional and bounds the number of execute simulations per second
+	simulateExecuteLimiter *RateLimiter
+	// gasHintsCache keeps the gas hints of the contracts for the gRPC query server
+	gasHintsCache *GasHintsCache
+	// interchainQueryClients is optional and verifies the proofs of the interchain query results
+	interchainQueryClients ClientConsensusStateSource
+	// interchainQueryConnections is optional and enables the interchain queries of the contracts
+	interchainQueryConnections ConnectionSource
+	// notifyTimeoutClosedChannels enables the notification of the contracts about ordered channels closed by a timeout
+	notifyTimeoutClosedChannels bool
+	// jobGasLimit is the gas budget of the end blocker for the job steps in a block
+	jobGasLimit uint64
+	// jobHandlers are the app specific handlers of the end blocker jobs by type
+	jobHandlers map[string]JobHandler
 }
 
 // NewKeeper creates a new contract Keeper instance
@@ -92,7 +152,11 @@ func NewKeeper(
 	supportedFeatures string,
 	opts ...Option,
 ) Keeper {
-	wasmer, err := wasmvm.NewVM(filepath.Join(homeDir, "wasm"), supportedFeatures, contractMemoryLimit, wasmConfig.ContractDebugMode, wasmConfig.MemoryCacheSize)
+	wasmDir := filepath.Join(homeDir, "wasm")
+	if err := checkWasmvmVersion(wasmDir, WasmvmVersion(), wasmConfig.UpgradeName, wasmConfig.AcceptVMUpgrade); err != nil {
+		panic(err)
+	}
+	wasmer, err := newWasmVM(wasmDir, supportedFeatures, wasmConfig)
 	if err != nil {
 		panic(err)
 	}
@@ -102,23 +166,51 @@ func NewKeeper(
 	}
 
 	keeper := &Keeper{
-		storeKey:         storeKey,
-		cdc:              cdc,
-		wasmVM:           wasmer,
-		accountKeeper:    accountKeeper,
-		bank:             NewBankCoinTransferrer(bankKeeper),
-		portKeeper:       portKeeper,
-		capabilityKeeper: capabilityKeeper,
-		messenger:        NewDefaultMessageHandler(router, channelKeeper, capabilityKeeper, bankKeeper, cdc, portSource),
-		queryGasLimit:    wasmConfig.SmartQueryGasLimit,
-		paramSpace:       paramSpace,
-		gasRegister:      NewDefaultWasmGasRegister(),
+		storeKey:               storeKey,
+		cdc:                    cdc,
+		wasmVM:                 wasmer,
+		accountKeeper:          accountKeeper,
+		bank:                   NewBankCoinTransferrer(bankKeeper),
+		bankViewKeeper:         bankKeeper,
+		bankKeeper:             bankKeeper,
+		distKeeper:             distKeeper,
+		portKeeper:             portKeeper,
+		channelKeeper:          channelKeeper,
+		capabilityKeeper:       capabilityKeeper,
+		messenger:              NewDefaultMessageHandlerWithBalanceCheck(router, channelKeeper, capabilityKeeper, bankKeeper, cdc, portSource),
+		queryGasLimit:          newRuntimeGasLimit(wasmConfig.SmartQueryGasLimit),
+		paramSpace:             paramSpace,
+		gasRegister:            NewDefaultWasmGasRegister(),
+		executeAllowlist:       newExecuteAllowlistCache(),
+		cacheStatusQuery:       wasmConfig.CacheStatusQuery,
+		legacyQuerierProtoJSON: wasmConfig.LegacyQuerierProtoJSON,
+		subQueryGasPercent:     DefaultSubQueryGasPercent,
+		supportedFeatures:      make(map[string]struct{}),
+		cosmwasmAPI:            cosmwasmAPI,
+		addressGenerator:       ClassicAddressGenerator(),
+		jobGasLimit:            DefaultJobGasLimit,
+		jobHandlers:            make(map[string]JobHandler),
+	}
+	for _, f := range parseFeatures(supportedFeatures) {
+		keeper.supportedFeatures[f] = struct{}{}
 	}
 
 	keeper.wasmVMQueryHandler = DefaultQueryPlugins(bankKeeper, stakingKeeper, distKeeper, channelKeeper, queryRouter, keeper)
 	for _, o := range opts {
 		o.apply(keeper)
 	}
+	if wasmConfig.StoreAuditLogSize != 0 {
+		keeper.storeAuditLog = NewStoreAuditLog(int(wasmConfig.StoreAuditLogSize))
+		keeper.wasmVM = newStoreAuditEngine(keeper.wasmVM, keeper.storeAuditLog)
+	}
+	keeper.simulateExecuteGasLimit = wasmConfig.SimulateExecuteGasLimit
+	keeper.gasHintsCache = NewGasHintsCache(DefaultGasHintsCacheBlocks, DefaultGasHintsCacheSize)
+	if wasmConfig.SimulateExecuteRate != 0 {
+		keeper.simulateExecuteLimiter = NewRateLimiter(wasmConfig.SimulateExecuteRate)
+	}
+	if wasmConfig.QueryConcurrency != 0 {
+		keeper.queryLimiter = NewQueryLimiter(wasmConfig.QueryConcurrency, wasmConfig.QueryQueueSize)
+	}
 	// not updateable, yet
 	keeper.wasmVMResponseHandler = NewDefaultWasmVMContractResponseHandler(NewMessageDispatcher(keeper.messenger, keeper))
 	return *keeper
@@ -142,6 +234,46 @@ func (k Keeper) GetMaxWasmCodeSize(ctx sdk.Context) uint64 {
 	return a
 }
 
+// GetIBCPacketGasLimit returns the max gas a contract can consume when handling an IBC packet or acknowledgement.
+// Zero means no dedicated limit is applied. The default is returned when the param was not set, yet.
+func (k Keeper) GetIBCPacketGasLimit(ctx sdk.Context) uint64 {
+	a := uint64(types.DefaultIBCPacketGasLimit)
+	k.paramSpace.GetIfExists(ctx, types.ParamStoreKeyIBCPacketGasLimit, &a)
+	return a
+}
+
+// GetMaxContractGas returns the max gas a single contract call including the dispatched messages can consume.
+// Zero means no dedicated limit is applied.
+func (k Keeper) GetMaxContractGas(ctx sdk.Context) uint64 {
+	var a uint64
+	k.paramSpace.GetIfExists(ctx, types.ParamStoreKeyMaxContractGas, &a)
+	return a
+}
+
+// GetGasCosts returns the gas cost table of the gas register. The second return value is false when the gas register
+// does not implement GasCostsRegister.
+func (k Keeper) GetGasCosts() (types.GasCosts, bool) {
+	r, ok := k.gasRegister.(GasCostsRegister)
+	if !ok {
+		return types.GasCosts{}, false
+	}
+	return r.GasCosts(), true
+}
+
+// GetMaxResultDataSize returns the max number of bytes of the result data or IBC acknowledgement that a contract can
+// return. Zero means no limit is applied. The default is returned when the param was not set, yet.
+func (k Keeper) GetMaxResultDataSize(ctx sdk.Context) uint64 {
+	a := uint64(types.DefaultMaxResultDataSize)
+	k.paramSpace.GetIfExists(ctx, types.ParamStoreKeyMaxResultDataSize, &a)
+	return a
+}
+
+// withMaxContractGas executes the contract call with the max contract gas param applied so that a single call can not
+// consume the whole block gas. Nested calls share the limit of the outermost call.
+func (k Keeper) withMaxContractGas(ctx sdk.Context, cb func(ctx sdk.Context) error) error {
+	return withGasLimit(ctx, k.GetMaxContractGas(ctx), "max contract gas", cb)
+}
+
 // GetParams returns the total set of wasm parameters.
 func (k Keeper) GetParams(ctx sdk.Context) types.Params {
 	var params types.Params
@@ -173,7 +305,15 @@ func (k Keeper) create(ctx sdk.Context, creator sdk.AccAddress, wasmCode []byte,
 		instantiateAccess = &defaultAccessConfig
 	}
 	codeInfo := types.NewCodeInfo(codeHash, creator, source, builder, *instantiateAccess)
+	codeInfo.RequiredFeatures = requiredFeatures(wasmCode)
 	k.storeCodeInfo(ctx, codeID, codeInfo)
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeStoreCode,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(types.AttributeKeyCodeID, strconv.FormatUint(codeID, 10)),
+		sdk.NewAttribute(types.AttributeKeyChecksum, hex.EncodeToString(codeHash)),
+		sdk.NewAttribute(types.AttributeKeyCreator, creator.String()),
+	))
 	return codeID, nil
 }
 
@@ -207,25 +347,46 @@ func (k Keeper) importCode(ctx sdk.Context, codeID uint64, codeInfo types.CodeIn
 }
 
 func (k Keeper) instantiate(ctx sdk.Context, codeID uint64, creator, admin sdk.AccAddress, initMsg []byte, label string, deposit sdk.Coins, authZ AuthorizationPolicy) (sdk.AccAddress, []byte, error) {
+	var contractAddress sdk.AccAddress
+	var data []byte
+	err := k.withMaxContractGas(ctx, func(ctx sdk.Context) (err error) {
+		contractAddress, data, err = k.instantiateContract(ctx, codeID, creator, admin, initMsg, label, deposit, authZ)
+		return err
+	})
+	return contractAddress, data, err
+}
+
+func (k Keeper) instantiateContract(ctx sdk.Context, codeID uint64, creator, admin sdk.AccAddress, initMsg []byte, label string, deposit sdk.Coins, authZ AuthorizationPolicy) (sdk.AccAddress, []byte, error) {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "instantiate")
+	ctx = withCallOrigin(ctx, creator)
 
-	instanceCosts := k.gasRegister.NewContractInstanceCosts(k.IsPinnedCode(ctx, codeID), len(initMsg))
+	pinned := k.IsPinnedCode(ctx, codeID)
+	instanceCosts := k.gasRegister.NewContractInstanceCosts(pinned, len(initMsg))
 	ctx.GasMeter().ConsumeGas(instanceCosts, "Loading CosmWasm module: instantiate")
 
 	// create contract address
 	contractAddress := k.generateContractAddress(ctx, codeID)
 	existingAcct := k.accountKeeper.GetAccount(ctx, contractAddress)
-	if existingAcct != nil {
+	if existingAcct != nil && !isUnclaimedAccount(existingAcct) {
 		return nil, nil, sdkerrors.Wrap(types.ErrAccountExists, existingAcct.GetAddress().String())
 	}
 
 	// deposit initial contract funds
+	// the creator can be a contract that funds the new instance from its own balance. The transfer is reverted
+	// with all other state changes when the instantiation fails. Funding is not restricted to instances that have
+	// the creator as admin.
 	if !deposit.IsZero() {
 		if err := k.bank.TransferCoins(ctx, creator, contractAddress, deposit); err != nil {
 			return nil, nil, err
 		}
-
-	} else {
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeInstantiateFunds,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+			sdk.NewAttribute(types.AttributeKeyCreator, creator.String()),
+			sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, deposit.String()),
+		))
+	} else if existingAcct == nil {
 		// create an empty account (so we don't have issues later)
 		// TODO: can we remove this?
 		contractAccount := k.accountKeeper.NewAccountWithAddress(ctx, contractAddress)
@@ -240,6 +401,9 @@ func (k Keeper) instantiate(ctx sdk.Context, codeID uint64, creator, admin sdk.A
 	}
 	var codeInfo types.CodeInfo
 	k.cdc.MustUnmarshalBinaryBare(bz, &codeInfo)
+	if err := k.assertSupportedFeatures(codeInfo); err != nil {
+		return nil, nil, err
+	}
 
 	if !authZ.CanInstantiateContract(codeInfo.InstantiateConfig, creator) {
 		return nil, nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "can not instantiate")
@@ -251,15 +415,21 @@ func (k Keeper) instantiate(ctx sdk.Context, codeID uint64, creator, admin sdk.A
 
 	// create prefixed data store
 	// 0x03 | BuildContractAddress (sdk.AccAddress)
-	prefixStoreKey := types.GetContractStorePrefix(contractAddress)
-	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), prefixStoreKey)
+	vmCtx, prefixStore, commitWrites := k.bufferedContractStore(ctx, contractAddress)
 
 	// prepare querier
-	querier := k.newQueryHandler(ctx, contractAddress)
+	querier := k.newQueryHandler(vmCtx, contractAddress)
 
 	// instantiate wasm contract
+	call := ContractCall{EntryPoint: "instantiate", Contract: contractAddress, CodeID: codeID, Sender: creator, Msg: initMsg, Funds: deposit}
+	if err := k.beforeContractExecution(ctx, call); err != nil {
+		return nil, nil, err
+	}
 	gas := k.runtimeGasForContract(ctx)
-	res, gasUsed, err := k.wasmVM.Instantiate(codeInfo.CodeHash, env, info, initMsg, prefixStore, cosmwasmAPI, querier, k.gasMeter(ctx), gas)
+	res, gasUsed, err := k.wasmVM.Instantiate(codeInfo.CodeHash, env, info, initMsg, prefixStore, k.cosmwasmAPI, querier, k.gasMeter(ctx), gas)
+	k.logContractExecution(ctx, "instantiate", contractAddress, codeID, gasUsed, err)
+	k.afterContractExecution(ctx, call, gasUsed, err)
+	commitWrites()
 	k.consumeRuntimeGas(ctx, gasUsed)
 	if err != nil {
 		return contractAddress, nil, sdkerrors.Wrap(types.ErrInstantiateFailed, err.Error())
@@ -290,7 +460,7 @@ func (k Keeper) instantiate(ctx sdk.Context, codeID uint64, creator, admin sdk.A
 	k.storeContractInfo(ctx, contractAddress, &contractInfo)
 
 	// dispatch submessages then messages
-	data, err := k.handleContractResponse(ctx, contractAddress, contractInfo.IBCPortID, res.Submessages, res.Messages, res.Attributes, res.Data)
+	data, err := k.handleContractResponse(ctx, contractAddress, contractInfo.IBCPortID, pinned, res.Submessages, res.Messages, res.Attributes, res.Data)
 	if err != nil {
 		return nil, nil, sdkerrors.Wrap(err, "dispatch")
 	}
@@ -299,18 +469,38 @@ func (k Keeper) instantiate(ctx sdk.Context, codeID uint64, creator, admin sdk.A
 }
 
 // Execute executes the contract instance
-func (k Keeper) execute(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, msg []byte, coins sdk.Coins) ([]byte, error) {
+func (k Keeper) execute(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, msg []byte, coins sdk.Coins, authZ AuthorizationPolicy) ([]byte, error) {
+	var data []byte
+	err := k.withMaxContractGas(ctx, func(ctx sdk.Context) (err error) {
+		data, err = k.executeContract(ctx, contractAddress, caller, msg, coins, authZ)
+		return err
+	})
+	return data, err
+}
+
+func (k Keeper) executeContract(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, msg []byte, coins sdk.Coins, authZ AuthorizationPolicy) ([]byte, error) {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "execute")
-	contractInfo, codeInfo, prefixStore, err := k.contractInstance(ctx, contractAddress)
+	ctx = withCallOrigin(ctx, caller)
+	contractInfo, codeInfo, _, err := k.contractInstance(ctx, contractAddress)
 	if err != nil {
 		return nil, err
 	}
+	if err := assertNoReentrancy(ctx, contractAddress, contractInfo); err != nil {
+		return nil, err
+	}
+	if !canExecuteContract(authZ, k.isExecuteAllowlisted(ctx, contractAddress), contractInfo.AdminAddr(), caller) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "contract not on the execute allowlist")
+	}
 
-	executeCosts := k.gasRegister.InstantiateContractCosts(k.IsPinnedCode(ctx, contractInfo.CodeID), len(msg))
+	pinned := k.IsPinnedCode(ctx, contractInfo.CodeID)
+	executeCosts := k.gasRegister.InstantiateContractCosts(pinned, len(msg))
 	ctx.GasMeter().ConsumeGas(executeCosts, "Loading CosmWasm module: execute")
 
 	// add more funds
 	if !coins.IsZero() {
+		if err := k.assertReceivableFunds(ctx, contractAddress, coins); err != nil {
+			return nil, err
+		}
 		if err := k.bank.TransferCoins(ctx, caller, contractAddress, coins); err != nil {
 			return nil, err
 		}
@@ -320,16 +510,25 @@ func (k Keeper) execute(ctx sdk.Context, contractAddress sdk.AccAddress, caller
 	info := types.NewInfo(caller, coins)
 
 	// prepare querier
-	querier := k.newQueryHandler(ctx, contractAddress)
+	vmCtx, prefixStore, commitWrites := k.bufferedContractStore(ctx, contractAddress)
+	querier := k.newQueryHandler(vmCtx, contractAddress)
+	call := ContractCall{EntryPoint: "execute", Contract: contractAddress, CodeID: contractInfo.CodeID, Sender: caller, Msg: msg, Funds: coins}
+	if err := k.beforeContractExecution(ctx, call); err != nil {
+		return nil, err
+	}
 	gas := k.runtimeGasForContract(ctx)
-	res, gasUsed, execErr := k.wasmVM.Execute(codeInfo.CodeHash, env, info, msg, prefixStore, cosmwasmAPI, querier, k.gasMeter(ctx), gas)
+	res, gasUsed, execErr := k.wasmVM.Execute(codeInfo.CodeHash, env, info, msg, prefixStore, k.cosmwasmAPI, querier, k.gasMeter(ctx), gas)
+	k.logContractExecution(ctx, "execute", contractAddress, contractInfo.CodeID, gasUsed, execErr)
+	k.afterContractExecution(ctx, call, gasUsed, execErr)
+	commitWrites()
 	k.consumeRuntimeGas(ctx, gasUsed)
+	k.recordCodeExecution(ctx, contractInfo.CodeID, gasUsed)
 	if execErr != nil {
 		return nil, sdkerrors.Wrap(types.ErrExecuteFailed, execErr.Error())
 	}
 
 	// dispatch submessages then messages
-	data, err := k.handleContractResponse(ctx, contractAddress, contractInfo.IBCPortID, res.Submessages, res.Messages, res.Attributes, res.Data)
+	data, err := k.handleContractResponse(ctx, contractAddress, contractInfo.IBCPortID, pinned, res.Submessages, res.Messages, res.Attributes, res.Data)
 	if err != nil {
 		return nil, sdkerrors.Wrap(err, "dispatch")
 	}
@@ -337,8 +536,19 @@ func (k Keeper) execute(ctx sdk.Context, contractAddress sdk.AccAddress, caller
 }
 
 func (k Keeper) migrate(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, newCodeID uint64, msg []byte, authZ AuthorizationPolicy) ([]byte, error) {
+	var data []byte
+	err := k.withMaxContractGas(ctx, func(ctx sdk.Context) (err error) {
+		data, err = k.migrateContract(ctx, contractAddress, caller, newCodeID, msg, authZ)
+		return err
+	})
+	return data, err
+}
+
+func (k Keeper) migrateContract(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, newCodeID uint64, msg []byte, authZ AuthorizationPolicy) ([]byte, error) {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "migrate")
-	migrateSetupCosts := k.gasRegister.InstantiateContractCosts(k.IsPinnedCode(ctx, newCodeID), len(msg))
+	ctx = withCallOrigin(ctx, caller)
+	pinned := k.IsPinnedCode(ctx, newCodeID)
+	migrateSetupCosts := k.gasRegister.InstantiateContractCosts(pinned, len(msg))
 	ctx.GasMeter().ConsumeGas(migrateSetupCosts, "Loading CosmWasm module: migrate")
 
 	contractInfo := k.GetContractInfo(ctx, contractAddress)
@@ -348,11 +558,17 @@ func (k Keeper) migrate(ctx sdk.Context, contractAddress sdk.AccAddress, caller
 	if !authZ.CanModifyContract(contractInfo.AdminAddr(), caller) {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "can not migrate")
 	}
+	if err := assertNoReentrancy(ctx, contractAddress, *contractInfo); err != nil {
+		return nil, err
+	}
 
 	newCodeInfo := k.GetCodeInfo(ctx, newCodeID)
 	if newCodeInfo == nil {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "unknown code")
 	}
+	if err := k.assertSupportedFeatures(*newCodeInfo); err != nil {
+		return nil, err
+	}
 
 	// check for IBC flag
 	switch report, err := k.wasmVM.AnalyzeCode(newCodeInfo.CodeHash); {
@@ -373,12 +589,21 @@ func (k Keeper) migrate(ctx sdk.Context, contractAddress sdk.AccAddress, caller
 	env := types.NewEnv(ctx, contractAddress)
 
 	// prepare querier
-	querier := k.newQueryHandler(ctx, contractAddress)
-
-	prefixStoreKey := types.GetContractStorePrefix(contractAddress)
-	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), prefixStoreKey)
+	// the persisted contract info and history are updated only after the migrate entry point was executed so that
+	// the contract can query the code id and checksum that it is migrated from with the `contract_info` and
+	// `code_info` custom queries, see WithContractInfoQuerier and WithCodeInfoQuerier
+	vmCtx, prefixStore, commitWrites := k.bufferedContractStore(ctx, contractAddress)
+	querier := k.newQueryHandler(vmCtx, contractAddress)
+
+	call := ContractCall{EntryPoint: "migrate", Contract: contractAddress, CodeID: newCodeID, Sender: caller, Msg: msg}
+	if err := k.beforeContractExecution(ctx, call); err != nil {
+		return nil, err
+	}
 	gas := k.runtimeGasForContract(ctx)
-	res, gasUsed, err := k.wasmVM.Migrate(newCodeInfo.CodeHash, env, msg, &prefixStore, cosmwasmAPI, &querier, k.gasMeter(ctx), gas)
+	res, gasUsed, err := k.wasmVM.Migrate(newCodeInfo.CodeHash, env, msg, &prefixStore, k.cosmwasmAPI, &querier, k.gasMeter(ctx), gas)
+	k.logContractExecution(ctx, "migrate", contractAddress, newCodeID, gasUsed, err)
+	k.afterContractExecution(ctx, call, gasUsed, err)
+	commitWrites()
 	k.consumeRuntimeGas(ctx, gasUsed)
 	if err != nil {
 		return nil, sdkerrors.Wrap(types.ErrMigrationFailed, err.Error())
@@ -393,7 +618,7 @@ func (k Keeper) migrate(ctx sdk.Context, contractAddress sdk.AccAddress, caller
 	k.storeContractInfo(ctx, contractAddress, contractInfo)
 
 	// dispatch submessages then messages
-	data, err := k.handleContractResponse(ctx, contractAddress, contractInfo.IBCPortID, res.Submessages, res.Messages, res.Attributes, res.Data)
+	data, err := k.handleContractResponse(ctx, contractAddress, contractInfo.IBCPortID, pinned, res.Submessages, res.Messages, res.Attributes, res.Data)
 	if err != nil {
 		return nil, sdkerrors.Wrap(err, "dispatch")
 	}
@@ -405,27 +630,37 @@ func (k Keeper) migrate(ctx sdk.Context, contractAddress sdk.AccAddress, caller
 // responsibility or the app developer (who passes the wasm.Keeper in app.go)
 func (k Keeper) Sudo(ctx sdk.Context, contractAddress sdk.AccAddress, msg []byte) ([]byte, error) {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "sudo")
-	contractInfo, codeInfo, prefixStore, err := k.contractInstance(ctx, contractAddress)
+	ctx = withCallOrigin(ctx, nil)
+	contractInfo, codeInfo, _, err := k.contractInstance(ctx, contractAddress)
 	if err != nil {
 		return nil, err
 	}
 
-	sudoSetupCosts := k.gasRegister.InstantiateContractCosts(k.IsPinnedCode(ctx, contractInfo.CodeID), len(msg))
+	pinned := k.IsPinnedCode(ctx, contractInfo.CodeID)
+	sudoSetupCosts := k.gasRegister.InstantiateContractCosts(pinned, len(msg))
 	ctx.GasMeter().ConsumeGas(sudoSetupCosts, "Loading CosmWasm module: sudo")
 
 	env := types.NewEnv(ctx, contractAddress)
 
 	// prepare querier
-	querier := k.newQueryHandler(ctx, contractAddress)
+	vmCtx, prefixStore, commitWrites := k.bufferedContractStore(ctx, contractAddress)
+	querier := k.newQueryHandler(vmCtx, contractAddress)
+	call := ContractCall{EntryPoint: "sudo", Contract: contractAddress, CodeID: contractInfo.CodeID, Msg: msg}
+	if err := k.beforeContractExecution(ctx, call); err != nil {
+		return nil, err
+	}
 	gas := k.runtimeGasForContract(ctx)
-	res, gasUsed, execErr := k.wasmVM.Sudo(codeInfo.CodeHash, env, msg, prefixStore, cosmwasmAPI, querier, k.gasMeter(ctx), gas)
+	res, gasUsed, execErr := k.wasmVM.Sudo(codeInfo.CodeHash, env, msg, prefixStore, k.cosmwasmAPI, querier, k.gasMeter(ctx), gas)
+	k.logContractExecution(ctx, "sudo", contractAddress, contractInfo.CodeID, gasUsed, execErr)
+	k.afterContractExecution(ctx, call, gasUsed, execErr)
+	commitWrites()
 	k.consumeRuntimeGas(ctx, gasUsed)
 	if execErr != nil {
 		return nil, sdkerrors.Wrap(types.ErrExecuteFailed, execErr.Error())
 	}
 
 	// dispatch submessages then messages
-	data, err := k.handleContractResponse(ctx, contractAddress, contractInfo.IBCPortID, res.Submessages, res.Messages, res.Attributes, res.Data)
+	data, err := k.handleContractResponse(ctx, contractAddress, contractInfo.IBCPortID, pinned, res.Submessages, res.Messages, res.Attributes, res.Data)
 	if err != nil {
 		return nil, sdkerrors.Wrap(err, "dispatch")
 	}
@@ -435,30 +670,36 @@ func (k Keeper) Sudo(ctx sdk.Context, contractAddress sdk.AccAddress, msg []byte
 // reply is only called from keeper internal functions (dispatchSubmessages) after processing the submessage
 // it
 func (k Keeper) reply(ctx sdk.Context, contractAddress sdk.AccAddress, reply wasmvmtypes.Reply) ([]byte, error) {
-	contractInfo, codeInfo, prefixStore, err := k.contractInstance(ctx, contractAddress)
+	contractInfo, codeInfo, _, err := k.contractInstance(ctx, contractAddress)
 	if err != nil {
 		return nil, err
 	}
 
-	replyCosts := k.gasRegister.ReplyCosts(k.IsPinnedCode(ctx, contractInfo.CodeID), reply)
+	pinned := k.IsPinnedCode(ctx, contractInfo.CodeID)
+	replyCosts := k.gasRegister.ReplyCosts(pinned, reply)
 	ctx.GasMeter().ConsumeGas(replyCosts, "Loading CosmWasm module: reply")
 
 	env := types.NewEnv(ctx, contractAddress)
 
 	// prepare querier
-	querier := QueryHandler{
-		Ctx:     ctx,
-		Plugins: k.wasmVMQueryHandler,
+	vmCtx, prefixStore, commitWrites := k.bufferedContractStore(ctx, contractAddress)
+	querier := k.newQueryHandler(vmCtx, contractAddress)
+	call := ContractCall{EntryPoint: "reply", Contract: contractAddress, CodeID: contractInfo.CodeID}
+	if err := k.beforeContractExecution(ctx, call); err != nil {
+		return nil, err
 	}
 	gas := k.runtimeGasForContract(ctx)
-	res, gasUsed, execErr := k.wasmVM.Reply(codeInfo.CodeHash, env, reply, prefixStore, cosmwasmAPI, querier, k.gasMeter(ctx), gas)
+	res, gasUsed, execErr := k.wasmVM.Reply(codeInfo.CodeHash, env, reply, prefixStore, k.cosmwasmAPI, querier, k.gasMeter(ctx), gas)
+	k.logContractExecution(ctx, "reply", contractAddress, contractInfo.CodeID, gasUsed, execErr)
+	k.afterContractExecution(ctx, call, gasUsed, execErr)
+	commitWrites()
 	k.consumeRuntimeGas(ctx, gasUsed)
 	if execErr != nil {
 		return nil, sdkerrors.Wrap(types.ErrExecuteFailed, execErr.Error())
 	}
 
 	// dispatch submessages then messages
-	data, err := k.handleContractResponse(ctx, contractAddress, contractInfo.IBCPortID, res.Submessages, res.Messages, res.Attributes, res.Data)
+	data, err := k.handleContractResponse(ctx, contractAddress, contractInfo.IBCPortID, pinned, res.Submessages, res.Messages, res.Attributes, res.Data)
 	if err != nil {
 		return nil, sdkerrors.Wrap(err, "dispatch")
 	}
@@ -479,14 +720,31 @@ func (k Keeper) removeFromContractCodeSecondaryIndex(ctx sdk.Context, contractAd
 // IterateContractsByCode iterates over all contracts with given codeID ASC on code update time.
 func (k Keeper) IterateContractsByCode(ctx sdk.Context, codeID uint64, cb func(address sdk.AccAddress) bool) {
 	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.GetContractByCodeIDSecondaryIndexPrefix(codeID))
-	for iter := prefixStore.Iterator(nil, nil); iter.Valid(); iter.Next() {
-		key := iter.Key()
-		if cb(key[types.AbsoluteTxPositionLen:]) {
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		if cb(contractAddressFromCodeIndexKey(iter.Key())) {
 			return
 		}
 	}
 }
 
+// PaginateContractsByCode calls the callback for the page of contracts with given codeID ASC on code update time.
+// The page is read from the secondary index so that no full scan of the contracts is required.
+func (k Keeper) PaginateContractsByCode(ctx sdk.Context, codeID uint64, pageReq *query.PageRequest, cb func(address sdk.AccAddress)) (*query.PageResponse, error) {
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.GetContractByCodeIDSecondaryIndexPrefix(codeID))
+	return query.Paginate(prefixStore, pageReq, func(key []byte, _ []byte) error {
+		cb(contractAddressFromCodeIndexKey(key))
+		return nil
+	})
+}
+
+// contractAddressFromCodeIndexKey returns the contract address of a key within the prefix store of a code id in the
+// contracts by code secondary index: `<created position><contractAddr>`
+func contractAddressFromCodeIndexKey(key []byte) sdk.AccAddress {
+	return key[types.AbsoluteTxPositionLen:]
+}
+
 func (k Keeper) setContractAdmin(ctx sdk.Context, contractAddress, caller, newAdmin sdk.AccAddress, authZ AuthorizationPolicy) error {
 	contractInfo := k.GetContractInfo(ctx, contractAddress)
 	if contractInfo == nil {
@@ -556,7 +814,12 @@ func (k Keeper) QuerySmart(ctx sdk.Context, contractAddr sdk.AccAddress, req []b
 	querier := k.newQueryHandler(ctx, contractAddr)
 
 	env := types.NewEnv(ctx, contractAddr)
-	queryResult, gasUsed, qErr := k.wasmVM.Query(codeInfo.CodeHash, env, req, prefixStore, cosmwasmAPI, querier, k.gasMeter(ctx), k.runtimeGasForContract(ctx))
+	call := ContractCall{EntryPoint: "query", Contract: contractAddr, CodeID: contractInfo.CodeID, Msg: req}
+	if err := k.beforeContractExecution(ctx, call); err != nil {
+		return nil, err
+	}
+	queryResult, gasUsed, qErr := k.wasmVM.Query(codeInfo.CodeHash, env, req, prefixStore, k.cosmwasmAPI, querier, k.gasMeter(ctx), k.runtimeGasForContract(ctx))
+	k.afterContractExecution(ctx, call, gasUsed, qErr)
 	k.consumeRuntimeGas(ctx, gasUsed)
 	if qErr != nil {
 		return nil, sdkerrors.Wrap(types.ErrQueryFailed, qErr.Error())
@@ -575,25 +838,26 @@ func (k Keeper) QueryRaw(ctx sdk.Context, contractAddress sdk.AccAddress, key []
 	return prefixStore.Get(key)
 }
 
-func (k Keeper) contractInstance(ctx sdk.Context, contractAddress sdk.AccAddress) (types.ContractInfo, types.CodeInfo, prefix.Store, error) {
+func (k Keeper) contractInstance(ctx sdk.Context, contractAddress sdk.AccAddress) (types.ContractInfo, types.CodeInfo, contractStore, error) {
 	store := ctx.KVStore(k.storeKey)
 
 	contractBz := store.Get(types.GetContractAddressKey(contractAddress))
 	if contractBz == nil {
-		return types.ContractInfo{}, types.CodeInfo{}, prefix.Store{}, sdkerrors.Wrap(types.ErrNotFound, "contract")
+		return types.ContractInfo{}, types.CodeInfo{}, contractStore{}, sdkerrors.Wrap(types.ErrNotFound, "contract")
 	}
 	var contractInfo types.ContractInfo
 	k.cdc.MustUnmarshalBinaryBare(contractBz, &contractInfo)
 
 	codeInfoBz := store.Get(types.GetCodeKey(contractInfo.CodeID))
 	if codeInfoBz == nil {
-		return contractInfo, types.CodeInfo{}, prefix.Store{}, sdkerrors.Wrap(types.ErrNotFound, "code info")
+		return contractInfo, types.CodeInfo{}, contractStore{}, sdkerrors.Wrap(types.ErrNotFound, "code info")
 	}
 	var codeInfo types.CodeInfo
 	k.cdc.MustUnmarshalBinaryBare(codeInfoBz, &codeInfo)
-	prefixStoreKey := types.GetContractStorePrefix(contractAddress)
-	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), prefixStoreKey)
-	return contractInfo, codeInfo, prefixStore, nil
+	if err := k.assertSupportedFeatures(codeInfo); err != nil {
+		return contractInfo, codeInfo, contractStore{}, err
+	}
+	return contractInfo, codeInfo, newContractStore(ctx, k.storeKey, contractAddress), nil
 }
 
 func (k Keeper) GetContractInfo(ctx sdk.Context, contractAddress sdk.AccAddress) *types.ContractInfo {
@@ -638,6 +902,9 @@ func (k Keeper) GetContractState(ctx sdk.Context, contractAddress sdk.AccAddress
 }
 
 func (k Keeper) importContractState(ctx sdk.Context, contractAddress sdk.AccAddress, models []types.Model) error {
+	if err := types.ValidateContractState(models); err != nil {
+		return err
+	}
 	prefixStoreKey := types.GetContractStorePrefix(contractAddress)
 	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), prefixStoreKey)
 	for _, model := range models {
@@ -705,6 +972,7 @@ func (k Keeper) pinCode(ctx sdk.Context, codeID uint64) error {
 	store := ctx.KVStore(k.storeKey)
 	// store 1 byte to not run into `nil` debugging issues
 	store.Set(types.GetPinnedCodeIndexPrefix(codeID), []byte{1})
+	ctx.EventManager().EmitEvent(newPinCodeEvent(types.EventTypePinCode, codeID, codeInfo.CodeHash))
 	return nil
 }
 
@@ -720,15 +988,46 @@ func (k Keeper) unpinCode(ctx sdk.Context, codeID uint64) error {
 
 	store := ctx.KVStore(k.storeKey)
 	store.Delete(types.GetPinnedCodeIndexPrefix(codeID))
+	ctx.EventManager().EmitEvent(newPinCodeEvent(types.EventTypeUnpinCode, codeID, codeInfo.CodeHash))
 	return nil
 }
 
+// newPinCodeEvent returns the event for a change of the pinned codes so that other nodes and explorers can track the
+// cache policy
+func newPinCodeEvent(eventType string, codeID uint64, checksum []byte) sdk.Event {
+	return sdk.NewEvent(
+		eventType,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(types.AttributeKeyCodeID, strconv.FormatUint(codeID, 10)),
+		sdk.NewAttribute(types.AttributeKeyChecksum, hex.EncodeToString(checksum)),
+	)
+}
+
 // IsPinnedCode returns true when codeID is pinned in wasmvm cache
 func (k Keeper) IsPinnedCode(ctx sdk.Context, codeID uint64) bool {
 	store := ctx.KVStore(k.storeKey)
 	return store.Has(types.GetPinnedCodeIndexPrefix(codeID))
 }
 
+// GetStoreAuditLog returns the recorded store operations of the latest executions of the contract.
+// The second return value is false when the store audit log is not enabled on this node.
+func (k Keeper) GetStoreAuditLog(contractAddr sdk.AccAddress) ([]types.StoreAuditEntry, bool) {
+	if k.storeAuditLog == nil {
+		return nil, false
+	}
+	return k.storeAuditLog.ContractEntries(contractAddr.String()), true
+}
+
+// GetWasmVMMetrics returns the cache metrics of the wasm VM.
+// The second return value is false when the cache status query is not enabled on this node.
+func (k Keeper) GetWasmVMMetrics() (*wasmvmtypes.Metrics, bool, error) {
+	if !k.cacheStatusQuery {
+		return nil, false, nil
+	}
+	m, err := k.wasmVM.GetMetrics()
+	return m, true, err
+}
+
 // InitializePinnedCodes updates wasmvm to pin to cache all contracts marked as pinned
 func (k Keeper) InitializePinnedCodes(ctx sdk.Context) error {
 	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.PinnedCodeIndexPrefix)
@@ -758,22 +1057,63 @@ func (k Keeper) setContractInfoExtension(ctx sdk.Context, contractAddr sdk.AccAd
 	return nil
 }
 
+func (k Keeper) fundFromCommunityPool(ctx sdk.Context, recipient sdk.AccAddress, amount sdk.Coins, authZ AuthorizationPolicy) error {
+	if !canFundFromCommunityPool(authZ) {
+		return sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "can not fund from community pool")
+	}
+	return k.distKeeper.DistributeFromFeePool(ctx, amount, recipient)
+}
+
 // handleContractResponse processes the contract response data by emitting events and sending sub-/messages.
+// Pinned contracts are charged with the pinned event costs.
 func (k *Keeper) handleContractResponse(
 	ctx sdk.Context,
 	contractAddr sdk.AccAddress,
 	ibcPort string,
+	pinned bool,
 	subMsg []wasmvmtypes.SubMsg,
 	msgs []wasmvmtypes.CosmosMsg,
 	attrs []wasmvmtypes.EventAttribute,
 	data []byte,
 ) ([]byte, error) {
-	attributeGasCost := k.gasRegister.EventCosts(attrs)
+	maxDataSize := k.GetMaxResultDataSize(ctx)
+	if err := assertResultDataSize(data, maxDataSize); err != nil {
+		return nil, err
+	}
+	if r, ok := k.gasRegister.(ResultDataCostsRegister); ok {
+		ctx.GasMeter().ConsumeGas(r.ResultDataCosts(len(data)), "Contract result data")
+	}
+	attributeGasCost := k.eventCosts(pinned, attrs)
 	ctx.GasMeter().ConsumeGas(attributeGasCost, "Custom contract event attributes")
 	// emit all events from this contract itself
 	events := types.ParseEvents(attrs, contractAddr)
 	ctx.EventManager().EmitEvents(events)
-	return k.wasmVMResponseHandler.Handle(ctx, contractAddr, ibcPort, subMsg, msgs, data)
+	result, err := k.wasmVMResponseHandler.Handle(withContractOnCallStack(ctx, contractAddr), contractAddr, ibcPort, subMsg, msgs, data)
+	if err != nil {
+		return nil, err
+	}
+	// the data can be replaced by the reply to a submessage
+	if err := assertResultDataSize(result, maxDataSize); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// eventCosts returns the costs of the contract event attributes. Pinned contracts are charged the pinned event costs
+// when the gas register implements PinnedEventCostsRegister.
+func (k Keeper) eventCosts(pinned bool, attrs []wasmvmtypes.EventAttribute) sdk.Gas {
+	if r, ok := k.gasRegister.(PinnedEventCostsRegister); ok && pinned {
+		return r.PinnedEventCosts(attrs)
+	}
+	return k.gasRegister.EventCosts(attrs)
+}
+
+// assertResultDataSize returns an error when the data exceeds the max size. Zero means no limit.
+func assertResultDataSize(data []byte, maxSize uint64) error {
+	if maxSize != 0 && uint64(len(data)) > maxSize {
+		return sdkerrors.Wrapf(types.ErrLimit, "result data size %d exceeds max %d", len(data), maxSize)
+	}
+	return nil
 }
 
 func (k Keeper) runtimeGasForContract(ctx sdk.Context) uint64 {
@@ -796,10 +1136,47 @@ func (k Keeper) consumeRuntimeGas(ctx sdk.Context, gas uint64) {
 	}
 }
 
+// logContractExecution writes a debug log entry for a contract entry point call. Operators can enable it with
+// `--log_level x/wasm:debug` and `--log_format json` to correlate slow blocks to contracts.
+func (k Keeper) logContractExecution(ctx sdk.Context, entryPoint string, contractAddr sdk.AccAddress, codeID uint64, gasUsed uint64, err error) {
+	keyVals := []interface{}{
+		"entry_point", entryPoint,
+		"tx_hash", lazyTxHash(ctx.TxBytes()),
+		"height", ctx.BlockHeight(),
+		"contract", contractAddr.String(),
+		"code_id", codeID,
+		"gas", k.gasRegister.FromWasmVMGas(gasUsed),
+		"success", err == nil,
+	}
+	if err != nil {
+		keyVals = append(keyVals, "error", err.Error())
+	}
+	moduleLogger(ctx).Debug("contract execution", keyVals...)
+}
+
+// lazyTxHash is the hash of the tx bytes that is only computed when the log entry is written, not for filtered
+// debug entries
+type lazyTxHash []byte
+
+func (b lazyTxHash) String() string {
+	if len(b) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%X", tmhash.Sum(b))
+}
+
+// isUnclaimedAccount returns true for a base account that was never used to sign a tx. Such an account is created
+// when tokens are sent to the address before the contract is instantiated. The contract takes it over with the
+// balance. Accounts with a pubkey, module accounts and vesting accounts are claimed and block the instantiation.
+func isUnclaimedAccount(acc authtypes.AccountI) bool {
+	baseAcc, ok := acc.(*authtypes.BaseAccount)
+	return ok && baseAcc.GetPubKey() == nil && baseAcc.GetSequence() == 0
+}
+
 // generates a contract address from codeID + instanceID
 func (k Keeper) generateContractAddress(ctx sdk.Context, codeID uint64) sdk.AccAddress {
 	instanceID := k.autoIncrementID(ctx, types.KeyLastInstanceID)
-	return BuildContractAddress(codeID, instanceID)
+	return k.addressGenerator(ctx, codeID, instanceID)
 }
 
 // BuildContractAddress builds an sdk account address for a contract.
@@ -870,11 +1247,14 @@ func (k Keeper) importContract(ctx sdk.Context, contractAddr sdk.AccAddress, c *
 	k.appendToContractHistory(ctx, contractAddr, historyEntry)
 	k.storeContractInfo(ctx, contractAddr, c)
 	k.addToContractCodeSecondaryIndex(ctx, contractAddr, historyEntry)
+	if c.IBCPortID != "" {
+		k.storePortIDMapping(ctx, c.IBCPortID, contractAddr)
+	}
 	return k.importContractState(ctx, contractAddr, state)
 }
 
 func (k Keeper) newQueryHandler(ctx sdk.Context, contractAddress sdk.AccAddress) QueryHandler {
-	return NewQueryHandler(ctx, k.wasmVMQueryHandler, contractAddress, k.gasRegister)
+	return NewQueryHandler(withReceivedPacketFor(ctx, contractAddress), k.wasmVMQueryHandler, contractAddress, k.gasRegister, k.subQueryGasPercent)
 }
 
 func addrFromUint64(id uint64) sdk.AccAddress {
@@ -915,12 +1295,34 @@ func moduleLogger(ctx sdk.Context) log.Logger {
 
 // Querier creates a new grpc querier instance
 func Querier(k *Keeper) *grpcQuerier {
-	return NewGrpcQuerier(k.cdc, k.storeKey, k, k.queryGasLimit)
+	q := NewGrpcQuerier(k.cdc, k.storeKey, k, 0)
+	q.queryGasLimit = k.queryGasLimit
+	q.limiter = k.queryLimiter
+	sim := *k
+	sim.wasmVM = simulationEngine{WasmerEngine: k.wasmVM}
+	q.proposalHandler = NewWasmProposalHandler(&sim, types.EnableAllProposals)
+	q.abciQuerier = k.codeStreamQuerier
+	q.executeSimulator = NewMsgServerImpl(NewDefaultPermissionKeeper(k))
+	q.simulateExecuteGasLimit = k.simulateExecuteGasLimit
+	q.simulateExecuteLimiter = k.simulateExecuteLimiter
+	q.gasHintsCache = k.gasHintsCache
+	return q
 }
 
 // QueryGasLimit returns the gas limit for smart queries.
 func (k Keeper) QueryGasLimit() sdk.Gas {
-	return k.queryGasLimit
+	return k.queryGasLimit.get()
+}
+
+// SetQueryGasLimit sets the gas limit for smart queries on the gRPC query server and returns the previous limit.
+// This is a node local setting that is not part of the consensus and is reset to the config value on restart.
+func (k Keeper) SetQueryGasLimit(limit sdk.Gas) sdk.Gas {
+	return k.queryGasLimit.set(limit)
+}
+
+// LegacyQuerierProtoJSON returns true when the legacy querier should return proto JSON.
+func (k Keeper) LegacyQuerierProtoJSON() bool {
+	return k.legacyQuerierProtoJSON
 }
 
 // BankCoinTransferrer replicates the cosmos-sdk behaviour as in
@@ -2,8 +2,10 @@ package keeper
 
 import (
 	"bytes"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
 	wasmvm "github.com/CosmWasm/wasmvm"
 	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
@@ -14,12 +16,17 @@ import (
 	"time"
 
 	"github.com/CosmWasm/wasmd/x/wasm/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
 	stypes "github.com/cosmos/cosmos-sdk/store/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/query"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	vestingtypes "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	"github.com/tendermint/tendermint/libs/log"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 )
 
@@ -284,7 +291,7 @@ func TestInstantiate(t *testing.T) {
 
 	gasAfter := ctx.GasMeter().GasConsumed()
 	if types.EnableGasVerification {
-		require.Equal(t, uint64(0x12324), gasAfter-gasBefore)
+		require.Equal(t, uint64(0x12b12), gasAfter-gasBefore)
 	}
 
 	// ensure it is stored properly
@@ -358,6 +365,14 @@ func TestInstantiateWithDeposit(t *testing.T) {
 			require.NoError(t, err)
 			balances := bankKeeper.GetAllBalances(ctx, addr)
 			assert.Equal(t, deposit, balances)
+			// and funds event emitted
+			expEvent := sdk.NewEvent(types.EventTypeInstantiateFunds,
+				sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+				sdk.NewAttribute(types.AttributeKeyCreator, spec.srcActor.String()),
+				sdk.NewAttribute(types.AttributeKeyContractAddr, addr.String()),
+				sdk.NewAttribute(sdk.AttributeKeyAmount, deposit.String()),
+			)
+			assert.Contains(t, ctx.EventManager().Events(), expEvent)
 		})
 	}
 }
@@ -439,6 +454,76 @@ func TestInstantiateWithNonExistingCodeID(t *testing.T) {
 	require.Nil(t, addr)
 }
 
+func TestInstantiateWithExistingAccount(t *testing.T) {
+	myBalance := sdk.NewCoins(sdk.NewInt64Coin("denom", 100))
+	anyPubKey := secp256k1.GenPrivKey().PubKey()
+	specs := map[string]struct {
+		srcAccount func(addr sdk.AccAddress) authtypes.AccountI
+		expErr     *sdkerrors.Error
+	}{
+		"unclaimed base account": {
+			srcAccount: func(addr sdk.AccAddress) authtypes.AccountI {
+				return authtypes.NewBaseAccountWithAddress(addr)
+			},
+		},
+		"base account with pubkey": {
+			srcAccount: func(addr sdk.AccAddress) authtypes.AccountI {
+				return authtypes.NewBaseAccount(addr, anyPubKey, 0, 0)
+			},
+			expErr: types.ErrAccountExists,
+		},
+		"base account with sequence": {
+			srcAccount: func(addr sdk.AccAddress) authtypes.AccountI {
+				return authtypes.NewBaseAccount(addr, nil, 0, 1)
+			},
+			expErr: types.ErrAccountExists,
+		},
+		"module account": {
+			srcAccount: func(addr sdk.AccAddress) authtypes.AccountI {
+				return authtypes.NewModuleAccount(authtypes.NewBaseAccountWithAddress(addr), "squatter")
+			},
+			expErr: types.ErrAccountExists,
+		},
+		"vesting account": {
+			srcAccount: func(addr sdk.AccAddress) authtypes.AccountI {
+				return vestingtypes.NewContinuousVestingAccount(authtypes.NewBaseAccountWithAddress(addr), myBalance, 1, 2)
+			},
+			expErr: types.ErrAccountExists,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+			mock := wasmtesting.MockWasmer{}
+			wasmtesting.MakeInstantiable(&mock)
+			example := StoreRandomContract(t, ctx, keepers, &mock)
+			// the address of the first instance is predictable
+			contractAddr := BuildContractAddress(example.CodeID, 1)
+			acc := spec.srcAccount(contractAddr)
+			require.NoError(t, acc.SetAccountNumber(keepers.AccountKeeper.GetNextAccountNumber(ctx)))
+			keepers.AccountKeeper.SetAccount(ctx, acc)
+			require.NoError(t, keepers.BankKeeper.SetBalances(ctx, contractAddr, myBalance))
+			deposit := sdk.NewCoins(sdk.NewInt64Coin("denom", 1))
+			require.NoError(t, keepers.BankKeeper.SetBalances(ctx, example.CreatorAddr, deposit))
+
+			// when
+			gotAddr, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, example.CreatorAddr, nil, []byte(`{}`), "squatted", deposit)
+
+			// then
+			if spec.expErr != nil {
+				assert.True(t, spec.expErr.Is(err), "got %+v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, contractAddr, gotAddr)
+			assert.NotNil(t, keepers.WasmKeeper.GetContractInfo(ctx, contractAddr))
+			// the account is taken over with the balance
+			assert.Equal(t, acc.GetAccountNumber(), keepers.AccountKeeper.GetAccount(ctx, contractAddr).GetAccountNumber())
+			assert.Equal(t, myBalance.Add(deposit...), keepers.BankKeeper.GetAllBalances(ctx, contractAddr))
+		})
+	}
+}
+
 func TestInstantiateWithContractDataResponse(t *testing.T) {
 	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
 
@@ -517,7 +602,7 @@ func TestExecute(t *testing.T) {
 	// make sure gas is properly deducted from ctx
 	gasAfter := ctx.GasMeter().GasConsumed()
 	if types.EnableGasVerification {
-		require.Equal(t, uint64(0x12939), gasAfter-gasBefore)
+		require.Equal(t, uint64(0x13e86), gasAfter-gasBefore)
 	}
 	// ensure bob now exists and got both payments released
 	bobAcct = accKeeper.GetAccount(ctx, bob)
@@ -533,6 +618,126 @@ func TestExecute(t *testing.T) {
 	t.Logf("Duration: %v (%d gas)\n", diff, gasAfter-gasBefore)
 }
 
+func TestExecuteLogging(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	example := InstantiateHackatomExampleContract(t, ctx, keepers)
+	var buf bytes.Buffer
+	txBytes := []byte("my tx")
+	ctx = ctx.WithLogger(log.NewTMJSONLogger(log.NewSyncWriter(&buf))).WithTxBytes(txBytes)
+
+	specs := map[string]struct {
+		caller     sdk.AccAddress
+		expSuccess bool
+	}{
+		"success": {
+			caller:     example.VerifierAddr,
+			expSuccess: true,
+		},
+		"failure": {
+			caller: example.BeneficiaryAddr,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			buf.Reset()
+			ctx, _ := ctx.CacheContext()
+			_, err := keepers.ContractKeeper.Execute(ctx, example.Contract, spec.caller, []byte(`{"release":{}}`), nil)
+			require.Equal(t, spec.expSuccess, err == nil)
+
+			var got map[string]interface{}
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+			assert.Equal(t, "contract execution", got["_msg"])
+			assert.Equal(t, "debug", got["level"])
+			assert.Equal(t, "x/wasm", got["module"])
+			assert.Equal(t, "execute", got["entry_point"])
+			assert.Equal(t, fmt.Sprintf("%X", tmhash.Sum(txBytes)), got["tx_hash"])
+			assert.Equal(t, example.Contract.String(), got["contract"])
+			assert.Equal(t, float64(example.CodeID), got["code_id"])
+			assert.NotZero(t, got["gas"])
+			assert.Equal(t, spec.expSuccess, got["success"])
+			_, hasErr := got["error"]
+			assert.Equal(t, !spec.expSuccess, hasErr)
+		})
+	}
+}
+
+func TestLazyTxHash(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.NewTMLogger(log.NewSyncWriter(&buf))
+	txBytes := []byte("my tx")
+
+	logger.Info("with tx", "tx_hash", lazyTxHash(txBytes))
+	assert.Contains(t, buf.String(), "tx_hash="+fmt.Sprintf("%X", tmhash.Sum(txBytes)))
+
+	buf.Reset()
+	logger.Info("without tx", "tx_hash", lazyTxHash(nil))
+	assert.Contains(t, buf.String(), "tx_hash=\n")
+}
+
+func TestExecuteWithCallback(t *testing.T) {
+	specs := map[string]struct {
+		execErr   error
+		cbErr     error
+		expStored bool
+		expErr    bool
+	}{
+		"success": {
+			expStored: true,
+		},
+		"contract fails": {
+			execErr: errors.New("testing"),
+		},
+		"callback fails": {
+			cbErr:     errors.New("testing"),
+			expStored: true,
+			expErr:    true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+			mock := wasmtesting.MockWasmer{}
+			wasmtesting.MakeInstantiable(&mock)
+			example := SeedNewContractInstance(t, ctx, keepers, &mock)
+			mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.Response, uint64, error) {
+				store.Set([]byte("settled"), []byte("true"))
+				if spec.execErr != nil {
+					return nil, 0, spec.execErr
+				}
+				return &wasmvmtypes.Response{Data: []byte("my-data"), Attributes: []wasmvmtypes.EventAttribute{{Key: "foo", Value: "bar"}}}, 0, nil
+			}
+			em := sdk.NewEventManager()
+
+			var got types.WasmResult
+			var gotStored []byte
+			err := keepers.ContractKeeper.ExecuteWithCallback(ctx.WithEventManager(em), example.Contract, example.CreatorAddr, []byte(`{}`), nil,
+				func(ctx sdk.Context, result types.WasmResult) error {
+					got = result
+					gotStored = keepers.WasmKeeper.QueryRaw(ctx, example.Contract, []byte("settled"))
+					return spec.cbErr
+				})
+			if spec.expErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			// the callback sees the committed contract state
+			assert.Equal(t, spec.expStored, gotStored != nil)
+			if spec.execErr != nil {
+				assert.Error(t, got.Err)
+				assert.Nil(t, got.Data)
+				assert.Empty(t, got.Events)
+				assert.Empty(t, em.Events())
+				return
+			}
+			assert.NoError(t, got.Err)
+			assert.Equal(t, []byte("my-data"), got.Data)
+			require.NotEmpty(t, got.Events)
+			assert.Equal(t, got.Events, []sdk.Event(em.Events()))
+		})
+	}
+}
+
 func TestExecuteWithDeposit(t *testing.T) {
 	wasmCode, err := ioutil.ReadFile("./testdata/hackatom.wasm")
 	require.NoError(t, err)
@@ -673,6 +878,246 @@ func TestExecuteWithPanic(t *testing.T) {
 	assert.Contains(t, err.Error(), "Error calling the VM: Error executing Wasm: Wasmer runtime error: RuntimeError: unreachable")
 }
 
+func TestExecuteWithAllowlist(t *testing.T) {
+	specs := map[string]struct {
+		enabled     bool
+		listed      bool
+		callerAdmin bool
+		authZ       AuthorizationPolicy
+		expErr      *sdkerrors.Error
+	}{
+		"allowlist disabled": {
+			authZ: DefaultAuthorizationPolicy{},
+		},
+		"allowlisted contract": {
+			enabled: true,
+			listed:  true,
+			authZ:   DefaultAuthorizationPolicy{},
+		},
+		"contract not allowlisted": {
+			enabled: true,
+			authZ:   DefaultAuthorizationPolicy{},
+			expErr:  sdkerrors.ErrUnauthorized,
+		},
+		"contract not allowlisted - admin": {
+			enabled:     true,
+			callerAdmin: true,
+			authZ:       DefaultAuthorizationPolicy{},
+			expErr:      types.ErrExecuteFailed, // passes the allowlist but the contract accepts the verifier only
+		},
+		"contract not allowlisted - gov": {
+			enabled: true,
+			authZ:   GovAuthorizationPolicy{},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+			k := keepers.WasmKeeper
+			example := InstantiateHackatomExampleContract(t, ctx, keepers)
+			params := types.DefaultParams()
+			params.ExecuteAllowlistEnabled = spec.enabled
+			if spec.listed {
+				params.ExecuteAllowlist = []string{example.Contract.String()}
+			}
+			k.setParams(ctx, params)
+			caller := example.VerifierAddr
+			if spec.callerAdmin {
+				caller = example.CreatorAddr
+			}
+
+			// when
+			_, err := k.execute(ctx, example.Contract, caller, []byte(`{"release":{}}`), nil, spec.authZ)
+
+			// then
+			if spec.expErr == nil {
+				require.NoError(t, err)
+			} else {
+				assert.True(t, spec.expErr.Is(err), "got %+v", err)
+			}
+			// queries are not restricted
+			_, err = k.QuerySmart(ctx, example.Contract, []byte(`{"verifier":{}}`))
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestExecuteWithMaxContractGas(t *testing.T) {
+	const myGasLimit = 100_000
+	specs := map[string]struct {
+		paramGasLimit uint64
+		txGasLimit    uint64
+		consume       uint64
+		expOutOfGas   bool
+		expPanic      bool
+	}{
+		"no param limit": {
+			consume: 2 * myGasLimit,
+		},
+		"within param limit": {
+			paramGasLimit: myGasLimit,
+			consume:       myGasLimit / 2,
+		},
+		"param limit exceeded": {
+			paramGasLimit: myGasLimit,
+			consume:       myGasLimit + 1,
+			expOutOfGas:   true,
+		},
+		"tx limit lower than param limit": {
+			paramGasLimit: 100 * myGasLimit,
+			txGasLimit:    10 * myGasLimit,
+			consume:       10*myGasLimit + 1,
+			expPanic:      true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			parentCtx, keepers := CreateTestInput(t, false, SupportedFeatures)
+			k := keepers.WasmKeeper
+			mock := wasmtesting.MockWasmer{}
+			wasmtesting.MakeInstantiable(&mock)
+			example := SeedNewContractInstance(t, parentCtx, keepers, &mock)
+			mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.Response, uint64, error) {
+				return &wasmvmtypes.Response{}, spec.consume * DefaultGasMultiplier, nil
+			}
+			params := types.DefaultParams()
+			params.MaxContractGas = spec.paramGasLimit
+			k.setParams(parentCtx, params)
+
+			ctx := parentCtx.WithGasMeter(sdk.NewInfiniteGasMeter())
+			if spec.txGasLimit != 0 {
+				ctx = ctx.WithGasMeter(sdk.NewGasMeter(spec.txGasLimit))
+			}
+			if spec.expPanic {
+				assert.Panics(t, func() {
+					_, _ = keepers.ContractKeeper.Execute(ctx, example.Contract, example.CreatorAddr, []byte(`{}`), nil)
+				})
+				return
+			}
+			// when
+			_, gotErr := keepers.ContractKeeper.Execute(ctx, example.Contract, example.CreatorAddr, []byte(`{}`), nil)
+			// then
+			if spec.expOutOfGas {
+				assert.True(t, sdkerrors.ErrOutOfGas.Is(gotErr), "got %#+v", gotErr)
+				assert.GreaterOrEqual(t, ctx.GasMeter().GasConsumed(), uint64(myGasLimit))
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.GreaterOrEqual(t, ctx.GasMeter().GasConsumed(), spec.consume)
+		})
+	}
+}
+
+func TestExecuteWithMaxResultDataSize(t *testing.T) {
+	const myLimit = 10
+	specs := map[string]struct {
+		paramLimit uint64
+		dataLen    int
+		// the data is returned by the reply to a submessage
+		srcReply bool
+		expErr   *sdkerrors.Error
+	}{
+		"no param limit": {
+			dataLen: 2 * myLimit,
+		},
+		"within param limit": {
+			paramLimit: myLimit,
+			dataLen:    myLimit,
+		},
+		"param limit exceeded": {
+			paramLimit: myLimit,
+			dataLen:    myLimit + 1,
+			expErr:     types.ErrLimit,
+		},
+		"reply within param limit": {
+			paramLimit: myLimit,
+			dataLen:    myLimit,
+			srcReply:   true,
+		},
+		"reply param limit exceeded": {
+			paramLimit: myLimit,
+			dataLen:    myLimit + 1,
+			srcReply:   true,
+			expErr:     types.ErrLimit,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			mock := wasmtesting.MockWasmer{}
+			wasmtesting.MakeInstantiable(&mock)
+			parentCtx, keepers := CreateTestInput(t, false, SupportedFeatures, WithWasmEngine(&mock))
+			k := keepers.WasmKeeper
+			example := SeedNewContractInstance(t, parentCtx, keepers, &mock)
+			myData := bytes.Repeat([]byte{1}, spec.dataLen)
+			mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.Response, uint64, error) {
+				if !spec.srcReply {
+					return &wasmvmtypes.Response{Data: myData}, 0, nil
+				}
+				if string(executeMsg) != `{}` {
+					return &wasmvmtypes.Response{}, 0, nil
+				}
+				subMsg := wasmvmtypes.SubMsg{
+					ID:      1,
+					Msg:     wasmvmtypes.CosmosMsg{Wasm: &wasmvmtypes.WasmMsg{Execute: &wasmvmtypes.ExecuteMsg{ContractAddr: env.Contract.Address, Msg: []byte(`{"sub":{}}`), Send: []wasmvmtypes.Coin{}}}},
+					ReplyOn: wasmvmtypes.ReplySuccess,
+				}
+				return &wasmvmtypes.Response{Data: []byte{1}, Submessages: []wasmvmtypes.SubMsg{subMsg}}, 0, nil
+			}
+			mock.ReplyFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, reply wasmvmtypes.Reply, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.Response, uint64, error) {
+				return &wasmvmtypes.Response{Data: myData}, 0, nil
+			}
+			params := types.DefaultParams()
+			params.MaxResultDataSize = spec.paramLimit
+			k.setParams(parentCtx, params)
+			ctx := parentCtx.WithGasMeter(sdk.NewInfiniteGasMeter())
+
+			// when
+			gotData, gotErr := keepers.ContractKeeper.Execute(ctx, example.Contract, example.CreatorAddr, []byte(`{}`), nil)
+
+			// then
+			if spec.expErr != nil {
+				assert.True(t, spec.expErr.Is(gotErr), "got %#+v", gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, myData, gotData)
+		})
+	}
+}
+
+func TestHandleContractResponseResultDataCosts(t *testing.T) {
+	specs := map[string]struct {
+		gasRegister GasRegister
+		expGas      sdk.Gas
+	}{
+		"default gas register": {
+			gasRegister: NewDefaultWasmGasRegister(),
+			expGas:      100 * DefaultContractMessageDataCost,
+		},
+		"custom gas register": {
+			gasRegister: wasmtesting.MockGasRegister{EventCostsFn: func(evts []wasmvmtypes.EventAttribute) sdk.Gas {
+				return 0
+			}},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			parentCtx, keepers := CreateTestInput(t, false, SupportedFeatures, WithGasRegister(spec.gasRegister))
+			k := keepers.WasmKeeper
+			gasConsumed := func(data []byte) sdk.Gas {
+				ctx := parentCtx.WithGasMeter(sdk.NewInfiniteGasMeter())
+				_, err := k.handleContractResponse(ctx, RandomAccountAddress(t), "", false, nil, nil, nil, data)
+				require.NoError(t, err)
+				return ctx.GasMeter().GasConsumed()
+			}
+			// when
+			gotGas := gasConsumed(bytes.Repeat([]byte{1}, 100)) - gasConsumed(nil)
+			// then
+			assert.Equal(t, spec.expGas, gotGas)
+		})
+	}
+}
+
 func TestExecuteWithCpuLoop(t *testing.T) {
 	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
 	accKeeper, keeper, bankKeeper := keepers.AccountKeeper, keepers.ContractKeeper, keepers.BankKeeper
@@ -977,6 +1422,31 @@ func TestMigrateReplacesTheSecondIndex(t *testing.T) {
 	require.False(t, exists)
 }
 
+func TestMigrateWithSameCodeID(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	example := InstantiateHackatomExampleContract(t, ctx, keepers)
+
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1) // increment for different block
+	migMsgBz, err := json.Marshal(map[string]string{"verifier": RandomAccountAddress(t).String()})
+	require.NoError(t, err)
+
+	// when
+	_, err = keepers.ContractKeeper.Migrate(ctx, example.Contract, example.CreatorAddr, example.CodeID, migMsgBz)
+
+	// then
+	require.NoError(t, err)
+	var gotAddr []sdk.AccAddress
+	keepers.WasmKeeper.IterateContractsByCode(ctx, example.CodeID, func(address sdk.AccAddress) bool {
+		gotAddr = append(gotAddr, address)
+		return false
+	})
+	assert.Equal(t, []sdk.AccAddress{example.Contract}, gotAddr)
+	history := keepers.WasmKeeper.GetContractHistory(ctx, example.Contract)
+	require.Len(t, history, 2)
+	assert.Equal(t, types.ContractCodeHistoryOperationTypeMigrate, history[1].Operation)
+	assert.Equal(t, example.CodeID, history[1].CodeID)
+}
+
 func TestMigrateWithDispatchedMessage(t *testing.T) {
 	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
 	accKeeper, keeper, bankKeeper := keepers.AccountKeeper, keepers.ContractKeeper, keepers.BankKeeper
@@ -1096,6 +1566,58 @@ func TestIterateContractsByCode(t *testing.T) {
 	}
 }
 
+func TestPaginateContractsByCode(t *testing.T) {
+	var mockWasmVM wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mockWasmVM)
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithWasmEngine(&mockWasmVM))
+	k := keepers.WasmKeeper
+	example := SeedNewContractInstance(t, ctx, keepers, &mockWasmVM)
+	contracts := []sdk.AccAddress{example.Contract}
+	for i := 0; i < 2; i++ {
+		ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+		addr, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, example.CreatorAddr, nil, []byte("{}"), "foo", nil)
+		require.NoError(t, err)
+		contracts = append(contracts, addr)
+	}
+	specs := map[string]struct {
+		codeID  uint64
+		pageReq *query.PageRequest
+		exp     []sdk.AccAddress
+		expNext bool
+	}{
+		"all": {
+			codeID: example.CodeID,
+			exp:    contracts,
+		},
+		"first page": {
+			codeID:  example.CodeID,
+			pageReq: &query.PageRequest{Limit: 2},
+			exp:     contracts[0:2],
+			expNext: true,
+		},
+		"with offset": {
+			codeID:  example.CodeID,
+			pageReq: &query.PageRequest{Offset: 1, Limit: 1},
+			exp:     contracts[1:2],
+			expNext: true,
+		},
+		"unknown code": {
+			codeID: 99999,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var gotAddr []sdk.AccAddress
+			gotPage, gotErr := k.PaginateContractsByCode(ctx, spec.codeID, spec.pageReq, func(address sdk.AccAddress) {
+				gotAddr = append(gotAddr, address)
+			})
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, gotAddr)
+			assert.Equal(t, spec.expNext, len(gotPage.NextKey) != 0)
+		})
+	}
+}
+
 func TestIterateContractsByCodeWithMigration(t *testing.T) {
 	// mock migration so that it does not fail when migrate example1 to example2.codeID
 	mockWasmVM := wasmtesting.MockWasmer{MigrateFn: func(codeID wasmvm.Checksum, env wasmvmtypes.Env, migrateMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.Response, uint64, error) {
@@ -1123,6 +1645,215 @@ func TestIterateContractsByCodeWithMigration(t *testing.T) {
 	assert.Equal(t, exp, gotAddr)
 }
 
+func TestMigrateCanQueryPreviousCodeInfo(t *testing.T) {
+	var mockWasmVM wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mockWasmVM)
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithWasmEngine(&mockWasmVM), WithContractInfoQuerier(), WithCodeInfoQuerier())
+	k, c := keepers.WasmKeeper, keepers.ContractKeeper
+	example1 := InstantiateHackatomExampleContract(t, ctx, keepers)
+	example2 := InstantiateIBCReflectContract(t, ctx, keepers)
+
+	var gotContractInfo ContractInfoQueryResponse
+	var gotCodeInfo CodeInfoQueryResponse
+	mockWasmVM.MigrateFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, migrateMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.Response, uint64, error) {
+		// the contract queries the code that it is migrated from with the custom queries
+		bz, err := querier.Query(wasmvmtypes.QueryRequest{Custom: []byte(`{"contract_info":{"contract_addr":"` + env.Contract.Address + `"}}`)}, gasLimit)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(bz, &gotContractInfo))
+		bz, err = querier.Query(wasmvmtypes.QueryRequest{Custom: []byte(fmt.Sprintf(`{"code_info":{"code_id":%d}}`, gotContractInfo.CodeID))}, gasLimit)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(bz, &gotCodeInfo))
+		return &wasmvmtypes.Response{}, 1, nil
+	}
+
+	// when
+	_, err := c.Migrate(ctx, example1.Contract, example1.CreatorAddr, example2.CodeID, []byte("{}"))
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, example1.CodeID, gotContractInfo.CodeID)
+	assert.Equal(t, example1.CodeID, gotCodeInfo.CodeID)
+	assert.Equal(t, hex.EncodeToString(k.GetCodeInfo(ctx, example1.CodeID).CodeHash), gotCodeInfo.Checksum)
+	assert.Equal(t, example2.CodeID, k.GetContractInfo(ctx, example1.Contract).CodeID)
+}
+
+func TestReplyCanQuery(t *testing.T) {
+	var mockWasmVM wasmtesting.MockWasmer
+	wasmtesting.MakeInstantiable(&mockWasmVM)
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	example := SeedNewContractInstance(t, ctx, keepers, &mockWasmVM)
+	require.NoError(t, keepers.BankKeeper.SetBalances(ctx, example.Contract, sdk.NewCoins(sdk.NewInt64Coin("denom", 100))))
+
+	var gotBalance wasmvmtypes.BalanceResponse
+	var gotGasConsumed uint64
+	mockWasmVM.ReplyFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, reply wasmvmtypes.Reply, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.Response, uint64, error) {
+		// the contract queries its own balance in the reply
+		bz, err := querier.Query(wasmvmtypes.QueryRequest{Bank: &wasmvmtypes.BankQuery{Balance: &wasmvmtypes.BalanceQuery{Address: env.Contract.Address, Denom: "denom"}}}, gasLimit)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(bz, &gotBalance))
+		gotGasConsumed = querier.GasConsumed()
+		return &wasmvmtypes.Response{}, 1, nil
+	}
+
+	// when
+	_, err := keepers.WasmKeeper.reply(ctx, example.Contract, wasmvmtypes.Reply{ID: 1, Result: wasmvmtypes.SubcallResult{Ok: &wasmvmtypes.SubcallResponse{}}})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, wasmvmtypes.Coin{Denom: "denom", Amount: "100"}, gotBalance.Amount)
+	assert.NotZero(t, gotGasConsumed)
+}
+
+func TestEnvConsistentAcrossEntryPoints(t *testing.T) {
+	var m wasmtesting.MockWasmer
+	wasmtesting.MakeIBCInstantiable(&m)
+	parentCtx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	example := SeedNewContractInstance(t, parentCtx, keepers, &m)
+	k := keepers.WasmKeeper
+
+	var gotEnv *wasmvmtypes.Env
+	capture := func(env wasmvmtypes.Env) {
+		gotEnv = &env
+	}
+	m.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.Response, uint64, error) {
+		capture(env)
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+	m.QueryFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, queryMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) ([]byte, uint64, error) {
+		capture(env)
+		return []byte(`{}`), 0, nil
+	}
+	m.MigrateFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, migrateMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.Response, uint64, error) {
+		capture(env)
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+	m.SudoFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, sudoMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.Response, uint64, error) {
+		capture(env)
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+	m.ReplyFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, reply wasmvmtypes.Reply, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.Response, uint64, error) {
+		capture(env)
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+	m.IBCChannelOpenFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, channel wasmvmtypes.IBCChannel, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (uint64, error) {
+		capture(env)
+		return 0, nil
+	}
+	m.IBCChannelConnectFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, channel wasmvmtypes.IBCChannel, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.IBCBasicResponse, uint64, error) {
+		capture(env)
+		return &wasmvmtypes.IBCBasicResponse{}, 0, nil
+	}
+	m.IBCChannelCloseFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, channel wasmvmtypes.IBCChannel, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.IBCBasicResponse, uint64, error) {
+		capture(env)
+		return &wasmvmtypes.IBCBasicResponse{}, 0, nil
+	}
+	m.IBCPacketReceiveFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, packet wasmvmtypes.IBCPacket, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.IBCReceiveResponse, uint64, error) {
+		capture(env)
+		return &wasmvmtypes.IBCReceiveResponse{}, 0, nil
+	}
+	m.IBCPacketAckFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, ack wasmvmtypes.IBCAcknowledgement, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.IBCBasicResponse, uint64, error) {
+		capture(env)
+		return &wasmvmtypes.IBCBasicResponse{}, 0, nil
+	}
+	m.IBCPacketTimeoutFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, packet wasmvmtypes.IBCPacket, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.IBCBasicResponse, uint64, error) {
+		capture(env)
+		return &wasmvmtypes.IBCBasicResponse{}, 0, nil
+	}
+
+	specs := map[string]func(ctx sdk.Context) error{
+		"execute": func(ctx sdk.Context) error {
+			_, err := k.execute(ctx, example.Contract, example.CreatorAddr, []byte(`{}`), nil, DefaultAuthorizationPolicy{})
+			return err
+		},
+		"query": func(ctx sdk.Context) error {
+			_, err := k.QuerySmart(ctx, example.Contract, []byte(`{}`))
+			return err
+		},
+		"migrate": func(ctx sdk.Context) error {
+			_, err := k.migrate(ctx, example.Contract, example.CreatorAddr, example.CodeID, []byte(`{}`), DefaultAuthorizationPolicy{})
+			return err
+		},
+		"sudo": func(ctx sdk.Context) error {
+			_, err := k.Sudo(ctx, example.Contract, []byte(`{}`))
+			return err
+		},
+		"reply": func(ctx sdk.Context) error {
+			_, err := k.reply(ctx, example.Contract, wasmvmtypes.Reply{})
+			return err
+		},
+		"ibc channel open": func(ctx sdk.Context) error {
+			return k.OnOpenChannel(ctx, example.Contract, wasmvmtypes.IBCChannel{})
+		},
+		"ibc channel connect": func(ctx sdk.Context) error {
+			return k.OnConnectChannel(ctx, example.Contract, wasmvmtypes.IBCChannel{})
+		},
+		"ibc channel close": func(ctx sdk.Context) error {
+			return k.OnCloseChannel(ctx, example.Contract, wasmvmtypes.IBCChannel{})
+		},
+		"ibc packet receive": func(ctx sdk.Context) error {
+			_, err := k.OnRecvPacket(ctx, example.Contract, wasmvmtypes.IBCPacket{})
+			return err
+		},
+		"ibc packet ack": func(ctx sdk.Context) error {
+			return k.OnAckPacket(ctx, example.Contract, wasmvmtypes.IBCAcknowledgement{})
+		},
+		"ibc packet timeout": func(ctx sdk.Context) error {
+			return k.OnTimeoutPacket(ctx, example.Contract, wasmvmtypes.IBCPacket{})
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			ctx = ctx.WithBlockHeight(123).
+				WithBlockTime(time.Unix(1, 2)).
+				WithChainID("my-chain")
+			gotEnv = nil
+
+			// when
+			require.NoError(t, spec(ctx))
+
+			// then
+			exp := wasmvmtypes.Env{
+				Block: wasmvmtypes.BlockInfo{
+					Height:  123,
+					Time:    1_000_000_002,
+					ChainID: "my-chain",
+				},
+				Contract: wasmvmtypes.ContractInfo{Address: example.Contract.String()},
+			}
+			require.NotNil(t, gotEnv)
+			assert.Equal(t, exp, *gotEnv)
+		})
+	}
+	t.Run("instantiate", func(t *testing.T) {
+		ctx, _ := parentCtx.CacheContext()
+		ctx = ctx.WithBlockHeight(123).
+			WithBlockTime(time.Unix(1, 2)).
+			WithChainID("my-chain")
+		m.InstantiateFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, initMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.Response, uint64, error) {
+			capture(env)
+			return &wasmvmtypes.Response{}, 0, nil
+		}
+		gotEnv = nil
+
+		// when
+		contractAddr, _, err := k.instantiate(ctx, example.CodeID, example.CreatorAddr, nil, []byte(`{}`), "", nil, DefaultAuthorizationPolicy{})
+
+		// then
+		require.NoError(t, err)
+		exp := wasmvmtypes.Env{
+			Block: wasmvmtypes.BlockInfo{
+				Height:  123,
+				Time:    1_000_000_002,
+				ChainID: "my-chain",
+			},
+			Contract: wasmvmtypes.ContractInfo{Address: contractAddr.String()},
+		}
+		require.NotNil(t, gotEnv)
+		assert.Equal(t, exp, *gotEnv)
+	})
+}
+
 type sudoMsg struct {
 	// This is a tongue-in-check demo command. This is not the intended purpose of Sudo.
 	// Here we show that some priviledged Go module can make a call that should never be exposed
@@ -1386,6 +2117,40 @@ func TestInitializePinnedCodes(t *testing.T) {
 	}
 }
 
+func TestPinCodeEvents(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	k := keepers.WasmKeeper
+	mock := wasmtesting.MockWasmer{
+		PinFn:   func(checksum wasmvm.Checksum) error { return nil },
+		UnpinFn: func(checksum wasmvm.Checksum) error { return nil },
+	}
+	wasmtesting.MakeIBCInstantiable(&mock)
+	example := StoreRandomContract(t, ctx, keepers, &mock)
+	checksum := hex.EncodeToString(k.GetCodeInfo(ctx, example.CodeID).CodeHash)
+	expAttrs := []sdk.Attribute{
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(types.AttributeKeyCodeID, fmt.Sprintf("%d", example.CodeID)),
+		sdk.NewAttribute(types.AttributeKeyChecksum, checksum),
+	}
+
+	// when pinned
+	em := sdk.NewEventManager()
+	require.NoError(t, k.pinCode(ctx.WithEventManager(em), example.CodeID))
+	// then
+	assert.Equal(t, sdk.Events{sdk.NewEvent(types.EventTypePinCode, expAttrs...)}, em.Events())
+
+	// when unpinned
+	em = sdk.NewEventManager()
+	require.NoError(t, k.unpinCode(ctx.WithEventManager(em), example.CodeID))
+	// then
+	assert.Equal(t, sdk.Events{sdk.NewEvent(types.EventTypeUnpinCode, expAttrs...)}, em.Events())
+
+	// and failures emit no events
+	em = sdk.NewEventManager()
+	require.Error(t, k.pinCode(ctx.WithEventManager(em), example.CodeID+1))
+	assert.Empty(t, em.Events())
+}
+
 func TestPinnedContractLoops(t *testing.T) {
 	// a pinned contract that calls itself via submessages should terminate with an
 	// error at some point
@@ -1423,7 +2188,7 @@ func TestPinnedContractLoops(t *testing.T) {
 	}
 	ctx = ctx.WithGasMeter(sdk.NewGasMeter(20000))
 	require.PanicsWithValue(t, sdk.ErrorOutOfGas{Descriptor: "ReadFlat"}, func() {
-		k.execute(ctx, example.Contract, RandomAccountAddress(t), anyMsg, nil)
+		k.execute(ctx, example.Contract, RandomAccountAddress(t), anyMsg, nil, DefaultAuthorizationPolicy{})
 	})
 	assert.True(t, ctx.GasMeter().IsOutOfGas())
 	assert.Greater(t, loops, 2)
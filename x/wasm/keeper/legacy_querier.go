@@ -3,8 +3,10 @@ package keeper
 import (
 	"encoding/json"
 	"github.com/CosmWasm/wasmd/x/wasm/types"
+	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/gogo/protobuf/proto"
 	abci "github.com/tendermint/tendermint/abci/types"
 	"reflect"
 	"strconv"
@@ -16,6 +18,7 @@ const (
 	QueryGetContractState   = "contract-state"
 	QueryGetCode            = "code"
 	QueryListCode           = "list-code"
+	QueryGetCodeInfo        = "code-info"
 	QueryContractHistory    = "contract-history"
 )
 
@@ -27,6 +30,17 @@ const (
 
 // NewLegacyQuerier creates a new querier
 func NewLegacyQuerier(keeper types.ViewKeeper, gasLimit sdk.Gas) sdk.Querier {
+	return newLegacyQuerier(keeper, gasLimit, nil)
+}
+
+// NewProtoJSONLegacyQuerier creates a new querier that returns the gRPC query response types encoded as proto JSON.
+// Clients get the same field names and encodings as with the gRPC and gRPC gateway endpoints.
+func NewProtoJSONLegacyQuerier(keeper types.ViewKeeper, gasLimit sdk.Gas, cdc codec.JSONMarshaler) sdk.Querier {
+	return newLegacyQuerier(keeper, gasLimit, cdc)
+}
+
+// newLegacyQuerier returns the proto JSON encoded gRPC responses when the codec is set and ad-hoc JSON otherwise
+func newLegacyQuerier(keeper types.ViewKeeper, gasLimit sdk.Gas, cdc codec.JSONMarshaler) sdk.Querier {
 	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, error) {
 		var (
 			rsp interface{}
@@ -49,13 +63,22 @@ func NewLegacyQuerier(keeper types.ViewKeeper, gasLimit sdk.Gas) sdk.Querier {
 			if len(path) < 3 {
 				return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "unknown data query endpoint")
 			}
-			return queryContractState(ctx, path[1], path[2], req.Data, gasLimit, keeper)
+			if cdc == nil {
+				return queryContractState(ctx, path[1], path[2], req.Data, gasLimit, keeper)
+			}
+			rsp, err = queryContractStateResponse(ctx, path[1], path[2], req.Data, gasLimit, keeper)
 		case QueryGetCode:
 			codeID, err := strconv.ParseUint(path[1], 10, 64)
 			if err != nil {
 				return nil, sdkerrors.Wrapf(types.ErrInvalid, "code id: %s", err.Error())
 			}
 			rsp, err = queryCode(ctx, codeID, keeper)
+		case QueryGetCodeInfo:
+			codeID, err := strconv.ParseUint(path[1], 10, 64)
+			if err != nil {
+				return nil, sdkerrors.Wrapf(types.ErrInvalid, "code id: %s", err.Error())
+			}
+			rsp = queryCodeInfo(ctx, codeID, keeper)
 		case QueryListCode:
 			rsp, err = queryCodeList(ctx, keeper)
 		case QueryContractHistory:
@@ -70,9 +93,19 @@ func NewLegacyQuerier(keeper types.ViewKeeper, gasLimit sdk.Gas) sdk.Querier {
 		if err != nil {
 			return nil, err
 		}
+		if cdc != nil {
+			rsp = toQueryResponse(rsp)
+		}
 		if rsp == nil || reflect.ValueOf(rsp).IsNil() {
 			return nil, nil
 		}
+		if cdc != nil {
+			bz, err := cdc.MarshalJSON(rsp.(proto.Message))
+			if err != nil {
+				return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+			}
+			return bz, nil
+		}
 		bz, err := json.MarshalIndent(rsp, "", "  ")
 		if err != nil {
 			return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
@@ -91,15 +124,7 @@ func queryContractState(ctx sdk.Context, bech, queryMethod string, data []byte,
 	switch queryMethod {
 	case QueryMethodContractStateAll:
 		// this returns a serialized json object (which internally encoded binary fields properly)
-		for iter := keeper.GetContractState(ctx, contractAddr); iter.Valid(); iter.Next() {
-			resultData = append(resultData, types.Model{
-				Key:   iter.Key(),
-				Value: iter.Value(),
-			})
-		}
-		if resultData == nil {
-			resultData = make([]types.Model, 0)
-		}
+		resultData = queryAllContractState(ctx, contractAddr, keeper)
 	case QueryMethodContractStateRaw:
 		// this returns the raw data from the state, base64-encoded
 		return keeper.QueryRaw(ctx, contractAddr, data), nil
@@ -118,6 +143,60 @@ func queryContractState(ctx sdk.Context, bech, queryMethod string, data []byte,
 	return bz, nil
 }
 
+// queryContractStateResponse returns the contract state as gRPC query response type
+func queryContractStateResponse(ctx sdk.Context, bech, queryMethod string, data []byte, gasLimit sdk.Gas, keeper types.ViewKeeper) (proto.Message, error) {
+	contractAddr, err := sdk.AccAddressFromBech32(bech)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, bech)
+	}
+	switch queryMethod {
+	case QueryMethodContractStateAll:
+		return &types.QueryAllContractStateResponse{Models: queryAllContractState(ctx, contractAddr, keeper)}, nil
+	case QueryMethodContractStateRaw:
+		return &types.QueryRawContractStateResponse{Data: keeper.QueryRaw(ctx, contractAddr, data)}, nil
+	case QueryMethodContractStateSmart:
+		// we enforce a subjective gas limit on all queries to avoid infinite loops
+		ctx = ctx.WithGasMeter(sdk.NewGasMeter(gasLimit))
+		bz, err := keeper.QuerySmart(ctx, contractAddr, data)
+		if err != nil {
+			return nil, err
+		}
+		return &types.QuerySmartContractStateResponse{Data: bz}, nil
+	default:
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, queryMethod)
+	}
+}
+
+func queryAllContractState(ctx sdk.Context, contractAddr sdk.AccAddress, keeper types.ViewKeeper) []types.Model {
+	resultData := make([]types.Model, 0)
+	for iter := keeper.GetContractState(ctx, contractAddr); iter.Valid(); iter.Next() {
+		resultData = append(resultData, types.Model{
+			Key:   iter.Key(),
+			Value: iter.Value(),
+		})
+	}
+	return resultData
+}
+
+// toQueryResponse converts the legacy query results into the gRPC query response types
+func toQueryResponse(rsp interface{}) interface{} {
+	switch r := rsp.(type) {
+	case []string:
+		return &types.QueryContractsByCodeResponse{Contracts: r}
+	case []types.CodeInfoResponse:
+		return &types.QueryCodesResponse{CodeInfos: r}
+	case *types.CodeInfoResponse:
+		if r == nil {
+			return nil
+		}
+		return &types.QueryCodeInfoResponse{CodeInfoResponse: r}
+	case []types.ContractCodeHistoryEntry:
+		return &types.QueryContractHistoryResponse{Entries: r}
+	default:
+		return rsp
+	}
+}
+
 func queryCodeList(ctx sdk.Context, keeper types.ViewKeeper) ([]types.CodeInfoResponse, error) {
 	var info []types.CodeInfoResponse
 	keeper.IterateCodeInfos(ctx, func(i uint64, res types.CodeInfo) bool {
@@ -10,6 +10,7 @@ import (
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkErrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/gogo/protobuf/proto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -363,3 +364,114 @@ func TestLegacyQueryCodeList(t *testing.T) {
 		})
 	}
 }
+
+func TestProtoJSONLegacyQuerier(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	k := keepers.WasmKeeper
+	example := InstantiateHackatomExampleContract(t, ctx, keepers)
+	contract := example.Contract.String()
+	grpcQuerier := Querier(k)
+	c := sdk.WrapSDKContext(ctx)
+
+	codeRsp, err := grpcQuerier.Code(c, &types.QueryCodeRequest{CodeId: example.CodeID})
+	require.NoError(t, err)
+	codeInfoRsp, err := grpcQuerier.CodeInfo(c, &types.QueryCodeInfoRequest{CodeId: example.CodeID})
+	require.NoError(t, err)
+	codesRsp, err := grpcQuerier.Codes(c, &types.QueryCodesRequest{})
+	require.NoError(t, err)
+	codesRsp.Pagination = nil
+	contractRsp, err := grpcQuerier.ContractInfo(c, &types.QueryContractInfoRequest{Address: contract})
+	require.NoError(t, err)
+	contractRsp.OpenChannelIDs = []string{} // empty list in proto JSON
+	contractsRsp, err := grpcQuerier.ContractsByCode(c, &types.QueryContractsByCodeRequest{CodeId: example.CodeID})
+	require.NoError(t, err)
+	contractsRsp.Pagination = nil
+	historyRsp, err := grpcQuerier.ContractHistory(c, &types.QueryContractHistoryRequest{Address: contract})
+	require.NoError(t, err)
+	historyRsp.Pagination = nil
+	allStateRsp, err := grpcQuerier.AllContractState(c, &types.QueryAllContractStateRequest{Address: contract})
+	require.NoError(t, err)
+	allStateRsp.Pagination = nil
+	rawStateRsp, err := grpcQuerier.RawContractState(c, &types.QueryRawContractStateRequest{Address: contract, QueryData: []byte("config")})
+	require.NoError(t, err)
+	smartStateRsp, err := grpcQuerier.SmartContractState(c, &types.QuerySmartContractStateRequest{Address: contract, QueryData: []byte(`{"verifier":{}}`)})
+	require.NoError(t, err)
+
+	specs := map[string]struct {
+		srcPath []string
+		srcData []byte
+		exp     proto.Message
+		dst     proto.Message
+	}{
+		"code": {
+			srcPath: []string{QueryGetCode, fmt.Sprint(example.CodeID)},
+			exp:     codeRsp,
+			dst:     &types.QueryCodeResponse{},
+		},
+		"code info": {
+			srcPath: []string{QueryGetCodeInfo, fmt.Sprint(example.CodeID)},
+			exp:     codeInfoRsp,
+			dst:     &types.QueryCodeInfoResponse{},
+		},
+		"list code": {
+			srcPath: []string{QueryListCode},
+			exp:     codesRsp,
+			dst:     &types.QueryCodesResponse{},
+		},
+		"contract info": {
+			srcPath: []string{QueryGetContract, contract},
+			exp:     contractRsp,
+			dst:     &types.QueryContractInfoResponse{},
+		},
+		"list contracts by code": {
+			srcPath: []string{QueryListContractByCode, fmt.Sprint(example.CodeID)},
+			exp:     contractsRsp,
+			dst:     &types.QueryContractsByCodeResponse{},
+		},
+		"contract history": {
+			srcPath: []string{QueryContractHistory, contract},
+			exp:     historyRsp,
+			dst:     &types.QueryContractHistoryResponse{},
+		},
+		"all contract state": {
+			srcPath: []string{QueryGetContractState, contract, QueryMethodContractStateAll},
+			exp:     allStateRsp,
+			dst:     &types.QueryAllContractStateResponse{},
+		},
+		"raw contract state": {
+			srcPath: []string{QueryGetContractState, contract, QueryMethodContractStateRaw},
+			srcData: []byte("config"),
+			exp:     rawStateRsp,
+			dst:     &types.QueryRawContractStateResponse{},
+		},
+		"smart contract state": {
+			srcPath: []string{QueryGetContractState, contract, QueryMethodContractStateSmart},
+			srcData: []byte(`{"verifier":{}}`),
+			exp:     smartStateRsp,
+			dst:     &types.QuerySmartContractStateResponse{},
+		},
+	}
+	q := NewProtoJSONLegacyQuerier(k, k.QueryGasLimit(), k.cdc)
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			bz, err := q(ctx, spec.srcPath, abci.RequestQuery{Data: spec.srcData})
+			require.NoError(t, err)
+			require.NoError(t, k.cdc.UnmarshalJSON(bz, spec.dst))
+			assert.Equal(t, spec.exp, spec.dst)
+		})
+	}
+}
+
+func TestProtoJSONLegacyQuerierNotFound(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	k := keepers.WasmKeeper
+	q := NewProtoJSONLegacyQuerier(k, k.QueryGasLimit(), k.cdc)
+
+	bz, err := q(ctx, []string{QueryGetContract, RandomBech32AccountAddress(t)}, abci.RequestQuery{})
+	require.NoError(t, err)
+	assert.Nil(t, bz)
+
+	bz, err = q(ctx, []string{QueryListContractByCode, "1"}, abci.RequestQuery{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"contracts":[],"pagination":null}`, string(bz))
+}
@@ -0,0 +1,52 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// ExecutionMiddleware is called around the contract calls of the wasm VM. It allows to layer cross-cutting features
+// like rate limiting, allowlists or tracing on top of the keeper. Middlewares are registered with
+// WithExecutionMiddleware.
+type ExecutionMiddleware interface {
+	// Before is called ahead of the VM call. A returned error vetoes the call and aborts the operation.
+	Before(ctx sdk.Context, call ContractCall) error
+	// After is called with the sdk gas used and the result of the VM call. It is not called for vetoed calls or
+	// when the VM call panics, for example with out of gas.
+	After(ctx sdk.Context, call ContractCall, gasUsed sdk.Gas, err error)
+}
+
+// ContractCall contains the details of a contract call that are passed to the ExecutionMiddleware
+type ContractCall struct {
+	// EntryPoint is the name of the contract entry point, for example "execute" or "ibc_packet_receive"
+	EntryPoint string
+	Contract   sdk.AccAddress
+	CodeID     uint64
+	// Sender is the caller on instantiate, execute and migrate. Nil otherwise.
+	Sender sdk.AccAddress
+	// Msg is the json message on instantiate, execute, migrate, sudo and query. Nil otherwise.
+	Msg []byte
+	// Funds are sent to the contract on instantiate and execute
+	Funds sdk.Coins
+}
+
+// beforeContractExecution calls the middlewares in the order of registration and stops on the first veto
+func (k Keeper) beforeContractExecution(ctx sdk.Context, call ContractCall) error {
+	for _, m := range k.executionMiddlewares {
+		if err := m.Before(ctx, call); err != nil {
+			return sdkerrors.Wrapf(err, "%s vetoed by execution middleware", call.EntryPoint)
+		}
+	}
+	return nil
+}
+
+// afterContractExecution calls the middlewares in the reverse order of registration
+func (k Keeper) afterContractExecution(ctx sdk.Context, call ContractCall, gasUsed uint64, err error) {
+	if len(k.executionMiddlewares) == 0 {
+		return
+	}
+	sdkGas := k.gasRegister.FromWasmVMGas(gasUsed)
+	for i := len(k.executionMiddlewares) - 1; i >= 0; i-- {
+		k.executionMiddlewares[i].After(ctx, call, sdkGas, err)
+	}
+}
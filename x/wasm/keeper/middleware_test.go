@@ -0,0 +1,128 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	wasmvm "github.com/CosmWasm/wasmvm"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecutionMiddleware(t *testing.T) {
+	myFunds := sdk.NewCoins(sdk.NewInt64Coin("denom", 1))
+	specs := map[string]struct {
+		srcVeto   error
+		srcVMErr  error
+		expErr    *sdkerrors.Error
+		expVMCall bool
+		expAfter  bool
+	}{
+		"allowed": {
+			expVMCall: true,
+			expAfter:  true,
+		},
+		"vetoed": {
+			srcVeto: sdkerrors.ErrUnauthorized,
+			expErr:  sdkerrors.ErrUnauthorized,
+		},
+		"vm error": {
+			srcVMErr:  types.ErrInvalid,
+			expErr:    types.ErrExecuteFailed,
+			expVMCall: true,
+			expAfter:  true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			var log []string
+			first := &mockExecutionMiddleware{name: "first", log: &log}
+			second := &mockExecutionMiddleware{name: "second", log: &log}
+			ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithExecutionMiddleware(first), WithExecutionMiddleware(second))
+			mock := wasmtesting.MockWasmer{}
+			wasmtesting.MakeInstantiable(&mock)
+			example := SeedNewContractInstance(t, ctx, keepers, &mock)
+			var vmCalled bool
+			mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.Response, uint64, error) {
+				vmCalled = true
+				return &wasmvmtypes.Response{}, 2 * DefaultGasMultiplier, spec.srcVMErr
+			}
+			user := RandomAccountAddress(t)
+			require.NoError(t, keepers.BankKeeper.SetBalances(ctx, user, myFunds))
+			first.veto, log = spec.srcVeto, nil
+
+			// when
+			_, err := keepers.ContractKeeper.Execute(ctx, example.Contract, user, []byte(`{"foo":"bar"}`), myFunds)
+
+			// then
+			assert.Equal(t, spec.expVMCall, vmCalled)
+			if spec.expErr != nil {
+				assert.True(t, spec.expErr.Is(err), "got %+v", err)
+			} else {
+				require.NoError(t, err)
+			}
+			expCall := ContractCall{
+				EntryPoint: "execute",
+				Contract:   example.Contract,
+				CodeID:     example.CodeID,
+				Sender:     user,
+				Msg:        []byte(`{"foo":"bar"}`),
+				Funds:      myFunds,
+			}
+			assert.Equal(t, expCall, first.lastCall)
+			if !spec.expAfter {
+				assert.Equal(t, []string{"first.before"}, log)
+				return
+			}
+			assert.Equal(t, []string{"first.before", "second.before", "second.after", "first.after"}, log)
+			assert.Equal(t, sdk.Gas(2), first.lastGasUsed)
+			assert.Equal(t, spec.srcVMErr, first.lastErr)
+		})
+	}
+}
+
+func TestExecutionMiddlewareOnQuery(t *testing.T) {
+	m := &mockExecutionMiddleware{name: "m", log: new([]string)}
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithExecutionMiddleware(m))
+	mock := wasmtesting.MockWasmer{}
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	m.veto = sdkerrors.ErrUnauthorized
+	mock.QueryFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, queryMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) ([]byte, uint64, error) {
+		t.Fatal("must not be called")
+		return nil, 0, nil
+	}
+
+	// when
+	_, err := keepers.WasmKeeper.QuerySmart(ctx, example.Contract, []byte(`{}`))
+
+	// then
+	assert.True(t, sdkerrors.ErrUnauthorized.Is(err), "got %+v", err)
+	assert.Equal(t, "query", m.lastCall.EntryPoint)
+	assert.Equal(t, []byte(`{}`), m.lastCall.Msg)
+}
+
+type mockExecutionMiddleware struct {
+	name        string
+	log         *[]string
+	veto        error
+	lastCall    ContractCall
+	lastGasUsed sdk.Gas
+	lastErr     error
+}
+
+func (m *mockExecutionMiddleware) Before(ctx sdk.Context, call ContractCall) error {
+	*m.log = append(*m.log, m.name+".before")
+	m.lastCall = call
+	return m.veto
+}
+
+func (m *mockExecutionMiddleware) After(ctx sdk.Context, call ContractCall, gasUsed sdk.Gas, err error) {
+	*m.log = append(*m.log, m.name+".after")
+	m.lastGasUsed = gasUsed
+	m.lastErr = err
+}
@@ -0,0 +1,84 @@
+package keeper
+
+import (
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// paramsAddedInV2 are the keys of the params that do not exist in the state of version 1 of the module
+var paramsAddedInV2 = [][]byte{
+	types.ParamStoreKeyIBCPacketGasLimit,
+	types.ParamStoreKeyExecuteAllowlistEnabled,
+	types.ParamStoreKeyExecuteAllowlist,
+	types.ParamStoreKeyMaxContractGas,
+	types.ParamStoreKeyMaxResultDataSize,
+	types.ParamStoreKeyStoreCodeDeposit,
+	types.ParamStoreKeyStoreCodeDepositRefundBlocks,
+}
+
+// disabledParamsAddedInV2 are the values of the params added with version 2 that are set for chains that upgrade
+// from version 1 instead of the defaults. The defaults would make contract calls fail that succeeded before, so that
+// the limits are disabled and governance can opt in with a param change proposal.
+var disabledParamsAddedInV2 = map[string]interface{}{
+	string(types.ParamStoreKeyIBCPacketGasLimit): uint64(0),
+	string(types.ParamStoreKeyMaxResultDataSize): uint64(0),
+}
+
+// Migrator runs the in place state migrations of the wasm module in an upgrade handler
+type Migrator struct {
+	keeper Keeper
+}
+
+// NewMigrator returns a new Migrator
+func NewMigrator(keeper Keeper) Migrator {
+	return Migrator{keeper: keeper}
+}
+
+// Migrate1to2 sets the params that were added with version 2 to their default values, or disables them when the
+// default would change the behavior of existing contracts, see disabledParamsAddedInV2. Params that were set
+// before are not modified. The required features are recorded for the codes that were stored before version 2.
+func (m Migrator) Migrate1to2(ctx sdk.Context) error {
+	defaults := types.DefaultParams()
+	pairs := defaults.ParamSetPairs()
+	for _, key := range paramsAddedInV2 {
+		if m.keeper.paramSpace.Has(ctx, key) {
+			continue
+		}
+		if v, ok := disabledParamsAddedInV2[string(key)]; ok {
+			m.keeper.paramSpace.Set(ctx, key, v)
+			continue
+		}
+		for _, p := range pairs {
+			if string(p.Key) == string(key) {
+				m.keeper.paramSpace.Set(ctx, key, p.Value)
+			}
+		}
+	}
+	return m.recordRequiredFeatures(ctx)
+}
+
+// recordRequiredFeatures sets the required features of the codes without features from their byte code so that
+// codes stored before the features were recorded can be checked for unsupported features, too.
+// Codes that are not in the wasm VM, as on nodes that were state synced, are logged and skipped so that the upgrade
+// does not fail on them.
+func (m Migrator) recordRequiredFeatures(ctx sdk.Context) error {
+	var legacyCodes []uint64
+	m.keeper.IterateCodeInfos(ctx, func(codeID uint64, info types.CodeInfo) bool {
+		if len(info.RequiredFeatures) == 0 {
+			legacyCodes = append(legacyCodes, codeID)
+		}
+		return false
+	})
+	for _, codeID := range legacyCodes {
+		codeInfo := m.keeper.GetCodeInfo(ctx, codeID)
+		wasmCode, err := m.keeper.wasmVM.GetCode(codeInfo.CodeHash)
+		if err != nil {
+			m.keeper.Logger(ctx).Error("skip recording required features", "code_id", codeID, "error", err.Error())
+			continue
+		}
+		if codeInfo.RequiredFeatures = requiredFeatures(wasmCode); len(codeInfo.RequiredFeatures) != 0 {
+			m.keeper.storeCodeInfo(ctx, codeID, *codeInfo)
+		}
+	}
+	return nil
+}
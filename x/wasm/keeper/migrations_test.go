@@ -0,0 +1,96 @@
+package keeper
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	paramstypes "github.com/cosmos/cosmos-sdk/x/params/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+)
+
+func TestMigrate1to2(t *testing.T) {
+	db := dbm.NewMemDB()
+	keyWasm := sdk.NewKVStoreKey(types.StoreKey)
+	keyParams, tkeyParams := sdk.NewKVStoreKey(paramstypes.StoreKey), sdk.NewTransientStoreKey(paramstypes.TStoreKey)
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyWasm, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
+	require.NoError(t, ms.LoadLatestVersion())
+	ctx := sdk.NewContext(ms, tmproto.Header{}, false, log.NewNopLogger())
+	encodingConfig := MakeEncodingConfig(t)
+	paramSpace := paramstypes.NewSubspace(encodingConfig.Marshaler, encodingConfig.Amino, keyParams, tkeyParams, types.DefaultParamspace).
+		WithKeyTable(types.ParamKeyTable())
+	k := Keeper{storeKey: keyWasm, cdc: encodingConfig.Marshaler, paramSpace: paramSpace}
+
+	// the params of version 1
+	paramSpace.Set(ctx, types.ParamStoreKeyUploadAccess, types.AllowNobody)
+	paramSpace.Set(ctx, types.ParamStoreKeyInstantiateAccess, types.AccessTypeNobody)
+	paramSpace.Set(ctx, types.ParamStoreKeyMaxWasmCodeSize, uint64(1))
+	require.Panics(t, func() { k.GetParams(ctx) })
+	assert.Equal(t, uint64(types.DefaultIBCPacketGasLimit), k.GetIBCPacketGasLimit(ctx))
+
+	// when
+	require.NoError(t, NewMigrator(k).Migrate1to2(ctx))
+
+	// then
+	for _, key := range paramsAddedInV2 {
+		assert.True(t, paramSpace.Has(ctx, key), string(key))
+	}
+	exp := types.DefaultParams()
+	exp.CodeUploadAccess, exp.InstantiateDefaultPermission, exp.MaxWasmCodeSize = types.AllowNobody, types.AccessTypeNobody, 1
+	// limits that would change the behavior of existing contracts are disabled
+	exp.IBCPacketGasLimit, exp.MaxResultDataSize = 0, 0
+	assert.Equal(t, exp, k.GetParams(ctx))
+	assert.Equal(t, uint64(0), k.GetIBCPacketGasLimit(ctx))
+	assert.True(t, k.isExecuteAllowlisted(ctx, RandomAccountAddress(t)))
+	assert.Equal(t, uint64(0), k.GetMaxContractGas(ctx))
+	assert.Equal(t, uint64(0), k.GetMaxResultDataSize(ctx))
+
+	// and params set before are not modified
+	paramSpace.Set(ctx, types.ParamStoreKeyIBCPacketGasLimit, uint64(1))
+	require.NoError(t, NewMigrator(k).Migrate1to2(ctx))
+	assert.Equal(t, uint64(1), k.GetIBCPacketGasLimit(ctx))
+}
+
+func TestMigrate1to2RecordsRequiredFeatures(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	k := keepers.WasmKeeper
+	creator := createFakeFundedAccount(t, ctx, keepers.AccountKeeper, keepers.BankKeeper, nil)
+	reflectCode, err := ioutil.ReadFile("./testdata/reflect.wasm")
+	require.NoError(t, err)
+	hackatomCode, err := ioutil.ReadFile("./testdata/hackatom.wasm")
+	require.NoError(t, err)
+	reflectCodeID, err := keepers.ContractKeeper.Create(ctx, creator, reflectCode, "", "", nil)
+	require.NoError(t, err)
+	hackatomCodeID, err := keepers.ContractKeeper.Create(ctx, creator, hackatomCode, "", "", nil)
+	require.NoError(t, err)
+	// codes stored before the features were recorded
+	for _, codeID := range []uint64{reflectCodeID, hackatomCodeID} {
+		codeInfo := k.GetCodeInfo(ctx, codeID)
+		codeInfo.RequiredFeatures = nil
+		k.storeCodeInfo(ctx, codeID, *codeInfo)
+	}
+	// and a code that is not in the wasm VM, like on a state synced node
+	missingCodeID := hackatomCodeID + 1
+	k.storeCodeInfo(ctx, missingCodeID, types.CodeInfoFixture())
+
+	// when
+	require.NoError(t, NewMigrator(*k).Migrate1to2(ctx))
+
+	// then
+	assert.Equal(t, []string{"staking", "stargate"}, k.GetCodeInfo(ctx, reflectCodeID).RequiredFeatures)
+	assert.Empty(t, k.GetCodeInfo(ctx, hackatomCodeID).RequiredFeatures)
+	assert.Empty(t, k.GetCodeInfo(ctx, missingCodeID).RequiredFeatures)
+	// and the legacy code can be recognized as bricked
+	delete(k.supportedFeatures, "stargate")
+	assert.True(t, k.isBrickedCode(*k.GetCodeInfo(ctx, reflectCodeID)))
+	assert.False(t, k.isBrickedCode(*k.GetCodeInfo(ctx, hackatomCodeID)))
+}
@@ -1,13 +1,28 @@
 package keeper
 
 import (
+	"errors"
+
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	"github.com/cosmos/cosmos-sdk/telemetry"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	channeltypes "github.com/cosmos/cosmos-sdk/x/ibc/core/04-channel/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	abci "github.com/tendermint/tendermint/abci/types"
 )
 
+// reasons for failed message dispatches in the telemetry metrics
+const (
+	dispatchFailureUnknownMsg        = "unknown_msg"
+	dispatchFailureInsufficientFunds = "insufficient_funds"
+	dispatchFailureChannelNotFound   = "channel_not_found"
+	dispatchFailureStaking           = "staking"
+	dispatchFailureOutOfGas          = "out_of_gas"
+	dispatchFailureOther             = "other"
+)
+
 // Messenger is an extension point for custom wasmd message handling
 type Messenger interface {
 	// DispatchMsg encodes the wasmVM message and dispatches it.
@@ -17,6 +32,9 @@ type Messenger interface {
 // replyer is a subset of keeper that can handle replies to submessages
 type replyer interface {
 	reply(ctx sdk.Context, contractAddress sdk.AccAddress, reply wasmvmtypes.Reply) ([]byte, error)
+	// storePacketReply persists a reference to the submessage that sent the IBC packet so that the packet result
+	// can be returned to the contract via reply, later
+	storePacketReply(ctx sdk.Context, portID, channelID string, sequence uint64, reply types.PacketReply)
 }
 
 // MessageDispatcher coordinates message sending and submessage reply/ state commits
@@ -30,16 +48,22 @@ func NewMessageDispatcher(messenger Messenger, keeper replyer) *MessageDispatche
 	return &MessageDispatcher{messenger: messenger, keeper: keeper}
 }
 
-// DispatchMessages sends all messages.
+// DispatchMessages sends all messages. The messages are executed in a sandbox so that state changes are only
+// committed when all of them succeed. On failure, the position of the failed message is added to the error.
 func (d MessageDispatcher) DispatchMessages(ctx sdk.Context, contractAddr sdk.AccAddress, ibcPort string, msgs []wasmvmtypes.CosmosMsg) error {
-	for _, msg := range msgs {
-		events, _, err := d.messenger.DispatchMsg(ctx, contractAddr, ibcPort, msg)
+	subCtx, commit := ctx.CacheContext()
+	em := sdk.NewEventManager()
+	for i, msg := range msgs {
+		events, _, err := d.messenger.DispatchMsg(subCtx.WithEventManager(em), contractAddr, ibcPort, msg)
 		if err != nil {
-			return err
+			countDispatchFailure(err)
+			return sdkerrors.Wrapf(err, "dispatch message %d of %d by contract %s", i+1, len(msgs), contractAddr)
 		}
 		// redispatch all events, (type sdk.EventTypeMessage will be filtered out in the handler)
-		ctx.EventManager().EmitEvents(events)
+		em.EmitEvents(events)
 	}
+	commit()
+	ctx.EventManager().EmitEvents(em.Events())
 	return nil
 }
 
@@ -82,6 +106,9 @@ func (d MessageDispatcher) DispatchSubmessages(ctx sdk.Context, contractAddr sdk
 		}
 		// first, we build a sub-context which we can use inside the submessages
 		subCtx, commit := ctx.CacheContext()
+		// collect the events emitted into the context, too, so that the reply gets all events of the submessage
+		em := sdk.NewEventManager()
+		subCtx = subCtx.WithEventManager(em)
 
 		// check how much gas left locally, optionally wrap the gas meter
 		gasRemaining := ctx.GasMeter().Limit() - ctx.GasMeter().GasConsumed()
@@ -95,11 +122,23 @@ func (d MessageDispatcher) DispatchSubmessages(ctx sdk.Context, contractAddr sdk
 		} else {
 			events, data, err = d.messenger.DispatchMsg(subCtx, contractAddr, ibcPort, msg.Msg)
 		}
+		if err != nil {
+			countDispatchFailure(err)
+		}
+		em.EmitEvents(events)
+		events = em.Events()
 
 		// if it succeeds, commit state changes from submessage, and pass on events to Event Manager
 		if err == nil {
 			commit()
 			ctx.EventManager().EmitEvents(events)
+			if sequence, ok := sentPacketSequence(msg.Msg, data); ok {
+				d.keeper.storePacketReply(ctx, ibcPort, msg.Msg.IBC.SendPacket.ChannelID, sequence, types.PacketReply{
+					ContractAddress: contractAddr.String(),
+					ReplyID:         msg.ID,
+					ReplyOn:         string(msg.ReplyOn),
+				})
+			}
 		}
 		// on failure, revert state from sandbox, and ignore events (just skip doing the above)
 
@@ -151,6 +190,43 @@ func (d MessageDispatcher) DispatchSubmessages(ctx sdk.Context, contractAddr sdk
 	return rsp, nil
 }
 
+// countDispatchFailure increments the telemetry counter for the reason of the failed message dispatch
+func countDispatchFailure(err error) {
+	telemetry.IncrCounter(1, "wasm", "msg", "dispatch_failed", dispatchFailureReason(err))
+}
+
+// dispatchFailureReason returns the category of a message dispatch error
+func dispatchFailureReason(err error) string {
+	switch {
+	case errors.Is(err, types.ErrUnknownMsg):
+		return dispatchFailureUnknownMsg
+	case errors.Is(err, sdkerrors.ErrInsufficientFunds):
+		return dispatchFailureInsufficientFunds
+	case errors.Is(err, channeltypes.ErrChannelNotFound),
+		errors.Is(err, channeltypes.ErrSequenceSendNotFound),
+		errors.Is(err, channeltypes.ErrChannelCapabilityNotFound):
+		return dispatchFailureChannelNotFound
+	case errors.Is(err, sdkerrors.ErrOutOfGas):
+		return dispatchFailureOutOfGas
+	}
+	if codespace, _, _ := sdkerrors.ABCIInfo(err, false); codespace == stakingtypes.ModuleName {
+		return dispatchFailureStaking
+	}
+	return dispatchFailureOther
+}
+
+// sentPacketSequence returns the packet sequence for a successfully dispatched IBC send packet message
+func sentPacketSequence(msg wasmvmtypes.CosmosMsg, data [][]byte) (uint64, bool) {
+	if msg.IBC == nil || msg.IBC.SendPacket == nil || len(data) == 0 {
+		return 0, false
+	}
+	var rsp types.MsgIBCSendResponse
+	if err := rsp.Unmarshal(data[0]); err != nil || rsp.Sequence == 0 {
+		return 0, false
+	}
+	return rsp.Sequence, true
+}
+
 func sdkEventsToWasmVmEvents(events []sdk.Event) []wasmvmtypes.Event {
 	res := make([]wasmvmtypes.Event, len(events))
 	for i, ev := range events {
@@ -4,8 +4,12 @@ import (
 	"errors"
 	"fmt"
 	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
 	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	channeltypes "github.com/cosmos/cosmos-sdk/x/ibc/core/04-channel/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -202,6 +206,46 @@ func TestDispatchSubmessages(t *testing.T) {
 			expData:    []byte{},
 			expCommits: []bool{false, false},
 		},
+		"reply gets all events of the submessage": {
+			msgs: []wasmvmtypes.SubMsg{{ReplyOn: wasmvmtypes.ReplySuccess}},
+			replyer: &mockReplyer{
+				replyFn: func(ctx sdk.Context, contractAddress sdk.AccAddress, reply wasmvmtypes.Reply) ([]byte, error) {
+					require.NotNil(t, reply.Result.Ok)
+					exp := wasmvmtypes.Events{
+						{Type: "myEmittedEvent", Attributes: []wasmvmtypes.EventAttribute{{Key: "foo", Value: "bar"}}},
+						{Type: "myReturnedEvent", Attributes: []wasmvmtypes.EventAttribute{{Key: "foo", Value: "baz"}}},
+					}
+					assert.Equal(t, exp, reply.Result.Ok.Events)
+					return nil, nil
+				},
+			},
+			msgHandler: &wasmtesting.MockMessageHandler{
+				DispatchMsgFn: func(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, err error) {
+					ctx.EventManager().EmitEvent(sdk.NewEvent("myEmittedEvent", sdk.NewAttribute("foo", "bar")))
+					return []sdk.Event{sdk.NewEvent("myReturnedEvent", sdk.NewAttribute("foo", "baz"))}, nil, nil
+				},
+			},
+			expCommits: []bool{true},
+			expEvents: []sdk.Event{
+				sdk.NewEvent("myEmittedEvent", sdk.NewAttribute("foo", "bar")),
+				sdk.NewEvent("myReturnedEvent", sdk.NewAttribute("foo", "baz")),
+			},
+		},
+		"events emitted by a failed submessage are dropped": {
+			msgs: []wasmvmtypes.SubMsg{{ReplyOn: wasmvmtypes.ReplyError}},
+			replyer: &mockReplyer{
+				replyFn: func(ctx sdk.Context, contractAddress sdk.AccAddress, reply wasmvmtypes.Reply) ([]byte, error) {
+					return nil, nil
+				},
+			},
+			msgHandler: &wasmtesting.MockMessageHandler{
+				DispatchMsgFn: func(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, err error) {
+					ctx.EventManager().EmitEvent(sdk.NewEvent("myEmittedEvent", sdk.NewAttribute("foo", "bar")))
+					return nil, nil, errors.New("my error")
+				},
+			},
+			expCommits: []bool{false},
+		},
 		"empty replyOn rejected": {
 			msgs:       []wasmvmtypes.SubMsg{{}},
 			replyer:    noReplyCalled,
@@ -242,8 +286,81 @@ func TestDispatchSubmessages(t *testing.T) {
 	}
 }
 
+func TestDispatchMessages(t *testing.T) {
+	myEvent := sdk.Event{Type: "myEvent", Attributes: []abci.EventAttribute{{Key: []byte("foo"), Value: []byte("bar")}}}
+	okHandler := func(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, err error) {
+		return sdk.Events{myEvent}, nil, nil
+	}
+	specs := map[string]struct {
+		msgs       []wasmvmtypes.CosmosMsg
+		msgHandler *wasmtesting.MockMessageHandler
+		expErr     string
+		expCommits []bool
+		expEvents  sdk.Events
+	}{
+		"all messages succeed": {
+			msgs:       []wasmvmtypes.CosmosMsg{{}, {}},
+			msgHandler: &wasmtesting.MockMessageHandler{DispatchMsgFn: okHandler},
+			expCommits: []bool{true},
+			expEvents:  sdk.Events{myEvent, myEvent},
+		},
+		"no messages": {
+			msgHandler: &wasmtesting.MockMessageHandler{},
+			expCommits: []bool{true},
+		},
+		"later message fails": {
+			msgs: []wasmvmtypes.CosmosMsg{{}, {}, {}},
+			msgHandler: &wasmtesting.MockMessageHandler{
+				DispatchMsgFn: func(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, err error) {
+					if len(ctx.EventManager().Events()) == 2 {
+						return nil, nil, errors.New("testing")
+					}
+					return okHandler(ctx, contractAddr, contractIBCPortID, msg)
+				},
+			},
+			expErr:     "dispatch message 3 of 3",
+			expCommits: []bool{false},
+		},
+		"first message fails": {
+			msgs: []wasmvmtypes.CosmosMsg{{}, {}},
+			msgHandler: &wasmtesting.MockMessageHandler{
+				DispatchMsgFn: func(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, err error) {
+					return nil, nil, errors.New("testing")
+				},
+			},
+			expErr:     "dispatch message 1 of 2",
+			expCommits: []bool{false},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var mockStore wasmtesting.MockCommitMultiStore
+			em := sdk.NewEventManager()
+			ctx := sdk.Context{}.WithMultiStore(&mockStore).
+				WithGasMeter(sdk.NewGasMeter(100)).
+				WithEventManager(em)
+			d := NewMessageDispatcher(spec.msgHandler, &mockReplyer{})
+			gotErr := d.DispatchMessages(ctx, RandomAccountAddress(t), "any_port", spec.msgs)
+			if spec.expErr != "" {
+				require.Error(t, gotErr)
+				assert.Contains(t, gotErr.Error(), spec.expErr)
+				assert.Contains(t, gotErr.Error(), "testing")
+			} else {
+				require.NoError(t, gotErr)
+			}
+			assert.Equal(t, spec.expCommits, mockStore.Committed)
+			if len(spec.expEvents) == 0 {
+				assert.Empty(t, em.Events())
+			} else {
+				assert.Equal(t, spec.expEvents, em.Events())
+			}
+		})
+	}
+}
+
 type mockReplyer struct {
-	replyFn func(ctx sdk.Context, contractAddress sdk.AccAddress, reply wasmvmtypes.Reply) ([]byte, error)
+	replyFn            func(ctx sdk.Context, contractAddress sdk.AccAddress, reply wasmvmtypes.Reply) ([]byte, error)
+	storePacketReplyFn func(ctx sdk.Context, portID, channelID string, sequence uint64, reply types.PacketReply)
 }
 
 func (m mockReplyer) reply(ctx sdk.Context, contractAddress sdk.AccAddress, reply wasmvmtypes.Reply) ([]byte, error) {
@@ -252,3 +369,119 @@ func (m mockReplyer) reply(ctx sdk.Context, contractAddress sdk.AccAddress, repl
 	}
 	return m.replyFn(ctx, contractAddress, reply)
 }
+
+func (m mockReplyer) storePacketReply(ctx sdk.Context, portID, channelID string, sequence uint64, reply types.PacketReply) {
+	if m.storePacketReplyFn == nil {
+		panic("not expected to be called")
+	}
+	m.storePacketReplyFn(ctx, portID, channelID, sequence, reply)
+}
+
+func TestDispatchSubmessagesStoresPacketReply(t *testing.T) {
+	myContractAddr := RandomAccountAddress(t)
+	myIBCSendRsp, err := (&types.MsgIBCSendResponse{Sequence: 7}).Marshal()
+	require.NoError(t, err)
+	ibcSendMsg := wasmvmtypes.CosmosMsg{IBC: &wasmvmtypes.IBCMsg{SendPacket: &wasmvmtypes.SendPacketMsg{ChannelID: "channel-1"}}}
+	specs := map[string]struct {
+		msg      wasmvmtypes.SubMsg
+		data     [][]byte
+		err      error
+		expStore bool
+	}{
+		"ibc send packet": {
+			msg:      wasmvmtypes.SubMsg{ID: 1, Msg: ibcSendMsg, ReplyOn: wasmvmtypes.ReplyAlways},
+			data:     [][]byte{myIBCSendRsp},
+			expStore: true,
+		},
+		"ibc send packet failed": {
+			msg: wasmvmtypes.SubMsg{ID: 1, Msg: ibcSendMsg, ReplyOn: wasmvmtypes.ReplyAlways},
+			err: errors.New("testing"),
+		},
+		"ibc send packet without sequence returned": {
+			msg: wasmvmtypes.SubMsg{ID: 1, Msg: ibcSendMsg, ReplyOn: wasmvmtypes.ReplyAlways},
+		},
+		"ibc send packet with invalid data returned": {
+			msg:  wasmvmtypes.SubMsg{ID: 1, Msg: ibcSendMsg, ReplyOn: wasmvmtypes.ReplyAlways},
+			data: [][]byte{[]byte("invalid")},
+		},
+		"other message": {
+			msg:  wasmvmtypes.SubMsg{ID: 1, Msg: wasmvmtypes.CosmosMsg{Bank: &wasmvmtypes.BankMsg{}}, ReplyOn: wasmvmtypes.ReplyAlways},
+			data: [][]byte{myIBCSendRsp},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var mockStore wasmtesting.MockCommitMultiStore
+			ctx := sdk.Context{}.WithMultiStore(&mockStore).
+				WithGasMeter(sdk.NewGasMeter(100)).
+				WithEventManager(sdk.NewEventManager())
+			var stored []types.PacketReply
+			replyer := &mockReplyer{
+				replyFn: func(ctx sdk.Context, contractAddress sdk.AccAddress, reply wasmvmtypes.Reply) ([]byte, error) {
+					return nil, nil
+				},
+				storePacketReplyFn: func(ctx sdk.Context, portID, channelID string, sequence uint64, reply types.PacketReply) {
+					assert.Equal(t, "my_port", portID)
+					assert.Equal(t, "channel-1", channelID)
+					assert.Equal(t, uint64(7), sequence)
+					stored = append(stored, reply)
+				},
+			}
+			msgHandler := &wasmtesting.MockMessageHandler{
+				DispatchMsgFn: func(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, err error) {
+					return nil, spec.data, spec.err
+				},
+			}
+			d := NewMessageDispatcher(msgHandler, replyer)
+			_, gotErr := d.DispatchSubmessages(ctx, myContractAddr, "my_port", []wasmvmtypes.SubMsg{spec.msg})
+			require.NoError(t, gotErr)
+			if !spec.expStore {
+				assert.Empty(t, stored)
+				return
+			}
+			exp := []types.PacketReply{{ContractAddress: myContractAddr.String(), ReplyID: 1, ReplyOn: "always"}}
+			assert.Equal(t, exp, stored)
+		})
+	}
+}
+
+func TestDispatchFailureReason(t *testing.T) {
+	specs := map[string]struct {
+		src error
+		exp string
+	}{
+		"unknown msg": {
+			src: sdkerrors.Wrap(types.ErrUnknownMsg, "no handler found"),
+			exp: dispatchFailureUnknownMsg,
+		},
+		"insufficient funds": {
+			src: sdkerrors.Wrap(sdkerrors.ErrInsufficientFunds, "1stake is smaller than 2stake"),
+			exp: dispatchFailureInsufficientFunds,
+		},
+		"channel not found": {
+			src: sdkerrors.Wrap(channeltypes.ErrChannelNotFound, "channel-0"),
+			exp: dispatchFailureChannelNotFound,
+		},
+		"send sequence not found": {
+			src: sdkerrors.Wrap(channeltypes.ErrSequenceSendNotFound, "channel-0"),
+			exp: dispatchFailureChannelNotFound,
+		},
+		"staking error": {
+			src: sdkerrors.Wrap(stakingtypes.ErrNoValidatorFound, "validator"),
+			exp: dispatchFailureStaking,
+		},
+		"out of gas": {
+			src: sdkerrors.Wrap(sdkerrors.ErrOutOfGas, "SubMsg hit gas limit"),
+			exp: dispatchFailureOutOfGas,
+		},
+		"other": {
+			src: errors.New("testing"),
+			exp: dispatchFailureOther,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, spec.exp, dispatchFailureReason(spec.src))
+		})
+	}
+}
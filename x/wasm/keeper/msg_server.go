@@ -4,9 +4,13 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
+	"strconv"
+	"strings"
+
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	clienttypes "github.com/cosmos/cosmos-sdk/x/ibc/core/02-client/types"
 )
 
 var _ types.MsgServer = msgServer{}
@@ -25,6 +29,11 @@ func (m msgServer) StoreCode(goCtx context.Context, msg *types.MsgStoreCode) (*t
 	if err != nil {
 		return nil, sdkerrors.Wrap(err, "sender")
 	}
+	if k, ok := m.keeper.(types.StoreCodeDepositOpsKeeper); ok {
+		if err := k.CollectStoreCodeDeposit(ctx, senderAddr); err != nil {
+			return nil, err
+		}
+	}
 	codeID, err := m.keeper.Create(ctx, senderAddr, msg.WASMByteCode, msg.Source, msg.Builder, msg.InstantiatePermission)
 	if err != nil {
 		return nil, err
@@ -50,7 +59,10 @@ func (m msgServer) InstantiateContract(goCtx context.Context, msg *types.MsgInst
 		return nil, sdkerrors.Wrap(err, "sender")
 	}
 	var adminAddr sdk.AccAddress
-	if msg.Admin != "" {
+	switch {
+	case msg.AdminIsSender:
+		adminAddr = senderAddr
+	case msg.Admin != "":
 		if adminAddr, err = sdk.AccAddressFromBech32(msg.Admin); err != nil {
 			return nil, sdkerrors.Wrap(err, "admin")
 		}
@@ -68,6 +80,11 @@ func (m msgServer) InstantiateContract(goCtx context.Context, msg *types.MsgInst
 		sdk.NewAttribute(types.AttributeKeyCodeID, fmt.Sprintf("%d", msg.CodeID)),
 		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String()),
 		sdk.NewAttribute(types.AttributeResultDataHex, hex.EncodeToString(data)),
+		sdk.NewAttribute(types.AttributeKeyLabel, msg.Label),
+		// empty when the contract has no admin
+		sdk.NewAttribute(types.AttributeKeyAdmin, adminAddr.String()),
+		// a contract without admin can never be migrated
+		sdk.NewAttribute(types.AttributeKeyImmutable, strconv.FormatBool(adminAddr.Empty())),
 	))
 
 	return &types.MsgInstantiateContractResponse{
@@ -188,3 +205,157 @@ func (m msgServer) ClearAdmin(goCtx context.Context, msg *types.MsgClearAdmin) (
 
 	return &types.MsgClearAdminResponse{}, nil
 }
+
+func (m msgServer) UpdateDeniedDenoms(goCtx context.Context, msg *types.MsgUpdateDeniedDenoms) (*types.MsgUpdateDeniedDenomsResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "sender")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(msg.Contract)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "contract")
+	}
+
+	k, ok := m.keeper.(types.DeniedDenomsOpsKeeper)
+	if !ok {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "denied denoms not supported")
+	}
+	if err := k.UpdateDeniedDenoms(ctx, contractAddr, senderAddr, msg.Denoms); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(types.AttributeKeySigner, msg.Sender),
+		sdk.NewAttribute(types.AttributeKeyContractAddr, msg.Contract),
+		sdk.NewAttribute(types.AttributeKeyDeniedDenoms, strings.Join(msg.Denoms, ",")),
+	))
+
+	return &types.MsgUpdateDeniedDenomsResponse{}, nil
+}
+
+func (m msgServer) UpdateReentrancyGuard(goCtx context.Context, msg *types.MsgUpdateReentrancyGuard) (*types.MsgUpdateReentrancyGuardResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "sender")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(msg.Contract)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "contract")
+	}
+
+	k, ok := m.keeper.(types.ReentrancyGuardOpsKeeper)
+	if !ok {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "reentrancy guard not supported")
+	}
+	if err := k.UpdateReentrancyGuard(ctx, contractAddr, senderAddr, msg.Enabled); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(types.AttributeKeySigner, msg.Sender),
+		sdk.NewAttribute(types.AttributeKeyContractAddr, msg.Contract),
+		sdk.NewAttribute(types.AttributeKeyEnabled, strconv.FormatBool(msg.Enabled)),
+	))
+
+	return &types.MsgUpdateReentrancyGuardResponse{}, nil
+}
+
+func (m msgServer) RegisterInterchainQuery(goCtx context.Context, msg *types.MsgRegisterInterchainQuery) (*types.MsgRegisterInterchainQueryResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "sender")
+	}
+
+	k, ok := m.keeper.(types.InterchainQueryOpsKeeper)
+	if !ok {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "interchain queries not supported")
+	}
+	queryID, err := k.RegisterInterchainQuery(ctx, senderAddr, msg.ConnectionID, msg.Keys, msg.UpdatePeriod)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(types.AttributeKeySigner, msg.Sender),
+	))
+
+	return &types.MsgRegisterInterchainQueryResponse{QueryID: queryID}, nil
+}
+
+func (m msgServer) RemoveInterchainQuery(goCtx context.Context, msg *types.MsgRemoveInterchainQuery) (*types.MsgRemoveInterchainQueryResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "sender")
+	}
+
+	k, ok := m.keeper.(types.InterchainQueryOpsKeeper)
+	if !ok {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "interchain queries not supported")
+	}
+	if err := k.RemoveInterchainQuery(ctx, senderAddr, msg.QueryID); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(types.AttributeKeySigner, msg.Sender),
+	))
+
+	return &types.MsgRemoveInterchainQueryResponse{}, nil
+}
+
+func (m msgServer) SubmitInterchainQueryResult(goCtx context.Context, msg *types.MsgSubmitInterchainQueryResult) (*types.MsgSubmitInterchainQueryResultResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	k, ok := m.keeper.(types.InterchainQueryOpsKeeper)
+	if !ok {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "interchain queries not supported")
+	}
+	height := clienttypes.NewHeight(msg.RevisionNumber, msg.RevisionHeight)
+	if err := k.SubmitInterchainQueryResult(ctx, msg.QueryID, height, msg.Results); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(types.AttributeKeySigner, msg.Sender),
+	))
+
+	return &types.MsgSubmitInterchainQueryResultResponse{}, nil
+}
+
+func (m msgServer) ReopenChannel(goCtx context.Context, msg *types.MsgReopenChannel) (*types.MsgReopenChannelResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "sender")
+	}
+
+	k, ok := m.keeper.(types.ChannelReopenOpsKeeper)
+	if !ok {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "channel reopen not supported")
+	}
+	channelID, err := k.ReopenChannel(ctx, senderAddr, msg.ChannelID)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(types.AttributeKeySigner, msg.Sender),
+	))
+
+	return &types.MsgReopenChannelResponse{ChannelID: channelID}, nil
+}
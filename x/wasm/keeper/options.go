@@ -3,7 +3,9 @@ package keeper
 import (
 	"fmt"
 	"github.com/CosmWasm/wasmd/x/wasm/types"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	"github.com/prometheus/client_golang/prometheus"
+	"time"
 )
 
 type optsFn func(*Keeper)
@@ -20,6 +22,14 @@ func WithWasmEngine(x types.WasmerEngine) Option {
 	})
 }
 
+// WithWasmEngineDecorator is an optional constructor parameter to decorate the default wasmVM engine.
+// This can be used to instrument or trace contract calls.
+func WithWasmEngineDecorator(d func(old types.WasmerEngine) types.WasmerEngine) Option {
+	return optsFn(func(k *Keeper) {
+		k.wasmVM = d(k.wasmVM)
+	})
+}
+
 // WithMessageHandler is an optional constructor parameter to set a custom handler for wasmVM messages.
 // This option should not be combined with Option `WithMessageEncoders`.
 func WithMessageHandler(x Messenger) Option {
@@ -28,6 +38,15 @@ func WithMessageHandler(x Messenger) Option {
 	})
 }
 
+// WithMessageHandlerDecorator is an optional constructor parameter to decorate the wasm handler for wasmVM messages.
+// This option should not be combined with Option `WithMessageEncoders` or `WithICS4Wrapper` that expect the
+// `DefaultMessageHandler` set.
+func WithMessageHandlerDecorator(d func(old Messenger) Messenger) Option {
+	return optsFn(func(k *Keeper) {
+		k.messenger = d(k.messenger)
+	})
+}
+
 // WithQueryHandler is an optional constructor parameter to set custom query handler for wasmVM requests.
 // This option should not be combined with Option `WithQueryPlugins`.
 func WithQueryHandler(x WasmVMQueryHandler) Option {
@@ -52,20 +71,203 @@ func WithQueryPlugins(x *QueryPlugins) Option {
 // This option expects the `DefaultMessageHandler` set an should not be combined with Option `WithMessageHandler`.
 func WithMessageEncoders(x *MessageEncoders) Option {
 	return optsFn(func(k *Keeper) {
-		q, ok := k.messenger.(*MessageHandlerChain)
+		updateMessageEncoders(k, func(e MessageEncoders) MessageEncoders {
+			return e.Merge(x)
+		})
+	})
+}
+
+// WithTokenFactory is an optional constructor parameter to expose an embedded token factory module to contracts via
+// custom messages and queries. Other custom messages and queries are passed to the previous custom encoder and querier.
+// This option expects the `DefaultMessageHandler` and default `QueryHandler` set and should not be combined with
+// Option `WithMessageHandler` or `WithQueryHandler`.
+func WithTokenFactory(x TokenFactory) Option {
+	return optsFn(func(k *Keeper) {
+		updateMessageEncoders(k, func(e MessageEncoders) MessageEncoders {
+			e.Custom = NewTokenFactoryEncoder(x, e.Custom)
+			return e
+		})
+		q, ok := k.wasmVMQueryHandler.(QueryPlugins)
 		if !ok {
-			panic(fmt.Sprintf("Unsupported message handler type: %T", k.messenger))
+			panic(fmt.Sprintf("Unsupported query handler type: %T", k.wasmVMQueryHandler))
 		}
-		s, ok := q.handlers[0].(SDKMessageHandler)
+		q.Custom = NewTokenFactoryQuerier(x, q.Custom)
+		k.wasmVMQueryHandler = q
+	})
+}
+
+// WithCallOriginQuerier is an optional constructor parameter to let contracts query the call origin via the
+// `call_origin` custom query. Other custom queries are passed to the previous custom querier.
+// This option expects the default `QueryHandler` set and should not be combined with Option `WithQueryHandler`.
+func WithCallOriginQuerier() Option {
+	return optsFn(func(k *Keeper) {
+		q, ok := k.wasmVMQueryHandler.(QueryPlugins)
+		if !ok {
+			panic(fmt.Sprintf("Unsupported query handler type: %T", k.wasmVMQueryHandler))
+		}
+		q.Custom = NewCallOriginQuerier(q.Custom)
+		k.wasmVMQueryHandler = q
+	})
+}
+
+// WithContractInfoQuerier is an optional constructor parameter to let contracts query the code id, creator, admin,
+// pinned status and IBC port of any contract via the `contract_info` custom query. Other custom queries are passed to
+// the previous custom querier.
+// This option expects the default `QueryHandler` set and should not be combined with Option `WithQueryHandler`.
+func WithContractInfoQuerier() Option {
+	return optsFn(func(k *Keeper) {
+		q, ok := k.wasmVMQueryHandler.(QueryPlugins)
 		if !ok {
-			panic(fmt.Sprintf("Unexpected message handler type: %T", q.handlers[0]))
+			panic(fmt.Sprintf("Unsupported query handler type: %T", k.wasmVMQueryHandler))
+		}
+		q.Custom = NewContractInfoQuerier(k, q.Custom)
+		k.wasmVMQueryHandler = q
+	})
+}
+
+// WithCodeInfoQuerier is an optional constructor parameter to let contracts query the checksum and creator of a code
+// via the `code_info` custom query. Other custom queries are passed to the previous custom querier.
+// This option expects the default `QueryHandler` set and should not be combined with Option `WithQueryHandler`.
+func WithCodeInfoQuerier() Option {
+	return optsFn(func(k *Keeper) {
+		q, ok := k.wasmVMQueryHandler.(QueryPlugins)
+		if !ok {
+			panic(fmt.Sprintf("Unsupported query handler type: %T", k.wasmVMQueryHandler))
+		}
+		q.Custom = NewCodeInfoQuerier(k, q.Custom)
+		k.wasmVMQueryHandler = q
+	})
+}
+
+// WithPacketForwardQuerier is an optional constructor parameter to let contracts query the sender and the packet
+// forward middleware route of a received packet via the `packet_forward` custom query. Other custom queries are passed
+// to the previous custom querier.
+// This option expects the default `QueryHandler` set and should not be combined with Option `WithQueryHandler`.
+func WithPacketForwardQuerier() Option {
+	return optsFn(func(k *Keeper) {
+		q, ok := k.wasmVMQueryHandler.(QueryPlugins)
+		if !ok {
+			panic(fmt.Sprintf("Unsupported query handler type: %T", k.wasmVMQueryHandler))
+		}
+		q.Custom = NewPacketForwardQuerier(q.Custom)
+		k.wasmVMQueryHandler = q
+	})
+}
+
+// WithProofVerificationQuerier is an optional constructor parameter to let contracts verify ICS-23 proofs of
+// counterparty chain state via the `verify_membership` and `verify_non_membership` custom queries. The IBC client
+// keeper is a ClientConsensusStateSource. Other custom queries are passed to the previous custom querier.
+// This option expects the default `QueryHandler` set and should not be combined with Option `WithQueryHandler`.
+func WithProofVerificationQuerier(x ClientConsensusStateSource) Option {
+	return optsFn(func(k *Keeper) {
+		q, ok := k.wasmVMQueryHandler.(QueryPlugins)
+		if !ok {
+			panic(fmt.Sprintf("Unsupported query handler type: %T", k.wasmVMQueryHandler))
+		}
+		q.Custom = NewProofVerificationQuerier(x, q.Custom)
+		k.wasmVMQueryHandler = q
+	})
+}
+
+// WithCustomExtensions is an optional constructor parameter to register the custom contract messages and queries of
+// chain modules in one call. The sdk message types of the extensions are registered with the interface registry of
+// the keeper codec. Custom messages and queries that are not addressed to an extension are passed to the previous
+// custom encoder and querier.
+// This option expects the `DefaultMessageHandler` and default `QueryHandler` set and should not be combined with
+// Option `WithMessageHandler` or `WithQueryHandler`.
+func WithCustomExtensions(exts ...CustomExtension) Option {
+	return optsFn(func(k *Keeper) {
+		names := make(map[string]struct{}, len(exts))
+		for _, ext := range exts {
+			if ext.Name == "" {
+				panic("custom extension name must not be empty")
+			}
+			if _, exists := names[ext.Name]; exists {
+				panic(fmt.Sprintf("duplicate custom extension: %s", ext.Name))
+			}
+			names[ext.Name] = struct{}{}
+		}
+		for _, ext := range exts {
+			if ext.RegisterInterfaces == nil {
+				continue
+			}
+			c, ok := k.cdc.(interface {
+				InterfaceRegistry() codectypes.InterfaceRegistry
+			})
+			if !ok {
+				panic(fmt.Sprintf("Unsupported codec type: %T", k.cdc))
+			}
+			ext.RegisterInterfaces(c.InterfaceRegistry())
+		}
+		updateMessageEncoders(k, func(e MessageEncoders) MessageEncoders {
+			for _, ext := range exts {
+				e.Custom = NewCustomExtensionEncoder(ext, e.Custom)
+			}
+			return e
+		})
+		q, ok := k.wasmVMQueryHandler.(QueryPlugins)
+		if !ok {
+			panic(fmt.Sprintf("Unsupported query handler type: %T", k.wasmVMQueryHandler))
+		}
+		for _, ext := range exts {
+			q.Custom = NewCustomExtensionQuerier(ext, q.Custom)
+		}
+		k.wasmVMQueryHandler = q
+	})
+}
+
+// updateMessageEncoders replaces the encoders of the SDKMessageHandler in the default message handler chain
+func updateMessageEncoders(k *Keeper, f func(MessageEncoders) MessageEncoders) {
+	q, ok := k.messenger.(*MessageHandlerChain)
+	if !ok {
+		panic(fmt.Sprintf("Unsupported message handler type: %T", k.messenger))
+	}
+	for i, h := range q.handlers {
+		s, ok := h.(SDKMessageHandler)
+		if !ok {
+			continue
 		}
 		e, ok := s.encoders.(MessageEncoders)
 		if !ok {
 			panic(fmt.Sprintf("Unsupported encoder type: %T", s.encoders))
 		}
-		s.encoders = e.Merge(x)
-		q.handlers[0] = s
+		s.encoders = f(e)
+		q.handlers[i] = s
+		return
+	}
+	panic("SDKMessageHandler not found in message handler chain")
+}
+
+// WithICS4Wrapper is an optional constructor parameter to send IBC packets via the given ICS4Wrapper instead of the
+// channel keeper. This allows to compose wasm contract ports in an IBC middleware stack, for example with rate
+// limiting or fee modules, that must also see the outgoing packets.
+// This option expects the `DefaultMessageHandler` set an should not be combined with Option `WithMessageHandler`.
+func WithICS4Wrapper(x types.ICS4Wrapper) Option {
+	return optsFn(func(k *Keeper) {
+		q, ok := k.messenger.(*MessageHandlerChain)
+		if !ok {
+			panic(fmt.Sprintf("Unsupported message handler type: %T", k.messenger))
+		}
+		for _, h := range q.handlers {
+			if p, ok := h.(*IBCRawPacketHandler); ok {
+				p.ics4Wrapper = x
+				return
+			}
+		}
+		panic("IBCRawPacketHandler not found in message handler chain")
+	})
+}
+
+// WithDenomBridge is an optional constructor parameter to let the designated CW20 contracts mint and burn their
+// native bank denom via custom messages. The wasm module account requires the minter and burner permissions.
+// This option expects the `DefaultMessageHandler` set an should not be combined with Option `WithMessageHandler`.
+func WithDenomBridge(bankKeeper DenomBridgeBankKeeper, hooks DenomBridgeHooks) Option {
+	return optsFn(func(k *Keeper) {
+		q, ok := k.messenger.(*MessageHandlerChain)
+		if !ok {
+			panic(fmt.Sprintf("Unsupported message handler type: %T", k.messenger))
+		}
+		q.handlers = append([]Messenger{NewDenomBridgeMessageHandler(bankKeeper, hooks)}, q.handlers...)
 	})
 }
 
@@ -79,6 +281,9 @@ func WithCoinTransferrer(x CoinTransferrer) Option {
 func WithVMCacheMetrics(r prometheus.Registerer) Option {
 	return optsFn(func(k *Keeper) {
 		NewWasmVMMetricsCollector(k.wasmVM).Register(r)
+		if s, ok := k.wasmVM.(shardMetricSource); ok {
+			NewWasmVMShardMetricsCollector(s).Register(r)
+		}
 	})
 }
 
@@ -91,6 +296,144 @@ func WithGasRegister(x GasRegister) Option {
 	})
 }
 
+// WithSubQueryGasLimit limits the gas that a query from a contract to another contract or a module can consume to
+// the given percentage of the calling contract's remaining gas. With a value below 100 a sub-query can not use
+// the whole budget of the transaction and the budget shrinks with every level of nested queries.
+func WithSubQueryGasLimit(percent uint64) Option {
+	return optsFn(func(k *Keeper) {
+		if percent == 0 || percent > 100 {
+			panic(fmt.Sprintf("invalid sub-query gas percentage: %d", percent))
+		}
+		k.subQueryGasPercent = percent
+	})
+}
+
+// WithClientFreezeNotifications enables the notification of the contracts via sudo when the light client of one of
+// their channels was frozen for misbehaviour, see NotifyFrozenClients. The channels are checked in the end blocker so
+// that the wasm module must be in the end blocker order of the app. The IBC channel keeper is a ChannelClientStateSource,
+// see NewIBCContractChannelSource for the channels.
+func WithClientFreezeNotifications(channels ContractChannelSource, clients ChannelClientStateSource) Option {
+	return optsFn(func(k *Keeper) {
+		k.contractChannels = channels
+		k.channelClients = clients
+	})
+}
+
+// WithPortChannelSource sets the source for the channels of a contract port so that the open channels of the contract
+// info query are read without iterating the channels of all ports. See NewIBCPortChannelSource.
+func WithPortChannelSource(x PortChannelSource) Option {
+	return optsFn(func(k *Keeper) {
+		k.portChannels = x
+	})
+}
+
+// WithChannelTimeoutCloseNotifications enables the notification of the contracts via sudo when one of their ordered
+// channels was closed by a packet timeout, see NotifyTimeoutClosedChannels. The channels are closed by IBC core after
+// the timeout callback of the contract so that the notification is sent in the end blocker. The wasm module must be in
+// the end blocker order of the app.
+func WithChannelTimeoutCloseNotifications() Option {
+	return optsFn(func(k *Keeper) {
+		k.notifyTimeoutClosedChannels = true
+	})
+}
+
+// WithInterchainQueries enables the interchain queries of the contracts. A contract registers a periodic query of
+// keys in the module stores of a counterparty chain with a MsgRegisterInterchainQuery stargate message. Relayers
+// submit the results with proofs that are verified against the consensus state of the IBC client of the connection,
+// and the values are passed to the contract via sudo. The IBC client keeper is a ClientConsensusStateSource and the IBC
+// connection keeper a ConnectionSource.
+func WithInterchainQueries(clients ClientConsensusStateSource, connections ConnectionSource) Option {
+	return optsFn(func(k *Keeper) {
+		k.interchainQueryClients = clients
+		k.interchainQueryConnections = connections
+	})
+}
+
+// WithJobGasLimit sets the gas budget of the end blocker for the steps of all jobs in a block, see ProcessJobs. The
+// default is DefaultJobGasLimit.
+func WithJobGasLimit(gasLimit uint64) Option {
+	return optsFn(func(k *Keeper) {
+		if gasLimit == 0 {
+			panic("job gas limit must not be 0")
+		}
+		k.jobGasLimit = gasLimit
+	})
+}
+
+// WithJobHandler registers the handler for an app specific job type, so that long running operations of other modules
+// can be queued with QueueJob and are processed by the end blocker of the wasm module. The wasm job types can not be
+// overwritten.
+func WithJobHandler(jobType string, h JobHandler) Option {
+	return optsFn(func(k *Keeper) {
+		if _, ok := k.builtinJobHandler(jobType); ok {
+			panic(fmt.Sprintf("job type %q is reserved", jobType))
+		}
+		k.jobHandlers[jobType] = h
+	})
+}
+
+// WithCodeStream enables the Query/CodeStream gRPC method that sends the byte code in chunks. The SDK serves gRPC
+// streams without an sdk context so that the byte code is loaded with an ABCI query instead. The BaseApp of the chain
+// is an ABCIQuerier.
+func WithCodeStream(x ABCIQuerier) Option {
+	return optsFn(func(k *Keeper) {
+		k.codeStreamQuerier = x
+	})
+}
+
+// WithCodeExecutionStats enables the on chain statistics of the contract executions and the gas consumed by the wasm VM
+// per code id, see CodeExecutionStats. An epoch spans the given number of blocks. The statistics of the current and the
+// previous epochs are kept for keepEpochs in total, older ones are deleted by an end blocker job. 0 keeps all epochs.
+func WithCodeExecutionStats(epochLength, keepEpochs uint64) Option {
+	return optsFn(func(k *Keeper) {
+		if epochLength == 0 {
+			panic("epoch length must not be 0")
+		}
+		k.codeStatsEpochLength = epochLength
+		k.codeStatsKeepEpochs = keepEpochs
+	})
+}
+
+// WithStrictAddressValidation is an optional constructor parameter to accept human addresses from contracts in the
+// normalized form only. The address must have the bech32 account prefix of the chain and be lower case, so that the
+// decoded address renders into the same string again. By default the upper case form is accepted, too.
+func WithStrictAddressValidation() Option {
+	return optsFn(func(k *Keeper) {
+		k.cosmwasmAPI.CanonicalAddress = strictCanonicalAddress
+	})
+}
+
+// WithAddressGenerator is an optional constructor parameter to replace the default generator of the contract
+// addresses, for example with ModuleAccountAddressGenerator for 32 byte addresses. Addresses that are not 20 bytes
+// long require an address verifier in the sdk config that accepts them, see types.VerifyAddressLen.
+func WithAddressGenerator(x AddressGenerator) Option {
+	return optsFn(func(k *Keeper) {
+		k.addressGenerator = x
+	})
+}
+
+// WithContractFundsRecovery enables the gov proposals to recover the funds of bricked contracts, see
+// RecoverContractFundsProposal. The proposals fail when their voting period is shorter than the given min period,
+// for example DefaultRecoverContractFundsMinVotingPeriod. The gov keeper is a ProposalSource.
+func WithContractFundsRecovery(x ProposalSource, minVotingPeriod time.Duration) Option {
+	return optsFn(func(k *Keeper) {
+		if minVotingPeriod == 0 {
+			panic("min voting period must not be 0")
+		}
+		k.proposals = x
+		k.recoveryMinVotingPeriod = minVotingPeriod
+	})
+}
+
+// WithExecutionMiddleware is an optional constructor parameter to register middlewares that are called around the
+// contract calls of the wasm VM. Before hooks are called in the given order, After hooks in the reverse order.
+// Multiple options append to the list.
+func WithExecutionMiddleware(m ...ExecutionMiddleware) Option {
+	return optsFn(func(k *Keeper) {
+		k.executionMiddlewares = append(k.executionMiddlewares, m...)
+	})
+}
+
 // WithApiCosts sets custom api costs. Amounts are in cosmwasm gas Not SDK gas.
 func WithApiCosts(human, canonical uint64) Option {
 	return optsFn(func(_ *Keeper) {
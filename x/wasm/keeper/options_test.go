@@ -1,13 +1,17 @@
 package keeper
 
 import (
+	"errors"
 	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
 	"github.com/CosmWasm/wasmd/x/wasm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	authkeeper "github.com/cosmos/cosmos-sdk/x/auth/keeper"
+	bankkeeper "github.com/cosmos/cosmos-sdk/x/bank/keeper"
 	distributionkeeper "github.com/cosmos/cosmos-sdk/x/distribution/keeper"
 	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
 	stakingkeeper "github.com/cosmos/cosmos-sdk/x/staking/keeper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"testing"
 )
 
@@ -22,18 +26,67 @@ func TestConstructorOptions(t *testing.T) {
 				assert.IsType(t, k.wasmVM, &wasmtesting.MockWasmer{})
 			},
 		},
+		"decorate wasmvm": {
+			srcOpt: WithWasmEngineDecorator(func(old types.WasmerEngine) types.WasmerEngine {
+				require.IsType(t, &recoveringWasmEngine{}, old)
+				return &wasmtesting.MockWasmer{}
+			}),
+			verify: func(t *testing.T, k Keeper) {
+				assert.IsType(t, &wasmtesting.MockWasmer{}, k.wasmVM)
+			},
+		},
 		"message handler": {
 			srcOpt: WithMessageHandler(&wasmtesting.MockMessageHandler{}),
 			verify: func(t *testing.T, k Keeper) {
 				assert.IsType(t, k.messenger, &wasmtesting.MockMessageHandler{})
 			},
 		},
+		"decorate message handler": {
+			srcOpt: WithMessageHandlerDecorator(func(old Messenger) Messenger {
+				require.IsType(t, &MessageHandlerChain{}, old)
+				return &wasmtesting.MockMessageHandler{}
+			}),
+			verify: func(t *testing.T, k Keeper) {
+				assert.IsType(t, &wasmtesting.MockMessageHandler{}, k.messenger)
+			},
+		},
 		"query plugins": {
 			srcOpt: WithQueryHandler(&wasmtesting.MockQueryHandler{}),
 			verify: func(t *testing.T, k Keeper) {
 				assert.IsType(t, k.wasmVMQueryHandler, &wasmtesting.MockQueryHandler{})
 			},
 		},
+		"ics4 wrapper": {
+			srcOpt: WithICS4Wrapper(&wasmtesting.MockChannelKeeper{}),
+			verify: func(t *testing.T, k Keeper) {
+				h := k.messenger.(*MessageHandlerChain).handlers[2].(*IBCRawPacketHandler)
+				assert.IsType(t, h.ics4Wrapper, &wasmtesting.MockChannelKeeper{})
+			},
+		},
+		"denom bridge": {
+			srcOpt: WithDenomBridge(bankkeeper.BaseKeeper{}, DenomBridgeHooksFunc(nil)),
+			verify: func(t *testing.T, k Keeper) {
+				handlers := k.messenger.(*MessageHandlerChain).handlers
+				require.Len(t, handlers, 5)
+				assert.IsType(t, MessageHandlerFunc(nil), handlers[0])
+				assert.IsType(t, MessageHandlerFunc(nil), handlers[1])
+				assert.IsType(t, SDKMessageHandler{}, handlers[2])
+			},
+		},
+		"token factory": {
+			srcOpt: WithTokenFactory(mockTokenFactory{}),
+			verify: func(t *testing.T, k Keeper) {
+				q := k.wasmVMQueryHandler.(QueryPlugins)
+				_, err := q.Custom(sdk.Context{}, []byte(`{"token_factory":{}}`))
+				assert.True(t, errors.Is(err, types.ErrInvalid))
+				s := k.messenger.(*MessageHandlerChain).handlers[1].(SDKMessageHandler)
+				_, err = s.encoders.(MessageEncoders).Custom(nil, []byte(`{"token_factory":{}}`))
+				assert.True(t, errors.Is(err, types.ErrInvalidMsg))
+				// other custom messages are passed to the default encoder
+				_, err = s.encoders.(MessageEncoders).Custom(nil, []byte(`{"foo":{}}`))
+				assert.True(t, errors.Is(err, types.ErrUnknownMsg))
+			},
+		},
 		"coin transferrer": {
 			srcOpt: WithCoinTransferrer(&wasmtesting.MockCoinTransferrer{}),
 			verify: func(t *testing.T, k Keeper) {
@@ -46,6 +99,12 @@ func TestConstructorOptions(t *testing.T) {
 				assert.IsType(t, k.gasRegister, &wasmtesting.MockGasRegister{})
 			},
 		},
+		"sub-query gas limit": {
+			srcOpt: WithSubQueryGasLimit(50),
+			verify: func(t *testing.T, k Keeper) {
+				assert.Equal(t, uint64(50), k.subQueryGasPercent)
+			},
+		},
 		"api costs": {
 			srcOpt: WithApiCosts(1, 2),
 			verify: func(t *testing.T, k Keeper) {
@@ -54,10 +113,17 @@ func TestConstructorOptions(t *testing.T) {
 				assert.Equal(t, uint64(2), costCanonical)
 			},
 		},
+		"code execution stats": {
+			srcOpt: WithCodeExecutionStats(100, 3),
+			verify: func(t *testing.T, k Keeper) {
+				assert.Equal(t, uint64(100), k.codeStatsEpochLength)
+				assert.Equal(t, uint64(3), k.codeStatsKeepEpochs)
+			},
+		},
 	}
 	for name, spec := range specs {
 		t.Run(name, func(t *testing.T) {
-			k := NewKeeper(nil, nil, paramtypes.NewSubspace(nil, nil, nil, nil, ""), authkeeper.AccountKeeper{}, nil, stakingkeeper.Keeper{}, distributionkeeper.Keeper{}, nil, nil, nil, nil, nil, nil, "tempDir", types.DefaultWasmConfig(), SupportedFeatures, spec.srcOpt)
+			k := NewKeeper(nil, nil, paramtypes.NewSubspace(nil, nil, nil, nil, ""), authkeeper.AccountKeeper{}, nil, stakingkeeper.Keeper{}, distributionkeeper.Keeper{}, nil, nil, nil, nil, nil, nil, t.TempDir(), types.DefaultWasmConfig(), SupportedFeatures, spec.srcOpt)
 			spec.verify(t, k)
 		})
 	}
@@ -0,0 +1,109 @@
+package keeper
+
+import (
+	"encoding/json"
+
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+type receivedPacketKey struct{}
+
+type receivedPacket struct {
+	receiver sdk.AccAddress
+	packet   wasmvmtypes.IBCPacket
+}
+
+// withReceivedPacket returns the context for the receive callback of the packet. The packet is bound to the receiving
+// contract. The packet data is only parsed when the contract queries the forward metadata.
+func withReceivedPacket(ctx sdk.Context, receiver sdk.AccAddress, packet wasmvmtypes.IBCPacket) sdk.Context {
+	return ctx.WithValue(receivedPacketKey{}, receivedPacket{receiver: receiver, packet: packet})
+}
+
+// withReceivedPacketFor returns the context for the queries of the contract. The received packet is removed unless the
+// contract is the receiver so that other contracts that are executed or queried within the receive callback can not
+// read it.
+func withReceivedPacketFor(ctx sdk.Context, contractAddr sdk.AccAddress) sdk.Context {
+	p, ok := ctx.Value(receivedPacketKey{}).(receivedPacket)
+	if !ok || p.receiver.Equals(contractAddr) {
+		return ctx
+	}
+	return ctx.WithValue(receivedPacketKey{}, nil)
+}
+
+// ReceivedPacketFromContext returns the packet of the current receive callback
+func ReceivedPacketFromContext(ctx sdk.Context) (wasmvmtypes.IBCPacket, bool) {
+	p, ok := ctx.Value(receivedPacketKey{}).(receivedPacket)
+	return p.packet, ok
+}
+
+// PacketForwardMetadata is the `forward` object of a packet memo as specified by the packet forward middleware
+type PacketForwardMetadata struct {
+	Receiver string `json:"receiver"`
+	Port     string `json:"port"`
+	Channel  string `json:"channel"`
+	// Next is the forward metadata of the following hop, if any
+	Next json.RawMessage `json:"next,omitempty"`
+}
+
+// packetForwardData contains the fields of an ICS-20 style packet that describe the origin
+type packetForwardData struct {
+	Sender   string `json:"sender"`
+	Receiver string `json:"receiver"`
+	Memo     string `json:"memo"`
+}
+
+type packetForwardMemo struct {
+	Forward *PacketForwardMetadata `json:"forward"`
+}
+
+// PacketForwardCustomQuery is the custom query envelope to query the forward metadata of the received packet
+type PacketForwardCustomQuery struct {
+	PacketForward *struct{} `json:"packet_forward,omitempty"`
+}
+
+type PacketForwardResponse struct {
+	// Received is true when the query is made within a packet receive callback
+	Received bool `json:"received"`
+	// SourcePort and SourceChannel are the counterparty endpoint of the last hop
+	SourcePort    string `json:"source_port,omitempty"`
+	SourceChannel string `json:"source_channel,omitempty"`
+	// Sender is the sender of the packet data. For a forwarded packet this is the sender on the previous hop.
+	Sender string `json:"sender,omitempty"`
+	// Receiver is the receiver of the packet data
+	Receiver string `json:"receiver,omitempty"`
+	// Forward is the forward metadata of the packet memo
+	Forward *PacketForwardMetadata `json:"forward,omitempty"`
+}
+
+// NewPacketForwardQuerier handles the packet forward custom query. Within the receive callback of a packet, the
+// receiving contract can read the sender and the forward route of the packet memo to apply per origin policies. Packet data that is not a JSON object with these fields is not an error but returns no
+// metadata.
+// All other custom queries are passed to the next querier.
+func NewPacketForwardQuerier(next CustomQuerier) CustomQuerier {
+	return func(ctx sdk.Context, request json.RawMessage) ([]byte, error) {
+		var customQuery PacketForwardCustomQuery
+		if err := json.Unmarshal(request, &customQuery); err != nil || customQuery.PacketForward == nil {
+			return next(ctx, request)
+		}
+		packet, ok := ReceivedPacketFromContext(ctx)
+		if !ok {
+			return json.Marshal(PacketForwardResponse{})
+		}
+		rsp := PacketForwardResponse{
+			Received:      true,
+			SourcePort:    packet.Src.PortID,
+			SourceChannel: packet.Src.ChannelID,
+		}
+		var data packetForwardData
+		if err := json.Unmarshal(packet.Data, &data); err != nil {
+			return json.Marshal(rsp)
+		}
+		rsp.Sender, rsp.Receiver = data.Sender, data.Receiver
+		var memo packetForwardMemo
+		if err := json.Unmarshal([]byte(data.Memo), &memo); err == nil {
+			rsp.Forward = memo.Forward
+		}
+		return json.Marshal(rsp)
+	}
+}
@@ -0,0 +1,150 @@
+package keeper
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	wasmvm "github.com/CosmWasm/wasmvm"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPacketForwardOnRecvPacket(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithPacketForwardQuerier())
+	mock := wasmtesting.MockWasmer{}
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+
+	var captured []byte
+	mock.IBCPacketReceiveFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, packet wasmvmtypes.IBCPacket, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.IBCReceiveResponse, uint64, error) {
+		bz, err := querier.Query(wasmvmtypes.QueryRequest{Custom: []byte(`{"packet_forward":{}}`)}, gasLimit)
+		require.NoError(t, err)
+		captured = bz
+		return &wasmvmtypes.IBCReceiveResponse{Acknowledgement: []byte("ack")}, 0, nil
+	}
+	myPacket := wasmvmtypes.IBCPacket{
+		Data: []byte(`{"amount":"100","denom":"uatom","sender":"cosmos1sender","receiver":"cosmos1receiver","memo":"{\"forward\":{\"receiver\":\"osmo1receiver\",\"port\":\"transfer\",\"channel\":\"channel-1\"}}"}`),
+		Src:  wasmvmtypes.IBCEndpoint{PortID: "transfer", ChannelID: "channel-7"},
+		Dest: wasmvmtypes.IBCEndpoint{PortID: PortIDForContract(example.Contract), ChannelID: "channel-0"},
+	}
+
+	// when
+	_, err := keepers.WasmKeeper.OnRecvPacket(ctx, example.Contract, myPacket)
+
+	// then
+	require.NoError(t, err)
+	exp := `{"received":true,"source_port":"transfer","source_channel":"channel-7","sender":"cosmos1sender","receiver":"cosmos1receiver",
+"forward":{"receiver":"osmo1receiver","port":"transfer","channel":"channel-1"}}`
+	assert.JSONEq(t, exp, string(captured))
+}
+
+func TestPacketForwardNotVisibleToOtherContracts(t *testing.T) {
+	mock := wasmtesting.MockWasmer{}
+	wasmtesting.MakeInstantiable(&mock)
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithWasmEngine(&mock), WithPacketForwardQuerier())
+	receiver := SeedNewContractInstance(t, ctx, keepers, &mock).Contract
+	other := SeedNewContractInstance(t, ctx, keepers, &mock).Contract
+
+	var captured []string
+	queryPacketForward := func(querier wasmvm.Querier) {
+		bz, err := querier.Query(wasmvmtypes.QueryRequest{Custom: []byte(`{"packet_forward":{}}`)}, 100000)
+		require.NoError(t, err)
+		captured = append(captured, string(bz))
+	}
+	mock.QueryFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, queryMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) ([]byte, uint64, error) {
+		queryPacketForward(querier)
+		return []byte(`{}`), 0, nil
+	}
+	mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.Response, uint64, error) {
+		queryPacketForward(querier)
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+	mock.IBCPacketReceiveFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, packet wasmvmtypes.IBCPacket, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.IBCReceiveResponse, uint64, error) {
+		queryPacketForward(querier)
+		_, err := querier.Query(wasmvmtypes.QueryRequest{Wasm: &wasmvmtypes.WasmQuery{Smart: &wasmvmtypes.SmartQuery{ContractAddr: other.String(), Msg: []byte(`{}`)}}}, gasLimit)
+		require.NoError(t, err)
+		return &wasmvmtypes.IBCReceiveResponse{
+			Acknowledgement: []byte("ack"),
+			Messages:        []wasmvmtypes.CosmosMsg{{Wasm: &wasmvmtypes.WasmMsg{Execute: &wasmvmtypes.ExecuteMsg{ContractAddr: other.String(), Msg: []byte(`{}`)}}}},
+		}, 0, nil
+	}
+	myPacket := wasmvmtypes.IBCPacket{
+		Data: []byte(`{"sender":"cosmos1sender","receiver":"cosmos1receiver"}`),
+		Src:  wasmvmtypes.IBCEndpoint{PortID: "transfer", ChannelID: "channel-7"},
+	}
+
+	// when
+	_, err := keepers.WasmKeeper.OnRecvPacket(ctx, receiver, myPacket)
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, captured, 3)
+	assert.JSONEq(t, `{"received":true,"source_port":"transfer","source_channel":"channel-7","sender":"cosmos1sender","receiver":"cosmos1receiver"}`, captured[0])
+	// and not for the queried contract
+	assert.JSONEq(t, `{"received":false}`, captured[1])
+	// and not for the executed contract
+	assert.JSONEq(t, `{"received":false}`, captured[2])
+}
+
+func TestPacketForwardQuerier(t *testing.T) {
+	next := func(ctx sdk.Context, request json.RawMessage) ([]byte, error) {
+		return []byte(`"next"`), nil
+	}
+	emptyCtx := sdk.Context{}.WithContext(context.Background())
+	src := wasmvmtypes.IBCEndpoint{PortID: "transfer", ChannelID: "channel-7"}
+	packetCtx := func(data string) sdk.Context {
+		return withReceivedPacket(emptyCtx, RandomAccountAddress(t), wasmvmtypes.IBCPacket{Data: []byte(data), Src: src})
+	}
+	specs := map[string]struct {
+		srcCtx  sdk.Context
+		srcReq  string
+		expResp string
+	}{
+		"forwarded packet": {
+			srcCtx:  packetCtx(`{"sender":"a","receiver":"b","memo":"{\"forward\":{\"receiver\":\"c\",\"port\":\"transfer\",\"channel\":\"channel-1\",\"next\":{\"forward\":{}}}}"}`),
+			srcReq:  `{"packet_forward":{}}`,
+			expResp: `{"received":true,"source_port":"transfer","source_channel":"channel-7","sender":"a","receiver":"b","forward":{"receiver":"c","port":"transfer","channel":"channel-1","next":{"forward":{}}}}`,
+		},
+		"without memo": {
+			srcCtx:  packetCtx(`{"sender":"a","receiver":"b"}`),
+			srcReq:  `{"packet_forward":{}}`,
+			expResp: `{"received":true,"source_port":"transfer","source_channel":"channel-7","sender":"a","receiver":"b"}`,
+		},
+		"memo without forward": {
+			srcCtx:  packetCtx(`{"sender":"a","receiver":"b","memo":"{\"wasm\":{}}"}`),
+			srcReq:  `{"packet_forward":{}}`,
+			expResp: `{"received":true,"source_port":"transfer","source_channel":"channel-7","sender":"a","receiver":"b"}`,
+		},
+		"plain text memo": {
+			srcCtx:  packetCtx(`{"sender":"a","receiver":"b","memo":"hello"}`),
+			srcReq:  `{"packet_forward":{}}`,
+			expResp: `{"received":true,"source_port":"transfer","source_channel":"channel-7","sender":"a","receiver":"b"}`,
+		},
+		"non json packet data": {
+			srcCtx:  packetCtx(`my data`),
+			srcReq:  `{"packet_forward":{}}`,
+			expResp: `{"received":true,"source_port":"transfer","source_channel":"channel-7"}`,
+		},
+		"outside of packet receive": {
+			srcCtx:  emptyCtx,
+			srcReq:  `{"packet_forward":{}}`,
+			expResp: `{"received":false}`,
+		},
+		"other custom query": {
+			srcCtx:  packetCtx(`{}`),
+			srcReq:  `{"foo":{}}`,
+			expResp: `"next"`,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			gotResp, gotErr := NewPacketForwardQuerier(next)(spec.srcCtx, []byte(spec.srcReq))
+			require.NoError(t, gotErr)
+			assert.JSONEq(t, spec.expResp, string(gotResp))
+		})
+	}
+}
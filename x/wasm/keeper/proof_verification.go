@@ -0,0 +1,142 @@
+package keeper
+
+import (
+	"encoding/json"
+	"net/url"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	clienttypes "github.com/cosmos/cosmos-sdk/x/ibc/core/02-client/types"
+	commitmenttypes "github.com/cosmos/cosmos-sdk/x/ibc/core/23-commitment/types"
+	ibcexported "github.com/cosmos/cosmos-sdk/x/ibc/core/exported"
+)
+
+const (
+	// DefaultProofVerificationGasCost is the gas charged for a single proof verification
+	DefaultProofVerificationGasCost uint64 = 10000
+	// DefaultProofVerificationGasCostPerByte is the gas charged per byte of the proof and the key path in addition, as
+	// the verification hashes all of them
+	DefaultProofVerificationGasCostPerByte uint64 = 10
+)
+
+// ClientConsensusStateSource is the subset of the IBC client keeper that is used to verify proofs against the
+// consensus states of the counterparty chains
+type ClientConsensusStateSource interface {
+	GetClientState(ctx sdk.Context, clientID string) (ibcexported.ClientState, bool)
+	GetClientConsensusState(ctx sdk.Context, clientID string, height ibcexported.Height) (ibcexported.ConsensusState, bool)
+}
+
+// ProofVerificationCustomQuery is the custom query envelope to verify ICS-23 proofs
+type ProofVerificationCustomQuery struct {
+	VerifyMembership    *VerifyMembershipQuery    `json:"verify_membership,omitempty"`
+	VerifyNonMembership *VerifyNonMembershipQuery `json:"verify_non_membership,omitempty"`
+}
+
+// ProofHeight is the height of the counterparty chain that the proof was created for
+type ProofHeight struct {
+	RevisionNumber uint64 `json:"revision_number"`
+	RevisionHeight uint64 `json:"revision_height"`
+}
+
+// VerifyNonMembershipQuery verifies that no value is stored under the key path on the counterparty chain
+type VerifyNonMembershipQuery struct {
+	// ClientID is the local IBC client that tracks the counterparty chain
+	ClientID string `json:"client_id"`
+	// Height selects the consensus state of the client with the commitment root
+	Height ProofHeight `json:"height"`
+	// Proof is the protobuf encoded ICS-23 merkle proof
+	Proof []byte `json:"proof"`
+	// KeyPath are the keys of the chained proofs starting with the outermost store, for example the store name of the
+	// module followed by the key within the module store
+	KeyPath [][]byte `json:"key_path"`
+}
+
+// VerifyMembershipQuery verifies that the value is stored under the key path on the counterparty chain
+type VerifyMembershipQuery struct {
+	VerifyNonMembershipQuery
+	Value []byte `json:"value"`
+}
+
+// ProofVerificationResponse is the result of a proof verification query
+type ProofVerificationResponse struct {
+	// Verified is false when the proof does not match the commitment root
+	Verified bool `json:"verified"`
+}
+
+// NewProofVerificationQuerier handles the `verify_membership` and `verify_non_membership` custom queries. Proofs are
+// verified against the commitment root of a consensus state that is tracked by a local IBC client so that contracts
+// can read the state of a counterparty chain without trusting a relayer. Queries to unknown or frozen clients fail.
+// All other custom queries are passed to the next querier.
+func NewProofVerificationQuerier(clients ClientConsensusStateSource, next CustomQuerier) CustomQuerier {
+	return func(ctx sdk.Context, request json.RawMessage) ([]byte, error) {
+		var customQuery ProofVerificationCustomQuery
+		if err := json.Unmarshal(request, &customQuery); err != nil {
+			return next(ctx, request)
+		}
+		var verified bool
+		switch {
+		case customQuery.VerifyMembership != nil:
+			q := customQuery.VerifyMembership
+			if len(q.Value) == 0 {
+				return nil, sdkerrors.Wrap(types.ErrEmpty, "value")
+			}
+			proof, root, path, err := proofVerificationArgs(ctx, clients, q.VerifyNonMembershipQuery)
+			if err != nil {
+				return nil, err
+			}
+			verified = proof.VerifyMembership(commitmenttypes.GetSDKSpecs(), root, path, q.Value) == nil
+		case customQuery.VerifyNonMembership != nil:
+			proof, root, path, err := proofVerificationArgs(ctx, clients, *customQuery.VerifyNonMembership)
+			if err != nil {
+				return nil, err
+			}
+			verified = proof.VerifyNonMembership(commitmenttypes.GetSDKSpecs(), root, path) == nil
+		default:
+			return next(ctx, request)
+		}
+		return json.Marshal(ProofVerificationResponse{Verified: verified})
+	}
+}
+
+// proofVerificationArgs charges the verification costs and returns the decoded proof with the commitment root of the
+// client consensus state and the merkle path
+func proofVerificationArgs(ctx sdk.Context, clients ClientConsensusStateSource, q VerifyNonMembershipQuery) (commitmenttypes.MerkleProof, ibcexported.Root, commitmenttypes.MerklePath, error) {
+	ctx.GasMeter().ConsumeGas(proofVerificationCosts(q), "proof verification")
+	var proof commitmenttypes.MerkleProof
+	if len(q.Proof) == 0 {
+		return proof, nil, commitmenttypes.MerklePath{}, sdkerrors.Wrap(types.ErrEmpty, "proof")
+	}
+	if err := proof.Unmarshal(q.Proof); err != nil {
+		return proof, nil, commitmenttypes.MerklePath{}, sdkerrors.Wrap(types.ErrInvalid, "proof")
+	}
+	if len(q.KeyPath) == 0 {
+		return proof, nil, commitmenttypes.MerklePath{}, sdkerrors.Wrap(types.ErrEmpty, "key path")
+	}
+	keys := make([]string, len(q.KeyPath))
+	for i, k := range q.KeyPath {
+		keys[i] = url.PathEscape(string(k))
+	}
+	clientState, ok := clients.GetClientState(ctx, q.ClientID)
+	if !ok {
+		return proof, nil, commitmenttypes.MerklePath{}, sdkerrors.Wrapf(types.ErrNotFound, "client: %s", q.ClientID)
+	}
+	if clientState.IsFrozen() {
+		return proof, nil, commitmenttypes.MerklePath{}, sdkerrors.Wrapf(types.ErrInvalid, "client frozen: %s", q.ClientID)
+	}
+	height := clienttypes.NewHeight(q.Height.RevisionNumber, q.Height.RevisionHeight)
+	consensusState, ok := clients.GetClientConsensusState(ctx, q.ClientID, height)
+	if !ok {
+		return proof, nil, commitmenttypes.MerklePath{}, sdkerrors.Wrapf(types.ErrNotFound, "consensus state: %s at %s", q.ClientID, height)
+	}
+	return proof, consensusState.GetRoot(), commitmenttypes.NewMerklePath(keys...), nil
+}
+
+// proofVerificationCosts returns the gas for the verification of the proof with the key path
+func proofVerificationCosts(q VerifyNonMembershipQuery) sdk.Gas {
+	size := len(q.Proof)
+	for _, k := range q.KeyPath {
+		size += len(k)
+	}
+	return DefaultProofVerificationGasCost + DefaultProofVerificationGasCostPerByte*uint64(size)
+}
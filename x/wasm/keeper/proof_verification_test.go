@@ -0,0 +1,211 @@
+package keeper
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	"github.com/cosmos/cosmos-sdk/store/rootmulti"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	clientkeeper "github.com/cosmos/cosmos-sdk/x/ibc/core/02-client/keeper"
+	clienttypes "github.com/cosmos/cosmos-sdk/x/ibc/core/02-client/types"
+	commitmenttypes "github.com/cosmos/cosmos-sdk/x/ibc/core/23-commitment/types"
+	ibcexported "github.com/cosmos/cosmos-sdk/x/ibc/core/exported"
+	ibctmtypes "github.com/cosmos/cosmos-sdk/x/ibc/light-clients/07-tendermint/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tm-db"
+)
+
+var _ ClientConsensusStateSource = clientkeeper.Keeper{}
+
+func TestProofVerificationQuerier(t *testing.T) {
+	// counterparty chain state
+	ms := rootmulti.NewStore(dbm.NewMemDB())
+	storeKey := sdk.NewKVStoreKey(types.StoreKey)
+	ms.MountStoreWithDB(storeKey, storetypes.StoreTypeIAVL, nil)
+	require.NoError(t, ms.LoadLatestVersion())
+	ms.GetKVStore(storeKey).Set([]byte("foo"), []byte("bar"))
+	commitID := ms.Commit()
+	prove := func(key string) []byte {
+		res := ms.Query(abci.RequestQuery{Path: "/" + types.StoreKey + "/key", Data: []byte(key), Prove: true})
+		require.Equal(t, uint32(0), res.Code, res.Log)
+		proof, err := commitmenttypes.ConvertProofs(res.ProofOps)
+		require.NoError(t, err)
+		bz, err := proof.Marshal()
+		require.NoError(t, err)
+		return bz
+	}
+	existingProof, absentProof := prove("foo"), prove("other")
+
+	source := mockClientConsensusStateSource{
+		clients: map[string]ibcexported.ClientState{
+			"my-client":     &ibctmtypes.ClientState{},
+			"frozen-client": &ibctmtypes.ClientState{FrozenHeight: clienttypes.NewHeight(0, 1)},
+		},
+		consensusStates: map[string]ibcexported.ConsensusState{
+			"my-client":     ibctmtypes.NewConsensusState(time.Now(), commitmenttypes.NewMerkleRoot(commitID.Hash), nil),
+			"frozen-client": ibctmtypes.NewConsensusState(time.Now(), commitmenttypes.NewMerkleRoot(commitID.Hash), nil),
+		},
+	}
+	membership := func(clientID string, proof []byte, key, value string) ProofVerificationCustomQuery {
+		return ProofVerificationCustomQuery{VerifyMembership: &VerifyMembershipQuery{
+			VerifyNonMembershipQuery: VerifyNonMembershipQuery{
+				ClientID: clientID,
+				Height:   ProofHeight{RevisionHeight: 1},
+				Proof:    proof,
+				KeyPath:  [][]byte{[]byte(types.StoreKey), []byte(key)},
+			},
+			Value: []byte(value),
+		}}
+	}
+	nonMembership := func(clientID string, proof []byte, key string) ProofVerificationCustomQuery {
+		return ProofVerificationCustomQuery{VerifyNonMembership: &VerifyNonMembershipQuery{
+			ClientID: clientID,
+			Height:   ProofHeight{RevisionHeight: 1},
+			Proof:    proof,
+			KeyPath:  [][]byte{[]byte(types.StoreKey), []byte(key)},
+		}}
+	}
+	next := func(ctx sdk.Context, request json.RawMessage) ([]byte, error) {
+		return []byte(`"next"`), nil
+	}
+	specs := map[string]struct {
+		src     interface{}
+		expResp string
+		expErr  *sdkerrors.Error
+	}{
+		"membership": {
+			src:     membership("my-client", existingProof, "foo", "bar"),
+			expResp: `{"verified":true}`,
+		},
+		"membership with other value": {
+			src:     membership("my-client", existingProof, "foo", "baz"),
+			expResp: `{"verified":false}`,
+		},
+		"membership with other key": {
+			src:     membership("my-client", existingProof, "other", "bar"),
+			expResp: `{"verified":false}`,
+		},
+		"membership with absence proof": {
+			src:     membership("my-client", absentProof, "other", "bar"),
+			expResp: `{"verified":false}`,
+		},
+		"membership without value": {
+			src:    membership("my-client", existingProof, "foo", ""),
+			expErr: types.ErrEmpty,
+		},
+		"non membership": {
+			src:     nonMembership("my-client", absentProof, "other"),
+			expResp: `{"verified":true}`,
+		},
+		"non membership with existence proof": {
+			src:     nonMembership("my-client", existingProof, "foo"),
+			expResp: `{"verified":false}`,
+		},
+		"unknown client": {
+			src:    nonMembership("unknown-client", absentProof, "other"),
+			expErr: types.ErrNotFound,
+		},
+		"frozen client": {
+			src:    nonMembership("frozen-client", absentProof, "other"),
+			expErr: types.ErrInvalid,
+		},
+		"empty proof": {
+			src:    nonMembership("my-client", nil, "other"),
+			expErr: types.ErrEmpty,
+		},
+		"invalid proof": {
+			src:    nonMembership("my-client", []byte("invalid"), "other"),
+			expErr: types.ErrInvalid,
+		},
+		"other custom query": {
+			src:     map[string]interface{}{"foo": struct{}{}},
+			expResp: `"next"`,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx := sdk.Context{}.WithContext(context.Background()).WithGasMeter(sdk.NewInfiniteGasMeter())
+			req, err := json.Marshal(spec.src)
+			require.NoError(t, err)
+			gotResp, gotErr := NewProofVerificationQuerier(source, next)(ctx, req)
+			if spec.expErr != nil {
+				assert.True(t, spec.expErr.Is(gotErr), "got %+v", gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.JSONEq(t, spec.expResp, string(gotResp))
+		})
+	}
+}
+
+func TestProofVerificationGasCosts(t *testing.T) {
+	next := func(ctx sdk.Context, request json.RawMessage) ([]byte, error) {
+		return []byte(`"next"`), nil
+	}
+	specs := map[string]struct {
+		proof   []byte
+		keyPath [][]byte
+		expGas  sdk.Gas
+	}{
+		"charged per byte of proof and key path": {
+			proof:   []byte("invalid"),
+			keyPath: [][]byte{[]byte("wasm"), []byte("other")},
+			expGas:  DefaultProofVerificationGasCost + 16*DefaultProofVerificationGasCostPerByte,
+		},
+		"bigger proof": {
+			proof:   bytes.Repeat([]byte{1}, 1000),
+			keyPath: [][]byte{[]byte("wasm"), []byte("other")},
+			expGas:  DefaultProofVerificationGasCost + 1009*DefaultProofVerificationGasCostPerByte,
+		},
+		"longer key path": {
+			proof:   []byte("invalid"),
+			keyPath: [][]byte{[]byte("wasm"), bytes.Repeat([]byte{1}, 100)},
+			expGas:  DefaultProofVerificationGasCost + 111*DefaultProofVerificationGasCostPerByte,
+		},
+		"empty": {
+			expGas: DefaultProofVerificationGasCost,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx := sdk.Context{}.WithContext(context.Background()).WithGasMeter(sdk.NewInfiniteGasMeter())
+			req, err := json.Marshal(ProofVerificationCustomQuery{VerifyNonMembership: &VerifyNonMembershipQuery{
+				ClientID: "my-client",
+				Height:   ProofHeight{RevisionHeight: 1},
+				Proof:    spec.proof,
+				KeyPath:  spec.keyPath,
+			}})
+			require.NoError(t, err)
+			// when
+			_, _ = NewProofVerificationQuerier(mockClientConsensusStateSource{}, next)(ctx, req)
+			// then the costs are charged before the proof is decoded
+			assert.Equal(t, spec.expGas, ctx.GasMeter().GasConsumed())
+		})
+	}
+}
+
+type mockClientConsensusStateSource struct {
+	clients         map[string]ibcexported.ClientState
+	consensusStates map[string]ibcexported.ConsensusState
+}
+
+func (m mockClientConsensusStateSource) GetClientState(ctx sdk.Context, clientID string) (ibcexported.ClientState, bool) {
+	s, ok := m.clients[clientID]
+	return s, ok
+}
+
+func (m mockClientConsensusStateSource) GetClientConsensusState(ctx sdk.Context, clientID string, height ibcexported.Height) (ibcexported.ConsensusState, bool) {
+	if !height.EQ(clienttypes.NewHeight(0, 1)) {
+		return nil, false
+	}
+	s, ok := m.consensusStates[clientID]
+	return s, ok
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 	"strconv"
 )
@@ -43,18 +44,44 @@ func NewWasmProposalHandlerX(k types.ContractOpsKeeper, enabledProposalTypes []t
 			return handlePinCodesProposal(ctx, k, *c)
 		case *types.UnpinCodesProposal:
 			return handleUnpinCodesProposal(ctx, k, *c)
+		case *types.RecoverContractFundsProposal:
+			x, ok := k.(types.ContractFundsRecoveryOpsKeeper)
+			if !ok {
+				return sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unsupported wasm proposal content type: %q", content.ProposalType())
+			}
+			return handleRecoverContractFundsProposal(ctx, x, *c)
+		case *types.SetContractVestingProposal:
+			x, ok := k.(types.ContractVestingOpsKeeper)
+			if !ok {
+				return sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unsupported wasm proposal content type: %q", content.ProposalType())
+			}
+			return handleSetContractVestingProposal(ctx, x, *c)
+		case *types.MigrateAllContractsProposal:
+			x, ok := k.(types.MigrateAllContractsOpsKeeper)
+			if !ok {
+				return sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unsupported wasm proposal content type: %q", content.ProposalType())
+			}
+			return handleMigrateAllContractsProposal(ctx, x, *c)
 		default:
 			return sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized wasm proposal content type: %T", c)
 		}
 	}
 }
 
+// govRunAsAddress returns the gov module account address when no run as address was set in the proposal
+func govRunAsAddress(runAs string) (sdk.AccAddress, error) {
+	if len(runAs) == 0 {
+		return authtypes.NewModuleAddress(govtypes.ModuleName), nil
+	}
+	return sdk.AccAddressFromBech32(runAs)
+}
+
 func handleStoreCodeProposal(ctx sdk.Context, k types.ContractOpsKeeper, p types.StoreCodeProposal) error {
 	if err := p.ValidateBasic(); err != nil {
 		return err
 	}
 
-	runAsAddr, err := sdk.AccAddressFromBech32(p.RunAs)
+	runAsAddr, err := govRunAsAddress(p.RunAs)
 	if err != nil {
 		return sdkerrors.Wrap(err, "run as address")
 	}
@@ -76,13 +103,26 @@ func handleInstantiateProposal(ctx sdk.Context, k types.ContractOpsKeeper, p typ
 	if err := p.ValidateBasic(); err != nil {
 		return err
 	}
-	runAsAddr, err := sdk.AccAddressFromBech32(p.RunAs)
+	runAsAddr, err := govRunAsAddress(p.RunAs)
 	if err != nil {
 		return sdkerrors.Wrap(err, "run as address")
 	}
-	adminAddr, err := sdk.AccAddressFromBech32(p.Admin)
-	if err != nil {
-		return sdkerrors.Wrap(err, "admin")
+	var adminAddr sdk.AccAddress
+	if p.Admin != "" {
+		if adminAddr, err = sdk.AccAddressFromBech32(p.Admin); err != nil {
+			return sdkerrors.Wrap(err, "admin")
+		}
+	}
+	if p.FundsFromCommunityPool {
+		// the gov module executes the proposal handler in a cached context so that
+		// nothing is drawn from the community pool when the instantiation fails
+		pool, ok := k.(types.CommunityPoolOpsKeeper)
+		if !ok {
+			return sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "community pool funding not supported")
+		}
+		if err := pool.FundFromCommunityPool(ctx, runAsAddr, p.Funds); err != nil {
+			return sdkerrors.Wrap(err, "community pool")
+		}
 	}
 
 	contractAddr, data, err := k.Instantiate(ctx, p.CodeID, runAsAddr, adminAddr, p.InitMsg, p.Label, p.Funds)
@@ -96,6 +136,11 @@ func handleInstantiateProposal(ctx sdk.Context, k types.ContractOpsKeeper, p typ
 		sdk.NewAttribute(types.AttributeKeyCodeID, fmt.Sprintf("%d", p.CodeID)),
 		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String()),
 		sdk.NewAttribute(types.AttributeResultDataHex, hex.EncodeToString(data)),
+		sdk.NewAttribute(types.AttributeKeyLabel, p.Label),
+		// empty when the contract has no admin
+		sdk.NewAttribute(types.AttributeKeyAdmin, p.Admin),
+		// a contract without admin can never be migrated
+		sdk.NewAttribute(types.AttributeKeyImmutable, strconv.FormatBool(adminAddr.Empty())),
 	)
 	ctx.EventManager().EmitEvent(ourEvent)
 	return nil
@@ -186,14 +231,6 @@ func handlePinCodesProposal(ctx sdk.Context, k types.ContractOpsKeeper, p types.
 			return sdkerrors.Wrapf(err, "code id: %d", v)
 		}
 	}
-	for _, v := range p.CodeIDs {
-		ourEvent := sdk.NewEvent(
-			types.EventTypePinCode,
-			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
-			sdk.NewAttribute(types.AttributeKeyCodeID, strconv.FormatUint(v, 10)),
-		)
-		ctx.EventManager().EmitEvent(ourEvent)
-	}
 	return nil
 }
 
@@ -206,13 +243,73 @@ func handleUnpinCodesProposal(ctx sdk.Context, k types.ContractOpsKeeper, p type
 			return sdkerrors.Wrapf(err, "code id: %d", v)
 		}
 	}
-	for _, v := range p.CodeIDs {
-		ourEvent := sdk.NewEvent(
-			types.EventTypeUnpinCode,
-			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
-			sdk.NewAttribute(types.AttributeKeyCodeID, strconv.FormatUint(v, 10)),
-		)
-		ctx.EventManager().EmitEvent(ourEvent)
+	return nil
+}
+
+func handleRecoverContractFundsProposal(ctx sdk.Context, k types.ContractFundsRecoveryOpsKeeper, p types.RecoverContractFundsProposal) error {
+	if err := p.ValidateBasic(); err != nil {
+		return err
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(p.Contract)
+	if err != nil {
+		return sdkerrors.Wrap(err, "contract")
+	}
+	recipientAddr, err := sdk.AccAddressFromBech32(p.Recipient)
+	if err != nil {
+		return sdkerrors.Wrap(err, "recipient")
+	}
+	if err := k.RecoverContractFunds(ctx, contractAddr, recipientAddr, p.Amount); err != nil {
+		return err
+	}
+	ourEvent := sdk.NewEvent(
+		types.EventTypeRecoverContractFunds,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(types.AttributeKeyContractAddr, p.Contract),
+		sdk.NewAttribute(types.AttributeKeyRecipient, p.Recipient),
+		sdk.NewAttribute(sdk.AttributeKeyAmount, p.Amount.String()),
+	)
+	ctx.EventManager().EmitEvent(ourEvent)
+	return nil
+}
+
+func handleSetContractVestingProposal(ctx sdk.Context, k types.ContractVestingOpsKeeper, p types.SetContractVestingProposal) error {
+	if err := p.ValidateBasic(); err != nil {
+		return err
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(p.Contract)
+	if err != nil {
+		return sdkerrors.Wrap(err, "contract")
+	}
+	if err := k.SetContractVesting(ctx, contractAddr, p.Schedule); err != nil {
+		return err
+	}
+	ourEvent := sdk.NewEvent(
+		types.EventTypeSetContractVesting,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(types.AttributeKeyContractAddr, p.Contract),
+		sdk.NewAttribute(types.AttributeKeyVestingType, p.Schedule.Type.String()),
+		sdk.NewAttribute(sdk.AttributeKeyAmount, p.Schedule.Amount.String()),
+		sdk.NewAttribute(types.AttributeKeyVestingEndTime, strconv.FormatInt(p.Schedule.VestingEndTime(), 10)),
+	)
+	ctx.EventManager().EmitEvent(ourEvent)
+	return nil
+}
+
+func handleMigrateAllContractsProposal(ctx sdk.Context, k types.MigrateAllContractsOpsKeeper, p types.MigrateAllContractsProposal) error {
+	if err := p.ValidateBasic(); err != nil {
+		return err
+	}
+	runAsAddr, err := sdk.AccAddressFromBech32(p.RunAs)
+	if err != nil {
+		return sdkerrors.Wrap(err, "run as address")
+	}
+	migrated, err := k.MigrateAllContracts(ctx, p.CodeID, p.NewCodeID, runAsAddr, p.MigrateMsg, p.ContractsPerBlock)
+	if err != nil {
+		return err
+	}
+	if p.ContractsPerBlock == 0 {
+		// a chunked migration emits the event when completed by the end blocker
+		ctx.EventManager().EmitEvent(newMigrateAllContractsEvent(p.CodeID, p.NewCodeID, migrated, 0))
 	}
 	return nil
 }
@@ -12,6 +12,7 @@ import (
 
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 	"github.com/cosmos/cosmos-sdk/x/params/types/proposal"
 	"github.com/stretchr/testify/assert"
@@ -43,8 +44,9 @@ func TestStoreCodeProposal(t *testing.T) {
 	require.NoError(t, err)
 
 	// and proposal execute
+	em := sdk.NewEventManager()
 	handler := govKeeper.Router().GetRoute(storedProposal.ProposalRoute())
-	err = handler(ctx, storedProposal.GetContent())
+	err = handler(ctx.WithEventManager(em), storedProposal.GetContent())
 	require.NoError(t, err)
 
 	// then
@@ -53,6 +55,13 @@ func TestStoreCodeProposal(t *testing.T) {
 	assert.Equal(t, myActorAddress, cInfo.Creator)
 	assert.Equal(t, "foo/bar:v0.0.0", cInfo.Builder)
 	assert.Equal(t, "https://example.com/mysource", cInfo.Source)
+	expEvt := sdk.NewEvent(types.EventTypeStoreCode,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(types.AttributeKeyCodeID, "1"),
+		sdk.NewAttribute(types.AttributeKeyChecksum, hex.EncodeToString(cInfo.CodeHash)),
+		sdk.NewAttribute(types.AttributeKeyCreator, myActorAddress),
+	)
+	assert.Contains(t, em.Events(), expEvt)
 
 	storedCode, err := wasmKeeper.GetByteCode(ctx, 1)
 	require.NoError(t, err)
@@ -116,7 +125,166 @@ func TestInstantiateProposal(t *testing.T) {
 	assert.Equal(t, expHistory, wasmKeeper.GetContractHistory(ctx, contractAddr))
 	// and event
 	require.Len(t, em.Events(), 2, "%#v", em.Events())
-	require.Len(t, em.Events()[1].Attributes, 4)
+	require.Len(t, em.Events()[1].Attributes, 7)
+	assert.Equal(t, types.AttributeKeyLabel, string(em.Events()[1].Attributes[4].Key))
+	assert.Equal(t, "testing", string(em.Events()[1].Attributes[4].Value))
+	assert.Equal(t, types.AttributeKeyAdmin, string(em.Events()[1].Attributes[5].Key))
+	assert.Equal(t, otherAddress.String(), string(em.Events()[1].Attributes[5].Value))
+	assert.Equal(t, types.AttributeKeyImmutable, string(em.Events()[1].Attributes[6].Key))
+	assert.Equal(t, "false", string(em.Events()[1].Attributes[6].Value))
+}
+
+func TestInstantiateProposalWithoutAdmin(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, "staking")
+	govKeeper, wasmKeeper := keepers.GovKeeper, keepers.WasmKeeper
+	wasmCode, err := ioutil.ReadFile("./testdata/hackatom.wasm")
+	require.NoError(t, err)
+	require.NoError(t, wasmKeeper.importCode(ctx, 1,
+		types.CodeInfoFixture(types.WithSHA256CodeHash(wasmCode)),
+		wasmCode),
+	)
+	src := types.InstantiateContractProposalFixture(func(p *types.InstantiateContractProposal) {
+		p.CodeID = firstCodeID
+		p.Admin = ""
+	})
+	em := sdk.NewEventManager()
+
+	// when stored
+	storedProposal, err := govKeeper.SubmitProposal(ctx, src)
+	require.NoError(t, err)
+
+	// and proposal execute
+	handler := govKeeper.Router().GetRoute(storedProposal.ProposalRoute())
+	err = handler(ctx.WithEventManager(em), storedProposal.GetContent())
+	require.NoError(t, err)
+
+	// then
+	cInfo := wasmKeeper.GetContractInfo(ctx, BuildContractAddress(1, 1))
+	require.NotNil(t, cInfo)
+	assert.Empty(t, cInfo.Admin)
+	// and event
+	require.Len(t, em.Events(), 2, "%#v", em.Events())
+	require.Len(t, em.Events()[1].Attributes, 7)
+	assert.Equal(t, types.AttributeKeyImmutable, string(em.Events()[1].Attributes[6].Key))
+	assert.Equal(t, "true", string(em.Events()[1].Attributes[6].Value))
+}
+
+func TestStoreAndInstantiateProposalWithoutRunAs(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, "staking")
+	govKeeper, wasmKeeper := keepers.GovKeeper, keepers.WasmKeeper
+	wasmKeeper.setParams(ctx, types.Params{
+		CodeUploadAccess:             types.AllowNobody,
+		InstantiateDefaultPermission: types.AccessTypeNobody,
+		MaxWasmCodeSize:              types.DefaultMaxWasmCodeSize,
+	})
+	wasmCode, err := ioutil.ReadFile("./testdata/hackatom.wasm")
+	require.NoError(t, err)
+	govAddress := authtypes.NewModuleAddress(govtypes.ModuleName).String()
+	handler := govKeeper.Router().GetRoute(types.RouterKey)
+
+	// when stored without run as
+	storeProposal, err := govKeeper.SubmitProposal(ctx, types.StoreCodeProposalFixture(func(p *types.StoreCodeProposal) {
+		p.RunAs = ""
+		p.WASMByteCode = wasmCode
+	}))
+	require.NoError(t, err)
+	require.NoError(t, handler(ctx, storeProposal.GetContent()))
+
+	// then the gov module account is the creator
+	cInfo := wasmKeeper.GetCodeInfo(ctx, firstCodeID)
+	require.NotNil(t, cInfo)
+	assert.Equal(t, govAddress, cInfo.Creator)
+
+	// when instantiated without run as
+	instantiateProposal, err := govKeeper.SubmitProposal(ctx, types.InstantiateContractProposalFixture(func(p *types.InstantiateContractProposal) {
+		p.CodeID = firstCodeID
+		p.RunAs = ""
+		p.Label = "testing"
+	}))
+	require.NoError(t, err)
+	require.NoError(t, handler(ctx, instantiateProposal.GetContent()))
+
+	// then the gov module account is the creator
+	var contractAddr sdk.AccAddress
+	wasmKeeper.IterateContractsByCode(ctx, firstCodeID, func(addr sdk.AccAddress) bool {
+		contractAddr = addr
+		return true
+	})
+	contractInfo := wasmKeeper.GetContractInfo(ctx, contractAddr)
+	require.NotNil(t, contractInfo)
+	assert.Equal(t, govAddress, contractInfo.Creator)
+}
+
+func TestInstantiateProposalWithFundsFromCommunityPool(t *testing.T) {
+	wasmCode, err := ioutil.ReadFile("./testdata/hackatom.wasm")
+	require.NoError(t, err)
+
+	var (
+		oneAddress   sdk.AccAddress = bytes.Repeat([]byte{0x1}, sdk.AddrLen)
+		otherAddress sdk.AccAddress = bytes.Repeat([]byte{0x2}, sdk.AddrLen)
+		poolFunds                   = sdk.NewCoins(sdk.NewInt64Coin("denom", 100))
+	)
+	specs := map[string]struct {
+		funds  sdk.Coins
+		expErr bool
+	}{
+		"funds drawn from pool": {
+			funds: sdk.NewCoins(sdk.NewInt64Coin("denom", 10)),
+		},
+		"all pool funds": {
+			funds: poolFunds,
+		},
+		"insufficient pool funds": {
+			funds:  sdk.NewCoins(sdk.NewInt64Coin("denom", 101)),
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			ctx, keepers := CreateTestInput(t, false, "staking")
+			govKeeper, wasmKeeper, distKeeper := keepers.GovKeeper, keepers.WasmKeeper, keepers.DistKeeper
+			require.NoError(t, wasmKeeper.importCode(ctx, 1,
+				types.CodeInfoFixture(types.WithSHA256CodeHash(wasmCode)),
+				wasmCode),
+			)
+			funder := createFakeFundedAccount(t, ctx, keepers.AccountKeeper, keepers.BankKeeper, poolFunds)
+			require.NoError(t, distKeeper.FundCommunityPool(ctx, poolFunds, funder))
+
+			src := types.InstantiateContractProposalFixture(func(p *types.InstantiateContractProposal) {
+				p.CodeID = firstCodeID
+				p.RunAs = oneAddress.String()
+				p.Admin = otherAddress.String()
+				p.Funds = spec.funds
+				p.FundsFromCommunityPool = true
+			})
+
+			contractAddr, err := sdk.AccAddressFromBech32("cosmos18vd8fpwxzck93qlwghaj6arh4p7c5n89uzcee5")
+			require.NoError(t, err)
+
+			// when stored
+			storedProposal, err := govKeeper.SubmitProposal(ctx, src)
+			if spec.expErr {
+				// the gov module executes the handler on submission in a cached context
+				require.Error(t, err)
+				assert.Equal(t, sdk.NewDecCoinsFromCoins(poolFunds...), distKeeper.GetFeePoolCommunityCoins(ctx))
+				assert.Nil(t, wasmKeeper.GetContractInfo(ctx, contractAddr))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, sdk.NewDecCoinsFromCoins(poolFunds...), distKeeper.GetFeePoolCommunityCoins(ctx))
+
+			// and proposal execute
+			handler := govKeeper.Router().GetRoute(storedProposal.ProposalRoute())
+			err = handler(ctx, storedProposal.GetContent())
+			require.NoError(t, err)
+
+			// then
+			assert.Equal(t, spec.funds, keepers.BankKeeper.GetAllBalances(ctx, contractAddr))
+			assert.Empty(t, keepers.BankKeeper.GetAllBalances(ctx, oneAddress))
+			expPool := sdk.NewDecCoinsFromCoins(poolFunds.Sub(spec.funds)...)
+			assert.Equal(t, expPool.String(), distKeeper.GetFeePoolCommunityCoins(ctx).String())
+		})
+	}
 }
 
 func TestMigrateProposal(t *testing.T) {
@@ -1,18 +1,29 @@
 package keeper
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"github.com/cosmos/cosmos-sdk/codec"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"runtime/debug"
+	"strconv"
+	"sync/atomic"
+
+	wasmvm "github.com/CosmWasm/wasmvm"
 
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 	"github.com/cosmos/cosmos-sdk/store/prefix"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/cosmos-sdk/types/query"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	host "github.com/cosmos/cosmos-sdk/x/ibc/core/24-host"
 )
 
 var _ types.QueryServer = &grpcQuerier{}
@@ -21,11 +32,44 @@ type grpcQuerier struct {
 	cdc           codec.Marshaler
 	storeKey      sdk.StoreKey
 	keeper        types.ViewKeeper
-	queryGasLimit sdk.Gas
+	queryGasLimit *runtimeGasLimit
+	// limiter is optional and bounds the number of concurrent smart queries
+	limiter *QueryLimiter
+	// proposalHandler is optional and executes the simulated proposals
+	proposalHandler govtypes.Handler
+	// abciQuerier is optional and loads the byte code for the code stream
+	abciQuerier ABCIQuerier
+	// executeSimulator is optional and executes the simulated contract calls
+	executeSimulator types.MsgServer
+	// simulateExecuteGasLimit is the gas cap of the execute simulations. 0 disables them.
+	simulateExecuteGasLimit uint64
+	// simulateExecuteLimiter is optional and bounds the number of execute simulations per second
+	simulateExecuteLimiter *RateLimiter
+	// gasHintsCache is optional and keeps the gas hints of the contracts for a number of blocks
+	gasHintsCache *GasHintsCache
 }
 
 func NewGrpcQuerier(cdc codec.Marshaler, storeKey sdk.StoreKey, keeper types.ViewKeeper, queryGasLimit sdk.Gas) *grpcQuerier {
-	return &grpcQuerier{cdc: cdc, storeKey: storeKey, keeper: keeper, queryGasLimit: queryGasLimit}
+	return &grpcQuerier{cdc: cdc, storeKey: storeKey, keeper: keeper, queryGasLimit: newRuntimeGasLimit(queryGasLimit)}
+}
+
+// runtimeGasLimit is a node local gas limit that can be adjusted while the node is running. It is safe for
+// concurrent use.
+type runtimeGasLimit struct {
+	limit uint64
+}
+
+func newRuntimeGasLimit(limit sdk.Gas) *runtimeGasLimit {
+	return &runtimeGasLimit{limit: limit}
+}
+
+func (r *runtimeGasLimit) get() sdk.Gas {
+	return atomic.LoadUint64(&r.limit)
+}
+
+// set stores the new limit and returns the previous one
+func (r *runtimeGasLimit) set(limit sdk.Gas) sdk.Gas {
+	return atomic.SwapUint64(&r.limit, limit)
 }
 
 func (q grpcQuerier) ContractInfo(c context.Context, req *types.QueryContractInfoRequest) (*types.QueryContractInfoResponse, error) {
@@ -46,6 +90,10 @@ func (q grpcQuerier) ContractInfo(c context.Context, req *types.QueryContractInf
 	return rsp, nil
 }
 
+// maxContractHistoryPageLimit is the max number of entries in a page of the contract history. Larger limits are
+// reduced to it.
+const maxContractHistoryPageLimit = 100
+
 func (q grpcQuerier) ContractHistory(c context.Context, req *types.QueryContractHistoryRequest) (*types.QueryContractHistoryResponse, error) {
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "empty request")
@@ -55,16 +103,27 @@ func (q grpcQuerier) ContractHistory(c context.Context, req *types.QueryContract
 		return nil, err
 	}
 
+	pageReq := req.Pagination
+	if pageReq != nil && pageReq.Limit > maxContractHistoryPageLimit {
+		bounded := *pageReq
+		bounded.Limit = maxContractHistoryPageLimit
+		pageReq = &bounded
+	}
+
 	ctx := sdk.UnwrapSDKContext(c)
 	r := make([]types.ContractCodeHistoryEntry, 0)
 
 	prefixStore := prefix.NewStore(ctx.KVStore(q.storeKey), types.GetContractCodeHistoryElementPrefix(contractAddr))
-	pageRes, err := query.FilteredPaginate(prefixStore, req.Pagination, func(key []byte, value []byte, accumulate bool) (bool, error) {
+	pageRes, err := query.FilteredPaginate(prefixStore, pageReq, func(key []byte, value []byte, accumulate bool) (bool, error) {
+		var e types.ContractCodeHistoryEntry
+		if err := q.cdc.UnmarshalBinaryBare(value, &e); err != nil {
+			return false, err
+		}
+		if req.Operation != types.ContractCodeHistoryOperationTypeUnspecified && e.Operation != req.Operation ||
+			req.CodeId != 0 && e.CodeID != req.CodeId {
+			return false, nil
+		}
 		if accumulate {
-			var e types.ContractCodeHistoryEntry
-			if err := q.cdc.UnmarshalBinaryBare(value, &e); err != nil {
-				return false, err
-			}
 			e.Updated = nil // redact
 			r = append(r, e)
 		}
@@ -91,12 +150,9 @@ func (q grpcQuerier) ContractsByCode(c context.Context, req *types.QueryContract
 	r := make([]string, 0)
 
 	prefixStore := prefix.NewStore(ctx.KVStore(q.storeKey), types.GetContractByCodeIDSecondaryIndexPrefix(req.CodeId))
-	pageRes, err := query.FilteredPaginate(prefixStore, req.Pagination, func(key []byte, value []byte, accumulate bool) (bool, error) {
-		if accumulate {
-			var contractAddr sdk.AccAddress = key[types.AbsoluteTxPositionLen:]
-			r = append(r, contractAddr.String())
-		}
-		return true, nil
+	pageRes, err := query.Paginate(prefixStore, req.Pagination, func(key []byte, _ []byte) error {
+		r = append(r, contractAddressFromCodeIndexKey(key).String())
+		return nil
 	})
 	if err != nil {
 		return nil, err
@@ -158,6 +214,33 @@ func (q grpcQuerier) RawContractState(c context.Context, req *types.QueryRawCont
 	return &types.QueryRawContractStateResponse{Data: rsp}, nil
 }
 
+// cancellableGasMeter aborts the execution with an out of gas panic when the context of the gRPC request is canceled
+// or has exceeded its deadline. The out of gas panic is used as it is propagated through the wasm VM callbacks.
+// Note that the VM can only be aborted on gas consumption by the host, for example on contract store access.
+type cancellableGasMeter struct {
+	sdk.GasMeter
+	ctx context.Context
+}
+
+func newCancellableGasMeter(ctx context.Context, nested sdk.GasMeter) cancellableGasMeter {
+	return cancellableGasMeter{GasMeter: nested, ctx: ctx}
+}
+
+func (m cancellableGasMeter) ConsumeGas(amount sdk.Gas, descriptor string) {
+	if err := m.ctx.Err(); err != nil {
+		panic(sdk.ErrorOutOfGas{Descriptor: "query aborted: " + err.Error()})
+	}
+	m.GasMeter.ConsumeGas(amount, descriptor)
+}
+
+// contextErrorStatus converts a context error into the matching gRPC status error
+func contextErrorStatus(err error) error {
+	if err == context.DeadlineExceeded {
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+	return status.Error(codes.Canceled, err.Error())
+}
+
 func (q grpcQuerier) SmartContractState(c context.Context, req *types.QuerySmartContractStateRequest) (rsp *types.QuerySmartContractStateResponse, err error) {
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "empty request")
@@ -166,10 +249,24 @@ func (q grpcQuerier) SmartContractState(c context.Context, req *types.QuerySmart
 	if err != nil {
 		return nil, err
 	}
-	ctx := sdk.UnwrapSDKContext(c).WithGasMeter(sdk.NewGasMeter(q.queryGasLimit))
+	if err := c.Err(); err != nil {
+		return nil, contextErrorStatus(err)
+	}
+	if q.limiter != nil {
+		release, err := q.limiter.acquire(c)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+	ctx := sdk.UnwrapSDKContext(c).WithGasMeter(newCancellableGasMeter(c, sdk.NewGasMeter(q.queryGasLimit.get())))
 	// recover from out-of-gas panic
 	defer func() {
 		if r := recover(); r != nil {
+			if cErr := c.Err(); cErr != nil {
+				rsp, err = nil, contextErrorStatus(cErr)
+				return
+			}
 			switch rType := r.(type) {
 			case sdk.ErrorOutOfGas:
 				err = sdkerrors.Wrapf(sdkerrors.ErrOutOfGas,
@@ -190,6 +287,9 @@ func (q grpcQuerier) SmartContractState(c context.Context, req *types.QuerySmart
 
 	bz, err := q.keeper.QuerySmart(ctx, contractAddr, req.QueryData)
 	switch {
+	case c.Err() != nil:
+		// the contract may have been aborted by the cancellable gas meter
+		return nil, contextErrorStatus(c.Err())
 	case err != nil:
 		return nil, err
 	case bz == nil:
@@ -219,6 +319,20 @@ func (q grpcQuerier) Code(c context.Context, req *types.QueryCodeRequest) (*type
 	}, nil
 }
 
+func (q grpcQuerier) CodeInfo(c context.Context, req *types.QueryCodeInfoRequest) (*types.QueryCodeInfoResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if req.CodeId == 0 {
+		return nil, sdkerrors.Wrap(types.ErrInvalid, "code id")
+	}
+	info := queryCodeInfo(sdk.UnwrapSDKContext(c), req.CodeId, q.keeper)
+	if info == nil {
+		return nil, types.ErrNotFound
+	}
+	return &types.QueryCodeInfoResponse{CodeInfoResponse: info}, nil
+}
+
 func (q grpcQuerier) Codes(c context.Context, req *types.QueryCodesRequest) (*types.QueryCodesResponse, error) {
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "empty request")
@@ -255,9 +369,14 @@ func queryContractInfo(ctx sdk.Context, addr sdk.AccAddress, keeper types.ViewKe
 	}
 	// redact the Created field (just used for sorting, not part of public API)
 	info.Created = nil
+	var channelIDs []string
+	if k, ok := keeper.(types.ChannelsViewKeeper); ok && info.IBCPortID != "" {
+		channelIDs = k.GetOpenChannelIDs(ctx, info.IBCPortID)
+	}
 	return &types.QueryContractInfoResponse{
-		Address:      addr.String(),
-		ContractInfo: *info,
+		Address:        addr.String(),
+		ContractInfo:   *info,
+		OpenChannelIDs: channelIDs,
 	}, nil
 }
 
@@ -265,23 +384,508 @@ func queryCode(ctx sdk.Context, codeID uint64, keeper types.ViewKeeper) (*types.
 	if codeID == 0 {
 		return nil, nil
 	}
-	res := keeper.GetCodeInfo(ctx, codeID)
-	if res == nil {
+	info := queryCodeInfo(ctx, codeID, keeper)
+	if info == nil {
 		// nil, nil leads to 404 in rest handler
 		return nil, nil
 	}
-	info := types.CodeInfoResponse{
+
+	code, err := keeper.GetByteCode(ctx, codeID)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "loading wasm code")
+	}
+
+	return &types.QueryCodeResponse{CodeInfoResponse: info, Data: code}, nil
+}
+
+// queryCodeInfo returns the code metadata without the byte code or nil when not found
+func queryCodeInfo(ctx sdk.Context, codeID uint64, keeper types.ViewKeeper) *types.CodeInfoResponse {
+	res := keeper.GetCodeInfo(ctx, codeID)
+	if res == nil {
+		return nil
+	}
+	return &types.CodeInfoResponse{
 		CodeID:   codeID,
 		Creator:  res.Creator,
 		DataHash: res.CodeHash,
 		Source:   res.Source,
 		Builder:  res.Builder,
 	}
+}
 
-	code, err := keeper.GetByteCode(ctx, codeID)
+func (q grpcQuerier) WasmCacheStatus(c context.Context, req *types.QueryWasmCacheStatusRequest) (*types.QueryWasmCacheStatusResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	k, ok := q.keeper.(types.WasmVMMetricsViewKeeper)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "cache status query not supported")
+	}
+	m, enabled, err := k.GetWasmVMMetrics()
+	switch {
+	case !enabled:
+		return nil, status.Error(codes.Unavailable, "cache status query not enabled on this node")
+	case err != nil:
+		return nil, err
+	}
+	rsp := &types.QueryWasmCacheStatusResponse{
+		PinnedCodeIDs:             make([]uint64, 0),
+		HitsPinnedMemoryCache:     uint64(m.HitsPinnedMemoryCache),
+		HitsMemoryCache:           uint64(m.HitsMemoryCache),
+		HitsFsCache:               uint64(m.HitsFsCache),
+		Misses:                    uint64(m.Misses),
+		HitRate:                   sdk.ZeroDec(),
+		ElementsPinnedMemoryCache: m.ElementsPinnedMemoryCache,
+		ElementsMemoryCache:       m.ElementsMemoryCache,
+		SizePinnedMemoryCache:     m.SizePinnedMemoryCache,
+		SizeMemoryCache:           m.SizeMemoryCache,
+	}
+	hits := rsp.HitsPinnedMemoryCache + rsp.HitsMemoryCache + rsp.HitsFsCache
+	if total := hits + rsp.Misses; total != 0 {
+		rsp.HitRate = sdk.NewDec(int64(hits)).QuoInt64(int64(total))
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	iter := prefix.NewStore(ctx.KVStore(q.storeKey), types.PinnedCodeIndexPrefix).Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		rsp.PinnedCodeIDs = append(rsp.PinnedCodeIDs, types.ParsePinnedCodeIndex(iter.Key()))
+	}
+	return rsp, nil
+}
+
+func (q grpcQuerier) ContractStoreAuditLog(c context.Context, req *types.QueryContractStoreAuditLogRequest) (*types.QueryContractStoreAuditLogResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(req.Address)
 	if err != nil {
-		return nil, sdkerrors.Wrap(err, "loading wasm code")
+		return nil, err
+	}
+	k, ok := q.keeper.(types.StoreAuditLogViewKeeper)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "store audit log not supported")
+	}
+	entries, enabled := k.GetStoreAuditLog(contractAddr)
+	if !enabled {
+		return nil, status.Error(codes.Unavailable, "store audit log not enabled on this node")
+	}
+	return &types.QueryContractStoreAuditLogResponse{Entries: entries}, nil
+}
+
+func (q grpcQuerier) ContractByPortID(c context.Context, req *types.QueryContractByPortIDRequest) (*types.QueryContractByPortIDResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if err := host.PortIdentifierValidator(req.PortId); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	k, ok := q.keeper.(types.ContractByPortViewKeeper)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "contract by port id not supported")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	contractAddr, err := k.ContractByPortID(ctx, req.PortId)
+	if err != nil || !q.keeper.HasContractInfo(ctx, contractAddr) {
+		return nil, types.ErrNotFound
+	}
+	return &types.QueryContractByPortIDResponse{Address: contractAddr.String()}, nil
+}
+
+func (q grpcQuerier) CodeExecutionStats(c context.Context, req *types.QueryCodeExecutionStatsRequest) (*types.QueryCodeExecutionStatsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if req.CodeId == 0 {
+		return nil, sdkerrors.Wrap(types.ErrInvalid, "code id")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	r := make([]types.CodeExecutionStats, 0)
+	prefixStore := prefix.NewStore(ctx.KVStore(q.storeKey), types.GetCodeExecutionStatsPrefix(req.CodeId))
+	pageRes, err := query.FilteredPaginate(prefixStore, req.Pagination, func(key []byte, value []byte, accumulate bool) (bool, error) {
+		if accumulate {
+			var stats types.CodeExecutionStats
+			if err := q.cdc.UnmarshalBinaryBare(value, &stats); err != nil {
+				return false, err
+			}
+			r = append(r, stats)
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryCodeExecutionStatsResponse{Stats: r, Pagination: pageRes}, nil
+}
+
+func (q grpcQuerier) ContractDeniedDenoms(c context.Context, req *types.QueryContractDeniedDenomsRequest) (*types.QueryContractDeniedDenomsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, err
+	}
+	k, ok := q.keeper.(types.DeniedDenomsViewKeeper)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "denied denoms not supported")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	if !q.keeper.HasContractInfo(ctx, contractAddr) {
+		return nil, types.ErrNotFound
+	}
+	denoms := k.GetDeniedDenoms(ctx, contractAddr)
+	if denoms == nil {
+		denoms = make([]string, 0)
+	}
+	return &types.QueryContractDeniedDenomsResponse{Denoms: denoms}, nil
+}
+
+// SimulateProposal executes the proposal in a cache context that is never committed. Failures of the proposal
+// execution are returned in the response together with the gas consumed up to this point.
+// The execution is metered with the smart query gas limit. Store code proposals are executed with a wasm VM that only
+// checks the wasm magic bytes and returns the checksum, the code is neither compiled nor stored. Pin and unpin
+// proposals are not supported as they modify the wasm VM cache in memory which can not be discarded with the context.
+func (q grpcQuerier) SimulateProposal(c context.Context, req *types.QuerySimulateProposalRequest) (*types.QuerySimulateProposalResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
 	}
+	if q.proposalHandler == nil {
+		return nil, status.Error(codes.Unimplemented, "proposal simulation not supported")
+	}
+	content := req.GetProposal()
+	if content == nil {
+		return nil, status.Error(codes.InvalidArgument, "proposal")
+	}
+	if content.ProposalRoute() != types.RouterKey {
+		return nil, status.Errorf(codes.InvalidArgument, "not a wasm proposal: %s", content.ProposalRoute())
+	}
+	if modifiesVMCache(content) {
+		return nil, status.Errorf(codes.InvalidArgument, "proposal type can not be simulated: %s", content.ProposalType())
+	}
+	if q.limiter != nil {
+		release, err := q.limiter.acquire(c)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+	ctx, _ := sdk.UnwrapSDKContext(c).CacheContext()
+	em := sdk.NewEventManager()
+	ctx = ctx.WithGasMeter(newCancellableGasMeter(c, sdk.NewGasMeter(q.queryGasLimit.get()))).WithEventManager(em)
+	rsp := types.QuerySimulateProposalResponse{}
+	err := func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				if rType, ok := r.(sdk.ErrorOutOfGas); ok {
+					err = sdkerrors.Wrapf(sdkerrors.ErrOutOfGas, "out of gas in location: %v; gasWanted: %d", rType.Descriptor, ctx.GasMeter().Limit())
+					return
+				}
+				err = sdkerrors.Wrapf(sdkerrors.ErrPanic, "%v", r)
+			}
+		}()
+		return q.proposalHandler(ctx, content)
+	}()
+	rsp.GasUsed = ctx.GasMeter().GasConsumed()
+	if err != nil {
+		rsp.Error = err.Error()
+		return &rsp, nil
+	}
+	// the proposal handler emits its event last, after the events of the contract calls
+	events := em.Events()
+	if len(events) == 0 {
+		return &rsp, nil
+	}
+	for _, a := range events[len(events)-1].Attributes {
+		switch string(a.Key) {
+		case types.AttributeKeyCodeID:
+			if rsp.CodeID, err = strconv.ParseUint(string(a.Value), 10, 64); err != nil {
+				return nil, err
+			}
+		case types.AttributeKeyContractAddr:
+			rsp.ContractAddress = string(a.Value)
+		case types.AttributeResultDataHex:
+			if rsp.Data, err = hex.DecodeString(string(a.Value)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return &rsp, nil
+}
+
+// modifiesVMCache returns true for the proposals that pin or unpin code in the wasm VM
+func modifiesVMCache(content govtypes.Content) bool {
+	switch content.(type) {
+	case *types.PinCodesProposal, *types.UnpinCodesProposal:
+		return true
+	default:
+		return false
+	}
+}
+
+// simulationEngine decorates the wasmvm engine so that new code is not persisted in the wasm VM cache
+type simulationEngine struct {
+	types.WasmerEngine
+}
+
+// Create returns the checksum of the wasm code without compiling or storing it
+func (e simulationEngine) Create(code wasmvm.WasmCode) (wasmvm.Checksum, error) {
+	if !bytes.HasPrefix(code, wasmIdent) {
+		return nil, errors.New("not a wasm binary")
+	}
+	checksum := sha256.Sum256(code)
+	return checksum[:], nil
+}
+
+func (q grpcQuerier) SimulateExecute(c context.Context, req *types.QuerySimulateExecuteRequest) (*types.QuerySimulateExecuteResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if q.executeSimulator == nil || q.simulateExecuteGasLimit == 0 {
+		return nil, status.Error(codes.Unimplemented, "execute simulation not enabled")
+	}
+	msg := types.MsgExecuteContract{
+		Sender:   req.Sender,
+		Contract: req.Contract,
+		Msg:      req.Msg,
+		Funds:    req.Funds,
+	}
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if q.simulateExecuteLimiter != nil {
+		if err := q.simulateExecuteLimiter.allow(); err != nil {
+			return nil, err
+		}
+	}
+	ctx, _ := sdk.UnwrapSDKContext(c).CacheContext()
+	ctx = ctx.WithGasMeter(sdk.NewGasMeter(q.simulateExecuteGasLimit)).WithEventManager(sdk.NewEventManager())
+	rsp := types.QuerySimulateExecuteResponse{GasLimit: q.simulateExecuteGasLimit}
+	err := func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				if _, ok := r.(sdk.ErrorOutOfGas); ok {
+					err = sdkerrors.Wrapf(sdkerrors.ErrOutOfGas, "gas limit %d", q.simulateExecuteGasLimit)
+					return
+				}
+				err = sdkerrors.Wrapf(sdkerrors.ErrPanic, "%v", r)
+			}
+		}()
+		_, err = q.executeSimulator.ExecuteContract(sdk.WrapSDKContext(ctx), &msg)
+		return err
+	}()
+	rsp.GasUsed = ctx.GasMeter().GasConsumed()
+	if err != nil {
+		rsp.Error = err.Error()
+	}
+	return &rsp, nil
+}
+
+func (q grpcQuerier) ModuleVersion(c context.Context, req *types.QueryModuleVersionRequest) (*types.QueryModuleVersionResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	rsp := types.QueryModuleVersionResponse{
+		ConsensusVersion: types.ModuleConsensusVersion,
+		WasmvmVersion:    WasmvmVersion(),
+	}
+	if k, ok := q.keeper.(types.SupportedFeaturesViewKeeper); ok {
+		rsp.SupportedFeatures = k.GetSupportedFeatures()
+	}
+	return &rsp, nil
+}
+
+func (q grpcQuerier) GasCosts(c context.Context, req *types.QueryGasCostsRequest) (*types.QueryGasCostsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	k, ok := q.keeper.(types.GasCostsViewKeeper)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "gas costs not supported")
+	}
+	gasCosts, ok := k.GetGasCosts()
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "gas costs not provided by the gas register")
+	}
+	return &types.QueryGasCostsResponse{GasCosts: gasCosts}, nil
+}
+
+func (q grpcQuerier) ContractBalance(c context.Context, req *types.QueryContractBalanceRequest) (*types.QueryContractBalanceResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, err
+	}
+	k, ok := q.keeper.(types.ContractBalanceViewKeeper)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "contract balance not supported")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	if !q.keeper.HasContractInfo(ctx, contractAddr) {
+		return nil, types.ErrNotFound
+	}
+	rsp := types.QueryContractBalanceResponse{
+		Balance:         k.GetContractBalance(ctx, contractAddr),
+		ExpectedBalance: sdk.NewCoins(),
+		Unaccounted:     sdk.NewCoins(),
+	}
+	queryData, err := json.Marshal(ExpectedBalanceQuery{ExpectedBalance: &struct{}{}})
+	if err != nil {
+		return nil, err
+	}
+	// the smart query is executed with the query gas limit
+	smartRsp, err := q.SmartContractState(c, &types.QuerySmartContractStateRequest{Address: req.Address, QueryData: queryData})
+	if err != nil {
+		if c.Err() != nil {
+			return nil, err
+		}
+		rsp.ExpectedBalanceError = err.Error()
+		return &rsp, nil
+	}
+	var expected ExpectedBalanceResponse
+	if err := json.Unmarshal(smartRsp.Data, &expected); err != nil {
+		rsp.ExpectedBalanceError = "invalid expected balance response: " + err.Error()
+		return &rsp, nil
+	}
+	expectedCoins, err := convertWasmCoinsToSdkCoins(expected.Amount)
+	if err != nil {
+		rsp.ExpectedBalanceError = "invalid expected balance amount: " + err.Error()
+		return &rsp, nil
+	}
+	rsp.ExpectedBalance = expectedCoins.Sort()
+	for _, coin := range rsp.Balance {
+		if diff := coin.Amount.Sub(rsp.ExpectedBalance.AmountOf(coin.Denom)); diff.IsPositive() {
+			rsp.Unaccounted = rsp.Unaccounted.Add(sdk.NewCoin(coin.Denom, diff))
+		}
+	}
+	return &rsp, nil
+}
+
+func (q grpcQuerier) ContractGasHints(c context.Context, req *types.QueryContractGasHintsRequest) (*types.QueryContractGasHintsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, err
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	info := q.keeper.GetContractInfo(ctx, contractAddr)
+	if info == nil {
+		return nil, types.ErrNotFound
+	}
+	if q.gasHintsCache != nil {
+		if rsp, ok := q.gasHintsCache.get(ctx, contractAddr, info.CodeID); ok {
+			return &rsp, nil
+		}
+	}
+	queryData, err := json.Marshal(GasHintsQuery{GasHints: &struct{}{}})
+	if err != nil {
+		return nil, err
+	}
+	rsp := types.QueryContractGasHintsResponse{Hints: []types.GasHint{}, Height: ctx.BlockHeight()}
+	// the smart query is executed with the query gas limit
+	smartRsp, err := q.SmartContractState(c, &types.QuerySmartContractStateRequest{Address: req.Address, QueryData: queryData})
+	switch {
+	case err != nil && c.Err() != nil:
+		return nil, err
+	case err != nil:
+		rsp.HintsError = err.Error()
+	default:
+		if hints, err := parseGasHints(smartRsp.Data); err != nil {
+			rsp.HintsError = err.Error()
+		} else {
+			rsp.Hints = hints
+		}
+	}
+	if q.gasHintsCache != nil {
+		q.gasHintsCache.set(contractAddr, info.CodeID, rsp)
+	}
+	return &rsp, nil
+}
 
-	return &types.QueryCodeResponse{CodeInfoResponse: &info, Data: code}, nil
+// InterchainQueries lists the interchain queries ordered by id. When an owner is set only the queries of the contract
+// are returned.
+func (q grpcQuerier) InterchainQueries(c context.Context, req *types.QueryInterchainQueriesRequest) (*types.QueryInterchainQueriesResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	r := make([]types.InterchainQuery, 0)
+
+	var pageRes *query.PageResponse
+	var err error
+	if req.Owner == "" {
+		prefixStore := prefix.NewStore(ctx.KVStore(q.storeKey), types.InterchainQueryPrefix)
+		pageRes, err = query.FilteredPaginate(prefixStore, req.Pagination, func(key []byte, value []byte, accumulate bool) (bool, error) {
+			if accumulate {
+				var e types.InterchainQuery
+				if err := q.cdc.UnmarshalBinaryBare(value, &e); err != nil {
+					return false, err
+				}
+				r = append(r, e)
+			}
+			return true, nil
+		})
+	} else {
+		ownerAddr, addrErr := sdk.AccAddressFromBech32(req.Owner)
+		if addrErr != nil {
+			return nil, sdkerrors.Wrap(addrErr, "owner")
+		}
+		prefixStore := prefix.NewStore(ctx.KVStore(q.storeKey), types.GetInterchainQueryByOwnerPrefix(ownerAddr))
+		pageRes, err = query.FilteredPaginate(prefixStore, req.Pagination, func(key []byte, value []byte, accumulate bool) (bool, error) {
+			if accumulate {
+				bz := ctx.KVStore(q.storeKey).Get(types.GetInterchainQueryKey(sdk.BigEndianToUint64(key)))
+				if bz == nil {
+					return false, types.ErrNotFound
+				}
+				var e types.InterchainQuery
+				if err := q.cdc.UnmarshalBinaryBare(bz, &e); err != nil {
+					return false, err
+				}
+				r = append(r, e)
+			}
+			return true, nil
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryInterchainQueriesResponse{
+		Queries:    r,
+		Pagination: pageRes,
+	}, nil
+}
+
+// Jobs lists the pending jobs of the end blocker, optionally filtered by the type
+func (q grpcQuerier) Jobs(c context.Context, req *types.QueryJobsRequest) (*types.QueryJobsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	r := make([]types.Job, 0)
+
+	prefixStore := prefix.NewStore(ctx.KVStore(q.storeKey), types.JobPrefix)
+	pageRes, err := query.FilteredPaginate(prefixStore, req.Pagination, func(key []byte, value []byte, accumulate bool) (bool, error) {
+		var e types.Job
+		if err := q.cdc.UnmarshalBinaryBare(value, &e); err != nil {
+			return false, err
+		}
+		if req.Type != "" && e.Type != req.Type {
+			return false, nil
+		}
+		if accumulate {
+			r = append(r, e)
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryJobsResponse{
+		Jobs:       r,
+		Pagination: pageRes,
+	}, nil
 }
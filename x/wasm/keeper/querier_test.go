@@ -1,8 +1,11 @@
 package keeper
 
 import (
+	"context"
+	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
 	"github.com/CosmWasm/wasmd/x/wasm/types"
@@ -12,9 +15,12 @@ import (
 	sdkErrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/cosmos-sdk/types/query"
 	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	channeltypes "github.com/cosmos/cosmos-sdk/x/ibc/core/04-channel/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/tendermint/tendermint/libs/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"io/ioutil"
 	"testing"
 	"time"
@@ -194,6 +200,61 @@ func TestQuerySmartContractPanics(t *testing.T) {
 	}
 }
 
+func TestQuerySmartContractCanceled(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	contractAddr := BuildContractAddress(1, 1)
+	keepers.WasmKeeper.storeCodeInfo(ctx, 1, types.CodeInfo{})
+	keepers.WasmKeeper.storeContractInfo(ctx, contractAddr, &types.ContractInfo{
+		CodeID:  1,
+		Created: types.NewAbsoluteTxPosition(ctx),
+	})
+	ctx = ctx.WithLogger(log.TestingLogger())
+
+	specs := map[string]struct {
+		setup   func(parent context.Context) (context.Context, context.CancelFunc)
+		doInVM  func(cancel context.CancelFunc, store cosmwasm.KVStore)
+		expCode codes.Code
+		expCall bool
+	}{
+		"canceled during execution": {
+			setup: context.WithCancel,
+			doInVM: func(cancel context.CancelFunc, store cosmwasm.KVStore) {
+				cancel()
+				store.Get([]byte("foo"))
+			},
+			expCode: codes.Canceled,
+			expCall: true,
+		},
+		"deadline exceeded before execution": {
+			setup: func(parent context.Context) (context.Context, context.CancelFunc) {
+				return context.WithDeadline(parent, time.Now().Add(-time.Second))
+			},
+			expCode: codes.DeadlineExceeded,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			c, cancel := spec.setup(sdk.WrapSDKContext(ctx))
+			defer cancel()
+			var called bool
+			keepers.WasmKeeper.wasmVM = &wasmtesting.MockWasmer{QueryFn: func(checksum cosmwasm.Checksum, env wasmvmtypes.Env, queryMsg []byte, store cosmwasm.KVStore, goapi cosmwasm.GoAPI, querier cosmwasm.Querier, gasMeter cosmwasm.GasMeter, gasLimit uint64) ([]byte, uint64, error) {
+				called = true
+				spec.doInVM(cancel, store)
+				return []byte(`{}`), 0, nil
+			}}
+			// when
+			q := Querier(keepers.WasmKeeper)
+			got, err := q.SmartContractState(c, &types.QuerySmartContractStateRequest{
+				Address: contractAddr.String(),
+			})
+			// then
+			assert.Equal(t, spec.expCode, status.Code(err), "got error: %+v", err)
+			assert.Nil(t, got)
+			assert.Equal(t, spec.expCall, called)
+		})
+	}
+}
+
 func TestQueryRawContractState(t *testing.T) {
 	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
 	keeper := keepers.WasmKeeper
@@ -314,6 +375,27 @@ func TestQueryContractHistory(t *testing.T) {
 		otherBech32Addr      = RandomBech32AccountAddress(t)
 	)
 
+	threeEntries := []types.ContractCodeHistoryEntry{{
+		Operation: types.ContractCodeHistoryOperationTypeInit,
+		CodeID:    firstCodeID,
+		Updated:   types.NewAbsoluteTxPosition(ctx),
+		Msg:       []byte(`"init message"`),
+	}, {
+		Operation: types.ContractCodeHistoryOperationTypeMigrate,
+		CodeID:    2,
+		Updated:   types.NewAbsoluteTxPosition(ctx),
+		Msg:       []byte(`"migrate message 1"`),
+	}, {
+		Operation: types.ContractCodeHistoryOperationTypeMigrate,
+		CodeID:    3,
+		Updated:   types.NewAbsoluteTxPosition(ctx),
+		Msg:       []byte(`"migrate message 2"`),
+	}}
+	manyEntries := make([]types.ContractCodeHistoryEntry, maxContractHistoryPageLimit+1)
+	for i := range manyEntries {
+		manyEntries[i] = types.ContractCodeHistoryEntry{Operation: types.ContractCodeHistoryOperationTypeMigrate, CodeID: firstCodeID, Updated: types.NewAbsoluteTxPosition(ctx)}
+	}
+
 	specs := map[string]struct {
 		srcHistory []types.ContractCodeHistoryEntry
 		req        types.QueryContractHistoryRequest
@@ -413,6 +495,61 @@ func TestQueryContractHistory(t *testing.T) {
 				Msg:       []byte(`"init message"`),
 			}},
 		},
+		"filtered by operation": {
+			srcHistory: threeEntries,
+			req: types.QueryContractHistoryRequest{
+				Address:   myContractBech32Addr,
+				Operation: types.ContractCodeHistoryOperationTypeMigrate,
+			},
+			expContent: []types.ContractCodeHistoryEntry{{
+				Operation: types.ContractCodeHistoryOperationTypeMigrate,
+				CodeID:    2,
+				Msg:       []byte(`"migrate message 1"`),
+			}, {
+				Operation: types.ContractCodeHistoryOperationTypeMigrate,
+				CodeID:    3,
+				Msg:       []byte(`"migrate message 2"`),
+			}},
+		},
+		"filtered by code id": {
+			srcHistory: threeEntries,
+			req: types.QueryContractHistoryRequest{
+				Address: myContractBech32Addr,
+				CodeId:  3,
+			},
+			expContent: []types.ContractCodeHistoryEntry{{
+				Operation: types.ContractCodeHistoryOperationTypeMigrate,
+				CodeID:    3,
+				Msg:       []byte(`"migrate message 2"`),
+			}},
+		},
+		"filtered with pagination limit": {
+			srcHistory: threeEntries,
+			req: types.QueryContractHistoryRequest{
+				Address:    myContractBech32Addr,
+				Operation:  types.ContractCodeHistoryOperationTypeMigrate,
+				Pagination: &query.PageRequest{Limit: 1},
+			},
+			expContent: []types.ContractCodeHistoryEntry{{
+				Operation: types.ContractCodeHistoryOperationTypeMigrate,
+				CodeID:    2,
+				Msg:       []byte(`"migrate message 1"`),
+			}},
+		},
+		"pagination limit bounded": {
+			srcHistory: manyEntries,
+			req: types.QueryContractHistoryRequest{
+				Address:    myContractBech32Addr,
+				Pagination: &query.PageRequest{Limit: 1000},
+			},
+			expContent: func() []types.ContractCodeHistoryEntry {
+				r := make([]types.ContractCodeHistoryEntry, maxContractHistoryPageLimit)
+				for i := range r {
+					r[i] = types.ContractCodeHistoryEntry{Operation: types.ContractCodeHistoryOperationTypeMigrate, CodeID: firstCodeID}
+				}
+				return r
+			}(),
+		},
 		"unknown contract address": {
 			req: types.QueryContractHistoryRequest{Address: otherBech32Addr},
 			srcHistory: []types.ContractCodeHistoryEntry{{
@@ -521,6 +658,55 @@ func TestQueryCodeList(t *testing.T) {
 	}
 }
 
+func TestQueryCodeInfo(t *testing.T) {
+	wasmCode, err := ioutil.ReadFile("./testdata/hackatom.wasm")
+	require.NoError(t, err)
+
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	keeper := keepers.WasmKeeper
+	codeInfo := types.CodeInfoFixture(types.WithSHA256CodeHash(wasmCode))
+	require.NoError(t, keeper.importCode(ctx, 1, codeInfo, wasmCode))
+
+	specs := map[string]struct {
+		src    *types.QueryCodeInfoRequest
+		expRsp *types.QueryCodeInfoResponse
+		expErr *sdkErrors.Error
+	}{
+		"found": {
+			src: &types.QueryCodeInfoRequest{CodeId: 1},
+			expRsp: &types.QueryCodeInfoResponse{
+				CodeInfoResponse: &types.CodeInfoResponse{
+					CodeID:   1,
+					Creator:  codeInfo.Creator,
+					DataHash: codeInfo.CodeHash,
+					Source:   codeInfo.Source,
+					Builder:  codeInfo.Builder,
+				},
+			},
+		},
+		"not found": {
+			src:    &types.QueryCodeInfoRequest{CodeId: 2},
+			expErr: types.ErrNotFound,
+		},
+		"invalid code id": {
+			src:    &types.QueryCodeInfoRequest{},
+			expErr: types.ErrInvalid,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			q := Querier(keeper)
+			gotRsp, gotErr := q.CodeInfo(sdk.WrapSDKContext(ctx), spec.src)
+			if spec.expErr != nil {
+				require.True(t, spec.expErr.Is(gotErr), "but got %+v", gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expRsp, gotRsp)
+		})
+	}
+}
+
 func TestQueryContractInfo(t *testing.T) {
 	var (
 		contractAddr = RandomAccountAddress(t)
@@ -535,7 +721,7 @@ func TestQueryContractInfo(t *testing.T) {
 	govtypes.RegisterInterfaces(keepers.EncodingConfig.InterfaceRegistry)
 
 	k := keepers.WasmKeeper
-	querier := NewGrpcQuerier(k.cdc, k.storeKey, k, k.queryGasLimit)
+	querier := NewGrpcQuerier(k.cdc, k.storeKey, k, k.QueryGasLimit())
 	myExtension := func(info *types.ContractInfo) {
 		// abuse gov proposal as a random protobuf extension with an Any type
 		myExt, err := govtypes.NewProposal(&govtypes.TextProposal{Title: "foo", Description: "bar"}, 1, anyDate, anyDate)
@@ -543,11 +729,15 @@ func TestQueryContractInfo(t *testing.T) {
 		myExt.TotalDeposit = nil
 		info.SetExtension(&myExt)
 	}
+	withPort := func(info *types.ContractInfo) {
+		info.IBCPortID = PortIDForContract(contractAddr)
+	}
 	specs := map[string]struct {
-		src    *types.QueryContractInfoRequest
-		stored types.ContractInfo
-		expRsp *types.QueryContractInfoResponse
-		expErr bool
+		src      *types.QueryContractInfoRequest
+		stored   types.ContractInfo
+		channels map[string]channeltypes.State
+		expRsp   *types.QueryContractInfoResponse
+		expErr   bool
 	}{
 		"found": {
 			src:    &types.QueryContractInfoRequest{Address: contractAddr.String()},
@@ -569,6 +759,33 @@ func TestQueryContractInfo(t *testing.T) {
 				}),
 			},
 		},
+		"with ibc port": {
+			src:    &types.QueryContractInfoRequest{Address: contractAddr.String()},
+			stored: types.ContractInfoFixture(withPort),
+			channels: map[string]channeltypes.State{
+				"channel-0": channeltypes.OPEN,
+				"channel-1": channeltypes.CLOSED,
+				"channel-2": channeltypes.OPEN,
+				"channel-3": channeltypes.INIT,
+			},
+			expRsp: &types.QueryContractInfoResponse{
+				Address: contractAddr.String(),
+				ContractInfo: types.ContractInfoFixture(withPort, func(info *types.ContractInfo) {
+					info.Created = nil // not returned on queries
+				}),
+				OpenChannelIDs: []string{"channel-0", "channel-2"},
+			},
+		},
+		"with ibc port without channels": {
+			src:    &types.QueryContractInfoRequest{Address: contractAddr.String()},
+			stored: types.ContractInfoFixture(withPort),
+			expRsp: &types.QueryContractInfoResponse{
+				Address: contractAddr.String(),
+				ContractInfo: types.ContractInfoFixture(withPort, func(info *types.ContractInfo) {
+					info.Created = nil // not returned on queries
+				}),
+			},
+		},
 		"not found": {
 			src:    &types.QueryContractInfoRequest{Address: RandomBech32AccountAddress(t)},
 			stored: types.ContractInfoFixture(),
@@ -579,6 +796,14 @@ func TestQueryContractInfo(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			xCtx, _ := ctx.CacheContext()
 			k.storeContractInfo(xCtx, contractAddr, &spec.stored)
+			for channelID, state := range spec.channels {
+				keepers.IBCKeeper.ChannelKeeper.SetChannel(xCtx, spec.stored.IBCPortID, channelID, channeltypes.Channel{
+					State:          state,
+					Ordering:       channeltypes.UNORDERED,
+					Counterparty:   channeltypes.NewCounterparty("other", "channel-9"),
+					ConnectionHops: []string{"connection-0"},
+				})
+			}
 			// when
 			gotRsp, gotErr := querier.ContractInfo(sdk.WrapSDKContext(xCtx), spec.src)
 			if spec.expErr {
@@ -599,3 +824,406 @@ func fromBase64(s string) []byte {
 	}
 	return r
 }
+
+func TestQueryWasmCacheStatus(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	k := keepers.WasmKeeper
+	k.wasmVM = &wasmtesting.MockWasmer{
+		PinFn: func(checksum cosmwasm.Checksum) error { return nil },
+		GetMetricsFn: func() (*wasmvmtypes.Metrics, error) {
+			return &wasmvmtypes.Metrics{
+				HitsPinnedMemoryCache:     2,
+				HitsMemoryCache:           1,
+				Misses:                    1,
+				ElementsPinnedMemoryCache: 1,
+				ElementsMemoryCache:       3,
+				SizePinnedMemoryCache:     100,
+				SizeMemoryCache:           300,
+			}, nil
+		},
+	}
+	k.storeCodeInfo(ctx, 1, types.CodeInfoFixture())
+	k.storeCodeInfo(ctx, 2, types.CodeInfoFixture())
+	require.NoError(t, k.pinCode(ctx, 2))
+
+	// disabled by default
+	q := Querier(k)
+	_, err := q.WasmCacheStatus(sdk.WrapSDKContext(ctx), &types.QueryWasmCacheStatusRequest{})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	// when
+	k.cacheStatusQuery = true
+	rsp, err := Querier(k).WasmCacheStatus(sdk.WrapSDKContext(ctx), &types.QueryWasmCacheStatusRequest{})
+
+	// then
+	require.NoError(t, err)
+	exp := &types.QueryWasmCacheStatusResponse{
+		PinnedCodeIDs:             []uint64{2},
+		HitsPinnedMemoryCache:     2,
+		HitsMemoryCache:           1,
+		Misses:                    1,
+		HitRate:                   sdk.NewDecWithPrec(75, 2),
+		ElementsPinnedMemoryCache: 1,
+		ElementsMemoryCache:       3,
+		SizePinnedMemoryCache:     100,
+		SizeMemoryCache:           300,
+	}
+	assert.Equal(t, exp, rsp)
+}
+
+func TestQueryContractByPortID(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	k := keepers.WasmKeeper
+	contractAddr := BuildContractAddress(1, 1)
+	contractInfo := types.ContractInfoFixture(func(info *types.ContractInfo) {
+		info.IBCPortID = "myPort"
+	})
+	k.storeContractInfo(ctx, contractAddr, &contractInfo)
+	k.storePortIDMapping(ctx, "myPort", contractAddr)
+
+	specs := map[string]struct {
+		src         string
+		expAddr     string
+		expNotFound bool
+		expInvalid  bool
+	}{
+		"stored mapping": {
+			src:     "myPort",
+			expAddr: contractAddr.String(),
+		},
+		"decoded port id": {
+			src:     PortIDForContract(contractAddr),
+			expAddr: contractAddr.String(),
+		},
+		"unknown contract": {
+			src:         PortIDForContract(RandomAccountAddress(t)),
+			expNotFound: true,
+		},
+		"unknown port": {
+			src:         "otherPort",
+			expNotFound: true,
+		},
+		"invalid port id": {
+			src:        "a",
+			expInvalid: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			rsp, err := Querier(k).ContractByPortID(sdk.WrapSDKContext(ctx), &types.QueryContractByPortIDRequest{PortId: spec.src})
+			switch {
+			case spec.expNotFound:
+				assert.True(t, types.ErrNotFound.Is(err), "got %+v", err)
+				return
+			case spec.expInvalid:
+				assert.Equal(t, codes.InvalidArgument, status.Code(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, spec.expAddr, rsp.Address)
+		})
+	}
+}
+
+func TestQuerySimulateProposal(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	k := keepers.WasmKeeper
+	example := StoreHackatomExampleContract(t, ctx, keepers)
+	wasmCode, err := ioutil.ReadFile("./testdata/burner.wasm")
+	require.NoError(t, err)
+	burnerChecksum := sha256.Sum256(wasmCode)
+
+	specs := map[string]struct {
+		src             govtypes.Content
+		gasLimit        sdk.Gas
+		expCodeID       uint64
+		expContractAddr string
+		expExecErr      bool
+		expInvalid      bool
+	}{
+		"store code": {
+			src: types.StoreCodeProposalFixture(func(p *types.StoreCodeProposal) {
+				p.WASMByteCode = wasmCode
+			}),
+			expCodeID: example.CodeID + 1,
+		},
+		"store code not wasm": {
+			src: types.StoreCodeProposalFixture(func(p *types.StoreCodeProposal) {
+				p.WASMByteCode = []byte("not wasm")
+			}),
+			expExecErr: true,
+		},
+		"pin codes": {
+			src:        &types.PinCodesProposal{Title: "foo", Description: "bar", CodeIDs: []uint64{example.CodeID}},
+			expInvalid: true,
+		},
+		"unpin codes": {
+			src:        &types.UnpinCodesProposal{Title: "foo", Description: "bar", CodeIDs: []uint64{example.CodeID}},
+			expInvalid: true,
+		},
+		"instantiate out of gas": {
+			src: types.InstantiateContractProposalFixture(func(p *types.InstantiateContractProposal) {
+				p.CodeID = example.CodeID
+			}),
+			gasLimit:   1000,
+			expExecErr: true,
+		},
+		"instantiate": {
+			src: types.InstantiateContractProposalFixture(func(p *types.InstantiateContractProposal) {
+				p.CodeID = example.CodeID
+			}),
+			expCodeID:       example.CodeID,
+			expContractAddr: BuildContractAddress(example.CodeID, 1).String(),
+		},
+		"instantiate unknown code": {
+			src: types.InstantiateContractProposalFixture(func(p *types.InstantiateContractProposal) {
+				p.CodeID = 99
+			}),
+			expExecErr: true,
+		},
+		"invalid proposal": {
+			src: types.InstantiateContractProposalFixture(func(p *types.InstantiateContractProposal) {
+				p.Title = ""
+			}),
+			expExecErr: true,
+		},
+		"non wasm proposal": {
+			src:        govtypes.NewTextProposal("foo", "bar"),
+			expInvalid: true,
+		},
+		"empty": {
+			expInvalid: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			req := &types.QuerySimulateProposalRequest{}
+			if spec.src != nil {
+				req, err = types.NewQuerySimulateProposalRequest(spec.src)
+				require.NoError(t, err)
+			}
+			q := Querier(k)
+			if spec.gasLimit != 0 {
+				q.queryGasLimit = newRuntimeGasLimit(spec.gasLimit)
+			}
+			rsp, err := q.SimulateProposal(sdk.WrapSDKContext(ctx), req)
+			if spec.expInvalid {
+				assert.Equal(t, codes.InvalidArgument, status.Code(err))
+				return
+			}
+			require.NoError(t, err)
+			if spec.expExecErr {
+				assert.NotEmpty(t, rsp.Error)
+				return
+			}
+			assert.Empty(t, rsp.Error)
+			assert.NotZero(t, rsp.GasUsed)
+			assert.Equal(t, spec.expCodeID, rsp.CodeID)
+			assert.Equal(t, spec.expContractAddr, rsp.ContractAddress)
+			// and nothing persisted
+			assert.Nil(t, k.GetCodeInfo(ctx, example.CodeID+1))
+			assert.False(t, k.HasContractInfo(ctx, BuildContractAddress(example.CodeID, 1)))
+			_, err = k.wasmVM.GetCode(burnerChecksum[:])
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestQuerySimulateExecute(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	k := keepers.WasmKeeper
+	example := InstantiateHackatomExampleContract(t, ctx, keepers)
+
+	specs := map[string]struct {
+		src        *types.QuerySimulateExecuteRequest
+		gasLimit   uint64
+		limiter    *RateLimiter
+		expExecErr string
+		expCode    codes.Code
+	}{
+		"executed": {
+			src: &types.QuerySimulateExecuteRequest{
+				Sender:   example.VerifierAddr.String(),
+				Contract: example.Contract.String(),
+				Msg:      []byte(`{"release":{}}`),
+			},
+			gasLimit: 1_000_000,
+		},
+		"execution failed": {
+			src: &types.QuerySimulateExecuteRequest{
+				Sender:   example.BeneficiaryAddr.String(),
+				Contract: example.Contract.String(),
+				Msg:      []byte(`{"release":{}}`),
+			},
+			gasLimit:   1_000_000,
+			expExecErr: "Unauthorized",
+		},
+		"out of gas": {
+			src: &types.QuerySimulateExecuteRequest{
+				Sender:   example.VerifierAddr.String(),
+				Contract: example.Contract.String(),
+				Msg:      []byte(`{"release":{}}`),
+			},
+			gasLimit:   1000,
+			expExecErr: "out of gas",
+		},
+		"invalid request": {
+			src: &types.QuerySimulateExecuteRequest{
+				Sender:   example.VerifierAddr.String(),
+				Contract: example.Contract.String(),
+			},
+			gasLimit: 1_000_000,
+			expCode:  codes.InvalidArgument,
+		},
+		"rate limited": {
+			src: &types.QuerySimulateExecuteRequest{
+				Sender:   example.VerifierAddr.String(),
+				Contract: example.Contract.String(),
+				Msg:      []byte(`{"release":{}}`),
+			},
+			gasLimit: 1_000_000,
+			limiter: func() *RateLimiter {
+				l := NewRateLimiter(1)
+				l.tokens = 0
+				l.now = func() time.Time { return l.last }
+				return l
+			}(),
+			expCode: codes.ResourceExhausted,
+		},
+		"disabled": {
+			src: &types.QuerySimulateExecuteRequest{
+				Sender:   example.VerifierAddr.String(),
+				Contract: example.Contract.String(),
+				Msg:      []byte(`{"release":{}}`),
+			},
+			expCode: codes.Unimplemented,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			q := Querier(k)
+			q.simulateExecuteGasLimit = spec.gasLimit
+			q.simulateExecuteLimiter = spec.limiter
+			// when
+			rsp, err := q.SimulateExecute(sdk.WrapSDKContext(ctx), spec.src)
+			// then
+			if spec.expCode != codes.OK {
+				assert.Equal(t, spec.expCode, status.Code(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, spec.gasLimit, rsp.GasLimit)
+			assert.NotZero(t, rsp.GasUsed)
+			if spec.expExecErr != "" {
+				assert.Contains(t, rsp.Error, spec.expExecErr)
+			} else {
+				assert.Empty(t, rsp.Error)
+			}
+			// and nothing persisted
+			assert.Empty(t, keepers.BankKeeper.GetAllBalances(ctx, example.BeneficiaryAddr))
+		})
+	}
+}
+
+func TestQueryModuleVersion(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, "staking,iterator")
+	rsp, err := Querier(keepers.WasmKeeper).ModuleVersion(sdk.WrapSDKContext(ctx), &types.QueryModuleVersionRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(types.ModuleConsensusVersion), rsp.ConsensusVersion)
+	assert.Regexp(t, `^v\d+\.\d+\.\d+`, rsp.WasmvmVersion)
+	assert.Equal(t, []string{"iterator", "staking"}, rsp.SupportedFeatures)
+}
+
+func TestQueryGasCosts(t *testing.T) {
+	cfg := DefaultGasRegisterConfig()
+	cfg.GasMultiplier = 50
+	cfg.PinnedCostsDiscount = 20
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithGasRegister(NewWasmGasRegister(cfg)))
+	rsp, err := Querier(keepers.WasmKeeper).GasCosts(sdk.WrapSDKContext(ctx), &types.QueryGasCostsRequest{})
+	require.NoError(t, err)
+	exp := types.GasCosts{
+		InstanceCost:                     DefaultInstanceCost,
+		CompileCost:                      DefaultCompileCost,
+		GasMultiplier:                    50,
+		ContractMessageDataCost:          DefaultContractMessageDataCost,
+		EventPerAttributeCost:            DefaultPerAttributeCost,
+		EventAttributeDataCost:           DefaultEventAttributeDataCost,
+		EventAttributeDataFreeTier:       DefaultEventAttributeDataFreeTier,
+		PinnedEventAttributeDataFreeTier: DefaultPinnedEventAttributeDataFreeTier,
+		PinnedCostsDiscount:              20,
+		HumanAddressCost:                 costHumanize / 50,
+		CanonicalAddressCost:             costCanonical / 50,
+	}
+	assert.Equal(t, exp, rsp.GasCosts)
+}
+
+func TestQueryGasCostsCustomGasRegister(t *testing.T) {
+	// a custom gas register without the GasCostsRegister extension
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithGasRegister(&wasmtesting.MockGasRegister{}))
+	_, err := Querier(keepers.WasmKeeper).GasCosts(sdk.WrapSDKContext(ctx), &types.QueryGasCostsRequest{})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestQueryContractBalance(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	k := keepers.WasmKeeper
+	mock := wasmtesting.MockWasmer{}
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	myBalance := sdk.NewCoins(sdk.NewInt64Coin("alx", 2), sdk.NewInt64Coin("denom", 100))
+	require.NoError(t, keepers.BankKeeper.SetBalances(ctx, example.Contract, myBalance))
+
+	specs := map[string]struct {
+		srcQueryRsp    string
+		srcQueryErr    error
+		expExpected    sdk.Coins
+		expUnaccounted sdk.Coins
+		expErrMsg      bool
+	}{
+		"all accounted": {
+			srcQueryRsp:    `{"amount":[{"denom":"denom","amount":"100"},{"denom":"alx","amount":"2"}]}`,
+			expExpected:    myBalance,
+			expUnaccounted: sdk.NewCoins(),
+		},
+		"funds sent by bank": {
+			srcQueryRsp:    `{"amount":[{"denom":"denom","amount":"60"}]}`,
+			expExpected:    sdk.NewCoins(sdk.NewInt64Coin("denom", 60)),
+			expUnaccounted: sdk.NewCoins(sdk.NewInt64Coin("alx", 2), sdk.NewInt64Coin("denom", 40)),
+		},
+		"more expected than held": {
+			srcQueryRsp:    `{"amount":[{"denom":"denom","amount":"200"},{"denom":"alx","amount":"2"}]}`,
+			expExpected:    sdk.NewCoins(sdk.NewInt64Coin("alx", 2), sdk.NewInt64Coin("denom", 200)),
+			expUnaccounted: sdk.NewCoins(),
+		},
+		"query not supported": {
+			srcQueryErr:    errors.New("unknown variant"),
+			expExpected:    sdk.NewCoins(),
+			expUnaccounted: sdk.NewCoins(),
+			expErrMsg:      true,
+		},
+		"invalid response": {
+			srcQueryRsp:    `{"amount":"100denom"}`,
+			expExpected:    sdk.NewCoins(),
+			expUnaccounted: sdk.NewCoins(),
+			expErrMsg:      true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			mock.QueryFn = func(checksum cosmwasm.Checksum, env wasmvmtypes.Env, queryMsg []byte, store cosmwasm.KVStore, goapi cosmwasm.GoAPI, querier cosmwasm.Querier, gasMeter cosmwasm.GasMeter, gasLimit uint64) ([]byte, uint64, error) {
+				assert.JSONEq(t, `{"expected_balance":{}}`, string(queryMsg))
+				return []byte(spec.srcQueryRsp), 0, spec.srcQueryErr
+			}
+			rsp, err := Querier(k).ContractBalance(sdk.WrapSDKContext(ctx), &types.QueryContractBalanceRequest{Address: example.Contract.String()})
+			require.NoError(t, err)
+			assert.Equal(t, myBalance, rsp.Balance)
+			assert.Equal(t, spec.expExpected, rsp.ExpectedBalance)
+			assert.Equal(t, spec.expUnaccounted, rsp.Unaccounted)
+			assert.Equal(t, spec.expErrMsg, rsp.ExpectedBalanceError != "", rsp.ExpectedBalanceError)
+		})
+	}
+
+	// and unknown contracts
+	_, err := Querier(k).ContractBalance(sdk.WrapSDKContext(ctx), &types.QueryContractBalanceRequest{Address: RandomBech32AccountAddress(t)})
+	assert.True(t, types.ErrNotFound.Is(err), "got %+v", err)
+}
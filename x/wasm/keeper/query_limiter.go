@@ -0,0 +1,95 @@
+package keeper
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// QueryLimiter bounds the number of smart queries that are executed in parallel by the gRPC query server.
+// Requests that can not be executed immediately wait in a queue of limited size. When the queue is full, the
+// request is rejected with a resource exhausted error.
+// The limiter is node local and not part of the consensus state.
+type QueryLimiter struct {
+	slots     chan struct{}
+	queueSize int64
+	waiting   int64
+}
+
+// NewQueryLimiter constructor. The concurrency must be greater than 0.
+func NewQueryLimiter(concurrency, queueSize uint32) *QueryLimiter {
+	if concurrency == 0 {
+		panic("concurrency must not be 0")
+	}
+	return &QueryLimiter{
+		slots:     make(chan struct{}, concurrency),
+		queueSize: int64(queueSize),
+	}
+}
+
+// acquire blocks until a slot is available or the context is done. The returned function must be called to release
+// the slot.
+func (l *QueryLimiter) acquire(ctx context.Context) (func(), error) {
+	release := func() { <-l.slots }
+	select {
+	case l.slots <- struct{}{}:
+		return release, nil
+	default:
+	}
+	if atomic.AddInt64(&l.waiting, 1) > l.queueSize {
+		atomic.AddInt64(&l.waiting, -1)
+		return nil, status.Error(codes.ResourceExhausted, "too many concurrent wasm queries")
+	}
+	defer atomic.AddInt64(&l.waiting, -1)
+	select {
+	case l.slots <- struct{}{}:
+		return release, nil
+	case <-ctx.Done():
+		return nil, contextErrorStatus(ctx.Err())
+	}
+}
+
+// RateLimiter bounds the number of requests per second with a token bucket that holds up to one second worth of
+// requests. Requests above the rate are rejected with a resource exhausted error.
+// The limiter is node local and not part of the consensus state.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+// NewRateLimiter constructor. The rate is the number of requests per second and must be greater than 0.
+func NewRateLimiter(perSecond uint32) *RateLimiter {
+	if perSecond == 0 {
+		panic("rate must not be 0")
+	}
+	return &RateLimiter{
+		rate:   float64(perSecond),
+		tokens: float64(perSecond),
+		last:   time.Now(),
+		now:    time.Now,
+	}
+}
+
+// allow takes a token from the bucket or returns an error when it is empty
+func (l *RateLimiter) allow() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.rate {
+		l.tokens = l.rate
+	}
+	l.last = now
+	if l.tokens < 1 {
+		return status.Error(codes.ResourceExhausted, "rate limit exceeded")
+	}
+	l.tokens--
+	return nil
+}
@@ -0,0 +1,71 @@
+package keeper
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestQueryLimiter(t *testing.T) {
+	l := NewQueryLimiter(1, 1)
+
+	release, err := l.acquire(context.Background())
+	require.NoError(t, err)
+
+	// second request waits in the queue until the first is released
+	acquired := make(chan error)
+	go func() {
+		rel, err := l.acquire(context.Background())
+		if err == nil {
+			defer rel()
+		}
+		acquired <- err
+	}()
+	require.Eventually(t, func() bool { return atomic.LoadInt64(&l.waiting) == 1 }, time.Second, time.Millisecond)
+
+	// third request is rejected as the queue is full
+	_, err = l.acquire(context.Background())
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	release()
+	require.NoError(t, <-acquired)
+
+	// a waiting request is aborted when the context is canceled
+	release, err = l.acquire(context.Background())
+	require.NoError(t, err)
+	defer release()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = l.acquire(ctx)
+	assert.Equal(t, codes.Canceled, status.Code(err))
+	assert.Equal(t, int64(0), atomic.LoadInt64(&l.waiting))
+}
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Now()
+	l := NewRateLimiter(2)
+	l.last = now
+	l.now = func() time.Time { return now }
+
+	// the burst is one second worth of requests
+	require.NoError(t, l.allow())
+	require.NoError(t, l.allow())
+	assert.Equal(t, codes.ResourceExhausted, status.Code(l.allow()))
+
+	// tokens are refilled over time
+	now = now.Add(500 * time.Millisecond)
+	require.NoError(t, l.allow())
+	assert.Equal(t, codes.ResourceExhausted, status.Code(l.allow()))
+
+	// but not above the burst
+	now = now.Add(time.Hour)
+	require.NoError(t, l.allow())
+	require.NoError(t, l.allow())
+	assert.Equal(t, codes.ResourceExhausted, status.Code(l.allow()))
+}
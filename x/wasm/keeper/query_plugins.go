@@ -14,19 +14,26 @@ import (
 	abci "github.com/tendermint/tendermint/abci/types"
 )
 
+// DefaultSubQueryGasPercent is the default share of the calling contract's remaining gas that is available to a
+// sub-query
+const DefaultSubQueryGasPercent uint64 = 100
+
 type QueryHandler struct {
 	Ctx         sdk.Context
 	Plugins     WasmVMQueryHandler
 	Caller      sdk.AccAddress
 	gasRegister GasRegister
+	// gasPercent is the share of the remaining gas in percent that a sub-query can consume
+	gasPercent uint64
 }
 
-func NewQueryHandler(ctx sdk.Context, vmQueryHandler WasmVMQueryHandler, caller sdk.AccAddress, gasRegister GasRegister) QueryHandler {
+func NewQueryHandler(ctx sdk.Context, vmQueryHandler WasmVMQueryHandler, caller sdk.AccAddress, gasRegister GasRegister, gasPercent uint64) QueryHandler {
 	return QueryHandler{
 		Ctx:         ctx,
 		Plugins:     vmQueryHandler,
 		Caller:      caller,
 		gasRegister: gasRegister,
+		gasPercent:  gasPercent,
 	}
 }
 
@@ -46,18 +53,44 @@ var _ wasmvmtypes.Querier = QueryHandler{}
 
 func (q QueryHandler) Query(request wasmvmtypes.QueryRequest, gasLimit uint64) ([]byte, error) {
 	// set a limit for a subctx
-	sdkGas := q.gasRegister.FromWasmVMGas(gasLimit)
-	subctx := q.Ctx.WithGasMeter(sdk.NewGasMeter(sdkGas))
+	subctx := q.Ctx.WithGasMeter(sdk.NewGasMeter(q.subQueryGasLimit(gasLimit)))
 
 	// make sure we charge the higher level context even on panic
 	defer func() {
 		q.Ctx.GasMeter().ConsumeGas(subctx.GasMeter().GasConsumed(), "contract sub-query")
 	}()
-	return q.Plugins.HandleQuery(subctx, q.Caller, request)
+	res, err := q.Plugins.HandleQuery(subctx, q.Caller, request)
+	if r, ok := q.gasRegister.(QueryResultCostsRegister); ok && err == nil && request.Wasm != nil && request.Wasm.Smart != nil {
+		// the costs to load the queried contract are charged by the keeper
+		subctx.GasMeter().ConsumeGas(r.QueryResultCosts(len(res)), "contract sub-query result")
+	}
+	return res, err
+}
+
+// subQueryGasLimit returns the share of the gas that is left for the calling contract. The limit set by the VM is
+// bounded by the gas remaining in the calling context so that a sub-query never gets more gas than its parent.
+func (q QueryHandler) subQueryGasLimit(wasmVMGasLimit uint64) sdk.Gas {
+	sdkGas := q.gasRegister.FromWasmVMGas(wasmVMGasLimit)
+	if limit := q.Ctx.GasMeter().Limit(); limit != 0 { // 0 for infinite gas meters
+		var remaining sdk.Gas
+		if consumed := q.Ctx.GasMeter().GasConsumed(); consumed < limit {
+			remaining = limit - consumed
+		}
+		if remaining < sdkGas {
+			sdkGas = remaining
+		}
+	}
+	if q.gasPercent >= 100 {
+		return sdkGas
+	}
+	// avoid an overflow on the multiplication
+	return sdkGas/100*q.gasPercent + sdkGas%100*q.gasPercent/100
 }
 
+// GasConsumed returns the gas consumed in wasmvm gas so that the VM charges the full costs of the queries to the
+// calling contract
 func (q QueryHandler) GasConsumed() uint64 {
-	return q.Ctx.GasMeter().GasConsumed()
+	return q.gasRegister.ToWasmVMGas(q.Ctx.GasMeter().GasConsumed())
 }
 
 type CustomQuerier func(ctx sdk.Context, request json.RawMessage) ([]byte, error)
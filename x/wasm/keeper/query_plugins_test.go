@@ -9,6 +9,7 @@ import (
 	channeltypes "github.com/cosmos/cosmos-sdk/x/ibc/core/04-channel/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"math"
 	"testing"
 )
 
@@ -243,6 +244,151 @@ type wasmKeeperMock struct {
 	GetContractInfoFn func(ctx sdk.Context, contractAddress sdk.AccAddress) *types.ContractInfo
 }
 
+func TestQueryHandlerGasCosts(t *testing.T) {
+	specs := map[string]struct {
+		src         wasmvmtypes.QueryRequest
+		srcErr      error
+		gasRegister GasRegister
+		expGas      sdk.Gas
+		expErr      bool
+	}{
+		"smart query result charged": {
+			src:    wasmvmtypes.QueryRequest{Wasm: &wasmvmtypes.WasmQuery{Smart: &wasmvmtypes.SmartQuery{}}},
+			expGas: 1 + 3, // query costs + result data costs
+		},
+		"smart query result not charged by custom gas register": {
+			src: wasmvmtypes.QueryRequest{Wasm: &wasmvmtypes.WasmQuery{Smart: &wasmvmtypes.SmartQuery{}}},
+			gasRegister: wasmtesting.MockGasRegister{
+				ToWasmVMGasFn:   func(source sdk.Gas) uint64 { return source * DefaultGasMultiplier },
+				FromWasmVMGasFn: func(source uint64) sdk.Gas { return source / DefaultGasMultiplier },
+			},
+			expGas: 1,
+		},
+		"smart query error": {
+			src:    wasmvmtypes.QueryRequest{Wasm: &wasmvmtypes.WasmQuery{Smart: &wasmvmtypes.SmartQuery{}}},
+			srcErr: types.ErrQueryFailed,
+			expGas: 1,
+			expErr: true,
+		},
+		"raw query result not charged": {
+			src:    wasmvmtypes.QueryRequest{Wasm: &wasmvmtypes.WasmQuery{Raw: &wasmvmtypes.RawQuery{}}},
+			expGas: 1,
+		},
+		"bank query result not charged": {
+			src:    wasmvmtypes.QueryRequest{Bank: &wasmvmtypes.BankQuery{}},
+			expGas: 1,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx := sdk.Context{}.WithGasMeter(sdk.NewInfiniteGasMeter())
+			mock := &wasmtesting.MockQueryHandler{
+				HandleQueryFn: func(ctx sdk.Context, request wasmvmtypes.QueryRequest, caller sdk.AccAddress) ([]byte, error) {
+					ctx.GasMeter().ConsumeGas(1, "testing")
+					if spec.srcErr != nil {
+						return nil, spec.srcErr
+					}
+					return []byte("foo"), nil
+				}}
+			var gasRegister GasRegister = NewDefaultWasmGasRegister()
+			if spec.gasRegister != nil {
+				gasRegister = spec.gasRegister
+			}
+			q := NewQueryHandler(ctx, mock, RandomAccountAddress(t), gasRegister, DefaultSubQueryGasPercent)
+
+			// when
+			_, err := q.Query(spec.src, 1_000_000)
+
+			// then
+			if spec.expErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, spec.expGas, ctx.GasMeter().GasConsumed())
+			// the calling contract is charged in wasmvm gas
+			assert.Equal(t, spec.expGas*DefaultGasMultiplier, q.GasConsumed())
+		})
+	}
+}
+
+func TestQueryHandlerSubQueryGasLimit(t *testing.T) {
+	specs := map[string]struct {
+		srcMeter   sdk.GasMeter
+		srcConsume sdk.Gas
+		srcPercent uint64
+		srcLimit   uint64
+		exp        sdk.Gas
+	}{
+		"vm limit": {
+			srcMeter:   sdk.NewGasMeter(1_000),
+			srcPercent: 100,
+			srcLimit:   500 * DefaultGasMultiplier,
+			exp:        500,
+		},
+		"bounded by remaining gas": {
+			srcMeter:   sdk.NewGasMeter(1_000),
+			srcConsume: 800,
+			srcPercent: 100,
+			srcLimit:   500 * DefaultGasMultiplier,
+			exp:        200,
+		},
+		"no gas remaining": {
+			srcMeter:   sdk.NewGasMeter(1_000),
+			srcConsume: 1_000,
+			srcPercent: 100,
+			srcLimit:   500 * DefaultGasMultiplier,
+			exp:        0,
+		},
+		"infinite gas meter": {
+			srcMeter:   sdk.NewInfiniteGasMeter(),
+			srcConsume: 800,
+			srcPercent: 100,
+			srcLimit:   500 * DefaultGasMultiplier,
+			exp:        500,
+		},
+		"share of vm limit": {
+			srcMeter:   sdk.NewGasMeter(1_000),
+			srcPercent: 50,
+			srcLimit:   500 * DefaultGasMultiplier,
+			exp:        250,
+		},
+		"share of remaining gas": {
+			srcMeter:   sdk.NewGasMeter(1_000),
+			srcConsume: 800,
+			srcPercent: 50,
+			srcLimit:   500 * DefaultGasMultiplier,
+			exp:        100,
+		},
+		"share of max gas": {
+			srcMeter:   sdk.NewInfiniteGasMeter(),
+			srcPercent: 50,
+			srcLimit:   math.MaxUint64,
+			exp:        math.MaxUint64 / DefaultGasMultiplier / 2,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			spec.srcMeter.ConsumeGas(spec.srcConsume, "testing")
+			ctx := sdk.Context{}.WithGasMeter(spec.srcMeter)
+			var gotLimit sdk.Gas
+			mock := &wasmtesting.MockQueryHandler{
+				HandleQueryFn: func(ctx sdk.Context, request wasmvmtypes.QueryRequest, caller sdk.AccAddress) ([]byte, error) {
+					gotLimit = ctx.GasMeter().Limit()
+					return nil, nil
+				}}
+			q := NewQueryHandler(ctx, mock, RandomAccountAddress(t), NewDefaultWasmGasRegister(), spec.srcPercent)
+
+			// when
+			_, err := q.Query(wasmvmtypes.QueryRequest{}, spec.srcLimit)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, spec.exp, gotLimit)
+		})
+	}
+}
+
 func newWasmKeeperMock(f func(ctx sdk.Context, contractAddress sdk.AccAddress) *types.ContractInfo) *wasmKeeperMock {
 	return &wasmKeeperMock{GetContractInfoFn: f}
 }
@@ -0,0 +1,77 @@
+package keeper
+
+import (
+	"time"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+)
+
+// DefaultRecoverContractFundsMinVotingPeriod is the min gov voting period for contract funds recovery proposals
+const DefaultRecoverContractFundsMinVotingPeriod = 14 * 24 * time.Hour
+
+// ProposalSource is the subset of the gov keeper that is used to look up the voting period of the executed proposal
+type ProposalSource interface {
+	IterateActiveProposalsQueue(ctx sdk.Context, endTime time.Time, cb func(proposal govtypes.Proposal) (stop bool))
+}
+
+// recoverContractFunds sends coins from a bricked contract to the recipient. A contract is bricked when it has no
+// admin to migrate it and its code can not run on this chain anymore, because the code requires features that are
+// not supported. The voting period of the executed proposal must not be shorter than the min voting period that was
+// set with WithContractFundsRecovery.
+func (k Keeper) recoverContractFunds(ctx sdk.Context, contractAddress, recipient sdk.AccAddress, amount sdk.Coins, authZ AuthorizationPolicy) error {
+	if !canRecoverContractFunds(authZ) {
+		return sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "can not recover contract funds")
+	}
+	if k.proposals == nil {
+		return sdkerrors.Wrap(types.ErrInvalid, "contract funds recovery not enabled")
+	}
+	period, ok := k.recoveryProposalVotingPeriod(ctx, contractAddress, recipient, amount)
+	if !ok {
+		return sdkerrors.Wrap(types.ErrNotFound, "executed recovery proposal")
+	}
+	if period < k.recoveryMinVotingPeriod {
+		return sdkerrors.Wrapf(types.ErrInvalid, "voting period %s is shorter than %s", period, k.recoveryMinVotingPeriod)
+	}
+	contractInfo := k.GetContractInfo(ctx, contractAddress)
+	if contractInfo == nil {
+		return sdkerrors.Wrap(types.ErrNotFound, "contract")
+	}
+	if contractInfo.Admin != "" {
+		return sdkerrors.Wrap(types.ErrInvalid, "contract has an admin to migrate it")
+	}
+	codeInfo := k.GetCodeInfo(ctx, contractInfo.CodeID)
+	if codeInfo == nil {
+		return sdkerrors.Wrap(types.ErrNotFound, "code")
+	}
+	if !k.isBrickedCode(*codeInfo) {
+		return sdkerrors.Wrap(types.ErrInvalid, "contract code is not bricked")
+	}
+	return k.bank.TransferCoins(ctx, contractAddress, recipient, amount)
+}
+
+// recoveryProposalVotingPeriod returns the voting period of the recovery proposal that is executed. Gov executes the
+// proposals in the end blocker before they are removed from the active queue, so that the proposal is the first one
+// in the queue with a voting end time up to the block time and the same content.
+func (k Keeper) recoveryProposalVotingPeriod(ctx sdk.Context, contractAddress, recipient sdk.AccAddress, amount sdk.Coins) (time.Duration, bool) {
+	var period time.Duration
+	var found bool
+	k.proposals.IterateActiveProposalsQueue(ctx, ctx.BlockTime(), func(proposal govtypes.Proposal) bool {
+		p, ok := proposal.GetContent().(*types.RecoverContractFundsProposal)
+		if !ok || p.Contract != contractAddress.String() || p.Recipient != recipient.String() || p.Amount.String() != amount.String() {
+			return false
+		}
+		period, found = proposal.VotingEndTime.Sub(proposal.VotingStartTime), true
+		return true
+	})
+	return period, found
+}
+
+// isBrickedCode returns true when the code can not be executed on this chain. It is decided with the code info
+// in the state only and not with the local wasm VM cache, which can differ between nodes. The required features of
+// the codes that were stored before they were recorded on store are set by the Migrate1to2 state migration.
+func (k Keeper) isBrickedCode(codeInfo types.CodeInfo) bool {
+	return k.assertSupportedFeatures(codeInfo) != nil
+}
@@ -0,0 +1,197 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecoverContractFundsProposal(t *testing.T) {
+	myFunds := sdk.NewCoins(sdk.NewInt64Coin("denom", 100))
+	proposals := &mockProposalSource{}
+	longPeriod := DefaultRecoverContractFundsMinVotingPeriod
+	specs := map[string]struct {
+		srcOpts    []Option
+		srcPeriod  time.Duration
+		srcAdmin   bool
+		srcBricked bool
+		srcAmount  sdk.Coins
+		// the executed proposal is not in the active queue
+		srcNotQueued bool
+		expErr       *sdkerrors.Error
+	}{
+		"bricked contract": {
+			srcOpts:    []Option{WithContractFundsRecovery(proposals, DefaultRecoverContractFundsMinVotingPeriod)},
+			srcPeriod:  longPeriod,
+			srcBricked: true,
+			srcAmount:  myFunds,
+		},
+		"partial amount": {
+			srcOpts:    []Option{WithContractFundsRecovery(proposals, DefaultRecoverContractFundsMinVotingPeriod)},
+			srcPeriod:  longPeriod,
+			srcBricked: true,
+			srcAmount:  sdk.NewCoins(sdk.NewInt64Coin("denom", 1)),
+		},
+		"insufficient funds": {
+			srcOpts:    []Option{WithContractFundsRecovery(proposals, DefaultRecoverContractFundsMinVotingPeriod)},
+			srcPeriod:  longPeriod,
+			srcBricked: true,
+			srcAmount:  sdk.NewCoins(sdk.NewInt64Coin("denom", 101)),
+			expErr:     sdkerrors.ErrInsufficientFunds,
+		},
+		"code not bricked": {
+			srcOpts:   []Option{WithContractFundsRecovery(proposals, DefaultRecoverContractFundsMinVotingPeriod)},
+			srcPeriod: longPeriod,
+			srcAmount: myFunds,
+			expErr:    types.ErrInvalid,
+		},
+		"contract with admin": {
+			srcOpts:    []Option{WithContractFundsRecovery(proposals, DefaultRecoverContractFundsMinVotingPeriod)},
+			srcPeriod:  longPeriod,
+			srcAdmin:   true,
+			srcBricked: true,
+			srcAmount:  myFunds,
+			expErr:     types.ErrInvalid,
+		},
+		"voting period too short": {
+			srcOpts:    []Option{WithContractFundsRecovery(proposals, DefaultRecoverContractFundsMinVotingPeriod)},
+			srcPeriod:  time.Hour,
+			srcBricked: true,
+			srcAmount:  myFunds,
+			expErr:     types.ErrInvalid,
+		},
+		"proposal not queued": {
+			srcOpts:      []Option{WithContractFundsRecovery(proposals, DefaultRecoverContractFundsMinVotingPeriod)},
+			srcPeriod:    longPeriod,
+			srcBricked:   true,
+			srcAmount:    myFunds,
+			srcNotQueued: true,
+			expErr:       types.ErrNotFound,
+		},
+		"recovery not enabled": {
+			srcBricked: true,
+			srcAmount:  myFunds,
+			expErr:     types.ErrInvalid,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			ctx, keepers := CreateTestInput(t, false, SupportedFeatures, spec.srcOpts...)
+			mock := wasmtesting.MockWasmer{}
+			wasmtesting.MakeInstantiable(&mock)
+			example := StoreRandomContract(t, ctx, keepers, &mock)
+			var admin sdk.AccAddress
+			if spec.srcAdmin {
+				admin = example.CreatorAddr
+			}
+			contractAddr, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, example.CreatorAddr, admin, []byte(`{}`), "bricked", nil)
+			require.NoError(t, err)
+			require.NoError(t, keepers.BankKeeper.SetBalances(ctx, contractAddr, myFunds))
+			if spec.srcBricked {
+				// the code requires a feature that is not supported anymore
+				codeInfo := keepers.WasmKeeper.GetCodeInfo(ctx, example.CodeID)
+				codeInfo.RequiredFeatures = []string{"other"}
+				keepers.WasmKeeper.storeCodeInfo(ctx, example.CodeID, *codeInfo)
+			}
+			recipient := RandomAccountAddress(t)
+
+			src := types.RecoverContractFundsProposalFixture(func(p *types.RecoverContractFundsProposal) {
+				p.Contract = contractAddr.String()
+				p.Recipient = recipient.String()
+				p.Amount = spec.srcAmount
+			})
+			proposals.queue = nil
+			if !spec.srcNotQueued {
+				proposals.add(t, ctx, src, spec.srcPeriod)
+			}
+			em := sdk.NewEventManager()
+
+			// when
+			handler := NewWasmProposalHandler(keepers.WasmKeeper, types.EnableAllProposals)
+			err = handler(ctx.WithEventManager(em), src)
+
+			// then
+			if spec.expErr != nil {
+				assert.True(t, spec.expErr.Is(err), "got %+v", err)
+				assert.Equal(t, myFunds, keepers.BankKeeper.GetAllBalances(ctx, contractAddr))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, spec.srcAmount, keepers.BankKeeper.GetAllBalances(ctx, recipient))
+			assert.Equal(t, myFunds.Sub(spec.srcAmount), keepers.BankKeeper.GetAllBalances(ctx, contractAddr))
+			expEvt := sdk.NewEvent(types.EventTypeRecoverContractFunds,
+				sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+				sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String()),
+				sdk.NewAttribute(types.AttributeKeyRecipient, recipient.String()),
+				sdk.NewAttribute(sdk.AttributeKeyAmount, spec.srcAmount.String()),
+			)
+			assert.Contains(t, em.Events(), expEvt)
+		})
+	}
+}
+
+func TestRecoverContractFundsWithUnsupportedFeatures(t *testing.T) {
+	proposals := &mockProposalSource{}
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithContractFundsRecovery(proposals, DefaultRecoverContractFundsMinVotingPeriod))
+	k := keepers.WasmKeeper
+	example := InstantiateHackatomExampleContract(t, ctx, keepers)
+	myFunds := sdk.NewCoins(sdk.NewInt64Coin("denom", 100))
+	require.NoError(t, keepers.BankKeeper.SetBalances(ctx, example.Contract, myFunds))
+	recipient := RandomAccountAddress(t)
+	govKeeper := NewGovPermissionKeeper(k)
+	require.NoError(t, govKeeper.ClearContractAdmin(ctx, example.Contract, example.CreatorAddr))
+	proposals.add(t, ctx, types.RecoverContractFundsProposalFixture(func(p *types.RecoverContractFundsProposal) {
+		p.Contract = example.Contract.String()
+		p.Recipient = recipient.String()
+		p.Amount = myFunds
+	}), DefaultRecoverContractFundsMinVotingPeriod)
+
+	// when the code runs
+	err := govKeeper.RecoverContractFunds(ctx, example.Contract, recipient, myFunds)
+	// then
+	assert.True(t, types.ErrInvalid.Is(err), "got %+v", err)
+
+	// and when the code requires a feature that is not supported anymore
+	codeInfo := k.GetCodeInfo(ctx, example.CodeID)
+	codeInfo.RequiredFeatures = []string{"other"}
+	k.storeCodeInfo(ctx, example.CodeID, *codeInfo)
+	err = govKeeper.RecoverContractFunds(ctx, example.Contract, recipient, myFunds)
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, myFunds, keepers.BankKeeper.GetAllBalances(ctx, recipient))
+
+	// and without gov permissions
+	err = NewDefaultPermissionKeeper(k).RecoverContractFunds(ctx, example.Contract, recipient, myFunds)
+	assert.True(t, sdkerrors.ErrUnauthorized.Is(err), "got %+v", err)
+}
+
+// mockProposalSource contains the active proposal queue
+type mockProposalSource struct {
+	queue []govtypes.Proposal
+}
+
+// add puts the proposal with the voting period ending at the block time into the active queue
+func (m *mockProposalSource) add(t *testing.T, ctx sdk.Context, content govtypes.Content, votingPeriod time.Duration) {
+	proposal, err := govtypes.NewProposal(content, uint64(len(m.queue)+1), ctx.BlockTime().Add(-votingPeriod), ctx.BlockTime().Add(-votingPeriod))
+	require.NoError(t, err)
+	proposal.VotingStartTime, proposal.VotingEndTime = ctx.BlockTime().Add(-votingPeriod), ctx.BlockTime()
+	m.queue = append(m.queue, proposal)
+}
+
+func (m *mockProposalSource) IterateActiveProposalsQueue(ctx sdk.Context, endTime time.Time, cb func(proposal govtypes.Proposal) (stop bool)) {
+	for _, p := range m.queue {
+		if p.VotingEndTime.After(endTime) {
+			continue
+		}
+		if cb(p) {
+			return
+		}
+	}
+}
@@ -61,8 +61,8 @@ func TestGasCostOnQuery(t *testing.T) {
 		// Note: about 100 SDK gas (10k wasmer gas) for each round of sha256
 		GasWork50 uint64 = 49_856 // this is a little shy of 50k gas - to keep an eye on the limit
 
-		GasReturnUnhashed uint64 = 224
-		GasReturnHashed   uint64 = 198
+		GasReturnUnhashed uint64 = 297
+		GasReturnHashed   uint64 = 255
 	)
 
 	cases := map[string]struct {
@@ -113,7 +113,7 @@ func TestGasCostOnQuery(t *testing.T) {
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
 			// external limit has no effect (we get a panic if this is enforced)
-			keeper.queryGasLimit = 1000
+			keeper.SetQueryGasLimit(1000)
 
 			// make sure we set a limit before calling
 			ctx = ctx.WithGasMeter(sdk.NewGasMeter(tc.gasLimit))
@@ -152,6 +152,7 @@ func TestGasOnExternalQuery(t *testing.T) {
 		gasLimit    uint64
 		msg         Recurse
 		expectPanic bool
+		expectErr   bool
 	}{
 		"no recursion, plenty gas": {
 			gasLimit: 400_000,
@@ -176,12 +177,13 @@ func TestGasOnExternalQuery(t *testing.T) {
 		},
 		"recursion 4, external gas limit": {
 			// this uses 244708 gas but give less
+			// the sub-queries are charged to the calling contract so that it runs out of gas inside the VM
 			gasLimit: 4 * GasWork50,
 			msg: Recurse{
 				Depth: 4,
 				Work:  50,
 			},
-			expectPanic: true,
+			expectErr: true,
 		},
 	}
 
@@ -202,6 +204,10 @@ func TestGasOnExternalQuery(t *testing.T) {
 					_, err := NewLegacyQuerier(keeper, tc.gasLimit)(ctx, path, req)
 					t.Logf("%v", err)
 				})
+			} else if tc.expectErr {
+				_, err := NewLegacyQuerier(keeper, tc.gasLimit)(ctx, path, req)
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "Ran out of gas")
 			} else {
 				// otherwise, make sure we get a good success
 				_, err := NewLegacyQuerier(keeper, tc.gasLimit)(ctx, path, req)
@@ -223,7 +229,7 @@ func TestLimitRecursiveQueryGas(t *testing.T) {
 		// Note: about 100 SDK gas (10k wasmer gas) for each round of sha256
 		GasWork2k uint64 = 273_661 // = NewContractInstanceCosts + x // we have 6x gas used in cpu than in the instance
 		// This is overhead for calling into a sub-contract
-		GasReturnHashed uint64 = 203
+		GasReturnHashed uint64 = 260
 	)
 
 	cases := map[string]struct {
@@ -0,0 +1,53 @@
+package keeper
+
+import (
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+type callStackKey struct{}
+
+// setReentrancyGuard enables or disables the reentrancy guard of the contract. Only the contract admin can modify it.
+func (k Keeper) setReentrancyGuard(ctx sdk.Context, contractAddress, caller sdk.AccAddress, enabled bool, authZ AuthorizationPolicy) error {
+	contractInfo := k.GetContractInfo(ctx, contractAddress)
+	if contractInfo == nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "unknown contract")
+	}
+	if !authZ.CanModifyContract(contractInfo.AdminAddr(), caller) {
+		return sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "can not modify contract")
+	}
+	contractInfo.ReentrancyGuard = enabled
+	k.storeContractInfo(ctx, contractAddress, contractInfo)
+	return nil
+}
+
+// withContractOnCallStack returns the context for the dispatch of the messages of a contract. The contracts on the
+// call stack are the ones whose messages are dispatched by the current and the outer calls.
+func withContractOnCallStack(ctx sdk.Context, contractAddr sdk.AccAddress) sdk.Context {
+	outer, _ := ctx.Value(callStackKey{}).([]string)
+	stack := make([]string, len(outer), len(outer)+1)
+	copy(stack, outer)
+	return ctx.WithValue(callStackKey{}, append(stack, contractAddr.String()))
+}
+
+// isOnCallStack returns true when the messages of the contract are dispatched by the current or an outer call
+func isOnCallStack(ctx sdk.Context, contractAddr sdk.AccAddress) bool {
+	stack, _ := ctx.Value(callStackKey{}).([]string)
+	addr := contractAddr.String()
+	for _, a := range stack {
+		if a == addr {
+			return true
+		}
+	}
+	return false
+}
+
+// assertNoReentrancy returns an error for a nested call into a contract with reentrancy guard that is on the call
+// stack. Replies to the submessages of the contract are not affected.
+func assertNoReentrancy(ctx sdk.Context, contractAddr sdk.AccAddress, contractInfo types.ContractInfo) error {
+	if contractInfo.ReentrancyGuard && isOnCallStack(ctx, contractAddr) {
+		return sdkerrors.Wrapf(types.ErrReentrancy, "contract %s", contractAddr)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	wasmvm "github.com/CosmWasm/wasmvm"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateReentrancyGuard(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	mock := wasmtesting.MockWasmer{}
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	otherAddr := RandomAccountAddress(t)
+
+	specs := map[string]struct {
+		caller   sdk.AccAddress
+		contract sdk.AccAddress
+		enabled  bool
+		expErr   *sdkerrors.Error
+	}{
+		"enable": {
+			caller:   example.CreatorAddr,
+			contract: example.Contract,
+			enabled:  true,
+		},
+		"disable": {
+			caller:   example.CreatorAddr,
+			contract: example.Contract,
+		},
+		"non admin": {
+			caller:   otherAddr,
+			contract: example.Contract,
+			enabled:  true,
+			expErr:   sdkerrors.ErrUnauthorized,
+		},
+		"unknown contract": {
+			caller:   example.CreatorAddr,
+			contract: otherAddr,
+			enabled:  true,
+			expErr:   sdkerrors.ErrInvalidRequest,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			ctx, _ := ctx.CacheContext()
+			err := keepers.ContractKeeper.UpdateReentrancyGuard(ctx, spec.contract, spec.caller, spec.enabled)
+			if spec.expErr != nil {
+				assert.True(t, spec.expErr.Is(err), "got %+v", err)
+				assert.False(t, keepers.WasmKeeper.GetContractInfo(ctx, example.Contract).ReentrancyGuard)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, spec.enabled, keepers.WasmKeeper.GetContractInfo(ctx, example.Contract).ReentrancyGuard)
+		})
+	}
+}
+
+func TestReentrancyGuard(t *testing.T) {
+	specs := map[string]struct {
+		guardFirst  bool
+		guardSecond bool
+		// submessage to the second contract with reply instead of the callback to the first contract
+		reply         bool
+		expExecutions []string
+		expErr        *sdkerrors.Error
+	}{
+		"reentrant call without guard": {
+			expExecutions: []string{"first", "second", "first"},
+		},
+		"reentrant call with guard": {
+			guardFirst: true,
+			expErr:     types.ErrReentrancy,
+		},
+		"guard on contract not reentered": {
+			guardSecond:   true,
+			expExecutions: []string{"first", "second", "first"},
+		},
+		"reply with guard": {
+			guardFirst:    true,
+			reply:         true,
+			expExecutions: []string{"first", "second", "reply"},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			mock := wasmtesting.MockWasmer{}
+			wasmtesting.MakeInstantiable(&mock)
+			ctx, keepers := CreateTestInput(t, false, SupportedFeatures, WithWasmEngine(&mock))
+			example := SeedNewContractInstance(t, ctx, keepers, &mock)
+			first := example.Contract
+			second, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, example.CreatorAddr, example.CreatorAddr, []byte(`{}`), "second", nil)
+			require.NoError(t, err)
+			require.NoError(t, keepers.ContractKeeper.UpdateReentrancyGuard(ctx, first, example.CreatorAddr, spec.guardFirst))
+			require.NoError(t, keepers.ContractKeeper.UpdateReentrancyGuard(ctx, second, example.CreatorAddr, spec.guardSecond))
+
+			executeMsg := func(contract sdk.AccAddress, msg string) wasmvmtypes.CosmosMsg {
+				return wasmvmtypes.CosmosMsg{Wasm: &wasmvmtypes.WasmMsg{Execute: &wasmvmtypes.ExecuteMsg{ContractAddr: contract.String(), Msg: []byte(msg), Send: []wasmvmtypes.Coin{}}}}
+			}
+			var gotExecutions []string
+			mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, msg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.Response, uint64, error) {
+				switch {
+				case env.Contract.Address == first.String() && string(msg) == `{"start":{}}`:
+					gotExecutions = append(gotExecutions, "first")
+					if spec.reply {
+						return &wasmvmtypes.Response{Submessages: []wasmvmtypes.SubMsg{{ID: 1, Msg: executeMsg(second, `{"noop":{}}`), ReplyOn: wasmvmtypes.ReplyAlways}}}, 0, nil
+					}
+					return &wasmvmtypes.Response{Messages: []wasmvmtypes.CosmosMsg{executeMsg(second, `{"callback":{}}`)}}, 0, nil
+				case env.Contract.Address == second.String():
+					gotExecutions = append(gotExecutions, "second")
+					if string(msg) == `{"callback":{}}` {
+						return &wasmvmtypes.Response{Messages: []wasmvmtypes.CosmosMsg{executeMsg(first, `{"done":{}}`)}}, 0, nil
+					}
+					return &wasmvmtypes.Response{}, 0, nil
+				default:
+					gotExecutions = append(gotExecutions, "first")
+					return &wasmvmtypes.Response{}, 0, nil
+				}
+			}
+			mock.ReplyFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, reply wasmvmtypes.Reply, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.Response, uint64, error) {
+				gotExecutions = append(gotExecutions, "reply")
+				return &wasmvmtypes.Response{}, 0, nil
+			}
+
+			// when
+			_, gotErr := keepers.ContractKeeper.Execute(ctx, first, RandomAccountAddress(t), []byte(`{"start":{}}`), nil)
+
+			// then
+			if spec.expErr != nil {
+				assert.True(t, spec.expErr.Is(gotErr), "got %+v", gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expExecutions, gotExecutions)
+		})
+	}
+}
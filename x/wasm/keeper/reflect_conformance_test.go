@@ -0,0 +1,70 @@
+package keeper
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/CosmWasm/wasmd/x/wasm/conformance"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReflectConformance(t *testing.T) {
+	cdc := MakeEncodingConfig(t).Marshaler
+	ctx, keepers := CreateTestInput(t, false, ReflectFeatures, WithMessageEncoders(reflectEncoders(cdc)), WithQueryPlugins(reflectPlugins()))
+	valAddr := addValidator(t, ctx, keepers.StakingKeeper, keepers.AccountKeeper, keepers.BankKeeper, sdk.NewInt64Coin("stake", 1000000))
+	ctx = nextBlock(ctx, keepers.StakingKeeper)
+	reflectCode, err := ioutil.ReadFile("./testdata/reflect.wasm")
+	require.NoError(t, err)
+	chain := keeperChain{
+		ctx:     ctx,
+		keepers: keepers,
+		sender:  createFakeFundedAccount(t, ctx, keepers.AccountKeeper, keepers.BankKeeper, sdk.NewCoins(sdk.NewInt64Coin("stake", 1000))),
+	}
+
+	conformance.Run(t, chain, conformance.Config{
+		ReflectCode: reflectCode,
+		Denom:       "stake",
+		Validator:   valAddr.String(),
+		Custom:      true,
+	})
+}
+
+var _ conformance.Chain = keeperChain{}
+
+// keeperChain runs the conformance suite in process
+type keeperChain struct {
+	ctx     sdk.Context
+	keepers TestKeepers
+	sender  sdk.AccAddress
+}
+
+func (c keeperChain) Sender() sdk.AccAddress {
+	return c.sender
+}
+
+func (c keeperChain) StoreCode(wasm []byte) (uint64, error) {
+	return c.keepers.ContractKeeper.Create(c.ctx, c.sender, wasm, "", "", nil)
+}
+
+func (c keeperChain) Instantiate(codeID uint64, initMsg []byte, funds sdk.Coins) (string, error) {
+	addr, _, err := c.keepers.ContractKeeper.Instantiate(c.ctx, codeID, c.sender, nil, initMsg, "conformance", funds)
+	return addr.String(), err
+}
+
+func (c keeperChain) Execute(contract string, execMsg []byte, funds sdk.Coins) error {
+	contractAddr, err := sdk.AccAddressFromBech32(contract)
+	if err != nil {
+		return err
+	}
+	_, err = c.keepers.ContractKeeper.Execute(c.ctx, contractAddr, c.sender, execMsg, funds)
+	return err
+}
+
+func (c keeperChain) QuerySmart(contract string, query []byte) ([]byte, error) {
+	contractAddr, err := sdk.AccAddressFromBech32(contract)
+	if err != nil {
+		return nil, err
+	}
+	return c.keepers.WasmKeeper.QuerySmart(c.ctx, contractAddr, query)
+}
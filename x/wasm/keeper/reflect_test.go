@@ -164,6 +164,73 @@ func TestReflectContractSend(t *testing.T) {
 
 }
 
+func TestReflectContractSendRollbackOnFailure(t *testing.T) {
+	cdc := MakeEncodingConfig(t).Marshaler
+	ctx, keepers := CreateTestInput(t, false, ReflectFeatures, WithMessageEncoders(reflectEncoders(cdc)))
+	accKeeper, keeper, bankKeeper := keepers.AccountKeeper, keepers.ContractKeeper, keepers.BankKeeper
+
+	deposit := sdk.NewCoins(sdk.NewInt64Coin("denom", 100000))
+	creator := createFakeFundedAccount(t, ctx, accKeeper, bankKeeper, deposit)
+	_, _, bob := keyPubAddr()
+
+	// upload reflect code
+	reflectCode, err := ioutil.ReadFile("./testdata/reflect.wasm")
+	require.NoError(t, err)
+	reflectID, err := keeper.Create(ctx, creator, reflectCode, "", "", nil)
+	require.NoError(t, err)
+
+	// upload hackatom escrow code
+	escrowCode, err := ioutil.ReadFile("./testdata/hackatom.wasm")
+	require.NoError(t, err)
+	escrowID, err := keeper.Create(ctx, creator, escrowCode, "", "", nil)
+	require.NoError(t, err)
+
+	reflectStart := sdk.NewCoins(sdk.NewInt64Coin("denom", 40000))
+	reflectAddr, _, err := keeper.Instantiate(ctx, reflectID, creator, nil, []byte("{}"), "reflect contract", reflectStart)
+	require.NoError(t, err)
+
+	initMsgBz, err := json.Marshal(HackatomExampleInitMsg{Verifier: reflectAddr, Beneficiary: bob})
+	require.NoError(t, err)
+	escrowStart := sdk.NewCoins(sdk.NewInt64Coin("denom", 25000))
+	escrowAddr, _, err := keeper.Instantiate(ctx, escrowID, creator, nil, initMsgBz, "escrow contract", escrowStart)
+	require.NoError(t, err)
+
+	// the first two messages succeed but the last one fails
+	msgs := []wasmvmtypes.CosmosMsg{{
+		Bank: &wasmvmtypes.BankMsg{
+			Send: &wasmvmtypes.SendMsg{
+				ToAddress: bob.String(),
+				Amount:    []wasmvmtypes.Coin{{Denom: "denom", Amount: "1000"}},
+			},
+		},
+	}, {
+		Wasm: &wasmvmtypes.WasmMsg{
+			Execute: &wasmvmtypes.ExecuteMsg{
+				ContractAddr: escrowAddr.String(),
+				Msg:          []byte(`{"release":{}}`),
+				Send:         []wasmvmtypes.Coin{{Denom: "denom", Amount: "14000"}},
+			},
+		},
+	}, {
+		Wasm: &wasmvmtypes.WasmMsg{
+			Execute: &wasmvmtypes.ExecuteMsg{
+				ContractAddr: escrowAddr.String(),
+				Msg:          []byte(`{"unknown":{}}`),
+			},
+		},
+	}}
+	reflectSendBz, err := json.Marshal(ReflectHandleMsg{Reflect: &reflectPayload{Msgs: msgs}})
+	require.NoError(t, err)
+	_, err = keeper.Execute(ctx, reflectAddr, creator, reflectSendBz, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "dispatch message 3 of 3")
+
+	// no state changes from the successful messages were committed
+	checkAccount(t, ctx, accKeeper, bankKeeper, reflectAddr, reflectStart)
+	checkAccount(t, ctx, accKeeper, bankKeeper, escrowAddr, escrowStart)
+	checkAccount(t, ctx, accKeeper, bankKeeper, bob, nil)
+}
+
 func TestReflectCustomMsg(t *testing.T) {
 	cdc := MakeEncodingConfig(t).Marshaler
 	ctx, keepers := CreateTestInput(t, false, ReflectFeatures, WithMessageEncoders(reflectEncoders(cdc)), WithQueryPlugins(reflectPlugins()))
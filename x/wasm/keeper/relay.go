@@ -1,11 +1,15 @@
 package keeper
 
 import (
+	"fmt"
+	"strconv"
+
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
 	"github.com/cosmos/cosmos-sdk/telemetry"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	channeltypes "github.com/cosmos/cosmos-sdk/x/ibc/core/04-channel/types"
 	"time"
 )
 
@@ -21,16 +25,25 @@ func (k Keeper) OnOpenChannel(
 ) error {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "ibc-open-channel")
 
-	_, codeInfo, prefixStore, err := k.contractInstance(ctx, contractAddr)
+	contractInfo, codeInfo, _, err := k.contractInstance(ctx, contractAddr)
 	if err != nil {
 		return err
 	}
 
+	ctx = withCallOrigin(ctx, nil)
 	env := types.NewEnv(ctx, contractAddr)
-	querier := k.newQueryHandler(ctx, contractAddr)
+	vmCtx, prefixStore, commitWrites := k.bufferedContractStore(ctx, contractAddr)
+	querier := k.newQueryHandler(vmCtx, contractAddr)
 
+	call := ContractCall{EntryPoint: "ibc_channel_open", Contract: contractAddr, CodeID: contractInfo.CodeID}
+	if err := k.beforeContractExecution(ctx, call); err != nil {
+		return err
+	}
 	gas := k.runtimeGasForContract(ctx)
-	gasUsed, execErr := k.wasmVM.IBCChannelOpen(codeInfo.CodeHash, env, channel, prefixStore, cosmwasmAPI, querier, ctx.GasMeter(), gas)
+	gasUsed, execErr := k.wasmVM.IBCChannelOpen(codeInfo.CodeHash, env, channel, prefixStore, k.cosmwasmAPI, querier, ctx.GasMeter(), gas)
+	k.logContractExecution(ctx, "ibc_channel_open", contractAddr, contractInfo.CodeID, gasUsed, execErr)
+	k.afterContractExecution(ctx, call, gasUsed, execErr)
+	commitWrites()
 	k.consumeRuntimeGas(ctx, gasUsed)
 	if execErr != nil {
 		return sdkerrors.Wrap(types.ErrExecuteFailed, execErr.Error())
@@ -52,22 +65,31 @@ func (k Keeper) OnConnectChannel(
 	channel wasmvmtypes.IBCChannel,
 ) error {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "ibc-connect-channel")
-	contractInfo, codeInfo, prefixStore, err := k.contractInstance(ctx, contractAddr)
+	contractInfo, codeInfo, _, err := k.contractInstance(ctx, contractAddr)
 	if err != nil {
 		return err
 	}
 
+	ctx = withCallOrigin(ctx, nil)
 	env := types.NewEnv(ctx, contractAddr)
-	querier := k.newQueryHandler(ctx, contractAddr)
+	vmCtx, prefixStore, commitWrites := k.bufferedContractStore(ctx, contractAddr)
+	querier := k.newQueryHandler(vmCtx, contractAddr)
 
+	call := ContractCall{EntryPoint: "ibc_channel_connect", Contract: contractAddr, CodeID: contractInfo.CodeID}
+	if err := k.beforeContractExecution(ctx, call); err != nil {
+		return err
+	}
 	gas := k.runtimeGasForContract(ctx)
-	res, gasUsed, execErr := k.wasmVM.IBCChannelConnect(codeInfo.CodeHash, env, channel, prefixStore, cosmwasmAPI, querier, ctx.GasMeter(), gas)
+	res, gasUsed, execErr := k.wasmVM.IBCChannelConnect(codeInfo.CodeHash, env, channel, prefixStore, k.cosmwasmAPI, querier, ctx.GasMeter(), gas)
+	k.logContractExecution(ctx, "ibc_channel_connect", contractAddr, contractInfo.CodeID, gasUsed, execErr)
+	k.afterContractExecution(ctx, call, gasUsed, execErr)
+	commitWrites()
 	k.consumeRuntimeGas(ctx, gasUsed)
 	if execErr != nil {
 		return sdkerrors.Wrap(types.ErrExecuteFailed, execErr.Error())
 	}
 
-	return k.handleIBCBasicContractResponse(ctx, contractAddr, contractInfo.IBCPortID, res)
+	return k.handleIBCBasicContractResponse(ctx, contractAddr, contractInfo.IBCPortID, k.isPinnedForEventCosts(ctx, contractInfo.CodeID), res)
 }
 
 // OnCloseChannel calls the contract to let it know the IBC channel is closed.
@@ -83,22 +105,32 @@ func (k Keeper) OnCloseChannel(
 ) error {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "ibc-close-channel")
 
-	contractInfo, codeInfo, prefixStore, err := k.contractInstance(ctx, contractAddr)
+	contractInfo, codeInfo, _, err := k.contractInstance(ctx, contractAddr)
 	if err != nil {
 		return err
 	}
 
-	params := types.NewEnv(ctx, contractAddr)
-	querier := k.newQueryHandler(ctx, contractAddr)
+	ctx = withCallOrigin(ctx, nil)
+	env := types.NewEnv(ctx, contractAddr)
+	vmCtx, prefixStore, commitWrites := k.bufferedContractStore(ctx, contractAddr)
+	querier := k.newQueryHandler(vmCtx, contractAddr)
 
+	call := ContractCall{EntryPoint: "ibc_channel_close", Contract: contractAddr, CodeID: contractInfo.CodeID}
+	if err := k.beforeContractExecution(ctx, call); err != nil {
+		return err
+	}
 	gas := k.runtimeGasForContract(ctx)
-	res, gasUsed, execErr := k.wasmVM.IBCChannelClose(codeInfo.CodeHash, params, channel, prefixStore, cosmwasmAPI, querier, ctx.GasMeter(), gas)
+	res, gasUsed, execErr := k.wasmVM.IBCChannelClose(codeInfo.CodeHash, env, channel, prefixStore, k.cosmwasmAPI, querier, ctx.GasMeter(), gas)
+	k.logContractExecution(ctx, "ibc_channel_close", contractAddr, contractInfo.CodeID, gasUsed, execErr)
+	k.afterContractExecution(ctx, call, gasUsed, execErr)
+	commitWrites()
 	k.consumeRuntimeGas(ctx, gasUsed)
 	if execErr != nil {
 		return sdkerrors.Wrap(types.ErrExecuteFailed, execErr.Error())
 	}
 
-	return k.handleIBCBasicContractResponse(ctx, contractAddr, contractInfo.IBCPortID, res)
+	// pending packet reply references are kept as packets in flight are still timed out on the closed channel
+	return k.handleIBCBasicContractResponse(ctx, contractAddr, contractInfo.IBCPortID, k.isPinnedForEventCosts(ctx, contractInfo.CodeID), res)
 }
 
 // OnRecvPacket calls the contract to process the incoming IBC packet. The contract fully owns the data processing and
@@ -113,22 +145,40 @@ func (k Keeper) OnRecvPacket(
 	packet wasmvmtypes.IBCPacket,
 ) ([]byte, error) {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "ibc-recv-packet")
-	contractInfo, codeInfo, prefixStore, err := k.contractInstance(ctx, contractAddr)
+	var ack []byte
+	err := k.withIBCPacketGasLimit(ctx, func(ctx sdk.Context) (err error) {
+		ack, err = k.onRecvPacket(ctx, contractAddr, packet)
+		return err
+	})
+	return ack, err
+}
+
+func (k Keeper) onRecvPacket(ctx sdk.Context, contractAddr sdk.AccAddress, packet wasmvmtypes.IBCPacket) ([]byte, error) {
+	contractInfo, codeInfo, _, err := k.contractInstance(ctx, contractAddr)
 	if err != nil {
 		return nil, err
 	}
 
+	ctx = withReceivedPacket(withCallOrigin(ctx, nil), contractAddr, packet)
 	env := types.NewEnv(ctx, contractAddr)
-	querier := k.newQueryHandler(ctx, contractAddr)
+	vmCtx, prefixStore, commitWrites := k.bufferedContractStore(ctx, contractAddr)
+	querier := k.newQueryHandler(vmCtx, contractAddr)
 
+	call := ContractCall{EntryPoint: "ibc_packet_receive", Contract: contractAddr, CodeID: contractInfo.CodeID}
+	if err := k.beforeContractExecution(ctx, call); err != nil {
+		return nil, err
+	}
 	gas := k.runtimeGasForContract(ctx)
-	res, gasUsed, execErr := k.wasmVM.IBCPacketReceive(codeInfo.CodeHash, env, packet, prefixStore, cosmwasmAPI, querier, ctx.GasMeter(), gas)
+	res, gasUsed, execErr := k.wasmVM.IBCPacketReceive(codeInfo.CodeHash, env, packet, prefixStore, k.cosmwasmAPI, querier, ctx.GasMeter(), gas)
+	k.logContractExecution(ctx, "ibc_packet_receive", contractAddr, contractInfo.CodeID, gasUsed, execErr)
+	k.afterContractExecution(ctx, call, gasUsed, execErr)
+	commitWrites()
 	k.consumeRuntimeGas(ctx, gasUsed)
 	if execErr != nil {
 		return nil, sdkerrors.Wrap(types.ErrExecuteFailed, execErr.Error())
 	}
 
-	return k.handleContractResponse(ctx, contractAddr, contractInfo.IBCPortID, res.Submessages, res.Messages, res.Attributes, res.Acknowledgement)
+	return k.handleContractResponse(ctx, contractAddr, contractInfo.IBCPortID, k.isPinnedForEventCosts(ctx, contractInfo.CodeID), res.Submessages, res.Messages, res.Attributes, res.Acknowledgement)
 }
 
 // OnAckPacket calls the contract to handle the "acknowledgement" data which can contain success or failure of a packet
@@ -144,21 +194,68 @@ func (k Keeper) OnAckPacket(
 	acknowledgement wasmvmtypes.IBCAcknowledgement,
 ) error {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "ibc-ack-packet")
-	contractInfo, codeInfo, prefixStore, err := k.contractInstance(ctx, contractAddr)
+	err := k.withIBCPacketGasLimit(ctx, func(ctx sdk.Context) error {
+		return k.onAckPacket(ctx, contractAddr, acknowledgement)
+	})
 	if err != nil {
 		return err
 	}
+	k.replyWithPacketResult(ctx, acknowledgement.OriginalPacket, packetAckResult(acknowledgement))
+	return nil
+}
 
+func (k Keeper) onAckPacket(ctx sdk.Context, contractAddr sdk.AccAddress, acknowledgement wasmvmtypes.IBCAcknowledgement) error {
+	contractInfo, codeInfo, _, err := k.contractInstance(ctx, contractAddr)
+	if err != nil {
+		return err
+	}
+
+	ctx = withCallOrigin(ctx, nil)
 	env := types.NewEnv(ctx, contractAddr)
-	querier := k.newQueryHandler(ctx, contractAddr)
+	vmCtx, prefixStore, commitWrites := k.bufferedContractStore(ctx, contractAddr)
+	querier := k.newQueryHandler(vmCtx, contractAddr)
 
+	call := ContractCall{EntryPoint: "ibc_packet_ack", Contract: contractAddr, CodeID: contractInfo.CodeID}
+	if err := k.beforeContractExecution(ctx, call); err != nil {
+		return err
+	}
 	gas := k.runtimeGasForContract(ctx)
-	res, gasUsed, execErr := k.wasmVM.IBCPacketAck(codeInfo.CodeHash, env, acknowledgement, prefixStore, cosmwasmAPI, querier, ctx.GasMeter(), gas)
+	res, gasUsed, execErr := k.wasmVM.IBCPacketAck(codeInfo.CodeHash, env, acknowledgement, prefixStore, k.cosmwasmAPI, querier, ctx.GasMeter(), gas)
+	k.logContractExecution(ctx, "ibc_packet_ack", contractAddr, contractInfo.CodeID, gasUsed, execErr)
+	k.afterContractExecution(ctx, call, gasUsed, execErr)
+	commitWrites()
 	k.consumeRuntimeGas(ctx, gasUsed)
 	if execErr != nil {
 		return sdkerrors.Wrap(types.ErrExecuteFailed, execErr.Error())
 	}
-	return k.handleIBCBasicContractResponse(ctx, contractAddr, contractInfo.IBCPortID, res)
+	return k.handleIBCBasicContractResponse(ctx, contractAddr, contractInfo.IBCPortID, k.isPinnedForEventCosts(ctx, contractInfo.CodeID), res)
+}
+
+// packetAckResult maps the acknowledgement to the result of the submessage that sent the packet. An acknowledgement
+// with the error of the standard envelope is an error result, any other data is passed on as success.
+func packetAckResult(acknowledgement wasmvmtypes.IBCAcknowledgement) wasmvmtypes.SubcallResult {
+	packet := acknowledgement.OriginalPacket
+	var ack channeltypes.Acknowledgement
+	if err := channeltypes.SubModuleCdc.UnmarshalJSON(acknowledgement.Acknowledgement, &ack); err == nil {
+		if e, ok := ack.Response.(*channeltypes.Acknowledgement_Error); ok {
+			return wasmvmtypes.SubcallResult{
+				Err: fmt.Sprintf("error acknowledgement: packet %s/%s/%d: %s", packet.Src.PortID, packet.Src.ChannelID, packet.Sequence, e.Error),
+			}
+		}
+	}
+	return wasmvmtypes.SubcallResult{
+		Ok: &wasmvmtypes.SubcallResponse{
+			Events: []wasmvmtypes.Event{{
+				Type: types.EventTypePacketAck,
+				Attributes: []wasmvmtypes.EventAttribute{
+					{Key: types.AttributeKeyPacketSrcPort, Value: packet.Src.PortID},
+					{Key: types.AttributeKeyPacketSrcChannel, Value: packet.Src.ChannelID},
+					{Key: types.AttributeKeyPacketSequence, Value: strconv.FormatUint(packet.Sequence, 10)},
+				},
+			}},
+			Data: acknowledgement.Acknowledgement,
+		},
+	}
 }
 
 // OnTimeoutPacket calls the contract to let it know the packet was never received on the destination chain within
@@ -170,26 +267,148 @@ func (k Keeper) OnTimeoutPacket(
 	packet wasmvmtypes.IBCPacket,
 ) error {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "ibc-timeout-packet")
+	err := k.withIBCPacketGasLimit(ctx, func(ctx sdk.Context) error {
+		return k.onTimeoutPacket(ctx, contractAddr, packet)
+	})
+	if err != nil {
+		return err
+	}
+	k.replyWithPacketResult(ctx, packet, wasmvmtypes.SubcallResult{
+		Err: fmt.Sprintf("timeout: packet %s/%s/%d", packet.Src.PortID, packet.Src.ChannelID, packet.Sequence),
+	})
+	k.onTimeoutClosedChannel(ctx, packet.Src.PortID, packet.Src.ChannelID)
+	return nil
+}
 
-	contractInfo, codeInfo, prefixStore, err := k.contractInstance(ctx, contractAddr)
+func (k Keeper) onTimeoutPacket(ctx sdk.Context, contractAddr sdk.AccAddress, packet wasmvmtypes.IBCPacket) error {
+	contractInfo, codeInfo, _, err := k.contractInstance(ctx, contractAddr)
 	if err != nil {
 		return err
 	}
 
+	ctx = withCallOrigin(ctx, nil)
 	env := types.NewEnv(ctx, contractAddr)
-	querier := k.newQueryHandler(ctx, contractAddr)
+	vmCtx, prefixStore, commitWrites := k.bufferedContractStore(ctx, contractAddr)
+	querier := k.newQueryHandler(vmCtx, contractAddr)
 
+	call := ContractCall{EntryPoint: "ibc_packet_timeout", Contract: contractAddr, CodeID: contractInfo.CodeID}
+	if err := k.beforeContractExecution(ctx, call); err != nil {
+		return err
+	}
 	gas := k.runtimeGasForContract(ctx)
-	res, gasUsed, execErr := k.wasmVM.IBCPacketTimeout(codeInfo.CodeHash, env, packet, prefixStore, cosmwasmAPI, querier, ctx.GasMeter(), gas)
+	res, gasUsed, execErr := k.wasmVM.IBCPacketTimeout(codeInfo.CodeHash, env, packet, prefixStore, k.cosmwasmAPI, querier, ctx.GasMeter(), gas)
+	k.logContractExecution(ctx, "ibc_packet_timeout", contractAddr, contractInfo.CodeID, gasUsed, execErr)
+	k.afterContractExecution(ctx, call, gasUsed, execErr)
+	commitWrites()
 	k.consumeRuntimeGas(ctx, gasUsed)
 	if execErr != nil {
 		return sdkerrors.Wrap(types.ErrExecuteFailed, execErr.Error())
 	}
 
-	return k.handleIBCBasicContractResponse(ctx, contractAddr, contractInfo.IBCPortID, res)
+	return k.handleIBCBasicContractResponse(ctx, contractAddr, contractInfo.IBCPortID, k.isPinnedForEventCosts(ctx, contractInfo.CodeID), res)
+}
+
+// isPinnedForEventCosts returns the pinned state of the code for the event costs of the IBC callbacks. The state is
+// not read when the gas register charges pinned contracts the same, so that the callbacks do not pay for the read.
+func (k Keeper) isPinnedForEventCosts(ctx sdk.Context, codeID uint64) bool {
+	if r, ok := k.gasRegister.(PinnedEventCostsRegister); !ok || !r.HasPinnedEventCosts() {
+		return false
+	}
+	return k.IsPinnedCode(ctx, codeID)
+}
+
+func (k Keeper) handleIBCBasicContractResponse(ctx sdk.Context, addr sdk.AccAddress, id string, pinned bool, res *wasmvmtypes.IBCBasicResponse) error {
+	_, err := k.handleContractResponse(ctx, addr, id, pinned, res.Submessages, res.Messages, res.Attributes, nil)
+	return err
+}
+
+// storePacketReply persists a reference to the submessage that sent the IBC packet
+func (k Keeper) storePacketReply(ctx sdk.Context, portID, channelID string, sequence uint64, reply types.PacketReply) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetPacketReplyKey(portID, channelID, sequence), k.cdc.MustMarshalBinaryBare(&reply))
+}
+
+// replyWithPacketResult returns the packet result to the contract when the packet was sent within a submessage.
+// The reply is sent via the contract's `reply` entry point with the id of the original submessage, so that contracts
+// do not have to correlate packet sequences on their own. The reference is removed so that there is one result only.
+// The reply runs with its own IBC packet gas limit. A failing reply, also when it runs out of this limit, reverts its
+// own state changes and events only, like a failed submessage, and is logged. It does not fail the packet callback.
+func (k Keeper) replyWithPacketResult(ctx sdk.Context, packet wasmvmtypes.IBCPacket, result wasmvmtypes.SubcallResult) {
+	store := ctx.KVStore(k.storeKey)
+	key := types.GetPacketReplyKey(packet.Src.PortID, packet.Src.ChannelID, packet.Sequence)
+	bz := store.Get(key)
+	if bz == nil {
+		return
+	}
+	store.Delete(key)
+	var packetReply types.PacketReply
+	k.cdc.MustUnmarshalBinaryBare(bz, &packetReply)
+
+	switch packetReply.ReplyOn {
+	case string(wasmvmtypes.ReplySuccess):
+		if result.Ok == nil {
+			return
+		}
+	case string(wasmvmtypes.ReplyError):
+		if result.Ok != nil {
+			return
+		}
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(packetReply.ContractAddress)
+	if err != nil {
+		k.Logger(ctx).Error("packet reply", "contract", packetReply.ContractAddress, "error", err.Error())
+		return
+	}
+	em := sdk.NewEventManager()
+	err = k.withIBCPacketGasLimit(ctx, func(ctx sdk.Context) error {
+		replyCtx, commit := ctx.CacheContext()
+		if _, err := k.reply(replyCtx.WithEventManager(em), contractAddr, wasmvmtypes.Reply{ID: packetReply.ReplyID, Result: result}); err != nil {
+			return err
+		}
+		commit()
+		return nil
+	})
+	if err != nil {
+		k.Logger(ctx).Error("packet reply", "contract", packetReply.ContractAddress, "reply_id", packetReply.ReplyID, "error", err.Error())
+		return
+	}
+	ctx.EventManager().EmitEvents(em.Events())
+}
+
+// withIBCPacketGasLimit executes the callback with the IBC packet gas limit param applied. This limit is independent
+// of the gas provided by the relayer so that a counterparty can not make packet processing arbitrary expensive.
+// All gas spent is charged to the parent context. When the limit is exceeded the full limit is charged and an
+// out of gas error returned.
+func (k Keeper) withIBCPacketGasLimit(ctx sdk.Context, cb func(ctx sdk.Context) error) (err error) {
+	return withGasLimit(ctx, k.GetIBCPacketGasLimit(ctx), "IBC packet gas limit", cb)
 }
 
-func (k Keeper) handleIBCBasicContractResponse(ctx sdk.Context, addr sdk.AccAddress, id string, res *wasmvmtypes.IBCBasicResponse) error {
-	_, err := k.handleContractResponse(ctx, addr, id, res.Submessages, res.Messages, res.Attributes, nil)
+// withGasLimit executes the callback with a dedicated gas meter when the given limit is lower than the remaining gas
+// of the parent context. A limit of 0 is ignored. All gas spent is charged to the parent context. When the limit is
+// exceeded the full limit is charged and an out of gas error returned.
+func withGasLimit(ctx sdk.Context, gasLimit uint64, descriptor string, cb func(ctx sdk.Context) error) (err error) {
+	if gasLimit == 0 {
+		return cb(ctx)
+	}
+	meter := ctx.GasMeter()
+	if meter.Limit() != 0 && meter.Limit()-meter.GasConsumedToLimit() <= gasLimit {
+		// the remaining tx gas is the tighter limit
+		return cb(ctx)
+	}
+	subCtx := ctx.WithGasMeter(sdk.NewGasMeter(gasLimit))
+	// catch out of gas panic and just charge the entire gas limit
+	defer func() {
+		if r := recover(); r != nil {
+			// if it's not an OutOfGas error, raise it again
+			if _, ok := r.(sdk.ErrorOutOfGas); !ok {
+				panic(r)
+			}
+			ctx.GasMeter().ConsumeGas(gasLimit, descriptor+" OutOfGas panic")
+			err = sdkerrors.Wrap(sdkerrors.ErrOutOfGas, descriptor)
+		}
+	}()
+	err = cb(subCtx)
+	// make sure we charge the parent what was spent
+	ctx.GasMeter().ConsumeGas(subCtx.GasMeter().GasConsumed(), descriptor)
 	return err
 }
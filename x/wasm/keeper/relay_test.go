@@ -4,9 +4,12 @@ import (
 	"encoding/json"
 	"errors"
 	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
 	wasmvm "github.com/CosmWasm/wasmvm"
 	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	channeltypes "github.com/cosmos/cosmos-sdk/x/ibc/core/04-channel/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"math"
@@ -178,7 +181,7 @@ func TestOnConnectChannel(t *testing.T) {
 			}
 			require.NoError(t, err)
 			// verify gas consumed
-			const storageCosts = sdk.Gas(0xa9d)
+			const storageCosts = sdk.Gas(0xe9a)
 			assert.Equal(t, spec.expContractGas, ctx.GasMeter().GasConsumed()-before-storageCosts)
 			// verify msgs dispatched
 			assert.Equal(t, spec.contractResp.Messages, *capturedMsgs)
@@ -288,7 +291,7 @@ func TestOnCloseChannel(t *testing.T) {
 			}
 			require.NoError(t, err)
 			// verify gas consumed
-			const storageCosts = sdk.Gas(0xa9d)
+			const storageCosts = sdk.Gas(0xe9a)
 			assert.Equal(t, spec.expContractGas, ctx.GasMeter().GasConsumed()-before-storageCosts)
 			// verify msgs dispatched
 			assert.Equal(t, spec.contractResp.Messages, *capturedMsgs)
@@ -318,7 +321,7 @@ func TestOnRecvPacket(t *testing.T) {
 	}{
 		"consume contract gas": {
 			contractAddr:   example.Contract,
-			expContractGas: myContractGas,
+			expContractGas: myContractGas + 5, // ack data costs
 			contractResp: &wasmvmtypes.IBCReceiveResponse{
 				Acknowledgement: []byte("myAck"),
 			},
@@ -342,7 +345,7 @@ func TestOnRecvPacket(t *testing.T) {
 		},
 		"dispatch contract messages on success": {
 			contractAddr:   example.Contract,
-			expContractGas: myContractGas,
+			expContractGas: myContractGas + 5, // ack data costs
 			contractResp: &wasmvmtypes.IBCReceiveResponse{
 				Acknowledgement: []byte("myAck"),
 				Messages:        []wasmvmtypes.CosmosMsg{{Bank: &wasmvmtypes.BankMsg{}}, {Custom: json.RawMessage(`{"foo":"bar"}`)}},
@@ -350,7 +353,7 @@ func TestOnRecvPacket(t *testing.T) {
 		},
 		"emit contract events on success": {
 			contractAddr:   example.Contract,
-			expContractGas: myContractGas + 10,
+			expContractGas: myContractGas + 15, // event and ack data costs
 			contractResp: &wasmvmtypes.IBCReceiveResponse{
 				Acknowledgement: []byte("myAck"),
 				Attributes:      []wasmvmtypes.EventAttribute{{Key: "Foo", Value: "Bar"}},
@@ -359,7 +362,7 @@ func TestOnRecvPacket(t *testing.T) {
 		},
 		"messenger errors returned, events stored": {
 			contractAddr:   example.Contract,
-			expContractGas: myContractGas + 10,
+			expContractGas: myContractGas + 15, // event and ack data costs
 			contractResp: &wasmvmtypes.IBCReceiveResponse{
 				Acknowledgement: []byte("myAck"),
 				Messages:        []wasmvmtypes.CosmosMsg{{Bank: &wasmvmtypes.BankMsg{}}, {Custom: json.RawMessage(`{"foo":"bar"}`)}},
@@ -414,7 +417,7 @@ func TestOnRecvPacket(t *testing.T) {
 			require.Equal(t, spec.contractResp.Acknowledgement, gotAck)
 
 			// verify gas consumed
-			const storageCosts = sdk.Gas(0xa9d)
+			const storageCosts = sdk.Gas(0x129d)
 			assert.Equal(t, spec.expContractGas, ctx.GasMeter().GasConsumed()-before-storageCosts)
 			// verify msgs dispatched
 			assert.Equal(t, spec.contractResp.Messages, *capturedMsgs)
@@ -526,7 +529,7 @@ func TestOnAckPacket(t *testing.T) {
 			}
 			require.NoError(t, err)
 			// verify gas consumed
-			const storageCosts = sdk.Gas(0xa9d)
+			const storageCosts = sdk.Gas(0x1685)
 			assert.Equal(t, spec.expContractGas, ctx.GasMeter().GasConsumed()-before-storageCosts)
 			// verify msgs dispatched
 			assert.Equal(t, spec.contractResp.Messages, *capturedMsgs)
@@ -637,7 +640,7 @@ func TestOnTimeoutPacket(t *testing.T) {
 			}
 			require.NoError(t, err)
 			// verify gas consumed
-			const storageCosts = sdk.Gas(0xa9d)
+			const storageCosts = sdk.Gas(0x1685)
 			assert.Equal(t, spec.expContractGas, ctx.GasMeter().GasConsumed()-before-storageCosts)
 			// verify msgs dispatched
 			assert.Equal(t, spec.contractResp.Messages, *capturedMsgs)
@@ -646,3 +649,268 @@ func TestOnTimeoutPacket(t *testing.T) {
 		})
 	}
 }
+
+func TestWithIBCPacketGasLimit(t *testing.T) {
+	const myGasLimit = 1000
+	specs := map[string]struct {
+		paramGasLimit uint64
+		txGasLimit    sdk.Gas
+		consume       sdk.Gas
+		expGasCharged sdk.Gas
+		expOutOfGas   bool
+		expPanic      bool
+	}{
+		"within limit": {
+			paramGasLimit: myGasLimit,
+			consume:       myGasLimit - 1,
+			expGasCharged: myGasLimit - 1,
+		},
+		"exceeds limit": {
+			paramGasLimit: myGasLimit,
+			consume:       myGasLimit + 1,
+			expGasCharged: myGasLimit,
+			expOutOfGas:   true,
+		},
+		"limit disabled": {
+			consume:       myGasLimit + 1,
+			expGasCharged: myGasLimit + 1,
+		},
+		"tx gas limit lower than param": {
+			paramGasLimit: 100 * myGasLimit,
+			txGasLimit:    50 * myGasLimit,
+			consume:       50*myGasLimit + 1,
+			expPanic:      true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			parentCtx, keepers := CreateTestInput(t, false, SupportedFeatures)
+			k := keepers.WasmKeeper
+			params := types.DefaultParams()
+			params.IBCPacketGasLimit = spec.paramGasLimit
+			k.setParams(parentCtx, params)
+
+			ctx := parentCtx.WithGasMeter(sdk.NewInfiniteGasMeter())
+			if spec.txGasLimit != 0 {
+				ctx = ctx.WithGasMeter(sdk.NewGasMeter(spec.txGasLimit))
+			}
+			// gas costs for reading the param
+			k.GetIBCPacketGasLimit(ctx)
+			paramReadCosts := ctx.GasMeter().GasConsumed()
+			ctx.GasMeter().RefundGas(paramReadCosts, "testing")
+
+			cb := func(ctx sdk.Context) error {
+				ctx.GasMeter().ConsumeGas(spec.consume, "testing")
+				return nil
+			}
+			if spec.expPanic {
+				assert.Panics(t, func() {
+					_ = k.withIBCPacketGasLimit(ctx, cb)
+				})
+				return
+			}
+			// when
+			gotErr := k.withIBCPacketGasLimit(ctx, cb)
+			// then
+			if spec.expOutOfGas {
+				assert.True(t, sdkerrors.ErrOutOfGas.Is(gotErr), "got %#+v", gotErr)
+			} else {
+				assert.NoError(t, gotErr)
+			}
+			assert.Equal(t, spec.expGasCharged, ctx.GasMeter().GasConsumed()-paramReadCosts)
+		})
+	}
+}
+
+func TestReplyWithPacketResult(t *testing.T) {
+	var m wasmtesting.MockWasmer
+	wasmtesting.MakeIBCInstantiable(&m)
+	parentCtx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	example := SeedNewContractInstance(t, parentCtx, keepers, &m)
+	myPacket := wasmvmtypes.IBCPacket{
+		Src:      wasmvmtypes.IBCEndpoint{PortID: "my_port", ChannelID: "channel-1"},
+		Sequence: 7,
+	}
+	okResult := wasmvmtypes.SubcallResult{Ok: &wasmvmtypes.SubcallResponse{Data: []byte("myAck")}}
+	errResult := wasmvmtypes.SubcallResult{Err: "timeout"}
+
+	specs := map[string]struct {
+		stored   *types.PacketReply
+		result   wasmvmtypes.SubcallResult
+		replyErr error
+		replyGas uint64
+		expReply *wasmvmtypes.Reply
+		expGas   sdk.Gas
+	}{
+		"reply always on success": {
+			stored:   &types.PacketReply{ContractAddress: example.Contract.String(), ReplyID: 1, ReplyOn: "always"},
+			result:   okResult,
+			expReply: &wasmvmtypes.Reply{ID: 1, Result: okResult},
+		},
+		"reply always on error": {
+			stored:   &types.PacketReply{ContractAddress: example.Contract.String(), ReplyID: 1, ReplyOn: "always"},
+			result:   errResult,
+			expReply: &wasmvmtypes.Reply{ID: 1, Result: errResult},
+		},
+		"reply on success only": {
+			stored: &types.PacketReply{ContractAddress: example.Contract.String(), ReplyID: 1, ReplyOn: "success"},
+			result: errResult,
+		},
+		"reply on error only": {
+			stored: &types.PacketReply{ContractAddress: example.Contract.String(), ReplyID: 1, ReplyOn: "error"},
+			result: okResult,
+		},
+		"no reference stored": {
+			result: okResult,
+		},
+		"reply fails": {
+			stored:   &types.PacketReply{ContractAddress: example.Contract.String(), ReplyID: 1, ReplyOn: "always"},
+			result:   okResult,
+			replyErr: errors.New("testing"),
+			expReply: &wasmvmtypes.Reply{ID: 1, Result: okResult},
+		},
+		"reply out of gas": {
+			stored:   &types.PacketReply{ContractAddress: example.Contract.String(), ReplyID: 1, ReplyOn: "always"},
+			result:   okResult,
+			replyGas: (types.DefaultIBCPacketGasLimit + 1) * DefaultGasMultiplier,
+			expReply: &wasmvmtypes.Reply{ID: 1, Result: okResult},
+			expGas:   types.DefaultIBCPacketGasLimit,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			em := sdk.NewEventManager()
+			ctx = ctx.WithEventManager(em)
+			var gotReply *wasmvmtypes.Reply
+			m.ReplyFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, reply wasmvmtypes.Reply, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.Response, uint64, error) {
+				gotReply = &reply
+				store.Set([]byte("my-key"), []byte("my-value"))
+				return &wasmvmtypes.Response{}, spec.replyGas, spec.replyErr
+			}
+			k := keepers.WasmKeeper
+			if spec.stored != nil {
+				k.storePacketReply(ctx, myPacket.Src.PortID, myPacket.Src.ChannelID, myPacket.Sequence, *spec.stored)
+			}
+
+			before := ctx.GasMeter().GasConsumed()
+
+			// when
+			k.replyWithPacketResult(ctx, myPacket, spec.result)
+
+			// then
+			assert.Equal(t, spec.expReply, gotReply)
+			if spec.expGas != 0 {
+				// the full packet gas limit is charged, besides the storage costs
+				assert.GreaterOrEqual(t, ctx.GasMeter().GasConsumed()-before, spec.expGas)
+			}
+			// and reply state and events committed on success only
+			committed := spec.expReply != nil && spec.replyErr == nil && spec.replyGas == 0
+			assert.Equal(t, committed, k.QueryRaw(ctx, example.Contract, []byte("my-key")) != nil)
+			assert.Equal(t, committed, len(em.Events()) != 0)
+			// and reference removed
+			assert.False(t, ctx.KVStore(k.storeKey).Has(types.GetPacketReplyKey(myPacket.Src.PortID, myPacket.Src.ChannelID, myPacket.Sequence)))
+		})
+	}
+}
+
+func TestPacketAckResult(t *testing.T) {
+	myPacket := wasmvmtypes.IBCPacket{
+		Src:      wasmvmtypes.IBCEndpoint{PortID: "my_port", ChannelID: "channel-1"},
+		Sequence: 7,
+	}
+	specs := map[string]struct {
+		ack    []byte
+		expErr string
+	}{
+		"result acknowledgement": {
+			ack: channeltypes.NewResultAcknowledgement([]byte("myResult")).GetBytes(),
+		},
+		"error acknowledgement": {
+			ack:    channeltypes.NewErrorAcknowledgement("myError").GetBytes(),
+			expErr: "error acknowledgement: packet my_port/channel-1/7: myError",
+		},
+		"custom acknowledgement": {
+			ack: []byte(`{"myAck":{}}`),
+		},
+		"non json acknowledgement": {
+			ack: []byte("myAck"),
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got := packetAckResult(wasmvmtypes.IBCAcknowledgement{Acknowledgement: spec.ack, OriginalPacket: myPacket})
+			if spec.expErr != "" {
+				assert.Equal(t, wasmvmtypes.SubcallResult{Err: spec.expErr}, got)
+				return
+			}
+			require.NotNil(t, got.Ok)
+			assert.Equal(t, spec.ack, got.Ok.Data)
+		})
+	}
+}
+
+func TestPacketReplyAfterChannelClose(t *testing.T) {
+	var m wasmtesting.MockWasmer
+	wasmtesting.MakeIBCInstantiable(&m)
+	m.IBCChannelCloseFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, channel wasmvmtypes.IBCChannel, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.IBCBasicResponse, uint64, error) {
+		return &wasmvmtypes.IBCBasicResponse{}, 0, nil
+	}
+	m.IBCPacketTimeoutFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, packet wasmvmtypes.IBCPacket, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.IBCBasicResponse, uint64, error) {
+		return &wasmvmtypes.IBCBasicResponse{}, 0, nil
+	}
+	var gotReplies []wasmvmtypes.Reply
+	m.ReplyFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, reply wasmvmtypes.Reply, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.Response, uint64, error) {
+		gotReplies = append(gotReplies, reply)
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+	parentCtx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	example := SeedNewContractInstance(t, parentCtx, keepers, &m)
+	myChannel := wasmvmtypes.IBCChannel{
+		Endpoint:             wasmvmtypes.IBCEndpoint{PortID: "my_port", ChannelID: "channel-1"},
+		CounterpartyEndpoint: wasmvmtypes.IBCEndpoint{PortID: "other", ChannelID: "channel-9"},
+		ConnectionID:         "connection-0",
+	}
+
+	specs := map[string]struct {
+		order channeltypes.Order
+	}{
+		"ordered channel": {
+			order: channeltypes.ORDERED,
+		},
+		"unordered channel": {
+			order: channeltypes.UNORDERED,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			gotReplies = nil
+			ctx, _ := parentCtx.CacheContext()
+			keepers.IBCKeeper.ChannelKeeper.SetChannel(ctx, myChannel.Endpoint.PortID, myChannel.Endpoint.ChannelID, channeltypes.Channel{
+				State:          channeltypes.CLOSED,
+				Ordering:       spec.order,
+				Counterparty:   channeltypes.NewCounterparty(myChannel.CounterpartyEndpoint.PortID, myChannel.CounterpartyEndpoint.ChannelID),
+				ConnectionHops: []string{myChannel.ConnectionID},
+			})
+			k := keepers.WasmKeeper
+			k.storePacketReply(ctx, myChannel.Endpoint.PortID, myChannel.Endpoint.ChannelID, 1, types.PacketReply{ContractAddress: example.Contract.String(), ReplyID: 1, ReplyOn: "always"})
+			k.storePacketReply(ctx, myChannel.Endpoint.PortID, myChannel.Endpoint.ChannelID, 2, types.PacketReply{ContractAddress: example.Contract.String(), ReplyID: 2, ReplyOn: "always"})
+			require.NoError(t, k.OnCloseChannel(ctx, example.Contract, myChannel))
+
+			// when packets in flight are timed out on the closed channel
+			for _, seq := range []uint64{1, 2} {
+				err := k.OnTimeoutPacket(ctx, example.Contract, wasmvmtypes.IBCPacket{Src: myChannel.Endpoint, Sequence: seq})
+				require.NoError(t, err)
+			}
+
+			// then
+			require.Len(t, gotReplies, 2)
+			assert.Equal(t, wasmvmtypes.Reply{ID: 1, Result: wasmvmtypes.SubcallResult{Err: "timeout: packet my_port/channel-1/1"}}, gotReplies[0])
+			assert.Equal(t, wasmvmtypes.Reply{ID: 2, Result: wasmvmtypes.SubcallResult{Err: "timeout: packet my_port/channel-1/2"}}, gotReplies[1])
+			// and references removed
+			store := ctx.KVStore(k.storeKey)
+			assert.False(t, store.Has(types.GetPacketReplyKey(myChannel.Endpoint.PortID, myChannel.Endpoint.ChannelID, 1)))
+			assert.False(t, store.Has(types.GetPacketReplyKey(myChannel.Endpoint.PortID, myChannel.Endpoint.ChannelID, 2)))
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package keeper
+
+import (
+	"sync"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	wasmvm "github.com/CosmWasm/wasmvm"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	dbm "github.com/tendermint/tm-db"
+)
+
+// StoreAuditLog keeps the contract store operations of the latest executions in memory.
+// The data is node local and not part of the consensus state.
+type StoreAuditLog struct {
+	mu      sync.RWMutex
+	size    int
+	records []storeAuditRecord
+}
+
+type storeAuditRecord struct {
+	contract string
+	entry    types.StoreAuditEntry
+}
+
+// NewStoreAuditLog constructor. The size is the max number of executions that are kept, for all contracts.
+func NewStoreAuditLog(size int) *StoreAuditLog {
+	return &StoreAuditLog{size: size}
+}
+
+func (l *StoreAuditLog) add(contract string, entry types.StoreAuditEntry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.records = append(l.records, storeAuditRecord{contract: contract, entry: entry})
+	if len(l.records) > l.size {
+		l.records = l.records[len(l.records)-l.size:]
+	}
+}
+
+// ContractEntries returns the recorded executions for the given contract, oldest first
+func (l *StoreAuditLog) ContractEntries(contract string) []types.StoreAuditEntry {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	r := make([]types.StoreAuditEntry, 0)
+	for _, v := range l.records {
+		if v.contract == contract {
+			r = append(r, v.entry)
+		}
+	}
+	return r
+}
+
+var _ wasmvm.KVStore = &storeAuditRecorder{}
+
+// storeAuditRecorder records all operations on the nested contract store
+type storeAuditRecorder struct {
+	nested wasmvm.KVStore
+	ops    []types.StoreOperation
+	// skip is set for calls outside of a block execution, like in CheckTx, that are not added to the log
+	skip bool
+}
+
+func newStoreAuditRecorder(store wasmvm.KVStore) *storeAuditRecorder {
+	s, ok := store.(contractStore)
+	return &storeAuditRecorder{nested: store, skip: ok && !s.audit}
+}
+
+func (s *storeAuditRecorder) record(op types.StoreOperationType, key, value []byte) {
+	if s.skip {
+		return
+	}
+	s.ops = append(s.ops, types.StoreOperation{Operation: op, Key: key, Value: value})
+}
+
+func (s *storeAuditRecorder) Get(key []byte) []byte {
+	v := s.nested.Get(key)
+	s.record(types.StoreOperationTypeRead, key, v)
+	return v
+}
+
+func (s *storeAuditRecorder) Set(key, value []byte) {
+	s.nested.Set(key, value)
+	s.record(types.StoreOperationTypeWrite, key, value)
+}
+
+func (s *storeAuditRecorder) Delete(key []byte) {
+	s.nested.Delete(key)
+	s.record(types.StoreOperationTypeDelete, key, nil)
+}
+
+func (s *storeAuditRecorder) Iterator(start, end []byte) dbm.Iterator {
+	return &auditIterator{Iterator: s.nested.Iterator(start, end), recorder: s}
+}
+
+func (s *storeAuditRecorder) ReverseIterator(start, end []byte) dbm.Iterator {
+	return &auditIterator{Iterator: s.nested.ReverseIterator(start, end), recorder: s}
+}
+
+// auditIterator records the values that were read by the contract
+type auditIterator struct {
+	dbm.Iterator
+	recorder *storeAuditRecorder
+}
+
+func (i *auditIterator) Value() []byte {
+	v := i.Iterator.Value()
+	i.recorder.record(types.StoreOperationTypeRead, i.Iterator.Key(), v)
+	return v
+}
+
+// storeAuditEngine decorates the wasmvm engine to record the store operations of all state changing entry points
+type storeAuditEngine struct {
+	types.WasmerEngine
+	log *StoreAuditLog
+}
+
+func newStoreAuditEngine(nested types.WasmerEngine, log *StoreAuditLog) *storeAuditEngine {
+	return &storeAuditEngine{WasmerEngine: nested, log: log}
+}
+
+func (e storeAuditEngine) add(entryPoint string, env wasmvmtypes.Env, s *storeAuditRecorder, err error) {
+	if s.skip {
+		return
+	}
+	entry := types.StoreAuditEntry{
+		EntryPoint:  entryPoint,
+		BlockHeight: int64(env.Block.Height),
+		Operations:  s.ops,
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	e.log.add(env.Contract.Address, entry)
+}
+
+func (e storeAuditEngine) Instantiate(code wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, initMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.Response, uint64, error) {
+	s := newStoreAuditRecorder(store)
+	res, gasUsed, err := e.WasmerEngine.Instantiate(code, env, info, initMsg, s, goapi, querier, gasMeter, gasLimit)
+	e.add("instantiate", env, s, err)
+	return res, gasUsed, err
+}
+
+func (e storeAuditEngine) Execute(code wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.Response, uint64, error) {
+	s := newStoreAuditRecorder(store)
+	res, gasUsed, err := e.WasmerEngine.Execute(code, env, info, executeMsg, s, goapi, querier, gasMeter, gasLimit)
+	e.add("execute", env, s, err)
+	return res, gasUsed, err
+}
+
+func (e storeAuditEngine) Migrate(code wasmvm.Checksum, env wasmvmtypes.Env, migrateMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.Response, uint64, error) {
+	s := newStoreAuditRecorder(store)
+	res, gasUsed, err := e.WasmerEngine.Migrate(code, env, migrateMsg, s, goapi, querier, gasMeter, gasLimit)
+	e.add("migrate", env, s, err)
+	return res, gasUsed, err
+}
+
+func (e storeAuditEngine) Sudo(code wasmvm.Checksum, env wasmvmtypes.Env, sudoMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.Response, uint64, error) {
+	s := newStoreAuditRecorder(store)
+	res, gasUsed, err := e.WasmerEngine.Sudo(code, env, sudoMsg, s, goapi, querier, gasMeter, gasLimit)
+	e.add("sudo", env, s, err)
+	return res, gasUsed, err
+}
+
+func (e storeAuditEngine) Reply(code wasmvm.Checksum, env wasmvmtypes.Env, reply wasmvmtypes.Reply, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.Response, uint64, error) {
+	s := newStoreAuditRecorder(store)
+	res, gasUsed, err := e.WasmerEngine.Reply(code, env, reply, s, goapi, querier, gasMeter, gasLimit)
+	e.add("reply", env, s, err)
+	return res, gasUsed, err
+}
+
+func (e storeAuditEngine) IBCChannelOpen(code wasmvm.Checksum, env wasmvmtypes.Env, channel wasmvmtypes.IBCChannel, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (uint64, error) {
+	s := newStoreAuditRecorder(store)
+	gasUsed, err := e.WasmerEngine.IBCChannelOpen(code, env, channel, s, goapi, querier, gasMeter, gasLimit)
+	e.add("ibc_channel_open", env, s, err)
+	return gasUsed, err
+}
+
+func (e storeAuditEngine) IBCChannelConnect(code wasmvm.Checksum, env wasmvmtypes.Env, channel wasmvmtypes.IBCChannel, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.IBCBasicResponse, uint64, error) {
+	s := newStoreAuditRecorder(store)
+	res, gasUsed, err := e.WasmerEngine.IBCChannelConnect(code, env, channel, s, goapi, querier, gasMeter, gasLimit)
+	e.add("ibc_channel_connect", env, s, err)
+	return res, gasUsed, err
+}
+
+func (e storeAuditEngine) IBCChannelClose(code wasmvm.Checksum, env wasmvmtypes.Env, channel wasmvmtypes.IBCChannel, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.IBCBasicResponse, uint64, error) {
+	s := newStoreAuditRecorder(store)
+	res, gasUsed, err := e.WasmerEngine.IBCChannelClose(code, env, channel, s, goapi, querier, gasMeter, gasLimit)
+	e.add("ibc_channel_close", env, s, err)
+	return res, gasUsed, err
+}
+
+func (e storeAuditEngine) IBCPacketReceive(code wasmvm.Checksum, env wasmvmtypes.Env, packet wasmvmtypes.IBCPacket, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.IBCReceiveResponse, uint64, error) {
+	s := newStoreAuditRecorder(store)
+	res, gasUsed, err := e.WasmerEngine.IBCPacketReceive(code, env, packet, s, goapi, querier, gasMeter, gasLimit)
+	e.add("ibc_packet_receive", env, s, err)
+	return res, gasUsed, err
+}
+
+func (e storeAuditEngine) IBCPacketAck(code wasmvm.Checksum, env wasmvmtypes.Env, ack wasmvmtypes.IBCAcknowledgement, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.IBCBasicResponse, uint64, error) {
+	s := newStoreAuditRecorder(store)
+	res, gasUsed, err := e.WasmerEngine.IBCPacketAck(code, env, ack, s, goapi, querier, gasMeter, gasLimit)
+	e.add("ibc_packet_ack", env, s, err)
+	return res, gasUsed, err
+}
+
+func (e storeAuditEngine) IBCPacketTimeout(code wasmvm.Checksum, env wasmvmtypes.Env, packet wasmvmtypes.IBCPacket, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.IBCBasicResponse, uint64, error) {
+	s := newStoreAuditRecorder(store)
+	res, gasUsed, err := e.WasmerEngine.IBCPacketTimeout(code, env, packet, s, goapi, querier, gasMeter, gasLimit)
+	e.add("ibc_packet_timeout", env, s, err)
+	return res, gasUsed, err
+}
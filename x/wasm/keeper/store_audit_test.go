@@ -0,0 +1,105 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	"github.com/cosmos/cosmos-sdk/store/dbadapter"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStoreAuditLogSize(t *testing.T) {
+	log := NewStoreAuditLog(2)
+	log.add("foo", types.StoreAuditEntry{EntryPoint: "instantiate"})
+	log.add("bar", types.StoreAuditEntry{EntryPoint: "instantiate"})
+	log.add("foo", types.StoreAuditEntry{EntryPoint: "execute"})
+
+	assert.Equal(t, []types.StoreAuditEntry{{EntryPoint: "execute"}}, log.ContractEntries("foo"))
+	assert.Equal(t, []types.StoreAuditEntry{{EntryPoint: "instantiate"}}, log.ContractEntries("bar"))
+	assert.Empty(t, log.ContractEntries("other"))
+}
+
+func TestStoreAuditRecorder(t *testing.T) {
+	nested := dbadapter.Store{DB: dbm.NewMemDB()}
+	nested.Set([]byte("a"), []byte("1"))
+	nested.Set([]byte("b"), []byte("2"))
+	s := &storeAuditRecorder{nested: nested}
+
+	s.Set([]byte("c"), []byte("3"))
+	assert.Equal(t, []byte("1"), s.Get([]byte("a")))
+	s.Delete([]byte("b"))
+	iter := s.Iterator(nil, nil)
+	for ; iter.Valid(); iter.Next() {
+		iter.Value()
+	}
+	iter.Close()
+
+	exp := []types.StoreOperation{
+		{Operation: types.StoreOperationTypeWrite, Key: []byte("c"), Value: []byte("3")},
+		{Operation: types.StoreOperationTypeRead, Key: []byte("a"), Value: []byte("1")},
+		{Operation: types.StoreOperationTypeDelete, Key: []byte("b")},
+		{Operation: types.StoreOperationTypeRead, Key: []byte("a"), Value: []byte("1")},
+		{Operation: types.StoreOperationTypeRead, Key: []byte("c"), Value: []byte("3")},
+	}
+	assert.Equal(t, exp, s.ops)
+}
+
+func TestQueryContractStoreAuditLog(t *testing.T) {
+	wasmConfig := types.DefaultWasmConfig()
+	wasmConfig.StoreAuditLogSize = 10
+	ctx, keepers := createTestInput(t, false, SupportedFeatures, wasmConfig, dbm.NewMemDB())
+	example := InstantiateHackatomExampleContract(t, ctx, keepers)
+
+	// a failing execution is recorded with the error
+	_, err := keepers.ContractKeeper.Execute(ctx, example.Contract, example.CreatorAddr, []byte(`{"release":{}}`), nil)
+	require.Error(t, err)
+	_, err = keepers.ContractKeeper.Execute(ctx, example.Contract, example.VerifierAddr, []byte(`{"release":{}}`), nil)
+	require.NoError(t, err)
+
+	q := Querier(keepers.WasmKeeper)
+	rsp, err := q.ContractStoreAuditLog(sdk.WrapSDKContext(ctx), &types.QueryContractStoreAuditLogRequest{Address: example.Contract.String()})
+	require.NoError(t, err)
+	require.Len(t, rsp.Entries, 3)
+
+	assert.Equal(t, "instantiate", rsp.Entries[0].EntryPoint)
+	assert.Equal(t, ctx.BlockHeight(), rsp.Entries[0].BlockHeight)
+	assert.Empty(t, rsp.Entries[0].Error)
+	require.Len(t, rsp.Entries[0].Operations, 1)
+	assert.Equal(t, types.StoreOperationTypeWrite, rsp.Entries[0].Operations[0].Operation)
+	assert.Equal(t, []byte("config"), rsp.Entries[0].Operations[0].Key)
+
+	assert.Equal(t, "execute", rsp.Entries[1].EntryPoint)
+	assert.NotEmpty(t, rsp.Entries[1].Error)
+
+	assert.Equal(t, "execute", rsp.Entries[2].EntryPoint)
+	assert.Empty(t, rsp.Entries[2].Error)
+	require.NotEmpty(t, rsp.Entries[2].Operations)
+	assert.Equal(t, types.StoreOperationTypeRead, rsp.Entries[2].Operations[0].Operation)
+	assert.Equal(t, []byte("config"), rsp.Entries[2].Operations[0].Key)
+
+	// other contracts are not included
+	rsp, err = q.ContractStoreAuditLog(sdk.WrapSDKContext(ctx), &types.QueryContractStoreAuditLogRequest{Address: RandomBech32AccountAddress(t)})
+	require.NoError(t, err)
+	assert.Empty(t, rsp.Entries)
+
+	// executions in CheckTx or simulations are not recorded
+	_, err = keepers.ContractKeeper.Execute(ctx.WithIsCheckTx(true), example.Contract, example.VerifierAddr, []byte(`{"release":{}}`), nil)
+	require.NoError(t, err)
+	rsp, err = q.ContractStoreAuditLog(sdk.WrapSDKContext(ctx), &types.QueryContractStoreAuditLogRequest{Address: example.Contract.String()})
+	require.NoError(t, err)
+	assert.Len(t, rsp.Entries, 3)
+}
+
+func TestQueryContractStoreAuditLogDisabled(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	example := InstantiateHackatomExampleContract(t, ctx, keepers)
+
+	q := Querier(keepers.WasmKeeper)
+	_, err := q.ContractStoreAuditLog(sdk.WrapSDKContext(ctx), &types.QueryContractStoreAuditLogRequest{Address: example.Contract.String()})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
@@ -0,0 +1,79 @@
+package keeper
+
+import (
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	"github.com/cosmos/cosmos-sdk/store/gaskv"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// newContractStore returns the prefix store for the state of a contract with a read cache for the duration of a
+// single contract execution. The cache sits below the gas metering layer so that every read is charged as before.
+func newContractStore(ctx sdk.Context, storeKey sdk.StoreKey, contractAddress sdk.AccAddress) contractStore {
+	cached := newReadCacheStore(ctx.MultiStore().GetKVStore(storeKey))
+	gasStore := gaskv.NewStore(cached, ctx.GasMeter(), kvGasConfig(ctx))
+	return contractStore{
+		Store: prefix.NewStore(gasStore, types.GetContractStorePrefix(contractAddress)),
+		// CheckTx, tx simulations and queries all run on the check state
+		audit: !ctx.IsCheckTx(),
+	}
+}
+
+// kvGasConfig returns the gas config that sdk.Context.KVStore charges with. The context of the SDK version in use
+// does not carry a custom KV gas config, so this is the default config. It must be read from the context once the
+// SDK supports a custom config to keep the gas costs of the contract store the same as for ctx.KVStore.
+func kvGasConfig(_ sdk.Context) storetypes.GasConfig {
+	return storetypes.KVGasConfig()
+}
+
+// bufferedContractStore returns the contract store for a single call into the wasm VM. All writes of the call are
+// buffered in a cache context and written to the parent store in one batch when commit is called after the VM
+// returns. Iterators read through the buffer. The returned context should be used for the querier so that queries
+// during the call, for example to the contract itself, see the buffered writes.
+func (k Keeper) bufferedContractStore(ctx sdk.Context, contractAddress sdk.AccAddress) (sdk.Context, contractStore, func()) {
+	vmCtx, commit := ctx.CacheContext()
+	return vmCtx, newContractStore(vmCtx, k.storeKey, contractAddress), commit
+}
+
+var _ sdk.KVStore = &readCacheStore{}
+
+// readCacheStore keeps the values that were read or written in memory so that repeated reads of the same key do not
+// hit the parent store. Writes and deletes are passed through to the parent immediately so that iterators on the
+// parent are always consistent.
+// It must not be shared between executions as changes to the parent store from other sources are not seen.
+type readCacheStore struct {
+	sdk.KVStore
+	// cache contains the latest value for a key. A nil value is stored for a key that does not exist.
+	cache map[string][]byte
+}
+
+func newReadCacheStore(parent sdk.KVStore) *readCacheStore {
+	return &readCacheStore{KVStore: parent, cache: make(map[string][]byte)}
+}
+
+func (s *readCacheStore) Get(key []byte) []byte {
+	if v, ok := s.cache[string(key)]; ok {
+		return v
+	}
+	v := s.KVStore.Get(key)
+	s.cache[string(key)] = v
+	return v
+}
+
+func (s *readCacheStore) Has(key []byte) bool {
+	if v, ok := s.cache[string(key)]; ok {
+		return v != nil
+	}
+	return s.KVStore.Has(key)
+}
+
+func (s *readCacheStore) Set(key, value []byte) {
+	s.KVStore.Set(key, value)
+	s.cache[string(key)] = value
+}
+
+func (s *readCacheStore) Delete(key []byte) {
+	s.KVStore.Delete(key)
+	s.cache[string(key)] = nil
+}
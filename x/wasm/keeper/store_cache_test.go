@@ -0,0 +1,137 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	"github.com/cosmos/cosmos-sdk/store/dbadapter"
+	"github.com/cosmos/cosmos-sdk/store/gaskv"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+)
+
+// readCountingStore counts the reads on the nested store
+type readCountingStore struct {
+	sdk.KVStore
+	reads int
+}
+
+func (s *readCountingStore) Get(key []byte) []byte {
+	s.reads++
+	return s.KVStore.Get(key)
+}
+
+func TestReadCacheStore(t *testing.T) {
+	parent := &readCountingStore{KVStore: dbadapter.Store{DB: dbm.NewMemDB()}}
+	parent.Set([]byte("a"), []byte("1"))
+	s := newReadCacheStore(parent)
+
+	// repeated reads hit the cache
+	assert.Equal(t, []byte("1"), s.Get([]byte("a")))
+	assert.Equal(t, []byte("1"), s.Get([]byte("a")))
+	assert.Equal(t, 1, parent.reads)
+
+	// non existing keys are cached, too
+	assert.Nil(t, s.Get([]byte("b")))
+	assert.False(t, s.Has([]byte("b")))
+	assert.Equal(t, 2, parent.reads)
+
+	// writes are passed through and visible
+	s.Set([]byte("b"), []byte("2"))
+	assert.Equal(t, []byte("2"), s.Get([]byte("b")))
+	assert.True(t, s.Has([]byte("b")))
+	assert.Equal(t, []byte("2"), parent.KVStore.Get([]byte("b")))
+
+	// deletes are passed through and visible
+	s.Delete([]byte("a"))
+	assert.Nil(t, s.Get([]byte("a")))
+	assert.False(t, s.Has([]byte("a")))
+	assert.False(t, parent.KVStore.Has([]byte("a")))
+	assert.Equal(t, 2, parent.reads)
+
+	// iterators see the parent state
+	iter := s.Iterator(nil, nil)
+	defer iter.Close()
+	assert.True(t, iter.Valid())
+	assert.Equal(t, []byte("b"), iter.Key())
+}
+
+func TestReadCacheStoreGasUnchanged(t *testing.T) {
+	ops := func(s sdk.KVStore) {
+		s.Set([]byte("a"), []byte("1"))
+		s.Get([]byte("a"))
+		s.Get([]byte("a"))
+		s.Get([]byte("b"))
+		s.Has([]byte("b"))
+		s.Delete([]byte("a"))
+		s.Get([]byte("a"))
+	}
+	uncachedMeter := sdk.NewInfiniteGasMeter()
+	ops(gaskv.NewStore(dbadapter.Store{DB: dbm.NewMemDB()}, uncachedMeter, storetypes.KVGasConfig()))
+
+	cachedMeter := sdk.NewInfiniteGasMeter()
+	ops(gaskv.NewStore(newReadCacheStore(dbadapter.Store{DB: dbm.NewMemDB()}), cachedMeter, storetypes.KVGasConfig()))
+
+	assert.Equal(t, uncachedMeter.GasConsumed(), cachedMeter.GasConsumed())
+}
+
+func TestBufferedContractStore(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	k := keepers.WasmKeeper
+	contractAddr := RandomAccountAddress(t)
+	require.NoError(t, k.importContractState(ctx, contractAddr, []types.Model{{Key: []byte("a"), Value: []byte("1")}}))
+
+	vmCtx, store, commit := k.bufferedContractStore(ctx, contractAddr)
+	store.Set([]byte("b"), []byte("2"))
+	store.Delete([]byte("a"))
+
+	// writes are buffered
+	assert.Equal(t, []byte("1"), k.QueryRaw(ctx, contractAddr, []byte("a")))
+	assert.Nil(t, k.QueryRaw(ctx, contractAddr, []byte("b")))
+	// but visible to queries within the call
+	assert.Nil(t, k.QueryRaw(vmCtx, contractAddr, []byte("a")))
+	assert.Equal(t, []byte("2"), k.QueryRaw(vmCtx, contractAddr, []byte("b")))
+	// and iterators
+	iter := store.Iterator(nil, nil)
+	require.True(t, iter.Valid())
+	assert.Equal(t, []byte("b"), iter.Key())
+	iter.Next()
+	assert.False(t, iter.Valid())
+	iter.Close()
+
+	// when
+	commit()
+	// then
+	assert.Nil(t, k.QueryRaw(ctx, contractAddr, []byte("a")))
+	assert.Equal(t, []byte("2"), k.QueryRaw(ctx, contractAddr, []byte("b")))
+}
+
+func TestBufferedContractStoreGasUnchanged(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	k := keepers.WasmKeeper
+	contractAddr := RandomAccountAddress(t)
+	ops := func(s sdk.KVStore) {
+		s.Set([]byte("a"), []byte("1"))
+		s.Set([]byte("a"), []byte("11"))
+		s.Get([]byte("a"))
+		s.Get([]byte("b"))
+		s.Delete([]byte("a"))
+		iter := s.Iterator(nil, nil)
+		for ; iter.Valid(); iter.Next() {
+		}
+		iter.Close()
+	}
+	directCtx, _ := ctx.WithGasMeter(sdk.NewInfiniteGasMeter()).CacheContext()
+	ops(prefix.NewStore(directCtx.KVStore(k.storeKey), types.GetContractStorePrefix(contractAddr)))
+
+	bufferedCtx := ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+	_, store, commit := k.bufferedContractStore(bufferedCtx, contractAddr)
+	ops(store)
+	commit()
+
+	assert.Equal(t, directCtx.GasMeter().GasConsumed(), bufferedCtx.GasMeter().GasConsumed())
+}
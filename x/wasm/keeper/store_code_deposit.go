@@ -0,0 +1,150 @@
+package keeper
+
+import (
+	"strconv"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// GetStoreCodeDeposit returns the deposit that is escrowed from the sender of a MsgStoreCode. Empty when disabled.
+func (k Keeper) GetStoreCodeDeposit(ctx sdk.Context) sdk.Coins {
+	var a sdk.Coins
+	k.paramSpace.GetIfExists(ctx, types.ParamStoreKeyStoreCodeDeposit, &a)
+	return a
+}
+
+// GetStoreCodeDepositRefundBlocks returns the number of blocks after which the store code deposit is refunded.
+// Zero means the deposit is burned.
+func (k Keeper) GetStoreCodeDepositRefundBlocks(ctx sdk.Context) uint64 {
+	var a uint64
+	k.paramSpace.GetIfExists(ctx, types.ParamStoreKeyStoreCodeDepositRefundBlocks, &a)
+	return a
+}
+
+// collectStoreCodeDeposit moves the store code deposit from the depositor into escrow in the deposit module account.
+// Without a refund period the deposit is burned right away. It is called before the code is created so that no wasm
+// code is compiled and persisted for a sender who can not pay the deposit. The deposit is stored for the code id
+// that the next create assigns.
+func (k Keeper) collectStoreCodeDeposit(ctx sdk.Context, depositor sdk.AccAddress) error {
+	amount := k.GetStoreCodeDeposit(ctx)
+	if amount.IsZero() {
+		return nil
+	}
+	if err := k.bankKeeper.SendCoinsFromAccountToModule(ctx, depositor, types.DepositEscrowName, amount); err != nil {
+		return sdkerrors.Wrap(err, "store code deposit")
+	}
+	codeID := k.peekAutoIncrementID(ctx, types.KeyLastCodeID)
+	event := sdk.NewEvent(
+		types.EventTypeStoreCodeDeposit,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(types.AttributeKeyCodeID, strconv.FormatUint(codeID, 10)),
+		sdk.NewAttribute(types.AttributeKeyDepositor, depositor.String()),
+		sdk.NewAttribute(sdk.AttributeKeyAmount, amount.String()),
+	)
+	refundBlocks := k.GetStoreCodeDepositRefundBlocks(ctx)
+	if refundBlocks == 0 {
+		if err := k.bankKeeper.BurnCoins(ctx, types.DepositEscrowName, amount); err != nil {
+			return sdkerrors.Wrap(err, "burn store code deposit")
+		}
+		ctx.EventManager().EmitEvent(event.AppendAttributes(sdk.NewAttribute(types.AttributeKeyBurned, "true")))
+		return nil
+	}
+	d := types.StoreCodeDeposit{
+		CodeID:       codeID,
+		Depositor:    depositor.String(),
+		Amount:       amount,
+		RefundHeight: ctx.BlockHeight() + int64(refundBlocks),
+	}
+	k.setStoreCodeDeposit(ctx, d)
+	ctx.EventManager().EmitEvent(event.AppendAttributes(sdk.NewAttribute(types.AttributeKeyRefundHeight, strconv.FormatInt(d.RefundHeight, 10))))
+	return nil
+}
+
+func (k Keeper) setStoreCodeDeposit(ctx sdk.Context, d types.StoreCodeDeposit) {
+	ctx.KVStore(k.storeKey).Set(types.GetStoreCodeDepositKey(d.RefundHeight, d.CodeID), k.cdc.MustMarshalBinaryBare(&d))
+}
+
+// importStoreCodeDeposit stores a deposit from genesis. The escrowed amount must be in the deposit module account already.
+func (k Keeper) importStoreCodeDeposit(ctx sdk.Context, d types.StoreCodeDeposit) error {
+	if ctx.KVStore(k.storeKey).Has(types.GetStoreCodeDepositKey(d.RefundHeight, d.CodeID)) {
+		return sdkerrors.Wrapf(types.ErrDuplicate, "code id %d at refund height %d", d.CodeID, d.RefundHeight)
+	}
+	k.setStoreCodeDeposit(ctx, d)
+	return nil
+}
+
+// IterateStoreCodeDeposits iterates the store code deposits in escrow ordered by refund height.
+// When the callback returns true the iteration is stopped.
+func (k Keeper) IterateStoreCodeDeposits(ctx sdk.Context, cb func(types.StoreCodeDeposit) bool) {
+	iter := prefix.NewStore(ctx.KVStore(k.storeKey), types.StoreCodeDepositPrefix).Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var d types.StoreCodeDeposit
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &d)
+		if cb(d) {
+			return
+		}
+	}
+}
+
+// RefundStoreCodeDeposits settles the store code deposits that are due at the current block height. A deposit is
+// returned to the depositor when at least one contract runs the code, otherwise it is burned as the code was not used.
+// It is called in the end blocker. Failures are logged and the amount remains in the deposit module account.
+func (k Keeper) RefundStoreCodeDeposits(ctx sdk.Context) {
+	var due []types.StoreCodeDeposit
+	k.IterateStoreCodeDeposits(ctx, func(d types.StoreCodeDeposit) bool {
+		if d.RefundHeight > ctx.BlockHeight() {
+			return true
+		}
+		due = append(due, d)
+		return false
+	})
+	for _, d := range due {
+		ctx.KVStore(k.storeKey).Delete(types.GetStoreCodeDepositKey(d.RefundHeight, d.CodeID))
+		if _, used := k.nextContractOfCode(ctx, d.CodeID, nil); !used {
+			if err := k.burnStoreCodeDeposit(ctx, d); err != nil {
+				k.Logger(ctx).Error("store code deposit burn", "code_id", d.CodeID, "depositor", d.Depositor, "error", err.Error())
+			}
+			continue
+		}
+		if err := k.refundStoreCodeDeposit(ctx, d); err != nil {
+			k.Logger(ctx).Error("store code deposit refund", "code_id", d.CodeID, "depositor", d.Depositor, "error", err.Error())
+		}
+	}
+}
+
+func (k Keeper) refundStoreCodeDeposit(ctx sdk.Context, d types.StoreCodeDeposit) error {
+	depositor, err := sdk.AccAddressFromBech32(d.Depositor)
+	if err != nil {
+		return sdkerrors.Wrap(err, "depositor")
+	}
+	if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.DepositEscrowName, depositor, d.Amount); err != nil {
+		return err
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeRefundStoreCodeDeposit,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(types.AttributeKeyCodeID, strconv.FormatUint(d.CodeID, 10)),
+		sdk.NewAttribute(types.AttributeKeyDepositor, d.Depositor),
+		sdk.NewAttribute(sdk.AttributeKeyAmount, d.Amount.String()),
+	))
+	return nil
+}
+
+func (k Keeper) burnStoreCodeDeposit(ctx sdk.Context, d types.StoreCodeDeposit) error {
+	if err := k.bankKeeper.BurnCoins(ctx, types.DepositEscrowName, d.Amount); err != nil {
+		return err
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeRefundStoreCodeDeposit,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(types.AttributeKeyCodeID, strconv.FormatUint(d.CodeID, 10)),
+		sdk.NewAttribute(types.AttributeKeyDepositor, d.Depositor),
+		sdk.NewAttribute(sdk.AttributeKeyAmount, d.Amount.String()),
+		sdk.NewAttribute(types.AttributeKeyBurned, "true"),
+	))
+	return nil
+}
@@ -0,0 +1,154 @@
+package keeper
+
+import (
+	"io/ioutil"
+	"strconv"
+	"testing"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreCodeWithDeposit(t *testing.T) {
+	wasmCode, err := ioutil.ReadFile("./testdata/hackatom.wasm")
+	require.NoError(t, err)
+	myDeposit := sdk.NewCoins(sdk.NewInt64Coin("denom", 100))
+	specs := map[string]struct {
+		srcDeposit      sdk.Coins
+		srcRefundBlocks uint64
+		srcFunds        sdk.Coins
+		expEscrow       bool
+		expErr          *sdkerrors.Error
+	}{
+		"deposit burned": {
+			srcDeposit: myDeposit,
+			srcFunds:   myDeposit,
+		},
+		"deposit escrowed": {
+			srcDeposit:      myDeposit,
+			srcRefundBlocks: 10,
+			srcFunds:        myDeposit,
+			expEscrow:       true,
+		},
+		"no deposit": {
+			srcFunds: myDeposit,
+		},
+		"insufficient funds": {
+			srcDeposit: myDeposit,
+			srcFunds:   sdk.NewCoins(sdk.NewInt64Coin("denom", 99)),
+			expErr:     sdkerrors.ErrInsufficientFunds,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+			k := keepers.WasmKeeper
+			params := types.DefaultParams()
+			params.StoreCodeDeposit = spec.srcDeposit
+			params.StoreCodeDepositRefundBlocks = spec.srcRefundBlocks
+			k.setParams(ctx, params)
+			sender := createFakeFundedAccount(t, ctx, keepers.AccountKeeper, keepers.BankKeeper, spec.srcFunds)
+			supplyBefore := keepers.BankKeeper.GetSupply(ctx).GetTotal()
+			em := sdk.NewEventManager()
+
+			// when
+			rsp, err := NewMsgServerImpl(keepers.ContractKeeper).StoreCode(sdk.WrapSDKContext(ctx.WithEventManager(em)), &types.MsgStoreCode{
+				Sender:       sender.String(),
+				WASMByteCode: wasmCode,
+			})
+
+			// then
+			if spec.expErr != nil {
+				assert.True(t, spec.expErr.Is(err), "got %+v", err)
+				assert.Equal(t, uint64(1), k.peekAutoIncrementID(ctx, types.KeyLastCodeID), "no code created")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, spec.srcFunds.Sub(spec.srcDeposit), keepers.BankKeeper.GetAllBalances(ctx, sender))
+			escrowAddr := authtypes.NewModuleAddress(types.DepositEscrowName)
+			var deposits []types.StoreCodeDeposit
+			k.IterateStoreCodeDeposits(ctx, func(d types.StoreCodeDeposit) bool {
+				deposits = append(deposits, d)
+				return false
+			})
+			if !spec.expEscrow {
+				assert.Empty(t, deposits)
+				assert.Empty(t, keepers.BankKeeper.GetAllBalances(ctx, escrowAddr))
+				assert.Equal(t, supplyBefore.Sub(spec.srcDeposit), keepers.BankKeeper.GetSupply(ctx).GetTotal())
+				return
+			}
+			assert.Equal(t, spec.srcDeposit, keepers.BankKeeper.GetAllBalances(ctx, escrowAddr))
+			exp := []types.StoreCodeDeposit{{
+				CodeID:       rsp.CodeID,
+				Depositor:    sender.String(),
+				Amount:       spec.srcDeposit,
+				RefundHeight: ctx.BlockHeight() + 10,
+			}}
+			assert.Equal(t, exp, deposits)
+			expEvt := sdk.NewEvent(types.EventTypeStoreCodeDeposit,
+				sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+				sdk.NewAttribute(types.AttributeKeyCodeID, "1"),
+				sdk.NewAttribute(types.AttributeKeyDepositor, sender.String()),
+				sdk.NewAttribute(sdk.AttributeKeyAmount, spec.srcDeposit.String()),
+				sdk.NewAttribute(types.AttributeKeyRefundHeight, strconv.FormatInt(ctx.BlockHeight()+10, 10)),
+			)
+			assert.Contains(t, em.Events(), expEvt)
+		})
+	}
+}
+
+func TestRefundStoreCodeDeposits(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	k := keepers.WasmKeeper
+	myAmount := sdk.NewCoins(sdk.NewInt64Coin("denom", 1))
+	require.NoError(t, keepers.BankKeeper.MintCoins(ctx, types.ModuleName, myAmount.Add(myAmount...).Add(myAmount...)))
+	require.NoError(t, keepers.BankKeeper.SendCoinsFromModuleToModule(ctx, types.ModuleName, types.DepositEscrowName, myAmount.Add(myAmount...).Add(myAmount...)))
+	anyAddr, otherAddr, unusedAddr := RandomAccountAddress(t), RandomAccountAddress(t), RandomAccountAddress(t)
+	k.setStoreCodeDeposit(ctx, types.StoreCodeDeposit{CodeID: 1, Depositor: anyAddr.String(), Amount: myAmount, RefundHeight: 10})
+	k.setStoreCodeDeposit(ctx, types.StoreCodeDeposit{CodeID: 2, Depositor: otherAddr.String(), Amount: myAmount, RefundHeight: 11})
+	k.setStoreCodeDeposit(ctx, types.StoreCodeDeposit{CodeID: 3, Depositor: unusedAddr.String(), Amount: myAmount, RefundHeight: 11})
+	for _, codeID := range []uint64{1, 2} {
+		k.addToContractCodeSecondaryIndex(ctx, RandomAccountAddress(t), types.ContractCodeHistoryEntry{CodeID: codeID, Updated: types.NewAbsoluteTxPosition(ctx)})
+	}
+	supplyBefore := keepers.BankKeeper.GetSupply(ctx).GetTotal()
+
+	// when
+	k.RefundStoreCodeDeposits(ctx.WithBlockHeight(9))
+	// then
+	assert.Empty(t, keepers.BankKeeper.GetAllBalances(ctx, anyAddr))
+
+	// and when
+	k.RefundStoreCodeDeposits(ctx.WithBlockHeight(10))
+	// then
+	assert.Equal(t, myAmount, keepers.BankKeeper.GetAllBalances(ctx, anyAddr))
+	assert.Empty(t, keepers.BankKeeper.GetAllBalances(ctx, otherAddr))
+
+	// and when
+	em := sdk.NewEventManager()
+	k.RefundStoreCodeDeposits(ctx.WithBlockHeight(12).WithEventManager(em))
+	// then
+	assert.Equal(t, myAmount, keepers.BankKeeper.GetAllBalances(ctx, anyAddr))
+	assert.Equal(t, myAmount, keepers.BankKeeper.GetAllBalances(ctx, otherAddr))
+	// and deposit of code without contracts burned
+	assert.Empty(t, keepers.BankKeeper.GetAllBalances(ctx, unusedAddr))
+	assert.Empty(t, keepers.BankKeeper.GetAllBalances(ctx, authtypes.NewModuleAddress(types.DepositEscrowName)))
+	assert.Equal(t, supplyBefore.Sub(myAmount), keepers.BankKeeper.GetSupply(ctx).GetTotal())
+	expEvt := sdk.NewEvent(types.EventTypeRefundStoreCodeDeposit,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(types.AttributeKeyCodeID, "3"),
+		sdk.NewAttribute(types.AttributeKeyDepositor, unusedAddr.String()),
+		sdk.NewAttribute(sdk.AttributeKeyAmount, myAmount.String()),
+		sdk.NewAttribute(types.AttributeKeyBurned, "true"),
+	)
+	assert.Contains(t, em.Events(), expEvt)
+	var remaining int
+	k.IterateStoreCodeDeposits(ctx, func(types.StoreCodeDeposit) bool {
+		remaining++
+		return false
+	})
+	assert.Zero(t, remaining)
+}
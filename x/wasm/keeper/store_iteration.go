@@ -0,0 +1,115 @@
+package keeper
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	dbm "github.com/tendermint/tm-db"
+)
+
+// contractStore is the state of a contract as it is given to the wasm VM. It guarantees the iteration semantics that
+// contracts rely on, independent of the store implementations below:
+//   - Iterator returns the keys in byte-wise ascending order, ReverseIterator in byte-wise descending order
+//   - start is inclusive, end is exclusive and nil means unbounded
+//   - an empty start is the same as nil, an empty end matches no key
+//   - a range with start not before end is empty
+//
+// A parent store that returns the keys out of order would let nodes diverge, so the iterator panics instead.
+type contractStore struct {
+	prefix.Store
+	// audit is set when the operations on the store belong to a block execution and can be recorded in the store
+	// audit log
+	audit bool
+}
+
+func (s contractStore) Iterator(start, end []byte) dbm.Iterator {
+	if isEmptyRange(start, end) {
+		return emptyIterator{start: start, end: end}
+	}
+	return newOrderedIterator(s.Store.Iterator(nilIfEmpty(start), end), true)
+}
+
+func (s contractStore) ReverseIterator(start, end []byte) dbm.Iterator {
+	if isEmptyRange(start, end) {
+		return emptyIterator{start: start, end: end}
+	}
+	return newOrderedIterator(s.Store.ReverseIterator(nilIfEmpty(start), end), false)
+}
+
+func isEmptyRange(start, end []byte) bool {
+	return end != nil && (len(end) == 0 || start != nil && bytes.Compare(start, end) >= 0)
+}
+
+func nilIfEmpty(b []byte) []byte {
+	if len(b) == 0 {
+		return nil
+	}
+	return b
+}
+
+var _ dbm.Iterator = &orderedIterator{}
+
+// orderedIterator panics when the nested iterator returns a key that is not strictly after the previous one in the
+// iteration order.
+type orderedIterator struct {
+	dbm.Iterator
+	ascending bool
+	lastKey   []byte
+}
+
+func newOrderedIterator(nested dbm.Iterator, ascending bool) *orderedIterator {
+	i := &orderedIterator{Iterator: nested, ascending: ascending}
+	if nested.Valid() {
+		i.lastKey = append([]byte{}, nested.Key()...)
+	}
+	return i
+}
+
+func (i *orderedIterator) Next() {
+	i.Iterator.Next()
+	if !i.Iterator.Valid() {
+		return
+	}
+	key := i.Iterator.Key()
+	cmp := bytes.Compare(i.lastKey, key)
+	if i.ascending && cmp >= 0 || !i.ascending && cmp <= 0 {
+		panic(fmt.Sprintf("contract store iterator out of order: %X after %X", key, i.lastKey))
+	}
+	i.lastKey = append(i.lastKey[:0], key...)
+}
+
+var _ dbm.Iterator = emptyIterator{}
+
+// emptyIterator is an iterator without any elements
+type emptyIterator struct {
+	start, end []byte
+}
+
+func (i emptyIterator) Domain() ([]byte, []byte) {
+	return i.start, i.end
+}
+
+func (emptyIterator) Valid() bool {
+	return false
+}
+
+func (emptyIterator) Next() {
+	panic("iterator is invalid")
+}
+
+func (emptyIterator) Key() []byte {
+	panic("iterator is invalid")
+}
+
+func (emptyIterator) Value() []byte {
+	panic("iterator is invalid")
+}
+
+func (emptyIterator) Error() error {
+	return nil
+}
+
+func (emptyIterator) Close() error {
+	return nil
+}
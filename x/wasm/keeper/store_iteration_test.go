@@ -0,0 +1,262 @@
+package keeper
+
+import (
+	"bytes"
+	"sort"
+	"testing"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	wasmvm "github.com/CosmWasm/wasmvm"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	"github.com/cosmos/cosmos-sdk/store"
+	"github.com/cosmos/cosmos-sdk/store/dbadapter"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+)
+
+// iterationFixtureKeys contains keys that are prefixes of each other and at the byte boundaries
+var iterationFixtureKeys = [][]byte{
+	{0x00}, {0x00, 0x00}, {0x00, 0x01}, []byte("a"), []byte("a\x00"), []byte("ab"), []byte("b"), {0x7f}, {0x80}, {0xff}, {0xff, 0xff},
+}
+
+// TestContractStoreIterationCompliance verifies that contracts see the same keys in byte-wise order for every range,
+// no matter if the state is committed, in the tx cache or buffered by the current contract call.
+func TestContractStoreIterationCompliance(t *testing.T) {
+	ranges := map[string]struct {
+		start, end []byte
+	}{
+		"unbounded":                {},
+		"start only":               {start: []byte("a")},
+		"end only":                 {end: []byte("b")},
+		"start and end":            {start: []byte{0x00, 0x01}, end: []byte("ab")},
+		"start is key prefix":      {start: []byte("a"), end: []byte("a\x01")},
+		"end at max byte":          {start: []byte{0x80}, end: []byte{0xff}},
+		"start at max byte":        {start: []byte{0xff}},
+		"start of min byte":        {start: []byte{0x00}, end: []byte{0x00, 0x00}},
+		"empty start":              {start: []byte{}},
+		"empty start and end":      {start: []byte{}, end: []byte("a")},
+		"empty end":                {end: []byte{}},
+		"start equals end":         {start: []byte("a"), end: []byte("a")},
+		"start after end":          {start: []byte("b"), end: []byte("a")},
+		"no keys in range":         {start: []byte("c"), end: []byte("d")},
+		"beyond all keys":          {start: []byte{0xff, 0xff, 0x00}},
+		"end before all keys":      {end: []byte{0x00}},
+		"end is successor of last": {start: []byte{0xff, 0xff}, end: []byte{0xff, 0xff, 0x00}},
+	}
+	backends := map[string]func(t *testing.T, ctx sdk.Context, k *Keeper, contractAddr sdk.AccAddress) contractStore{
+		"committed": func(t *testing.T, ctx sdk.Context, k *Keeper, contractAddr sdk.AccAddress) contractStore {
+			s := newContractStore(ctx, k.storeKey, contractAddr)
+			for _, key := range iterationFixtureKeys {
+				s.Set(key, key)
+			}
+			ms, ok := ctx.MultiStore().(sdk.CommitMultiStore)
+			require.True(t, ok)
+			ms.Commit()
+			return newContractStore(ctx, k.storeKey, contractAddr)
+		},
+		"tx cache": func(t *testing.T, ctx sdk.Context, k *Keeper, contractAddr sdk.AccAddress) contractStore {
+			cacheCtx, _ := ctx.CacheContext()
+			s := newContractStore(cacheCtx, k.storeKey, contractAddr)
+			for _, key := range iterationFixtureKeys {
+				s.Set(key, key)
+			}
+			return s
+		},
+		"buffered on committed with overwrites and deletes": func(t *testing.T, ctx sdk.Context, k *Keeper, contractAddr sdk.AccAddress) contractStore {
+			s := newContractStore(ctx, k.storeKey, contractAddr)
+			for i, key := range iterationFixtureKeys {
+				if i%2 == 0 {
+					s.Set(key, []byte("old"))
+				}
+			}
+			s.Set([]byte("deleted"), []byte("old"))
+			ms, ok := ctx.MultiStore().(sdk.CommitMultiStore)
+			require.True(t, ok)
+			ms.Commit()
+			_, buffered, _ := k.bufferedContractStore(ctx, contractAddr)
+			for _, key := range iterationFixtureKeys {
+				buffered.Set(key, key)
+			}
+			buffered.Delete([]byte("deleted"))
+			return buffered
+		},
+	}
+	for backendName, setup := range backends {
+		t.Run(backendName, func(t *testing.T) {
+			ctx, k := newIterationTestInput(t)
+			contractAddr := sdk.AccAddress(bytes.Repeat([]byte{0x01}, sdk.AddrLen))
+			// state of the contracts with the neighbour addresses must not be visible
+			for _, b := range []byte{0x00, 0x02} {
+				neighbour := newContractStore(ctx, k.storeKey, sdk.AccAddress(bytes.Repeat([]byte{b}, sdk.AddrLen)))
+				neighbour.Set([]byte{0x00}, []byte("other"))
+				neighbour.Set([]byte("a"), []byte("other"))
+				neighbour.Set([]byte{0xff}, []byte("other"))
+			}
+			s := setup(t, ctx, k, contractAddr)
+			for name, r := range ranges {
+				t.Run(name, func(t *testing.T) {
+					expAsc := expRangeKeys(iterationFixtureKeys, r.start, r.end)
+					gotAsc := collectIteratorKeys(t, s.Iterator(r.start, r.end), expAsc)
+					assert.Equal(t, expAsc, gotAsc)
+
+					expDesc := reverseKeys(expAsc)
+					gotDesc := collectIteratorKeys(t, s.ReverseIterator(r.start, r.end), expDesc)
+					assert.Equal(t, expDesc, gotDesc)
+				})
+			}
+		})
+	}
+}
+
+func TestContractRangeQueryDuringExecution(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	mock := wasmtesting.MockWasmer{}
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.Response, uint64, error) {
+		// insert in an order that is different from the byte order
+		for i := len(iterationFixtureKeys) - 1; i >= 0; i -= 2 {
+			store.Set(iterationFixtureKeys[i], []byte{1})
+		}
+		for i := len(iterationFixtureKeys) - 2; i >= 0; i -= 2 {
+			store.Set(iterationFixtureKeys[i], []byte{1})
+		}
+		exp := expRangeKeys(iterationFixtureKeys, nil, nil)
+		assert.Equal(t, exp, collectIteratorKeys(t, store.Iterator(nil, nil), exp))
+		assert.Equal(t, reverseKeys(exp), collectIteratorKeys(t, store.ReverseIterator(nil, nil), exp))
+		return &wasmvmtypes.Response{}, 0, nil
+	}
+
+	_, err := keepers.ContractKeeper.Execute(ctx, example.Contract, example.CreatorAddr, []byte(`{}`), nil)
+	require.NoError(t, err)
+
+	// and the committed state is iterated in the same order
+	_, _, s, err := keepers.WasmKeeper.contractInstance(ctx, example.Contract)
+	require.NoError(t, err)
+	exp := expRangeKeys(iterationFixtureKeys, nil, nil)
+	assert.Equal(t, exp, collectIteratorKeys(t, s.Iterator(nil, nil), exp))
+}
+
+func TestOrderedIterator(t *testing.T) {
+	specs := map[string]struct {
+		srcKeys   [][]byte
+		ascending bool
+		expPanic  bool
+	}{
+		"ascending": {
+			srcKeys:   [][]byte{{0x01}, {0x01, 0x00}, {0x02}},
+			ascending: true,
+		},
+		"descending": {
+			srcKeys: [][]byte{{0x02}, {0x01, 0x00}, {0x01}},
+		},
+		"ascending out of order": {
+			srcKeys:   [][]byte{{0x01}, {0x03}, {0x02}},
+			ascending: true,
+			expPanic:  true,
+		},
+		"descending out of order": {
+			srcKeys:  [][]byte{{0x03}, {0x01}, {0x02}},
+			expPanic: true,
+		},
+		"duplicate key": {
+			srcKeys:   [][]byte{{0x01}, {0x01}},
+			ascending: true,
+			expPanic:  true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			iter := newOrderedIterator(&sliceIterator{keys: spec.srcKeys}, spec.ascending)
+			iterate := func() {
+				for ; iter.Valid(); iter.Next() {
+				}
+			}
+			if spec.expPanic {
+				assert.Panics(t, iterate)
+				return
+			}
+			assert.NotPanics(t, iterate)
+		})
+	}
+}
+
+func TestContractStoreEmptyRange(t *testing.T) {
+	s := contractStore{Store: prefixStoreFixture(t)}
+	for _, iter := range []dbm.Iterator{s.Iterator([]byte("b"), []byte("a")), s.ReverseIterator(nil, []byte{})} {
+		assert.False(t, iter.Valid())
+		assert.NoError(t, iter.Error())
+		assert.Panics(t, iter.Next)
+		assert.NoError(t, iter.Close())
+	}
+	start, end := s.Iterator([]byte("b"), []byte("a")).Domain()
+	assert.Equal(t, []byte("b"), start)
+	assert.Equal(t, []byte("a"), end)
+}
+
+// expRangeKeys is the reference model: the sorted keys within [start, end)
+func expRangeKeys(keys [][]byte, start, end []byte) [][]byte {
+	var r [][]byte
+	for _, k := range keys {
+		if bytes.Compare(k, start) < 0 || end != nil && bytes.Compare(k, end) >= 0 {
+			continue
+		}
+		r = append(r, k)
+	}
+	sort.Slice(r, func(i, j int) bool { return bytes.Compare(r[i], r[j]) < 0 })
+	return r
+}
+
+func reverseKeys(keys [][]byte) [][]byte {
+	var r [][]byte
+	for i := len(keys) - 1; i >= 0; i-- {
+		r = append(r, keys[i])
+	}
+	return r
+}
+
+func collectIteratorKeys(t *testing.T, iter dbm.Iterator, exp [][]byte) [][]byte {
+	defer iter.Close()
+	var r [][]byte
+	for ; iter.Valid(); iter.Next() {
+		require.Less(t, len(r), len(exp)+1, "more keys than expected")
+		r = append(r, append([]byte{}, iter.Key()...))
+	}
+	return r
+}
+
+// newIterationTestInput returns a context on a multistore that contains only the wasm store and can be committed
+func newIterationTestInput(t *testing.T) (sdk.Context, *Keeper) {
+	storeKey := sdk.NewKVStoreKey(types.StoreKey)
+	ms := store.NewCommitMultiStore(dbm.NewMemDB())
+	ms.MountStoreWithDB(storeKey, sdk.StoreTypeIAVL, nil)
+	require.NoError(t, ms.LoadLatestVersion())
+	ctx := sdk.NewContext(ms, tmproto.Header{Height: 1}, false, log.NewNopLogger())
+	return ctx, &Keeper{storeKey: storeKey}
+}
+
+func prefixStoreFixture(t *testing.T) prefix.Store {
+	s := dbadapter.Store{DB: dbm.NewMemDB()}
+	s.Set([]byte("prefixa"), []byte("1"))
+	return prefix.NewStore(s, []byte("prefix"))
+}
+
+// sliceIterator iterates the given keys in the given order
+type sliceIterator struct {
+	keys [][]byte
+	pos  int
+}
+
+func (s *sliceIterator) Domain() ([]byte, []byte) { return nil, nil }
+func (s *sliceIterator) Valid() bool              { return s.pos < len(s.keys) }
+func (s *sliceIterator) Next()                    { s.pos++ }
+func (s *sliceIterator) Key() []byte              { return s.keys[s.pos] }
+func (s *sliceIterator) Value() []byte            { return s.keys[s.pos] }
+func (s *sliceIterator) Error() error             { return nil }
+func (s *sliceIterator) Close() error             { return nil }
@@ -20,6 +20,7 @@ import (
 	authkeeper "github.com/cosmos/cosmos-sdk/x/auth/keeper"
 	"github.com/cosmos/cosmos-sdk/x/auth/tx"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/cosmos/cosmos-sdk/x/auth/vesting"
 	"github.com/cosmos/cosmos-sdk/x/bank"
 	bankkeeper "github.com/cosmos/cosmos-sdk/x/bank/keeper"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
@@ -75,6 +76,7 @@ type TestingT interface {
 
 var ModuleBasics = module.NewBasicManager(
 	auth.AppModuleBasic{},
+	vesting.AppModuleBasic{},
 	bank.AppModuleBasic{},
 	capability.AppModuleBasic{},
 	staking.AppModuleBasic{},
@@ -132,7 +134,7 @@ type TestKeepers struct {
 	DistKeeper     distributionkeeper.Keeper
 	BankKeeper     bankkeeper.Keeper
 	GovKeeper      govkeeper.Keeper
-	ContractKeeper types.ContractOpsKeeper
+	ContractKeeper *PermissionedKeeper
 	WasmKeeper     *Keeper
 	IBCKeeper      *ibckeeper.Keeper
 	Router         *baseapp.Router
@@ -214,7 +216,8 @@ func createTestInput(
 		stakingtypes.NotBondedPoolName: {authtypes.Burner, authtypes.Staking},
 		govtypes.ModuleName:            {authtypes.Burner},
 		ibctransfertypes.ModuleName:    {authtypes.Minter, authtypes.Burner},
-		types.ModuleName:               {authtypes.Burner},
+		types.ModuleName:               {authtypes.Minter, authtypes.Burner},
+		types.DepositEscrowName:        {authtypes.Burner},
 	}
 	authSubsp, _ := paramsKeeper.GetSubspace(authtypes.ModuleName)
 	authKeeper := authkeeper.NewAccountKeeper(
@@ -286,6 +289,7 @@ func createTestInput(
 	stakingtypes.RegisterQueryServer(querier, stakingkeeper.Querier{Keeper: stakingKeeper})
 	distributiontypes.RegisterQueryServer(querier, distKeeper)
 
+	opts = append([]Option{WithPortChannelSource(NewIBCPortChannelSource(keyIBC, appCodec))}, opts...)
 	keeper := NewKeeper(
 		appCodec,
 		keyWasm,
@@ -380,7 +384,10 @@ func handleInstantiate(ctx sdk.Context, k types.ContractOpsKeeper, msg *types.Ms
 		return nil, sdkerrors.Wrap(err, "sender")
 	}
 	var adminAddr sdk.AccAddress
-	if msg.Admin != "" {
+	switch {
+	case msg.AdminIsSender:
+		adminAddr = senderAddr
+	case msg.Admin != "":
 		if adminAddr, err = sdk.AccAddressFromBech32(msg.Admin); err != nil {
 			return nil, sdkerrors.Wrap(err, "admin")
 		}
@@ -0,0 +1,166 @@
+package keeper
+
+import (
+	"encoding/json"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// TokenFactory is implemented by chains that embed a token factory module. The contract messages are encoded into
+// the sdk messages of the module so that they are routed and charged for gas like any other transaction. The queries
+// run with the gas meter of the contract query.
+type TokenFactory interface {
+	// EncodeCreateDenom returns the message to create a new denom with the sender as admin
+	EncodeCreateDenom(sender sdk.AccAddress, subdenom string) (sdk.Msg, error)
+	// EncodeMint returns the message to mint new tokens of a denom administrated by the sender
+	EncodeMint(sender sdk.AccAddress, amount sdk.Coin, mintTo sdk.AccAddress) (sdk.Msg, error)
+	// EncodeBurn returns the message to burn tokens of a denom administrated by the sender from the sender account
+	EncodeBurn(sender sdk.AccAddress, amount sdk.Coin) (sdk.Msg, error)
+	// EncodeChangeAdmin returns the message to hand over the admin role for a denom to a new address
+	EncodeChangeAdmin(sender sdk.AccAddress, denom string, newAdmin sdk.AccAddress) (sdk.Msg, error)
+	// FullDenom returns the denom that is created for the creator and subdenom
+	FullDenom(ctx sdk.Context, creator sdk.AccAddress, subdenom string) (string, error)
+	// DenomAdmin returns the current admin of the denom
+	DenomAdmin(ctx sdk.Context, denom string) (sdk.AccAddress, error)
+}
+
+// TokenFactoryCustomMsg is the custom message envelope for token factory messages
+type TokenFactoryCustomMsg struct {
+	TokenFactory *TokenFactoryMsg `json:"token_factory,omitempty"`
+}
+
+// TokenFactoryMsg contains exactly one token factory operation
+type TokenFactoryMsg struct {
+	CreateDenom *CreateDenomMsg `json:"create_denom,omitempty"`
+	Mint        *MintTokensMsg  `json:"mint,omitempty"`
+	Burn        *BurnTokensMsg  `json:"burn,omitempty"`
+	ChangeAdmin *ChangeAdminMsg `json:"change_admin,omitempty"`
+}
+
+type CreateDenomMsg struct {
+	Subdenom string `json:"subdenom"`
+}
+
+type MintTokensMsg struct {
+	Denom         string `json:"denom"`
+	Amount        string `json:"amount"`
+	MintToAddress string `json:"mint_to_address"`
+}
+
+type BurnTokensMsg struct {
+	Denom  string `json:"denom"`
+	Amount string `json:"amount"`
+}
+
+type ChangeAdminMsg struct {
+	Denom           string `json:"denom"`
+	NewAdminAddress string `json:"new_admin_address"`
+}
+
+// TokenFactoryCustomQuery is the custom query envelope for token factory queries
+type TokenFactoryCustomQuery struct {
+	TokenFactory *TokenFactoryQuery `json:"token_factory,omitempty"`
+}
+
+// TokenFactoryQuery contains exactly one token factory query
+type TokenFactoryQuery struct {
+	FullDenom  *FullDenomQuery  `json:"full_denom,omitempty"`
+	DenomAdmin *DenomAdminQuery `json:"denom_admin,omitempty"`
+}
+
+type FullDenomQuery struct {
+	CreatorAddr string `json:"creator_addr"`
+	Subdenom    string `json:"subdenom"`
+}
+
+type FullDenomResponse struct {
+	Denom string `json:"denom"`
+}
+
+type DenomAdminQuery struct {
+	Denom string `json:"denom"`
+}
+
+type DenomAdminResponse struct {
+	Admin string `json:"admin"`
+}
+
+// NewTokenFactoryEncoder encodes the token factory custom messages. All other custom messages are passed to the
+// next encoder.
+func NewTokenFactoryEncoder(tf TokenFactory, next CustomEncoder) CustomEncoder {
+	return func(sender sdk.AccAddress, msg json.RawMessage) ([]sdk.Msg, error) {
+		var customMsg TokenFactoryCustomMsg
+		if err := json.Unmarshal(msg, &customMsg); err != nil || customMsg.TokenFactory == nil {
+			return next(sender, msg)
+		}
+		sdkMsg, err := encodeTokenFactoryMsg(tf, sender, *customMsg.TokenFactory)
+		if err != nil {
+			return nil, err
+		}
+		return []sdk.Msg{sdkMsg}, nil
+	}
+}
+
+func encodeTokenFactoryMsg(tf TokenFactory, sender sdk.AccAddress, msg TokenFactoryMsg) (sdk.Msg, error) {
+	switch {
+	case msg.CreateDenom != nil:
+		return tf.EncodeCreateDenom(sender, msg.CreateDenom.Subdenom)
+	case msg.Mint != nil:
+		amount, err := parsePositiveCoin(msg.Mint.Denom, msg.Mint.Amount)
+		if err != nil {
+			return nil, err
+		}
+		mintTo, err := sdk.AccAddressFromBech32(msg.Mint.MintToAddress)
+		if err != nil {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Mint.MintToAddress)
+		}
+		return tf.EncodeMint(sender, amount, mintTo)
+	case msg.Burn != nil:
+		amount, err := parsePositiveCoin(msg.Burn.Denom, msg.Burn.Amount)
+		if err != nil {
+			return nil, err
+		}
+		return tf.EncodeBurn(sender, amount)
+	case msg.ChangeAdmin != nil:
+		newAdmin, err := sdk.AccAddressFromBech32(msg.ChangeAdmin.NewAdminAddress)
+		if err != nil {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.ChangeAdmin.NewAdminAddress)
+		}
+		return tf.EncodeChangeAdmin(sender, msg.ChangeAdmin.Denom, newAdmin)
+	default:
+		return nil, sdkerrors.Wrap(types.ErrInvalidMsg, "unknown token factory variant")
+	}
+}
+
+// NewTokenFactoryQuerier handles the token factory custom queries. All other custom queries are passed to the
+// next querier.
+func NewTokenFactoryQuerier(tf TokenFactory, next CustomQuerier) CustomQuerier {
+	return func(ctx sdk.Context, request json.RawMessage) ([]byte, error) {
+		var customQuery TokenFactoryCustomQuery
+		if err := json.Unmarshal(request, &customQuery); err != nil || customQuery.TokenFactory == nil {
+			return next(ctx, request)
+		}
+		switch q := customQuery.TokenFactory; {
+		case q.FullDenom != nil:
+			creator, err := sdk.AccAddressFromBech32(q.FullDenom.CreatorAddr)
+			if err != nil {
+				return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, q.FullDenom.CreatorAddr)
+			}
+			denom, err := tf.FullDenom(ctx, creator, q.FullDenom.Subdenom)
+			if err != nil {
+				return nil, err
+			}
+			return json.Marshal(FullDenomResponse{Denom: denom})
+		case q.DenomAdmin != nil:
+			admin, err := tf.DenomAdmin(ctx, q.DenomAdmin.Denom)
+			if err != nil {
+				return nil, err
+			}
+			return json.Marshal(DenomAdminResponse{Admin: admin.String()})
+		default:
+			return nil, sdkerrors.Wrap(types.ErrInvalid, "unknown token factory query variant")
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package keeper
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenFactoryEncoder(t *testing.T) {
+	sender := RandomAccountAddress(t)
+	otherAddr := RandomAccountAddress(t)
+	nextMsg := &banktypes.MsgSend{FromAddress: "next"}
+	next := func(sender sdk.AccAddress, msg json.RawMessage) ([]sdk.Msg, error) {
+		return []sdk.Msg{nextMsg}, nil
+	}
+	specs := map[string]struct {
+		src    string
+		expMsg sdk.Msg
+		expErr *sdkerrors.Error
+	}{
+		"create denom": {
+			src:    `{"token_factory":{"create_denom":{"subdenom":"foo"}}}`,
+			expMsg: &banktypes.MsgSend{FromAddress: sender.String(), ToAddress: "create:foo"},
+		},
+		"mint": {
+			src:    fmt.Sprintf(`{"token_factory":{"mint":{"denom":"factory/foo","amount":"10","mint_to_address":%q}}}`, otherAddr.String()),
+			expMsg: &banktypes.MsgSend{FromAddress: sender.String(), ToAddress: otherAddr.String(), Amount: sdk.NewCoins(sdk.NewInt64Coin("factory/foo", 10))},
+		},
+		"burn": {
+			src:    `{"token_factory":{"burn":{"denom":"factory/foo","amount":"10"}}}`,
+			expMsg: &banktypes.MsgSend{FromAddress: sender.String(), ToAddress: "burn", Amount: sdk.NewCoins(sdk.NewInt64Coin("factory/foo", 10))},
+		},
+		"change admin": {
+			src:    fmt.Sprintf(`{"token_factory":{"change_admin":{"denom":"factory/foo","new_admin_address":%q}}}`, otherAddr.String()),
+			expMsg: &banktypes.MsgSend{FromAddress: sender.String(), ToAddress: otherAddr.String() + ":factory/foo"},
+		},
+		"mint with invalid amount": {
+			src:    fmt.Sprintf(`{"token_factory":{"mint":{"denom":"factory/foo","amount":"-1","mint_to_address":%q}}}`, otherAddr.String()),
+			expErr: sdkerrors.ErrInvalidCoins,
+		},
+		"mint with invalid recipient": {
+			src:    `{"token_factory":{"mint":{"denom":"factory/foo","amount":"1","mint_to_address":"invalid"}}}`,
+			expErr: sdkerrors.ErrInvalidAddress,
+		},
+		"empty variant": {
+			src:    `{"token_factory":{}}`,
+			expErr: types.ErrInvalidMsg,
+		},
+		"other custom message": {
+			src:    `{"foo":{}}`,
+			expMsg: nextMsg,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			encoder := NewTokenFactoryEncoder(mockTokenFactory{}, next)
+			gotMsgs, gotErr := encoder(sender, []byte(spec.src))
+			if spec.expErr != nil {
+				assert.True(t, errors.Is(gotErr, spec.expErr), "got %+v", gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, []sdk.Msg{spec.expMsg}, gotMsgs)
+		})
+	}
+}
+
+func TestTokenFactoryQuerier(t *testing.T) {
+	creator := RandomAccountAddress(t)
+	next := func(ctx sdk.Context, request json.RawMessage) ([]byte, error) {
+		return []byte(`"next"`), nil
+	}
+	specs := map[string]struct {
+		src    string
+		expRsp string
+		expErr *sdkerrors.Error
+	}{
+		"full denom": {
+			src:    fmt.Sprintf(`{"token_factory":{"full_denom":{"creator_addr":%q,"subdenom":"foo"}}}`, creator.String()),
+			expRsp: fmt.Sprintf(`{"denom":"factory/%s/foo"}`, creator.String()),
+		},
+		"full denom with invalid creator": {
+			src:    `{"token_factory":{"full_denom":{"creator_addr":"invalid","subdenom":"foo"}}}`,
+			expErr: sdkerrors.ErrInvalidAddress,
+		},
+		"denom admin": {
+			src:    fmt.Sprintf(`{"token_factory":{"denom_admin":{"denom":"factory/%s/foo"}}}`, creator.String()),
+			expRsp: fmt.Sprintf(`{"admin":%q}`, creator.String()),
+		},
+		"empty variant": {
+			src:    `{"token_factory":{}}`,
+			expErr: types.ErrInvalid,
+		},
+		"other custom query": {
+			src:    `{"foo":{}}`,
+			expRsp: `"next"`,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			querier := NewTokenFactoryQuerier(mockTokenFactory{}, next)
+			gotRsp, gotErr := querier(sdk.Context{}, []byte(spec.src))
+			if spec.expErr != nil {
+				assert.True(t, errors.Is(gotErr, spec.expErr), "got %+v", gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.JSONEq(t, spec.expRsp, string(gotRsp))
+		})
+	}
+}
+
+// mockTokenFactory encodes all operations into bank send messages to capture the arguments
+type mockTokenFactory struct{}
+
+func (m mockTokenFactory) EncodeCreateDenom(sender sdk.AccAddress, subdenom string) (sdk.Msg, error) {
+	return &banktypes.MsgSend{FromAddress: sender.String(), ToAddress: "create:" + subdenom}, nil
+}
+
+func (m mockTokenFactory) EncodeMint(sender sdk.AccAddress, amount sdk.Coin, mintTo sdk.AccAddress) (sdk.Msg, error) {
+	return &banktypes.MsgSend{FromAddress: sender.String(), ToAddress: mintTo.String(), Amount: sdk.NewCoins(amount)}, nil
+}
+
+func (m mockTokenFactory) EncodeBurn(sender sdk.AccAddress, amount sdk.Coin) (sdk.Msg, error) {
+	return &banktypes.MsgSend{FromAddress: sender.String(), ToAddress: "burn", Amount: sdk.NewCoins(amount)}, nil
+}
+
+func (m mockTokenFactory) EncodeChangeAdmin(sender sdk.AccAddress, denom string, newAdmin sdk.AccAddress) (sdk.Msg, error) {
+	return &banktypes.MsgSend{FromAddress: sender.String(), ToAddress: newAdmin.String() + ":" + denom}, nil
+}
+
+func (m mockTokenFactory) FullDenom(_ sdk.Context, creator sdk.AccAddress, subdenom string) (string, error) {
+	return fmt.Sprintf("factory/%s/%s", creator, subdenom), nil
+}
+
+func (m mockTokenFactory) DenomAdmin(_ sdk.Context, denom string) (sdk.AccAddress, error) {
+	return sdk.AccAddressFromBech32(strings.Split(denom, "/")[1])
+}
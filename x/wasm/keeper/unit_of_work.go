@@ -0,0 +1,37 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// UnitOfWork is a contract operation that is executed by RunUnitOfWork, for example an instantiate or execute call
+// on the contract keeper.
+type UnitOfWork func(ctx sdk.Context) ([]byte, error)
+
+// RunUnitOfWork executes the operation in a cache context with a dedicated gas meter. The state changes are never
+// committed so that this can be used for simulations and dry-runs. A gas limit of 0 means no limit.
+// The gas used is reported but not charged to the parent context. When the operation runs out of gas, the full
+// limit is returned as gas used together with an out of gas error.
+func (k Keeper) RunUnitOfWork(ctx sdk.Context, gasLimit uint64, op UnitOfWork) (events []sdk.Event, data []byte, gasUsed uint64, err error) {
+	gasMeter := sdk.NewInfiniteGasMeter()
+	if gasLimit != 0 {
+		gasMeter = sdk.NewGasMeter(gasLimit)
+	}
+	em := sdk.NewEventManager()
+	cacheCtx, _ := ctx.CacheContext()
+	cacheCtx = cacheCtx.WithGasMeter(gasMeter).WithEventManager(em)
+
+	defer func() {
+		if r := recover(); r != nil {
+			// if it's not an OutOfGas error, raise it again
+			if _, ok := r.(sdk.ErrorOutOfGas); !ok {
+				panic(r)
+			}
+			events, data, gasUsed = nil, nil, gasLimit
+			err = sdkerrors.Wrap(sdkerrors.ErrOutOfGas, "unit of work hit gas limit")
+		}
+	}()
+	data, err = op(cacheCtx)
+	return em.Events(), data, gasMeter.GasConsumed(), err
+}
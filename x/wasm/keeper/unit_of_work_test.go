@@ -0,0 +1,78 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunUnitOfWork(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	example := InstantiateHackatomExampleContract(t, ctx, keepers)
+	contractBalance := keepers.BankKeeper.GetAllBalances(ctx, example.Contract)
+	require.False(t, contractBalance.IsZero())
+
+	release := func(ctx sdk.Context) ([]byte, error) {
+		return keepers.ContractKeeper.Execute(ctx, example.Contract, example.VerifierAddr, []byte(`{"release":{}}`), nil)
+	}
+	specs := map[string]struct {
+		gasLimit   uint64
+		op         UnitOfWork
+		expErr     *sdkerrors.Error
+		expGasUsed uint64
+		expEvents  bool
+	}{
+		"success": {
+			gasLimit:  1_000_000,
+			op:        release,
+			expEvents: true,
+		},
+		"success without gas limit": {
+			op:        release,
+			expEvents: true,
+		},
+		"contract error": {
+			gasLimit: 1_000_000,
+			op: func(ctx sdk.Context) ([]byte, error) {
+				return keepers.ContractKeeper.Execute(ctx, example.Contract, example.CreatorAddr, []byte(`{"release":{}}`), nil)
+			},
+			expErr: types.ErrExecuteFailed,
+		},
+		"out of gas": {
+			gasLimit:   1,
+			op:         release,
+			expErr:     sdkerrors.ErrOutOfGas,
+			expGasUsed: 1,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			parentGasBefore := ctx.GasMeter().GasConsumed()
+			em := sdk.NewEventManager()
+
+			// when
+			events, _, gasUsed, err := keepers.WasmKeeper.RunUnitOfWork(ctx.WithEventManager(em), spec.gasLimit, spec.op)
+
+			// then
+			assert.Equal(t, parentGasBefore, ctx.GasMeter().GasConsumed())
+			if spec.expErr != nil {
+				assert.True(t, spec.expErr.Is(err), "got %+v", err)
+			} else {
+				require.NoError(t, err)
+			}
+			if spec.expGasUsed != 0 {
+				assert.Equal(t, spec.expGasUsed, gasUsed)
+			} else {
+				assert.NotZero(t, gasUsed)
+			}
+			assert.Equal(t, spec.expEvents, len(events) != 0)
+			// nothing was committed
+			assert.Equal(t, contractBalance, keepers.BankKeeper.GetAllBalances(ctx, example.Contract))
+			assert.Empty(t, em.Events())
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package keeper
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"sync"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+const (
+	// wasmvmModulePath is the Go module that bundles the libwasmvm shared library
+	wasmvmModulePath = "github.com/CosmWasm/wasmvm"
+	// wasmvmVersionFile is the file in the wasm data directory with the wasmvm version that the directory was used with
+	wasmvmVersionFile = "wasmvm_version"
+	// unknownWasmvmVersion is returned when the binary was built without module information
+	unknownWasmvmVersion = "unknown"
+)
+
+var (
+	wasmvmVersionOnce sync.Once
+	wasmvmVersion     string
+)
+
+// WasmvmVersion returns the version of the wasmvm Go module that the binary was built with, or "unknown" when the
+// build information is not available. The libwasmvm library is released together with the Go module.
+func WasmvmVersion() string {
+	wasmvmVersionOnce.Do(func() {
+		wasmvmVersion = unknownWasmvmVersion
+		buildInfo, ok := debug.ReadBuildInfo()
+		if !ok {
+			return
+		}
+		for _, dep := range buildInfo.Deps {
+			if dep.Path != wasmvmModulePath {
+				continue
+			}
+			if dep.Replace != nil {
+				dep = dep.Replace
+			}
+			// a replacement with a local directory has no version
+			if dep.Version != "" {
+				wasmvmVersion = dep.Version
+			}
+			return
+		}
+	})
+	return wasmvmVersion
+}
+
+// checkWasmvmVersion fails when the wasm data directory was used with another wasmvm version than the current one
+// before, unless the change is accepted. This protects nodes from processing blocks with a wasmvm from a partial or
+// early upgrade that may execute contracts differently or read an incompatible cache. libwasmvm v0.14 does not report
+// its version, so the version of the wasmvm Go module that bundles it is used, see WasmvmVersion. The check is skipped
+// when the version is unknown.
+// The change is accepted automatically when the binary was switched at the height of a planned software upgrade, see
+// WasmConfig.UpgradeName. The upgrade name is recorded with the version so that the same upgrade does not accept
+// another change later.
+func checkWasmvmVersion(dataDir, current, upgradeName string, acceptChange bool) error {
+	if current == unknownWasmvmVersion {
+		return nil
+	}
+	path := filepath.Join(dataDir, wasmvmVersionFile)
+	bz, err := ioutil.ReadFile(path)
+	recorded, recordedUpgrade := parseWasmvmVersionFile(bz)
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return sdkerrors.Wrap(err, "read wasmvm version")
+	case recorded == current:
+		if recordedUpgrade == upgradeName {
+			return nil
+		}
+	case acceptChange:
+	case upgradeName != recordedUpgrade:
+		// binary switched at the upgrade height, by cosmovisor for example
+	default:
+		return sdkerrors.Wrapf(types.ErrInvalid, "wasm data in %s was used with wasmvm %s but this binary is built with %s. Accept the change for a planned upgrade with --wasm.accept_vm_upgrade", dataDir, recorded, current)
+	}
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return sdkerrors.Wrap(err, "wasm data directory")
+	}
+	content := current
+	if upgradeName != "" {
+		content += "\n" + upgradeName
+	}
+	return ioutil.WriteFile(path, []byte(content), 0644)
+}
+
+// parseWasmvmVersionFile returns the wasmvm version and the optional name of the software upgrade from the
+// wasmvm version file content
+func parseWasmvmVersionFile(bz []byte) (string, string) {
+	lines := strings.SplitN(strings.TrimSpace(string(bz)), "\n", 2)
+	if len(lines) == 1 {
+		return lines[0], ""
+	}
+	return strings.TrimSpace(lines[0]), strings.TrimSpace(lines[1])
+}
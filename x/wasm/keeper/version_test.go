@@ -0,0 +1,131 @@
+package keeper
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	authkeeper "github.com/cosmos/cosmos-sdk/x/auth/keeper"
+	distributionkeeper "github.com/cosmos/cosmos-sdk/x/distribution/keeper"
+	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
+	stakingkeeper "github.com/cosmos/cosmos-sdk/x/staking/keeper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckWasmvmVersion(t *testing.T) {
+	const myVersion = "v0.14.0"
+	specs := map[string]struct {
+		srcVersion   *string
+		current      string
+		upgradeName  string
+		acceptChange bool
+		expErr       bool
+		expVersion   string
+	}{
+		"new data dir": {
+			current:    myVersion,
+			expVersion: myVersion,
+		},
+		"same version": {
+			srcVersion: strPtr(myVersion),
+			current:    myVersion,
+			expVersion: myVersion + "\n",
+		},
+		"version mismatch": {
+			srcVersion: strPtr("v0.13.2"),
+			current:    myVersion,
+			expErr:     true,
+			expVersion: "v0.13.2\n",
+		},
+		"version mismatch accepted": {
+			srcVersion:   strPtr("v0.13.2"),
+			current:      myVersion,
+			acceptChange: true,
+			expVersion:   myVersion,
+		},
+		"version mismatch at upgrade": {
+			srcVersion:  strPtr("v0.13.2"),
+			current:     myVersion,
+			upgradeName: "v2",
+			expVersion:  myVersion + "\nv2",
+		},
+		"version mismatch at upgrade already recorded": {
+			srcVersion:  strPtr("v0.13.2\nv2"),
+			current:     myVersion,
+			upgradeName: "v2",
+			expErr:      true,
+			expVersion:  "v0.13.2\nv2\n",
+		},
+		"version mismatch after upgrade": {
+			srcVersion:  strPtr("v0.13.2\nv1"),
+			current:     myVersion,
+			upgradeName: "v2",
+			expVersion:  myVersion + "\nv2",
+		},
+		"same version records upgrade": {
+			srcVersion:  strPtr(myVersion),
+			current:     myVersion,
+			upgradeName: "v2",
+			expVersion:  myVersion + "\nv2",
+		},
+		"new data dir at upgrade": {
+			current:     myVersion,
+			upgradeName: "v2",
+			expVersion:  myVersion + "\nv2",
+		},
+		"unknown current version": {
+			srcVersion: strPtr("v0.13.2"),
+			current:    unknownWasmvmVersion,
+			expVersion: "v0.13.2\n",
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			dataDir := filepath.Join(t.TempDir(), "wasm")
+			path := filepath.Join(dataDir, wasmvmVersionFile)
+			if spec.srcVersion != nil {
+				require.NoError(t, os.MkdirAll(dataDir, 0755))
+				require.NoError(t, ioutil.WriteFile(path, []byte(*spec.srcVersion+"\n"), 0644))
+			}
+
+			// when
+			err := checkWasmvmVersion(dataDir, spec.current, spec.upgradeName, spec.acceptChange)
+
+			// then
+			if spec.expErr {
+				assert.True(t, types.ErrInvalid.Is(err), "got %+v", err)
+			} else {
+				require.NoError(t, err)
+			}
+			bz, err := ioutil.ReadFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, spec.expVersion, string(bz))
+		})
+	}
+}
+
+func TestNewKeeperRefusesWasmvmVersionMismatch(t *testing.T) {
+	current := WasmvmVersion()
+	if current == unknownWasmvmVersion {
+		t.Skip("binary built without module information")
+	}
+	homeDir := t.TempDir()
+	dataDir := filepath.Join(homeDir, "wasm")
+	require.NoError(t, os.MkdirAll(dataDir, 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dataDir, wasmvmVersionFile), []byte("v0.0.1"), 0644))
+
+	defer func() {
+		err, ok := recover().(error)
+		require.True(t, ok)
+		assert.True(t, types.ErrInvalid.Is(err), "got %+v", err)
+	}()
+	NewKeeper(nil, nil, paramtypes.NewSubspace(nil, nil, nil, nil, ""), authkeeper.AccountKeeper{}, nil, stakingkeeper.Keeper{}, distributionkeeper.Keeper{}, nil, nil, nil, nil, nil, nil, homeDir, types.DefaultWasmConfig(), SupportedFeatures)
+	t.Fatal("expected panic")
+}
+
+func strPtr(s string) *string {
+	return &s
+}
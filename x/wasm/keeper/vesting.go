@@ -0,0 +1,36 @@
+package keeper
+
+import (
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+)
+
+// setContractVesting converts the account of the contract into a vesting account that locks the scheduled amount.
+// The bank module does not let the contract spend locked funds so that a lockup is enforced for any contract code,
+// also after a migration. The contract must hold the amount and the schedule must not be vested already. A contract
+// account can be converted only once.
+func (k Keeper) setContractVesting(ctx sdk.Context, contractAddress sdk.AccAddress, schedule types.ContractVestingSchedule, authZ AuthorizationPolicy) error {
+	if !canSetContractVesting(authZ) {
+		return sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "can not set contract vesting")
+	}
+	if err := schedule.ValidateBasic(); err != nil {
+		return sdkerrors.Wrap(err, "schedule")
+	}
+	if k.GetContractInfo(ctx, contractAddress) == nil {
+		return sdkerrors.Wrap(types.ErrNotFound, "contract")
+	}
+	if schedule.VestingEndTime() <= ctx.BlockTime().Unix() {
+		return sdkerrors.Wrap(types.ErrInvalid, "vesting must end after the block time")
+	}
+	baseAccount, ok := k.accountKeeper.GetAccount(ctx, contractAddress).(*authtypes.BaseAccount)
+	if !ok {
+		return sdkerrors.Wrap(types.ErrInvalid, "contract account can not be converted")
+	}
+	if balance := k.bankKeeper.GetAllBalances(ctx, contractAddress); !balance.IsAllGTE(schedule.Amount) {
+		return sdkerrors.Wrapf(sdkerrors.ErrInsufficientFunds, "balance %s is smaller than %s", balance, schedule.Amount)
+	}
+	k.accountKeeper.SetAccount(ctx, schedule.NewVestingAccount(baseAccount))
+	return nil
+}
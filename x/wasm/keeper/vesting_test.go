@@ -0,0 +1,167 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	vestingexported "github.com/cosmos/cosmos-sdk/x/auth/vesting/exported"
+	vestingtypes "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetContractVestingProposal(t *testing.T) {
+	myFunds := sdk.NewCoins(sdk.NewInt64Coin("denom", 100))
+	now := time.Unix(1000, 0).UTC()
+	specs := map[string]struct {
+		srcSchedule   types.ContractVestingSchedule
+		srcContract   func(contractAddr sdk.AccAddress) sdk.AccAddress
+		expLocked     sdk.Coins
+		expLockedLate sdk.Coins
+		expErr        *sdkerrors.Error
+	}{
+		"continuous": {
+			srcSchedule: types.ContractVestingSchedule{
+				Type:      types.ContractVestingTypeContinuous,
+				Amount:    sdk.NewCoins(sdk.NewInt64Coin("denom", 80)),
+				StartTime: now.Unix(),
+				EndTime:   now.Unix() + 100,
+			},
+			expLocked:     sdk.NewCoins(sdk.NewInt64Coin("denom", 80)),
+			expLockedLate: sdk.NewCoins(sdk.NewInt64Coin("denom", 40)),
+		},
+		"delayed": {
+			srcSchedule: types.ContractVestingSchedule{
+				Type:    types.ContractVestingTypeDelayed,
+				Amount:  myFunds,
+				EndTime: now.Unix() + 100,
+			},
+			expLocked:     myFunds,
+			expLockedLate: myFunds,
+		},
+		"periodic": {
+			srcSchedule: types.ContractVestingSchedule{
+				Type:      types.ContractVestingTypePeriodic,
+				Amount:    myFunds,
+				StartTime: now.Unix(),
+				Periods: []types.ContractVestingPeriod{
+					{Length: 10, Amount: sdk.NewCoins(sdk.NewInt64Coin("denom", 30))},
+					{Length: 90, Amount: sdk.NewCoins(sdk.NewInt64Coin("denom", 70))},
+				},
+			},
+			expLocked:     myFunds,
+			expLockedLate: sdk.NewCoins(sdk.NewInt64Coin("denom", 70)),
+		},
+		"insufficient funds": {
+			srcSchedule: types.ContractVestingSchedule{
+				Type:    types.ContractVestingTypeDelayed,
+				Amount:  sdk.NewCoins(sdk.NewInt64Coin("denom", 101)),
+				EndTime: now.Unix() + 100,
+			},
+			expErr: sdkerrors.ErrInsufficientFunds,
+		},
+		"vested already": {
+			srcSchedule: types.ContractVestingSchedule{
+				Type:    types.ContractVestingTypeDelayed,
+				Amount:  myFunds,
+				EndTime: now.Unix(),
+			},
+			expErr: types.ErrInvalid,
+		},
+		"unknown contract": {
+			srcSchedule: types.ContractVestingSchedule{
+				Type:    types.ContractVestingTypeDelayed,
+				Amount:  myFunds,
+				EndTime: now.Unix() + 100,
+			},
+			srcContract: func(sdk.AccAddress) sdk.AccAddress {
+				return RandomAccountAddress(t)
+			},
+			expErr: types.ErrNotFound,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+			ctx = ctx.WithBlockTime(now)
+			mock := wasmtesting.MockWasmer{}
+			wasmtesting.MakeInstantiable(&mock)
+			example := SeedNewContractInstance(t, ctx, keepers, &mock)
+			require.NoError(t, keepers.BankKeeper.SetBalances(ctx, example.Contract, myFunds))
+			contractAddr := example.Contract
+			if spec.srcContract != nil {
+				contractAddr = spec.srcContract(contractAddr)
+			}
+			src := types.SetContractVestingProposalFixture(func(p *types.SetContractVestingProposal) {
+				p.Contract = contractAddr.String()
+				p.Schedule = spec.srcSchedule
+			})
+			em := sdk.NewEventManager()
+
+			// when
+			handler := NewWasmProposalHandler(keepers.WasmKeeper, types.EnableAllProposals)
+			err := handler(ctx.WithEventManager(em), src)
+
+			// then
+			if spec.expErr != nil {
+				assert.True(t, spec.expErr.Is(err), "got %+v", err)
+				_, isVesting := keepers.AccountKeeper.GetAccount(ctx, example.Contract).(vestingexported.VestingAccount)
+				assert.False(t, isVesting)
+				return
+			}
+			require.NoError(t, err)
+			acc, ok := keepers.AccountKeeper.GetAccount(ctx, example.Contract).(vestingexported.VestingAccount)
+			require.True(t, ok)
+			assert.Equal(t, spec.expLocked, acc.LockedCoins(ctx.BlockTime()))
+			assert.Equal(t, spec.expLockedLate, acc.LockedCoins(ctx.BlockTime().Add(50*time.Second)))
+			assert.Empty(t, acc.LockedCoins(ctx.BlockTime().Add(100*time.Second)))
+			expEvt := sdk.NewEvent(types.EventTypeSetContractVesting,
+				sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+				sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String()),
+				sdk.NewAttribute(types.AttributeKeyVestingType, spec.srcSchedule.Type.String()),
+				sdk.NewAttribute(sdk.AttributeKeyAmount, spec.srcSchedule.Amount.String()),
+				sdk.NewAttribute(types.AttributeKeyVestingEndTime, "1100"),
+			)
+			assert.Contains(t, em.Events(), expEvt)
+
+			// and the locked funds can not be spent
+			recipient := RandomAccountAddress(t)
+			spendable := myFunds.Sub(spec.expLocked)
+			err = keepers.BankKeeper.SendCoins(ctx, example.Contract, recipient, spendable.Add(sdk.NewInt64Coin("denom", 1)))
+			assert.True(t, sdkerrors.ErrInsufficientFunds.Is(err), "got %+v", err)
+			if !spendable.IsZero() {
+				require.NoError(t, keepers.BankKeeper.SendCoins(ctx, example.Contract, recipient, spendable))
+			}
+		})
+	}
+}
+
+func TestSetContractVestingOnVestingAccount(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, SupportedFeatures)
+	ctx = ctx.WithBlockTime(time.Unix(1000, 0))
+	k := keepers.WasmKeeper
+	mock := wasmtesting.MockWasmer{}
+	wasmtesting.MakeInstantiable(&mock)
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	myFunds := sdk.NewCoins(sdk.NewInt64Coin("denom", 100))
+	require.NoError(t, keepers.BankKeeper.SetBalances(ctx, example.Contract, myFunds))
+	schedule := types.ContractVestingSchedule{Type: types.ContractVestingTypeDelayed, Amount: myFunds, EndTime: 2000}
+	govKeeper := NewGovPermissionKeeper(k)
+	require.NoError(t, govKeeper.SetContractVesting(ctx, example.Contract, schedule))
+
+	// when converted again
+	schedule.EndTime = 3000
+	err := govKeeper.SetContractVesting(ctx, example.Contract, schedule)
+	// then
+	assert.True(t, types.ErrInvalid.Is(err), "got %+v", err)
+	acc := keepers.AccountKeeper.GetAccount(ctx, example.Contract).(*vestingtypes.DelayedVestingAccount)
+	assert.Equal(t, int64(2000), acc.EndTime)
+
+	// and without gov permissions
+	err = NewDefaultPermissionKeeper(k).SetContractVesting(ctx, example.Contract, schedule)
+	assert.True(t, sdkerrors.ErrUnauthorized.Is(err), "got %+v", err)
+}
@@ -0,0 +1,348 @@
+package keeper
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	wasmvm "github.com/CosmWasm/wasmvm"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	"github.com/cosmos/cosmos-sdk/telemetry"
+)
+
+// fatalVMErrors are the messages of wasmvm errors that are caused by the VM and not by the contract. For example a
+// panic in the VM. Errors about the stored data of a single code, like a missing or modified wasm file, are not
+// included as a new VM would fail on them the same way.
+var fatalVMErrors = []string{
+	"Caught panic",
+	"Uninitialized Context Data",
+}
+
+// minReinitializeInterval is the minimum time between two re-creations of the VM so that an error that is raised on
+// every call of a contract does not make the node re-create the VM and pin all codes again for every call.
+const minReinitializeInterval = time.Minute
+
+// isFatalVMError returns true when the error was caused by an internal failure of the VM
+func isFatalVMError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := err.Error()
+	for _, v := range fatalVMErrors {
+		if strings.Contains(msg, v) {
+			return true
+		}
+	}
+	return false
+}
+
+var _ types.WasmerEngine = &recoveringWasmEngine{}
+
+// recoveringWasmEngine re-creates the wasm VM when a call fails with an internal VM error so that the node does not
+// need to be restarted. The memory cache of the new VM starts empty and the pinned codes are pinned again.
+// The failed call returns the error. The old VM is released when all calls that still use it have returned.
+type recoveringWasmEngine struct {
+	newVM func() (types.WasmerEngine, error)
+	now   func() time.Time
+
+	mu      sync.Mutex
+	current *vmInstance
+	// reinitializing is set while a new VM is created and the codes are pinned again
+	reinitializing bool
+	// lastReinitialized is the time of the last re-creation of the VM
+	lastReinitialized time.Time
+	// pinned contains the checksums of the pinned codes
+	pinned map[string]wasmvm.Checksum
+	// retired contains the replaced VMs that are still used by calls
+	retired map[*vmInstance]struct{}
+}
+
+// vmInstance is a VM with the number of calls that use it
+type vmInstance struct {
+	types.WasmerEngine
+	refs int
+}
+
+func newRecoveringWasmEngine(newVM func() (types.WasmerEngine, error)) (*recoveringWasmEngine, error) {
+	vm, err := newVM()
+	if err != nil {
+		return nil, err
+	}
+	return &recoveringWasmEngine{
+		newVM:   newVM,
+		now:     time.Now,
+		current: &vmInstance{WasmerEngine: vm},
+		pinned:  make(map[string]wasmvm.Checksum),
+		retired: make(map[*vmInstance]struct{}),
+	}, nil
+}
+
+func (e *recoveringWasmEngine) acquire() *vmInstance {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.current.refs++
+	return e.current
+}
+
+func (e *recoveringWasmEngine) release(vm *vmInstance) {
+	e.mu.Lock()
+	vm.refs--
+	_, retired := e.retired[vm]
+	retired = retired && vm.refs == 0
+	if retired {
+		delete(e.retired, vm)
+	}
+	e.mu.Unlock()
+	if retired {
+		vm.Cleanup()
+	}
+}
+
+// call runs the operation with the current VM and re-creates the VM when it fails with an internal error
+func (e *recoveringWasmEngine) call(op func(vm types.WasmerEngine) error) {
+	vm := e.acquire()
+	err := op(vm)
+	e.release(vm)
+	if isFatalVMError(err) {
+		e.reinitialize(vm)
+	}
+}
+
+// reinitialize replaces the failed VM with a new one unless this was done by another call already or the VM was
+// re-created within the minReinitializeInterval. The new VM is set up without holding the lock so that the
+// calls on the failed VM are not blocked while the codes are pinned again.
+func (e *recoveringWasmEngine) reinitialize(failed *vmInstance) {
+	e.mu.Lock()
+	now := e.now()
+	if e.current != failed || e.reinitializing || now.Sub(e.lastReinitialized) < minReinitializeInterval {
+		e.mu.Unlock()
+		return
+	}
+	e.reinitializing = true
+	e.lastReinitialized = now
+	pinned := e.copyPinned()
+	e.mu.Unlock()
+
+	vm, err := e.newVM()
+	if err == nil {
+		if err = pinChecksums(vm, pinned); err != nil {
+			vm.Cleanup()
+		}
+	}
+
+	e.mu.Lock()
+	e.reinitializing = false
+	if err != nil {
+		e.mu.Unlock()
+		telemetry.IncrCounter(1, "wasm", "vm", "reinitialize_failed")
+		return
+	}
+	e.current = &vmInstance{WasmerEngine: vm}
+	retired := failed.refs == 0
+	if !retired {
+		e.retired[failed] = struct{}{}
+	}
+	// codes can be pinned or unpinned on the failed VM while the new one is set up
+	current := e.copyPinned()
+	e.mu.Unlock()
+	if retired {
+		failed.Cleanup()
+	}
+	for k, checksum := range current {
+		if _, ok := pinned[k]; !ok {
+			_ = vm.Pin(checksum)
+		}
+	}
+	for k, checksum := range pinned {
+		if _, ok := current[k]; !ok {
+			_ = vm.Unpin(checksum)
+		}
+	}
+	telemetry.IncrCounter(1, "wasm", "vm", "reinitialized")
+}
+
+// copyPinned returns a copy of the pinned checksums. The caller must hold the lock.
+func (e *recoveringWasmEngine) copyPinned() map[string]wasmvm.Checksum {
+	r := make(map[string]wasmvm.Checksum, len(e.pinned))
+	for k, v := range e.pinned {
+		r[k] = v
+	}
+	return r
+}
+
+func pinChecksums(vm types.WasmerEngine, checksums map[string]wasmvm.Checksum) error {
+	for _, checksum := range checksums {
+		if err := vm.Pin(checksum); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *recoveringWasmEngine) Create(code wasmvm.WasmCode) (checksum wasmvm.Checksum, err error) {
+	e.call(func(vm types.WasmerEngine) error {
+		checksum, err = vm.Create(code)
+		return err
+	})
+	return
+}
+
+func (e *recoveringWasmEngine) AnalyzeCode(checksum wasmvm.Checksum) (report *wasmvmtypes.AnalysisReport, err error) {
+	e.call(func(vm types.WasmerEngine) error {
+		report, err = vm.AnalyzeCode(checksum)
+		return err
+	})
+	return
+}
+
+func (e *recoveringWasmEngine) Instantiate(code wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, initMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (res *wasmvmtypes.Response, gasUsed uint64, err error) {
+	e.call(func(vm types.WasmerEngine) error {
+		res, gasUsed, err = vm.Instantiate(code, env, info, initMsg, store, goapi, querier, gasMeter, gasLimit)
+		return err
+	})
+	return
+}
+
+func (e *recoveringWasmEngine) Execute(code wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (res *wasmvmtypes.Response, gasUsed uint64, err error) {
+	e.call(func(vm types.WasmerEngine) error {
+		res, gasUsed, err = vm.Execute(code, env, info, executeMsg, store, goapi, querier, gasMeter, gasLimit)
+		return err
+	})
+	return
+}
+
+func (e *recoveringWasmEngine) Query(code wasmvm.Checksum, env wasmvmtypes.Env, queryMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (res []byte, gasUsed uint64, err error) {
+	e.call(func(vm types.WasmerEngine) error {
+		res, gasUsed, err = vm.Query(code, env, queryMsg, store, goapi, querier, gasMeter, gasLimit)
+		return err
+	})
+	return
+}
+
+func (e *recoveringWasmEngine) Migrate(code wasmvm.Checksum, env wasmvmtypes.Env, migrateMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (res *wasmvmtypes.Response, gasUsed uint64, err error) {
+	e.call(func(vm types.WasmerEngine) error {
+		res, gasUsed, err = vm.Migrate(code, env, migrateMsg, store, goapi, querier, gasMeter, gasLimit)
+		return err
+	})
+	return
+}
+
+func (e *recoveringWasmEngine) Sudo(code wasmvm.Checksum, env wasmvmtypes.Env, sudoMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (res *wasmvmtypes.Response, gasUsed uint64, err error) {
+	e.call(func(vm types.WasmerEngine) error {
+		res, gasUsed, err = vm.Sudo(code, env, sudoMsg, store, goapi, querier, gasMeter, gasLimit)
+		return err
+	})
+	return
+}
+
+func (e *recoveringWasmEngine) Reply(code wasmvm.Checksum, env wasmvmtypes.Env, reply wasmvmtypes.Reply, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (res *wasmvmtypes.Response, gasUsed uint64, err error) {
+	e.call(func(vm types.WasmerEngine) error {
+		res, gasUsed, err = vm.Reply(code, env, reply, store, goapi, querier, gasMeter, gasLimit)
+		return err
+	})
+	return
+}
+
+func (e *recoveringWasmEngine) GetCode(code wasmvm.Checksum) (res wasmvm.WasmCode, err error) {
+	e.call(func(vm types.WasmerEngine) error {
+		res, err = vm.GetCode(code)
+		return err
+	})
+	return
+}
+
+// Cleanup releases the current VM and the replaced VMs that are still used by calls
+func (e *recoveringWasmEngine) Cleanup() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for vm := range e.retired {
+		delete(e.retired, vm)
+		vm.Cleanup()
+	}
+	e.current.Cleanup()
+}
+
+func (e *recoveringWasmEngine) IBCChannelOpen(code wasmvm.Checksum, env wasmvmtypes.Env, channel wasmvmtypes.IBCChannel, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (gasUsed uint64, err error) {
+	e.call(func(vm types.WasmerEngine) error {
+		gasUsed, err = vm.IBCChannelOpen(code, env, channel, store, goapi, querier, gasMeter, gasLimit)
+		return err
+	})
+	return
+}
+
+func (e *recoveringWasmEngine) IBCChannelConnect(code wasmvm.Checksum, env wasmvmtypes.Env, channel wasmvmtypes.IBCChannel, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (res *wasmvmtypes.IBCBasicResponse, gasUsed uint64, err error) {
+	e.call(func(vm types.WasmerEngine) error {
+		res, gasUsed, err = vm.IBCChannelConnect(code, env, channel, store, goapi, querier, gasMeter, gasLimit)
+		return err
+	})
+	return
+}
+
+func (e *recoveringWasmEngine) IBCChannelClose(code wasmvm.Checksum, env wasmvmtypes.Env, channel wasmvmtypes.IBCChannel, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (res *wasmvmtypes.IBCBasicResponse, gasUsed uint64, err error) {
+	e.call(func(vm types.WasmerEngine) error {
+		res, gasUsed, err = vm.IBCChannelClose(code, env, channel, store, goapi, querier, gasMeter, gasLimit)
+		return err
+	})
+	return
+}
+
+func (e *recoveringWasmEngine) IBCPacketReceive(code wasmvm.Checksum, env wasmvmtypes.Env, packet wasmvmtypes.IBCPacket, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (res *wasmvmtypes.IBCReceiveResponse, gasUsed uint64, err error) {
+	e.call(func(vm types.WasmerEngine) error {
+		res, gasUsed, err = vm.IBCPacketReceive(code, env, packet, store, goapi, querier, gasMeter, gasLimit)
+		return err
+	})
+	return
+}
+
+func (e *recoveringWasmEngine) IBCPacketAck(code wasmvm.Checksum, env wasmvmtypes.Env, ack wasmvmtypes.IBCAcknowledgement, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (res *wasmvmtypes.IBCBasicResponse, gasUsed uint64, err error) {
+	e.call(func(vm types.WasmerEngine) error {
+		res, gasUsed, err = vm.IBCPacketAck(code, env, ack, store, goapi, querier, gasMeter, gasLimit)
+		return err
+	})
+	return
+}
+
+func (e *recoveringWasmEngine) IBCPacketTimeout(code wasmvm.Checksum, env wasmvmtypes.Env, packet wasmvmtypes.IBCPacket, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (res *wasmvmtypes.IBCBasicResponse, gasUsed uint64, err error) {
+	e.call(func(vm types.WasmerEngine) error {
+		res, gasUsed, err = vm.IBCPacketTimeout(code, env, packet, store, goapi, querier, gasMeter, gasLimit)
+		return err
+	})
+	return
+}
+
+// Pin pins the code and keeps track of it so that it can be pinned again on a new VM
+func (e *recoveringWasmEngine) Pin(checksum wasmvm.Checksum) (err error) {
+	e.call(func(vm types.WasmerEngine) error {
+		err = vm.Pin(checksum)
+		return err
+	})
+	if err == nil {
+		e.mu.Lock()
+		e.pinned[string(checksum)] = checksum
+		e.mu.Unlock()
+	}
+	return
+}
+
+// Unpin unpins the code and removes it from tracking
+func (e *recoveringWasmEngine) Unpin(checksum wasmvm.Checksum) (err error) {
+	e.call(func(vm types.WasmerEngine) error {
+		err = vm.Unpin(checksum)
+		return err
+	})
+	if err == nil {
+		e.mu.Lock()
+		delete(e.pinned, string(checksum))
+		e.mu.Unlock()
+	}
+	return
+}
+
+func (e *recoveringWasmEngine) GetMetrics() (m *wasmvmtypes.Metrics, err error) {
+	e.call(func(vm types.WasmerEngine) error {
+		m, err = vm.GetMetrics()
+		return err
+	})
+	return
+}
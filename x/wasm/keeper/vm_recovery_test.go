@@ -0,0 +1,232 @@
+package keeper
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	wasmvm "github.com/CosmWasm/wasmvm"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsFatalVMError(t *testing.T) {
+	specs := map[string]struct {
+		src error
+		exp bool
+	}{
+		"nil":               {},
+		"contract error":    {src: errors.New("Generic error: not authorized")},
+		"out of gas":        {src: wasmvmtypes.OutOfGasError{}},
+		"cache error":       {src: errors.New("Error calling the VM: Cache error: Error opening Wasm file for reading")},
+		"caught panic":      {src: errors.New("Error calling the VM: Caught panic"), exp: true},
+		"integrity error":   {src: errors.New("Hash doesn't match stored data")},
+		"uninitialized ctx": {src: errors.New("Uninitialized Context Data: querier"), exp: true},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, spec.exp, isFatalVMError(spec.src))
+		})
+	}
+}
+
+// recoveryTestVM is a mock VM that records its lifecycle
+type recoveryTestVM struct {
+	*wasmtesting.MockWasmer
+	pinned    []string
+	cleanedUp bool
+}
+
+func newRecoveryTestVM(queryFn func(vm *recoveryTestVM) error) *recoveryTestVM {
+	vm := &recoveryTestVM{}
+	vm.MockWasmer = &wasmtesting.MockWasmer{
+		PinFn: func(checksum wasmvm.Checksum) error {
+			vm.pinned = append(vm.pinned, string(checksum))
+			return nil
+		},
+		QueryFn: func(code wasmvm.Checksum, env wasmvmtypes.Env, queryMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) ([]byte, uint64, error) {
+			return nil, 0, queryFn(vm)
+		},
+		UnpinFn:   func(checksum wasmvm.Checksum) error { return nil },
+		CleanupFn: func() { vm.cleanedUp = true },
+	}
+	return vm
+}
+
+func TestRecoveringWasmEngine(t *testing.T) {
+	var queryErr error
+	var vms []*recoveryTestVM
+	e, err := newRecoveringWasmEngine(func() (types.WasmerEngine, error) {
+		vm := newRecoveryTestVM(func(*recoveryTestVM) error { return queryErr })
+		vms = append(vms, vm)
+		return vm, nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, e.Pin([]byte("pinned")))
+	require.NoError(t, e.Pin([]byte("unpinned")))
+	require.NoError(t, e.Unpin([]byte("unpinned")))
+
+	// contract errors are returned without re-creating the VM
+	queryErr = errors.New("Generic error: not authorized")
+	_, _, err = e.Query(nil, wasmvmtypes.Env{}, nil, nil, wasmvm.GoAPI{}, nil, nil, 0)
+	require.Equal(t, queryErr, err)
+	require.Len(t, vms, 1)
+
+	// internal VM errors are returned and the VM is re-created
+	queryErr = errors.New("Caught panic")
+	_, _, err = e.Query(nil, wasmvmtypes.Env{}, nil, nil, wasmvm.GoAPI{}, nil, nil, 0)
+	require.Equal(t, queryErr, err)
+	require.Len(t, vms, 2)
+	assert.True(t, vms[0].cleanedUp)
+	assert.False(t, vms[1].cleanedUp)
+	assert.Equal(t, []string{"pinned"}, vms[1].pinned)
+
+	// the new VM is used for the next calls
+	queryErr = nil
+	_, _, err = e.Query(nil, wasmvmtypes.Env{}, nil, nil, wasmvm.GoAPI{}, nil, nil, 0)
+	require.NoError(t, err)
+	require.Len(t, vms, 2)
+}
+
+func TestRecoveringWasmEngineReinitializeInterval(t *testing.T) {
+	now := time.Unix(1, 0)
+	var vms []*recoveryTestVM
+	e, err := newRecoveringWasmEngine(func() (types.WasmerEngine, error) {
+		vm := newRecoveryTestVM(func(*recoveryTestVM) error { return errors.New("Caught panic") })
+		vms = append(vms, vm)
+		return vm, nil
+	})
+	require.NoError(t, err)
+	e.now = func() time.Time { return now }
+	query := func() {
+		_, _, err := e.Query(nil, wasmvmtypes.Env{}, nil, nil, wasmvm.GoAPI{}, nil, nil, 0)
+		require.Error(t, err)
+	}
+
+	// first fatal error re-creates the VM
+	query()
+	require.Len(t, vms, 2)
+
+	// within the interval
+	now = now.Add(minReinitializeInterval - time.Nanosecond)
+	query()
+	assert.Len(t, vms, 2)
+
+	// after the interval
+	now = now.Add(time.Nanosecond)
+	query()
+	assert.Len(t, vms, 3)
+}
+
+func TestRecoveringWasmEnginePinsWithoutLock(t *testing.T) {
+	var vms []*recoveryTestVM
+	var e *recoveringWasmEngine
+	var concurrentErr error
+	e, err := newRecoveringWasmEngine(func() (types.WasmerEngine, error) {
+		vm := newRecoveryTestVM(func(*recoveryTestVM) error { return nil })
+		if len(vms) != 0 {
+			// the new VM runs a call on the engine while it pins the codes
+			vm.PinFn = func(checksum wasmvm.Checksum) error {
+				done := make(chan error, 1)
+				go func() {
+					_, _, err := e.Query(nil, wasmvmtypes.Env{}, nil, nil, wasmvm.GoAPI{}, nil, nil, 0)
+					done <- err
+				}()
+				select {
+				case concurrentErr = <-done:
+				case <-time.After(time.Second):
+					concurrentErr = errors.New("call blocked while pinning")
+				}
+				vm.pinned = append(vm.pinned, string(checksum))
+				return nil
+			}
+		}
+		vms = append(vms, vm)
+		return vm, nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, e.Pin([]byte("pinned")))
+	vms[0].QueryFn = func(code wasmvm.Checksum, env wasmvmtypes.Env, queryMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) ([]byte, uint64, error) {
+		if len(vms) == 1 {
+			return nil, 0, errors.New("Caught panic")
+		}
+		return nil, 0, nil
+	}
+
+	// when
+	_, _, err = e.Query(nil, wasmvmtypes.Env{}, nil, nil, wasmvm.GoAPI{}, nil, nil, 0)
+
+	// then
+	require.Error(t, err)
+	require.Len(t, vms, 2)
+	require.NoError(t, concurrentErr)
+	assert.Equal(t, []string{"pinned"}, vms[1].pinned)
+	assert.True(t, vms[0].cleanedUp)
+}
+
+func TestRecoveringWasmEngineReleasesVMAfterNestedCalls(t *testing.T) {
+	var vms []*recoveryTestVM
+	e, err := newRecoveringWasmEngine(func() (types.WasmerEngine, error) {
+		vm := newRecoveryTestVM(func(*recoveryTestVM) error { return nil })
+		vms = append(vms, vm)
+		return vm, nil
+	})
+	require.NoError(t, err)
+	// the first VM fails on a nested call
+	vms[0].QueryFn = func(code wasmvm.Checksum, env wasmvmtypes.Env, queryMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) ([]byte, uint64, error) {
+		if string(code) == "nested" {
+			return nil, 0, errors.New("Caught panic")
+		}
+		_, _, err := e.Query([]byte("nested"), env, nil, nil, goapi, nil, nil, 0)
+		if err == nil {
+			return nil, 0, errors.New("nested call: expected error")
+		}
+		// the VM must not be released while it is still in use
+		if vms[0].cleanedUp {
+			return nil, 0, errors.New("VM released while in use")
+		}
+		return nil, 0, nil
+	}
+
+	// when
+	_, _, err = e.Query([]byte("outer"), wasmvmtypes.Env{}, nil, nil, wasmvm.GoAPI{}, nil, nil, 0)
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, vms, 2)
+	assert.True(t, vms[0].cleanedUp)
+	assert.False(t, vms[1].cleanedUp)
+}
+
+func TestRecoveringWasmEngineCleanupReleasesRetiredVMs(t *testing.T) {
+	var vms []*recoveryTestVM
+	e, err := newRecoveringWasmEngine(func() (types.WasmerEngine, error) {
+		vm := newRecoveryTestVM(func(*recoveryTestVM) error { return nil })
+		vms = append(vms, vm)
+		return vm, nil
+	})
+	require.NoError(t, err)
+	// the first VM is replaced on a nested call and the node shuts down before the outer call returns
+	vms[0].QueryFn = func(code wasmvm.Checksum, env wasmvmtypes.Env, queryMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) ([]byte, uint64, error) {
+		if string(code) == "nested" {
+			return nil, 0, errors.New("Caught panic")
+		}
+		_, _, _ = e.Query([]byte("nested"), env, nil, nil, goapi, nil, nil, 0)
+		e.Cleanup()
+		return nil, 0, nil
+	}
+	var cleanups int
+	vms[0].CleanupFn = func() { cleanups++ }
+
+	// when
+	_, _, err = e.Query([]byte("outer"), wasmvmtypes.Env{}, nil, nil, wasmvm.GoAPI{}, nil, nil, 0)
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, vms, 2)
+	assert.Equal(t, 1, cleanups)
+	assert.True(t, vms[1].cleanedUp)
+}
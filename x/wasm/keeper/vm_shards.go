@@ -0,0 +1,233 @@
+package keeper
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"strconv"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	wasmvm "github.com/CosmWasm/wasmvm"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// newWasmVM creates the wasm VM for the given config. With more than one VM shard configured, the contract calls are
+// distributed over multiple VM instances that share the data directory. The memory cache size is split between them.
+// Each instance is re-created on internal VM errors.
+func newWasmVM(dataDir string, supportedFeatures string, wasmConfig types.WasmConfig) (types.WasmerEngine, error) {
+	newInstance := func(memoryCacheSize uint32) (types.WasmerEngine, error) {
+		return newRecoveringWasmEngine(func() (types.WasmerEngine, error) {
+			return wasmvm.NewVM(dataDir, supportedFeatures, contractMemoryLimit, wasmConfig.ContractDebugMode, memoryCacheSize)
+		})
+	}
+	if wasmConfig.VMShards <= 1 {
+		return newInstance(wasmConfig.MemoryCacheSize)
+	}
+	shards := make([]types.WasmerEngine, wasmConfig.VMShards)
+	for i := range shards {
+		vm, err := newInstance(wasmConfig.MemoryCacheSize / wasmConfig.VMShards)
+		if err != nil {
+			for _, s := range shards[:i] {
+				s.Cleanup()
+			}
+			return nil, err
+		}
+		shards[i] = vm
+	}
+	return NewShardedWasmEngine(shards...), nil
+}
+
+var _ types.WasmerEngine = &ShardedWasmEngine{}
+
+// ShardedWasmEngine distributes the contract calls over multiple wasm VM instances. The instance is selected by
+// the code checksum so that all calls for a code are handled by the same instance and its memory cache.
+// The instances run in the same process. A failure that is contained by the VM, like a cache error, affects only
+// the codes of one instance but a crash of the process affects all.
+type ShardedWasmEngine struct {
+	shards []types.WasmerEngine
+}
+
+// NewShardedWasmEngine constructor. At least one shard is required.
+func NewShardedWasmEngine(shards ...types.WasmerEngine) *ShardedWasmEngine {
+	if len(shards) == 0 {
+		panic("shards must not be empty")
+	}
+	return &ShardedWasmEngine{shards: shards}
+}
+
+// shard returns the VM instance that is responsible for the code
+func (e ShardedWasmEngine) shard(checksum wasmvm.Checksum) types.WasmerEngine {
+	if len(checksum) < 8 {
+		return e.shards[0]
+	}
+	return e.shards[binary.BigEndian.Uint64(checksum[:8])%uint64(len(e.shards))]
+}
+
+func (e ShardedWasmEngine) Create(code wasmvm.WasmCode) (wasmvm.Checksum, error) {
+	// the checksum of the wasm code is the sha256 hash, same as in wasmvm
+	checksum := sha256.Sum256(code)
+	return e.shard(checksum[:]).Create(code)
+}
+
+func (e ShardedWasmEngine) AnalyzeCode(checksum wasmvm.Checksum) (*wasmvmtypes.AnalysisReport, error) {
+	return e.shard(checksum).AnalyzeCode(checksum)
+}
+
+func (e ShardedWasmEngine) Instantiate(code wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, initMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.Response, uint64, error) {
+	return e.shard(code).Instantiate(code, env, info, initMsg, store, goapi, querier, gasMeter, gasLimit)
+}
+
+func (e ShardedWasmEngine) Execute(code wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.Response, uint64, error) {
+	return e.shard(code).Execute(code, env, info, executeMsg, store, goapi, querier, gasMeter, gasLimit)
+}
+
+func (e ShardedWasmEngine) Query(code wasmvm.Checksum, env wasmvmtypes.Env, queryMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) ([]byte, uint64, error) {
+	return e.shard(code).Query(code, env, queryMsg, store, goapi, querier, gasMeter, gasLimit)
+}
+
+func (e ShardedWasmEngine) Migrate(code wasmvm.Checksum, env wasmvmtypes.Env, migrateMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.Response, uint64, error) {
+	return e.shard(code).Migrate(code, env, migrateMsg, store, goapi, querier, gasMeter, gasLimit)
+}
+
+func (e ShardedWasmEngine) Sudo(code wasmvm.Checksum, env wasmvmtypes.Env, sudoMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.Response, uint64, error) {
+	return e.shard(code).Sudo(code, env, sudoMsg, store, goapi, querier, gasMeter, gasLimit)
+}
+
+func (e ShardedWasmEngine) Reply(code wasmvm.Checksum, env wasmvmtypes.Env, reply wasmvmtypes.Reply, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.Response, uint64, error) {
+	return e.shard(code).Reply(code, env, reply, store, goapi, querier, gasMeter, gasLimit)
+}
+
+func (e ShardedWasmEngine) GetCode(code wasmvm.Checksum) (wasmvm.WasmCode, error) {
+	return e.shard(code).GetCode(code)
+}
+
+// Cleanup releases all VM instances
+func (e ShardedWasmEngine) Cleanup() {
+	for _, s := range e.shards {
+		s.Cleanup()
+	}
+}
+
+func (e ShardedWasmEngine) IBCChannelOpen(code wasmvm.Checksum, env wasmvmtypes.Env, channel wasmvmtypes.IBCChannel, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (uint64, error) {
+	return e.shard(code).IBCChannelOpen(code, env, channel, store, goapi, querier, gasMeter, gasLimit)
+}
+
+func (e ShardedWasmEngine) IBCChannelConnect(code wasmvm.Checksum, env wasmvmtypes.Env, channel wasmvmtypes.IBCChannel, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.IBCBasicResponse, uint64, error) {
+	return e.shard(code).IBCChannelConnect(code, env, channel, store, goapi, querier, gasMeter, gasLimit)
+}
+
+func (e ShardedWasmEngine) IBCChannelClose(code wasmvm.Checksum, env wasmvmtypes.Env, channel wasmvmtypes.IBCChannel, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.IBCBasicResponse, uint64, error) {
+	return e.shard(code).IBCChannelClose(code, env, channel, store, goapi, querier, gasMeter, gasLimit)
+}
+
+func (e ShardedWasmEngine) IBCPacketReceive(code wasmvm.Checksum, env wasmvmtypes.Env, packet wasmvmtypes.IBCPacket, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.IBCReceiveResponse, uint64, error) {
+	return e.shard(code).IBCPacketReceive(code, env, packet, store, goapi, querier, gasMeter, gasLimit)
+}
+
+func (e ShardedWasmEngine) IBCPacketAck(code wasmvm.Checksum, env wasmvmtypes.Env, ack wasmvmtypes.IBCAcknowledgement, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.IBCBasicResponse, uint64, error) {
+	return e.shard(code).IBCPacketAck(code, env, ack, store, goapi, querier, gasMeter, gasLimit)
+}
+
+func (e ShardedWasmEngine) IBCPacketTimeout(code wasmvm.Checksum, env wasmvmtypes.Env, packet wasmvmtypes.IBCPacket, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.IBCBasicResponse, uint64, error) {
+	return e.shard(code).IBCPacketTimeout(code, env, packet, store, goapi, querier, gasMeter, gasLimit)
+}
+
+func (e ShardedWasmEngine) Pin(checksum wasmvm.Checksum) error {
+	return e.shard(checksum).Pin(checksum)
+}
+
+func (e ShardedWasmEngine) Unpin(checksum wasmvm.Checksum) error {
+	return e.shard(checksum).Unpin(checksum)
+}
+
+// GetMetrics returns the sum of the metrics of all shards
+func (e ShardedWasmEngine) GetMetrics() (*wasmvmtypes.Metrics, error) {
+	all, err := e.ShardMetrics()
+	if err != nil {
+		return nil, err
+	}
+	var r wasmvmtypes.Metrics
+	for _, m := range all {
+		r.HitsPinnedMemoryCache += m.HitsPinnedMemoryCache
+		r.HitsMemoryCache += m.HitsMemoryCache
+		r.HitsFsCache += m.HitsFsCache
+		r.Misses += m.Misses
+		r.ElementsPinnedMemoryCache += m.ElementsPinnedMemoryCache
+		r.ElementsMemoryCache += m.ElementsMemoryCache
+		r.SizePinnedMemoryCache += m.SizePinnedMemoryCache
+		r.SizeMemoryCache += m.SizeMemoryCache
+	}
+	return &r, nil
+}
+
+// ShardMetrics returns the metrics for each shard
+func (e ShardedWasmEngine) ShardMetrics() ([]*wasmvmtypes.Metrics, error) {
+	r := make([]*wasmvmtypes.Metrics, len(e.shards))
+	for i, s := range e.shards {
+		m, err := s.GetMetrics()
+		if err != nil {
+			return nil, err
+		}
+		r[i] = m
+	}
+	return r, nil
+}
+
+// shardMetricSource source of wasmvm metrics per shard
+type shardMetricSource interface {
+	ShardMetrics() ([]*wasmvmtypes.Metrics, error)
+}
+
+var _ prometheus.Collector = (*WasmVMShardMetricsCollector)(nil)
+
+// WasmVMShardMetricsCollector custom metrics collector for the wasmvm cache metrics per shard to be used with Prometheus
+type WasmVMShardMetricsCollector struct {
+	source             shardMetricSource
+	CacheHitsDescr     *prometheus.Desc
+	CacheMissesDescr   *prometheus.Desc
+	CacheElementsDescr *prometheus.Desc
+	CacheSizeDescr     *prometheus.Desc
+}
+
+// NewWasmVMShardMetricsCollector constructor
+func NewWasmVMShardMetricsCollector(s shardMetricSource) *WasmVMShardMetricsCollector {
+	return &WasmVMShardMetricsCollector{
+		source:             s,
+		CacheHitsDescr:     prometheus.NewDesc("wasmvm_shard_cache_hits_total", "Total number of cache hits per shard", []string{"shard", "type"}, nil),
+		CacheMissesDescr:   prometheus.NewDesc("wasmvm_shard_cache_misses_total", "Total number of cache misses per shard", []string{"shard"}, nil),
+		CacheElementsDescr: prometheus.NewDesc("wasmvm_shard_cache_elements_total", "Total number of elements in the cache per shard", []string{"shard", "type"}, nil),
+		CacheSizeDescr:     prometheus.NewDesc("wasmvm_shard_cache_size_bytes", "Total size of the elements in the cache per shard", []string{"shard", "type"}, nil),
+	}
+}
+
+// Register registers all metrics
+func (p *WasmVMShardMetricsCollector) Register(r prometheus.Registerer) {
+	r.MustRegister(p)
+}
+
+// Describe sends the super-set of all possible descriptors of metrics
+func (p *WasmVMShardMetricsCollector) Describe(descs chan<- *prometheus.Desc) {
+	descs <- p.CacheHitsDescr
+	descs <- p.CacheMissesDescr
+	descs <- p.CacheElementsDescr
+	descs <- p.CacheSizeDescr
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (p *WasmVMShardMetricsCollector) Collect(c chan<- prometheus.Metric) {
+	all, err := p.source.ShardMetrics()
+	if err != nil {
+		return
+	}
+	for i, m := range all {
+		shard := strconv.Itoa(i)
+		c <- prometheus.MustNewConstMetric(p.CacheHitsDescr, prometheus.CounterValue, float64(m.HitsPinnedMemoryCache), shard, labelPinned)
+		c <- prometheus.MustNewConstMetric(p.CacheHitsDescr, prometheus.CounterValue, float64(m.HitsMemoryCache), shard, labelMemory)
+		c <- prometheus.MustNewConstMetric(p.CacheHitsDescr, prometheus.CounterValue, float64(m.HitsFsCache), shard, labelFs)
+		c <- prometheus.MustNewConstMetric(p.CacheMissesDescr, prometheus.CounterValue, float64(m.Misses), shard)
+		c <- prometheus.MustNewConstMetric(p.CacheElementsDescr, prometheus.GaugeValue, float64(m.ElementsPinnedMemoryCache), shard, labelPinned)
+		c <- prometheus.MustNewConstMetric(p.CacheElementsDescr, prometheus.GaugeValue, float64(m.ElementsMemoryCache), shard, labelMemory)
+		c <- prometheus.MustNewConstMetric(p.CacheSizeDescr, prometheus.GaugeValue, float64(m.SizeMemoryCache), shard, labelMemory)
+		c <- prometheus.MustNewConstMetric(p.CacheSizeDescr, prometheus.GaugeValue, float64(m.SizePinnedMemoryCache), shard, labelPinned)
+	}
+}
@@ -0,0 +1,107 @@
+package keeper
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"testing"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+	wasmvm "github.com/CosmWasm/wasmvm"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+)
+
+func TestShardedWasmEngineRouting(t *testing.T) {
+	var calls [2][]string
+	newShard := func(i int) *wasmtesting.MockWasmer {
+		return &wasmtesting.MockWasmer{
+			CreateFn: func(code wasmvm.WasmCode) (wasmvm.Checksum, error) {
+				calls[i] = append(calls[i], "create")
+				checksum := sha256.Sum256(code)
+				return checksum[:], nil
+			},
+			PinFn: func(checksum wasmvm.Checksum) error {
+				calls[i] = append(calls[i], "pin")
+				return nil
+			},
+			QueryFn: func(code wasmvm.Checksum, env wasmvmtypes.Env, queryMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) ([]byte, uint64, error) {
+				calls[i] = append(calls[i], "query")
+				return nil, 0, nil
+			},
+		}
+	}
+	e := NewShardedWasmEngine(newShard(0), newShard(1))
+
+	// find codes that map to each shard
+	codes := make(map[uint64][]byte)
+	for i := byte(0); len(codes) < 2; i++ {
+		code := []byte{i}
+		checksum := sha256.Sum256(code)
+		shard := uint64(checksum[7]) % 2
+		if _, exists := codes[shard]; !exists {
+			codes[shard] = code
+		}
+	}
+	for shard := uint64(0); shard < 2; shard++ {
+		checksum, err := e.Create(codes[shard])
+		require.NoError(t, err)
+		require.NoError(t, e.Pin(checksum))
+		_, _, err = e.Query(checksum, wasmvmtypes.Env{}, nil, nil, wasmvm.GoAPI{}, nil, nil, 0)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []string{"create", "pin", "query"}, calls[0])
+	assert.Equal(t, []string{"create", "pin", "query"}, calls[1])
+}
+
+func TestShardedWasmEngineMetrics(t *testing.T) {
+	newShard := func(m wasmvmtypes.Metrics) *wasmtesting.MockWasmer {
+		return &wasmtesting.MockWasmer{GetMetricsFn: func() (*wasmvmtypes.Metrics, error) {
+			return &m, nil
+		}}
+	}
+	e := NewShardedWasmEngine(
+		newShard(wasmvmtypes.Metrics{HitsMemoryCache: 1, Misses: 2, ElementsMemoryCache: 3, SizeMemoryCache: 4}),
+		newShard(wasmvmtypes.Metrics{HitsMemoryCache: 10, HitsPinnedMemoryCache: 20, ElementsPinnedMemoryCache: 30, SizePinnedMemoryCache: 40}),
+	)
+	got, err := e.GetMetrics()
+	require.NoError(t, err)
+	exp := &wasmvmtypes.Metrics{
+		HitsPinnedMemoryCache:     20,
+		HitsMemoryCache:           11,
+		Misses:                    2,
+		ElementsPinnedMemoryCache: 30,
+		ElementsMemoryCache:       3,
+		SizePinnedMemoryCache:     40,
+		SizeMemoryCache:           4,
+	}
+	assert.Equal(t, exp, got)
+
+	perShard, err := e.ShardMetrics()
+	require.NoError(t, err)
+	require.Len(t, perShard, 2)
+	assert.Equal(t, uint32(1), perShard[0].HitsMemoryCache)
+	assert.Equal(t, uint32(10), perShard[1].HitsMemoryCache)
+}
+
+func TestKeeperWithVMShards(t *testing.T) {
+	wasmConfig := types.DefaultWasmConfig()
+	wasmConfig.VMShards = 2
+	ctx, keepers := createTestInput(t, false, SupportedFeatures, wasmConfig, dbm.NewMemDB())
+	require.IsType(t, &ShardedWasmEngine{}, keepers.WasmKeeper.wasmVM)
+
+	example := InstantiateHackatomExampleContract(t, ctx, keepers)
+	require.NoError(t, keepers.ContractKeeper.PinCode(ctx, example.CodeID))
+
+	rsp, err := keepers.WasmKeeper.QuerySmart(ctx, example.Contract, []byte(`{"verifier":{}}`))
+	require.NoError(t, err)
+	var res map[string]string
+	require.NoError(t, json.Unmarshal(rsp, &res))
+	assert.Equal(t, example.VerifierAddr.String(), res["verifier"])
+
+	_, err = keepers.ContractKeeper.Execute(ctx, example.Contract, example.VerifierAddr, []byte(`{"release":{}}`), nil)
+	require.NoError(t, err)
+	assert.True(t, keepers.BankKeeper.GetAllBalances(ctx, example.Contract).IsZero())
+}
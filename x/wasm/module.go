@@ -16,6 +16,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/server"
 	servertypes "github.com/cosmos/cosmos-sdk/server/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/cosmos-sdk/types/module"
 	simtypes "github.com/cosmos/cosmos-sdk/types/simulation"
 	"github.com/gorilla/mux"
@@ -32,8 +33,22 @@ var (
 
 // Module init related flags
 const (
-	flagWasmMemoryCacheSize = "wasm.memory_cache_size"
-	flagWasmQueryGasLimit   = "wasm.query_gas_limit"
+	flagWasmMemoryCacheSize  = "wasm.memory_cache_size"
+	flagWasmQueryGasLimit    = "wasm.query_gas_limit"
+	flagWasmStoreAuditLog    = "wasm.store_audit_log_size"
+	flagWasmQueryConcurrency = "wasm.query_concurrency"
+	flagWasmQueryQueueSize   = "wasm.query_queue_size"
+	flagWasmCacheStatusQuery = "wasm.cache_status_query"
+	flagWasmVMShards         = "wasm.vm_shards"
+	flagWasmLegacyProtoJSON  = "wasm.legacy_querier_proto_json"
+	flagWasmAdminGRPCAddress = "wasm.admin_grpc_address"
+	flagWasmAdminGRPCToken   = "wasm.admin_grpc_token"
+	flagWasmContractTxIndex  = "wasm.contract_tx_index"
+	flagWasmAcceptVMUpgrade  = "wasm.accept_vm_upgrade"
+	flagWasmSimulateGasLimit = "wasm.simulate_execute_gas_limit"
+	flagWasmSimulateRate     = "wasm.simulate_execute_rate"
+	// flagTxIndexer is the tendermint config key of the tx indexer
+	flagTxIndexer = "tx_index.indexer"
 )
 
 // AppModuleBasic defines the basic application module used by the wasm module.
@@ -98,6 +113,8 @@ type AppModule struct {
 	cdc                codec.Marshaler
 	keeper             *Keeper
 	validatorSetSource keeper.ValidatorSetSource
+	// authZPolicy is optional and guards the wasm messages instead of the default authorization policy
+	authZPolicy keeper.AuthorizationPolicy
 }
 
 // NewAppModule creates a new AppModule object
@@ -110,21 +127,52 @@ func NewAppModule(cdc codec.Marshaler, keeper *Keeper, validatorSetSource keeper
 	}
 }
 
+// NewPermissionedAppModule creates a new AppModule object for permissioned chains. The MsgStoreCode and
+// MsgInstantiateContract messages are rejected so that code can only be stored and contracts can only be
+// instantiated via governance proposals.
+func NewPermissionedAppModule(cdc codec.Marshaler, k *Keeper, validatorSetSource keeper.ValidatorSetSource) AppModule {
+	am := NewAppModule(cdc, k, validatorSetSource)
+	am.authZPolicy = keeper.GovOnlyAuthorizationPolicy{}
+	return am
+}
+
+// contractKeeper returns the keeper to handle the wasm messages
+func (am AppModule) contractKeeper() *keeper.PermissionedKeeper {
+	if am.authZPolicy == nil {
+		return keeper.NewDefaultPermissionKeeper(am.keeper)
+	}
+	return keeper.NewPermissionedKeeper(am.keeper, am.authZPolicy)
+}
+
 func (am AppModule) RegisterServices(cfg module.Configurator) {
-	types.RegisterMsgServer(cfg.MsgServer(), keeper.NewMsgServerImpl(keeper.NewDefaultPermissionKeeper(am.keeper)))
+	types.RegisterMsgServer(cfg.MsgServer(), keeper.NewMsgServerImpl(am.contractKeeper()))
 	types.RegisterQueryServer(cfg.QueryServer(), NewQuerier(am.keeper))
 }
 
+// LegacyQuerierHandler returns the querier for the legacy ABCI query routes.
+//
+// Deprecated: use the gRPC Query service registered in RegisterServices.
 func (am AppModule) LegacyQuerierHandler(amino *codec.LegacyAmino) sdk.Querier {
+	if am.keeper.LegacyQuerierProtoJSON() {
+		return keeper.NewProtoJSONLegacyQuerier(am.keeper, am.keeper.QueryGasLimit(), am.cdc)
+	}
 	return keeper.NewLegacyQuerier(am.keeper, am.keeper.QueryGasLimit())
 }
 
+// ConsensusVersion is the version of the wasm module state.
+func (AppModule) ConsensusVersion() uint64 {
+	return types.ModuleConsensusVersion
+}
+
 // RegisterInvariants registers the wasm module invariants.
 func (am AppModule) RegisterInvariants(ir sdk.InvariantRegistry) {}
 
 // Route returns the message routing key for the wasm module.
+//
+// Deprecated: messages are routed to the Msg service registered in RegisterServices. This is kept for legacy
+// messages only and dispatches to the same Msg service implementation.
 func (am AppModule) Route() sdk.Route {
-	return sdk.NewRoute(RouterKey, NewHandler(keeper.NewDefaultPermissionKeeper(am.keeper)))
+	return sdk.NewRoute(RouterKey, NewHandler(am.contractKeeper()))
 }
 
 // QuerierRoute returns the wasm module's querier route name.
@@ -154,9 +202,15 @@ func (am AppModule) ExportGenesis(ctx sdk.Context, cdc codec.JSONMarshaler) json
 // BeginBlock returns the begin blocker for the wasm module.
 func (am AppModule) BeginBlock(_ sdk.Context, _ abci.RequestBeginBlock) {}
 
-// EndBlock returns the end blocker for the wasm module. It returns no validator
-// updates.
-func (AppModule) EndBlock(_ sdk.Context, _ abci.RequestEndBlock) []abci.ValidatorUpdate {
+// EndBlock returns the end blocker for the wasm module. It notifies the contracts
+// about frozen IBC clients and ordered channels closed by a timeout, refunds the due store code deposits, queues the
+// pruning of old code execution statistics, continues the pending jobs and returns no validator updates.
+func (am AppModule) EndBlock(ctx sdk.Context, _ abci.RequestEndBlock) []abci.ValidatorUpdate {
+	am.keeper.NotifyFrozenClients(ctx)
+	am.keeper.NotifyTimeoutClosedChannels(ctx)
+	am.keeper.RefundStoreCodeDeposits(ctx)
+	am.keeper.QueueCodeStatsPruning(ctx)
+	am.keeper.ProcessJobs(ctx)
 	return []abci.ValidatorUpdate{}
 }
 
@@ -195,6 +249,18 @@ func AddModuleInitFlags(startCmd *cobra.Command) {
 	defaults := DefaultWasmConfig()
 	startCmd.Flags().Uint32(flagWasmMemoryCacheSize, defaults.MemoryCacheSize, "Sets the size in MiB (NOT bytes) of an in-memory cache for Wasm modules. Set to 0 to disable.")
 	startCmd.Flags().Uint64(flagWasmQueryGasLimit, defaults.SmartQueryGasLimit, "Set the max gas that can be spent on executing a query with a Wasm contract")
+	startCmd.Flags().Uint32(flagWasmStoreAuditLog, defaults.StoreAuditLogSize, "Number of contract executions in blocks for which the store reads and writes are kept in memory for the debug query. Set to 0 to disable.")
+	startCmd.Flags().Uint32(flagWasmQueryConcurrency, defaults.QueryConcurrency, "Max number of smart queries that are executed in parallel by the gRPC query server. Set to 0 to disable.")
+	startCmd.Flags().Uint32(flagWasmQueryQueueSize, defaults.QueryQueueSize, "Max number of smart queries that wait for execution when the query concurrency is reached")
+	startCmd.Flags().Bool(flagWasmCacheStatusQuery, defaults.CacheStatusQuery, "Enable the operator query for the pinned codes and the wasm VM cache metrics")
+	startCmd.Flags().Uint32(flagWasmVMShards, defaults.VMShards, "Number of wasm VM instances that the contract calls are distributed over. The memory cache size is split between them and must be at least 1 MiB per instance.")
+	startCmd.Flags().Bool(flagWasmLegacyProtoJSON, defaults.LegacyQuerierProtoJSON, "Return the gRPC query response types encoded as proto JSON from the legacy querier")
+	startCmd.Flags().String(flagWasmAdminGRPCAddress, defaults.AdminGRPCAddress, "Loopback address of the node local admin gRPC server to adjust the smart query gas limit at runtime. Empty disables the server.")
+	startCmd.Flags().String(flagWasmAdminGRPCToken, defaults.AdminGRPCToken, "Token that the admin gRPC server requires as 'authorization: Bearer <token>' metadata")
+	startCmd.Flags().Bool(flagWasmContractTxIndex, defaults.ContractTxIndex, "Fail on start when the node does not index the contract events that the contract txs query uses")
+	startCmd.Flags().Bool(flagWasmAcceptVMUpgrade, defaults.AcceptVMUpgrade, "Accept a wasmvm version that is different from the one the wasm data directory was used with. Not required when the binary is switched at a software upgrade height.")
+	startCmd.Flags().Uint64(flagWasmSimulateGasLimit, defaults.SimulateExecuteGasLimit, "Max gas of the unauthenticated execute simulation query for fee estimation. Set to 0 to disable the query.")
+	startCmd.Flags().Uint32(flagWasmSimulateRate, defaults.SimulateExecuteRate, "Max number of execute simulations per second. Set to 0 to disable the limit.")
 }
 
 // ReadWasmConfig reads the wasm specifig configuration
@@ -211,6 +277,75 @@ func ReadWasmConfig(opts servertypes.AppOptions) (types.WasmConfig, error) {
 			return cfg, err
 		}
 	}
+	if v := opts.Get(flagWasmStoreAuditLog); v != nil {
+		if cfg.StoreAuditLogSize, err = cast.ToUint32E(v); err != nil {
+			return cfg, err
+		}
+	}
+	if v := opts.Get(flagWasmQueryConcurrency); v != nil {
+		if cfg.QueryConcurrency, err = cast.ToUint32E(v); err != nil {
+			return cfg, err
+		}
+	}
+	if v := opts.Get(flagWasmQueryQueueSize); v != nil {
+		if cfg.QueryQueueSize, err = cast.ToUint32E(v); err != nil {
+			return cfg, err
+		}
+	}
+	if v := opts.Get(flagWasmCacheStatusQuery); v != nil {
+		if cfg.CacheStatusQuery, err = cast.ToBoolE(v); err != nil {
+			return cfg, err
+		}
+	}
+	if v := opts.Get(flagWasmVMShards); v != nil {
+		if cfg.VMShards, err = cast.ToUint32E(v); err != nil {
+			return cfg, err
+		}
+	}
+	if v := opts.Get(flagWasmLegacyProtoJSON); v != nil {
+		if cfg.LegacyQuerierProtoJSON, err = cast.ToBoolE(v); err != nil {
+			return cfg, err
+		}
+	}
+	if v := opts.Get(flagWasmAdminGRPCAddress); v != nil {
+		if cfg.AdminGRPCAddress, err = cast.ToStringE(v); err != nil {
+			return cfg, err
+		}
+	}
+	if v := opts.Get(flagWasmAdminGRPCToken); v != nil {
+		if cfg.AdminGRPCToken, err = cast.ToStringE(v); err != nil {
+			return cfg, err
+		}
+	}
+	if v := opts.Get(flagWasmContractTxIndex); v != nil {
+		if cfg.ContractTxIndex, err = cast.ToBoolE(v); err != nil {
+			return cfg, err
+		}
+	}
+	if v := opts.Get(flagWasmAcceptVMUpgrade); v != nil {
+		if cfg.AcceptVMUpgrade, err = cast.ToBoolE(v); err != nil {
+			return cfg, err
+		}
+	}
+	if v := opts.Get(flagWasmSimulateGasLimit); v != nil {
+		if cfg.SimulateExecuteGasLimit, err = cast.ToUint64E(v); err != nil {
+			return cfg, err
+		}
+	}
+	if v := opts.Get(flagWasmSimulateRate); v != nil {
+		if cfg.SimulateExecuteRate, err = cast.ToUint32E(v); err != nil {
+			return cfg, err
+		}
+	}
+	// the memory cache is split between the shards and each shard needs at least 1 MiB
+	if cfg.VMShards > 1 && cfg.MemoryCacheSize != 0 && cfg.VMShards > cfg.MemoryCacheSize {
+		return cfg, sdkerrors.Wrapf(types.ErrInvalid, "%d vm shards exceed the memory cache size of %d MiB", cfg.VMShards, cfg.MemoryCacheSize)
+	}
+	if cfg.ContractTxIndex {
+		if err := validateContractTxIndex(opts); err != nil {
+			return cfg, err
+		}
+	}
 	// attach contract debugging to global "trace" flag
 	if v := opts.Get(server.FlagTrace); v != nil {
 		if cfg.ContractDebugMode, err = cast.ToBoolE(v); err != nil {
@@ -219,3 +354,34 @@ func ReadWasmConfig(opts servertypes.AppOptions) (types.WasmConfig, error) {
 	}
 	return cfg, nil
 }
+
+// validateContractTxIndex ensures that the node indexes the contract events that the txs of a contract are
+// searched by. All events are indexed when no index events are configured.
+func validateContractTxIndex(opts servertypes.AppOptions) error {
+	if v := opts.Get(flagTxIndexer); v != nil {
+		indexer, err := cast.ToStringE(v)
+		if err != nil {
+			return err
+		}
+		if indexer != "kv" {
+			return sdkerrors.Wrapf(types.ErrInvalid, "contract tx index requires the kv tx indexer, got %q", indexer)
+		}
+	}
+	v := opts.Get(server.FlagIndexEvents)
+	if v == nil {
+		return nil
+	}
+	indexEvents, err := cast.ToStringSliceE(v)
+	if err != nil {
+		return err
+	}
+	if len(indexEvents) == 0 {
+		return nil
+	}
+	for _, e := range indexEvents {
+		if e == types.EventKeyContractAddr {
+			return nil
+		}
+	}
+	return sdkerrors.Wrapf(types.ErrInvalid, "contract tx index requires %q in %s", types.EventKeyContractAddr, server.FlagIndexEvents)
+}
@@ -9,11 +9,14 @@ import (
 	"github.com/CosmWasm/wasmd/x/wasm/keeper"
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/cosmos-sdk/types/module"
 	authkeeper "github.com/cosmos/cosmos-sdk/x/auth/keeper"
 	bankkeeper "github.com/cosmos/cosmos-sdk/x/bank/keeper"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	stakingkeeper "github.com/cosmos/cosmos-sdk/x/staking/keeper"
 	"github.com/dvsekhvalnov/jose2go/base64url"
+	"github.com/gogo/protobuf/proto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -170,6 +173,7 @@ func TestHandleInstantiate(t *testing.T) {
 	initCmd := MsgInstantiateContract{
 		Sender:  creator.String(),
 		CodeID:  firstCodeID,
+		Label:   "demo contract",
 		InitMsg: initMsgBz,
 		Funds:   nil,
 	}
@@ -184,6 +188,11 @@ func TestHandleInstantiate(t *testing.T) {
 	assertAttribute(t, "contract_address", contractBech32Addr, res.Events[0].Attributes[0])
 	assert.Equal(t, "message", res.Events[1].Type)
 	assertAttribute(t, "module", "wasm", res.Events[1].Attributes[0])
+	assertAttribute(t, "code_id", "1", res.Events[1].Attributes[2])
+	assertAttribute(t, "contract_address", contractBech32Addr, res.Events[1].Attributes[3])
+	assertAttribute(t, "label", "demo contract", res.Events[1].Attributes[5])
+	assertAttribute(t, "admin", "", res.Events[1].Attributes[6])
+	assertAttribute(t, "immutable", "true", res.Events[1].Attributes[7])
 
 	assertCodeList(t, q, data.ctx, 1)
 	assertCodeBytes(t, q, data.ctx, 1, testContract)
@@ -235,13 +244,15 @@ func TestHandleExecute(t *testing.T) {
 	contractBech32Addr := parseInitResponse(t, res.Data)
 
 	require.Equal(t, "cosmos18vd8fpwxzck93qlwghaj6arh4p7c5n89uzcee5", contractBech32Addr)
-	// this should be standard x/wasm init event, plus a bank send event (2), with no custom contract events
-	require.Equal(t, 3, len(res.Events), prettyEvents(res.Events))
+	// this should be standard x/wasm init event, plus a bank send and instantiate funds event (2), with no custom contract events
+	require.Equal(t, 4, len(res.Events), prettyEvents(res.Events))
 	assert.Equal(t, "transfer", res.Events[0].Type)
-	assert.Equal(t, "wasm", res.Events[1].Type)
-	assertAttribute(t, "contract_address", contractBech32Addr, res.Events[1].Attributes[0])
-	assert.Equal(t, "message", res.Events[2].Type)
-	assertAttribute(t, "module", "wasm", res.Events[2].Attributes[0])
+	assert.Equal(t, "instantiate_funds", res.Events[1].Type)
+	assertAttribute(t, "module", "wasm", res.Events[1].Attributes[0])
+	assert.Equal(t, "wasm", res.Events[2].Type)
+	assertAttribute(t, "contract_address", contractBech32Addr, res.Events[2].Attributes[0])
+	assert.Equal(t, "message", res.Events[3].Type)
+	assertAttribute(t, "module", "wasm", res.Events[3].Attributes[0])
 
 	// ensure bob doesn't exist
 	bobAcct := data.acctKeeper.GetAccount(data.ctx, bob)
@@ -382,6 +393,112 @@ func TestHandleExecuteEscrow(t *testing.T) {
 	assert.Equal(t, sdk.Coins(nil), data.bankKeeper.GetAllBalances(data.ctx, contractAcct.GetAddress()))
 }
 
+func TestPermissionedAppModule(t *testing.T) {
+	data := setupTest(t)
+	data.module = NewPermissionedAppModule(keeper.MakeTestCodec(t), &data.keeper, data.stakingKeeper)
+
+	deposit := sdk.NewCoins(sdk.NewInt64Coin("denom", 100000))
+	creator := createFakeFundedAccount(t, data.ctx, data.acctKeeper, data.bankKeeper, deposit)
+	h := data.module.Route().Handler()
+
+	// store code and instantiate are rejected
+	_, err := h(data.ctx, &MsgStoreCode{
+		Sender:       creator.String(),
+		WASMByteCode: testContract,
+	})
+	require.True(t, sdkerrors.ErrUnauthorized.Is(err), "got %+v", err)
+
+	// but allowed via governance
+	govKeeper := keeper.NewGovPermissionKeeper(data.keeper)
+	codeID, err := govKeeper.Create(data.ctx, creator, testContract, "", "", nil)
+	require.NoError(t, err)
+
+	_, _, bob := keyPubAddr()
+	initMsgBz, err := json.Marshal(initMsg{Verifier: creator, Beneficiary: bob})
+	require.NoError(t, err)
+	_, err = h(data.ctx, &MsgInstantiateContract{
+		Sender:  creator.String(),
+		CodeID:  codeID,
+		Label:   "demo contract",
+		InitMsg: initMsgBz,
+	})
+	require.True(t, sdkerrors.ErrUnauthorized.Is(err), "got %+v", err)
+
+	contractAddr, _, err := govKeeper.Instantiate(data.ctx, codeID, creator, nil, initMsgBz, "demo contract", nil)
+	require.NoError(t, err)
+
+	// the contract can be executed
+	_, err = h(data.ctx, &MsgExecuteContract{
+		Sender:   creator.String(),
+		Contract: contractAddr.String(),
+		Msg:      []byte(`{"release":{}}`),
+	})
+	require.NoError(t, err)
+}
+
+func TestHandleInstantiateAdminIsSender(t *testing.T) {
+	data := setupTest(t)
+	creator := createFakeFundedAccount(t, data.ctx, data.acctKeeper, data.bankKeeper, sdk.NewCoins(sdk.NewInt64Coin("denom", 100000)))
+	h := data.module.Route().Handler()
+
+	_, err := h(data.ctx, &MsgStoreCode{
+		Sender:       creator.String(),
+		WASMByteCode: testContract,
+	})
+	require.NoError(t, err)
+
+	_, _, bob := keyPubAddr()
+	initMsgBz, err := json.Marshal(initMsg{Verifier: creator, Beneficiary: bob})
+	require.NoError(t, err)
+
+	res, err := h(data.ctx, &MsgInstantiateContract{
+		Sender:        creator.String(),
+		CodeID:        firstCodeID,
+		Label:         "demo contract",
+		InitMsg:       initMsgBz,
+		AdminIsSender: true,
+	})
+	require.NoError(t, err)
+	contractAddr, err := sdk.AccAddressFromBech32(parseInitResponse(t, res.Data))
+	require.NoError(t, err)
+
+	info := data.keeper.GetContractInfo(data.ctx, contractAddr)
+	require.NotNil(t, info)
+	assert.Equal(t, creator.String(), info.Admin)
+	assertAttribute(t, "immutable", "false", res.Events[1].Attributes[7])
+}
+
+func TestLegacyRouteCoversMsgService(t *testing.T) {
+	routes := make(legacyMsgRoutes)
+	types.RegisterMsgServer(routes, keeper.NewMsgServerImpl(nil))
+
+	exp := []proto.Message{
+		&MsgStoreCode{},
+		&MsgInstantiateContract{},
+		&MsgExecuteContract{},
+		&MsgMigrateContract{},
+		&MsgUpdateAdmin{},
+		&MsgClearAdmin{},
+		&MsgUpdateDeniedDenoms{},
+		&types.MsgUpdateReentrancyGuard{},
+		&types.MsgRegisterInterchainQuery{},
+		&types.MsgRemoveInterchainQuery{},
+		&types.MsgSubmitInterchainQueryResult{},
+		&types.MsgReopenChannel{},
+	}
+	for _, msg := range exp {
+		assert.Contains(t, routes, proto.MessageName(msg))
+	}
+	assert.Len(t, routes, len(exp))
+}
+
+func TestHandleUnknownMsg(t *testing.T) {
+	data := setupTest(t)
+	h := data.module.Route().Handler()
+	_, err := h(data.ctx, &banktypes.MsgSend{})
+	require.True(t, sdkerrors.ErrUnknownRequest.Is(err), "got %+v", err)
+}
+
 func TestReadWasmConfig(t *testing.T) {
 	defaults := DefaultWasmConfig()
 	specs := map[string]struct {
@@ -406,6 +523,72 @@ func TestReadWasmConfig(t *testing.T) {
 				SmartQueryGasLimit: defaults.SmartQueryGasLimit,
 			},
 		},
+		"set query concurrency via opts": {
+			src: AppOptionsMock{
+				"wasm.query_concurrency": 4,
+				"wasm.query_queue_size":  8,
+			},
+			exp: types.WasmConfig{
+				SmartQueryGasLimit: defaults.SmartQueryGasLimit,
+				MemoryCacheSize:    defaults.MemoryCacheSize,
+				QueryConcurrency:   4,
+				QueryQueueSize:     8,
+			},
+		},
+		"enable cache status query via opts": {
+			src: AppOptionsMock{
+				"wasm.cache_status_query": true,
+			},
+			exp: types.WasmConfig{
+				SmartQueryGasLimit: defaults.SmartQueryGasLimit,
+				MemoryCacheSize:    defaults.MemoryCacheSize,
+				CacheStatusQuery:   true,
+			},
+		},
+		"set admin grpc server via opts": {
+			src: AppOptionsMock{
+				"wasm.admin_grpc_address": "127.0.0.1:9095",
+				"wasm.admin_grpc_token":   "my-token",
+			},
+			exp: types.WasmConfig{
+				SmartQueryGasLimit: defaults.SmartQueryGasLimit,
+				MemoryCacheSize:    defaults.MemoryCacheSize,
+				AdminGRPCAddress:   "127.0.0.1:9095",
+				AdminGRPCToken:     "my-token",
+			},
+		},
+		"set vm shards via opts": {
+			src: AppOptionsMock{
+				"wasm.vm_shards": 4,
+			},
+			exp: types.WasmConfig{
+				SmartQueryGasLimit: defaults.SmartQueryGasLimit,
+				MemoryCacheSize:    defaults.MemoryCacheSize,
+				VMShards:           4,
+			},
+		},
+		"set legacy querier proto json via opts": {
+			src: AppOptionsMock{
+				"wasm.legacy_querier_proto_json": true,
+			},
+			exp: types.WasmConfig{
+				SmartQueryGasLimit:     defaults.SmartQueryGasLimit,
+				MemoryCacheSize:        defaults.MemoryCacheSize,
+				LegacyQuerierProtoJSON: true,
+			},
+		},
+		"set execute simulation via opts": {
+			src: AppOptionsMock{
+				"wasm.simulate_execute_gas_limit": 500000,
+				"wasm.simulate_execute_rate":      10,
+			},
+			exp: types.WasmConfig{
+				SmartQueryGasLimit:      defaults.SmartQueryGasLimit,
+				MemoryCacheSize:         defaults.MemoryCacheSize,
+				SimulateExecuteGasLimit: 500000,
+				SimulateExecuteRate:     10,
+			},
+		},
 		"set debug via opts": {
 			src: AppOptionsMock{
 				"trace": true,
@@ -429,6 +612,87 @@ func TestReadWasmConfig(t *testing.T) {
 	}
 }
 
+func TestReadWasmConfigVMShards(t *testing.T) {
+	specs := map[string]struct {
+		src    AppOptionsMock
+		expErr bool
+	}{
+		"shards within memory cache size": {
+			src: AppOptionsMock{"wasm.vm_shards": 4, "wasm.memory_cache_size": 4},
+		},
+		"shards exceed memory cache size": {
+			src:    AppOptionsMock{"wasm.vm_shards": 5, "wasm.memory_cache_size": 4},
+			expErr: true,
+		},
+		"memory cache disabled": {
+			src: AppOptionsMock{"wasm.vm_shards": 5, "wasm.memory_cache_size": 0},
+		},
+		"single vm": {
+			src: AppOptionsMock{"wasm.vm_shards": 1, "wasm.memory_cache_size": 0},
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			_, err := ReadWasmConfig(spec.src)
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestReadWasmConfigContractTxIndex(t *testing.T) {
+	specs := map[string]struct {
+		src    AppOptionsMock
+		expErr bool
+	}{
+		"tendermint defaults": {
+			src: AppOptionsMock{},
+		},
+		"kv indexer and all events": {
+			src: AppOptionsMock{
+				"tx_index.indexer": "kv",
+				"index-events":     []string{},
+			},
+		},
+		"contract events indexed": {
+			src: AppOptionsMock{
+				"tx_index.indexer": "kv",
+				"index-events":     []string{"message.sender", "wasm.contract_address"},
+			},
+		},
+		"contract events not indexed": {
+			src: AppOptionsMock{
+				"index-events": []string{"message.sender"},
+			},
+			expErr: true,
+		},
+		"null indexer": {
+			src: AppOptionsMock{
+				"tx_index.indexer": "null",
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			spec.src["wasm.contract_tx_index"] = true
+			got, err := ReadWasmConfig(spec.src)
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, got.ContractTxIndex)
+		})
+	}
+	// and is not validated when disabled
+	_, err := ReadWasmConfig(AppOptionsMock{"tx_index.indexer": "null"})
+	require.NoError(t, err)
+}
+
 type AppOptionsMock map[string]interface{}
 
 func (a AppOptionsMock) Get(s string) interface{} {
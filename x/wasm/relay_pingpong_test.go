@@ -132,6 +132,67 @@ func TestPinPong(t *testing.T) {
 
 }
 
+func TestPingPongTimeout(t *testing.T) {
+	pingContract := &player{t: t, actor: ping}
+	pongContract := &player{t: t, actor: pong}
+
+	var (
+		chainAOpts = []wasmkeeper.Option{wasmkeeper.WithWasmEngine(
+			wasmtesting.NewIBCContractMockWasmer(pingContract)),
+		}
+		chainBOpts = []wasmkeeper.Option{wasmkeeper.WithWasmEngine(
+			wasmtesting.NewIBCContractMockWasmer(pongContract),
+		)}
+		coordinator = wasmibctesting.NewCoordinator(t, 2, chainAOpts, chainBOpts)
+		chainA      = coordinator.GetChain(wasmibctesting.GetChainID(0))
+		chainB      = coordinator.GetChain(wasmibctesting.GetChainID(1))
+	)
+	coordinator.CommitBlock(chainA, chainB)
+
+	_ = chainB.SeedNewContractInstance() // skip 1 instance so that addresses are not the same
+	var (
+		pingContractAddr = chainA.SeedNewContractInstance()
+		pongContractAddr = chainB.SeedNewContractInstance()
+	)
+	pingContract.chain = chainA
+	pingContract.contractAddr = pingContractAddr
+	pongContract.chain = chainB
+	pongContract.contractAddr = pongContractAddr
+
+	var (
+		sourcePortID       = wasmkeeper.PortIDForContract(pingContractAddr)
+		counterpartyPortID = wasmkeeper.PortIDForContract(pongContractAddr)
+	)
+	clientA, clientB, connA, connB := coordinator.SetupClientConnections(chainA, chainB, ibcexported.Tendermint)
+	connA.NextChannelVersion = ping
+	connB.NextChannelVersion = pong
+	channelA, _ := coordinator.CreateChannel(chainA, chainB, connA, connB, sourcePortID, counterpartyPortID, channeltypes.UNORDERED)
+
+	// when the game is started with a hit that times out soon
+	startMsg := &wasmtypes.MsgExecuteContract{
+		Sender:   chainA.SenderAccount.GetAddress().String(),
+		Contract: pingContractAddr.String(),
+		Msg: startGame{
+			ChannelID:     channelA.ID,
+			Value:         100,
+			TimeoutHeight: chainB.LastHeader.GetHeight().GetRevisionHeight() + 10,
+		}.GetBytes(),
+	}
+	err := coordinator.SendMsg(chainA, chainB, clientB, startMsg)
+	require.NoError(t, err)
+	require.Len(t, chainA.PendingSendPackets, 1)
+
+	// and the hit is not relayed before the timeout
+	err = coordinator.TimeoutPendingPackets(chainA, chainB, clientA, clientB)
+	require.NoError(t, err)
+
+	// then
+	assert.Empty(t, chainA.PendingSendPackets)
+	assert.Equal(t, uint64(1), pingContract.QueryState(timedOutBallsCountKey))
+	assert.Equal(t, uint64(0), pingContract.QueryState(confirmedBallsCountKey))
+	assert.Equal(t, uint64(0), pongContract.QueryState(receivedBallsCountKey))
+}
+
 var _ wasmtesting.IBCContractCallbacks = &player{}
 
 // player is a (mock) contract that sends and receives ibc packages
@@ -159,6 +220,10 @@ func (p *player) Execute(code wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmt
 	if start.MaxValue != 0 {
 		store.Set(maxValueKey, sdk.Uint64ToBigEndian(start.MaxValue))
 	}
+	timeout := doNotTimeout
+	if start.TimeoutHeight != 0 {
+		timeout = clienttypes.NewHeight(0, start.TimeoutHeight)
+	}
 	service := NewHit(p.actor, start.Value)
 	p.t.Logf("[%s] starting game with: %d: %v\n", p.actor, start.Value, service)
 
@@ -172,8 +237,8 @@ func (p *player) Execute(code wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmt
 						ChannelID: start.ChannelID,
 						Data:      service.GetBytes(),
 						Timeout: wasmvmtypes.IBCTimeout{Block: &wasmvmtypes.IBCTimeoutBlock{
-							Revision: doNotTimeout.RevisionNumber,
-							Height:   doNotTimeout.RevisionHeight,
+							Revision: timeout.RevisionNumber,
+							Height:   timeout.RevisionHeight,
 						}},
 					},
 				},
@@ -242,6 +307,7 @@ var ( // store keys
 	sentBallsCountKey      = []byte("sentBalls")
 	receivedBallsCountKey  = []byte("recvBalls")
 	confirmedBallsCountKey = []byte("confBalls")
+	timedOutBallsCountKey  = []byte("timedOutBalls")
 )
 
 // IBCPacketReceive receives the hit and serves a response hit via `wasmvmtypes.IBCPacket`
@@ -309,8 +375,10 @@ func (p player) IBCPacketAck(codeID wasmvm.Checksum, env wasmvmtypes.Env, packet
 	return &wasmvmtypes.IBCBasicResponse{}, 0, nil
 }
 
+// IBCPacketTimeout counts the hits that were not received by the other player in time
 func (p player) IBCPacketTimeout(codeID wasmvm.Checksum, env wasmvmtypes.Env, packet wasmvmtypes.IBCPacket, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64) (*wasmvmtypes.IBCBasicResponse, uint64, error) {
-	panic("implement me")
+	p.incrementCounter(timedOutBallsCountKey, store)
+	return &wasmvmtypes.IBCBasicResponse{}, 0, nil
 }
 
 func (p player) incrementCounter(key []byte, store wasmvm.KVStore) uint64 {
@@ -387,6 +455,8 @@ type startGame struct {
 	Value     uint64
 	// limit above the game is aborted
 	MaxValue uint64 `json:"max_value,omitempty"`
+	// block height on the counterparty chain when the first hit times out
+	TimeoutHeight uint64 `json:"timeout_height,omitempty"`
 }
 
 func (g startGame) GetBytes() json.RawMessage {
@@ -32,6 +32,21 @@ func ParamChanges(r *rand.Rand, cdc codec.Marshaler) []simtypes.ParamChange {
 				return fmt.Sprintf(`"%d"`, params.MaxWasmCodeSize)
 			},
 		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.ParamStoreKeyIBCPacketGasLimit),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf(`"%d"`, params.IBCPacketGasLimit)
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.ParamStoreKeyMaxContractGas),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf(`"%d"`, params.MaxContractGas)
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.ParamStoreKeyMaxResultDataSize),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf(`"%d"`, params.MaxResultDataSize)
+			},
+		),
 	}
 }
 
@@ -43,5 +58,7 @@ func RandomParams(r *rand.Rand) types.Params {
 		CodeUploadAccess:             accessConfig,
 		InstantiateDefaultPermission: accessConfig.Permission,
 		MaxWasmCodeSize:              uint64(simtypes.RandIntBetween(r, 1, 600) * 1024),
+		IBCPacketGasLimit:            uint64(simtypes.RandIntBetween(r, 0, 10) * 500_000),
+		MaxResultDataSize:            uint64(simtypes.RandIntBetween(r, 0, 128) * 1024),
 	}
 }
@@ -0,0 +1,21 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ContractAddrLen is the length of the contract addresses that are derived like module account addresses
+const ContractAddrLen = 32
+
+// VerifyAddressLen returns an address verifier for the sdk config that accepts the 20 byte account addresses and
+// the 32 byte contract addresses
+func VerifyAddressLen() func(addr []byte) error {
+	return func(addr []byte) error {
+		if len(addr) != sdk.AddrLen && len(addr) != ContractAddrLen {
+			return fmt.Errorf("incorrect address length (expected: %d or %d, actual: %d)", sdk.AddrLen, ContractAddrLen, len(addr))
+		}
+		return nil
+	}
+}
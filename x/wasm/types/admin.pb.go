@@ -0,0 +1,852 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: cosmwasm/wasm/v1beta1/admin.proto
+
+package types
+
+import (
+	context "context"
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	grpc1 "github.com/gogo/protobuf/grpc"
+	proto "github.com/gogo/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// AdminSmartQueryGasLimitRequest is the request type for the
+// Admin/SmartQueryGasLimit RPC method
+type AdminSmartQueryGasLimitRequest struct {
+}
+
+func (m *AdminSmartQueryGasLimitRequest) Reset()         { *m = AdminSmartQueryGasLimitRequest{} }
+func (m *AdminSmartQueryGasLimitRequest) String() string { return proto.CompactTextString(m) }
+func (*AdminSmartQueryGasLimitRequest) ProtoMessage()    {}
+func (*AdminSmartQueryGasLimitRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_04a267880973dde8, []int{0}
+}
+func (m *AdminSmartQueryGasLimitRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AdminSmartQueryGasLimitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AdminSmartQueryGasLimitRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AdminSmartQueryGasLimitRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AdminSmartQueryGasLimitRequest.Merge(m, src)
+}
+func (m *AdminSmartQueryGasLimitRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *AdminSmartQueryGasLimitRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AdminSmartQueryGasLimitRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AdminSmartQueryGasLimitRequest proto.InternalMessageInfo
+
+// AdminSmartQueryGasLimitResponse is the response type for the
+// Admin/SmartQueryGasLimit RPC method
+type AdminSmartQueryGasLimitResponse struct {
+	// GasLimit is the max gas that can be spent on a smart query
+	GasLimit uint64 `protobuf:"varint,1,opt,name=gas_limit,json=gasLimit,proto3" json:"gas_limit,omitempty"`
+}
+
+func (m *AdminSmartQueryGasLimitResponse) Reset()         { *m = AdminSmartQueryGasLimitResponse{} }
+func (m *AdminSmartQueryGasLimitResponse) String() string { return proto.CompactTextString(m) }
+func (*AdminSmartQueryGasLimitResponse) ProtoMessage()    {}
+func (*AdminSmartQueryGasLimitResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_04a267880973dde8, []int{1}
+}
+func (m *AdminSmartQueryGasLimitResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AdminSmartQueryGasLimitResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AdminSmartQueryGasLimitResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AdminSmartQueryGasLimitResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AdminSmartQueryGasLimitResponse.Merge(m, src)
+}
+func (m *AdminSmartQueryGasLimitResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *AdminSmartQueryGasLimitResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AdminSmartQueryGasLimitResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AdminSmartQueryGasLimitResponse proto.InternalMessageInfo
+
+// AdminSetSmartQueryGasLimitRequest is the request type for the
+// Admin/SetSmartQueryGasLimit RPC method
+type AdminSetSmartQueryGasLimitRequest struct {
+	// GasLimit is the new max gas that can be spent on a smart query
+	GasLimit uint64 `protobuf:"varint,1,opt,name=gas_limit,json=gasLimit,proto3" json:"gas_limit,omitempty"`
+}
+
+func (m *AdminSetSmartQueryGasLimitRequest) Reset()         { *m = AdminSetSmartQueryGasLimitRequest{} }
+func (m *AdminSetSmartQueryGasLimitRequest) String() string { return proto.CompactTextString(m) }
+func (*AdminSetSmartQueryGasLimitRequest) ProtoMessage()    {}
+func (*AdminSetSmartQueryGasLimitRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_04a267880973dde8, []int{2}
+}
+func (m *AdminSetSmartQueryGasLimitRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AdminSetSmartQueryGasLimitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AdminSetSmartQueryGasLimitRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AdminSetSmartQueryGasLimitRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AdminSetSmartQueryGasLimitRequest.Merge(m, src)
+}
+func (m *AdminSetSmartQueryGasLimitRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *AdminSetSmartQueryGasLimitRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AdminSetSmartQueryGasLimitRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AdminSetSmartQueryGasLimitRequest proto.InternalMessageInfo
+
+// AdminSetSmartQueryGasLimitResponse is the response type for the
+// Admin/SetSmartQueryGasLimit RPC method
+type AdminSetSmartQueryGasLimitResponse struct {
+	// PreviousGasLimit is the gas limit that was replaced
+	PreviousGasLimit uint64 `protobuf:"varint,1,opt,name=previous_gas_limit,json=previousGasLimit,proto3" json:"previous_gas_limit,omitempty"`
+}
+
+func (m *AdminSetSmartQueryGasLimitResponse) Reset()         { *m = AdminSetSmartQueryGasLimitResponse{} }
+func (m *AdminSetSmartQueryGasLimitResponse) String() string { return proto.CompactTextString(m) }
+func (*AdminSetSmartQueryGasLimitResponse) ProtoMessage()    {}
+func (*AdminSetSmartQueryGasLimitResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_04a267880973dde8, []int{3}
+}
+func (m *AdminSetSmartQueryGasLimitResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AdminSetSmartQueryGasLimitResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AdminSetSmartQueryGasLimitResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AdminSetSmartQueryGasLimitResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AdminSetSmartQueryGasLimitResponse.Merge(m, src)
+}
+func (m *AdminSetSmartQueryGasLimitResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *AdminSetSmartQueryGasLimitResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AdminSetSmartQueryGasLimitResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AdminSetSmartQueryGasLimitResponse proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*AdminSmartQueryGasLimitRequest)(nil), "cosmwasm.wasm.v1beta1.AdminSmartQueryGasLimitRequest")
+	proto.RegisterType((*AdminSmartQueryGasLimitResponse)(nil), "cosmwasm.wasm.v1beta1.AdminSmartQueryGasLimitResponse")
+	proto.RegisterType((*AdminSetSmartQueryGasLimitRequest)(nil), "cosmwasm.wasm.v1beta1.AdminSetSmartQueryGasLimitRequest")
+	proto.RegisterType((*AdminSetSmartQueryGasLimitResponse)(nil), "cosmwasm.wasm.v1beta1.AdminSetSmartQueryGasLimitResponse")
+}
+
+func init() { proto.RegisterFile("cosmwasm/wasm/v1beta1/admin.proto", fileDescriptor_04a267880973dde8) }
+
+var fileDescriptor_04a267880973dde8 = []byte{
+	// 303 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x52, 0x4c, 0xce, 0x2f, 0xce,
+	0x2d, 0x4f, 0x2c, 0xce, 0xd5, 0x07, 0x13, 0x65, 0x86, 0x49, 0xa9, 0x25, 0x89, 0x86, 0xfa, 0x89,
+	0x29, 0xb9, 0x99, 0x79, 0x7a, 0x05, 0x45, 0xf9, 0x25, 0xf9, 0x42, 0xa2, 0x30, 0x25, 0x7a, 0x60,
+	0x02, 0xaa, 0x44, 0x4a, 0x24, 0x3d, 0x3f, 0x3d, 0x1f, 0xac, 0x42, 0x1f, 0xc4, 0x82, 0x28, 0x56,
+	0x52, 0xe0, 0x92, 0x73, 0x04, 0xe9, 0x0d, 0xce, 0x4d, 0x2c, 0x2a, 0x09, 0x2c, 0x4d, 0x2d, 0xaa,
+	0x74, 0x4f, 0x2c, 0xf6, 0xc9, 0xcc, 0xcd, 0x2c, 0x09, 0x4a, 0x2d, 0x2c, 0x4d, 0x2d, 0x2e, 0x51,
+	0xb2, 0xe3, 0x92, 0xc7, 0xa9, 0xa2, 0xb8, 0x20, 0x3f, 0xaf, 0x38, 0x55, 0x48, 0x9a, 0x8b, 0x33,
+	0x3d, 0xb1, 0x38, 0x3e, 0x07, 0x24, 0x28, 0xc1, 0xa8, 0xc0, 0xa8, 0xc1, 0x12, 0xc4, 0x91, 0x0e,
+	0x55, 0xa4, 0xe4, 0xc0, 0xa5, 0x08, 0xd1, 0x9f, 0x5a, 0x82, 0xd3, 0x12, 0xfc, 0x26, 0x04, 0x71,
+	0x29, 0xe1, 0x33, 0x01, 0xea, 0x08, 0x1d, 0x2e, 0xa1, 0x82, 0xa2, 0xd4, 0xb2, 0xcc, 0xfc, 0xd2,
+	0xe2, 0x78, 0x74, 0xb3, 0x04, 0x60, 0x32, 0x30, 0x5d, 0x46, 0x73, 0x98, 0xb8, 0x58, 0xc1, 0x86,
+	0x0a, 0x35, 0x33, 0x72, 0x09, 0x61, 0x1a, 0x2b, 0x64, 0xaa, 0x87, 0x35, 0x18, 0xf5, 0xf0, 0x87,
+	0x96, 0x94, 0x19, 0xa9, 0xda, 0xa0, 0xae, 0xef, 0x61, 0xe4, 0x12, 0xc5, 0xea, 0x3f, 0x21, 0x0b,
+	0xbc, 0x26, 0xe2, 0x09, 0x54, 0x29, 0x4b, 0x32, 0x74, 0x42, 0x9c, 0xe3, 0xe4, 0x71, 0xe2, 0xa1,
+	0x1c, 0xc3, 0x8a, 0x47, 0x72, 0x0c, 0x27, 0x1e, 0xc9, 0x31, 0x5e, 0x78, 0x24, 0xc7, 0xf8, 0xe0,
+	0x91, 0x1c, 0xe3, 0x84, 0xc7, 0x72, 0x0c, 0x17, 0x1e, 0xcb, 0x31, 0xdc, 0x78, 0x2c, 0xc7, 0x10,
+	0xa5, 0x96, 0x9e, 0x59, 0x92, 0x51, 0x9a, 0xa4, 0x97, 0x9c, 0x9f, 0xab, 0xef, 0x9c, 0x5f, 0x9c,
+	0x1b, 0x0e, 0x4b, 0x93, 0x29, 0xfa, 0x15, 0x60, 0x5a, 0xbf, 0xa4, 0xb2, 0x20, 0xb5, 0x38, 0x89,
+	0x0d, 0x9c, 0xce, 0x8c, 0x01, 0x03, 0x00, 0xb9, 0x53, 0x30, 0x4e, 0xb9, 0x02, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// AdminClient is the client API for Admin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type AdminClient interface {
+	// SmartQueryGasLimit gets the max gas that can be spent on a smart query
+	SmartQueryGasLimit(ctx context.Context, in *AdminSmartQueryGasLimitRequest, opts ...grpc.CallOption) (*AdminSmartQueryGasLimitResponse, error)
+	// SetSmartQueryGasLimit sets the max gas that can be spent on a smart query
+	// until the node is restarted
+	SetSmartQueryGasLimit(ctx context.Context, in *AdminSetSmartQueryGasLimitRequest, opts ...grpc.CallOption) (*AdminSetSmartQueryGasLimitResponse, error)
+}
+
+type adminClient struct {
+	cc grpc1.ClientConn
+}
+
+func NewAdminClient(cc grpc1.ClientConn) AdminClient {
+	return &adminClient{cc}
+}
+
+func (c *adminClient) SmartQueryGasLimit(ctx context.Context, in *AdminSmartQueryGasLimitRequest, opts ...grpc.CallOption) (*AdminSmartQueryGasLimitResponse, error) {
+	out := new(AdminSmartQueryGasLimitResponse)
+	err := c.cc.Invoke(ctx, "/cosmwasm.wasm.v1beta1.Admin/SmartQueryGasLimit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) SetSmartQueryGasLimit(ctx context.Context, in *AdminSetSmartQueryGasLimitRequest, opts ...grpc.CallOption) (*AdminSetSmartQueryGasLimitResponse, error) {
+	out := new(AdminSetSmartQueryGasLimitResponse)
+	err := c.cc.Invoke(ctx, "/cosmwasm.wasm.v1beta1.Admin/SetSmartQueryGasLimit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServer is the server API for Admin service.
+type AdminServer interface {
+	// SmartQueryGasLimit gets the max gas that can be spent on a smart query
+	SmartQueryGasLimit(context.Context, *AdminSmartQueryGasLimitRequest) (*AdminSmartQueryGasLimitResponse, error)
+	// SetSmartQueryGasLimit sets the max gas that can be spent on a smart query
+	// until the node is restarted
+	SetSmartQueryGasLimit(context.Context, *AdminSetSmartQueryGasLimitRequest) (*AdminSetSmartQueryGasLimitResponse, error)
+}
+
+// UnimplementedAdminServer can be embedded to have forward compatible implementations.
+type UnimplementedAdminServer struct {
+}
+
+func (*UnimplementedAdminServer) SmartQueryGasLimit(ctx context.Context, req *AdminSmartQueryGasLimitRequest) (*AdminSmartQueryGasLimitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SmartQueryGasLimit not implemented")
+}
+func (*UnimplementedAdminServer) SetSmartQueryGasLimit(ctx context.Context, req *AdminSetSmartQueryGasLimitRequest) (*AdminSetSmartQueryGasLimitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetSmartQueryGasLimit not implemented")
+}
+
+func RegisterAdminServer(s grpc1.Server, srv AdminServer) {
+	s.RegisterService(&_Admin_serviceDesc, srv)
+}
+
+func _Admin_SmartQueryGasLimit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminSmartQueryGasLimitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).SmartQueryGasLimit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cosmwasm.wasm.v1beta1.Admin/SmartQueryGasLimit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).SmartQueryGasLimit(ctx, req.(*AdminSmartQueryGasLimitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_SetSmartQueryGasLimit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminSetSmartQueryGasLimitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).SetSmartQueryGasLimit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cosmwasm.wasm.v1beta1.Admin/SetSmartQueryGasLimit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).SetSmartQueryGasLimit(ctx, req.(*AdminSetSmartQueryGasLimitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Admin_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "cosmwasm.wasm.v1beta1.Admin",
+	HandlerType: (*AdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SmartQueryGasLimit",
+			Handler:    _Admin_SmartQueryGasLimit_Handler,
+		},
+		{
+			MethodName: "SetSmartQueryGasLimit",
+			Handler:    _Admin_SetSmartQueryGasLimit_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "cosmwasm/wasm/v1beta1/admin.proto",
+}
+
+func (m *AdminSmartQueryGasLimitRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AdminSmartQueryGasLimitRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AdminSmartQueryGasLimitRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *AdminSmartQueryGasLimitResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AdminSmartQueryGasLimitResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AdminSmartQueryGasLimitResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.GasLimit != 0 {
+		i = encodeVarintAdmin(dAtA, i, uint64(m.GasLimit))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AdminSetSmartQueryGasLimitRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AdminSetSmartQueryGasLimitRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AdminSetSmartQueryGasLimitRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.GasLimit != 0 {
+		i = encodeVarintAdmin(dAtA, i, uint64(m.GasLimit))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AdminSetSmartQueryGasLimitResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AdminSetSmartQueryGasLimitResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AdminSetSmartQueryGasLimitResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.PreviousGasLimit != 0 {
+		i = encodeVarintAdmin(dAtA, i, uint64(m.PreviousGasLimit))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintAdmin(dAtA []byte, offset int, v uint64) int {
+	offset -= sovAdmin(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *AdminSmartQueryGasLimitRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *AdminSmartQueryGasLimitResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.GasLimit != 0 {
+		n += 1 + sovAdmin(uint64(m.GasLimit))
+	}
+	return n
+}
+
+func (m *AdminSetSmartQueryGasLimitRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.GasLimit != 0 {
+		n += 1 + sovAdmin(uint64(m.GasLimit))
+	}
+	return n
+}
+
+func (m *AdminSetSmartQueryGasLimitResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PreviousGasLimit != 0 {
+		n += 1 + sovAdmin(uint64(m.PreviousGasLimit))
+	}
+	return n
+}
+
+func sovAdmin(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozAdmin(x uint64) (n int) {
+	return sovAdmin(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *AdminSmartQueryGasLimitRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AdminSmartQueryGasLimitRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AdminSmartQueryGasLimitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AdminSmartQueryGasLimitResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AdminSmartQueryGasLimitResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AdminSmartQueryGasLimitResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GasLimit", wireType)
+			}
+			m.GasLimit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GasLimit |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AdminSetSmartQueryGasLimitRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AdminSetSmartQueryGasLimitRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AdminSetSmartQueryGasLimitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GasLimit", wireType)
+			}
+			m.GasLimit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GasLimit |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AdminSetSmartQueryGasLimitResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AdminSetSmartQueryGasLimitResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AdminSetSmartQueryGasLimitResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PreviousGasLimit", wireType)
+			}
+			m.PreviousGasLimit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PreviousGasLimit |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAdmin(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAdmin
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipAdmin(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowAdmin
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowAdmin
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthAdmin
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupAdmin
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthAdmin
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthAdmin        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowAdmin          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupAdmin = fmt.Errorf("proto: unexpected end of group")
+)
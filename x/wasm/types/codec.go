@@ -17,8 +17,17 @@ func RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) {
 	cdc.RegisterConcrete(&MsgMigrateContract{}, "wasm/MsgMigrateContract", nil)
 	cdc.RegisterConcrete(&MsgUpdateAdmin{}, "wasm/MsgUpdateAdmin", nil)
 	cdc.RegisterConcrete(&MsgClearAdmin{}, "wasm/MsgClearAdmin", nil)
+	cdc.RegisterConcrete(&MsgUpdateDeniedDenoms{}, "wasm/MsgUpdateDeniedDenoms", nil)
+	cdc.RegisterConcrete(&MsgUpdateReentrancyGuard{}, "wasm/MsgUpdateReentrancyGuard", nil)
+	cdc.RegisterConcrete(&MsgRegisterInterchainQuery{}, "wasm/MsgRegisterInterchainQuery", nil)
+	cdc.RegisterConcrete(&MsgRemoveInterchainQuery{}, "wasm/MsgRemoveInterchainQuery", nil)
+	cdc.RegisterConcrete(&MsgSubmitInterchainQueryResult{}, "wasm/MsgSubmitInterchainQueryResult", nil)
+	cdc.RegisterConcrete(&MsgReopenChannel{}, "wasm/MsgReopenChannel", nil)
 	cdc.RegisterConcrete(&PinCodesProposal{}, "wasm/PinCodesProposal", nil)
 	cdc.RegisterConcrete(&UnpinCodesProposal{}, "wasm/UnpinCodesProposal", nil)
+	cdc.RegisterConcrete(&RecoverContractFundsProposal{}, "wasm/RecoverContractFundsProposal", nil)
+	cdc.RegisterConcrete(&SetContractVestingProposal{}, "wasm/SetContractVestingProposal", nil)
+	cdc.RegisterConcrete(&MigrateAllContractsProposal{}, "wasm/MigrateAllContractsProposal", nil)
 
 	cdc.RegisterConcrete(&StoreCodeProposal{}, "wasm/StoreCodeProposal", nil)
 	cdc.RegisterConcrete(&InstantiateContractProposal{}, "wasm/InstantiateContractProposal", nil)
@@ -36,6 +45,12 @@ func RegisterInterfaces(registry types.InterfaceRegistry) {
 		&MsgMigrateContract{},
 		&MsgUpdateAdmin{},
 		&MsgClearAdmin{},
+		&MsgUpdateDeniedDenoms{},
+		&MsgUpdateReentrancyGuard{},
+		&MsgRegisterInterchainQuery{},
+		&MsgRemoveInterchainQuery{},
+		&MsgSubmitInterchainQueryResult{},
+		&MsgReopenChannel{},
 		&MsgIBCCloseChannel{},
 		&MsgIBCSend{},
 	)
@@ -48,6 +63,9 @@ func RegisterInterfaces(registry types.InterfaceRegistry) {
 		&ClearAdminProposal{},
 		&PinCodesProposal{},
 		&UnpinCodesProposal{},
+		&RecoverContractFundsProposal{},
+		&SetContractVestingProposal{},
+		&MigrateAllContractsProposal{},
 	)
 
 	registry.RegisterInterface("ContractInfoExtension", (*ContractInfoExtension)(nil))
@@ -66,4 +66,10 @@ var (
 
 	// ErrUnknownMsg error by a message handler to show that it is not responsible for this message type
 	ErrUnknownMsg = sdkErrors.Register(DefaultCodespace, 20, "unknown message from the contract")
+
+	// ErrUnsupportedFeatures error when the code requires features that are not enabled on the chain
+	ErrUnsupportedFeatures = sdkErrors.Register(DefaultCodespace, 21, "unsupported features")
+
+	// ErrReentrancy error for a nested call into a contract with reentrancy guard that is on the call stack
+	ErrReentrancy = sdkErrors.Register(DefaultCodespace, 22, "reentrancy")
 )
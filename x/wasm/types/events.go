@@ -4,10 +4,72 @@ const (
 	CustomEventType    = "wasm"
 	EventTypePinCode   = "pin_code"
 	EventTypeUnpinCode = "unpin_code"
+	// EventTypeInstantiateFunds is emitted when a new contract is funded by the creator on instantiation
+	EventTypeInstantiateFunds = "instantiate_funds"
+	// EventTypePacketAck is passed to the contract in the reply for a submessage that sent an IBC packet
+	EventTypePacketAck = "wasm_packet_ack"
+	// EventTypeMintNative is emitted when a bridged CW20 contract mints its native denom
+	EventTypeMintNative = "mint_native"
+	// EventTypeBurnNative is emitted when a bridged CW20 contract burns its native denom
+	EventTypeBurnNative = "burn_native"
+	// EventTypeRecoverContractFunds is emitted when governance moved the funds out of a bricked contract
+	EventTypeRecoverContractFunds = "recover_contract_funds"
+	// EventTypeSetContractVesting is emitted when governance locked the funds of a contract with a vesting schedule
+	EventTypeSetContractVesting = "set_contract_vesting"
+	// EventTypeRegisterInterchainQuery is emitted when a contract registered an interchain query
+	EventTypeRegisterInterchainQuery = "register_interchain_query"
+	// EventTypeRemoveInterchainQuery is emitted when a contract removed an interchain query
+	EventTypeRemoveInterchainQuery = "remove_interchain_query"
+	// EventTypeInterchainQueryResult is emitted when the result of an interchain query was passed to the contract
+	EventTypeInterchainQueryResult = "interchain_query_result"
+	// EventTypeReopenChannel is emitted when a contract started a new handshake for a closed ordered channel
+	EventTypeReopenChannel = "reopen_channel"
+	// EventTypeMigrateAllContracts is emitted when all contracts of a code were migrated or a bulk migration completed
+	EventTypeMigrateAllContracts = "migrate_all_contracts"
+	// EventTypeBulkMigrateContract is emitted for every contract of a bulk migration that is run by the end blocker
+	EventTypeBulkMigrateContract = "bulk_migrate_contract"
+	// EventTypeJobCompleted is emitted when a job of the end blocker was completed or aborted
+	EventTypeJobCompleted = "job_completed"
+	// EventTypeStoreCode is emitted when a new wasm code was stored by a message or a governance proposal
+	EventTypeStoreCode = "store_code"
+	// EventTypeStoreCodeDeposit is emitted when the store code deposit was taken from the sender
+	EventTypeStoreCodeDeposit = "store_code_deposit"
+	// EventTypeRefundStoreCodeDeposit is emitted when the store code deposit was returned to the depositor
+	EventTypeRefundStoreCodeDeposit = "refund_store_code_deposit"
 )
 const ( // event attributes
-	AttributeKeyContractAddr = "contract_address"
-	AttributeKeyCodeID       = "code_id"
-	AttributeKeySigner       = "signer"
-	AttributeResultDataHex   = "result"
+	AttributeKeyContractAddr     = "contract_address"
+	AttributeKeyCodeID           = "code_id"
+	AttributeKeySigner           = "signer"
+	AttributeKeyCreator          = "creator"
+	AttributeResultDataHex       = "result"
+	AttributeKeyPacketSrcPort    = "packet_src_port"
+	AttributeKeyPacketSrcChannel = "packet_src_channel"
+	AttributeKeyPacketSequence   = "packet_sequence"
+	AttributeKeyRecipient        = "recipient"
+	AttributeKeyLabel            = "label"
+	AttributeKeyAdmin            = "admin"
+	AttributeKeyChecksum         = "checksum"
+	AttributeKeyDeniedDenoms     = "denied_denoms"
+	AttributeKeyDepositor        = "depositor"
+	AttributeKeyRefundHeight     = "refund_height"
+	AttributeKeyBurned           = "burned"
+	AttributeKeyImmutable        = "immutable"
+	AttributeKeyVestingType      = "vesting_type"
+	AttributeKeyVestingEndTime   = "vesting_end_time"
+	AttributeKeyQueryID          = "query_id"
+	AttributeKeyConnectionID     = "connection_id"
+	AttributeKeyRemoteHeight     = "remote_height"
+	AttributeKeySuccess          = "success"
+	AttributeKeyChannelID        = "channel_id"
+	AttributeKeyNewChannelID     = "new_channel_id"
+	AttributeKeyNewCodeID        = "new_code_id"
+	AttributeKeyMigrated         = "migrated"
+	AttributeKeyFailed           = "failed"
+	AttributeKeyJobID            = "job_id"
+	AttributeKeyJobType          = "job_type"
+	AttributeKeyEnabled          = "enabled"
 )
+
+// EventKeyContractAddr is the indexed event key that the txs of a contract are searched by
+const EventKeyContractAddr = CustomEventType + "." + AttributeKeyContractAddr
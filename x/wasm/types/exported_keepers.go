@@ -5,6 +5,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	capabilitytypes "github.com/cosmos/cosmos-sdk/x/capability/types"
+	clienttypes "github.com/cosmos/cosmos-sdk/x/ibc/core/02-client/types"
 )
 
 // ViewKeeper provides read only operations
@@ -23,6 +24,63 @@ type ViewKeeper interface {
 	IsPinnedCode(ctx types.Context, codeID uint64) bool
 }
 
+// StoreAuditLogViewKeeper is an optional extension of the ViewKeeper that provides the node local store audit log
+type StoreAuditLogViewKeeper interface {
+	GetStoreAuditLog(contractAddr sdk.AccAddress) ([]StoreAuditEntry, bool)
+}
+
+// WasmVMMetricsViewKeeper is an optional extension of the ViewKeeper that provides the cache metrics of the wasm VM
+type WasmVMMetricsViewKeeper interface {
+	GetWasmVMMetrics() (*types2.Metrics, bool, error)
+}
+
+// ContractByPortViewKeeper is an optional extension of the ViewKeeper that resolves the contract of an IBC port
+type ContractByPortViewKeeper interface {
+	ContractByPortID(ctx sdk.Context, portID string) (sdk.AccAddress, error)
+}
+
+// DeniedDenomsViewKeeper is an optional extension of the ViewKeeper that provides the denoms a contract refuses
+// to receive
+type DeniedDenomsViewKeeper interface {
+	GetDeniedDenoms(ctx sdk.Context, contractAddress sdk.AccAddress) []string
+}
+
+// SupportedFeaturesViewKeeper is an optional extension of the ViewKeeper that provides the contract capabilities
+// enabled on this chain
+type SupportedFeaturesViewKeeper interface {
+	GetSupportedFeatures() []string
+}
+
+// ContractBalanceViewKeeper is an optional extension of the ViewKeeper that provides the bank balance of a contract
+type ContractBalanceViewKeeper interface {
+	GetContractBalance(ctx sdk.Context, contractAddress sdk.AccAddress) sdk.Coins
+}
+
+// ChannelsViewKeeper is an optional extension of the ViewKeeper that provides the open IBC channels of a port.
+// Without it no channels are returned with the contract info.
+type ChannelsViewKeeper interface {
+	GetOpenChannelIDs(ctx sdk.Context, portID string) []string
+}
+
+// GasCostsViewKeeper is an optional extension of the ViewKeeper that provides the active gas costs
+type GasCostsViewKeeper interface {
+	GetGasCosts() (GasCosts, bool)
+}
+
+// WasmResult is the outcome of a contract execution that was triggered by another module
+type WasmResult struct {
+	// Data is the response data of the contract
+	Data []byte
+	// Events were emitted by the execution. They are empty when the execution failed.
+	Events []sdk.Event
+	// Err is the contract execution error or nil on success
+	Err error
+}
+
+// OnWasmResult is called with the outcome of a contract execution, after the contract state changes were committed
+// on success or discarded on failure. It can be used to coordinate the state of the calling module with the contract.
+type OnWasmResult func(ctx sdk.Context, result WasmResult) error
+
 // ContractOpsKeeper contains mutable operations on a contract.
 type ContractOpsKeeper interface {
 	// Create uploads and compiles a WASM contract, returning a short identifier for the contract
@@ -53,6 +111,84 @@ type ContractOpsKeeper interface {
 	SetContractInfoExtension(ctx sdk.Context, contract sdk.AccAddress, extra ContractInfoExtension) error
 }
 
+// CommunityPoolOpsKeeper is an optional extension of the ContractOpsKeeper to fund contracts from the community pool
+type CommunityPoolOpsKeeper interface {
+	// FundFromCommunityPool sends coins from the community pool to the recipient. This is restricted to governance.
+	FundFromCommunityPool(ctx sdk.Context, recipient sdk.AccAddress, amount sdk.Coins) error
+}
+
+// ExecuteWithCallbackOpsKeeper is an optional extension of the ContractOpsKeeper for modules that coordinate their
+// state with the outcome of a contract execution
+type ExecuteWithCallbackOpsKeeper interface {
+	// ExecuteWithCallback executes the contract instance and passes the outcome to the callback. Contract failures
+	// are not returned but handed to the callback. Only the callback error is returned.
+	ExecuteWithCallback(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, msg []byte, coins sdk.Coins, cb OnWasmResult) error
+}
+
+// DeniedDenomsOpsKeeper is an optional extension of the ContractOpsKeeper to manage the denoms a contract
+// refuses to receive
+type DeniedDenomsOpsKeeper interface {
+	// UpdateDeniedDenoms replaces the denoms that the contract refuses to receive as funds.
+	UpdateDeniedDenoms(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, denoms []string) error
+}
+
+// ContractFundsRecoveryOpsKeeper is an optional extension of the ContractOpsKeeper to recover the funds of bricked
+// contracts
+type ContractFundsRecoveryOpsKeeper interface {
+	// RecoverContractFunds sends coins from a bricked contract to the recipient. This is restricted to governance.
+	RecoverContractFunds(ctx sdk.Context, contractAddress, recipient sdk.AccAddress, amount sdk.Coins) error
+}
+
+// StoreCodeDepositOpsKeeper is an optional extension of the ContractOpsKeeper to collect a deposit for stored codes.
+// No deposit is required with keepers that do not implement it.
+type StoreCodeDepositOpsKeeper interface {
+	// CollectStoreCodeDeposit escrows or burns the store code deposit of the params from the sender of the next code.
+	// It must be called before Create so that no code is compiled and persisted without deposit.
+	CollectStoreCodeDeposit(ctx sdk.Context, depositor sdk.AccAddress) error
+}
+
+// ContractVestingOpsKeeper is an optional extension of the ContractOpsKeeper to lock contract funds with a vesting
+// schedule
+type ContractVestingOpsKeeper interface {
+	// SetContractVesting converts the contract account into a vesting account that locks the contract funds with the
+	// schedule. This is restricted to governance.
+	SetContractVesting(ctx sdk.Context, contractAddress sdk.AccAddress, schedule ContractVestingSchedule) error
+}
+
+// InterchainQueryOpsKeeper is an optional extension of the ContractOpsKeeper to manage the interchain queries of
+// contracts
+type InterchainQueryOpsKeeper interface {
+	// RegisterInterchainQuery stores a periodic query of the contract against the counterparty chain of the connection
+	RegisterInterchainQuery(ctx sdk.Context, contractAddress sdk.AccAddress, connectionID string, keys []InterchainQueryKey, updatePeriod uint64) (uint64, error)
+
+	// RemoveInterchainQuery deletes an interchain query of the contract
+	RemoveInterchainQuery(ctx sdk.Context, contractAddress sdk.AccAddress, queryID uint64) error
+
+	// SubmitInterchainQueryResult verifies the proofs of the result and passes the values to the contract
+	SubmitInterchainQueryResult(ctx sdk.Context, queryID uint64, height clienttypes.Height, results []InterchainQueryResult) error
+}
+
+// ChannelReopenOpsKeeper is an optional extension of the ContractOpsKeeper to reopen closed channels of contracts
+type ChannelReopenOpsKeeper interface {
+	// ReopenChannel starts a new handshake with the counterparty of a closed ordered channel of the contract
+	ReopenChannel(ctx sdk.Context, contractAddress sdk.AccAddress, channelID string) (string, error)
+}
+
+// MigrateAllContractsOpsKeeper is an optional extension of the ContractOpsKeeper to migrate all contracts of a code
+type MigrateAllContractsOpsKeeper interface {
+	// MigrateAllContracts migrates all contracts of a code to the new code. When contractsPerBlock is not zero the
+	// contracts are migrated in chunks by the end blocker. This is restricted to governance.
+	MigrateAllContracts(ctx sdk.Context, codeID, newCodeID uint64, caller sdk.AccAddress, msg []byte, contractsPerBlock uint32) (uint64, error)
+}
+
+// ReentrancyGuardOpsKeeper is an optional extension of the ContractOpsKeeper to manage the reentrancy guard of
+// contracts
+type ReentrancyGuardOpsKeeper interface {
+	// UpdateReentrancyGuard enables or disables the rejection of nested calls into the contract while it is on the
+	// call stack.
+	UpdateReentrancyGuard(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, enabled bool) error
+}
+
 // IBCContractKeeper IBC lifecycle event handler
 type IBCContractKeeper interface {
 	OnOpenChannel(
@@ -90,4 +226,6 @@ type IBCContractKeeper interface {
 	ClaimCapability(ctx sdk.Context, cap *capabilitytypes.Capability, name string) error
 	// AuthenticateCapability wraps the scopedKeeper's AuthenticateCapability function
 	AuthenticateCapability(ctx sdk.Context, cap *capabilitytypes.Capability, name string) bool
+	// ContractByPortID returns the address of the contract that is bound to the IBC port
+	ContractByPortID(ctx sdk.Context, portID string) (sdk.AccAddress, error)
 }
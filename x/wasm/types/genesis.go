@@ -4,6 +4,7 @@ import "C"
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	host "github.com/cosmos/cosmos-sdk/x/ibc/core/24-host"
 )
 
 func (s Sequence) ValidateBasic() error {
@@ -37,6 +38,77 @@ func (s GenesisState) ValidateBasic() error {
 			return sdkerrors.Wrapf(err, "gen message: %d", i)
 		}
 	}
+	for i := range s.StoreCodeDeposits {
+		if err := s.StoreCodeDeposits[i].ValidateBasic(); err != nil {
+			return sdkerrors.Wrapf(err, "store code deposit: %d", i)
+		}
+	}
+	for i := range s.InterchainQueries {
+		if err := s.InterchainQueries[i].ValidateBasic(); err != nil {
+			return sdkerrors.Wrapf(err, "interchain query: %d", i)
+		}
+	}
+	for i := range s.Jobs {
+		if err := s.Jobs[i].ValidateBasic(); err != nil {
+			return sdkerrors.Wrapf(err, "job: %d", i)
+		}
+	}
+	for i := range s.FrozenClientNotifications {
+		if err := s.FrozenClientNotifications[i].ValidateBasic(); err != nil {
+			return sdkerrors.Wrapf(err, "frozen client notification: %d", i)
+		}
+	}
+	for i := range s.CodeStats {
+		if err := s.CodeStats[i].ValidateBasic(); err != nil {
+			return sdkerrors.Wrapf(err, "code stats: %d", i)
+		}
+	}
+	return nil
+}
+
+func (d StoreCodeDeposit) ValidateBasic() error {
+	if d.CodeID == 0 {
+		return sdkerrors.Wrap(ErrEmpty, "code id")
+	}
+	if _, err := sdk.AccAddressFromBech32(d.Depositor); err != nil {
+		return sdkerrors.Wrap(err, "depositor")
+	}
+	if d.Amount.Empty() || validateCoins(d.Amount) != nil {
+		return sdkerrors.Wrap(ErrInvalid, "amount")
+	}
+	if d.RefundHeight <= 0 {
+		return sdkerrors.Wrap(ErrInvalid, "refund height")
+	}
+	return nil
+}
+
+func (j Job) ValidateBasic() error {
+	if j.ID == 0 {
+		return sdkerrors.Wrap(ErrEmpty, "id")
+	}
+	if j.Type == "" {
+		return sdkerrors.Wrap(ErrEmpty, "type")
+	}
+	return nil
+}
+
+func (n FrozenClientNotification) ValidateBasic() error {
+	if err := host.ClientIdentifierValidator(n.ClientID); err != nil {
+		return sdkerrors.Wrap(err, "client id")
+	}
+	if err := host.PortIdentifierValidator(n.PortID); err != nil {
+		return sdkerrors.Wrap(err, "port id")
+	}
+	if err := host.ChannelIdentifierValidator(n.ChannelID); err != nil {
+		return sdkerrors.Wrap(err, "channel id")
+	}
+	return nil
+}
+
+func (s CodeStats) ValidateBasic() error {
+	if s.CodeID == 0 {
+		return sdkerrors.Wrap(ErrEmpty, "code id")
+	}
 	return nil
 }
 
@@ -64,10 +136,11 @@ func (c Contract) ValidateBasic() error {
 	if c.ContractInfo.Created != nil {
 		return sdkerrors.Wrap(ErrInvalid, "created must be empty")
 	}
-	for i := range c.ContractState {
-		if err := c.ContractState[i].ValidateBasic(); err != nil {
-			return sdkerrors.Wrapf(err, "contract state %d", i)
-		}
+	if err := ValidateContractState(c.ContractState); err != nil {
+		return sdkerrors.Wrap(err, "contract state")
+	}
+	if err := ValidateDeniedDenoms(c.DeniedDenoms); err != nil {
+		return sdkerrors.Wrap(err, "denied denoms")
 	}
 	return nil
 }
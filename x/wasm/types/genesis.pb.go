@@ -25,11 +25,16 @@ const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
 // GenesisState - genesis state of x/wasm
 type GenesisState struct {
-	Params    Params                 `protobuf:"bytes,1,opt,name=params,proto3" json:"params"`
-	Codes     []Code                 `protobuf:"bytes,2,rep,name=codes,proto3" json:"codes,omitempty"`
-	Contracts []Contract             `protobuf:"bytes,3,rep,name=contracts,proto3" json:"contracts,omitempty"`
-	Sequences []Sequence             `protobuf:"bytes,4,rep,name=sequences,proto3" json:"sequences,omitempty"`
-	GenMsgs   []GenesisState_GenMsgs `protobuf:"bytes,5,rep,name=gen_msgs,json=genMsgs,proto3" json:"gen_msgs,omitempty"`
+	Params                    Params                     `protobuf:"bytes,1,opt,name=params,proto3" json:"params"`
+	Codes                     []Code                     `protobuf:"bytes,2,rep,name=codes,proto3" json:"codes,omitempty"`
+	Contracts                 []Contract                 `protobuf:"bytes,3,rep,name=contracts,proto3" json:"contracts,omitempty"`
+	Sequences                 []Sequence                 `protobuf:"bytes,4,rep,name=sequences,proto3" json:"sequences,omitempty"`
+	GenMsgs                   []GenesisState_GenMsgs     `protobuf:"bytes,5,rep,name=gen_msgs,json=genMsgs,proto3" json:"gen_msgs,omitempty"`
+	StoreCodeDeposits         []StoreCodeDeposit         `protobuf:"bytes,6,rep,name=store_code_deposits,json=storeCodeDeposits,proto3" json:"store_code_deposits,omitempty"`
+	InterchainQueries         []InterchainQuery          `protobuf:"bytes,7,rep,name=interchain_queries,json=interchainQueries,proto3" json:"interchain_queries,omitempty"`
+	Jobs                      []Job                      `protobuf:"bytes,8,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	FrozenClientNotifications []FrozenClientNotification `protobuf:"bytes,9,rep,name=frozen_client_notifications,json=frozenClientNotifications,proto3" json:"frozen_client_notifications,omitempty"`
+	CodeStats                 []CodeStats                `protobuf:"bytes,10,rep,name=code_stats,json=codeStats,proto3" json:"code_stats,omitempty"`
 }
 
 func (m *GenesisState) Reset()         { *m = GenesisState{} }
@@ -100,6 +105,41 @@ func (m *GenesisState) GetGenMsgs() []GenesisState_GenMsgs {
 	return nil
 }
 
+func (m *GenesisState) GetStoreCodeDeposits() []StoreCodeDeposit {
+	if m != nil {
+		return m.StoreCodeDeposits
+	}
+	return nil
+}
+
+func (m *GenesisState) GetInterchainQueries() []InterchainQuery {
+	if m != nil {
+		return m.InterchainQueries
+	}
+	return nil
+}
+
+func (m *GenesisState) GetJobs() []Job {
+	if m != nil {
+		return m.Jobs
+	}
+	return nil
+}
+
+func (m *GenesisState) GetFrozenClientNotifications() []FrozenClientNotification {
+	if m != nil {
+		return m.FrozenClientNotifications
+	}
+	return nil
+}
+
+func (m *GenesisState) GetCodeStats() []CodeStats {
+	if m != nil {
+		return m.CodeStats
+	}
+	return nil
+}
+
 // GenMsgs define the messages that can be executed during genesis phase in
 // order. The intention is to have more human readable data that is auditable.
 type GenesisState_GenMsgs struct {
@@ -277,6 +317,8 @@ type Contract struct {
 	ContractAddress string       `protobuf:"bytes,1,opt,name=contract_address,json=contractAddress,proto3" json:"contract_address,omitempty"`
 	ContractInfo    ContractInfo `protobuf:"bytes,2,opt,name=contract_info,json=contractInfo,proto3" json:"contract_info"`
 	ContractState   []Model      `protobuf:"bytes,3,rep,name=contract_state,json=contractState,proto3" json:"contract_state"`
+	// DeniedDenoms are the denoms that the contract refuses to receive
+	DeniedDenoms []string `protobuf:"bytes,4,rep,name=denied_denoms,json=deniedDenoms,proto3" json:"denied_denoms,omitempty"`
 }
 
 func (m *Contract) Reset()         { *m = Contract{} }
@@ -333,6 +375,13 @@ func (m *Contract) GetContractState() []Model {
 	return nil
 }
 
+func (m *Contract) GetDeniedDenoms() []string {
+	if m != nil {
+		return m.DeniedDenoms
+	}
+	return nil
+}
+
 // Sequence key and value of an id generation counter
 type Sequence struct {
 	IDKey []byte `protobuf:"bytes,1,opt,name=id_key,json=idKey,proto3" json:"id_key,omitempty"`
@@ -386,12 +435,129 @@ func (m *Sequence) GetValue() uint64 {
 	return 0
 }
 
+// FrozenClientNotification is a contract channel on a frozen IBC client that
+// the contract was notified about
+type FrozenClientNotification struct {
+	ClientID  string `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	PortID    string `protobuf:"bytes,2,opt,name=port_id,json=portId,proto3" json:"port_id,omitempty"`
+	ChannelID string `protobuf:"bytes,3,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+}
+
+func (m *FrozenClientNotification) Reset()         { *m = FrozenClientNotification{} }
+func (m *FrozenClientNotification) String() string { return proto.CompactTextString(m) }
+func (*FrozenClientNotification) ProtoMessage()    {}
+func (*FrozenClientNotification) Descriptor() ([]byte, []int) {
+	return fileDescriptor_931ba204ce53afe0, []int{4}
+}
+func (m *FrozenClientNotification) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FrozenClientNotification) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FrozenClientNotification.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *FrozenClientNotification) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FrozenClientNotification.Merge(m, src)
+}
+func (m *FrozenClientNotification) XXX_Size() int {
+	return m.Size()
+}
+func (m *FrozenClientNotification) XXX_DiscardUnknown() {
+	xxx_messageInfo_FrozenClientNotification.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FrozenClientNotification proto.InternalMessageInfo
+
+func (m *FrozenClientNotification) GetClientID() string {
+	if m != nil {
+		return m.ClientID
+	}
+	return ""
+}
+
+func (m *FrozenClientNotification) GetPortID() string {
+	if m != nil {
+		return m.PortID
+	}
+	return ""
+}
+
+func (m *FrozenClientNotification) GetChannelID() string {
+	if m != nil {
+		return m.ChannelID
+	}
+	return ""
+}
+
+// CodeStats are the execution statistics of a code in an epoch
+type CodeStats struct {
+	CodeID uint64             `protobuf:"varint,1,opt,name=code_id,json=codeId,proto3" json:"code_id,omitempty"`
+	Stats  CodeExecutionStats `protobuf:"bytes,2,opt,name=stats,proto3" json:"stats"`
+}
+
+func (m *CodeStats) Reset()         { *m = CodeStats{} }
+func (m *CodeStats) String() string { return proto.CompactTextString(m) }
+func (*CodeStats) ProtoMessage()    {}
+func (*CodeStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_931ba204ce53afe0, []int{5}
+}
+func (m *CodeStats) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CodeStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CodeStats.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CodeStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CodeStats.Merge(m, src)
+}
+func (m *CodeStats) XXX_Size() int {
+	return m.Size()
+}
+func (m *CodeStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_CodeStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CodeStats proto.InternalMessageInfo
+
+func (m *CodeStats) GetCodeID() uint64 {
+	if m != nil {
+		return m.CodeID
+	}
+	return 0
+}
+
+func (m *CodeStats) GetStats() CodeExecutionStats {
+	if m != nil {
+		return m.Stats
+	}
+	return CodeExecutionStats{}
+}
+
 func init() {
 	proto.RegisterType((*GenesisState)(nil), "cosmwasm.wasm.v1beta1.GenesisState")
 	proto.RegisterType((*GenesisState_GenMsgs)(nil), "cosmwasm.wasm.v1beta1.GenesisState.GenMsgs")
 	proto.RegisterType((*Code)(nil), "cosmwasm.wasm.v1beta1.Code")
 	proto.RegisterType((*Contract)(nil), "cosmwasm.wasm.v1beta1.Contract")
 	proto.RegisterType((*Sequence)(nil), "cosmwasm.wasm.v1beta1.Sequence")
+	proto.RegisterType((*FrozenClientNotification)(nil), "cosmwasm.wasm.v1beta1.FrozenClientNotification")
+	proto.RegisterType((*CodeStats)(nil), "cosmwasm.wasm.v1beta1.CodeStats")
 }
 
 func init() {
@@ -399,48 +565,68 @@ func init() {
 }
 
 var fileDescriptor_931ba204ce53afe0 = []byte{
-	// 647 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x94, 0xc1, 0x6e, 0xd3, 0x40,
-	0x10, 0x86, 0xe3, 0x26, 0x4e, 0x93, 0x69, 0xa0, 0xd5, 0xb6, 0x80, 0x95, 0x52, 0x27, 0x24, 0x12,
-	0x6a, 0x05, 0x24, 0x6a, 0x39, 0x72, 0x01, 0xb7, 0x88, 0x86, 0xaa, 0x08, 0xb9, 0x12, 0x48, 0xbd,
-	0x44, 0x8e, 0x3d, 0x35, 0x16, 0xb5, 0x37, 0x64, 0x37, 0xa5, 0x7e, 0x0b, 0xc4, 0x43, 0xf0, 0x2c,
-	0x3d, 0xf6, 0xc8, 0x29, 0x42, 0xe9, 0x8d, 0xa7, 0x40, 0xbb, 0x5e, 0xbb, 0x46, 0xd4, 0xe5, 0x62,
-	0x79, 0x66, 0xff, 0xf9, 0x76, 0x66, 0x76, 0x76, 0xa1, 0xeb, 0x52, 0x16, 0x7e, 0x75, 0x58, 0xd8,
-	0x97, 0x9f, 0xb3, 0xed, 0x11, 0x72, 0x67, 0xbb, 0xef, 0x63, 0x84, 0x2c, 0x60, 0xbd, 0xf1, 0x84,
-	0x72, 0x4a, 0xee, 0xa5, 0xa2, 0x9e, 0xfc, 0x28, 0x51, 0x73, 0xcd, 0xa7, 0x3e, 0x95, 0x8a, 0xbe,
-	0xf8, 0x4b, 0xc4, 0xcd, 0x47, 0x37, 0x13, 0x79, 0x3c, 0x46, 0xc5, 0x6b, 0x9a, 0x05, 0x92, 0xf3,
-	0x64, 0xbd, 0x73, 0xa1, 0x43, 0xe3, 0x4d, 0x92, 0xc1, 0x11, 0x77, 0x38, 0x92, 0x17, 0x50, 0x1d,
-	0x3b, 0x13, 0x27, 0x64, 0x86, 0xd6, 0xd6, 0x36, 0x97, 0x76, 0x36, 0x7a, 0x37, 0x66, 0xd4, 0x7b,
-	0x2f, 0x45, 0x56, 0xe5, 0x62, 0xd6, 0x2a, 0xd9, 0x2a, 0x84, 0xbc, 0x05, 0xdd, 0xa5, 0x1e, 0x32,
-	0x63, 0xa1, 0x5d, 0xde, 0x5c, 0xda, 0x59, 0x2f, 0x88, 0xdd, 0xa5, 0x1e, 0x5a, 0x0f, 0x44, 0xe4,
-	0xef, 0x59, 0x6b, 0x59, 0x46, 0x3c, 0xa5, 0x61, 0xc0, 0x31, 0x1c, 0xf3, 0xd8, 0x4e, 0x10, 0xe4,
-	0x18, 0xea, 0x2e, 0x8d, 0xf8, 0xc4, 0x71, 0x39, 0x33, 0xca, 0x92, 0xd7, 0x2a, 0xe4, 0x25, 0x3a,
-	0x6b, 0x5d, 0x31, 0x57, 0xb3, 0xc8, 0x1c, 0xf7, 0x1a, 0x27, 0xd8, 0x0c, 0xbf, 0x4c, 0x31, 0x72,
-	0x91, 0x19, 0x95, 0x5b, 0xd9, 0x47, 0x4a, 0x77, 0xcd, 0xce, 0x22, 0xf3, 0xec, 0xcc, 0x49, 0x46,
-	0x50, 0xf3, 0x31, 0x1a, 0x86, 0xcc, 0x67, 0x86, 0x2e, 0xd1, 0x4f, 0x0a, 0xd0, 0xf9, 0xbe, 0x0b,
-	0xe3, 0x90, 0xf9, 0xcc, 0x6a, 0xaa, 0x6d, 0x48, 0x0a, 0xc9, 0xed, 0xb2, 0xe8, 0x27, 0xa2, 0xe6,
-	0xf7, 0x05, 0x58, 0x54, 0x01, 0x64, 0x0f, 0x80, 0x71, 0x3a, 0xc1, 0xa1, 0x68, 0x9b, 0x3a, 0xb4,
-	0x6e, 0xc1, 0x8e, 0x87, 0xcc, 0x3f, 0x12, 0x5a, 0x71, 0x00, 0xfb, 0x25, 0xbb, 0xce, 0x52, 0x83,
-	0x8c, 0x60, 0x2d, 0x88, 0x18, 0x77, 0x22, 0x1e, 0x38, 0x5c, 0xb0, 0x92, 0x56, 0x19, 0x0b, 0x92,
-	0xf7, 0xac, 0x98, 0x37, 0xb8, 0x8e, 0x4a, 0x8f, 0x61, 0xbf, 0x64, 0xaf, 0x06, 0xff, 0xba, 0xc9,
-	0x07, 0x58, 0xc1, 0x73, 0x74, 0xa7, 0x79, 0x7e, 0x59, 0xf2, 0xb7, 0x8a, 0xf9, 0xaf, 0x93, 0x88,
-	0x1c, 0x7b, 0x19, 0xff, 0x76, 0x59, 0x3a, 0x94, 0xd9, 0x34, 0xec, 0xfc, 0xd0, 0xa0, 0x22, 0x6b,
-	0xe9, 0xc2, 0xa2, 0xe8, 0xc5, 0x30, 0xf0, 0x64, 0x3b, 0x2a, 0x16, 0xcc, 0x67, 0xad, 0xaa, 0x58,
-	0x1a, 0xec, 0xd9, 0x55, 0xb1, 0x34, 0xf0, 0x88, 0x25, 0xc6, 0x4b, 0x88, 0xa2, 0x13, 0xaa, 0xaa,
-	0x6c, 0xdd, 0x32, 0xae, 0x83, 0xe8, 0x84, 0xaa, 0x61, 0xaf, 0xb9, 0xca, 0x26, 0x1b, 0x00, 0x92,
-	0x31, 0x8a, 0x39, 0x32, 0x59, 0x4a, 0xc3, 0x96, 0x54, 0x4b, 0x38, 0xc8, 0x7d, 0xa8, 0x8e, 0x83,
-	0x28, 0x42, 0xcf, 0xa8, 0xb4, 0xb5, 0xcd, 0x9a, 0xad, 0xac, 0xce, 0xa5, 0x06, 0xb5, 0xac, 0x29,
-	0x5b, 0xb0, 0x92, 0x36, 0x63, 0xe8, 0x78, 0xde, 0x04, 0x59, 0x72, 0xf3, 0xea, 0xf6, 0x72, 0xea,
-	0x7f, 0x95, 0xb8, 0xc9, 0x3b, 0xb8, 0x93, 0x49, 0x73, 0x69, 0x77, 0xff, 0x73, 0x2b, 0x72, 0xa9,
-	0x37, 0xdc, 0x9c, 0x8f, 0x0c, 0xe0, 0x6e, 0xc6, 0x63, 0x62, 0x08, 0xd5, 0x35, 0x7b, 0x58, 0x74,
-	0x1a, 0xd4, 0xc3, 0x53, 0x45, 0xca, 0x32, 0x91, 0xd3, 0xdb, 0xb1, 0xa0, 0x96, 0x5e, 0x14, 0xd2,
-	0x86, 0x6a, 0xe0, 0x0d, 0x3f, 0x63, 0x2c, 0xeb, 0x68, 0x58, 0xf5, 0xf9, 0xac, 0xa5, 0x0f, 0xf6,
-	0x0e, 0x30, 0xb6, 0xf5, 0xc0, 0x3b, 0xc0, 0x98, 0xac, 0x81, 0x7e, 0xe6, 0x9c, 0x4e, 0x51, 0x16,
-	0x50, 0xb1, 0x13, 0xc3, 0x7a, 0x79, 0x31, 0x37, 0xb5, 0xcb, 0xb9, 0xa9, 0xfd, 0x9a, 0x9b, 0xda,
-	0xb7, 0x2b, 0xb3, 0x74, 0x79, 0x65, 0x96, 0x7e, 0x5e, 0x99, 0xa5, 0xe3, 0xc7, 0x7e, 0xc0, 0x3f,
-	0x4d, 0x47, 0x3d, 0x97, 0x86, 0xfd, 0x5d, 0xca, 0xc2, 0x8f, 0xe9, 0x7b, 0xe6, 0xf5, 0xcf, 0x93,
-	0x77, 0x4d, 0x3e, 0x79, 0xa3, 0xaa, 0x7c, 0xd3, 0x9e, 0xff, 0x09, 0x00, 0x00, 0xff, 0xff, 0xb4,
-	0x5c, 0x72, 0x53, 0x6a, 0x05, 0x00, 0x00,
+	// 973 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x56, 0x4f, 0x6f, 0x13, 0x47,
+	0x14, 0xb7, 0x1d, 0xdb, 0xf1, 0x3e, 0x1c, 0x42, 0x27, 0x29, 0x6c, 0x9d, 0xc4, 0x4e, 0x9d, 0x42,
+	0x13, 0x15, 0x62, 0x41, 0x8f, 0xbd, 0xd0, 0x8d, 0xa1, 0x2c, 0x08, 0x44, 0x17, 0xa9, 0x95, 0x90,
+	0xaa, 0xd5, 0x7a, 0xf7, 0xc5, 0x99, 0x36, 0x3b, 0x63, 0x76, 0xc6, 0x10, 0xb7, 0x52, 0xbf, 0x41,
+	0xa5, 0x8a, 0x53, 0x4f, 0x3d, 0xf6, 0xb3, 0x70, 0xe4, 0xd8, 0x93, 0x55, 0x39, 0x37, 0x3e, 0x45,
+	0xb5, 0x33, 0xe3, 0xcd, 0xa6, 0x64, 0x5d, 0x2e, 0x9b, 0xec, 0x9b, 0xdf, 0x9f, 0x99, 0x37, 0xef,
+	0x3d, 0x2f, 0xec, 0x84, 0x5c, 0xc4, 0xaf, 0x02, 0x11, 0xf7, 0xd4, 0xe3, 0xe5, 0xed, 0x01, 0xca,
+	0xe0, 0x76, 0x6f, 0x88, 0x0c, 0x05, 0x15, 0xfb, 0xa3, 0x84, 0x4b, 0x4e, 0x3e, 0x9e, 0x83, 0xf6,
+	0xd5, 0xc3, 0x80, 0x5a, 0xeb, 0x43, 0x3e, 0xe4, 0x0a, 0xd1, 0x4b, 0xff, 0xd3, 0xe0, 0xd6, 0xa7,
+	0x17, 0x2b, 0xca, 0xc9, 0x08, 0x8d, 0x5e, 0xab, 0x5d, 0x00, 0x39, 0xd1, 0xeb, 0xdd, 0xdf, 0x00,
+	0x9a, 0xdf, 0xe8, 0x1d, 0x3c, 0x93, 0x81, 0x44, 0xf2, 0x15, 0xd4, 0x47, 0x41, 0x12, 0xc4, 0xc2,
+	0x2e, 0x6f, 0x97, 0x77, 0x2f, 0xdd, 0xd9, 0xda, 0xbf, 0x70, 0x47, 0xfb, 0x4f, 0x15, 0xc8, 0xa9,
+	0xbe, 0x99, 0x76, 0x4a, 0x9e, 0xa1, 0x90, 0x87, 0x50, 0x0b, 0x79, 0x84, 0xc2, 0xae, 0x6c, 0x2f,
+	0xed, 0x5e, 0xba, 0xb3, 0x51, 0xc0, 0x3d, 0xe0, 0x11, 0x3a, 0xd7, 0x52, 0xe6, 0xbb, 0x69, 0x67,
+	0x55, 0x31, 0x6e, 0xf2, 0x98, 0x4a, 0x8c, 0x47, 0x72, 0xe2, 0x69, 0x09, 0xf2, 0x1c, 0xac, 0x90,
+	0x33, 0x99, 0x04, 0xa1, 0x14, 0xf6, 0x92, 0xd2, 0xeb, 0x14, 0xea, 0x69, 0x9c, 0xb3, 0x61, 0x34,
+	0xd7, 0x32, 0x66, 0x4e, 0xf7, 0x4c, 0x2e, 0xd5, 0x16, 0xf8, 0x62, 0x8c, 0x2c, 0x44, 0x61, 0x57,
+	0x17, 0x6a, 0x3f, 0x33, 0xb8, 0x33, 0xed, 0x8c, 0x99, 0xd7, 0xce, 0x82, 0x64, 0x00, 0x8d, 0x21,
+	0x32, 0x3f, 0x16, 0x43, 0x61, 0xd7, 0x94, 0xf4, 0x17, 0x05, 0xd2, 0xf9, 0xbc, 0xa7, 0x2f, 0x8f,
+	0xc5, 0x50, 0x38, 0x2d, 0x63, 0x43, 0xe6, 0x22, 0x39, 0x97, 0xe5, 0xa1, 0x06, 0x91, 0x5f, 0x61,
+	0x4d, 0x48, 0x9e, 0xa0, 0x9f, 0xa6, 0xca, 0x8f, 0x70, 0xc4, 0x05, 0x95, 0xc2, 0xae, 0x2b, 0xbb,
+	0xcf, 0x8b, 0x4e, 0x92, 0x32, 0xd2, 0xd4, 0xf7, 0x35, 0xde, 0xb9, 0x6e, 0xac, 0xb6, 0x2e, 0xd0,
+	0xca, 0xb9, 0x7e, 0x24, 0xfe, 0x43, 0x14, 0xe4, 0x17, 0x20, 0x94, 0x49, 0x4c, 0xc2, 0xa3, 0x80,
+	0x32, 0xff, 0xc5, 0x18, 0x13, 0x8a, 0xc2, 0x5e, 0x56, 0xf6, 0x37, 0x0a, 0xec, 0xdd, 0x8c, 0xf0,
+	0xed, 0x18, 0x93, 0x89, 0xf3, 0x99, 0x71, 0xdf, 0x7c, 0x5f, 0x29, 0x6f, 0x4e, 0xcf, 0xd1, 0x28,
+	0x0a, 0x72, 0x1f, 0xaa, 0x3f, 0xf2, 0x81, 0xb0, 0x1b, 0xca, 0xae, 0x55, 0x60, 0xf7, 0x90, 0x0f,
+	0x9c, 0xab, 0xc6, 0xe2, 0x72, 0x8a, 0xcf, 0x89, 0x2a, 0x3e, 0xf9, 0xb3, 0x0c, 0x1b, 0x87, 0x09,
+	0xff, 0x19, 0x99, 0x1f, 0x1e, 0x53, 0x64, 0xd2, 0x67, 0x5c, 0xd2, 0x43, 0x1a, 0x06, 0x92, 0x72,
+	0x26, 0x6c, 0x4b, 0xe9, 0xf7, 0x0a, 0xf4, 0xef, 0x2b, 0xe6, 0x81, 0x22, 0x3e, 0xc9, 0xf1, 0x9c,
+	0x5b, 0xc6, 0xf4, 0xfa, 0x02, 0xed, 0xdc, 0x5e, 0x3e, 0x39, 0x2c, 0x10, 0x12, 0xe4, 0x07, 0x00,
+	0x75, 0x27, 0x42, 0x06, 0x52, 0xd8, 0xa0, 0xb6, 0xb3, 0xbd, 0xa0, 0xa5, 0xd2, 0x42, 0x12, 0xce,
+	0xa6, 0xf1, 0x5f, 0x3f, 0xe3, 0x9e, 0x6f, 0x02, 0x03, 0x6c, 0xbd, 0xae, 0xc0, 0xb2, 0xa9, 0x3a,
+	0xd2, 0x07, 0x38, 0x2b, 0x02, 0xd3, 0xf9, 0x3b, 0x05, 0x56, 0x8f, 0xc5, 0x30, 0x2b, 0xa5, 0x07,
+	0x25, 0xcf, 0xca, 0xca, 0x83, 0x0c, 0x60, 0x9d, 0x32, 0x21, 0x03, 0x26, 0x69, 0x20, 0xd1, 0x9f,
+	0xf7, 0x9b, 0x5d, 0x51, 0x7a, 0xb7, 0x8a, 0xf5, 0xdc, 0x33, 0xd6, 0xbc, 0x97, 0x1f, 0x94, 0xbc,
+	0x35, 0xfa, 0x7e, 0x98, 0x7c, 0x07, 0x57, 0xf0, 0x04, 0xc3, 0x71, 0x5e, 0x7f, 0x49, 0xe9, 0xef,
+	0x15, 0xeb, 0xdf, 0xd3, 0x8c, 0x9c, 0xf6, 0x2a, 0x9e, 0x0f, 0x39, 0x35, 0x58, 0x12, 0xe3, 0xb8,
+	0xfb, 0x57, 0x19, 0xaa, 0xea, 0x2c, 0x3b, 0xb0, 0xac, 0x12, 0x48, 0x23, 0x95, 0x8e, 0xaa, 0x03,
+	0xb3, 0x69, 0xa7, 0x9e, 0x2e, 0xb9, 0x7d, 0xaf, 0x9e, 0x2e, 0xb9, 0x11, 0x71, 0xc0, 0xd2, 0x20,
+	0x76, 0xc8, 0xcd, 0x29, 0x3b, 0x0b, 0x2e, 0xc8, 0x65, 0x87, 0xdc, 0x4c, 0xcc, 0x46, 0x68, 0xde,
+	0xc9, 0x96, 0xb9, 0xe5, 0xc1, 0x44, 0xa2, 0x50, 0x47, 0x69, 0xea, 0x5b, 0x72, 0xd2, 0x00, 0xb9,
+	0x0a, 0xf5, 0x11, 0x65, 0x0c, 0x23, 0xbb, 0xba, 0x5d, 0xde, 0x6d, 0x78, 0xe6, 0xad, 0xfb, 0xba,
+	0x02, 0x8d, 0x2c, 0x29, 0x7b, 0x70, 0x65, 0x9e, 0x0c, 0x3f, 0x88, 0xa2, 0x04, 0x85, 0x1e, 0xdf,
+	0x96, 0xb7, 0x3a, 0x8f, 0x7f, 0xad, 0xc3, 0xe4, 0x09, 0xac, 0x64, 0xd0, 0xdc, 0xb6, 0x77, 0xfe,
+	0x67, 0xb4, 0xe6, 0xb6, 0xde, 0x0c, 0x73, 0x31, 0xe2, 0xc2, 0xe5, 0x4c, 0x2f, 0x2d, 0x36, 0x34,
+	0xb3, 0x7a, 0xb3, 0xe8, 0x36, 0x78, 0x84, 0xc7, 0x46, 0x29, 0xdb, 0x89, 0xfe, 0xe9, 0xb9, 0x0b,
+	0x2b, 0x11, 0x32, 0x8a, 0x91, 0x1f, 0x21, 0xe3, 0xb1, 0x9e, 0xcc, 0x96, 0xb3, 0xf1, 0x6e, 0xda,
+	0xb9, 0x76, 0x6e, 0x21, 0x57, 0xcf, 0x4d, 0xbd, 0xd0, 0x57, 0xf1, 0xae, 0x03, 0x8d, 0xf9, 0xbc,
+	0x26, 0xdb, 0x50, 0xa7, 0x91, 0xff, 0x13, 0x4e, 0x54, 0x26, 0x9a, 0x8e, 0x35, 0x9b, 0x76, 0x6a,
+	0x6e, 0xff, 0x11, 0x4e, 0xbc, 0x1a, 0x8d, 0x1e, 0xe1, 0x84, 0xac, 0x43, 0xed, 0x65, 0x70, 0x3c,
+	0x46, 0x95, 0x82, 0xaa, 0xa7, 0x5f, 0xba, 0x7f, 0x94, 0xc1, 0x2e, 0x6a, 0x6e, 0xb2, 0x07, 0x96,
+	0xe9, 0x67, 0x53, 0x17, 0x96, 0xd3, 0x9c, 0x4d, 0x3b, 0x0d, 0x0d, 0x75, 0xfb, 0x5e, 0x43, 0x2f,
+	0xbb, 0x51, 0x5a, 0x40, 0x23, 0x9e, 0x28, 0x60, 0x45, 0x01, 0x55, 0x01, 0x3d, 0xe5, 0x49, 0x0a,
+	0xab, 0xa7, 0x4b, 0x6e, 0x44, 0x6e, 0x02, 0x84, 0x47, 0x01, 0x63, 0x78, 0x9c, 0xe2, 0x96, 0x14,
+	0x6e, 0x65, 0x36, 0xed, 0x58, 0x07, 0x3a, 0xea, 0xf6, 0x3d, 0xcb, 0x00, 0xdc, 0xa8, 0xfb, 0x0a,
+	0xac, 0xac, 0xcf, 0x3f, 0xac, 0x40, 0xef, 0x41, 0x4d, 0x4f, 0x8f, 0xca, 0xc2, 0x16, 0x49, 0x59,
+	0xba, 0x47, 0x28, 0x67, 0x7a, 0x8c, 0xe8, 0x1b, 0xd2, 0x6c, 0xe7, 0xee, 0x9b, 0x59, 0xbb, 0xfc,
+	0x76, 0xd6, 0x2e, 0xff, 0x33, 0x6b, 0x97, 0x7f, 0x3f, 0x6d, 0x97, 0xde, 0x9e, 0xb6, 0x4b, 0x7f,
+	0x9f, 0xb6, 0x4b, 0xcf, 0x6f, 0x0c, 0xa9, 0x3c, 0x1a, 0x0f, 0xf6, 0x43, 0x1e, 0xf7, 0x0e, 0xb8,
+	0x88, 0xbf, 0x9f, 0x7f, 0x6a, 0x44, 0xbd, 0x13, 0xf5, 0x57, 0x7f, 0x8d, 0x0c, 0xea, 0xea, 0x73,
+	0xe3, 0xcb, 0x7f, 0x07, 0x00, 0x1d, 0xf0, 0x86, 0x76, 0x05, 0x09, 0x00, 0x00,
 }
 
 func (m *GenesisState) Marshal() (dAtA []byte, err error) {
@@ -463,6 +649,76 @@ func (m *GenesisState) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.CodeStats) > 0 {
+		for iNdEx := len(m.CodeStats) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.CodeStats[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x52
+		}
+	}
+	if len(m.FrozenClientNotifications) > 0 {
+		for iNdEx := len(m.FrozenClientNotifications) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.FrozenClientNotifications[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x4a
+		}
+	}
+	if len(m.Jobs) > 0 {
+		for iNdEx := len(m.Jobs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Jobs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x42
+		}
+	}
+	if len(m.InterchainQueries) > 0 {
+		for iNdEx := len(m.InterchainQueries) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.InterchainQueries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if len(m.StoreCodeDeposits) > 0 {
+		for iNdEx := len(m.StoreCodeDeposits) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.StoreCodeDeposits[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
+	}
 	if len(m.GenMsgs) > 0 {
 		for iNdEx := len(m.GenMsgs) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -702,6 +958,15 @@ func (m *Contract) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.DeniedDenoms) > 0 {
+		for iNdEx := len(m.DeniedDenoms) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.DeniedDenoms[iNdEx])
+			copy(dAtA[i:], m.DeniedDenoms[iNdEx])
+			i = encodeVarintGenesis(dAtA, i, uint64(len(m.DeniedDenoms[iNdEx])))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
 	if len(m.ContractState) > 0 {
 		for iNdEx := len(m.ContractState) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -771,6 +1036,88 @@ func (m *Sequence) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *FrozenClientNotification) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FrozenClientNotification) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FrozenClientNotification) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ChannelID) > 0 {
+		i -= len(m.ChannelID)
+		copy(dAtA[i:], m.ChannelID)
+		i = encodeVarintGenesis(dAtA, i, uint64(len(m.ChannelID)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.PortID) > 0 {
+		i -= len(m.PortID)
+		copy(dAtA[i:], m.PortID)
+		i = encodeVarintGenesis(dAtA, i, uint64(len(m.PortID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ClientID) > 0 {
+		i -= len(m.ClientID)
+		copy(dAtA[i:], m.ClientID)
+		i = encodeVarintGenesis(dAtA, i, uint64(len(m.ClientID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CodeStats) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CodeStats) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CodeStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.Stats.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintGenesis(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	if m.CodeID != 0 {
+		i = encodeVarintGenesis(dAtA, i, uint64(m.CodeID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintGenesis(dAtA []byte, offset int, v uint64) int {
 	offset -= sovGenesis(v)
 	base := offset
@@ -814,6 +1161,36 @@ func (m *GenesisState) Size() (n int) {
 			n += 1 + l + sovGenesis(uint64(l))
 		}
 	}
+	if len(m.StoreCodeDeposits) > 0 {
+		for _, e := range m.StoreCodeDeposits {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
+	if len(m.InterchainQueries) > 0 {
+		for _, e := range m.InterchainQueries {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
+	if len(m.Jobs) > 0 {
+		for _, e := range m.Jobs {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
+	if len(m.FrozenClientNotifications) > 0 {
+		for _, e := range m.FrozenClientNotifications {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
+	if len(m.CodeStats) > 0 {
+		for _, e := range m.CodeStats {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -904,6 +1281,12 @@ func (m *Contract) Size() (n int) {
 			n += 1 + l + sovGenesis(uint64(l))
 		}
 	}
+	if len(m.DeniedDenoms) > 0 {
+		for _, s := range m.DeniedDenoms {
+			l = len(s)
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -923,11 +1306,46 @@ func (m *Sequence) Size() (n int) {
 	return n
 }
 
-func sovGenesis(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
+func (m *FrozenClientNotification) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ClientID)
+	if l > 0 {
+		n += 1 + l + sovGenesis(uint64(l))
+	}
+	l = len(m.PortID)
+	if l > 0 {
+		n += 1 + l + sovGenesis(uint64(l))
+	}
+	l = len(m.ChannelID)
+	if l > 0 {
+		n += 1 + l + sovGenesis(uint64(l))
+	}
+	return n
 }
-func sozGenesis(x uint64) (n int) {
-	return sovGenesis(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+
+func (m *CodeStats) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CodeID != 0 {
+		n += 1 + sovGenesis(uint64(m.CodeID))
+	}
+	l = m.Stats.Size()
+	n += 1 + l + sovGenesis(uint64(l))
+	return n
+}
+
+func sovGenesis(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozGenesis(x uint64) (n int) {
+	return sovGenesis(uint64((x << 1) ^ uint64((int64(x) >> 63))))
 }
 func (m *GenesisState) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
@@ -1127,6 +1545,176 @@ func (m *GenesisState) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StoreCodeDeposits", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.StoreCodeDeposits = append(m.StoreCodeDeposits, StoreCodeDeposit{})
+			if err := m.StoreCodeDeposits[len(m.StoreCodeDeposits)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InterchainQueries", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.InterchainQueries = append(m.InterchainQueries, InterchainQuery{})
+			if err := m.InterchainQueries[len(m.InterchainQueries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Jobs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Jobs = append(m.Jobs, Job{})
+			if err := m.Jobs[len(m.Jobs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FrozenClientNotifications", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.FrozenClientNotifications = append(m.FrozenClientNotifications, FrozenClientNotification{})
+			if err := m.FrozenClientNotifications[len(m.FrozenClientNotifications)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CodeStats", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CodeStats = append(m.CodeStats, CodeStats{})
+			if err := m.CodeStats[len(m.CodeStats)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenesis(dAtA[iNdEx:])
@@ -1587,6 +2175,38 @@ func (m *Contract) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeniedDenoms", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DeniedDenoms = append(m.DeniedDenoms, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenesis(dAtA[iNdEx:])
@@ -1711,6 +2331,254 @@ func (m *Sequence) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *FrozenClientNotification) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenesis
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FrozenClientNotification: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FrozenClientNotification: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClientID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PortID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PortID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChannelID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChannelID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenesis(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CodeStats) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenesis
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CodeStats: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CodeStats: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CodeID", wireType)
+			}
+			m.CodeID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CodeID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Stats", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Stats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenesis(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipGenesis(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"testing"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stretchr/testify/require"
 )
 
@@ -57,6 +58,28 @@ func TestValidateGenesisState(t *testing.T) {
 			},
 			expError: true,
 		},
+		"frozen client notification valid": {
+			srcMutator: func(s *GenesisState) {
+				s.FrozenClientNotifications = []FrozenClientNotification{{ClientID: "07-tendermint-0", PortID: "wasm.myport", ChannelID: "channel-0"}}
+			},
+		},
+		"frozen client notification invalid": {
+			srcMutator: func(s *GenesisState) {
+				s.FrozenClientNotifications = []FrozenClientNotification{{ClientID: "07-tendermint-0", PortID: "wasm.myport"}}
+			},
+			expError: true,
+		},
+		"code stats valid": {
+			srcMutator: func(s *GenesisState) {
+				s.CodeStats = []CodeStats{{CodeID: 1, Stats: CodeExecutionStats{Epoch: 1, Executions: 2, GasUsed: 3}}}
+			},
+		},
+		"code stats without code id": {
+			srcMutator: func(s *GenesisState) {
+				s.CodeStats = []CodeStats{{Stats: CodeExecutionStats{Epoch: 1, Executions: 2, GasUsed: 3}}}
+			},
+			expError: true,
+		},
 		"genesis invalid message type": {
 			srcMutator: func(s *GenesisState) {
 				s.GenMsgs[0].Sum = nil
@@ -157,6 +180,29 @@ func TestContractValidateBasic(t *testing.T) {
 			},
 			expError: true,
 		},
+		"contract state key too long": {
+			srcMutator: func(c *Contract) {
+				c.ContractState = append(c.ContractState, Model{Key: bytes.Repeat([]byte{0x1}, MaxContractStateKeySize+1)})
+			},
+			expError: true,
+		},
+		"contract state value too long": {
+			srcMutator: func(c *Contract) {
+				c.ContractState = append(c.ContractState, Model{Key: []byte{0x1}, Value: bytes.Repeat([]byte{0x1}, MaxContractStateValueSize+1)})
+			},
+			expError: true,
+		},
+		"contract state with max key and value size": {
+			srcMutator: func(c *Contract) {
+				c.ContractState = []Model{{Key: bytes.Repeat([]byte{0x1}, MaxContractStateKeySize), Value: bytes.Repeat([]byte{0x1}, MaxContractStateValueSize)}}
+			},
+		},
+		"contract state key with contract store prefix": {
+			srcMutator: func(c *Contract) {
+				addr, _ := sdk.AccAddressFromBech32(c.ContractAddress)
+				c.ContractState = append(c.ContractState, Model{Key: append(GetContractStorePrefix(addr), 0x1)})
+			},
+		},
 	}
 	for msg, spec := range specs {
 		t.Run(msg, func(t *testing.T) {
@@ -71,6 +71,46 @@ func (m *MsgIBCSend) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_MsgIBCSend proto.InternalMessageInfo
 
+// MsgIBCSendResponse is returned as data to the contract when an IBC packet
+// was sent within a submessage
+type MsgIBCSendResponse struct {
+	// Sequence number of the IBC packet sent
+	Sequence uint64 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+}
+
+func (m *MsgIBCSendResponse) Reset()         { *m = MsgIBCSendResponse{} }
+func (m *MsgIBCSendResponse) String() string { return proto.CompactTextString(m) }
+func (*MsgIBCSendResponse) ProtoMessage()    {}
+func (*MsgIBCSendResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_62898492b0dd5f88, []int{1}
+}
+func (m *MsgIBCSendResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MsgIBCSendResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MsgIBCSendResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MsgIBCSendResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MsgIBCSendResponse.Merge(m, src)
+}
+func (m *MsgIBCSendResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *MsgIBCSendResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MsgIBCSendResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MsgIBCSendResponse proto.InternalMessageInfo
+
 // MsgIBCCloseChannel port and channel need to be owned by the contract
 type MsgIBCCloseChannel struct {
 	Channel string `protobuf:"bytes,2,opt,name=channel,proto3" json:"channel,omitempty" yaml:"source_channel"`
@@ -80,7 +120,7 @@ func (m *MsgIBCCloseChannel) Reset()         { *m = MsgIBCCloseChannel{} }
 func (m *MsgIBCCloseChannel) String() string { return proto.CompactTextString(m) }
 func (*MsgIBCCloseChannel) ProtoMessage()    {}
 func (*MsgIBCCloseChannel) Descriptor() ([]byte, []int) {
-	return fileDescriptor_62898492b0dd5f88, []int{1}
+	return fileDescriptor_62898492b0dd5f88, []int{2}
 }
 func (m *MsgIBCCloseChannel) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -111,34 +151,37 @@ var xxx_messageInfo_MsgIBCCloseChannel proto.InternalMessageInfo
 
 func init() {
 	proto.RegisterType((*MsgIBCSend)(nil), "cosmwasm.wasm.v1beta1.MsgIBCSend")
+	proto.RegisterType((*MsgIBCSendResponse)(nil), "cosmwasm.wasm.v1beta1.MsgIBCSendResponse")
 	proto.RegisterType((*MsgIBCCloseChannel)(nil), "cosmwasm.wasm.v1beta1.MsgIBCCloseChannel")
 }
 
 func init() { proto.RegisterFile("cosmwasm/wasm/v1beta1/ibc.proto", fileDescriptor_62898492b0dd5f88) }
 
 var fileDescriptor_62898492b0dd5f88 = []byte{
-	// 333 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x91, 0x31, 0x4f, 0xc2, 0x40,
-	0x14, 0xc7, 0x5b, 0x82, 0x18, 0x2f, 0x6a, 0xb4, 0x91, 0xa4, 0x1a, 0x72, 0x25, 0x1d, 0x0c, 0x53,
-	0x2b, 0x61, 0x73, 0x32, 0xad, 0x83, 0x0c, 0x2c, 0xd5, 0xc4, 0xc4, 0x85, 0x5c, 0xdb, 0xcb, 0xb5,
-	0x86, 0xbb, 0x47, 0xb8, 0x43, 0x64, 0xf3, 0x23, 0xf8, 0xb1, 0x18, 0x19, 0x9d, 0x88, 0xc2, 0x37,
-	0x60, 0x74, 0x32, 0x1c, 0xad, 0x91, 0xd5, 0xe5, 0xdd, 0xdd, 0xff, 0xfd, 0xee, 0x25, 0xef, 0xff,
-	0x47, 0x4e, 0x02, 0x92, 0x4f, 0x88, 0xe4, 0xbe, 0x2e, 0x2f, 0xed, 0x98, 0x2a, 0xd2, 0xf6, 0xf3,
-	0x38, 0xf1, 0x86, 0x23, 0x50, 0x60, 0xd5, 0x4b, 0xc0, 0xd3, 0xa5, 0x00, 0x2e, 0xce, 0x18, 0x30,
-	0xd0, 0x84, 0xbf, 0xb9, 0x6d, 0x61, 0xf7, 0xad, 0x82, 0x50, 0x4f, 0xb2, 0x6e, 0x10, 0xde, 0x53,
-	0x91, 0x5a, 0x1d, 0xb4, 0x9f, 0x64, 0x44, 0x08, 0x3a, 0xb0, 0x2b, 0x4d, 0xb3, 0x75, 0x10, 0x9c,
-	0xaf, 0x17, 0x4e, 0x7d, 0x4a, 0xf8, 0xe0, 0xda, 0x95, 0x30, 0x1e, 0x25, 0xb4, 0x5f, 0xf4, 0xdd,
-	0xa8, 0x24, 0xad, 0x1b, 0x74, 0xac, 0x72, 0x4e, 0x61, 0xac, 0xfa, 0x19, 0xcd, 0x59, 0xa6, 0xec,
-	0x6a, 0xd3, 0x6c, 0x55, 0xff, 0xfe, 0xdd, 0xed, 0xbb, 0xd1, 0x51, 0x21, 0xdc, 0xe9, 0xb7, 0xd5,
-	0x45, 0xa7, 0x25, 0xb1, 0x39, 0xa5, 0x22, 0x7c, 0x68, 0xef, 0xe9, 0x21, 0x8d, 0xf5, 0xc2, 0xb1,
-	0x77, 0x87, 0xfc, 0x22, 0x6e, 0x74, 0x52, 0x68, 0x0f, 0xa5, 0x64, 0x5d, 0xa1, 0x6a, 0x4a, 0x14,
-	0xb1, 0x6b, 0x4d, 0xb3, 0x75, 0x18, 0x34, 0xbe, 0x17, 0x8e, 0x4d, 0x45, 0x02, 0x69, 0x2e, 0x98,
-	0xff, 0x2c, 0x41, 0x78, 0x11, 0x99, 0xf4, 0xa8, 0x94, 0x84, 0xd1, 0x48, 0x93, 0x6e, 0x17, 0x59,
-	0x5b, 0x07, 0xc2, 0x01, 0x48, 0x1a, 0x16, 0x4b, 0xfd, 0xc7, 0x89, 0xe0, 0x76, 0xf6, 0x85, 0x8d,
-	0xd9, 0x12, 0x9b, 0xf3, 0x25, 0x36, 0x3f, 0x97, 0xd8, 0x7c, 0x5f, 0x61, 0x63, 0xbe, 0xc2, 0xc6,
-	0xc7, 0x0a, 0x1b, 0x4f, 0x97, 0x2c, 0x57, 0xd9, 0x38, 0xf6, 0x12, 0xe0, 0x7e, 0x08, 0x92, 0x3f,
-	0x96, 0x21, 0xa6, 0xfe, 0xeb, 0x36, 0x4c, 0x35, 0x1d, 0x52, 0x19, 0xd7, 0x74, 0x34, 0x9d, 0x9f,
-	0x00, 0x00, 0x00, 0xff, 0xff, 0x9f, 0xe8, 0x9b, 0xa4, 0xea, 0x01, 0x00, 0x00,
+	// 356 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x91, 0x31, 0x4f, 0xea, 0x50,
+	0x14, 0xc7, 0x5b, 0xc2, 0xe3, 0xbd, 0x77, 0xa3, 0x46, 0x1b, 0x49, 0x2a, 0x21, 0x2d, 0xe9, 0x60,
+	0x98, 0x5a, 0x08, 0x9b, 0x93, 0x69, 0x1d, 0x64, 0x60, 0xa9, 0x26, 0x26, 0x2e, 0xe4, 0xb6, 0x3d,
+	0x69, 0x6b, 0xe8, 0xbd, 0x95, 0x73, 0x2b, 0xb2, 0xf9, 0x11, 0xfc, 0x58, 0x8c, 0x8c, 0x4e, 0x44,
+	0xe1, 0x1b, 0x30, 0x3a, 0x19, 0x4a, 0x8b, 0xb2, 0xba, 0x9c, 0xf6, 0xfc, 0xcf, 0xef, 0x9c, 0xdc,
+	0xfc, 0xff, 0x44, 0xf7, 0x39, 0x26, 0x13, 0x8a, 0x89, 0x95, 0x97, 0xa7, 0xae, 0x07, 0x82, 0x76,
+	0xad, 0xd8, 0xf3, 0xcd, 0x74, 0xcc, 0x05, 0x57, 0xea, 0x25, 0x60, 0xe6, 0xa5, 0x00, 0x1a, 0xa7,
+	0x21, 0x0f, 0x79, 0x4e, 0x58, 0x9b, 0xbf, 0x2d, 0x6c, 0xbc, 0x54, 0x08, 0x19, 0x60, 0xd8, 0xb7,
+	0x9d, 0x1b, 0x60, 0x81, 0xd2, 0x23, 0x7f, 0xfd, 0x88, 0x32, 0x06, 0x23, 0xb5, 0xd2, 0x92, 0xdb,
+	0xff, 0xed, 0xb3, 0xf5, 0x42, 0xaf, 0x4f, 0x69, 0x32, 0xba, 0x30, 0x90, 0x67, 0x63, 0x1f, 0x86,
+	0xc5, 0xdc, 0x70, 0x4b, 0x52, 0xb9, 0x24, 0x47, 0x22, 0x4e, 0x80, 0x67, 0x62, 0x18, 0x41, 0x1c,
+	0x46, 0x42, 0xad, 0xb6, 0xe4, 0x76, 0xf5, 0xe7, 0xee, 0xfe, 0xdc, 0x70, 0x0f, 0x0b, 0xe1, 0x3a,
+	0xef, 0x95, 0x3e, 0x39, 0x29, 0x89, 0xcd, 0x17, 0x05, 0x4d, 0x52, 0xf5, 0x4f, 0x7e, 0xa4, 0xb9,
+	0x5e, 0xe8, 0xea, 0xfe, 0x91, 0x1d, 0x62, 0xb8, 0xc7, 0x85, 0x76, 0x5b, 0x4a, 0x4a, 0x87, 0x54,
+	0x03, 0x2a, 0xa8, 0x5a, 0x6b, 0xc9, 0xed, 0x03, 0xbb, 0xf9, 0xb9, 0xd0, 0x55, 0x60, 0x3e, 0x0f,
+	0x62, 0x16, 0x5a, 0x0f, 0xc8, 0x99, 0xe9, 0xd2, 0xc9, 0x00, 0x10, 0x69, 0x08, 0x6e, 0x4e, 0x1a,
+	0x1d, 0xa2, 0x7c, 0x3b, 0xe0, 0x02, 0xa6, 0x9c, 0x21, 0x28, 0x0d, 0xf2, 0x0f, 0xe1, 0x31, 0x03,
+	0xe6, 0x83, 0x2a, 0x6f, 0x5e, 0xe2, 0xee, 0x7a, 0xa3, 0x5f, 0x6e, 0x38, 0x23, 0x8e, 0xe0, 0x14,
+	0x36, 0xfc, 0xc6, 0x3b, 0xfb, 0x6a, 0xf6, 0xa1, 0x49, 0xb3, 0xa5, 0x26, 0xcf, 0x97, 0x9a, 0xfc,
+	0xbe, 0xd4, 0xe4, 0xd7, 0x95, 0x26, 0xcd, 0x57, 0x9a, 0xf4, 0xb6, 0xd2, 0xa4, 0xfb, 0xf3, 0x30,
+	0x16, 0x51, 0xe6, 0x99, 0x3e, 0x4f, 0x2c, 0x87, 0x63, 0x72, 0x57, 0xc6, 0x1e, 0x58, 0xcf, 0xdb,
+	0xf8, 0xc5, 0x34, 0x05, 0xf4, 0x6a, 0x79, 0x98, 0xbd, 0xaf, 0x01, 0x00, 0x5a, 0x66, 0x5f, 0xbf,
+	0x1c, 0x02, 0x00, 0x00,
 }
 
 func (m *MsgIBCSend) Marshal() (dAtA []byte, err error) {
@@ -188,6 +231,34 @@ func (m *MsgIBCSend) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *MsgIBCSendResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MsgIBCSendResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MsgIBCSendResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Sequence != 0 {
+		i = encodeVarintIbc(dAtA, i, uint64(m.Sequence))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *MsgIBCCloseChannel) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -252,6 +323,18 @@ func (m *MsgIBCSend) Size() (n int) {
 	return n
 }
 
+func (m *MsgIBCSendResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Sequence != 0 {
+		n += 1 + sovIbc(uint64(m.Sequence))
+	}
+	return n
+}
+
 func (m *MsgIBCCloseChannel) Size() (n int) {
 	if m == nil {
 		return 0
@@ -425,6 +508,75 @@ func (m *MsgIBCSend) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *MsgIBCSendResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIbc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MsgIBCSendResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MsgIBCSendResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sequence", wireType)
+			}
+			m.Sequence = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIbc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Sequence |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIbc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIbc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *MsgIBCCloseChannel) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -0,0 +1,66 @@
+package types
+
+import (
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	host "github.com/cosmos/cosmos-sdk/x/ibc/core/24-host"
+)
+
+const (
+	// MaxInterchainQueryKeys is the max number of keys of an interchain query
+	MaxInterchainQueryKeys = 16
+	// MaxInterchainQueryKeySize is the max length of a key of an interchain query
+	MaxInterchainQueryKeySize = 512
+)
+
+// ValidateInterchainQueryKeys ensures that the keys of an interchain query are valid and unique
+func ValidateInterchainQueryKeys(keys []InterchainQueryKey) error {
+	if len(keys) == 0 {
+		return sdkerrors.Wrap(ErrEmpty, "keys")
+	}
+	if len(keys) > MaxInterchainQueryKeys {
+		return sdkerrors.Wrapf(ErrLimit, "more than %d keys", MaxInterchainQueryKeys)
+	}
+	unique := make(map[string]struct{}, len(keys))
+	for i, k := range keys {
+		if k.Path == "" || strings.TrimSpace(k.Path) != k.Path || strings.Contains(k.Path, "/") {
+			return sdkerrors.Wrapf(ErrInvalid, "path of key %d", i)
+		}
+		if len(k.Key) == 0 {
+			return sdkerrors.Wrapf(ErrEmpty, "key %d", i)
+		}
+		if len(k.Key) > MaxInterchainQueryKeySize {
+			return sdkerrors.Wrapf(ErrLimit, "key %d exceeds %d bytes", i, MaxInterchainQueryKeySize)
+		}
+		id := k.Path + "/" + string(k.Key)
+		if _, exists := unique[id]; exists {
+			return sdkerrors.Wrapf(ErrDuplicate, "key %d", i)
+		}
+		unique[id] = struct{}{}
+	}
+	return nil
+}
+
+func (q InterchainQuery) ValidateBasic() error {
+	if q.ID == 0 {
+		return sdkerrors.Wrap(ErrEmpty, "id")
+	}
+	if _, err := sdk.AccAddressFromBech32(q.Owner); err != nil {
+		return sdkerrors.Wrap(err, "owner")
+	}
+	if err := host.ConnectionIdentifierValidator(q.ConnectionID); err != nil {
+		return sdkerrors.Wrap(err, "connection id")
+	}
+	if err := ValidateInterchainQueryKeys(q.Keys); err != nil {
+		return sdkerrors.Wrap(err, "keys")
+	}
+	if q.UpdatePeriod == 0 {
+		return sdkerrors.Wrap(ErrEmpty, "update period")
+	}
+	if q.LastSubmittedHeight < 0 {
+		return sdkerrors.Wrap(ErrInvalid, "last submitted height")
+	}
+	return nil
+}
@@ -2,12 +2,16 @@ package types
 
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	host "github.com/cosmos/cosmos-sdk/x/ibc/core/24-host"
 )
 
 const (
 	// ModuleName is the name of the contract module
 	ModuleName = "wasm"
 
+	// DepositEscrowName is the name of the module account that holds the store code deposits in escrow
+	DepositEscrowName = ModuleName + "_deposit"
+
 	// StoreKey is the string store representation
 	StoreKey = ModuleName
 
@@ -19,6 +23,9 @@ const (
 
 	// RouterKey is the msg router key for the wasm module
 	RouterKey = ModuleName
+
+	// ModuleConsensusVersion is the version of the wasm module state. It must be increased with every state migration.
+	ModuleConsensusVersion = 2
 )
 
 // nolint
@@ -30,9 +37,22 @@ var (
 	ContractCodeHistoryElementPrefix               = []byte{0x05}
 	ContractByCodeIDAndCreatedSecondaryIndexPrefix = []byte{0x06}
 	PinnedCodeIndexPrefix                          = []byte{0x07}
-
-	KeyLastCodeID     = append(SequenceKeyPrefix, []byte("lastCodeId")...)
-	KeyLastInstanceID = append(SequenceKeyPrefix, []byte("lastContractId")...)
+	PacketReplyPrefix                              = []byte{0x08}
+	PortIDToContractPrefix                         = []byte{0x09}
+	FrozenClientNotifiedPrefix                     = []byte{0x0a}
+	CodeExecutionStatsPrefix                       = []byte{0x0b}
+	ContractDeniedDenomPrefix                      = []byte{0x0c}
+	StoreCodeDepositPrefix                         = []byte{0x0d}
+	InterchainQueryPrefix                          = []byte{0x0e}
+	InterchainQueryByOwnerPrefix                   = []byte{0x0f}
+	TimeoutClosedChannelPrefix                     = []byte{0x10}
+	JobPrefix                                      = []byte{0x11}
+	FrozenClientScanCursorKey                      = []byte{0x12}
+
+	KeyLastCodeID            = append(SequenceKeyPrefix, []byte("lastCodeId")...)
+	KeyLastInstanceID        = append(SequenceKeyPrefix, []byte("lastContractId")...)
+	KeyLastInterchainQueryID = append(SequenceKeyPrefix, []byte("lastInterchainQueryId")...)
+	KeyLastJobID             = append(SequenceKeyPrefix, []byte("lastJobId")...)
 )
 
 // GetCodeKey constructs the key for retreiving the ID for the WASM code
@@ -56,7 +76,7 @@ func GetContractStorePrefix(addr sdk.AccAddress) []byte {
 func GetContractByCreatedSecondaryIndexKey(contractAddr sdk.AccAddress, c ContractCodeHistoryEntry) []byte {
 	prefix := GetContractByCodeIDSecondaryIndexPrefix(c.CodeID)
 	prefixLen := len(prefix)
-	r := make([]byte, prefixLen+AbsoluteTxPositionLen+sdk.AddrLen)
+	r := make([]byte, prefixLen+AbsoluteTxPositionLen+len(contractAddr))
 	copy(r[0:], prefix)
 	copy(r[prefixLen:], c.Updated.Bytes())
 	copy(r[prefixLen+AbsoluteTxPositionLen:], contractAddr)
@@ -86,7 +106,7 @@ func GetContractCodeHistoryElementKey(contractAddr sdk.AccAddress, pos uint64) [
 // GetContractCodeHistoryElementPrefix returns the key prefix for a contract code history entry: `<prefix><contractAddr>`
 func GetContractCodeHistoryElementPrefix(contractAddr sdk.AccAddress) []byte {
 	prefixLen := len(ContractCodeHistoryElementPrefix)
-	r := make([]byte, prefixLen+sdk.AddrLen)
+	r := make([]byte, prefixLen+len(contractAddr))
 	copy(r[0:], ContractCodeHistoryElementPrefix)
 	copy(r[prefixLen:], contractAddr)
 	return r
@@ -105,3 +125,89 @@ func GetPinnedCodeIndexPrefix(codeID uint64) []byte {
 func ParsePinnedCodeIndex(s []byte) uint64 {
 	return sdk.BigEndianToUint64(s)
 }
+
+// GetPacketReplyChannelPrefix returns the key prefix for the packet reply references of a channel:
+// `<prefix><portID>/<channelID>/`
+func GetPacketReplyChannelPrefix(portID, channelID string) []byte {
+	return append(append([]byte{}, PacketReplyPrefix...), portID+"/"+channelID+"/"...)
+}
+
+// GetPacketReplyKey returns the key for a packet reply reference: `<prefix><portID>/<channelID>/<sequence>`
+func GetPacketReplyKey(portID, channelID string, sequence uint64) []byte {
+	return append(GetPacketReplyChannelPrefix(portID, channelID), sdk.Uint64ToBigEndian(sequence)...)
+}
+
+// GetPortIDToContractKey returns the key for the contract that is bound to the IBC port: `<prefix><portID>`
+func GetPortIDToContractKey(portID string) []byte {
+	return append(append([]byte{}, PortIDToContractPrefix...), portID...)
+}
+
+// GetFrozenClientNotifiedKey returns the key for a contract channel on a frozen IBC client that the contract was
+// notified about: `<prefix><clientID>/<portID>/<channelID>`
+func GetFrozenClientNotifiedKey(clientID, portID, channelID string) []byte {
+	return append(append([]byte{}, FrozenClientNotifiedPrefix...), clientID+"/"+portID+"/"+channelID...)
+}
+
+// GetCodeExecutionStatsPrefix returns the key prefix for the execution statistics of a code: `<prefix><codeID>`
+func GetCodeExecutionStatsPrefix(codeID uint64) []byte {
+	prefixLen := len(CodeExecutionStatsPrefix)
+	r := make([]byte, prefixLen+8)
+	copy(r[0:], CodeExecutionStatsPrefix)
+	copy(r[prefixLen:], sdk.Uint64ToBigEndian(codeID))
+	return r
+}
+
+// GetCodeExecutionStatsKey returns the key for the execution statistics of a code in an epoch:
+// `<prefix><codeID><epoch>`
+func GetCodeExecutionStatsKey(codeID, epoch uint64) []byte {
+	return append(GetCodeExecutionStatsPrefix(codeID), sdk.Uint64ToBigEndian(epoch)...)
+}
+
+// GetContractDeniedDenomsPrefix returns the key prefix for the denoms that a contract refuses to receive:
+// `<prefix><contractAddr>`
+func GetContractDeniedDenomsPrefix(contractAddr sdk.AccAddress) []byte {
+	return append(append([]byte{}, ContractDeniedDenomPrefix...), contractAddr...)
+}
+
+// GetContractDeniedDenomKey returns the key for a denom that a contract refuses to receive:
+// `<prefix><contractAddr><denom>`
+func GetContractDeniedDenomKey(contractAddr sdk.AccAddress, denom string) []byte {
+	return append(GetContractDeniedDenomsPrefix(contractAddr), denom...)
+}
+
+// GetStoreCodeDepositKey returns the key for a store code deposit in escrow: `<prefix><refundHeight><codeID>`
+func GetStoreCodeDepositKey(refundHeight int64, codeID uint64) []byte {
+	prefixLen := len(StoreCodeDepositPrefix)
+	r := make([]byte, prefixLen+8+8)
+	copy(r[0:], StoreCodeDepositPrefix)
+	copy(r[prefixLen:], sdk.Uint64ToBigEndian(uint64(refundHeight)))
+	copy(r[prefixLen+8:], sdk.Uint64ToBigEndian(codeID))
+	return r
+}
+
+// GetInterchainQueryKey returns the key for an interchain query: `<prefix><queryID>`
+func GetInterchainQueryKey(queryID uint64) []byte {
+	return append(append([]byte{}, InterchainQueryPrefix...), sdk.Uint64ToBigEndian(queryID)...)
+}
+
+// GetInterchainQueryByOwnerPrefix returns the key prefix for the interchain queries of a contract: `<prefix><contractAddr>`
+func GetInterchainQueryByOwnerPrefix(contractAddr sdk.AccAddress) []byte {
+	return append(append([]byte{}, InterchainQueryByOwnerPrefix...), contractAddr...)
+}
+
+// GetInterchainQueryByOwnerKey returns the key for the secondary index of an interchain query by the owner contract:
+// `<prefix><contractAddr><queryID>`
+func GetInterchainQueryByOwnerKey(contractAddr sdk.AccAddress, queryID uint64) []byte {
+	return append(GetInterchainQueryByOwnerPrefix(contractAddr), sdk.Uint64ToBigEndian(queryID)...)
+}
+
+// GetTimeoutClosedChannelKey returns the key for an ordered contract channel that was closed by a packet timeout and
+// the contract was not notified about yet: `<prefix><channelPath>`
+func GetTimeoutClosedChannelKey(portID, channelID string) []byte {
+	return append(append([]byte{}, TimeoutClosedChannelPrefix...), host.ChannelPath(portID, channelID)...)
+}
+
+// GetJobKey returns the key for a job of the end blocker: `<prefix><jobID>`
+func GetJobKey(jobID uint64) []byte {
+	return append(append([]byte{}, JobPrefix...), sdk.Uint64ToBigEndian(jobID)...)
+}
@@ -43,3 +43,11 @@ func TestGetContractByCreatedSecondaryIndexKey(t *testing.T) {
 	}
 	assert.Equal(t, exp, got)
 }
+
+func TestGetContractCodeHistoryElementKeyWithLongAddress(t *testing.T) {
+	addr := bytes.Repeat([]byte{4}, ContractAddrLen)
+	got := GetContractCodeHistoryElementKey(addr, 1)
+	exp := append(append([]byte{5}, addr...), 0, 0, 0, 0, 0, 0, 0, 1)
+	assert.Equal(t, exp, got)
+	assert.Equal(t, append([]byte{5}, addr...), GetContractCodeHistoryElementPrefix(addr))
+}
@@ -17,11 +17,23 @@ const (
 	DefaultParamspace = ModuleName
 	// DefaultMaxWasmCodeSize limit max bytes read to prevent gzip bombs
 	DefaultMaxWasmCodeSize = 600 * 1024 * 2
+	// DefaultIBCPacketGasLimit max SDK gas a contract can consume when handling an IBC packet receive, acknowledgement,
+	// timeout or the packet result reply
+	DefaultIBCPacketGasLimit = 2_000_000
+	// DefaultMaxResultDataSize max bytes of the result data or IBC acknowledgement that a contract can return
+	DefaultMaxResultDataSize = 64 * 1024
 )
 
 var ParamStoreKeyUploadAccess = []byte("uploadAccess")
 var ParamStoreKeyInstantiateAccess = []byte("instantiateAccess")
 var ParamStoreKeyMaxWasmCodeSize = []byte("maxWasmCodeSize")
+var ParamStoreKeyIBCPacketGasLimit = []byte("ibcPacketGasLimit")
+var ParamStoreKeyExecuteAllowlistEnabled = []byte("executeAllowlistEnabled")
+var ParamStoreKeyExecuteAllowlist = []byte("executeAllowlist")
+var ParamStoreKeyMaxContractGas = []byte("maxContractGas")
+var ParamStoreKeyMaxResultDataSize = []byte("maxResultDataSize")
+var ParamStoreKeyStoreCodeDeposit = []byte("storeCodeDeposit")
+var ParamStoreKeyStoreCodeDepositRefundBlocks = []byte("storeCodeDepositRefundBlocks")
 
 var AllAccessTypes = []AccessType{
 	AccessTypeNobody,
@@ -99,6 +111,8 @@ func DefaultParams() Params {
 		CodeUploadAccess:             AllowEverybody,
 		InstantiateDefaultPermission: AccessTypeEverybody,
 		MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
+		IBCPacketGasLimit:            DefaultIBCPacketGasLimit,
+		MaxResultDataSize:            DefaultMaxResultDataSize,
 	}
 }
 
@@ -113,6 +127,13 @@ func (p *Params) ParamSetPairs() paramtypes.ParamSetPairs {
 		paramtypes.NewParamSetPair(ParamStoreKeyUploadAccess, &p.CodeUploadAccess, validateAccessConfig),
 		paramtypes.NewParamSetPair(ParamStoreKeyInstantiateAccess, &p.InstantiateDefaultPermission, validateAccessType),
 		paramtypes.NewParamSetPair(ParamStoreKeyMaxWasmCodeSize, &p.MaxWasmCodeSize, validateMaxWasmCodeSize),
+		paramtypes.NewParamSetPair(ParamStoreKeyIBCPacketGasLimit, &p.IBCPacketGasLimit, validateIBCPacketGasLimit),
+		paramtypes.NewParamSetPair(ParamStoreKeyExecuteAllowlistEnabled, &p.ExecuteAllowlistEnabled, validateExecuteAllowlistEnabled),
+		paramtypes.NewParamSetPair(ParamStoreKeyExecuteAllowlist, &p.ExecuteAllowlist, validateExecuteAllowlist),
+		paramtypes.NewParamSetPair(ParamStoreKeyMaxContractGas, &p.MaxContractGas, validateMaxContractGas),
+		paramtypes.NewParamSetPair(ParamStoreKeyMaxResultDataSize, &p.MaxResultDataSize, validateMaxResultDataSize),
+		paramtypes.NewParamSetPair(ParamStoreKeyStoreCodeDeposit, &p.StoreCodeDeposit, validateStoreCodeDeposit),
+		paramtypes.NewParamSetPair(ParamStoreKeyStoreCodeDepositRefundBlocks, &p.StoreCodeDepositRefundBlocks, validateStoreCodeDepositRefundBlocks),
 	}
 }
 
@@ -127,6 +148,24 @@ func (p Params) ValidateBasic() error {
 	if err := validateMaxWasmCodeSize(p.MaxWasmCodeSize); err != nil {
 		return errors.Wrap(err, "max wasm code size")
 	}
+	if err := validateIBCPacketGasLimit(p.IBCPacketGasLimit); err != nil {
+		return errors.Wrap(err, "ibc packet gas limit")
+	}
+	if err := validateExecuteAllowlist(p.ExecuteAllowlist); err != nil {
+		return errors.Wrap(err, "execute allowlist")
+	}
+	if err := validateMaxContractGas(p.MaxContractGas); err != nil {
+		return errors.Wrap(err, "max contract gas")
+	}
+	if err := validateMaxResultDataSize(p.MaxResultDataSize); err != nil {
+		return errors.Wrap(err, "max result data size")
+	}
+	if err := validateStoreCodeDeposit(p.StoreCodeDeposit); err != nil {
+		return errors.Wrap(err, "store code deposit")
+	}
+	if err := validateStoreCodeDepositRefundBlocks(p.StoreCodeDepositRefundBlocks); err != nil {
+		return errors.Wrap(err, "store code deposit refund blocks")
+	}
 	return nil
 }
 
@@ -165,6 +204,70 @@ func validateMaxWasmCodeSize(i interface{}) error {
 	return nil
 }
 
+func validateIBCPacketGasLimit(i interface{}) error {
+	if _, ok := i.(uint64); !ok {
+		return sdkerrors.Wrapf(ErrInvalid, "type: %T", i)
+	}
+	return nil
+}
+
+func validateMaxContractGas(i interface{}) error {
+	if _, ok := i.(uint64); !ok {
+		return sdkerrors.Wrapf(ErrInvalid, "type: %T", i)
+	}
+	return nil
+}
+
+func validateMaxResultDataSize(i interface{}) error {
+	if _, ok := i.(uint64); !ok {
+		return sdkerrors.Wrapf(ErrInvalid, "type: %T", i)
+	}
+	return nil
+}
+
+func validateStoreCodeDeposit(i interface{}) error {
+	a, ok := i.(sdk.Coins)
+	if !ok {
+		return sdkerrors.Wrapf(ErrInvalid, "type: %T", i)
+	}
+	if err := validateCoins(a); err != nil {
+		return sdkerrors.Wrap(ErrInvalid, err.Error())
+	}
+	return nil
+}
+
+func validateStoreCodeDepositRefundBlocks(i interface{}) error {
+	if _, ok := i.(uint64); !ok {
+		return sdkerrors.Wrapf(ErrInvalid, "type: %T", i)
+	}
+	return nil
+}
+
+func validateExecuteAllowlistEnabled(i interface{}) error {
+	if _, ok := i.(bool); !ok {
+		return sdkerrors.Wrapf(ErrInvalid, "type: %T", i)
+	}
+	return nil
+}
+
+func validateExecuteAllowlist(i interface{}) error {
+	a, ok := i.([]string)
+	if !ok {
+		return sdkerrors.Wrapf(ErrInvalid, "type: %T", i)
+	}
+	unique := make(map[string]struct{}, len(a))
+	for _, addr := range a {
+		if _, err := sdk.AccAddressFromBech32(addr); err != nil {
+			return sdkerrors.Wrapf(err, "contract %q", addr)
+		}
+		if _, exists := unique[addr]; exists {
+			return sdkerrors.Wrapf(ErrDuplicate, "contract %q", addr)
+		}
+		unique[addr] = struct{}{}
+	}
+	return nil
+}
+
 func (v AccessConfig) ValidateBasic() error {
 	switch v.Permission {
 	case AccessTypeUnspecified:
@@ -106,6 +106,67 @@ func TestValidateParams(t *testing.T) {
 			},
 			expErr: true,
 		},
+		"all good with max contract gas": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
+				MaxContractGas:               1,
+			},
+		},
+		"all good with store code deposit": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
+				StoreCodeDeposit:             sdk.NewCoins(sdk.NewInt64Coin("denom", 1)),
+				StoreCodeDepositRefundBlocks: 1,
+			},
+		},
+		"reject invalid store code deposit": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
+				StoreCodeDeposit:             sdk.Coins{sdk.Coin{Denom: "denom", Amount: sdk.ZeroInt()}},
+			},
+			expErr: true,
+		},
+		"all good with max result data size": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
+				MaxResultDataSize:            1,
+			},
+		},
+		"all good with execute allowlist": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
+				ExecuteAllowlistEnabled:      true,
+				ExecuteAllowlist:             []string{anyAddress.String()},
+			},
+		},
+		"reject invalid execute allowlist address": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
+				ExecuteAllowlist:             []string{invalidAddress},
+			},
+			expErr: true,
+		},
+		"reject duplicate execute allowlist address": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				MaxWasmCodeSize:              DefaultMaxWasmCodeSize,
+				ExecuteAllowlist:             []string{anyAddress.String(), anyAddress.String()},
+			},
+			expErr: true,
+		},
 	}
 	for msg, spec := range specs {
 		t.Run(msg, func(t *testing.T) {
@@ -168,7 +229,9 @@ func TestParamsUnmarshalJson(t *testing.T) {
 		"defaults": {
 			src: `{"code_upload_access": {"permission": "Everybody"},
 				"instantiate_default_permission": "Everybody",
-				"max_wasm_code_size": 1228800}`,
+				"max_wasm_code_size": 1228800,
+				"ibc_packet_gas_limit": "2000000",
+				"max_result_data_size": "65536"}`,
 			exp: DefaultParams(),
 		},
 	}
@@ -0,0 +1,41 @@
+package types
+
+import (
+	"github.com/cosmos/cosmos-sdk/store/rootmulti"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	tmcrypto "github.com/tendermint/tendermint/proto/tendermint/crypto"
+)
+
+// RawContractStateQueryPath is the ABCI query path for the raw values of the wasm store. With `prove` set the
+// response contains the Merkle proof of the value against the app hash.
+const RawContractStateQueryPath = "/store/" + StoreKey + "/key"
+
+// GetRawContractStateKey returns the wasm store key of a raw contract state value
+func GetRawContractStateKey(contractAddr sdk.AccAddress, key []byte) []byte {
+	return append(GetContractStorePrefix(contractAddr), key...)
+}
+
+// VerifyRawContractState verifies the Merkle proof of a raw contract state value. The proof of a query at height h
+// is verified against the app hash of the block header at h+1. An empty value is verified to be absent.
+func VerifyRawContractState(proof *tmcrypto.ProofOps, appHash []byte, contractAddr sdk.AccAddress, key, value []byte) error {
+	if proof == nil {
+		return sdkerrors.Wrap(ErrEmpty, "proof")
+	}
+	keyPath := merkle.KeyPath{}.
+		AppendKey([]byte(StoreKey), merkle.KeyEncodingURL).
+		AppendKey(GetRawContractStateKey(contractAddr, key), merkle.KeyEncodingURL).
+		String()
+
+	var err error
+	if len(value) == 0 {
+		err = rootmulti.DefaultProofRuntime().VerifyAbsence(proof, appHash, keyPath)
+	} else {
+		err = rootmulti.DefaultProofRuntime().VerifyValue(proof, appHash, keyPath, value)
+	}
+	if err != nil {
+		return sdkerrors.Wrap(ErrInvalid, err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/store/rootmulti"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tm-db"
+)
+
+func TestVerifyRawContractState(t *testing.T) {
+	myContractAddr := sdk.AccAddress(make([]byte, sdk.AddrLen))
+	otherContractAddr := sdk.AccAddress(append(make([]byte, sdk.AddrLen-1), 1))
+
+	ms := rootmulti.NewStore(dbm.NewMemDB())
+	storeKey := sdk.NewKVStoreKey(StoreKey)
+	ms.MountStoreWithDB(storeKey, storetypes.StoreTypeIAVL, nil)
+	require.NoError(t, ms.LoadLatestVersion())
+	ms.GetKVStore(storeKey).Set(GetRawContractStateKey(myContractAddr, []byte("foo")), []byte("bar"))
+	commitID := ms.Commit()
+
+	query := func(contractAddr sdk.AccAddress, key []byte) abci.ResponseQuery {
+		res := ms.Query(abci.RequestQuery{
+			Path:  "/" + StoreKey + "/key", // the multistore path without the `/store` prefix of the app router
+			Data:  GetRawContractStateKey(contractAddr, key),
+			Prove: true,
+		})
+		require.Equal(t, uint32(0), res.Code, res.Log)
+		return res
+	}
+	existing := query(myContractAddr, []byte("foo"))
+	absent := query(myContractAddr, []byte("other"))
+
+	specs := map[string]struct {
+		srcRes          abci.ResponseQuery
+		srcContractAddr sdk.AccAddress
+		srcKey          []byte
+		srcValue        []byte
+		srcAppHash      []byte
+		expErr          bool
+	}{
+		"existing value": {
+			srcRes:          existing,
+			srcContractAddr: myContractAddr,
+			srcKey:          []byte("foo"),
+			srcValue:        existing.Value,
+			srcAppHash:      commitID.Hash,
+		},
+		"absent value": {
+			srcRes:          absent,
+			srcContractAddr: myContractAddr,
+			srcKey:          []byte("other"),
+			srcAppHash:      commitID.Hash,
+		},
+		"other value": {
+			srcRes:          existing,
+			srcContractAddr: myContractAddr,
+			srcKey:          []byte("foo"),
+			srcValue:        []byte("baz"),
+			srcAppHash:      commitID.Hash,
+			expErr:          true,
+		},
+		"other contract": {
+			srcRes:          existing,
+			srcContractAddr: otherContractAddr,
+			srcKey:          []byte("foo"),
+			srcValue:        existing.Value,
+			srcAppHash:      commitID.Hash,
+			expErr:          true,
+		},
+		"other app hash": {
+			srcRes:          existing,
+			srcContractAddr: myContractAddr,
+			srcKey:          []byte("foo"),
+			srcValue:        existing.Value,
+			srcAppHash:      make([]byte, len(commitID.Hash)),
+			expErr:          true,
+		},
+		"existing value as absent": {
+			srcRes:          existing,
+			srcContractAddr: myContractAddr,
+			srcKey:          []byte("foo"),
+			srcAppHash:      commitID.Hash,
+			expErr:          true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := VerifyRawContractState(spec.srcRes.ProofOps, spec.srcAppHash, spec.srcContractAddr, spec.srcKey, spec.srcValue)
+			if spec.expErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+	// and without proof
+	err := VerifyRawContractState(nil, commitID.Hash, myContractAddr, []byte("foo"), []byte("bar"))
+	assert.True(t, ErrEmpty.Is(err), "got %+v", err)
+}
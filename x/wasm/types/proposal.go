@@ -14,13 +14,16 @@ import (
 type ProposalType string
 
 const (
-	ProposalTypeStoreCode           ProposalType = "StoreCode"
-	ProposalTypeInstantiateContract ProposalType = "InstantiateContract"
-	ProposalTypeMigrateContract     ProposalType = "MigrateContract"
-	ProposalTypeUpdateAdmin         ProposalType = "UpdateAdmin"
-	ProposalTypeClearAdmin          ProposalType = "ClearAdmin"
-	ProposalTypePinCodes            ProposalType = "PinCodes"
-	ProposalTypeUnpinCodes          ProposalType = "UnpinCodes"
+	ProposalTypeStoreCode            ProposalType = "StoreCode"
+	ProposalTypeInstantiateContract  ProposalType = "InstantiateContract"
+	ProposalTypeMigrateContract      ProposalType = "MigrateContract"
+	ProposalTypeUpdateAdmin          ProposalType = "UpdateAdmin"
+	ProposalTypeClearAdmin           ProposalType = "ClearAdmin"
+	ProposalTypePinCodes             ProposalType = "PinCodes"
+	ProposalTypeUnpinCodes           ProposalType = "UnpinCodes"
+	ProposalTypeRecoverContractFunds ProposalType = "RecoverContractFunds"
+	ProposalTypeSetContractVesting   ProposalType = "SetContractVesting"
+	ProposalTypeMigrateAllContracts  ProposalType = "MigrateAllContracts"
 )
 
 // DisableAllProposals contains no wasm gov types.
@@ -35,6 +38,9 @@ var EnableAllProposals = []ProposalType{
 	ProposalTypeClearAdmin,
 	ProposalTypePinCodes,
 	ProposalTypeUnpinCodes,
+	ProposalTypeRecoverContractFunds,
+	ProposalTypeSetContractVesting,
+	ProposalTypeMigrateAllContracts,
 }
 
 // ConvertToProposals maps each key to a ProposalType and returns a typed list.
@@ -63,6 +69,9 @@ func init() { // register new content types with the sdk
 	govtypes.RegisterProposalType(string(ProposalTypeClearAdmin))
 	govtypes.RegisterProposalType(string(ProposalTypePinCodes))
 	govtypes.RegisterProposalType(string(ProposalTypeUnpinCodes))
+	govtypes.RegisterProposalType(string(ProposalTypeRecoverContractFunds))
+	govtypes.RegisterProposalType(string(ProposalTypeSetContractVesting))
+	govtypes.RegisterProposalType(string(ProposalTypeMigrateAllContracts))
 	govtypes.RegisterProposalTypeCodec(&StoreCodeProposal{}, "wasm/StoreCodeProposal")
 	govtypes.RegisterProposalTypeCodec(&InstantiateContractProposal{}, "wasm/InstantiateContractProposal")
 	govtypes.RegisterProposalTypeCodec(&MigrateContractProposal{}, "wasm/MigrateContractProposal")
@@ -70,6 +79,9 @@ func init() { // register new content types with the sdk
 	govtypes.RegisterProposalTypeCodec(&ClearAdminProposal{}, "wasm/ClearAdminProposal")
 	govtypes.RegisterProposalTypeCodec(&PinCodesProposal{}, "wasm/PinCodesProposal")
 	govtypes.RegisterProposalTypeCodec(&UnpinCodesProposal{}, "wasm/UnpinCodesProposal")
+	govtypes.RegisterProposalTypeCodec(&RecoverContractFundsProposal{}, "wasm/RecoverContractFundsProposal")
+	govtypes.RegisterProposalTypeCodec(&SetContractVestingProposal{}, "wasm/SetContractVestingProposal")
+	govtypes.RegisterProposalTypeCodec(&MigrateAllContractsProposal{}, "wasm/MigrateAllContractsProposal")
 }
 
 // ProposalRoute returns the routing key of a parameter change proposal.
@@ -89,8 +101,10 @@ func (p StoreCodeProposal) ValidateBasic() error {
 	if err := validateProposalCommons(p.Title, p.Description); err != nil {
 		return err
 	}
-	if _, err := sdk.AccAddressFromBech32(p.RunAs); err != nil {
-		return sdkerrors.Wrap(err, "run as")
+	if len(p.RunAs) != 0 {
+		if _, err := sdk.AccAddressFromBech32(p.RunAs); err != nil {
+			return sdkerrors.Wrap(err, "run as")
+		}
 	}
 
 	if err := validateWasmCode(p.WASMByteCode); err != nil {
@@ -164,8 +178,10 @@ func (p InstantiateContractProposal) ValidateBasic() error {
 	if err := validateProposalCommons(p.Title, p.Description); err != nil {
 		return err
 	}
-	if _, err := sdk.AccAddressFromBech32(p.RunAs); err != nil {
-		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "run as")
+	if len(p.RunAs) != 0 {
+		if _, err := sdk.AccAddressFromBech32(p.RunAs); err != nil {
+			return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "run as")
+		}
 	}
 
 	if p.CodeID == 0 {
@@ -176,9 +192,15 @@ func (p InstantiateContractProposal) ValidateBasic() error {
 		return err
 	}
 
-	if !p.Funds.IsValid() {
+	if validateCoins(p.Funds) != nil {
 		return sdkerrors.ErrInvalidCoins
 	}
+	if p.FundsFromCommunityPool && p.Funds.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "funds required when drawn from community pool")
+	}
+	if len(p.RunAs) == 0 && !p.Funds.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "run as required for funds")
+	}
 
 	if len(p.Admin) != 0 {
 		if _, err := sdk.AccAddressFromBech32(p.Admin); err != nil {
@@ -203,29 +225,32 @@ func (p InstantiateContractProposal) String() string {
   Label:       %s
   InitMsg:     %q
   Funds:       %s
-`, p.Title, p.Description, p.RunAs, p.Admin, p.CodeID, p.Label, p.InitMsg, p.Funds)
+  Funds from community pool: %t
+`, p.Title, p.Description, p.RunAs, p.Admin, p.CodeID, p.Label, p.InitMsg, p.Funds, p.FundsFromCommunityPool)
 }
 
 // MarshalYAML pretty prints the init message
 func (p InstantiateContractProposal) MarshalYAML() (interface{}, error) {
 	return struct {
-		Title       string    `yaml:"title"`
-		Description string    `yaml:"description"`
-		RunAs       string    `yaml:"run_as"`
-		Admin       string    `yaml:"admin"`
-		CodeID      uint64    `yaml:"code_id"`
-		Label       string    `yaml:"label"`
-		InitMsg     string    `yaml:"init_msg"`
-		Funds       sdk.Coins `yaml:"funds"`
+		Title                  string    `yaml:"title"`
+		Description            string    `yaml:"description"`
+		RunAs                  string    `yaml:"run_as"`
+		Admin                  string    `yaml:"admin"`
+		CodeID                 uint64    `yaml:"code_id"`
+		Label                  string    `yaml:"label"`
+		InitMsg                string    `yaml:"init_msg"`
+		Funds                  sdk.Coins `yaml:"funds"`
+		FundsFromCommunityPool bool      `yaml:"funds_from_community_pool"`
 	}{
-		Title:       p.Title,
-		Description: p.Description,
-		RunAs:       p.RunAs,
-		Admin:       p.Admin,
-		CodeID:      p.CodeID,
-		Label:       p.Label,
-		InitMsg:     string(p.InitMsg),
-		Funds:       p.Funds,
+		Title:                  p.Title,
+		Description:            p.Description,
+		RunAs:                  p.RunAs,
+		Admin:                  p.Admin,
+		CodeID:                 p.CodeID,
+		Label:                  p.Label,
+		InitMsg:                string(p.InitMsg),
+		Funds:                  p.Funds,
+		FundsFromCommunityPool: p.FundsFromCommunityPool,
 	}, nil
 }
 
@@ -424,6 +449,164 @@ func (p UnpinCodesProposal) String() string {
 `, p.Title, p.Description, p.CodeIDs)
 }
 
+// ProposalRoute returns the routing key of a parameter change proposal.
+func (p RecoverContractFundsProposal) ProposalRoute() string { return RouterKey }
+
+// GetTitle returns the title of the proposal
+func (p *RecoverContractFundsProposal) GetTitle() string { return p.Title }
+
+// GetDescription returns the human readable description of the proposal
+func (p RecoverContractFundsProposal) GetDescription() string { return p.Description }
+
+// ProposalType returns the type
+func (p RecoverContractFundsProposal) ProposalType() string {
+	return string(ProposalTypeRecoverContractFunds)
+}
+
+// ValidateBasic validates the proposal
+func (p RecoverContractFundsProposal) ValidateBasic() error {
+	if err := validateProposalCommons(p.Title, p.Description); err != nil {
+		return err
+	}
+	if _, err := sdk.AccAddressFromBech32(p.Contract); err != nil {
+		return sdkerrors.Wrap(err, "contract")
+	}
+	if _, err := sdk.AccAddressFromBech32(p.Recipient); err != nil {
+		return sdkerrors.Wrap(err, "recipient")
+	}
+	if p.Contract == p.Recipient {
+		return sdkerrors.Wrap(ErrInvalid, "recipient must not be the contract")
+	}
+	if p.Amount.Empty() {
+		return sdkerrors.Wrap(ErrEmpty, "amount")
+	}
+	if validateCoins(p.Amount) != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "amount")
+	}
+	return nil
+}
+
+// String implements the Stringer interface.
+func (p RecoverContractFundsProposal) String() string {
+	return fmt.Sprintf(`Recover Contract Funds Proposal:
+  Title:       %s
+  Description: %s
+  Contract:    %s
+  Recipient:   %s
+  Amount:      %s
+`, p.Title, p.Description, p.Contract, p.Recipient, p.Amount)
+}
+
+// ProposalRoute returns the routing key of a parameter change proposal.
+func (p SetContractVestingProposal) ProposalRoute() string { return RouterKey }
+
+// GetTitle returns the title of the proposal
+func (p *SetContractVestingProposal) GetTitle() string { return p.Title }
+
+// GetDescription returns the human readable description of the proposal
+func (p SetContractVestingProposal) GetDescription() string { return p.Description }
+
+// ProposalType returns the type
+func (p SetContractVestingProposal) ProposalType() string {
+	return string(ProposalTypeSetContractVesting)
+}
+
+// ValidateBasic validates the proposal
+func (p SetContractVestingProposal) ValidateBasic() error {
+	if err := validateProposalCommons(p.Title, p.Description); err != nil {
+		return err
+	}
+	if _, err := sdk.AccAddressFromBech32(p.Contract); err != nil {
+		return sdkerrors.Wrap(err, "contract")
+	}
+	return sdkerrors.Wrap(p.Schedule.ValidateBasic(), "schedule")
+}
+
+// String implements the Stringer interface.
+func (p SetContractVestingProposal) String() string {
+	return fmt.Sprintf(`Set Contract Vesting Proposal:
+  Title:       %s
+  Description: %s
+  Contract:    %s
+  Type:        %s
+  Amount:      %s
+  Start Time:  %d
+  End Time:    %d
+  Periods:     %d
+`, p.Title, p.Description, p.Contract, p.Schedule.Type, p.Schedule.Amount, p.Schedule.StartTime, p.Schedule.EndTime, len(p.Schedule.Periods))
+}
+
+// ProposalRoute returns the routing key of a parameter change proposal.
+func (p MigrateAllContractsProposal) ProposalRoute() string { return RouterKey }
+
+// GetTitle returns the title of the proposal
+func (p *MigrateAllContractsProposal) GetTitle() string { return p.Title }
+
+// GetDescription returns the human readable description of the proposal
+func (p MigrateAllContractsProposal) GetDescription() string { return p.Description }
+
+// ProposalType returns the type
+func (p MigrateAllContractsProposal) ProposalType() string {
+	return string(ProposalTypeMigrateAllContracts)
+}
+
+// ValidateBasic validates the proposal
+func (p MigrateAllContractsProposal) ValidateBasic() error {
+	if err := validateProposalCommons(p.Title, p.Description); err != nil {
+		return err
+	}
+	if p.CodeID == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "code_id is required")
+	}
+	if p.NewCodeID == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "new_code_id is required")
+	}
+	if p.CodeID == p.NewCodeID {
+		return sdkerrors.Wrap(ErrInvalid, "new code id must not be the code id")
+	}
+	if _, err := sdk.AccAddressFromBech32(p.RunAs); err != nil {
+		return sdkerrors.Wrap(err, "run as")
+	}
+	if !json.Valid(p.MigrateMsg) {
+		return sdkerrors.Wrap(ErrInvalid, "migrate msg json")
+	}
+	return nil
+}
+
+// String implements the Stringer interface.
+func (p MigrateAllContractsProposal) String() string {
+	return fmt.Sprintf(`Migrate All Contracts Proposal:
+  Title:               %s
+  Description:         %s
+  Code id:             %d
+  New code id:         %d
+  Run as:              %s
+  MigrateMsg           %q
+  Contracts per block: %d
+`, p.Title, p.Description, p.CodeID, p.NewCodeID, p.RunAs, p.MigrateMsg, p.ContractsPerBlock)
+}
+
+// MarshalYAML pretty prints the migrate message
+func (p MigrateAllContractsProposal) MarshalYAML() (interface{}, error) {
+	return struct {
+		Title             string `yaml:"title"`
+		Description       string `yaml:"description"`
+		RunAs             string `yaml:"run_as"`
+		CodeID            uint64 `yaml:"code_id"`
+		NewCodeID         uint64 `yaml:"new_code_id"`
+		MigrateMsg        string `yaml:"msg"`
+		ContractsPerBlock uint32 `yaml:"contracts_per_block"`
+	}{
+		Title:             p.Title,
+		Description:       p.Description,
+		RunAs:             p.RunAs,
+		CodeID:            p.CodeID,
+		NewCodeID:         p.NewCodeID,
+		MigrateMsg:        string(p.MigrateMsg),
+		ContractsPerBlock: p.ContractsPerBlock,
+	}, nil
+}
+
 func validateProposalCommons(title, description string) error {
 	if strings.TrimSpace(title) != title {
 		return sdkerrors.Wrap(govtypes.ErrInvalidProposalContent, "proposal title must not start/end with white spaces")
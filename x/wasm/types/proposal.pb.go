@@ -26,13 +26,52 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
+// ContractVestingType defines how the locked contract funds vest
+type ContractVestingType int32
+
+const (
+	// ContractVestingTypeUnspecified placeholder for empty value
+	ContractVestingTypeUnspecified ContractVestingType = 0
+	// ContractVestingTypeContinuous unlocks the funds linearly between the start
+	// and end time
+	ContractVestingTypeContinuous ContractVestingType = 1
+	// ContractVestingTypeDelayed unlocks all funds at the end time
+	ContractVestingTypeDelayed ContractVestingType = 2
+	// ContractVestingTypePeriodic unlocks the amount of each period at the end
+	// of the period
+	ContractVestingTypePeriodic ContractVestingType = 3
+)
+
+var ContractVestingType_name = map[int32]string{
+	0: "CONTRACT_VESTING_TYPE_UNSPECIFIED",
+	1: "CONTRACT_VESTING_TYPE_CONTINUOUS",
+	2: "CONTRACT_VESTING_TYPE_DELAYED",
+	3: "CONTRACT_VESTING_TYPE_PERIODIC",
+}
+
+var ContractVestingType_value = map[string]int32{
+	"CONTRACT_VESTING_TYPE_UNSPECIFIED": 0,
+	"CONTRACT_VESTING_TYPE_CONTINUOUS":  1,
+	"CONTRACT_VESTING_TYPE_DELAYED":     2,
+	"CONTRACT_VESTING_TYPE_PERIODIC":    3,
+}
+
+func (x ContractVestingType) String() string {
+	return proto.EnumName(ContractVestingType_name, int32(x))
+}
+
+func (ContractVestingType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_6428c760f8f86eed, []int{0}
+}
+
 // StoreCodeProposal gov proposal content type to submit WASM code to the system
 type StoreCodeProposal struct {
 	// Title is a short summary
 	Title string `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
 	// Description is a human readable text
 	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	// RunAs is the address that is passed to the contract's environment as sender
+	// RunAs is the address that is stored as code creator, optional. Defaults to
+	// the gov module account when empty
 	RunAs string `protobuf:"bytes,3,opt,name=run_as,json=runAs,proto3" json:"run_as,omitempty"`
 	// WASMByteCode can be raw or gzip compressed
 	WASMByteCode []byte `protobuf:"bytes,4,opt,name=wasm_byte_code,json=wasmByteCode,proto3" json:"wasm_byte_code,omitempty"`
@@ -85,6 +124,8 @@ type InstantiateContractProposal struct {
 	// Description is a human readable text
 	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
 	// RunAs is the address that is passed to the contract's environment as sender
+	// and stored as contract creator, optional. Defaults to the gov module
+	// account when empty. Required when funds are set
 	RunAs string `protobuf:"bytes,3,opt,name=run_as,json=runAs,proto3" json:"run_as,omitempty"`
 	// Admin is an optional address that can execute migrations
 	Admin string `protobuf:"bytes,4,opt,name=admin,proto3" json:"admin,omitempty"`
@@ -96,6 +137,10 @@ type InstantiateContractProposal struct {
 	InitMsg []byte `protobuf:"bytes,7,opt,name=init_msg,json=initMsg,proto3" json:"init_msg,omitempty"`
 	// Funds coins that are transferred to the contract on instantiation
 	Funds github_com_cosmos_cosmos_sdk_types.Coins `protobuf:"bytes,8,rep,name=funds,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"funds"`
+	// FundsFromCommunityPool when set, the funds are drawn from the community
+	// pool to the RunAs address on proposal execution instead of being paid by
+	// the RunAs account
+	FundsFromCommunityPool bool `protobuf:"varint,9,opt,name=funds_from_community_pool,json=fundsFromCommunityPool,proto3" json:"funds_from_community_pool,omitempty"`
 }
 
 func (m *InstantiateContractProposal) Reset()      { *m = InstantiateContractProposal{} }
@@ -351,7 +396,251 @@ func (m *UnpinCodesProposal) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_UnpinCodesProposal proto.InternalMessageInfo
 
+// RecoverContractFundsProposal gov proposal content type to move the funds out
+// of a bricked contract. The contract must have no admin and its code must
+// not run on this chain anymore.
+type RecoverContractFundsProposal struct {
+	// Title is a short summary
+	Title string `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty" yaml:"title"`
+	// Description is a human readable text
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty" yaml:"description"`
+	// Contract is the address of the bricked contract
+	Contract string `protobuf:"bytes,3,opt,name=contract,proto3" json:"contract,omitempty" yaml:"contract"`
+	// Recipient is the address that receives the funds
+	Recipient string `protobuf:"bytes,4,opt,name=recipient,proto3" json:"recipient,omitempty" yaml:"recipient"`
+	// Amount of coins to send from the contract to the recipient
+	Amount github_com_cosmos_cosmos_sdk_types.Coins `protobuf:"bytes,5,rep,name=amount,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"amount" yaml:"amount"`
+}
+
+func (m *RecoverContractFundsProposal) Reset()      { *m = RecoverContractFundsProposal{} }
+func (*RecoverContractFundsProposal) ProtoMessage() {}
+func (*RecoverContractFundsProposal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6428c760f8f86eed, []int{7}
+}
+func (m *RecoverContractFundsProposal) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RecoverContractFundsProposal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RecoverContractFundsProposal.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RecoverContractFundsProposal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecoverContractFundsProposal.Merge(m, src)
+}
+func (m *RecoverContractFundsProposal) XXX_Size() int {
+	return m.Size()
+}
+func (m *RecoverContractFundsProposal) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecoverContractFundsProposal.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RecoverContractFundsProposal proto.InternalMessageInfo
+
+// ContractVestingPeriod is a single period of a periodic vesting schedule
+type ContractVestingPeriod struct {
+	// Length of the period in seconds
+	Length int64 `protobuf:"varint,1,opt,name=length,proto3" json:"length,omitempty" yaml:"length"`
+	// Amount that vests at the end of the period
+	Amount github_com_cosmos_cosmos_sdk_types.Coins `protobuf:"bytes,2,rep,name=amount,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"amount" yaml:"amount"`
+}
+
+func (m *ContractVestingPeriod) Reset()         { *m = ContractVestingPeriod{} }
+func (m *ContractVestingPeriod) String() string { return proto.CompactTextString(m) }
+func (*ContractVestingPeriod) ProtoMessage()    {}
+func (*ContractVestingPeriod) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6428c760f8f86eed, []int{8}
+}
+func (m *ContractVestingPeriod) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ContractVestingPeriod) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ContractVestingPeriod.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ContractVestingPeriod) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContractVestingPeriod.Merge(m, src)
+}
+func (m *ContractVestingPeriod) XXX_Size() int {
+	return m.Size()
+}
+func (m *ContractVestingPeriod) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContractVestingPeriod.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContractVestingPeriod proto.InternalMessageInfo
+
+// ContractVestingSchedule defines the lockup of contract funds
+type ContractVestingSchedule struct {
+	// Type of the vesting
+	Type ContractVestingType `protobuf:"varint,1,opt,name=type,proto3,enum=cosmwasm.wasm.v1beta1.ContractVestingType" json:"type,omitempty" yaml:"type"`
+	// Amount of the contract funds that is locked
+	Amount github_com_cosmos_cosmos_sdk_types.Coins `protobuf:"bytes,2,rep,name=amount,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"amount" yaml:"amount"`
+	// StartTime is the unix time in seconds when the funds start to vest. Not
+	// used for delayed vesting.
+	StartTime int64 `protobuf:"varint,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty" yaml:"start_time"`
+	// EndTime is the unix time in seconds when all funds are vested. Not used for
+	// periodic vesting where it is the end of the last period.
+	EndTime int64 `protobuf:"varint,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty" yaml:"end_time"`
+	// Periods of a periodic vesting. The period amounts must add up to the
+	// amount.
+	Periods []ContractVestingPeriod `protobuf:"bytes,5,rep,name=periods,proto3" json:"periods" yaml:"periods"`
+}
+
+func (m *ContractVestingSchedule) Reset()         { *m = ContractVestingSchedule{} }
+func (m *ContractVestingSchedule) String() string { return proto.CompactTextString(m) }
+func (*ContractVestingSchedule) ProtoMessage()    {}
+func (*ContractVestingSchedule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6428c760f8f86eed, []int{9}
+}
+func (m *ContractVestingSchedule) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ContractVestingSchedule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ContractVestingSchedule.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ContractVestingSchedule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContractVestingSchedule.Merge(m, src)
+}
+func (m *ContractVestingSchedule) XXX_Size() int {
+	return m.Size()
+}
+func (m *ContractVestingSchedule) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContractVestingSchedule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContractVestingSchedule proto.InternalMessageInfo
+
+// SetContractVestingProposal gov proposal content type to lock funds of a
+// contract with a vesting schedule. The contract account is converted into a
+// vesting account so that the locked funds can not be sent by the contract
+// before they vest.
+type SetContractVestingProposal struct {
+	// Title is a short summary
+	Title string `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty" yaml:"title"`
+	// Description is a human readable text
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty" yaml:"description"`
+	// Contract is the address of the contract with the funds
+	Contract string `protobuf:"bytes,3,opt,name=contract,proto3" json:"contract,omitempty" yaml:"contract"`
+	// Schedule of the lockup
+	Schedule ContractVestingSchedule `protobuf:"bytes,4,opt,name=schedule,proto3" json:"schedule" yaml:"schedule"`
+}
+
+func (m *SetContractVestingProposal) Reset()      { *m = SetContractVestingProposal{} }
+func (*SetContractVestingProposal) ProtoMessage() {}
+func (*SetContractVestingProposal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6428c760f8f86eed, []int{10}
+}
+func (m *SetContractVestingProposal) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SetContractVestingProposal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SetContractVestingProposal.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SetContractVestingProposal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetContractVestingProposal.Merge(m, src)
+}
+func (m *SetContractVestingProposal) XXX_Size() int {
+	return m.Size()
+}
+func (m *SetContractVestingProposal) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetContractVestingProposal.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetContractVestingProposal proto.InternalMessageInfo
+
+// MigrateAllContractsProposal gov proposal content type to migrate all
+// contracts of a code to a new code, for example to fix a vulnerability in a
+// widely instantiated code.
+type MigrateAllContractsProposal struct {
+	// Title is a short summary
+	Title string `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty" yaml:"title"`
+	// Description is a human readable text
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty" yaml:"description"`
+	// RunAs is the address that is passed to the contract's environment as
+	// sender
+	RunAs string `protobuf:"bytes,3,opt,name=run_as,json=runAs,proto3" json:"run_as,omitempty" yaml:"run_as"`
+	// CodeID of the contracts that are migrated
+	CodeID uint64 `protobuf:"varint,4,opt,name=code_id,json=codeId,proto3" json:"code_id,omitempty" yaml:"code_id"`
+	// NewCodeID references the new WASM code
+	NewCodeID uint64 `protobuf:"varint,5,opt,name=new_code_id,json=newCodeId,proto3" json:"new_code_id,omitempty" yaml:"new_code_id"`
+	// MigrateMsg json encoded message to be passed to the contracts on migration
+	MigrateMsg []byte `protobuf:"bytes,6,opt,name=migrate_msg,json=migrateMsg,proto3" json:"migrate_msg,omitempty" yaml:"migrate_msg"`
+	// ContractsPerBlock limits the migrations to this number of contracts per
+	// block, starting with the block after the proposal passed. A contract that
+	// fails to migrate is skipped then. With zero all contracts are migrated
+	// when the proposal is executed and the proposal fails when any migration
+	// fails.
+	ContractsPerBlock uint32 `protobuf:"varint,7,opt,name=contracts_per_block,json=contractsPerBlock,proto3" json:"contracts_per_block,omitempty" yaml:"contracts_per_block"`
+}
+
+func (m *MigrateAllContractsProposal) Reset()      { *m = MigrateAllContractsProposal{} }
+func (*MigrateAllContractsProposal) ProtoMessage() {}
+func (*MigrateAllContractsProposal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6428c760f8f86eed, []int{11}
+}
+func (m *MigrateAllContractsProposal) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MigrateAllContractsProposal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MigrateAllContractsProposal.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MigrateAllContractsProposal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MigrateAllContractsProposal.Merge(m, src)
+}
+func (m *MigrateAllContractsProposal) XXX_Size() int {
+	return m.Size()
+}
+func (m *MigrateAllContractsProposal) XXX_DiscardUnknown() {
+	xxx_messageInfo_MigrateAllContractsProposal.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MigrateAllContractsProposal proto.InternalMessageInfo
+
 func init() {
+	proto.RegisterEnum("cosmwasm.wasm.v1beta1.ContractVestingType", ContractVestingType_name, ContractVestingType_value)
 	proto.RegisterType((*StoreCodeProposal)(nil), "cosmwasm.wasm.v1beta1.StoreCodeProposal")
 	proto.RegisterType((*InstantiateContractProposal)(nil), "cosmwasm.wasm.v1beta1.InstantiateContractProposal")
 	proto.RegisterType((*MigrateContractProposal)(nil), "cosmwasm.wasm.v1beta1.MigrateContractProposal")
@@ -359,6 +648,11 @@ func init() {
 	proto.RegisterType((*ClearAdminProposal)(nil), "cosmwasm.wasm.v1beta1.ClearAdminProposal")
 	proto.RegisterType((*PinCodesProposal)(nil), "cosmwasm.wasm.v1beta1.PinCodesProposal")
 	proto.RegisterType((*UnpinCodesProposal)(nil), "cosmwasm.wasm.v1beta1.UnpinCodesProposal")
+	proto.RegisterType((*RecoverContractFundsProposal)(nil), "cosmwasm.wasm.v1beta1.RecoverContractFundsProposal")
+	proto.RegisterType((*ContractVestingPeriod)(nil), "cosmwasm.wasm.v1beta1.ContractVestingPeriod")
+	proto.RegisterType((*ContractVestingSchedule)(nil), "cosmwasm.wasm.v1beta1.ContractVestingSchedule")
+	proto.RegisterType((*SetContractVestingProposal)(nil), "cosmwasm.wasm.v1beta1.SetContractVestingProposal")
+	proto.RegisterType((*MigrateAllContractsProposal)(nil), "cosmwasm.wasm.v1beta1.MigrateAllContractsProposal")
 }
 
 func init() {
@@ -366,52 +660,90 @@ func init() {
 }
 
 var fileDescriptor_6428c760f8f86eed = []byte{
-	// 707 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x55, 0xcd, 0x6a, 0xdb, 0x4a,
-	0x14, 0xb6, 0xe2, 0xf8, 0x27, 0x63, 0x73, 0xaf, 0xef, 0x5c, 0x27, 0x57, 0xc9, 0x05, 0xc9, 0x55,
-	0x4a, 0xf0, 0xa6, 0x76, 0x93, 0x42, 0x69, 0xbb, 0xb3, 0xdc, 0x4d, 0xa0, 0x86, 0xa0, 0x10, 0x02,
-	0xd9, 0x98, 0xb1, 0x34, 0x51, 0x86, 0x5a, 0x33, 0x42, 0x33, 0x6e, 0xea, 0xb7, 0xe8, 0x03, 0xf4,
-	0x01, 0x42, 0x37, 0xa5, 0xeb, 0xbe, 0x40, 0xe8, 0x2a, 0xcb, 0xac, 0xd4, 0xc6, 0x79, 0x03, 0x3f,
-	0x41, 0x99, 0x19, 0xd9, 0x75, 0x4a, 0x28, 0x85, 0xfe, 0x40, 0x37, 0xb2, 0xcf, 0x9c, 0x6f, 0xce,
-	0xf7, 0xcd, 0x77, 0x8e, 0x46, 0xe0, 0xae, 0xcf, 0x78, 0x74, 0x8a, 0x78, 0xd4, 0x56, 0x8f, 0x17,
-	0xdb, 0x03, 0x2c, 0xd0, 0x76, 0x3b, 0x4e, 0x58, 0xcc, 0x38, 0x1a, 0xb6, 0xe2, 0x84, 0x09, 0x06,
-	0x57, 0x67, 0xa8, 0x96, 0x7a, 0x64, 0xa8, 0x8d, 0x7a, 0xc8, 0x42, 0xa6, 0x10, 0x6d, 0xf9, 0x4f,
-	0x83, 0x37, 0x2c, 0x09, 0x66, 0xbc, 0x3d, 0x40, 0x1c, 0xcf, 0x0b, 0xfa, 0x8c, 0xd0, 0x2c, 0x7f,
-	0xe7, 0x76, 0x4a, 0x31, 0x8e, 0x31, 0xd7, 0x10, 0xe7, 0x6c, 0x09, 0xfc, 0xb3, 0x2f, 0x58, 0x82,
-	0xbb, 0x2c, 0xc0, 0x7b, 0x99, 0x16, 0x58, 0x07, 0x05, 0x41, 0xc4, 0x10, 0x9b, 0x46, 0xc3, 0x68,
-	0xae, 0x78, 0x3a, 0x80, 0x0d, 0x50, 0x09, 0x30, 0xf7, 0x13, 0x12, 0x0b, 0xc2, 0xa8, 0xb9, 0xa4,
-	0x72, 0x8b, 0x4b, 0x70, 0x15, 0x14, 0x93, 0x11, 0xed, 0x23, 0x6e, 0xe6, 0xf5, 0xc6, 0x64, 0x44,
-	0x3b, 0x1c, 0x3e, 0x04, 0x7f, 0x49, 0x01, 0xfd, 0xc1, 0x58, 0xe0, 0xbe, 0xcf, 0x02, 0x6c, 0x2e,
-	0x37, 0x8c, 0x66, 0xd5, 0xad, 0x4d, 0x52, 0xbb, 0x7a, 0xd8, 0xd9, 0xef, 0xb9, 0x63, 0xa1, 0x04,
-	0x78, 0x55, 0x89, 0x9b, 0x45, 0x70, 0x0d, 0x14, 0x39, 0x1b, 0x25, 0x3e, 0x36, 0x0b, 0xaa, 0x5c,
-	0x16, 0x41, 0x13, 0x94, 0x06, 0x23, 0x32, 0x0c, 0x70, 0x62, 0x16, 0x55, 0x62, 0x16, 0xc2, 0x23,
-	0xb0, 0x46, 0x28, 0x17, 0x88, 0x0a, 0x82, 0x04, 0xee, 0xc7, 0x38, 0x89, 0x08, 0xe7, 0x52, 0x6d,
-	0xa9, 0x61, 0x34, 0x2b, 0x3b, 0x9b, 0xad, 0x5b, 0xfd, 0x6d, 0x75, 0x7c, 0x1f, 0x73, 0xde, 0x65,
-	0xf4, 0x98, 0x84, 0xde, 0xea, 0x42, 0x89, 0xbd, 0x79, 0x05, 0xe7, 0xfd, 0x12, 0xf8, 0x7f, 0xf7,
-	0x4b, 0xa6, 0xcb, 0xa8, 0x48, 0x90, 0x2f, 0x7e, 0x95, 0x69, 0x75, 0x50, 0x40, 0x41, 0x44, 0xa8,
-	0xf2, 0x6a, 0xc5, 0xd3, 0x01, 0xdc, 0x04, 0x25, 0x69, 0x60, 0x9f, 0x04, 0xca, 0x93, 0x65, 0x17,
-	0x4c, 0x52, 0xbb, 0x28, 0xdd, 0xda, 0x7d, 0xea, 0x15, 0x65, 0x6a, 0x37, 0x90, 0x5b, 0x87, 0x68,
-	0x80, 0x87, 0x99, 0x3b, 0x3a, 0x80, 0xeb, 0xa0, 0x4c, 0x28, 0x11, 0xfd, 0x88, 0x87, 0xca, 0x8d,
-	0xaa, 0x57, 0x92, 0x71, 0x8f, 0x87, 0x10, 0x81, 0xc2, 0xf1, 0x88, 0x06, 0xdc, 0x2c, 0x37, 0xf2,
-	0xcd, 0xca, 0xce, 0x7a, 0x4b, 0x0f, 0x56, 0x4b, 0x0e, 0xd6, 0xdc, 0xa3, 0x2e, 0x23, 0xd4, 0xbd,
-	0x7f, 0x9e, 0xda, 0xb9, 0x37, 0x1f, 0xed, 0x66, 0x48, 0xc4, 0xc9, 0x68, 0xd0, 0xf2, 0x59, 0xd4,
-	0xce, 0xa6, 0x50, 0xff, 0xdc, 0xe3, 0xc1, 0xf3, 0x6c, 0xc2, 0xe4, 0x06, 0xee, 0xe9, 0xca, 0xce,
-	0x07, 0x03, 0xfc, 0xd7, 0x23, 0x61, 0xf2, 0x1b, 0x9c, 0xdb, 0x00, 0x65, 0x3f, 0xa3, 0xc8, 0xcc,
-	0x9b, 0xc7, 0xdf, 0xe7, 0x9f, 0x0d, 0x2a, 0x91, 0x96, 0xaa, 0xcc, 0x2a, 0x2a, 0xb3, 0x40, 0xb6,
-	0xd4, 0xe3, 0xa1, 0xf3, 0xda, 0x00, 0xff, 0x1e, 0xc4, 0x01, 0x12, 0xb8, 0x23, 0xbb, 0xf2, 0xc3,
-	0x07, 0xd9, 0x06, 0x2b, 0x14, 0x9f, 0xf6, 0x75, 0xbf, 0xd5, 0x59, 0xdc, 0xfa, 0x34, 0xb5, 0x6b,
-	0x63, 0x14, 0x0d, 0x9f, 0x38, 0xf3, 0x94, 0xe3, 0x95, 0x29, 0x3e, 0x55, 0x94, 0xdf, 0x3a, 0xa4,
-	0x73, 0x02, 0x60, 0x77, 0x88, 0x51, 0xf2, 0x73, 0xc4, 0x2d, 0x32, 0xe5, 0xbf, 0x62, 0x7a, 0x6b,
-	0x80, 0xda, 0x1e, 0xa1, 0xd2, 0x3f, 0x3e, 0x27, 0xda, 0xba, 0x41, 0xe4, 0xd6, 0xa6, 0xa9, 0x5d,
-	0xd5, 0x27, 0x51, 0xcb, 0xce, 0x8c, 0xfa, 0xd1, 0x2d, 0xd4, 0xee, 0xda, 0x34, 0xb5, 0xa1, 0x46,
-	0x2f, 0x24, 0x9d, 0x9b, 0x92, 0x1e, 0x4b, 0x49, 0xaa, 0x8b, 0xb2, 0xf5, 0xf9, 0xe6, 0xb2, 0x6b,
-	0x4d, 0x52, 0xbb, 0xa4, 0xdb, 0xc8, 0xa7, 0xa9, 0xfd, 0xb7, 0xae, 0x30, 0x03, 0x39, 0x5e, 0x49,
-	0xb7, 0x96, 0x3b, 0xef, 0x0c, 0x00, 0x0f, 0x68, 0xfc, 0x27, 0x69, 0x76, 0x9f, 0x9d, 0x5f, 0x59,
-	0xb9, 0xcb, 0x2b, 0x2b, 0x77, 0x36, 0xb1, 0x8c, 0xf3, 0x89, 0x65, 0x5c, 0x4c, 0x2c, 0xe3, 0xd3,
-	0xc4, 0x32, 0x5e, 0x5d, 0x5b, 0xb9, 0x8b, 0x6b, 0x2b, 0x77, 0x79, 0x6d, 0xe5, 0x8e, 0xb6, 0x16,
-	0x5e, 0xc9, 0x2e, 0xe3, 0xd1, 0xe1, 0xec, 0xe2, 0x0f, 0xda, 0x2f, 0xf5, 0x07, 0x40, 0xbd, 0x96,
-	0x83, 0xa2, 0xba, 0xf9, 0x1f, 0x7c, 0x0e, 0x00, 0x00, 0xff, 0xff, 0x7f, 0xdb, 0xe2, 0xec, 0x91,
-	0x06, 0x00, 0x00,
+	// 1315 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x57, 0xcf, 0x8f, 0xdb, 0xc4,
+	0x17, 0x8f, 0x93, 0x6c, 0x7e, 0x4c, 0xb6, 0x6d, 0xd6, 0xfb, 0xa3, 0x69, 0xda, 0xda, 0xe9, 0xf4,
+	0xab, 0x2a, 0xdf, 0x0a, 0x12, 0xba, 0xfc, 0x6c, 0x6f, 0x71, 0x36, 0xad, 0x22, 0x75, 0x77, 0x23,
+	0x67, 0xb7, 0x55, 0x7b, 0xc0, 0x72, 0xec, 0xd9, 0xec, 0xa8, 0xb6, 0xc7, 0xf2, 0x38, 0x5d, 0xf2,
+	0x1f, 0xa0, 0x3d, 0x71, 0xe0, 0xc0, 0x81, 0x95, 0x90, 0xb8, 0x54, 0x08, 0x81, 0x38, 0x71, 0xe3,
+	0x5c, 0x71, 0xea, 0xb1, 0x12, 0x52, 0xa0, 0xe9, 0x7f, 0x90, 0x03, 0x67, 0xe4, 0xf1, 0x38, 0xf1,
+	0xae, 0x02, 0x14, 0x01, 0x0b, 0x5c, 0x92, 0xcc, 0xbc, 0xf7, 0x3e, 0xef, 0xcd, 0xe7, 0x7d, 0x3c,
+	0x7e, 0x01, 0xff, 0x33, 0x08, 0xb5, 0x0f, 0x74, 0x6a, 0xd7, 0xd9, 0xc7, 0xe3, 0x1b, 0x3d, 0xe4,
+	0xeb, 0x37, 0xea, 0xae, 0x47, 0x5c, 0x42, 0x75, 0xab, 0xe6, 0x7a, 0xc4, 0x27, 0xe2, 0x6a, 0xe4,
+	0x55, 0x63, 0x1f, 0xdc, 0xab, 0xbc, 0xd2, 0x27, 0x7d, 0xc2, 0x3c, 0xea, 0xc1, 0xaf, 0xd0, 0xb9,
+	0x2c, 0x05, 0xce, 0x84, 0xd6, 0x7b, 0x3a, 0x45, 0x53, 0x40, 0x83, 0x60, 0x87, 0xdb, 0xaf, 0xcc,
+	0x4f, 0xe9, 0x0f, 0x5d, 0x44, 0x43, 0x17, 0xf8, 0x24, 0x09, 0x96, 0xba, 0x3e, 0xf1, 0x50, 0x93,
+	0x98, 0xa8, 0xc3, 0x6b, 0x11, 0x57, 0xc0, 0x82, 0x8f, 0x7d, 0x0b, 0x95, 0x84, 0x8a, 0x50, 0xcd,
+	0xab, 0xe1, 0x42, 0xac, 0x80, 0x82, 0x89, 0xa8, 0xe1, 0x61, 0xd7, 0xc7, 0xc4, 0x29, 0x25, 0x99,
+	0x2d, 0xbe, 0x25, 0xae, 0x82, 0x8c, 0x37, 0x70, 0x34, 0x9d, 0x96, 0x52, 0x61, 0xa0, 0x37, 0x70,
+	0x1a, 0x54, 0x7c, 0x07, 0x9c, 0x0d, 0x0a, 0xd0, 0x7a, 0x43, 0x1f, 0x69, 0x06, 0x31, 0x51, 0x29,
+	0x5d, 0x11, 0xaa, 0x8b, 0x4a, 0x71, 0x3c, 0x92, 0x17, 0xef, 0x37, 0xba, 0x9b, 0xca, 0xd0, 0x67,
+	0x05, 0xa8, 0x8b, 0x81, 0x5f, 0xb4, 0x12, 0xd7, 0x40, 0x86, 0x92, 0x81, 0x67, 0xa0, 0xd2, 0x02,
+	0x83, 0xe3, 0x2b, 0xb1, 0x04, 0xb2, 0xbd, 0x01, 0xb6, 0x4c, 0xe4, 0x95, 0x32, 0xcc, 0x10, 0x2d,
+	0xc5, 0x87, 0x60, 0x0d, 0x3b, 0xd4, 0xd7, 0x1d, 0x1f, 0xeb, 0x3e, 0xd2, 0x5c, 0xe4, 0xd9, 0x98,
+	0xd2, 0xa0, 0xda, 0x6c, 0x45, 0xa8, 0x16, 0xd6, 0xaf, 0xd6, 0xe6, 0xf2, 0x5b, 0x6b, 0x18, 0x06,
+	0xa2, 0xb4, 0x49, 0x9c, 0x3d, 0xdc, 0x57, 0x57, 0x63, 0x10, 0x9d, 0x29, 0x02, 0xfc, 0x39, 0x09,
+	0x2e, 0xb6, 0x67, 0x96, 0x26, 0x71, 0x7c, 0x4f, 0x37, 0xfc, 0xbf, 0x8b, 0xb4, 0x15, 0xb0, 0xa0,
+	0x9b, 0x36, 0x76, 0x18, 0x57, 0x79, 0x35, 0x5c, 0x88, 0x57, 0x41, 0x36, 0x20, 0x50, 0xc3, 0x26,
+	0xe3, 0x24, 0xad, 0x80, 0xf1, 0x48, 0xce, 0x04, 0x6c, 0xb5, 0x37, 0xd4, 0x4c, 0x60, 0x6a, 0x9b,
+	0x41, 0xa8, 0xa5, 0xf7, 0x90, 0xc5, 0xd9, 0x09, 0x17, 0xe2, 0x05, 0x90, 0xc3, 0x0e, 0xf6, 0x35,
+	0x9b, 0xf6, 0x19, 0x1b, 0x8b, 0x6a, 0x36, 0x58, 0x6f, 0xd2, 0xbe, 0xa8, 0x83, 0x85, 0xbd, 0x81,
+	0x63, 0xd2, 0x52, 0xae, 0x92, 0xaa, 0x16, 0xd6, 0x2f, 0xd4, 0x42, 0x61, 0xd5, 0x02, 0x61, 0x4d,
+	0x39, 0x6a, 0x12, 0xec, 0x28, 0x6f, 0x3c, 0x1d, 0xc9, 0x89, 0x2f, 0x7e, 0x94, 0xab, 0x7d, 0xec,
+	0xef, 0x0f, 0x7a, 0x35, 0x83, 0xd8, 0x75, 0xae, 0xc2, 0xf0, 0xeb, 0x75, 0x6a, 0x3e, 0xe2, 0x0a,
+	0x0b, 0x02, 0xa8, 0x1a, 0x22, 0x8b, 0x37, 0xc1, 0x05, 0xf6, 0x43, 0xdb, 0xf3, 0x88, 0xad, 0x19,
+	0xc4, 0xb6, 0x07, 0x0e, 0xf6, 0x87, 0x9a, 0x4b, 0x88, 0x55, 0xca, 0x57, 0x84, 0x6a, 0x4e, 0x5d,
+	0x63, 0x0e, 0xb7, 0x3d, 0x62, 0x37, 0x23, 0x73, 0x87, 0x10, 0x0b, 0x7e, 0x2f, 0x80, 0xf3, 0x9b,
+	0xb8, 0xef, 0x9d, 0x02, 0xe9, 0x65, 0x90, 0x33, 0x78, 0x0a, 0xce, 0xfb, 0x74, 0xfd, 0x6a, 0xd4,
+	0xcb, 0xa0, 0x60, 0x87, 0xa5, 0x32, 0x9e, 0x33, 0x8c, 0x67, 0xc0, 0xb7, 0x36, 0x69, 0x1f, 0x7e,
+	0x2a, 0x80, 0xe5, 0x5d, 0xd7, 0xd4, 0x7d, 0xd4, 0x08, 0x1a, 0xfa, 0xa7, 0x0f, 0x72, 0x03, 0xe4,
+	0x1d, 0x74, 0xa0, 0x85, 0x52, 0x61, 0x67, 0x51, 0x56, 0x26, 0x23, 0xb9, 0x38, 0xd4, 0x6d, 0xeb,
+	0x16, 0x9c, 0x9a, 0xa0, 0x9a, 0x73, 0xd0, 0x01, 0x4b, 0xf9, 0x5b, 0x87, 0x84, 0xfb, 0x40, 0x6c,
+	0x5a, 0x48, 0xf7, 0xfe, 0x9a, 0xe2, 0xe2, 0x99, 0x52, 0x27, 0x32, 0x7d, 0x2d, 0x80, 0x62, 0x07,
+	0x3b, 0x01, 0x7f, 0x74, 0x9a, 0xe8, 0xda, 0xb1, 0x44, 0x4a, 0x71, 0x32, 0x92, 0x17, 0xc3, 0x93,
+	0xb0, 0x6d, 0x18, 0xa5, 0x7e, 0x6f, 0x4e, 0x6a, 0x65, 0x6d, 0x32, 0x92, 0xc5, 0xd0, 0x3b, 0x66,
+	0x84, 0xc7, 0x4b, 0xba, 0x09, 0x72, 0xbc, 0x8b, 0x41, 0xeb, 0x53, 0xd5, 0xb4, 0x22, 0x8d, 0x47,
+	0x72, 0x36, 0x6c, 0x23, 0x9d, 0x8c, 0xe4, 0x73, 0x21, 0x42, 0xe4, 0x04, 0xd5, 0x6c, 0xd8, 0x5a,
+	0x0a, 0xbf, 0x11, 0x80, 0xb8, 0xeb, 0xb8, 0xff, 0xa9, 0x9a, 0x7f, 0x48, 0x82, 0x4b, 0x2a, 0x32,
+	0xc8, 0x63, 0xe4, 0x45, 0xcf, 0xce, 0xed, 0xe0, 0x29, 0x3b, 0xc5, 0xea, 0xeb, 0x27, 0x45, 0xa0,
+	0x2c, 0xc7, 0x4b, 0xe6, 0x72, 0x88, 0x3d, 0x68, 0xeb, 0x20, 0xef, 0x21, 0x03, 0xbb, 0x18, 0x39,
+	0x5c, 0xa0, 0x71, 0x49, 0x4f, 0x4d, 0x50, 0x9d, 0xb9, 0x89, 0x3e, 0xc8, 0xe8, 0x36, 0x19, 0x38,
+	0x7e, 0x69, 0xe1, 0xf7, 0xae, 0xb0, 0x46, 0x70, 0x85, 0x4d, 0x46, 0xf2, 0x99, 0x10, 0x2f, 0x0c,
+	0x83, 0x7f, 0xe8, 0x4e, 0xe3, 0xb9, 0xe0, 0x77, 0x02, 0x58, 0x8d, 0x68, 0xbd, 0x87, 0xa8, 0x8f,
+	0x9d, 0x7e, 0x07, 0x79, 0x98, 0x98, 0xe2, 0xff, 0x41, 0xc6, 0x42, 0x4e, 0xdf, 0xdf, 0x67, 0xbc,
+	0xa6, 0x94, 0xa5, 0x59, 0xc2, 0x70, 0x1f, 0xaa, 0xdc, 0x21, 0x56, 0x7a, 0xf2, 0xf4, 0x4a, 0xbf,
+	0x95, 0xfe, 0xe4, 0x33, 0x59, 0x80, 0x5f, 0xa6, 0xc0, 0xf9, 0x13, 0x07, 0xe8, 0x1a, 0xfb, 0xc8,
+	0x1c, 0x58, 0x48, 0xdc, 0x06, 0xe9, 0x20, 0x90, 0x1d, 0xe0, 0xec, 0xfa, 0xf5, 0x5f, 0x79, 0x73,
+	0x9e, 0x88, 0xde, 0x19, 0xba, 0x48, 0x39, 0x37, 0x19, 0xc9, 0x05, 0x2e, 0xa2, 0xa1, 0x8b, 0xa0,
+	0xca, 0x80, 0xfe, 0x99, 0x83, 0x8a, 0x6f, 0x01, 0x40, 0x7d, 0xdd, 0xf3, 0x35, 0x1f, 0xdb, 0x88,
+	0x09, 0x30, 0xa5, 0xac, 0x4e, 0x46, 0xf2, 0x52, 0x08, 0x3d, 0xb3, 0x41, 0x35, 0xcf, 0x16, 0x3b,
+	0xd8, 0x46, 0x62, 0x0d, 0xe4, 0x90, 0x63, 0x86, 0x31, 0x69, 0x16, 0x13, 0x13, 0x6d, 0x64, 0x81,
+	0x6a, 0x16, 0x39, 0x26, 0xf3, 0x7f, 0x1f, 0x64, 0x5d, 0xd6, 0x79, 0xca, 0x05, 0xf8, 0xda, 0xab,
+	0xf1, 0x15, 0xca, 0x45, 0x59, 0xe3, 0xe7, 0x3d, 0x1b, 0x26, 0xe0, 0x50, 0x50, 0x8d, 0x40, 0x79,
+	0xbb, 0x3e, 0x4e, 0x82, 0x72, 0x17, 0xf9, 0x27, 0x31, 0xfe, 0xc5, 0xcf, 0xb2, 0x01, 0x72, 0x94,
+	0x0b, 0x8a, 0xf1, 0x58, 0x58, 0xaf, 0xbd, 0x1a, 0x31, 0x91, 0x0c, 0x95, 0xf3, 0x9c, 0x1a, 0x9e,
+	0x24, 0x42, 0x83, 0xea, 0x14, 0x18, 0x7e, 0x95, 0x02, 0x17, 0xf9, 0x80, 0xd0, 0xb0, 0xac, 0x08,
+	0xe8, 0x34, 0xef, 0xb8, 0xea, 0xf1, 0x71, 0x22, 0xfe, 0xb8, 0x87, 0xfb, 0x30, 0x9a, 0x30, 0xde,
+	0x9e, 0x4d, 0x11, 0x69, 0x36, 0x45, 0x5c, 0x9a, 0x4d, 0x11, 0x33, 0x01, 0x70, 0x17, 0x38, 0x9d,
+	0x2b, 0x9a, 0xa0, 0x10, 0xbc, 0xcb, 0x8f, 0x0f, 0x20, 0x57, 0xc7, 0x23, 0x39, 0xbf, 0x85, 0x0e,
+	0xa6, 0xd1, 0xe2, 0xec, 0xad, 0x3f, 0x45, 0xc8, 0x3b, 0xdc, 0xc1, 0x14, 0xdf, 0x9d, 0x33, 0x9c,
+	0xc4, 0xcf, 0x17, 0x33, 0xc2, 0xf8, 0xd0, 0x22, 0x6e, 0x81, 0xe5, 0xa8, 0xa3, 0x34, 0x18, 0xaa,
+	0xb5, 0x9e, 0x45, 0x8c, 0x47, 0x6c, 0x8a, 0x3c, 0xa3, 0x48, 0x93, 0x91, 0x5c, 0x3e, 0xae, 0x80,
+	0x98, 0x13, 0x54, 0x97, 0xa6, 0xbb, 0x1d, 0xe4, 0x29, 0xc1, 0xde, 0xf5, 0x6f, 0x93, 0x60, 0x79,
+	0xce, 0xc5, 0x21, 0xb6, 0xc1, 0x95, 0xe6, 0xf6, 0xd6, 0x8e, 0xda, 0x68, 0xee, 0x68, 0xf7, 0x5a,
+	0xdd, 0x9d, 0xf6, 0xd6, 0x1d, 0x6d, 0xe7, 0x41, 0xa7, 0xa5, 0xed, 0x6e, 0x75, 0x3b, 0xad, 0x66,
+	0xfb, 0x76, 0xbb, 0xb5, 0x51, 0x4c, 0x94, 0xe1, 0xe1, 0x51, 0x45, 0x9a, 0x13, 0xbf, 0xeb, 0x50,
+	0x17, 0x19, 0x78, 0x0f, 0x23, 0x53, 0xbc, 0x03, 0x2a, 0xf3, 0xa1, 0x82, 0xdd, 0xf6, 0xd6, 0xee,
+	0xf6, 0x6e, 0xb7, 0x28, 0x94, 0xaf, 0x1c, 0x1e, 0x55, 0x2e, 0xcf, 0x41, 0x0a, 0xb6, 0xb0, 0x33,
+	0x20, 0x03, 0x2a, 0x36, 0xc0, 0xe5, 0xf9, 0x40, 0x1b, 0xad, 0xbb, 0x8d, 0x07, 0xad, 0x8d, 0x62,
+	0xb2, 0x2c, 0x1d, 0x1e, 0x55, 0xca, 0x73, 0x50, 0x36, 0x90, 0xa5, 0x0f, 0x51, 0xd0, 0x3c, 0x69,
+	0x3e, 0x44, 0xa7, 0xa5, 0xb6, 0xb7, 0x37, 0xda, 0xcd, 0x62, 0xaa, 0x2c, 0x1f, 0x1e, 0x55, 0x2e,
+	0xce, 0xc1, 0x08, 0x2f, 0x08, 0x6c, 0x94, 0xd3, 0x1f, 0x7e, 0x2e, 0x25, 0x94, 0xbb, 0x4f, 0x5f,
+	0x48, 0x89, 0xe7, 0x2f, 0xa4, 0xc4, 0x93, 0xb1, 0x24, 0x3c, 0x1d, 0x4b, 0xc2, 0xb3, 0xb1, 0x24,
+	0xfc, 0x34, 0x96, 0x84, 0x8f, 0x5e, 0x4a, 0x89, 0x67, 0x2f, 0xa5, 0xc4, 0xf3, 0x97, 0x52, 0xe2,
+	0xe1, 0xb5, 0xd8, 0x2d, 0xd9, 0x24, 0xd4, 0xbe, 0x1f, 0xfd, 0x07, 0x34, 0xeb, 0x1f, 0xb0, 0xef,
+	0xf0, 0xa6, 0xec, 0x65, 0xd8, 0x9f, 0xc0, 0x37, 0x7f, 0x19, 0x00, 0xa2, 0xd8, 0x60, 0xfd, 0x9c,
+	0x0e, 0x00, 0x00,
 }
 
 func (this *StoreCodeProposal) Equal(that interface{}) bool {
@@ -504,6 +836,9 @@ func (this *InstantiateContractProposal) Equal(that interface{}) bool {
 			return false
 		}
 	}
+	if this.FundsFromCommunityPool != that1.FundsFromCommunityPool {
+		return false
+	}
 	return true
 }
 func (this *MigrateContractProposal) Equal(that interface{}) bool {
@@ -678,103 +1013,307 @@ func (this *UnpinCodesProposal) Equal(that interface{}) bool {
 	}
 	return true
 }
-func (m *StoreCodeProposal) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *RecoverContractFundsProposal) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
-
-func (m *StoreCodeProposal) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
 
-func (m *StoreCodeProposal) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.InstantiatePermission != nil {
-		{
-			size, err := m.InstantiatePermission.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintProposal(dAtA, i, uint64(size))
+	that1, ok := that.(*RecoverContractFundsProposal)
+	if !ok {
+		that2, ok := that.(RecoverContractFundsProposal)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
-		i--
-		dAtA[i] = 0x3a
 	}
-	if len(m.Builder) > 0 {
-		i -= len(m.Builder)
-		copy(dAtA[i:], m.Builder)
-		i = encodeVarintProposal(dAtA, i, uint64(len(m.Builder)))
-		i--
-		dAtA[i] = 0x32
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if len(m.Source) > 0 {
-		i -= len(m.Source)
-		copy(dAtA[i:], m.Source)
-		i = encodeVarintProposal(dAtA, i, uint64(len(m.Source)))
-		i--
-		dAtA[i] = 0x2a
+	if this.Title != that1.Title {
+		return false
 	}
-	if len(m.WASMByteCode) > 0 {
-		i -= len(m.WASMByteCode)
-		copy(dAtA[i:], m.WASMByteCode)
-		i = encodeVarintProposal(dAtA, i, uint64(len(m.WASMByteCode)))
-		i--
-		dAtA[i] = 0x22
+	if this.Description != that1.Description {
+		return false
 	}
-	if len(m.RunAs) > 0 {
-		i -= len(m.RunAs)
-		copy(dAtA[i:], m.RunAs)
-		i = encodeVarintProposal(dAtA, i, uint64(len(m.RunAs)))
-		i--
-		dAtA[i] = 0x1a
+	if this.Contract != that1.Contract {
+		return false
 	}
-	if len(m.Description) > 0 {
-		i -= len(m.Description)
-		copy(dAtA[i:], m.Description)
-		i = encodeVarintProposal(dAtA, i, uint64(len(m.Description)))
-		i--
-		dAtA[i] = 0x12
+	if this.Recipient != that1.Recipient {
+		return false
 	}
-	if len(m.Title) > 0 {
-		i -= len(m.Title)
-		copy(dAtA[i:], m.Title)
-		i = encodeVarintProposal(dAtA, i, uint64(len(m.Title)))
-		i--
-		dAtA[i] = 0xa
+	if len(this.Amount) != len(that1.Amount) {
+		return false
 	}
-	return len(dAtA) - i, nil
+	for i := range this.Amount {
+		if !this.Amount[i].Equal(&that1.Amount[i]) {
+			return false
+		}
+	}
+	return true
 }
+func (this *ContractVestingPeriod) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *InstantiateContractProposal) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	that1, ok := that.(*ContractVestingPeriod)
+	if !ok {
+		that2, ok := that.(ContractVestingPeriod)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return dAtA[:n], nil
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Length != that1.Length {
+		return false
+	}
+	if len(this.Amount) != len(that1.Amount) {
+		return false
+	}
+	for i := range this.Amount {
+		if !this.Amount[i].Equal(&that1.Amount[i]) {
+			return false
+		}
+	}
+	return true
 }
+func (this *ContractVestingSchedule) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *InstantiateContractProposal) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
+	that1, ok := that.(*ContractVestingSchedule)
+	if !ok {
+		that2, ok := that.(ContractVestingSchedule)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Type != that1.Type {
+		return false
+	}
+	if len(this.Amount) != len(that1.Amount) {
+		return false
+	}
+	for i := range this.Amount {
+		if !this.Amount[i].Equal(&that1.Amount[i]) {
+			return false
+		}
+	}
+	if this.StartTime != that1.StartTime {
+		return false
+	}
+	if this.EndTime != that1.EndTime {
+		return false
+	}
+	if len(this.Periods) != len(that1.Periods) {
+		return false
+	}
+	for i := range this.Periods {
+		if !this.Periods[i].Equal(&that1.Periods[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *SetContractVestingProposal) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*SetContractVestingProposal)
+	if !ok {
+		that2, ok := that.(SetContractVestingProposal)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Title != that1.Title {
+		return false
+	}
+	if this.Description != that1.Description {
+		return false
+	}
+	if this.Contract != that1.Contract {
+		return false
+	}
+	if !this.Schedule.Equal(&that1.Schedule) {
+		return false
+	}
+	return true
+}
+func (this *MigrateAllContractsProposal) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*MigrateAllContractsProposal)
+	if !ok {
+		that2, ok := that.(MigrateAllContractsProposal)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Title != that1.Title {
+		return false
+	}
+	if this.Description != that1.Description {
+		return false
+	}
+	if this.RunAs != that1.RunAs {
+		return false
+	}
+	if this.CodeID != that1.CodeID {
+		return false
+	}
+	if this.NewCodeID != that1.NewCodeID {
+		return false
+	}
+	if !bytes.Equal(this.MigrateMsg, that1.MigrateMsg) {
+		return false
+	}
+	if this.ContractsPerBlock != that1.ContractsPerBlock {
+		return false
+	}
+	return true
+}
+func (m *StoreCodeProposal) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StoreCodeProposal) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StoreCodeProposal) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.InstantiatePermission != nil {
+		{
+			size, err := m.InstantiatePermission.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintProposal(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.Builder) > 0 {
+		i -= len(m.Builder)
+		copy(dAtA[i:], m.Builder)
+		i = encodeVarintProposal(dAtA, i, uint64(len(m.Builder)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.Source) > 0 {
+		i -= len(m.Source)
+		copy(dAtA[i:], m.Source)
+		i = encodeVarintProposal(dAtA, i, uint64(len(m.Source)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.WASMByteCode) > 0 {
+		i -= len(m.WASMByteCode)
+		copy(dAtA[i:], m.WASMByteCode)
+		i = encodeVarintProposal(dAtA, i, uint64(len(m.WASMByteCode)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.RunAs) > 0 {
+		i -= len(m.RunAs)
+		copy(dAtA[i:], m.RunAs)
+		i = encodeVarintProposal(dAtA, i, uint64(len(m.RunAs)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Description) > 0 {
+		i -= len(m.Description)
+		copy(dAtA[i:], m.Description)
+		i = encodeVarintProposal(dAtA, i, uint64(len(m.Description)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Title) > 0 {
+		i -= len(m.Title)
+		copy(dAtA[i:], m.Title)
+		i = encodeVarintProposal(dAtA, i, uint64(len(m.Title)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *InstantiateContractProposal) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *InstantiateContractProposal) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
 
 func (m *InstantiateContractProposal) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
+	if m.FundsFromCommunityPool {
+		i--
+		if m.FundsFromCommunityPool {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x48
+	}
 	if len(m.Funds) > 0 {
 		for iNdEx := len(m.Funds) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -1107,42 +1646,335 @@ func (m *UnpinCodesProposal) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintProposal(dAtA []byte, offset int, v uint64) int {
-	offset -= sovProposal(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *RecoverContractFundsProposal) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return dAtA[:n], nil
 }
-func (m *StoreCodeProposal) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+
+func (m *RecoverContractFundsProposal) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RecoverContractFundsProposal) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Title)
-	if l > 0 {
-		n += 1 + l + sovProposal(uint64(l))
+	if len(m.Amount) > 0 {
+		for iNdEx := len(m.Amount) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Amount[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintProposal(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
 	}
-	l = len(m.Description)
-	if l > 0 {
-		n += 1 + l + sovProposal(uint64(l))
+	if len(m.Recipient) > 0 {
+		i -= len(m.Recipient)
+		copy(dAtA[i:], m.Recipient)
+		i = encodeVarintProposal(dAtA, i, uint64(len(m.Recipient)))
+		i--
+		dAtA[i] = 0x22
 	}
-	l = len(m.RunAs)
-	if l > 0 {
-		n += 1 + l + sovProposal(uint64(l))
+	if len(m.Contract) > 0 {
+		i -= len(m.Contract)
+		copy(dAtA[i:], m.Contract)
+		i = encodeVarintProposal(dAtA, i, uint64(len(m.Contract)))
+		i--
+		dAtA[i] = 0x1a
 	}
-	l = len(m.WASMByteCode)
-	if l > 0 {
-		n += 1 + l + sovProposal(uint64(l))
+	if len(m.Description) > 0 {
+		i -= len(m.Description)
+		copy(dAtA[i:], m.Description)
+		i = encodeVarintProposal(dAtA, i, uint64(len(m.Description)))
+		i--
+		dAtA[i] = 0x12
 	}
-	l = len(m.Source)
-	if l > 0 {
-		n += 1 + l + sovProposal(uint64(l))
+	if len(m.Title) > 0 {
+		i -= len(m.Title)
+		copy(dAtA[i:], m.Title)
+		i = encodeVarintProposal(dAtA, i, uint64(len(m.Title)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ContractVestingPeriod) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ContractVestingPeriod) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ContractVestingPeriod) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Amount) > 0 {
+		for iNdEx := len(m.Amount) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Amount[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintProposal(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Length != 0 {
+		i = encodeVarintProposal(dAtA, i, uint64(m.Length))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ContractVestingSchedule) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ContractVestingSchedule) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ContractVestingSchedule) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Periods) > 0 {
+		for iNdEx := len(m.Periods) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Periods[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintProposal(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if m.EndTime != 0 {
+		i = encodeVarintProposal(dAtA, i, uint64(m.EndTime))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.StartTime != 0 {
+		i = encodeVarintProposal(dAtA, i, uint64(m.StartTime))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Amount) > 0 {
+		for iNdEx := len(m.Amount) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Amount[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintProposal(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Type != 0 {
+		i = encodeVarintProposal(dAtA, i, uint64(m.Type))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SetContractVestingProposal) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SetContractVestingProposal) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SetContractVestingProposal) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.Schedule.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintProposal(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x22
+	if len(m.Contract) > 0 {
+		i -= len(m.Contract)
+		copy(dAtA[i:], m.Contract)
+		i = encodeVarintProposal(dAtA, i, uint64(len(m.Contract)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Description) > 0 {
+		i -= len(m.Description)
+		copy(dAtA[i:], m.Description)
+		i = encodeVarintProposal(dAtA, i, uint64(len(m.Description)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Title) > 0 {
+		i -= len(m.Title)
+		copy(dAtA[i:], m.Title)
+		i = encodeVarintProposal(dAtA, i, uint64(len(m.Title)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MigrateAllContractsProposal) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MigrateAllContractsProposal) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MigrateAllContractsProposal) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ContractsPerBlock != 0 {
+		i = encodeVarintProposal(dAtA, i, uint64(m.ContractsPerBlock))
+		i--
+		dAtA[i] = 0x38
+	}
+	if len(m.MigrateMsg) > 0 {
+		i -= len(m.MigrateMsg)
+		copy(dAtA[i:], m.MigrateMsg)
+		i = encodeVarintProposal(dAtA, i, uint64(len(m.MigrateMsg)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.NewCodeID != 0 {
+		i = encodeVarintProposal(dAtA, i, uint64(m.NewCodeID))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.CodeID != 0 {
+		i = encodeVarintProposal(dAtA, i, uint64(m.CodeID))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.RunAs) > 0 {
+		i -= len(m.RunAs)
+		copy(dAtA[i:], m.RunAs)
+		i = encodeVarintProposal(dAtA, i, uint64(len(m.RunAs)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Description) > 0 {
+		i -= len(m.Description)
+		copy(dAtA[i:], m.Description)
+		i = encodeVarintProposal(dAtA, i, uint64(len(m.Description)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Title) > 0 {
+		i -= len(m.Title)
+		copy(dAtA[i:], m.Title)
+		i = encodeVarintProposal(dAtA, i, uint64(len(m.Title)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintProposal(dAtA []byte, offset int, v uint64) int {
+	offset -= sovProposal(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *StoreCodeProposal) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Title)
+	if l > 0 {
+		n += 1 + l + sovProposal(uint64(l))
+	}
+	l = len(m.Description)
+	if l > 0 {
+		n += 1 + l + sovProposal(uint64(l))
+	}
+	l = len(m.RunAs)
+	if l > 0 {
+		n += 1 + l + sovProposal(uint64(l))
+	}
+	l = len(m.WASMByteCode)
+	if l > 0 {
+		n += 1 + l + sovProposal(uint64(l))
+	}
+	l = len(m.Source)
+	if l > 0 {
+		n += 1 + l + sovProposal(uint64(l))
 	}
 	l = len(m.Builder)
 	if l > 0 {
@@ -1194,6 +2026,9 @@ func (m *InstantiateContractProposal) Size() (n int) {
 			n += 1 + l + sovProposal(uint64(l))
 		}
 	}
+	if m.FundsFromCommunityPool {
+		n += 2
+	}
 	return n
 }
 
@@ -1323,11 +2158,147 @@ func (m *UnpinCodesProposal) Size() (n int) {
 	return n
 }
 
-func sovProposal(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozProposal(x uint64) (n int) {
-	return sovProposal(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+func (m *RecoverContractFundsProposal) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Title)
+	if l > 0 {
+		n += 1 + l + sovProposal(uint64(l))
+	}
+	l = len(m.Description)
+	if l > 0 {
+		n += 1 + l + sovProposal(uint64(l))
+	}
+	l = len(m.Contract)
+	if l > 0 {
+		n += 1 + l + sovProposal(uint64(l))
+	}
+	l = len(m.Recipient)
+	if l > 0 {
+		n += 1 + l + sovProposal(uint64(l))
+	}
+	if len(m.Amount) > 0 {
+		for _, e := range m.Amount {
+			l = e.Size()
+			n += 1 + l + sovProposal(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ContractVestingPeriod) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Length != 0 {
+		n += 1 + sovProposal(uint64(m.Length))
+	}
+	if len(m.Amount) > 0 {
+		for _, e := range m.Amount {
+			l = e.Size()
+			n += 1 + l + sovProposal(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ContractVestingSchedule) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != 0 {
+		n += 1 + sovProposal(uint64(m.Type))
+	}
+	if len(m.Amount) > 0 {
+		for _, e := range m.Amount {
+			l = e.Size()
+			n += 1 + l + sovProposal(uint64(l))
+		}
+	}
+	if m.StartTime != 0 {
+		n += 1 + sovProposal(uint64(m.StartTime))
+	}
+	if m.EndTime != 0 {
+		n += 1 + sovProposal(uint64(m.EndTime))
+	}
+	if len(m.Periods) > 0 {
+		for _, e := range m.Periods {
+			l = e.Size()
+			n += 1 + l + sovProposal(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *SetContractVestingProposal) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Title)
+	if l > 0 {
+		n += 1 + l + sovProposal(uint64(l))
+	}
+	l = len(m.Description)
+	if l > 0 {
+		n += 1 + l + sovProposal(uint64(l))
+	}
+	l = len(m.Contract)
+	if l > 0 {
+		n += 1 + l + sovProposal(uint64(l))
+	}
+	l = m.Schedule.Size()
+	n += 1 + l + sovProposal(uint64(l))
+	return n
+}
+
+func (m *MigrateAllContractsProposal) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Title)
+	if l > 0 {
+		n += 1 + l + sovProposal(uint64(l))
+	}
+	l = len(m.Description)
+	if l > 0 {
+		n += 1 + l + sovProposal(uint64(l))
+	}
+	l = len(m.RunAs)
+	if l > 0 {
+		n += 1 + l + sovProposal(uint64(l))
+	}
+	if m.CodeID != 0 {
+		n += 1 + sovProposal(uint64(m.CodeID))
+	}
+	if m.NewCodeID != 0 {
+		n += 1 + sovProposal(uint64(m.NewCodeID))
+	}
+	l = len(m.MigrateMsg)
+	if l > 0 {
+		n += 1 + l + sovProposal(uint64(l))
+	}
+	if m.ContractsPerBlock != 0 {
+		n += 1 + sovProposal(uint64(m.ContractsPerBlock))
+	}
+	return n
+}
+
+func sovProposal(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozProposal(x uint64) (n int) {
+	return sovProposal(uint64((x << 1) ^ uint64((int64(x) >> 63))))
 }
 func (m *StoreCodeProposal) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
@@ -1885,6 +2856,26 @@ func (m *InstantiateContractProposal) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FundsFromCommunityPool", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProposal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.FundsFromCommunityPool = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProposal(dAtA[iNdEx:])
@@ -2841,6 +3832,912 @@ func (m *UnpinCodesProposal) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *RecoverContractFundsProposal) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProposal
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RecoverContractFundsProposal: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RecoverContractFundsProposal: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Title", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProposal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProposal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProposal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Title = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Description", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProposal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProposal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProposal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Description = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Contract", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProposal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProposal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProposal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Contract = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Recipient", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProposal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProposal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProposal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Recipient = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Amount", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProposal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProposal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProposal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Amount = append(m.Amount, types.Coin{})
+			if err := m.Amount[len(m.Amount)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProposal(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthProposal
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ContractVestingPeriod) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProposal
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ContractVestingPeriod: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ContractVestingPeriod: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Length", wireType)
+			}
+			m.Length = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProposal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Length |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Amount", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProposal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProposal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProposal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Amount = append(m.Amount, types.Coin{})
+			if err := m.Amount[len(m.Amount)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProposal(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthProposal
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ContractVestingSchedule) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProposal
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ContractVestingSchedule: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ContractVestingSchedule: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProposal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= ContractVestingType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Amount", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProposal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProposal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProposal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Amount = append(m.Amount, types.Coin{})
+			if err := m.Amount[len(m.Amount)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartTime", wireType)
+			}
+			m.StartTime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProposal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StartTime |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndTime", wireType)
+			}
+			m.EndTime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProposal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EndTime |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Periods", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProposal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProposal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProposal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Periods = append(m.Periods, ContractVestingPeriod{})
+			if err := m.Periods[len(m.Periods)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProposal(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthProposal
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SetContractVestingProposal) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProposal
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SetContractVestingProposal: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SetContractVestingProposal: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Title", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProposal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProposal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProposal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Title = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Description", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProposal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProposal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProposal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Description = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Contract", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProposal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProposal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProposal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Contract = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Schedule", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProposal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProposal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProposal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Schedule.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProposal(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthProposal
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MigrateAllContractsProposal) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProposal
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MigrateAllContractsProposal: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MigrateAllContractsProposal: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Title", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProposal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProposal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProposal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Title = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Description", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProposal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProposal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProposal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Description = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RunAs", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProposal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProposal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProposal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RunAs = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CodeID", wireType)
+			}
+			m.CodeID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProposal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CodeID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NewCodeID", wireType)
+			}
+			m.NewCodeID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProposal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NewCodeID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MigrateMsg", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProposal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthProposal
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProposal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MigrateMsg = append(m.MigrateMsg[:0], dAtA[iNdEx:postIndex]...)
+			if m.MigrateMsg == nil {
+				m.MigrateMsg = []byte{}
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContractsPerBlock", wireType)
+			}
+			m.ContractsPerBlock = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProposal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ContractsPerBlock |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProposal(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthProposal
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipProposal(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -0,0 +1,59 @@
+//go:build go1.18
+// +build go1.18
+
+package types
+
+import (
+	"testing"
+
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// FuzzProposalValidateBasic decodes attacker controlled bytes from the wire into every proposal type. ValidateBasic
+// must not panic.
+func FuzzProposalValidateBasic(f *testing.F) {
+	seeds := []interface{ Marshal() ([]byte, error) }{
+		StoreCodeProposalFixture(),
+		InstantiateContractProposalFixture(),
+		MigrateContractProposalFixture(),
+		UpdateAdminProposalFixture(),
+		ClearAdminProposalFixture(),
+		RecoverContractFundsProposalFixture(),
+		SetContractVestingProposalFixture(),
+		&PinCodesProposal{Title: "Foo", Description: "Bar", CodeIDs: []uint64{1}},
+		&UnpinCodesProposal{Title: "Foo", Description: "Bar", CodeIDs: []uint64{1}},
+	}
+	for _, s := range seeds {
+		bz, err := s.Marshal()
+		require.NoError(f, err)
+		f.Add(bz)
+	}
+	type wireProposal interface {
+		govtypes.Content
+		Unmarshal([]byte) error
+	}
+	f.Fuzz(func(t *testing.T, bz []byte) {
+		for _, p := range []wireProposal{
+			&StoreCodeProposal{},
+			&InstantiateContractProposal{},
+			&MigrateContractProposal{},
+			&UpdateAdminProposal{},
+			&ClearAdminProposal{},
+			&PinCodesProposal{},
+			&UnpinCodesProposal{},
+			&RecoverContractFundsProposal{},
+			&SetContractVestingProposal{},
+		} {
+			if err := p.Unmarshal(bz); err != nil {
+				continue
+			}
+			if err := p.ValidateBasic(); err != nil {
+				continue
+			}
+			assert.NotEmpty(t, p.GetTitle())
+			assert.NotEmpty(t, p.ProposalType())
+		}
+	})
+}
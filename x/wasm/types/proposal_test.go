@@ -124,11 +124,10 @@ func TestValidateStoreCodeProposal(t *testing.T) {
 			}),
 			expErr: true,
 		},
-		"run_as missing": {
+		"without run_as": {
 			src: StoreCodeProposalFixture(func(p *StoreCodeProposal) {
 				p.RunAs = ""
 			}),
-			expErr: true,
 		},
 		"run_as invalid": {
 			src: StoreCodeProposalFixture(func(p *StoreCodeProposal) {
@@ -213,16 +212,43 @@ func TestValidateInstantiateContractProposal(t *testing.T) {
 				p.Funds = nil
 			}),
 		},
+		"with funds from community pool": {
+			src: InstantiateContractProposalFixture(func(p *InstantiateContractProposal) {
+				p.Funds = sdk.NewCoins(sdk.NewInt64Coin("foo", 1))
+				p.FundsFromCommunityPool = true
+			}),
+		},
+		"funds from community pool without funds": {
+			src: InstantiateContractProposalFixture(func(p *InstantiateContractProposal) {
+				p.Funds = nil
+				p.FundsFromCommunityPool = true
+			}),
+			expErr: true,
+		},
 		"base data missing": {
 			src: InstantiateContractProposalFixture(func(p *InstantiateContractProposal) {
 				p.Title = ""
 			}),
 			expErr: true,
 		},
-		"run_as missing": {
+		"without run_as": {
 			src: InstantiateContractProposalFixture(func(p *InstantiateContractProposal) {
 				p.RunAs = ""
 			}),
+		},
+		"without run_as but funds": {
+			src: InstantiateContractProposalFixture(func(p *InstantiateContractProposal) {
+				p.RunAs = ""
+				p.Funds = sdk.Coins{{Denom: "foo", Amount: sdk.NewInt(1)}}
+			}),
+			expErr: true,
+		},
+		"without run_as but funds from community pool": {
+			src: InstantiateContractProposalFixture(func(p *InstantiateContractProposal) {
+				p.RunAs = ""
+				p.Funds = sdk.Coins{{Denom: "foo", Amount: sdk.NewInt(1)}}
+				p.FundsFromCommunityPool = true
+			}),
 			expErr: true,
 		},
 		"run_as invalid": {
@@ -445,6 +471,279 @@ func TestValidateClearAdminProposal(t *testing.T) {
 	}
 }
 
+func TestValidateSetContractVestingProposal(t *testing.T) {
+	periodic := func(p *SetContractVestingProposal) {
+		p.Schedule.Type = ContractVestingTypePeriodic
+		p.Schedule.EndTime = 0
+		p.Schedule.Periods = []ContractVestingPeriod{
+			{Length: 10, Amount: sdk.Coins{{Denom: "stake", Amount: sdk.NewInt(40)}}},
+			{Length: 20, Amount: sdk.Coins{{Denom: "stake", Amount: sdk.NewInt(60)}}},
+		}
+	}
+	delayed := func(p *SetContractVestingProposal) {
+		p.Schedule.Type = ContractVestingTypeDelayed
+		p.Schedule.StartTime = 0
+	}
+	specs := map[string]struct {
+		src    *SetContractVestingProposal
+		expErr bool
+	}{
+		"continuous": {
+			src: SetContractVestingProposalFixture(),
+		},
+		"delayed": {
+			src: SetContractVestingProposalFixture(delayed),
+		},
+		"periodic": {
+			src: SetContractVestingProposalFixture(periodic),
+		},
+		"base data missing": {
+			src: SetContractVestingProposalFixture(func(p *SetContractVestingProposal) {
+				p.Title = ""
+			}),
+			expErr: true,
+		},
+		"contract invalid": {
+			src: SetContractVestingProposalFixture(func(p *SetContractVestingProposal) {
+				p.Contract = "invalid address"
+			}),
+			expErr: true,
+		},
+		"type unspecified": {
+			src: SetContractVestingProposalFixture(func(p *SetContractVestingProposal) {
+				p.Schedule.Type = ContractVestingTypeUnspecified
+			}),
+			expErr: true,
+		},
+		"amount missing": {
+			src: SetContractVestingProposalFixture(func(p *SetContractVestingProposal) {
+				p.Schedule.Amount = nil
+			}),
+			expErr: true,
+		},
+		"amount invalid": {
+			src: SetContractVestingProposalFixture(func(p *SetContractVestingProposal) {
+				p.Schedule.Amount = sdk.Coins{{Denom: "stake", Amount: sdk.NewInt(0)}}
+			}),
+			expErr: true,
+		},
+		"continuous without start time": {
+			src: SetContractVestingProposalFixture(func(p *SetContractVestingProposal) {
+				p.Schedule.StartTime = 0
+			}),
+			expErr: true,
+		},
+		"continuous end before start": {
+			src: SetContractVestingProposalFixture(func(p *SetContractVestingProposal) {
+				p.Schedule.EndTime = p.Schedule.StartTime
+			}),
+			expErr: true,
+		},
+		"continuous with periods": {
+			src: SetContractVestingProposalFixture(func(p *SetContractVestingProposal) {
+				p.Schedule.Periods = []ContractVestingPeriod{{Length: 1, Amount: p.Schedule.Amount}}
+			}),
+			expErr: true,
+		},
+		"delayed with start time": {
+			src: SetContractVestingProposalFixture(delayed, func(p *SetContractVestingProposal) {
+				p.Schedule.StartTime = 1
+			}),
+			expErr: true,
+		},
+		"delayed without end time": {
+			src: SetContractVestingProposalFixture(delayed, func(p *SetContractVestingProposal) {
+				p.Schedule.EndTime = 0
+			}),
+			expErr: true,
+		},
+		"periodic with end time": {
+			src: SetContractVestingProposalFixture(periodic, func(p *SetContractVestingProposal) {
+				p.Schedule.EndTime = 2000
+			}),
+			expErr: true,
+		},
+		"periodic without periods": {
+			src: SetContractVestingProposalFixture(periodic, func(p *SetContractVestingProposal) {
+				p.Schedule.Periods = nil
+			}),
+			expErr: true,
+		},
+		"periodic with zero length": {
+			src: SetContractVestingProposalFixture(periodic, func(p *SetContractVestingProposal) {
+				p.Schedule.Periods[0].Length = 0
+			}),
+			expErr: true,
+		},
+		"periodic with empty period amount": {
+			src: SetContractVestingProposalFixture(periodic, func(p *SetContractVestingProposal) {
+				p.Schedule.Periods[0].Amount = nil
+			}),
+			expErr: true,
+		},
+		"periodic amounts do not add up": {
+			src: SetContractVestingProposalFixture(periodic, func(p *SetContractVestingProposal) {
+				p.Schedule.Amount = sdk.Coins{{Denom: "stake", Amount: sdk.NewInt(101)}}
+			}),
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateMigrateAllContractsProposal(t *testing.T) {
+	specs := map[string]struct {
+		src    *MigrateAllContractsProposal
+		expErr bool
+	}{
+		"all good": {
+			src: MigrateAllContractsProposalFixture(),
+		},
+		"with contracts per block": {
+			src: MigrateAllContractsProposalFixture(func(p *MigrateAllContractsProposal) {
+				p.ContractsPerBlock = 10
+			}),
+		},
+		"base data missing": {
+			src: MigrateAllContractsProposalFixture(func(p *MigrateAllContractsProposal) {
+				p.Title = ""
+			}),
+			expErr: true,
+		},
+		"code id empty": {
+			src: MigrateAllContractsProposalFixture(func(p *MigrateAllContractsProposal) {
+				p.CodeID = 0
+			}),
+			expErr: true,
+		},
+		"new code id empty": {
+			src: MigrateAllContractsProposalFixture(func(p *MigrateAllContractsProposal) {
+				p.NewCodeID = 0
+			}),
+			expErr: true,
+		},
+		"same code ids": {
+			src: MigrateAllContractsProposalFixture(func(p *MigrateAllContractsProposal) {
+				p.NewCodeID = p.CodeID
+			}),
+			expErr: true,
+		},
+		"run as invalid": {
+			src: MigrateAllContractsProposalFixture(func(p *MigrateAllContractsProposal) {
+				p.RunAs = "invalid address"
+			}),
+			expErr: true,
+		},
+		"migrate msg invalid json": {
+			src: MigrateAllContractsProposalFixture(func(p *MigrateAllContractsProposal) {
+				p.MigrateMsg = []byte("not json")
+			}),
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestContractVestingScheduleVestingEndTime(t *testing.T) {
+	specs := map[string]struct {
+		src ContractVestingSchedule
+		exp int64
+	}{
+		"continuous": {
+			src: ContractVestingSchedule{Type: ContractVestingTypeContinuous, StartTime: 1000, EndTime: 2000},
+			exp: 2000,
+		},
+		"delayed": {
+			src: ContractVestingSchedule{Type: ContractVestingTypeDelayed, EndTime: 2000},
+			exp: 2000,
+		},
+		"periodic": {
+			src: ContractVestingSchedule{Type: ContractVestingTypePeriodic, StartTime: 1000, Periods: []ContractVestingPeriod{{Length: 10}, {Length: 20}}},
+			exp: 1030,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			assert.Equal(t, spec.exp, spec.src.VestingEndTime())
+		})
+	}
+}
+
+func TestValidateRecoverContractFundsProposal(t *testing.T) {
+	specs := map[string]struct {
+		src    *RecoverContractFundsProposal
+		expErr bool
+	}{
+		"all good": {
+			src: RecoverContractFundsProposalFixture(),
+		},
+		"base data missing": {
+			src: RecoverContractFundsProposalFixture(func(p *RecoverContractFundsProposal) {
+				p.Title = ""
+			}),
+			expErr: true,
+		},
+		"contract invalid": {
+			src: RecoverContractFundsProposalFixture(func(p *RecoverContractFundsProposal) {
+				p.Contract = "invalid address"
+			}),
+			expErr: true,
+		},
+		"recipient missing": {
+			src: RecoverContractFundsProposalFixture(func(p *RecoverContractFundsProposal) {
+				p.Recipient = ""
+			}),
+			expErr: true,
+		},
+		"recipient is contract": {
+			src: RecoverContractFundsProposalFixture(func(p *RecoverContractFundsProposal) {
+				p.Recipient = p.Contract
+			}),
+			expErr: true,
+		},
+		"amount missing": {
+			src: RecoverContractFundsProposalFixture(func(p *RecoverContractFundsProposal) {
+				p.Amount = nil
+			}),
+			expErr: true,
+		},
+		"amount invalid": {
+			src: RecoverContractFundsProposalFixture(func(p *RecoverContractFundsProposal) {
+				p.Amount = sdk.Coins{{Denom: "stake", Amount: sdk.NewInt(0)}}
+			}),
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestProposalStrings(t *testing.T) {
 	specs := map[string]struct {
 		src govtypes.Content
@@ -476,6 +775,7 @@ func TestProposalStrings(t *testing.T) {
   Label:       testing
   InitMsg:     "{\"verifier\":\"cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpjnp7du\",\"beneficiary\":\"cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpjnp7du\"}"
   Funds:       1foo,2bar
+  Funds from community pool: false
 `,
 		},
 		"instantiate contract without funds": {
@@ -489,6 +789,7 @@ func TestProposalStrings(t *testing.T) {
   Label:       testing
   InitMsg:     "{\"verifier\":\"cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpjnp7du\",\"beneficiary\":\"cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpjnp7du\"}"
   Funds:       
+  Funds from community pool: false
 `,
 		},
 		"instantiate contract without admin": {
@@ -502,6 +803,7 @@ func TestProposalStrings(t *testing.T) {
   Label:       testing
   InitMsg:     "{\"verifier\":\"cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpjnp7du\",\"beneficiary\":\"cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpjnp7du\"}"
   Funds:       
+  Funds from community pool: false
 `,
 		},
 		"migrate contract": {
@@ -598,6 +900,7 @@ funds:
   amount: "1"
 - denom: bar
   amount: "2"
+funds_from_community_pool: false
 `,
 		},
 		"instantiate contract without funds": {
@@ -610,6 +913,7 @@ code_id: 1
 label: testing
 init_msg: '{"verifier":"cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpjnp7du","beneficiary":"cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpjnp7du"}'
 funds: []
+funds_from_community_pool: false
 `,
 		},
 		"instantiate contract without admin": {
@@ -622,6 +926,7 @@ code_id: 1
 label: testing
 init_msg: '{"verifier":"cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpjnp7du","beneficiary":"cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpjnp7du"}'
 funds: []
+funds_from_community_pool: false
 `,
 		},
 		"migrate contract": {
@@ -0,0 +1,44 @@
+package types
+
+import (
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	"github.com/gogo/protobuf/proto"
+)
+
+var _ codectypes.UnpackInterfacesMessage = &QuerySimulateProposalRequest{}
+
+// NewQuerySimulateProposalRequest returns a request to simulate the execution of the proposal content
+func NewQuerySimulateProposalRequest(content govtypes.Content) (*QuerySimulateProposalRequest, error) {
+	msg, ok := content.(proto.Message)
+	if !ok {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidType, "%T does not implement proto.Message", content)
+	}
+	any, err := codectypes.NewAnyWithValue(msg)
+	if err != nil {
+		return nil, err
+	}
+	return &QuerySimulateProposalRequest{Proposal: any}, nil
+}
+
+// GetProposal returns the unpacked proposal content or nil
+func (m QuerySimulateProposalRequest) GetProposal() govtypes.Content {
+	if m.Proposal == nil {
+		return nil
+	}
+	content, ok := m.Proposal.GetCachedValue().(govtypes.Content)
+	if !ok {
+		return nil
+	}
+	return content
+}
+
+// UnpackInterfaces implements codectypes.UnpackInterfaces
+func (m *QuerySimulateProposalRequest) UnpackInterfaces(unpacker codectypes.AnyUnpacker) error {
+	if m.Proposal == nil {
+		return nil
+	}
+	var content govtypes.Content
+	return unpacker.UnpackAny(m.Proposal, &content)
+}
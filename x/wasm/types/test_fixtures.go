@@ -294,3 +294,56 @@ func ClearAdminProposalFixture(mutators ...func(p *ClearAdminProposal)) *ClearAd
 	}
 	return p
 }
+
+func RecoverContractFundsProposalFixture(mutators ...func(p *RecoverContractFundsProposal)) *RecoverContractFundsProposal {
+	const (
+		contractAddr = "cosmos18vd8fpwxzck93qlwghaj6arh4p7c5n89uzcee5"
+		anyAddress   = "cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpjnp7du"
+	)
+	p := &RecoverContractFundsProposal{
+		Title:       "Foo",
+		Description: "Bar",
+		Contract:    contractAddr,
+		Recipient:   anyAddress,
+		Amount:      sdk.Coins{{Denom: "stake", Amount: sdk.NewInt(1)}},
+	}
+	for _, m := range mutators {
+		m(p)
+	}
+	return p
+}
+
+func SetContractVestingProposalFixture(mutators ...func(p *SetContractVestingProposal)) *SetContractVestingProposal {
+	const contractAddr = "cosmos18vd8fpwxzck93qlwghaj6arh4p7c5n89uzcee5"
+	p := &SetContractVestingProposal{
+		Title:       "Foo",
+		Description: "Bar",
+		Contract:    contractAddr,
+		Schedule: ContractVestingSchedule{
+			Type:      ContractVestingTypeContinuous,
+			Amount:    sdk.Coins{{Denom: "stake", Amount: sdk.NewInt(100)}},
+			StartTime: 1000,
+			EndTime:   2000,
+		},
+	}
+	for _, m := range mutators {
+		m(p)
+	}
+	return p
+}
+
+func MigrateAllContractsProposalFixture(mutators ...func(p *MigrateAllContractsProposal)) *MigrateAllContractsProposal {
+	const anyAddress = "cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpjnp7du"
+	p := &MigrateAllContractsProposal{
+		Title:       "Foo",
+		Description: "Bar",
+		RunAs:       anyAddress,
+		CodeID:      1,
+		NewCodeID:   2,
+		MigrateMsg:  []byte(`{"verifier":"cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpjnp7du"}`),
+	}
+	for _, m := range mutators {
+		m(p)
+	}
+	return p
+}
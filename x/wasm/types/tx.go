@@ -6,6 +6,7 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	host "github.com/cosmos/cosmos-sdk/x/ibc/core/24-host"
 )
 
 func (msg MsgStoreCode) Route() string {
@@ -75,17 +76,20 @@ func (msg MsgInstantiateContract) ValidateBasic() error {
 
 	}
 
-	if !msg.Funds.IsValid() {
+	if validateCoins(msg.Funds) != nil {
 		return sdkerrors.ErrInvalidCoins
 	}
 
 	if len(msg.Admin) != 0 {
+		if msg.AdminIsSender {
+			return sdkerrors.Wrap(ErrInvalid, "admin must not be set when admin is sender")
+		}
 		if _, err := sdk.AccAddressFromBech32(msg.Admin); err != nil {
 			return sdkerrors.Wrap(err, "admin")
 		}
 	}
-	if !json.Valid(msg.InitMsg) {
-		return sdkerrors.Wrap(ErrInvalid, "init msg json")
+	if err := validateMsgPayload(msg.InitMsg, msg.MsgEncoding); err != nil {
+		return sdkerrors.Wrap(err, "init msg")
 	}
 	return nil
 }
@@ -120,11 +124,11 @@ func (msg MsgExecuteContract) ValidateBasic() error {
 		return sdkerrors.Wrap(err, "contract")
 	}
 
-	if !msg.Funds.IsValid() {
+	if validateCoins(msg.Funds) != nil {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "sentFunds")
 	}
-	if !json.Valid(msg.Msg) {
-		return sdkerrors.Wrap(ErrInvalid, "msg json")
+	if err := validateMsgPayload(msg.Msg, msg.MsgEncoding); err != nil {
+		return sdkerrors.Wrap(err, "msg")
 	}
 	return nil
 }
@@ -252,6 +256,81 @@ func (msg MsgClearAdmin) GetSigners() []sdk.AccAddress {
 
 }
 
+func (msg MsgUpdateDeniedDenoms) Route() string {
+	return RouterKey
+}
+
+func (msg MsgUpdateDeniedDenoms) Type() string {
+	return "update-denied-denoms"
+}
+
+func (msg MsgUpdateDeniedDenoms) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return sdkerrors.Wrap(err, "sender")
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Contract); err != nil {
+		return sdkerrors.Wrap(err, "contract")
+	}
+	return ValidateDeniedDenoms(msg.Denoms)
+}
+
+func (msg MsgUpdateDeniedDenoms) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+func (msg MsgUpdateDeniedDenoms) GetSigners() []sdk.AccAddress {
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil { // should never happen as valid basic rejects invalid addresses
+		panic(err.Error())
+	}
+	return []sdk.AccAddress{senderAddr}
+}
+
+func (msg MsgUpdateReentrancyGuard) Route() string {
+	return RouterKey
+}
+
+func (msg MsgUpdateReentrancyGuard) Type() string {
+	return "update-reentrancy-guard"
+}
+
+func (msg MsgUpdateReentrancyGuard) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return sdkerrors.Wrap(err, "sender")
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Contract); err != nil {
+		return sdkerrors.Wrap(err, "contract")
+	}
+	return nil
+}
+
+func (msg MsgUpdateReentrancyGuard) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+func (msg MsgUpdateReentrancyGuard) GetSigners() []sdk.AccAddress {
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil { // should never happen as valid basic rejects invalid addresses
+		panic(err.Error())
+	}
+	return []sdk.AccAddress{senderAddr}
+}
+
+// ValidateDeniedDenoms ensures the denied denoms of a contract are valid and unique
+func ValidateDeniedDenoms(denoms []string) error {
+	unique := make(map[string]struct{}, len(denoms))
+	for _, d := range denoms {
+		if err := sdk.ValidateDenom(d); err != nil {
+			return sdkerrors.Wrapf(err, "denom %q", d)
+		}
+		if _, exists := unique[d]; exists {
+			return sdkerrors.Wrapf(ErrDuplicate, "denom %q", d)
+		}
+		unique[d] = struct{}{}
+	}
+	return nil
+}
+
 func (msg MsgIBCSend) Route() string {
 	return RouterKey
 }
@@ -291,3 +370,143 @@ func (msg MsgIBCCloseChannel) GetSignBytes() []byte {
 func (msg MsgIBCCloseChannel) GetSigners() []sdk.AccAddress {
 	return nil
 }
+
+func (msg MsgRegisterInterchainQuery) Route() string {
+	return RouterKey
+}
+
+func (msg MsgRegisterInterchainQuery) Type() string {
+	return "register-interchain-query"
+}
+
+func (msg MsgRegisterInterchainQuery) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return sdkerrors.Wrap(err, "sender")
+	}
+	if err := host.ConnectionIdentifierValidator(msg.ConnectionID); err != nil {
+		return sdkerrors.Wrap(err, "connection id")
+	}
+	if err := ValidateInterchainQueryKeys(msg.Keys); err != nil {
+		return sdkerrors.Wrap(err, "keys")
+	}
+	if msg.UpdatePeriod == 0 {
+		return sdkerrors.Wrap(ErrEmpty, "update period")
+	}
+	return nil
+}
+
+func (msg MsgRegisterInterchainQuery) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+func (msg MsgRegisterInterchainQuery) GetSigners() []sdk.AccAddress {
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil { // should never happen as valid basic rejects invalid addresses
+		panic(err.Error())
+	}
+	return []sdk.AccAddress{senderAddr}
+}
+
+func (msg MsgRemoveInterchainQuery) Route() string {
+	return RouterKey
+}
+
+func (msg MsgRemoveInterchainQuery) Type() string {
+	return "remove-interchain-query"
+}
+
+func (msg MsgRemoveInterchainQuery) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return sdkerrors.Wrap(err, "sender")
+	}
+	if msg.QueryID == 0 {
+		return sdkerrors.Wrap(ErrEmpty, "query id")
+	}
+	return nil
+}
+
+func (msg MsgRemoveInterchainQuery) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+func (msg MsgRemoveInterchainQuery) GetSigners() []sdk.AccAddress {
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil { // should never happen as valid basic rejects invalid addresses
+		panic(err.Error())
+	}
+	return []sdk.AccAddress{senderAddr}
+}
+
+func (msg MsgSubmitInterchainQueryResult) Route() string {
+	return RouterKey
+}
+
+func (msg MsgSubmitInterchainQueryResult) Type() string {
+	return "submit-interchain-query-result"
+}
+
+func (msg MsgSubmitInterchainQueryResult) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return sdkerrors.Wrap(err, "sender")
+	}
+	if msg.QueryID == 0 {
+		return sdkerrors.Wrap(ErrEmpty, "query id")
+	}
+	if msg.RevisionHeight == 0 {
+		return sdkerrors.Wrap(ErrEmpty, "revision height")
+	}
+	if len(msg.Results) == 0 {
+		return sdkerrors.Wrap(ErrEmpty, "results")
+	}
+	if len(msg.Results) > MaxInterchainQueryKeys {
+		return sdkerrors.Wrapf(ErrLimit, "more than %d results", MaxInterchainQueryKeys)
+	}
+	for i, r := range msg.Results {
+		if len(r.Proof) == 0 {
+			return sdkerrors.Wrapf(ErrEmpty, "proof of result %d", i)
+		}
+	}
+	return nil
+}
+
+func (msg MsgSubmitInterchainQueryResult) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+func (msg MsgSubmitInterchainQueryResult) GetSigners() []sdk.AccAddress {
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil { // should never happen as valid basic rejects invalid addresses
+		panic(err.Error())
+	}
+	return []sdk.AccAddress{senderAddr}
+}
+
+func (msg MsgReopenChannel) Route() string {
+	return RouterKey
+}
+
+func (msg MsgReopenChannel) Type() string {
+	return "reopen-channel"
+}
+
+func (msg MsgReopenChannel) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return sdkerrors.Wrap(err, "sender")
+	}
+	if err := host.ChannelIdentifierValidator(msg.ChannelID); err != nil {
+		return sdkerrors.Wrap(err, "channel id")
+	}
+	return nil
+}
+
+func (msg MsgReopenChannel) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+func (msg MsgReopenChannel) GetSigners() []sdk.AccAddress {
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil { // should never happen as valid basic rejects invalid addresses
+		panic(err.Error())
+	}
+	return []sdk.AccAddress{senderAddr}
+}
@@ -0,0 +1,59 @@
+//go:build go1.18
+// +build go1.18
+
+package types
+
+import (
+	"bytes"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// FuzzMsgValidateBasic decodes attacker controlled bytes from the wire into every message type. ValidateBasic must
+// not panic and the signers of a valid message must be available.
+func FuzzMsgValidateBasic(f *testing.F) {
+	anyAddr := sdk.AccAddress(bytes.Repeat([]byte{1}, sdk.AddrLen)).String()
+	seeds := []interface{ Marshal() ([]byte, error) }{
+		MsgStoreCodeFixture(),
+		MsgInstantiateContractFixture(),
+		MsgExecuteContractFixture(),
+		&MsgMigrateContract{Sender: anyAddr, Contract: anyAddr, CodeID: firstCodeID, MigrateMsg: []byte(`{}`)},
+		&MsgUpdateAdmin{Sender: anyAddr, NewAdmin: anyAddr, Contract: anyAddr},
+		&MsgClearAdmin{Sender: anyAddr, Contract: anyAddr},
+		&MsgUpdateDeniedDenoms{Sender: anyAddr, Contract: anyAddr, Denoms: []string{"denom"}},
+		&MsgUpdateReentrancyGuard{Sender: anyAddr, Contract: anyAddr, Enabled: true},
+	}
+	for _, s := range seeds {
+		bz, err := s.Marshal()
+		require.NoError(f, err)
+		f.Add(bz)
+	}
+	type wireMsg interface {
+		sdk.Msg
+		Unmarshal([]byte) error
+	}
+	f.Fuzz(func(t *testing.T, bz []byte) {
+		for _, msg := range []wireMsg{
+			&MsgStoreCode{},
+			&MsgInstantiateContract{},
+			&MsgExecuteContract{},
+			&MsgMigrateContract{},
+			&MsgUpdateAdmin{},
+			&MsgClearAdmin{},
+			&MsgUpdateDeniedDenoms{},
+			&MsgUpdateReentrancyGuard{},
+		} {
+			if err := msg.Unmarshal(bz); err != nil {
+				continue
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				continue
+			}
+			assert.NotEmpty(t, msg.GetSigners())
+			assert.NotEmpty(t, msg.GetSignBytes())
+		}
+	})
+}
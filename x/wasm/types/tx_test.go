@@ -156,6 +156,27 @@ func TestInstantiateContractValidation(t *testing.T) {
 			},
 			valid: true,
 		},
+		"admin is sender": {
+			msg: MsgInstantiateContract{
+				Sender:        goodAddress,
+				CodeID:        firstCodeID,
+				Label:         "foo",
+				InitMsg:       []byte("{}"),
+				AdminIsSender: true,
+			},
+			valid: true,
+		},
+		"admin is sender with admin": {
+			msg: MsgInstantiateContract{
+				Sender:        goodAddress,
+				Admin:         goodAddress,
+				CodeID:        firstCodeID,
+				Label:         "foo",
+				InitMsg:       []byte("{}"),
+				AdminIsSender: true,
+			},
+			valid: false,
+		},
 		"missing code": {
 			msg: MsgInstantiateContract{
 				Sender:  goodAddress,
@@ -225,6 +246,35 @@ func TestInstantiateContractValidation(t *testing.T) {
 			},
 			valid: false,
 		},
+		"binary init msg": {
+			msg: MsgInstantiateContract{
+				Sender:      goodAddress,
+				CodeID:      firstCodeID,
+				Label:       "foo",
+				InitMsg:     []byte{0x81, 0xa3, 0x66, 0x6f, 0x6f},
+				MsgEncoding: MessageEncodingBinary,
+			},
+			valid: true,
+		},
+		"empty binary init msg": {
+			msg: MsgInstantiateContract{
+				Sender:      goodAddress,
+				CodeID:      firstCodeID,
+				Label:       "foo",
+				MsgEncoding: MessageEncodingBinary,
+			},
+			valid: false,
+		},
+		"unknown init msg encoding": {
+			msg: MsgInstantiateContract{
+				Sender:      goodAddress,
+				CodeID:      firstCodeID,
+				Label:       "foo",
+				InitMsg:     []byte("{}"),
+				MsgEncoding: MessageEncoding(100),
+			},
+			valid: false,
+		},
 	}
 
 	for name, tc := range cases {
@@ -334,6 +384,32 @@ func TestExecuteContractValidation(t *testing.T) {
 			},
 			valid: false,
 		},
+		"binary msg": {
+			msg: MsgExecuteContract{
+				Sender:      goodAddress,
+				Contract:    goodAddress,
+				Msg:         []byte{0x81, 0xa3, 0x66, 0x6f, 0x6f},
+				MsgEncoding: MessageEncodingBinary,
+			},
+			valid: true,
+		},
+		"empty binary msg": {
+			msg: MsgExecuteContract{
+				Sender:      goodAddress,
+				Contract:    goodAddress,
+				MsgEncoding: MessageEncodingBinary,
+			},
+			valid: false,
+		},
+		"unknown msg encoding": {
+			msg: MsgExecuteContract{
+				Sender:      goodAddress,
+				Contract:    goodAddress,
+				Msg:         []byte("{}"),
+				MsgEncoding: MessageEncoding(100),
+			},
+			valid: false,
+		},
 	}
 
 	for name, tc := range cases {
@@ -471,6 +547,126 @@ func TestMsgClearAdministrator(t *testing.T) {
 	}
 }
 
+func TestMsgUpdateDeniedDenoms(t *testing.T) {
+	bad, err := sdk.AccAddressFromHex("012345")
+	require.NoError(t, err)
+	badAddress := bad.String()
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	anotherGoodAddress := sdk.AccAddress(bytes.Repeat([]byte{0x2}, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgUpdateDeniedDenoms
+		expErr bool
+	}{
+		"all good": {
+			src: MsgUpdateDeniedDenoms{
+				Sender:   goodAddress,
+				Contract: anotherGoodAddress,
+				Denoms:   []string{"ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2", "uatom"},
+			},
+		},
+		"empty denoms": {
+			src: MsgUpdateDeniedDenoms{
+				Sender:   goodAddress,
+				Contract: anotherGoodAddress,
+			},
+		},
+		"bad sender": {
+			src: MsgUpdateDeniedDenoms{
+				Sender:   badAddress,
+				Contract: anotherGoodAddress,
+			},
+			expErr: true,
+		},
+		"bad contract addr": {
+			src: MsgUpdateDeniedDenoms{
+				Sender:   goodAddress,
+				Contract: badAddress,
+			},
+			expErr: true,
+		},
+		"invalid denom": {
+			src: MsgUpdateDeniedDenoms{
+				Sender:   goodAddress,
+				Contract: anotherGoodAddress,
+				Denoms:   []string{"1nvalid"},
+			},
+			expErr: true,
+		},
+		"duplicate denoms": {
+			src: MsgUpdateDeniedDenoms{
+				Sender:   goodAddress,
+				Contract: anotherGoodAddress,
+				Denoms:   []string{"uatom", "uatom"},
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgUpdateReentrancyGuard(t *testing.T) {
+	bad, err := sdk.AccAddressFromHex("012345")
+	require.NoError(t, err)
+	badAddress := bad.String()
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	anotherGoodAddress := sdk.AccAddress(bytes.Repeat([]byte{0x2}, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgUpdateReentrancyGuard
+		expErr bool
+	}{
+		"enable": {
+			src: MsgUpdateReentrancyGuard{
+				Sender:   goodAddress,
+				Contract: anotherGoodAddress,
+				Enabled:  true,
+			},
+		},
+		"disable": {
+			src: MsgUpdateReentrancyGuard{
+				Sender:   goodAddress,
+				Contract: anotherGoodAddress,
+			},
+		},
+		"bad sender": {
+			src: MsgUpdateReentrancyGuard{
+				Sender:   badAddress,
+				Contract: anotherGoodAddress,
+			},
+			expErr: true,
+		},
+		"bad contract addr": {
+			src: MsgUpdateReentrancyGuard{
+				Sender:   goodAddress,
+				Contract: badAddress,
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
 func TestMsgMigrateContract(t *testing.T) {
 	bad, err := sdk.AccAddressFromHex("012345")
 	require.NoError(t, err)
@@ -557,3 +753,214 @@ func TestMsgMigrateContract(t *testing.T) {
 		})
 	}
 }
+
+func TestMsgRegisterInterchainQuery(t *testing.T) {
+	bad, err := sdk.AccAddressFromHex("012345")
+	require.NoError(t, err)
+	badAddress := bad.String()
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	myKeys := []InterchainQueryKey{{Path: "bank", Key: []byte("myKey")}}
+
+	specs := map[string]struct {
+		src    MsgRegisterInterchainQuery
+		expErr bool
+	}{
+		"all good": {
+			src: MsgRegisterInterchainQuery{
+				Sender:       goodAddress,
+				ConnectionID: "connection-0",
+				Keys:         myKeys,
+				UpdatePeriod: 10,
+			},
+		},
+		"bad sender": {
+			src: MsgRegisterInterchainQuery{
+				Sender:       badAddress,
+				ConnectionID: "connection-0",
+				Keys:         myKeys,
+				UpdatePeriod: 10,
+			},
+			expErr: true,
+		},
+		"invalid connection id": {
+			src: MsgRegisterInterchainQuery{
+				Sender:       goodAddress,
+				ConnectionID: "/invalid",
+				Keys:         myKeys,
+				UpdatePeriod: 10,
+			},
+			expErr: true,
+		},
+		"empty keys": {
+			src: MsgRegisterInterchainQuery{
+				Sender:       goodAddress,
+				ConnectionID: "connection-0",
+				UpdatePeriod: 10,
+			},
+			expErr: true,
+		},
+		"empty key path": {
+			src: MsgRegisterInterchainQuery{
+				Sender:       goodAddress,
+				ConnectionID: "connection-0",
+				Keys:         []InterchainQueryKey{{Key: []byte("myKey")}},
+				UpdatePeriod: 10,
+			},
+			expErr: true,
+		},
+		"empty key": {
+			src: MsgRegisterInterchainQuery{
+				Sender:       goodAddress,
+				ConnectionID: "connection-0",
+				Keys:         []InterchainQueryKey{{Path: "bank"}},
+				UpdatePeriod: 10,
+			},
+			expErr: true,
+		},
+		"key exceeds limit": {
+			src: MsgRegisterInterchainQuery{
+				Sender:       goodAddress,
+				ConnectionID: "connection-0",
+				Keys:         []InterchainQueryKey{{Path: "bank", Key: bytes.Repeat([]byte{0x1}, MaxInterchainQueryKeySize+1)}},
+				UpdatePeriod: 10,
+			},
+			expErr: true,
+		},
+		"duplicate keys": {
+			src: MsgRegisterInterchainQuery{
+				Sender:       goodAddress,
+				ConnectionID: "connection-0",
+				Keys:         append(myKeys, myKeys...),
+				UpdatePeriod: 10,
+			},
+			expErr: true,
+		},
+		"zero update period": {
+			src: MsgRegisterInterchainQuery{
+				Sender:       goodAddress,
+				ConnectionID: "connection-0",
+				Keys:         myKeys,
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgSubmitInterchainQueryResult(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	myResults := []InterchainQueryResult{{Value: []byte("myValue"), Proof: []byte("myProof")}, {Proof: []byte("myProof")}}
+
+	specs := map[string]struct {
+		src    MsgSubmitInterchainQueryResult
+		expErr bool
+	}{
+		"all good": {
+			src: MsgSubmitInterchainQueryResult{
+				Sender:         goodAddress,
+				QueryID:        1,
+				RevisionHeight: 1,
+				Results:        myResults,
+			},
+		},
+		"bad sender": {
+			src: MsgSubmitInterchainQueryResult{
+				Sender:         "invalid",
+				QueryID:        1,
+				RevisionHeight: 1,
+				Results:        myResults,
+			},
+			expErr: true,
+		},
+		"empty query id": {
+			src: MsgSubmitInterchainQueryResult{
+				Sender:         goodAddress,
+				RevisionHeight: 1,
+				Results:        myResults,
+			},
+			expErr: true,
+		},
+		"empty revision height": {
+			src: MsgSubmitInterchainQueryResult{
+				Sender:  goodAddress,
+				QueryID: 1,
+				Results: myResults,
+			},
+			expErr: true,
+		},
+		"empty results": {
+			src: MsgSubmitInterchainQueryResult{
+				Sender:         goodAddress,
+				QueryID:        1,
+				RevisionHeight: 1,
+			},
+			expErr: true,
+		},
+		"empty proof": {
+			src: MsgSubmitInterchainQueryResult{
+				Sender:         goodAddress,
+				QueryID:        1,
+				RevisionHeight: 1,
+				Results:        []InterchainQueryResult{{Value: []byte("myValue")}},
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgReopenChannel(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgReopenChannel
+		expErr bool
+	}{
+		"all good": {
+			src: MsgReopenChannel{Sender: goodAddress, ChannelID: "channel-0"},
+		},
+		"bad sender": {
+			src:    MsgReopenChannel{Sender: "invalid", ChannelID: "channel-0"},
+			expErr: true,
+		},
+		"empty channel id": {
+			src:    MsgReopenChannel{Sender: goodAddress},
+			expErr: true,
+		},
+		"invalid channel id": {
+			src:    MsgReopenChannel{Sender: goodAddress, ChannelID: "/invalid"},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"encoding/json"
 	"fmt"
 	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
@@ -20,6 +21,23 @@ func (m Model) ValidateBasic() error {
 	if len(m.Key) == 0 {
 		return sdkerrors.Wrap(ErrEmpty, "key")
 	}
+	if len(m.Key) > MaxContractStateKeySize {
+		return sdkerrors.Wrapf(ErrLimit, "key cannot be longer than %d bytes", MaxContractStateKeySize)
+	}
+	if len(m.Value) > MaxContractStateValueSize {
+		return sdkerrors.Wrapf(ErrLimit, "value cannot be longer than %d bytes", MaxContractStateValueSize)
+	}
+	return nil
+}
+
+// ValidateContractState checks the raw state models that are imported into the contract store. The keys are stored
+// under the contract store prefix and can not collide with the keys of the keeper.
+func ValidateContractState(models []Model) error {
+	for i, m := range models {
+		if err := m.ValidateBasic(); err != nil {
+			return sdkerrors.Wrapf(err, "model %d", i)
+		}
+	}
 	return nil
 }
 
@@ -39,6 +57,11 @@ func (c CodeInfo) ValidateBasic() error {
 	if err := c.InstantiateConfig.ValidateBasic(); err != nil {
 		return sdkerrors.Wrap(err, "instantiate config")
 	}
+	for _, f := range c.RequiredFeatures {
+		if len(f) == 0 {
+			return sdkerrors.Wrap(ErrEmpty, "required feature")
+		}
+	}
 	return nil
 }
 
@@ -153,25 +176,42 @@ func (c *ContractInfo) ReadExtension(e ContractInfoExtension) error {
 }
 
 func (c ContractInfo) InitialHistory(initMsg []byte) ContractCodeHistoryEntry {
+	msg, encoding := historyMsg(initMsg)
 	return ContractCodeHistoryEntry{
-		Operation: ContractCodeHistoryOperationTypeInit,
-		CodeID:    c.CodeID,
-		Updated:   c.Created,
-		Msg:       initMsg,
+		Operation:   ContractCodeHistoryOperationTypeInit,
+		CodeID:      c.CodeID,
+		Updated:     c.Created,
+		Msg:         msg,
+		MsgEncoding: encoding,
 	}
 }
 
 func (c *ContractInfo) AddMigration(ctx sdk.Context, codeID uint64, msg []byte) ContractCodeHistoryEntry {
+	entryMsg, encoding := historyMsg(msg)
 	h := ContractCodeHistoryEntry{
-		Operation: ContractCodeHistoryOperationTypeMigrate,
-		CodeID:    codeID,
-		Updated:   NewAbsoluteTxPosition(ctx),
-		Msg:       msg,
+		Operation:   ContractCodeHistoryOperationTypeMigrate,
+		CodeID:      codeID,
+		Updated:     NewAbsoluteTxPosition(ctx),
+		Msg:         entryMsg,
+		MsgEncoding: encoding,
 	}
 	c.CodeID = codeID
 	return h
 }
 
+// historyMsg returns the message as it is stored in the contract code history. The history entries are json
+// encoded so that binary payloads are stored as base64 encoded json string instead.
+func historyMsg(msg []byte) (json.RawMessage, MessageEncoding) {
+	if len(msg) == 0 || json.Valid(msg) {
+		return msg, MessageEncodingJSON
+	}
+	bz, err := json.Marshal(msg)
+	if err != nil { // should never happen for a byte slice
+		panic(err)
+	}
+	return bz, MessageEncodingBinary
+}
+
 // ResetFromGenesis resets contracts timestamp and history.
 func (c *ContractInfo) ResetFromGenesis(ctx sdk.Context) ContractCodeHistoryEntry {
 	c.Created = NewAbsoluteTxPosition(ctx)
@@ -321,6 +361,42 @@ type WasmConfig struct {
 	MemoryCacheSize uint32
 	// ContractDebugMode log what contract print
 	ContractDebugMode bool
+	// StoreAuditLogSize is the number of contract executions for which the store operations are kept in memory
+	// for debugging. Only executions in blocks are recorded, not in CheckTx or simulations. Set to 0 to disable.
+	StoreAuditLogSize uint32
+	// QueryConcurrency is the max number of smart queries that are executed in parallel by the gRPC query server.
+	// Set to 0 to disable the limit.
+	QueryConcurrency uint32
+	// QueryQueueSize is the max number of smart queries that wait for execution when QueryConcurrency is reached.
+	// Further queries are rejected.
+	QueryQueueSize uint32
+	// CacheStatusQuery enables the operator query for the pinned codes and the wasm VM cache metrics
+	CacheStatusQuery bool
+	// VMShards is the number of wasm VM instances that the contract calls are distributed over by code checksum.
+	// The memory cache size is split between the instances. Set to 0 or 1 for a single instance.
+	VMShards uint32
+	// LegacyQuerierProtoJSON makes the legacy querier return the gRPC query response types encoded as proto JSON
+	// instead of the ad-hoc JSON format
+	LegacyQuerierProtoJSON bool
+	// AdminGRPCAddress is the loopback address of the node local admin gRPC server. Empty disables the server.
+	AdminGRPCAddress string
+	// AdminGRPCToken is the token that the admin gRPC server requires as `authorization: Bearer <token>` metadata
+	AdminGRPCToken string
+	// ContractTxIndex requires the node to index the contract events that the txs of a contract are searched by.
+	// The node fails to start when the tx indexer or the indexed events do not match.
+	ContractTxIndex bool
+	// AcceptVMUpgrade allows the node to start with a wasmvm version that is different from the one that the wasm
+	// data directory was used with before. This must only be set for a planned upgrade.
+	AcceptVMUpgrade bool
+	// UpgradeName is the name of the software upgrade that the upgrade module wrote to the upgrade info file when it
+	// halted the chain. It is set by the app and not read from the config. A wasmvm version change is accepted once
+	// for a new upgrade name.
+	UpgradeName string
+	// SimulateExecuteGasLimit is the max gas of the unauthenticated execute simulation query. Set to 0 to disable
+	// the query.
+	SimulateExecuteGasLimit uint64
+	// SimulateExecuteRate is the max number of execute simulations per second. Set to 0 to disable the limit.
+	SimulateExecuteRate uint32
 }
 
 // DefaultWasmConfig returns the default settings for WasmConfig
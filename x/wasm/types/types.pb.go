@@ -7,7 +7,9 @@ import (
 	bytes "bytes"
 	encoding_json "encoding/json"
 	fmt "fmt"
-	types "github.com/cosmos/cosmos-sdk/codec/types"
+	types1 "github.com/cosmos/cosmos-sdk/codec/types"
+	github_com_cosmos_cosmos_sdk_types "github.com/cosmos/cosmos-sdk/types"
+	types "github.com/cosmos/cosmos-sdk/types"
 	_ "github.com/gogo/protobuf/gogoproto"
 	proto "github.com/gogo/protobuf/proto"
 	_ "github.com/regen-network/cosmos-proto"
@@ -96,6 +98,34 @@ func (ContractCodeHistoryOperationType) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_2548aa229a1f29bc, []int{1}
 }
 
+// MessageEncoding defines the encoding of a message payload to a contract
+type MessageEncoding int32
+
+const (
+	// MessageEncodingJSON default, the payload must be valid json
+	MessageEncodingJSON MessageEncoding = 0
+	// MessageEncodingBinary arbitrary bytes that are not interpreted by the chain
+	MessageEncodingBinary MessageEncoding = 1
+)
+
+var MessageEncoding_name = map[int32]string{
+	0: "MESSAGE_ENCODING_JSON",
+	1: "MESSAGE_ENCODING_BINARY",
+}
+
+var MessageEncoding_value = map[string]int32{
+	"MESSAGE_ENCODING_JSON":   0,
+	"MESSAGE_ENCODING_BINARY": 1,
+}
+
+func (x MessageEncoding) String() string {
+	return proto.EnumName(MessageEncoding_name, int32(x))
+}
+
+func (MessageEncoding) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_2548aa229a1f29bc, []int{2}
+}
+
 // AccessTypeParam
 type AccessTypeParam struct {
 	Value AccessType `protobuf:"varint,1,opt,name=value,proto3,enum=cosmwasm.wasm.v1beta1.AccessType" json:"value,omitempty" yaml:"value"`
@@ -178,6 +208,31 @@ type Params struct {
 	CodeUploadAccess             AccessConfig `protobuf:"bytes,1,opt,name=code_upload_access,json=codeUploadAccess,proto3" json:"code_upload_access" yaml:"code_upload_access"`
 	InstantiateDefaultPermission AccessType   `protobuf:"varint,2,opt,name=instantiate_default_permission,json=instantiateDefaultPermission,proto3,enum=cosmwasm.wasm.v1beta1.AccessType" json:"instantiate_default_permission,omitempty" yaml:"instantiate_default_permission"`
 	MaxWasmCodeSize              uint64       `protobuf:"varint,3,opt,name=max_wasm_code_size,json=maxWasmCodeSize,proto3" json:"max_wasm_code_size,omitempty" yaml:"max_wasm_code_size"`
+	// IBCPacketGasLimit is the max gas that can be spent by a contract when
+	// receiving an IBC packet or processing an acknowledgement. The limit is
+	// independent of the relayer's tx gas. Zero disables the limit.
+	IBCPacketGasLimit uint64 `protobuf:"varint,4,opt,name=ibc_packet_gas_limit,json=ibcPacketGasLimit,proto3" json:"ibc_packet_gas_limit,omitempty" yaml:"ibc_packet_gas_limit"`
+	// ExecuteAllowlistEnabled restricts the execution of contracts to the
+	// contracts in the ExecuteAllowlist. The contract admin can always execute
+	// the contract. Queries are not restricted.
+	ExecuteAllowlistEnabled bool `protobuf:"varint,5,opt,name=execute_allowlist_enabled,json=executeAllowlistEnabled,proto3" json:"execute_allowlist_enabled,omitempty" yaml:"execute_allowlist_enabled"`
+	// ExecuteAllowlist contains the addresses of the contracts that can be
+	// executed by any account when the allowlist is enabled
+	ExecuteAllowlist []string `protobuf:"bytes,6,rep,name=execute_allowlist,json=executeAllowlist,proto3" json:"execute_allowlist,omitempty" yaml:"execute_allowlist"`
+	// MaxContractGas is the max gas that can be spent by a single contract
+	// instantiation, execution or migration including the dispatched messages.
+	// The limit is independent of the tx gas. Zero disables the limit.
+	MaxContractGas uint64 `protobuf:"varint,7,opt,name=max_contract_gas,json=maxContractGas,proto3" json:"max_contract_gas,omitempty" yaml:"max_contract_gas"`
+	// MaxResultDataSize is the max number of bytes of the result data or IBC
+	// acknowledgement that a contract can return. Zero disables the limit.
+	MaxResultDataSize uint64 `protobuf:"varint,8,opt,name=max_result_data_size,json=maxResultDataSize,proto3" json:"max_result_data_size,omitempty" yaml:"max_result_data_size"`
+	// StoreCodeDeposit is the deposit that is escrowed from the sender of a
+	// MsgStoreCode in addition to the fees. Empty disables the deposit.
+	StoreCodeDeposit github_com_cosmos_cosmos_sdk_types.Coins `protobuf:"bytes,9,rep,name=store_code_deposit,json=storeCodeDeposit,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"store_code_deposit" yaml:"store_code_deposit"`
+	// StoreCodeDepositRefundBlocks is the number of blocks after which the
+	// store code deposit is refunded to the sender when a contract runs the code
+	// and burned otherwise. Zero burns the deposit.
+	StoreCodeDepositRefundBlocks uint64 `protobuf:"varint,10,opt,name=store_code_deposit_refund_blocks,json=storeCodeDepositRefundBlocks,proto3" json:"store_code_deposit_refund_blocks,omitempty" yaml:"store_code_deposit_refund_blocks"`
 }
 
 func (m *Params) Reset()      { *m = Params{} }
@@ -225,6 +280,9 @@ type CodeInfo struct {
 	Builder string `protobuf:"bytes,4,opt,name=builder,proto3" json:"builder,omitempty"`
 	// InstantiateConfig access control to apply on contract creation, optional
 	InstantiateConfig AccessConfig `protobuf:"bytes,5,opt,name=instantiate_config,json=instantiateConfig,proto3" json:"instantiate_config"`
+	// RequiredFeatures are the capabilities that the code requires from the
+	// chain. They are recorded on store and checked on every contract call
+	RequiredFeatures []string `protobuf:"bytes,6,rep,name=required_features,json=requiredFeatures,proto3" json:"required_features,omitempty"`
 }
 
 func (m *CodeInfo) Reset()         { *m = CodeInfo{} }
@@ -277,7 +335,10 @@ type ContractInfo struct {
 	IBCPortID string              `protobuf:"bytes,6,opt,name=ibc_port_id,json=ibcPortId,proto3" json:"ibc_port_id,omitempty"`
 	// Extension is an extension point to store custom metadata within the
 	// persistence model.
-	Extension *types.Any `protobuf:"bytes,7,opt,name=extension,proto3" json:"extension,omitempty"`
+	Extension *types1.Any `protobuf:"bytes,7,opt,name=extension,proto3" json:"extension,omitempty"`
+	// ReentrancyGuard rejects nested executions and migrations of the contract
+	// while it is on the current call stack
+	ReentrancyGuard bool `protobuf:"varint,8,opt,name=reentrancy_guard,json=reentrancyGuard,proto3" json:"reentrancy_guard,omitempty"`
 }
 
 func (m *ContractInfo) Reset()         { *m = ContractInfo{} }
@@ -319,8 +380,11 @@ type ContractCodeHistoryEntry struct {
 	// CodeID is the reference to the stored WASM code
 	CodeID uint64 `protobuf:"varint,2,opt,name=code_id,json=codeId,proto3" json:"code_id,omitempty"`
 	// Updated Tx position when the operation was executed.
-	Updated *AbsoluteTxPosition      `protobuf:"bytes,3,opt,name=updated,proto3" json:"updated,omitempty"`
-	Msg     encoding_json.RawMessage `protobuf:"bytes,4,opt,name=msg,proto3,casttype=encoding/json.RawMessage" json:"msg,omitempty"`
+	Updated *AbsoluteTxPosition `protobuf:"bytes,3,opt,name=updated,proto3" json:"updated,omitempty"`
+	// Msg is stored as base64 encoded json string for binary payloads
+	Msg encoding_json.RawMessage `protobuf:"bytes,4,opt,name=msg,proto3,casttype=encoding/json.RawMessage" json:"msg,omitempty"`
+	// MsgEncoding of the original message payload
+	MsgEncoding MessageEncoding `protobuf:"varint,5,opt,name=msg_encoding,json=msgEncoding,proto3,enum=cosmwasm.wasm.v1beta1.MessageEncoding" json:"msg_encoding,omitempty"`
 }
 
 func (m *ContractCodeHistoryEntry) Reset()         { *m = ContractCodeHistoryEntry{} }
@@ -440,9 +504,428 @@ func (m *Model) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_Model proto.InternalMessageInfo
 
+// PacketReply references the submessage that sent an IBC packet so that the
+// acknowledgement or timeout result can be returned to the contract via reply
+type PacketReply struct {
+	// ContractAddress is the contract that dispatched the submessage
+	ContractAddress string `protobuf:"bytes,1,opt,name=contract_address,json=contractAddress,proto3" json:"contract_address,omitempty"`
+	// ReplyID is the submessage id
+	ReplyID uint64 `protobuf:"varint,2,opt,name=reply_id,json=replyId,proto3" json:"reply_id,omitempty"`
+	// ReplyOn defines when the reply is sent: "always", "success" or "error"
+	ReplyOn string `protobuf:"bytes,3,opt,name=reply_on,json=replyOn,proto3" json:"reply_on,omitempty"`
+}
+
+func (m *PacketReply) Reset()         { *m = PacketReply{} }
+func (m *PacketReply) String() string { return proto.CompactTextString(m) }
+func (*PacketReply) ProtoMessage()    {}
+func (*PacketReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2548aa229a1f29bc, []int{8}
+}
+func (m *PacketReply) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PacketReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PacketReply.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PacketReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PacketReply.Merge(m, src)
+}
+func (m *PacketReply) XXX_Size() int {
+	return m.Size()
+}
+func (m *PacketReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_PacketReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PacketReply proto.InternalMessageInfo
+
+// CodeExecutionStats are the contract executions of a code in an epoch
+type CodeExecutionStats struct {
+	// Epoch is the block height divided by the epoch length
+	Epoch uint64 `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	// Executions is the number of calls of the execute entry point. Other entry
+	// points are not counted.
+	Executions uint64 `protobuf:"varint,2,opt,name=executions,proto3" json:"executions,omitempty"`
+	// GasUsed is the cumulative sdk gas consumed by the wasm VM
+	GasUsed uint64 `protobuf:"varint,3,opt,name=gas_used,json=gasUsed,proto3" json:"gas_used,omitempty"`
+}
+
+func (m *CodeExecutionStats) Reset()         { *m = CodeExecutionStats{} }
+func (m *CodeExecutionStats) String() string { return proto.CompactTextString(m) }
+func (*CodeExecutionStats) ProtoMessage()    {}
+func (*CodeExecutionStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2548aa229a1f29bc, []int{9}
+}
+func (m *CodeExecutionStats) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CodeExecutionStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CodeExecutionStats.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CodeExecutionStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CodeExecutionStats.Merge(m, src)
+}
+func (m *CodeExecutionStats) XXX_Size() int {
+	return m.Size()
+}
+func (m *CodeExecutionStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_CodeExecutionStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CodeExecutionStats proto.InternalMessageInfo
+
+// StoreCodeDeposit is a store code deposit in escrow until it is refunded
+type StoreCodeDeposit struct {
+	CodeID uint64 `protobuf:"varint,1,opt,name=code_id,json=codeId,proto3" json:"code_id,omitempty"`
+	// Depositor is the sender of the MsgStoreCode that receives the refund
+	Depositor string                                   `protobuf:"bytes,2,opt,name=depositor,proto3" json:"depositor,omitempty"`
+	Amount    github_com_cosmos_cosmos_sdk_types.Coins `protobuf:"bytes,3,rep,name=amount,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"amount"`
+	// RefundHeight is the block height at which the deposit is refunded
+	RefundHeight int64 `protobuf:"varint,4,opt,name=refund_height,json=refundHeight,proto3" json:"refund_height,omitempty"`
+}
+
+func (m *StoreCodeDeposit) Reset()         { *m = StoreCodeDeposit{} }
+func (m *StoreCodeDeposit) String() string { return proto.CompactTextString(m) }
+func (*StoreCodeDeposit) ProtoMessage()    {}
+func (*StoreCodeDeposit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2548aa229a1f29bc, []int{10}
+}
+func (m *StoreCodeDeposit) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StoreCodeDeposit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StoreCodeDeposit.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StoreCodeDeposit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StoreCodeDeposit.Merge(m, src)
+}
+func (m *StoreCodeDeposit) XXX_Size() int {
+	return m.Size()
+}
+func (m *StoreCodeDeposit) XXX_DiscardUnknown() {
+	xxx_messageInfo_StoreCodeDeposit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StoreCodeDeposit proto.InternalMessageInfo
+
+// InterchainQueryKey is a key in a module store of the counterparty chain
+type InterchainQueryKey struct {
+	// Path is the store name of the module, for example "bank"
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// Key is the key within the module store
+	Key []byte `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (m *InterchainQueryKey) Reset()         { *m = InterchainQueryKey{} }
+func (m *InterchainQueryKey) String() string { return proto.CompactTextString(m) }
+func (*InterchainQueryKey) ProtoMessage()    {}
+func (*InterchainQueryKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2548aa229a1f29bc, []int{11}
+}
+func (m *InterchainQueryKey) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *InterchainQueryKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_InterchainQueryKey.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *InterchainQueryKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InterchainQueryKey.Merge(m, src)
+}
+func (m *InterchainQueryKey) XXX_Size() int {
+	return m.Size()
+}
+func (m *InterchainQueryKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_InterchainQueryKey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InterchainQueryKey proto.InternalMessageInfo
+
+// InterchainQuery is a periodic query that a contract registered to read the
+// state of a counterparty chain. The results are submitted by relayers with
+// proofs that are verified against the consensus states of the IBC client of
+// the connection.
+type InterchainQuery struct {
+	ID uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Owner is the address of the contract that receives the results
+	Owner string `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	// ConnectionID is the IBC connection to the counterparty chain
+	ConnectionID string `protobuf:"bytes,3,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	// Keys are read on the counterparty chain
+	Keys []InterchainQueryKey `protobuf:"bytes,4,rep,name=keys,proto3" json:"keys"`
+	// UpdatePeriod is the min number of blocks between two results
+	UpdatePeriod uint64 `protobuf:"varint,5,opt,name=update_period,json=updatePeriod,proto3" json:"update_period,omitempty"`
+	// LastSubmittedHeight is the block height when the last result was
+	// submitted. Zero before the first result.
+	LastSubmittedHeight int64 `protobuf:"varint,6,opt,name=last_submitted_height,json=lastSubmittedHeight,proto3" json:"last_submitted_height,omitempty"`
+	// LastRemoteRevisionNumber is the revision number of the counterparty chain
+	// height of the last result
+	LastRemoteRevisionNumber uint64 `protobuf:"varint,7,opt,name=last_remote_revision_number,json=lastRemoteRevisionNumber,proto3" json:"last_remote_revision_number,omitempty"`
+	// LastRemoteRevisionHeight is the revision height of the counterparty chain
+	// height of the last result
+	LastRemoteRevisionHeight uint64 `protobuf:"varint,8,opt,name=last_remote_revision_height,json=lastRemoteRevisionHeight,proto3" json:"last_remote_revision_height,omitempty"`
+}
+
+func (m *InterchainQuery) Reset()         { *m = InterchainQuery{} }
+func (m *InterchainQuery) String() string { return proto.CompactTextString(m) }
+func (*InterchainQuery) ProtoMessage()    {}
+func (*InterchainQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2548aa229a1f29bc, []int{12}
+}
+func (m *InterchainQuery) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *InterchainQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_InterchainQuery.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *InterchainQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InterchainQuery.Merge(m, src)
+}
+func (m *InterchainQuery) XXX_Size() int {
+	return m.Size()
+}
+func (m *InterchainQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_InterchainQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InterchainQuery proto.InternalMessageInfo
+
+// InterchainQueryResult is the value with proof for a key of an interchain
+// query
+type InterchainQueryResult struct {
+	// Value stored under the key. Empty when the key does not exist.
+	Value []byte `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	// Proof is the protobuf encoded ICS-23 merkle proof of the value or of the
+	// absence of the key
+	Proof []byte `protobuf:"bytes,2,opt,name=proof,proto3" json:"proof,omitempty"`
+}
+
+func (m *InterchainQueryResult) Reset()         { *m = InterchainQueryResult{} }
+func (m *InterchainQueryResult) String() string { return proto.CompactTextString(m) }
+func (*InterchainQueryResult) ProtoMessage()    {}
+func (*InterchainQueryResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2548aa229a1f29bc, []int{13}
+}
+func (m *InterchainQueryResult) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *InterchainQueryResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_InterchainQueryResult.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *InterchainQueryResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InterchainQueryResult.Merge(m, src)
+}
+func (m *InterchainQueryResult) XXX_Size() int {
+	return m.Size()
+}
+func (m *InterchainQueryResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_InterchainQueryResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InterchainQueryResult proto.InternalMessageInfo
+
+// Job is a long running keeper operation that is continued step by step by
+// the end blocker within a gas budget per block
+type Job struct {
+	ID uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Type selects the handler of the job
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// Payload is the state of the job that is encoded by its handler
+	Payload []byte `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
+	// StepsPerBlock is the max number of steps of the job in a block. Zero for
+	// no limit other than the gas budget.
+	StepsPerBlock uint32 `protobuf:"varint,4,opt,name=steps_per_block,json=stepsPerBlock,proto3" json:"steps_per_block,omitempty"`
+	// CreatedHeight is the block height when the job was queued
+	CreatedHeight int64 `protobuf:"varint,5,opt,name=created_height,json=createdHeight,proto3" json:"created_height,omitempty"`
+}
+
+func (m *Job) Reset()         { *m = Job{} }
+func (m *Job) String() string { return proto.CompactTextString(m) }
+func (*Job) ProtoMessage()    {}
+func (*Job) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2548aa229a1f29bc, []int{14}
+}
+func (m *Job) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Job) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Job.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Job) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Job.Merge(m, src)
+}
+func (m *Job) XXX_Size() int {
+	return m.Size()
+}
+func (m *Job) XXX_DiscardUnknown() {
+	xxx_messageInfo_Job.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Job proto.InternalMessageInfo
+
+// BulkMigration is the job payload of the migration of all contracts of a
+// code
+type BulkMigration struct {
+	// CodeID of the contracts that are migrated
+	CodeID uint64 `protobuf:"varint,1,opt,name=code_id,json=codeId,proto3" json:"code_id,omitempty"`
+	// NewCodeID is the code that the contracts are migrated to
+	NewCodeID uint64 `protobuf:"varint,2,opt,name=new_code_id,json=newCodeId,proto3" json:"new_code_id,omitempty"`
+	// RunAs is the address that is passed to the contract's environment as
+	// sender
+	RunAs string `protobuf:"bytes,3,opt,name=run_as,json=runAs,proto3" json:"run_as,omitempty"`
+	// MigrateMsg json encoded message to be passed to the contracts on migration
+	MigrateMsg []byte `protobuf:"bytes,4,opt,name=migrate_msg,json=migrateMsg,proto3" json:"migrate_msg,omitempty"`
+	// NextKey is the position in the contracts by code index of the next
+	// contract. Contracts that failed to migrate are before this key.
+	NextKey []byte `protobuf:"bytes,5,opt,name=next_key,json=nextKey,proto3" json:"next_key,omitempty"`
+	// Migrated is the number of contracts that were migrated
+	Migrated uint64 `protobuf:"varint,6,opt,name=migrated,proto3" json:"migrated,omitempty"`
+	// Failed is the number of contracts that failed to migrate and keep the old
+	// code
+	Failed uint64 `protobuf:"varint,7,opt,name=failed,proto3" json:"failed,omitempty"`
+}
+
+func (m *BulkMigration) Reset()         { *m = BulkMigration{} }
+func (m *BulkMigration) String() string { return proto.CompactTextString(m) }
+func (*BulkMigration) ProtoMessage()    {}
+func (*BulkMigration) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2548aa229a1f29bc, []int{15}
+}
+func (m *BulkMigration) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BulkMigration) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BulkMigration.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *BulkMigration) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BulkMigration.Merge(m, src)
+}
+func (m *BulkMigration) XXX_Size() int {
+	return m.Size()
+}
+func (m *BulkMigration) XXX_DiscardUnknown() {
+	xxx_messageInfo_BulkMigration.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BulkMigration proto.InternalMessageInfo
+
+// CodeStatsPruning is the job payload of the deletion of the code execution
+// statistics of old epochs
+type CodeStatsPruning struct {
+	// BeforeEpoch is the first epoch that is kept
+	BeforeEpoch uint64 `protobuf:"varint,1,opt,name=before_epoch,json=beforeEpoch,proto3" json:"before_epoch,omitempty"`
+	// NextKey is the position in the statistics of all codes of the next step
+	NextKey []byte `protobuf:"bytes,2,opt,name=next_key,json=nextKey,proto3" json:"next_key,omitempty"`
+}
+
+func (m *CodeStatsPruning) Reset()         { *m = CodeStatsPruning{} }
+func (m *CodeStatsPruning) String() string { return proto.CompactTextString(m) }
+func (*CodeStatsPruning) ProtoMessage()    {}
+func (*CodeStatsPruning) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2548aa229a1f29bc, []int{16}
+}
+func (m *CodeStatsPruning) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CodeStatsPruning) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CodeStatsPruning.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CodeStatsPruning) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CodeStatsPruning.Merge(m, src)
+}
+func (m *CodeStatsPruning) XXX_Size() int {
+	return m.Size()
+}
+func (m *CodeStatsPruning) XXX_DiscardUnknown() {
+	xxx_messageInfo_CodeStatsPruning.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CodeStatsPruning proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterEnum("cosmwasm.wasm.v1beta1.AccessType", AccessType_name, AccessType_value)
 	proto.RegisterEnum("cosmwasm.wasm.v1beta1.ContractCodeHistoryOperationType", ContractCodeHistoryOperationType_name, ContractCodeHistoryOperationType_value)
+	proto.RegisterEnum("cosmwasm.wasm.v1beta1.MessageEncoding", MessageEncoding_name, MessageEncoding_value)
 	proto.RegisterType((*AccessTypeParam)(nil), "cosmwasm.wasm.v1beta1.AccessTypeParam")
 	proto.RegisterType((*AccessConfig)(nil), "cosmwasm.wasm.v1beta1.AccessConfig")
 	proto.RegisterType((*Params)(nil), "cosmwasm.wasm.v1beta1.Params")
@@ -451,87 +934,159 @@ func init() {
 	proto.RegisterType((*ContractCodeHistoryEntry)(nil), "cosmwasm.wasm.v1beta1.ContractCodeHistoryEntry")
 	proto.RegisterType((*AbsoluteTxPosition)(nil), "cosmwasm.wasm.v1beta1.AbsoluteTxPosition")
 	proto.RegisterType((*Model)(nil), "cosmwasm.wasm.v1beta1.Model")
+	proto.RegisterType((*PacketReply)(nil), "cosmwasm.wasm.v1beta1.PacketReply")
+	proto.RegisterType((*CodeExecutionStats)(nil), "cosmwasm.wasm.v1beta1.CodeExecutionStats")
+	proto.RegisterType((*StoreCodeDeposit)(nil), "cosmwasm.wasm.v1beta1.StoreCodeDeposit")
+	proto.RegisterType((*InterchainQueryKey)(nil), "cosmwasm.wasm.v1beta1.InterchainQueryKey")
+	proto.RegisterType((*InterchainQuery)(nil), "cosmwasm.wasm.v1beta1.InterchainQuery")
+	proto.RegisterType((*InterchainQueryResult)(nil), "cosmwasm.wasm.v1beta1.InterchainQueryResult")
+	proto.RegisterType((*Job)(nil), "cosmwasm.wasm.v1beta1.Job")
+	proto.RegisterType((*BulkMigration)(nil), "cosmwasm.wasm.v1beta1.BulkMigration")
+	proto.RegisterType((*CodeStatsPruning)(nil), "cosmwasm.wasm.v1beta1.CodeStatsPruning")
 }
 
 func init() { proto.RegisterFile("cosmwasm/wasm/v1beta1/types.proto", fileDescriptor_2548aa229a1f29bc) }
 
 var fileDescriptor_2548aa229a1f29bc = []byte{
-	// 1190 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0x5d, 0x6f, 0x1b, 0x45,
-	0x17, 0xf6, 0xda, 0xce, 0x87, 0xa7, 0x79, 0x5b, 0x77, 0xde, 0x84, 0x3a, 0xa6, 0xd8, 0xce, 0x96,
-	0x8f, 0xf4, 0xcb, 0xa6, 0x05, 0x51, 0xd4, 0x3b, 0x7b, 0xbd, 0x34, 0x5b, 0x11, 0xdb, 0x1a, 0x3b,
-	0xb4, 0x41, 0x42, 0xab, 0xd9, 0xdd, 0x89, 0x33, 0x74, 0xbd, 0x63, 0xed, 0x8c, 0x5b, 0xbb, 0xbf,
-	0x00, 0x85, 0x1b, 0xc4, 0x15, 0x17, 0x44, 0x42, 0x02, 0xa1, 0xfe, 0x00, 0x7e, 0x01, 0x57, 0x15,
-	0x12, 0x52, 0x2f, 0xb9, 0xb2, 0x20, 0x15, 0x12, 0xdc, 0xe6, 0xb2, 0x57, 0x68, 0x67, 0x6c, 0x79,
-	0x45, 0x3f, 0x62, 0x6e, 0xac, 0x39, 0x67, 0xce, 0xf3, 0x9c, 0x79, 0xce, 0x99, 0x33, 0x5e, 0xb0,
-	0xe1, 0x32, 0xde, 0x7b, 0x80, 0x79, 0xaf, 0x22, 0x7f, 0xee, 0x5f, 0x73, 0x88, 0xc0, 0xd7, 0x2a,
-	0x62, 0xd4, 0x27, 0xbc, 0xdc, 0x0f, 0x99, 0x60, 0x70, 0x6d, 0x1a, 0x52, 0x96, 0x3f, 0x93, 0x90,
-	0xfc, 0x7a, 0xe4, 0x66, 0xdc, 0x96, 0x41, 0x15, 0x65, 0x28, 0x44, 0x7e, 0xb5, 0xcb, 0xba, 0x4c,
-	0xf9, 0xa3, 0xd5, 0xc4, 0xbb, 0xde, 0x65, 0xac, 0xeb, 0x93, 0x8a, 0xb4, 0x9c, 0xc1, 0x5e, 0x05,
-	0x07, 0x23, 0xb5, 0xa5, 0x3b, 0xe0, 0x4c, 0xd5, 0x75, 0x09, 0xe7, 0x9d, 0x51, 0x9f, 0xb4, 0x70,
-	0x88, 0x7b, 0xd0, 0x02, 0x0b, 0xf7, 0xb1, 0x3f, 0x20, 0x39, 0xad, 0xa4, 0x6d, 0x9e, 0xbe, 0xbe,
-	0x51, 0x7e, 0xe1, 0x29, 0xca, 0x33, 0x58, 0x2d, 0x7b, 0x3c, 0x2e, 0xae, 0x8c, 0x70, 0xcf, 0xbf,
-	0xa9, 0x4b, 0xa4, 0x8e, 0x14, 0xc3, 0xcd, 0xf4, 0x37, 0xdf, 0x15, 0x35, 0xfd, 0x5b, 0x0d, 0xac,
-	0xa8, 0x68, 0x83, 0x05, 0x7b, 0xb4, 0x0b, 0xef, 0x02, 0xd0, 0x27, 0x61, 0x8f, 0x72, 0x4e, 0x59,
-	0x30, 0x7f, 0x9a, 0xb5, 0xe3, 0x71, 0xf1, 0xac, 0x4a, 0x33, 0x83, 0xeb, 0x28, 0xc6, 0x05, 0xaf,
-	0x80, 0x25, 0xec, 0x79, 0x21, 0xe1, 0x3c, 0x97, 0x2c, 0x69, 0x9b, 0x99, 0x1a, 0x3c, 0x1e, 0x17,
-	0x4f, 0x2b, 0xcc, 0x64, 0x43, 0x47, 0xd3, 0x90, 0xc9, 0xf1, 0xfe, 0x4c, 0x82, 0x45, 0xa9, 0x9c,
-	0x43, 0x01, 0xa0, 0xcb, 0x3c, 0x62, 0x0f, 0xfa, 0x3e, 0xc3, 0x9e, 0x8d, 0x65, 0x6e, 0x79, 0xc0,
-	0x53, 0xd7, 0x2f, 0xbc, 0xf2, 0x80, 0x4a, 0x59, 0x6d, 0xe3, 0xf1, 0xb8, 0x98, 0x38, 0x1e, 0x17,
-	0xd7, 0x55, 0xca, 0xe7, 0xc9, 0x74, 0x94, 0x8d, 0x9c, 0x3b, 0xd2, 0xa7, 0xa0, 0xf0, 0x6b, 0x0d,
-	0x14, 0x68, 0xc0, 0x05, 0x0e, 0x04, 0xc5, 0x82, 0xd8, 0x1e, 0xd9, 0xc3, 0x03, 0x5f, 0xd8, 0xb1,
-	0x1a, 0x25, 0xe7, 0xad, 0xd1, 0xc5, 0xe3, 0x71, 0xf1, 0x2d, 0x95, 0xfc, 0xd5, 0x94, 0x3a, 0x3a,
-	0x1f, 0x0b, 0xa8, 0xab, 0xfd, 0xd6, 0xac, 0x92, 0xb7, 0x01, 0xec, 0xe1, 0xa1, 0x1d, 0xe5, 0xb1,
-	0xa5, 0x0c, 0x4e, 0x1f, 0x92, 0x5c, 0xaa, 0xa4, 0x6d, 0xa6, 0x6b, 0x6f, 0xcc, 0x14, 0x3e, 0x1f,
-	0xa3, 0xa3, 0x33, 0x3d, 0x3c, 0xbc, 0x83, 0x79, 0xcf, 0x60, 0x1e, 0x69, 0xd3, 0x87, 0xea, 0x1a,
-	0x24, 0xf4, 0x5f, 0x35, 0xb0, 0x1c, 0xb9, 0xac, 0x60, 0x8f, 0xc1, 0xd7, 0x41, 0x46, 0x22, 0xf6,
-	0x31, 0xdf, 0x97, 0x05, 0x5e, 0x41, 0xcb, 0x91, 0x63, 0x0b, 0xf3, 0x7d, 0x98, 0x03, 0x4b, 0x6e,
-	0x48, 0xb0, 0x60, 0xa1, 0xea, 0x22, 0x9a, 0x9a, 0xf0, 0x35, 0xb0, 0xc8, 0xd9, 0x20, 0x74, 0xd5,
-	0x49, 0x32, 0x68, 0x62, 0x45, 0x08, 0x67, 0x40, 0x7d, 0x8f, 0x84, 0xb9, 0xb4, 0x42, 0x4c, 0x4c,
-	0x78, 0x17, 0xc0, 0x78, 0x21, 0x5c, 0xd9, 0xa7, 0xdc, 0xc2, 0xfc, 0x2d, 0x4d, 0x47, 0x2d, 0x45,
-	0x67, 0x63, 0x24, 0x6a, 0x43, 0xff, 0x39, 0x09, 0x56, 0x0c, 0x16, 0x88, 0x10, 0xbb, 0x42, 0x6a,
-	0xba, 0x00, 0x96, 0xa4, 0x26, 0xea, 0x49, 0x45, 0xe9, 0x1a, 0x38, 0x1a, 0x17, 0x17, 0xa5, 0xe4,
-	0x3a, 0x5a, 0x8c, 0xb6, 0x2c, 0xef, 0x15, 0xda, 0x56, 0xc1, 0x02, 0xf6, 0x7a, 0x34, 0x98, 0x48,
-	0x53, 0x46, 0xe4, 0xf5, 0xb1, 0x43, 0xfc, 0x89, 0x2e, 0x65, 0x40, 0x63, 0xc2, 0x42, 0xbc, 0x89,
-	0x94, 0x8b, 0x2f, 0x93, 0xe2, 0x70, 0xe6, 0x0f, 0x04, 0xe9, 0x0c, 0x5b, 0x8c, 0x53, 0x41, 0x59,
-	0x80, 0xa6, 0x48, 0x78, 0x15, 0x9c, 0xa2, 0x8e, 0x6b, 0xf7, 0x59, 0x28, 0xa2, 0x33, 0x2f, 0xca,
-	0x81, 0xf9, 0xdf, 0xd1, 0xb8, 0x98, 0xb1, 0x6a, 0x46, 0x8b, 0x85, 0xc2, 0xaa, 0xa3, 0x0c, 0x75,
-	0x5c, 0xb9, 0xf4, 0xe0, 0x36, 0xc8, 0x90, 0xa1, 0x20, 0x81, 0xbc, 0x90, 0x4b, 0x32, 0xeb, 0x6a,
-	0x59, 0xbd, 0x2c, 0xe5, 0xe9, 0xcb, 0x52, 0xae, 0x06, 0xa3, 0xda, 0xfa, 0x2f, 0x3f, 0x5d, 0x5d,
-	0x8b, 0x57, 0xc6, 0x9c, 0xc2, 0xd0, 0x8c, 0xe1, 0x66, 0xfa, 0xaf, 0x68, 0xf8, 0xbe, 0x4c, 0x82,
-	0xdc, 0x34, 0x34, 0xaa, 0xd4, 0x16, 0xe5, 0x82, 0x85, 0x23, 0x33, 0x10, 0xe1, 0x08, 0xee, 0x80,
-	0x0c, 0xeb, 0x93, 0x10, 0x8b, 0xd9, 0x33, 0x71, 0xe3, 0x25, 0x3a, 0x5f, 0xc0, 0xd1, 0x9c, 0x42,
-	0xa3, 0xc1, 0x40, 0x33, 0xa6, 0x78, 0x9f, 0x92, 0x2f, 0xed, 0x93, 0x01, 0x96, 0x06, 0x7d, 0x4f,
-	0x56, 0x38, 0xf5, 0x9f, 0x2b, 0x3c, 0x41, 0xc2, 0x32, 0x48, 0xf5, 0x78, 0x57, 0xb6, 0x6e, 0xa5,
-	0x76, 0xfe, 0xd9, 0xb8, 0x98, 0x23, 0x81, 0xcb, 0x3c, 0x1a, 0x74, 0x2b, 0x9f, 0x73, 0x16, 0x94,
-	0x11, 0x7e, 0xb0, 0x4d, 0x38, 0xc7, 0x5d, 0x82, 0xa2, 0x40, 0x1d, 0x01, 0xf8, 0x3c, 0x1d, 0xdc,
-	0x00, 0x2b, 0x8e, 0xcf, 0xdc, 0x7b, 0xf6, 0x3e, 0xa1, 0xdd, 0x7d, 0xa1, 0x2e, 0x17, 0x3a, 0x25,
-	0x7d, 0x5b, 0xd2, 0x05, 0xd7, 0xc1, 0xb2, 0x18, 0xda, 0x34, 0xf0, 0xc8, 0x50, 0x69, 0x42, 0x4b,
-	0x62, 0x68, 0x45, 0xa6, 0x4e, 0xc1, 0xc2, 0x36, 0xf3, 0x88, 0x0f, 0x6f, 0x83, 0xd4, 0x3d, 0x32,
-	0x52, 0xc3, 0x56, 0xfb, 0xf0, 0xd9, 0xb8, 0xf8, 0x7e, 0x97, 0x8a, 0xfd, 0x81, 0x53, 0x76, 0x59,
-	0xaf, 0x22, 0x48, 0xe0, 0x45, 0xc3, 0x1f, 0x88, 0xf8, 0xd2, 0xa7, 0x0e, 0xaf, 0x38, 0x23, 0x41,
-	0x78, 0x79, 0x8b, 0x0c, 0x6b, 0xd1, 0x02, 0x45, 0x24, 0xd1, 0xad, 0x54, 0xff, 0x11, 0x49, 0x39,
-	0xba, 0xca, 0xb8, 0xf4, 0xb7, 0x06, 0xc0, 0xec, 0x2d, 0x82, 0x1f, 0x80, 0x73, 0x55, 0xc3, 0x30,
-	0xdb, 0x6d, 0xbb, 0xb3, 0xdb, 0x32, 0xed, 0x9d, 0x46, 0xbb, 0x65, 0x1a, 0xd6, 0x47, 0x96, 0x59,
-	0xcf, 0x26, 0xf2, 0xeb, 0x07, 0x87, 0xa5, 0xb5, 0x59, 0xf0, 0x4e, 0xc0, 0xfb, 0xc4, 0xa5, 0x7b,
-	0x94, 0x78, 0xf0, 0x0a, 0x80, 0x71, 0x5c, 0xa3, 0x59, 0x6b, 0xd6, 0x77, 0xb3, 0x5a, 0x7e, 0xf5,
-	0xe0, 0xb0, 0x94, 0x9d, 0x41, 0x1a, 0xcc, 0x61, 0xde, 0x08, 0xde, 0x00, 0xb9, 0x78, 0x74, 0xb3,
-	0xf1, 0xf1, 0xae, 0x5d, 0xad, 0xd7, 0x91, 0xd9, 0x6e, 0x67, 0x93, 0xff, 0x4e, 0xd3, 0x0c, 0xfc,
-	0x51, 0x55, 0xbd, 0xfe, 0xf0, 0x3a, 0x58, 0x8b, 0x03, 0xcd, 0x4f, 0x4c, 0xb4, 0x2b, 0x33, 0xa5,
-	0xf2, 0xe7, 0x0e, 0x0e, 0x4b, 0xff, 0x9f, 0xa1, 0xcc, 0xfb, 0x24, 0x1c, 0x45, 0xc9, 0xf2, 0xcb,
-	0x5f, 0x7c, 0x5f, 0x48, 0x3c, 0xfa, 0xa1, 0x90, 0xb8, 0xf4, 0x63, 0x0a, 0x94, 0x4e, 0xba, 0x74,
-	0x90, 0x80, 0x77, 0x8d, 0x66, 0xa3, 0x83, 0xaa, 0x46, 0xc7, 0x36, 0x9a, 0x75, 0xd3, 0xde, 0xb2,
-	0xda, 0x9d, 0x26, 0xda, 0xb5, 0x9b, 0x2d, 0x13, 0x55, 0x3b, 0x56, 0xb3, 0xf1, 0xa2, 0xd2, 0x54,
-	0x0e, 0x0e, 0x4b, 0x97, 0x4f, 0xe2, 0x8e, 0x17, 0xec, 0x0e, 0xb8, 0x38, 0x57, 0x1a, 0xab, 0x61,
-	0x75, 0xb2, 0x5a, 0x7e, 0xf3, 0xe0, 0xb0, 0xf4, 0xe6, 0x49, 0xfc, 0x56, 0x40, 0x05, 0xfc, 0x0c,
-	0x5c, 0x99, 0x8b, 0x78, 0xdb, 0xba, 0x85, 0xaa, 0x1d, 0x33, 0x9b, 0xcc, 0x5f, 0x3e, 0x38, 0x2c,
-	0xbd, 0x73, 0x12, 0xf7, 0x36, 0xed, 0x86, 0x58, 0x90, 0xb9, 0xe9, 0x6f, 0x99, 0x0d, 0xb3, 0x6d,
-	0xb5, 0xb3, 0xa9, 0xf9, 0xe8, 0x6f, 0x91, 0x80, 0x70, 0xca, 0xf3, 0xe9, 0xa8, 0x59, 0xb5, 0xad,
-	0xc7, 0x7f, 0x14, 0x12, 0x8f, 0x8e, 0x0a, 0xda, 0xe3, 0xa3, 0x82, 0xf6, 0xe4, 0xa8, 0xa0, 0xfd,
-	0x7e, 0x54, 0xd0, 0xbe, 0x7a, 0x5a, 0x48, 0x3c, 0x79, 0x5a, 0x48, 0xfc, 0xf6, 0xb4, 0x90, 0xf8,
-	0xf4, 0xed, 0xd8, 0x1c, 0x18, 0x8c, 0xf7, 0xee, 0x4c, 0x3f, 0xca, 0xbc, 0xca, 0x50, 0x7d, 0x9c,
-	0xc9, 0x8f, 0x32, 0x67, 0x51, 0xbe, 0x72, 0xef, 0xfd, 0x13, 0x00, 0x00, 0xff, 0xff, 0xf0, 0xea,
-	0x50, 0x19, 0xba, 0x09, 0x00, 0x00,
+	// 2207 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x58, 0xcb, 0x6f, 0x1b, 0xc7,
+	0xfd, 0xd7, 0x92, 0xd4, 0x83, 0x23, 0xca, 0xa2, 0x26, 0x52, 0x4c, 0xc9, 0xfe, 0x71, 0xe9, 0x4d,
+	0xe2, 0xc8, 0x2f, 0x2a, 0xd1, 0x2f, 0x6d, 0x0a, 0x03, 0x3d, 0xf0, 0xb1, 0x91, 0xd6, 0x8d, 0x48,
+	0x76, 0x28, 0xd7, 0x71, 0x81, 0x62, 0x3b, 0xdc, 0x1d, 0x51, 0x5b, 0x91, 0x3b, 0xec, 0xce, 0xac,
+	0x45, 0xa6, 0xc7, 0x5e, 0x0a, 0x9d, 0x8a, 0x1c, 0x8a, 0xa2, 0xa8, 0x80, 0x00, 0x2d, 0x8a, 0xa0,
+	0xe7, 0xfe, 0x11, 0x46, 0x4f, 0x39, 0x15, 0x3d, 0x31, 0xad, 0x7c, 0x69, 0x0b, 0xf4, 0xa2, 0xa3,
+	0x4f, 0xc5, 0xcc, 0xec, 0x8a, 0x94, 0x64, 0xda, 0xca, 0x45, 0x9c, 0xef, 0x73, 0x66, 0x3e, 0xdf,
+	0xd7, 0xac, 0xc0, 0x2d, 0x87, 0xb2, 0xee, 0x21, 0x66, 0xdd, 0x0d, 0xf9, 0xe7, 0xd9, 0x87, 0x2d,
+	0xc2, 0xf1, 0x87, 0x1b, 0x7c, 0xd0, 0x23, 0xac, 0xd8, 0x0b, 0x28, 0xa7, 0x70, 0x25, 0x56, 0x29,
+	0xca, 0x3f, 0x91, 0xca, 0xda, 0xaa, 0x60, 0x53, 0x66, 0x4b, 0xa5, 0x0d, 0x45, 0x28, 0x8b, 0xb5,
+	0xbc, 0xa2, 0x36, 0x5a, 0x98, 0x91, 0x33, 0x97, 0x0e, 0xf5, 0xfc, 0x48, 0xbe, 0xdc, 0xa6, 0x6d,
+	0xaa, 0xec, 0xc4, 0x2a, 0xe2, 0xae, 0xb6, 0x29, 0x6d, 0x77, 0xc8, 0x86, 0xa4, 0x5a, 0xe1, 0xde,
+	0x06, 0xf6, 0x07, 0x4a, 0x64, 0xb4, 0xc0, 0x62, 0xc9, 0x71, 0x08, 0x63, 0xbb, 0x83, 0x1e, 0x69,
+	0xe0, 0x00, 0x77, 0xa1, 0x05, 0xa6, 0x9f, 0xe1, 0x4e, 0x48, 0x72, 0x5a, 0x41, 0x5b, 0xbf, 0xb6,
+	0x79, 0xab, 0xf8, 0xca, 0x53, 0x16, 0x47, 0x66, 0xe5, 0xec, 0xe9, 0x50, 0xcf, 0x0c, 0x70, 0xb7,
+	0xf3, 0xd0, 0x90, 0x96, 0x06, 0x52, 0x1e, 0x1e, 0xa6, 0x7e, 0xfb, 0xa5, 0xae, 0x19, 0xbf, 0xd7,
+	0x40, 0x46, 0x69, 0x57, 0xa8, 0xbf, 0xe7, 0xb5, 0xe1, 0x67, 0x00, 0xf4, 0x48, 0xd0, 0xf5, 0x18,
+	0xf3, 0xa8, 0x7f, 0xf5, 0x6d, 0x56, 0x4e, 0x87, 0xfa, 0x92, 0xda, 0x66, 0x64, 0x6e, 0xa0, 0x31,
+	0x5f, 0xf0, 0x3e, 0x98, 0xc5, 0xae, 0x1b, 0x10, 0xc6, 0x72, 0x89, 0x82, 0xb6, 0x9e, 0x2e, 0xc3,
+	0xd3, 0xa1, 0x7e, 0x4d, 0xd9, 0x44, 0x02, 0x03, 0xc5, 0x2a, 0xd1, 0xf1, 0xbe, 0x9c, 0x03, 0x33,
+	0xf2, 0xe6, 0x0c, 0x72, 0x00, 0x1d, 0xea, 0x12, 0x3b, 0xec, 0x75, 0x28, 0x76, 0x6d, 0x2c, 0xf7,
+	0x96, 0x07, 0x9c, 0xdf, 0x7c, 0xe7, 0xb5, 0x07, 0x54, 0x37, 0x2b, 0xdf, 0x7a, 0x3e, 0xd4, 0xa7,
+	0x4e, 0x87, 0xfa, 0xaa, 0xda, 0xf2, 0xb2, 0x33, 0x03, 0x65, 0x05, 0xf3, 0xb1, 0xe4, 0x29, 0x53,
+	0xf8, 0x85, 0x06, 0xf2, 0x9e, 0xcf, 0x38, 0xf6, 0xb9, 0x87, 0x39, 0xb1, 0x5d, 0xb2, 0x87, 0xc3,
+	0x0e, 0xb7, 0xc7, 0x30, 0x4a, 0x5c, 0x15, 0xa3, 0x3b, 0xa7, 0x43, 0xfd, 0x3d, 0xb5, 0xf9, 0xeb,
+	0x5d, 0x1a, 0xe8, 0xe6, 0x98, 0x42, 0x55, 0xc9, 0x1b, 0x23, 0x24, 0x1f, 0x01, 0xd8, 0xc5, 0x7d,
+	0x5b, 0xec, 0x63, 0xcb, 0x6b, 0x30, 0xef, 0x73, 0x92, 0x4b, 0x16, 0xb4, 0xf5, 0x54, 0xf9, 0xff,
+	0x46, 0x37, 0xbc, 0xac, 0x63, 0xa0, 0xc5, 0x2e, 0xee, 0x3f, 0xc1, 0xac, 0x5b, 0xa1, 0x2e, 0x69,
+	0x7a, 0x9f, 0x13, 0x48, 0xc0, 0xb2, 0xd7, 0x72, 0xec, 0x1e, 0x76, 0x0e, 0x08, 0xb7, 0xdb, 0x98,
+	0xd9, 0x1d, 0xaf, 0xeb, 0xf1, 0x5c, 0x4a, 0x7a, 0xfb, 0xe8, 0x64, 0xa8, 0x2f, 0x59, 0xe5, 0x4a,
+	0x43, 0x8a, 0xb7, 0x30, 0xfb, 0x54, 0x08, 0x4f, 0x87, 0xfa, 0x8d, 0xe8, 0x1e, 0xaf, 0x30, 0x35,
+	0xd0, 0x92, 0xd7, 0x72, 0xce, 0x5b, 0xc0, 0x9f, 0x82, 0x55, 0xd2, 0x27, 0x4e, 0xc8, 0x89, 0x8d,
+	0x3b, 0x1d, 0x7a, 0xd8, 0xf1, 0x18, 0xb7, 0x89, 0x8f, 0x5b, 0x1d, 0xe2, 0xe6, 0xa6, 0x0b, 0xda,
+	0xfa, 0x5c, 0xf9, 0xdd, 0xd3, 0xa1, 0x5e, 0x50, 0x6e, 0x27, 0xaa, 0x1a, 0xe8, 0x7a, 0x24, 0x2b,
+	0xc5, 0x22, 0x53, 0x49, 0xa0, 0x05, 0x96, 0x2e, 0x99, 0xe5, 0x66, 0x0a, 0xc9, 0xf5, 0x74, 0xf9,
+	0xe6, 0xe9, 0x50, 0xcf, 0x4d, 0xf0, 0x6c, 0xa0, 0xec, 0x45, 0x8f, 0xd0, 0x04, 0x59, 0x81, 0x9d,
+	0x43, 0x7d, 0x1e, 0x60, 0x47, 0x5e, 0x2d, 0x37, 0x2b, 0xf1, 0xb8, 0x71, 0x3a, 0xd4, 0xaf, 0x8f,
+	0xd0, 0x1d, 0xd7, 0x30, 0xd0, 0xb5, 0x2e, 0xee, 0x57, 0x22, 0xce, 0x16, 0x66, 0xb0, 0x01, 0x96,
+	0x85, 0x52, 0x40, 0x98, 0x08, 0xaf, 0x8b, 0x39, 0x56, 0x81, 0x9a, 0x93, 0xae, 0xf4, 0x11, 0x8a,
+	0xaf, 0xd2, 0x32, 0xd0, 0x52, 0x17, 0xf7, 0x91, 0xe4, 0x56, 0x31, 0xc7, 0x32, 0x58, 0xbf, 0xd1,
+	0x00, 0x64, 0x9c, 0x06, 0x44, 0x85, 0xd4, 0x25, 0x3d, 0xca, 0x3c, 0x9e, 0x4b, 0x17, 0x92, 0xeb,
+	0xf3, 0x9b, 0xab, 0xc5, 0xa8, 0x1d, 0x89, 0x06, 0x74, 0x96, 0x7f, 0x15, 0xea, 0xf9, 0xe5, 0x9d,
+	0xf3, 0xa9, 0x7f, 0xd9, 0x85, 0xf1, 0xe7, 0x6f, 0xf4, 0xf5, 0xb6, 0xc7, 0xf7, 0xc3, 0x56, 0xd1,
+	0xa1, 0xdd, 0xa8, 0xb1, 0x45, 0x3f, 0x0f, 0x98, 0x7b, 0x10, 0xf5, 0x46, 0xe1, 0x8d, 0xa1, 0xac,
+	0x74, 0x20, 0x52, 0xa8, 0xaa, 0xcc, 0x21, 0x03, 0x85, 0xcb, 0x4e, 0xed, 0x80, 0xec, 0x85, 0xbe,
+	0x6b, 0xb7, 0x3a, 0xd4, 0x39, 0x60, 0x39, 0x20, 0xaf, 0x7d, 0xef, 0x74, 0xa8, 0xbf, 0x3f, 0xe9,
+	0x18, 0xe7, 0x2d, 0x0c, 0x74, 0xf3, 0xe2, 0x46, 0x48, 0xca, 0xcb, 0x52, 0x2c, 0x5b, 0xc4, 0x94,
+	0xf1, 0x52, 0x03, 0x73, 0x42, 0xc3, 0xf2, 0xf7, 0x28, 0xbc, 0x01, 0xd2, 0xd2, 0xdf, 0x3e, 0x66,
+	0xfb, 0xb2, 0x37, 0x64, 0xd0, 0x9c, 0x60, 0x6c, 0x63, 0xb6, 0x0f, 0x73, 0x60, 0xd6, 0x09, 0x08,
+	0xe6, 0x34, 0x50, 0x0d, 0x08, 0xc5, 0x24, 0x7c, 0x1b, 0xcc, 0x30, 0x1a, 0x06, 0x8e, 0x2a, 0xa2,
+	0x34, 0x8a, 0x28, 0x61, 0xd1, 0x0a, 0xbd, 0x8e, 0x4b, 0x02, 0x59, 0x0f, 0x69, 0x14, 0x93, 0xf0,
+	0x33, 0x00, 0xc7, 0x6b, 0xd8, 0x91, 0x2d, 0x26, 0x37, 0x7d, 0xf5, 0x6e, 0x94, 0x12, 0x21, 0x41,
+	0x4b, 0x63, 0x4e, 0x94, 0x00, 0xde, 0x03, 0x4b, 0x01, 0xf9, 0x79, 0xe8, 0x05, 0xc4, 0xb5, 0xf7,
+	0x08, 0xe6, 0x61, 0x40, 0x98, 0xca, 0x63, 0x94, 0x8d, 0x05, 0x9f, 0x44, 0x7c, 0xe3, 0xbf, 0x09,
+	0x90, 0x89, 0x53, 0x4e, 0x02, 0xf0, 0x0e, 0x98, 0x95, 0x00, 0x78, 0xae, 0xbc, 0x7e, 0xaa, 0x0c,
+	0x4e, 0x86, 0xfa, 0x8c, 0xc4, 0xa7, 0x8a, 0x66, 0x84, 0xc8, 0x72, 0x5f, 0x03, 0xc4, 0x32, 0x98,
+	0xc6, 0x6e, 0xd7, 0xf3, 0x23, 0x1c, 0x14, 0x21, 0xb8, 0x1d, 0xdc, 0x22, 0x9d, 0x08, 0x04, 0x45,
+	0xc0, 0x4a, 0xe4, 0x25, 0x2a, 0xe0, 0xf9, 0xcd, 0x3b, 0x93, 0xee, 0xdd, 0x62, 0xb4, 0x13, 0x72,
+	0xb2, 0xdb, 0x6f, 0x88, 0x18, 0x7a, 0xd4, 0x47, 0xb1, 0x25, 0x7c, 0x00, 0xe6, 0x65, 0x0f, 0xa1,
+	0x01, 0x17, 0x67, 0x9e, 0x91, 0x83, 0x61, 0xe1, 0x64, 0xa8, 0xa7, 0x45, 0xd7, 0xa1, 0x01, 0xb7,
+	0xaa, 0x28, 0x2d, 0xda, 0x89, 0x58, 0xba, 0x70, 0x07, 0xa4, 0x49, 0x9f, 0x13, 0x5f, 0x36, 0xde,
+	0x59, 0xb9, 0xeb, 0x72, 0x51, 0x4d, 0xd0, 0x62, 0x3c, 0x41, 0x8b, 0x25, 0x7f, 0x50, 0x5e, 0xfd,
+	0xeb, 0x5f, 0x1e, 0xac, 0x8c, 0x23, 0x63, 0xc6, 0x66, 0x68, 0xe4, 0x01, 0xde, 0x01, 0xd9, 0x80,
+	0x10, 0xa1, 0xe4, 0x3b, 0x03, 0xbb, 0x1d, 0xe2, 0xc0, 0x95, 0xd5, 0x39, 0x87, 0x16, 0x47, 0xfc,
+	0x2d, 0xc1, 0x7e, 0x98, 0xfa, 0x97, 0x98, 0x47, 0x7f, 0x4b, 0x80, 0x5c, 0xec, 0x55, 0x80, 0xba,
+	0xed, 0x89, 0x0c, 0x1d, 0x98, 0x3e, 0x0f, 0x06, 0xf0, 0x31, 0x48, 0xd3, 0x1e, 0x09, 0x30, 0x1f,
+	0x4d, 0xce, 0x8f, 0x27, 0x40, 0xf2, 0x0a, 0x1f, 0xf5, 0xd8, 0x54, 0xcc, 0x0a, 0x34, 0xf2, 0x34,
+	0x1e, 0xd2, 0xc4, 0xc4, 0x90, 0x56, 0xc0, 0x6c, 0xd8, 0x73, 0x65, 0x30, 0x92, 0xdf, 0x3a, 0x18,
+	0x91, 0x25, 0x2c, 0x82, 0x64, 0x97, 0xb5, 0x65, 0x94, 0x33, 0xe5, 0x9b, 0x2f, 0x87, 0x7a, 0x8e,
+	0xf8, 0x0e, 0x75, 0x3d, 0xbf, 0xbd, 0xf1, 0x33, 0x46, 0xfd, 0x22, 0xc2, 0x87, 0x3b, 0x84, 0x31,
+	0xdc, 0x26, 0x48, 0x28, 0x42, 0x0b, 0x64, 0xba, 0xac, 0x6d, 0xc7, 0x4a, 0x32, 0x0d, 0xae, 0x6d,
+	0xde, 0x9e, 0xb0, 0x73, 0x64, 0x6a, 0x46, 0xda, 0x68, 0xbe, 0xcb, 0xda, 0x31, 0x61, 0x20, 0x00,
+	0x2f, 0x9f, 0x0c, 0xde, 0x02, 0x19, 0xd9, 0x0a, 0xec, 0x7d, 0xe2, 0xb5, 0xf7, 0xb9, 0x4a, 0x69,
+	0x34, 0x2f, 0x79, 0xdb, 0x92, 0x05, 0x57, 0xc1, 0x1c, 0xef, 0xdb, 0x9e, 0xef, 0x92, 0xbe, 0x82,
+	0x07, 0xcd, 0xf2, 0xbe, 0x25, 0x48, 0xc3, 0x03, 0xd3, 0x3b, 0xd4, 0x25, 0x1d, 0xf8, 0x08, 0x24,
+	0x0f, 0xc8, 0x40, 0xf5, 0x83, 0xf2, 0xf7, 0x5e, 0x0e, 0xf5, 0x8f, 0xc6, 0x5a, 0x1d, 0x27, 0xbe,
+	0x2b, 0x46, 0xab, 0xcf, 0xc7, 0x97, 0x1d, 0xaf, 0xc5, 0x36, 0x5a, 0x03, 0x4e, 0x58, 0x71, 0x9b,
+	0xf4, 0xcb, 0x62, 0x81, 0x84, 0x13, 0x51, 0x0b, 0xea, 0x05, 0x96, 0x90, 0xdd, 0x45, 0x11, 0xc6,
+	0x2f, 0xc0, 0xbc, 0x1a, 0x78, 0x88, 0xf4, 0x3a, 0x03, 0x91, 0x57, 0x67, 0xa3, 0x21, 0x7e, 0xf3,
+	0x68, 0xb2, 0x76, 0x16, 0x63, 0x7e, 0x49, 0xb1, 0xe1, 0x6d, 0x30, 0x17, 0x08, 0x9b, 0x51, 0x78,
+	0xe7, 0x4f, 0x86, 0xfa, 0xac, 0xf4, 0x63, 0x55, 0xd1, 0xac, 0x14, 0x5a, 0xae, 0xb8, 0xa7, 0xd2,
+	0xa3, 0x71, 0x71, 0x2a, 0x51, 0xdd, 0x37, 0x08, 0x80, 0x22, 0x1b, 0x4c, 0x39, 0xc6, 0x3c, 0xea,
+	0x37, 0x39, 0xe6, 0x4c, 0x1c, 0x94, 0xf4, 0xa8, 0xb3, 0x1f, 0x81, 0xa6, 0x08, 0x98, 0x07, 0x80,
+	0xc4, 0x7a, 0x2c, 0x02, 0x6c, 0x8c, 0x23, 0xb6, 0x11, 0x83, 0x3c, 0x64, 0x51, 0x22, 0xa5, 0xd0,
+	0x6c, 0x1b, 0xb3, 0xc7, 0x8c, 0xb8, 0xc6, 0x37, 0x1a, 0xc8, 0x36, 0x2f, 0x36, 0xfe, 0x2b, 0xf5,
+	0x9b, 0x9b, 0x20, 0x1d, 0x35, 0xf8, 0xb3, 0x8e, 0x33, 0x62, 0x40, 0x07, 0xcc, 0xe0, 0x2e, 0x0d,
+	0x7d, 0x9e, 0x4b, 0xbe, 0x69, 0x8e, 0x7d, 0x20, 0x9a, 0xe6, 0xb7, 0x1a, 0x55, 0x91, 0x6b, 0xf8,
+	0x0e, 0x58, 0x88, 0x66, 0x4b, 0x94, 0x4a, 0x22, 0xc9, 0x93, 0x28, 0xa3, 0x98, 0x2a, 0x97, 0x8c,
+	0x87, 0x00, 0x5a, 0x3e, 0x27, 0x81, 0xb3, 0x8f, 0x3d, 0xff, 0x87, 0x21, 0x09, 0x06, 0x3f, 0x20,
+	0x03, 0x08, 0x41, 0xaa, 0x87, 0xf9, 0x7e, 0x14, 0x40, 0xb9, 0x86, 0x59, 0x95, 0x51, 0x2a, 0x07,
+	0xc4, 0xd2, 0xf8, 0x22, 0x09, 0x16, 0x2f, 0x18, 0xc3, 0xb7, 0x41, 0xe2, 0x0c, 0x97, 0x99, 0x93,
+	0xa1, 0x9e, 0xb0, 0xaa, 0x28, 0xe1, 0xb9, 0x22, 0x34, 0xf4, 0xd0, 0x27, 0x31, 0x16, 0x8a, 0x80,
+	0xdf, 0x01, 0x0b, 0x0e, 0xf5, 0x7d, 0xe2, 0x88, 0x48, 0xd8, 0x9e, 0xc2, 0x3f, 0x5d, 0xce, 0x9e,
+	0x0c, 0xf5, 0x4c, 0xe5, 0x4c, 0x60, 0x55, 0x51, 0x66, 0xa4, 0x26, 0x2b, 0x3f, 0x75, 0x40, 0x06,
+	0x2c, 0x97, 0x2a, 0x24, 0x5f, 0x53, 0xf6, 0x97, 0xef, 0x15, 0x4d, 0x20, 0x69, 0x2c, 0xe0, 0x51,
+	0x4d, 0x40, 0xbc, 0x42, 0x3d, 0xaa, 0x3a, 0x7a, 0x0a, 0x65, 0x14, 0xb3, 0x21, 0x79, 0x70, 0x13,
+	0xac, 0x74, 0x30, 0xe3, 0x36, 0x0b, 0x5b, 0x5d, 0x8f, 0x73, 0x72, 0x86, 0xe5, 0x8c, 0xc4, 0xf2,
+	0x2d, 0x21, 0x6c, 0xc6, 0xb2, 0xa8, 0x3c, 0xbf, 0x0f, 0x6e, 0x48, 0x9b, 0x80, 0x74, 0x29, 0x27,
+	0x76, 0x40, 0x9e, 0x79, 0xa2, 0xf3, 0xda, 0x7e, 0xd8, 0x6d, 0x91, 0x40, 0xbd, 0xaa, 0x50, 0x4e,
+	0xa8, 0x20, 0xa9, 0x81, 0x22, 0x85, 0x9a, 0x94, 0x4f, 0x34, 0x8f, 0x36, 0x9e, 0x9b, 0x64, 0x1e,
+	0x05, 0xb4, 0x02, 0x56, 0x2e, 0x5c, 0x5c, 0x3d, 0xa8, 0x46, 0x55, 0xac, 0x8d, 0x55, 0xb1, 0xe0,
+	0xf6, 0x02, 0x4a, 0xf7, 0xe2, 0xda, 0x96, 0x84, 0xf1, 0x3b, 0x0d, 0x24, 0x1f, 0xd1, 0xd6, 0xc4,
+	0x68, 0x42, 0x90, 0x12, 0x19, 0x17, 0x05, 0x53, 0xae, 0xc5, 0x84, 0xed, 0xe1, 0x81, 0xf8, 0x8e,
+	0x90, 0x51, 0xcc, 0xa0, 0x98, 0x84, 0xb7, 0xc1, 0x22, 0xe3, 0xa4, 0xc7, 0x04, 0xd0, 0xea, 0x9d,
+	0x23, 0x53, 0x71, 0x01, 0x2d, 0x48, 0x76, 0x83, 0x04, 0xf2, 0x75, 0x03, 0xdf, 0x03, 0xd7, 0xa2,
+	0x19, 0x19, 0x5f, 0x76, 0x5a, 0xa2, 0xbc, 0x10, 0x71, 0xa3, 0x1b, 0xfe, 0x47, 0x03, 0x0b, 0xe5,
+	0xb0, 0x73, 0xb0, 0xe3, 0xb5, 0x2f, 0x8f, 0x8b, 0xc9, 0x15, 0xf9, 0x00, 0xcc, 0xfb, 0xe4, 0xd0,
+	0x3e, 0x3f, 0x57, 0xe4, 0xd8, 0xad, 0x91, 0xc3, 0x48, 0x37, 0xed, 0x47, 0x4b, 0x17, 0xae, 0x80,
+	0x99, 0x20, 0xf4, 0x6d, 0xcc, 0xe2, 0x77, 0x41, 0x10, 0xfa, 0x25, 0x06, 0x75, 0x30, 0xdf, 0x95,
+	0xfb, 0x12, 0xfb, 0x6c, 0x6e, 0x20, 0x10, 0xb1, 0x76, 0x58, 0x5b, 0x74, 0x13, 0x9f, 0xf4, 0xb9,
+	0x2d, 0x6a, 0x65, 0x5a, 0xe1, 0x20, 0x68, 0x51, 0x55, 0x6b, 0x60, 0x2e, 0x52, 0x54, 0x53, 0x3f,
+	0x85, 0xce, 0x68, 0xf1, 0x1c, 0xdb, 0xc3, 0x9e, 0xf8, 0x32, 0x50, 0xf9, 0x11, 0x51, 0x46, 0x03,
+	0x64, 0xe5, 0x77, 0x8b, 0xe8, 0x6f, 0x8d, 0x20, 0xf4, 0x3d, 0xbf, 0x2d, 0x47, 0x04, 0xd9, 0x13,
+	0x0f, 0xc9, 0xf1, 0x6e, 0x37, 0xaf, 0x78, 0xa6, 0x60, 0x9d, 0x3b, 0x45, 0xe2, 0xdc, 0x29, 0xee,
+	0xfe, 0x5b, 0x03, 0x60, 0xf4, 0x85, 0x06, 0xbf, 0x0b, 0xae, 0x97, 0x2a, 0x15, 0xb3, 0xd9, 0xb4,
+	0x77, 0x9f, 0x36, 0x4c, 0xfb, 0x71, 0xad, 0xd9, 0x30, 0x2b, 0xd6, 0x27, 0x96, 0x59, 0xcd, 0x4e,
+	0xad, 0xad, 0x1e, 0x1d, 0x17, 0x56, 0x46, 0xca, 0x8f, 0x7d, 0xd6, 0x23, 0x8e, 0xb7, 0xe7, 0x11,
+	0x17, 0xde, 0x07, 0x70, 0xdc, 0xae, 0x56, 0x2f, 0xd7, 0xab, 0x4f, 0xb3, 0xda, 0xda, 0xf2, 0xd1,
+	0x71, 0x21, 0x3b, 0x32, 0xa9, 0xd1, 0x16, 0x75, 0x07, 0xf0, 0x63, 0x90, 0x1b, 0xd7, 0xae, 0xd7,
+	0x3e, 0x7d, 0x6a, 0x97, 0xaa, 0x55, 0x64, 0x36, 0x9b, 0xd9, 0xc4, 0xc5, 0x6d, 0xea, 0x7e, 0x67,
+	0x10, 0xcf, 0x8a, 0x4d, 0xb0, 0x32, 0x6e, 0x68, 0xfe, 0xc8, 0x44, 0x4f, 0xe5, 0x4e, 0xc9, 0xb5,
+	0xeb, 0x47, 0xc7, 0x85, 0xb7, 0x46, 0x56, 0xe6, 0x33, 0x12, 0x0c, 0xc4, 0x66, 0x6b, 0x73, 0xbf,
+	0xfa, 0x43, 0x7e, 0xea, 0xab, 0x3f, 0xe6, 0xa7, 0xee, 0xfe, 0x29, 0x09, 0x0a, 0x6f, 0x7a, 0x77,
+	0x40, 0x02, 0x3e, 0xa8, 0xd4, 0x6b, 0xbb, 0xa8, 0x54, 0xd9, 0xb5, 0x2b, 0xf5, 0xaa, 0x69, 0x6f,
+	0x5b, 0xcd, 0xdd, 0x3a, 0x7a, 0x6a, 0xd7, 0x1b, 0x26, 0x2a, 0xed, 0x5a, 0xf5, 0xda, 0xab, 0xa0,
+	0xd9, 0x38, 0x3a, 0x2e, 0xdc, 0x7b, 0x93, 0xef, 0x71, 0xc0, 0x9e, 0x80, 0x3b, 0x57, 0xda, 0xc6,
+	0xaa, 0x59, 0xbb, 0x59, 0x6d, 0x6d, 0xfd, 0xe8, 0xb8, 0xf0, 0xee, 0x9b, 0xfc, 0x5b, 0xbe, 0xc7,
+	0xe1, 0x4f, 0xc0, 0xfd, 0x2b, 0x39, 0xde, 0xb1, 0xb6, 0x50, 0x69, 0xd7, 0xcc, 0x26, 0xd6, 0xee,
+	0x1d, 0x1d, 0x17, 0xde, 0x7f, 0x93, 0x6f, 0x55, 0x5e, 0xe4, 0xca, 0xee, 0xb7, 0xcc, 0x9a, 0xd9,
+	0xb4, 0x9a, 0xd9, 0xe4, 0xd5, 0xdc, 0x6f, 0x11, 0x9f, 0x30, 0x8f, 0xad, 0xa5, 0x44, 0xb0, 0xee,
+	0xfe, 0x52, 0x03, 0x8b, 0x17, 0x1e, 0x4b, 0x22, 0xf4, 0x3b, 0x66, 0xb3, 0x59, 0xda, 0x32, 0x6d,
+	0xb3, 0x56, 0xa9, 0x57, 0xad, 0xda, 0x96, 0xfd, 0xa8, 0x59, 0xaf, 0x65, 0xa7, 0x54, 0xe8, 0x2f,
+	0xe8, 0x0b, 0x91, 0xc8, 0xe6, 0x4b, 0x36, 0x65, 0xab, 0x56, 0x42, 0x22, 0x35, 0x65, 0x9a, 0x5d,
+	0xb0, 0x2a, 0x7b, 0x3e, 0x0e, 0x06, 0xea, 0x14, 0xe5, 0xed, 0xe7, 0xff, 0xcc, 0x4f, 0x7d, 0x75,
+	0x92, 0xd7, 0x9e, 0x9f, 0xe4, 0xb5, 0xaf, 0x4f, 0xf2, 0xda, 0x3f, 0x4e, 0xf2, 0xda, 0xaf, 0x5f,
+	0xe4, 0xa7, 0xbe, 0x7e, 0x91, 0x9f, 0xfa, 0xfb, 0x8b, 0xfc, 0xd4, 0x8f, 0x6f, 0x8f, 0x4d, 0xe1,
+	0x0a, 0x65, 0xdd, 0x27, 0xf1, 0x3f, 0xd4, 0xdc, 0x8d, 0xbe, 0xfc, 0x55, 0x93, 0xb8, 0x35, 0x23,
+	0x5f, 0xe6, 0xff, 0xff, 0xbf, 0x01, 0x00, 0x38, 0x0c, 0x63, 0x00, 0x76, 0x13, 0x00, 0x00,
 }
 
 func (this *AccessTypeParam) Equal(that interface{}) bool {
@@ -613,6 +1168,37 @@ func (this *Params) Equal(that interface{}) bool {
 	if this.MaxWasmCodeSize != that1.MaxWasmCodeSize {
 		return false
 	}
+	if this.IBCPacketGasLimit != that1.IBCPacketGasLimit {
+		return false
+	}
+	if this.ExecuteAllowlistEnabled != that1.ExecuteAllowlistEnabled {
+		return false
+	}
+	if len(this.ExecuteAllowlist) != len(that1.ExecuteAllowlist) {
+		return false
+	}
+	for i := range this.ExecuteAllowlist {
+		if this.ExecuteAllowlist[i] != that1.ExecuteAllowlist[i] {
+			return false
+		}
+	}
+	if this.MaxContractGas != that1.MaxContractGas {
+		return false
+	}
+	if this.MaxResultDataSize != that1.MaxResultDataSize {
+		return false
+	}
+	if len(this.StoreCodeDeposit) != len(that1.StoreCodeDeposit) {
+		return false
+	}
+	for i := range this.StoreCodeDeposit {
+		if !this.StoreCodeDeposit[i].Equal(&that1.StoreCodeDeposit[i]) {
+			return false
+		}
+	}
+	if this.StoreCodeDepositRefundBlocks != that1.StoreCodeDepositRefundBlocks {
+		return false
+	}
 	return true
 }
 func (this *CodeInfo) Equal(that interface{}) bool {
@@ -649,6 +1235,14 @@ func (this *CodeInfo) Equal(that interface{}) bool {
 	if !this.InstantiateConfig.Equal(&that1.InstantiateConfig) {
 		return false
 	}
+	if len(this.RequiredFeatures) != len(that1.RequiredFeatures) {
+		return false
+	}
+	for i := range this.RequiredFeatures {
+		if this.RequiredFeatures[i] != that1.RequiredFeatures[i] {
+			return false
+		}
+	}
 	return true
 }
 func (this *ContractInfo) Equal(that interface{}) bool {
@@ -691,6 +1285,9 @@ func (this *ContractInfo) Equal(that interface{}) bool {
 	if !this.Extension.Equal(that1.Extension) {
 		return false
 	}
+	if this.ReentrancyGuard != that1.ReentrancyGuard {
+		return false
+	}
 	return true
 }
 func (this *ContractCodeHistoryEntry) Equal(that interface{}) bool {
@@ -724,6 +1321,9 @@ func (this *ContractCodeHistoryEntry) Equal(that interface{}) bool {
 	if !bytes.Equal(this.Msg, that1.Msg) {
 		return false
 	}
+	if this.MsgEncoding != that1.MsgEncoding {
+		return false
+	}
 	return true
 }
 func (this *AbsoluteTxPosition) Equal(that interface{}) bool {
@@ -780,306 +1380,314 @@ func (this *Model) Equal(that interface{}) bool {
 	}
 	return true
 }
-func (m *AccessTypeParam) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *PacketReply) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
-
-func (m *AccessTypeParam) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
 
-func (m *AccessTypeParam) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Value != 0 {
-		i = encodeVarintTypes(dAtA, i, uint64(m.Value))
-		i--
-		dAtA[i] = 0x8
-	}
-	return len(dAtA) - i, nil
-}
-
-func (m *AccessConfig) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
-}
-
-func (m *AccessConfig) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *AccessConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Address) > 0 {
-		i -= len(m.Address)
-		copy(dAtA[i:], m.Address)
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Address)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if m.Permission != 0 {
-		i = encodeVarintTypes(dAtA, i, uint64(m.Permission))
-		i--
-		dAtA[i] = 0x8
+	that1, ok := that.(*PacketReply)
+	if !ok {
+		that2, ok := that.(PacketReply)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *Params) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return dAtA[:n], nil
-}
-
-func (m *Params) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *Params) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.MaxWasmCodeSize != 0 {
-		i = encodeVarintTypes(dAtA, i, uint64(m.MaxWasmCodeSize))
-		i--
-		dAtA[i] = 0x18
+	if this.ContractAddress != that1.ContractAddress {
+		return false
 	}
-	if m.InstantiateDefaultPermission != 0 {
-		i = encodeVarintTypes(dAtA, i, uint64(m.InstantiateDefaultPermission))
-		i--
-		dAtA[i] = 0x10
+	if this.ReplyID != that1.ReplyID {
+		return false
 	}
-	{
-		size, err := m.CodeUploadAccess.MarshalToSizedBuffer(dAtA[:i])
-		if err != nil {
-			return 0, err
-		}
-		i -= size
-		i = encodeVarintTypes(dAtA, i, uint64(size))
+	if this.ReplyOn != that1.ReplyOn {
+		return false
 	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
+	return true
 }
-
-func (m *CodeInfo) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *CodeExecutionStats) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
-
-func (m *CodeInfo) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
 
-func (m *CodeInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	{
-		size, err := m.InstantiateConfig.MarshalToSizedBuffer(dAtA[:i])
-		if err != nil {
-			return 0, err
+	that1, ok := that.(*CodeExecutionStats)
+	if !ok {
+		that2, ok := that.(CodeExecutionStats)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
-		i -= size
-		i = encodeVarintTypes(dAtA, i, uint64(size))
 	}
-	i--
-	dAtA[i] = 0x2a
-	if len(m.Builder) > 0 {
-		i -= len(m.Builder)
-		copy(dAtA[i:], m.Builder)
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Builder)))
-		i--
-		dAtA[i] = 0x22
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if len(m.Source) > 0 {
-		i -= len(m.Source)
-		copy(dAtA[i:], m.Source)
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Source)))
-		i--
-		dAtA[i] = 0x1a
+	if this.Epoch != that1.Epoch {
+		return false
 	}
-	if len(m.Creator) > 0 {
-		i -= len(m.Creator)
-		copy(dAtA[i:], m.Creator)
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Creator)))
-		i--
-		dAtA[i] = 0x12
+	if this.Executions != that1.Executions {
+		return false
 	}
-	if len(m.CodeHash) > 0 {
-		i -= len(m.CodeHash)
-		copy(dAtA[i:], m.CodeHash)
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.CodeHash)))
-		i--
-		dAtA[i] = 0xa
+	if this.GasUsed != that1.GasUsed {
+		return false
 	}
-	return len(dAtA) - i, nil
+	return true
 }
-
-func (m *ContractInfo) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *StoreCodeDeposit) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
 
-func (m *ContractInfo) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *ContractInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Extension != nil {
-		{
-			size, err := m.Extension.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintTypes(dAtA, i, uint64(size))
+	that1, ok := that.(*StoreCodeDeposit)
+	if !ok {
+		that2, ok := that.(StoreCodeDeposit)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
-		i--
-		dAtA[i] = 0x3a
 	}
-	if len(m.IBCPortID) > 0 {
-		i -= len(m.IBCPortID)
-		copy(dAtA[i:], m.IBCPortID)
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.IBCPortID)))
-		i--
-		dAtA[i] = 0x32
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.Created != nil {
-		{
-			size, err := m.Created.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintTypes(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x2a
+	if this.CodeID != that1.CodeID {
+		return false
 	}
-	if len(m.Label) > 0 {
-		i -= len(m.Label)
-		copy(dAtA[i:], m.Label)
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Label)))
-		i--
-		dAtA[i] = 0x22
+	if this.Depositor != that1.Depositor {
+		return false
 	}
-	if len(m.Admin) > 0 {
-		i -= len(m.Admin)
-		copy(dAtA[i:], m.Admin)
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Admin)))
-		i--
-		dAtA[i] = 0x1a
+	if len(this.Amount) != len(that1.Amount) {
+		return false
 	}
-	if len(m.Creator) > 0 {
-		i -= len(m.Creator)
-		copy(dAtA[i:], m.Creator)
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Creator)))
-		i--
-		dAtA[i] = 0x12
+	for i := range this.Amount {
+		if !this.Amount[i].Equal(&that1.Amount[i]) {
+			return false
+		}
 	}
-	if m.CodeID != 0 {
-		i = encodeVarintTypes(dAtA, i, uint64(m.CodeID))
-		i--
-		dAtA[i] = 0x8
+	if this.RefundHeight != that1.RefundHeight {
+		return false
 	}
-	return len(dAtA) - i, nil
+	return true
 }
-
-func (m *ContractCodeHistoryEntry) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *InterchainQueryKey) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
 
-func (m *ContractCodeHistoryEntry) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	that1, ok := that.(*InterchainQueryKey)
+	if !ok {
+		that2, ok := that.(InterchainQueryKey)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Path != that1.Path {
+		return false
+	}
+	if !bytes.Equal(this.Key, that1.Key) {
+		return false
+	}
+	return true
 }
+func (this *InterchainQuery) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *ContractCodeHistoryEntry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Msg) > 0 {
-		i -= len(m.Msg)
-		copy(dAtA[i:], m.Msg)
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Msg)))
-		i--
-		dAtA[i] = 0x22
+	that1, ok := that.(*InterchainQuery)
+	if !ok {
+		that2, ok := that.(InterchainQuery)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if m.Updated != nil {
-		{
-			size, err := m.Updated.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintTypes(dAtA, i, uint64(size))
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.ID != that1.ID {
+		return false
+	}
+	if this.Owner != that1.Owner {
+		return false
+	}
+	if this.ConnectionID != that1.ConnectionID {
+		return false
+	}
+	if len(this.Keys) != len(that1.Keys) {
+		return false
+	}
+	for i := range this.Keys {
+		if !this.Keys[i].Equal(&that1.Keys[i]) {
+			return false
 		}
-		i--
-		dAtA[i] = 0x1a
 	}
-	if m.CodeID != 0 {
-		i = encodeVarintTypes(dAtA, i, uint64(m.CodeID))
-		i--
-		dAtA[i] = 0x10
+	if this.UpdatePeriod != that1.UpdatePeriod {
+		return false
 	}
-	if m.Operation != 0 {
-		i = encodeVarintTypes(dAtA, i, uint64(m.Operation))
-		i--
-		dAtA[i] = 0x8
+	if this.LastSubmittedHeight != that1.LastSubmittedHeight {
+		return false
 	}
-	return len(dAtA) - i, nil
+	if this.LastRemoteRevisionNumber != that1.LastRemoteRevisionNumber {
+		return false
+	}
+	if this.LastRemoteRevisionHeight != that1.LastRemoteRevisionHeight {
+		return false
+	}
+	return true
 }
+func (this *InterchainQueryResult) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *AbsoluteTxPosition) Marshal() (dAtA []byte, err error) {
+	that1, ok := that.(*InterchainQueryResult)
+	if !ok {
+		that2, ok := that.(InterchainQueryResult)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !bytes.Equal(this.Value, that1.Value) {
+		return false
+	}
+	if !bytes.Equal(this.Proof, that1.Proof) {
+		return false
+	}
+	return true
+}
+func (this *Job) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Job)
+	if !ok {
+		that2, ok := that.(Job)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.ID != that1.ID {
+		return false
+	}
+	if this.Type != that1.Type {
+		return false
+	}
+	if !bytes.Equal(this.Payload, that1.Payload) {
+		return false
+	}
+	if this.StepsPerBlock != that1.StepsPerBlock {
+		return false
+	}
+	if this.CreatedHeight != that1.CreatedHeight {
+		return false
+	}
+	return true
+}
+func (this *BulkMigration) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*BulkMigration)
+	if !ok {
+		that2, ok := that.(BulkMigration)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.CodeID != that1.CodeID {
+		return false
+	}
+	if this.NewCodeID != that1.NewCodeID {
+		return false
+	}
+	if this.RunAs != that1.RunAs {
+		return false
+	}
+	if !bytes.Equal(this.MigrateMsg, that1.MigrateMsg) {
+		return false
+	}
+	if !bytes.Equal(this.NextKey, that1.NextKey) {
+		return false
+	}
+	if this.Migrated != that1.Migrated {
+		return false
+	}
+	if this.Failed != that1.Failed {
+		return false
+	}
+	return true
+}
+func (this *CodeStatsPruning) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*CodeStatsPruning)
+	if !ok {
+		that2, ok := that.(CodeStatsPruning)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.BeforeEpoch != that1.BeforeEpoch {
+		return false
+	}
+	if !bytes.Equal(this.NextKey, that1.NextKey) {
+		return false
+	}
+	return true
+}
+func (m *AccessTypeParam) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -1089,30 +1697,25 @@ func (m *AbsoluteTxPosition) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *AbsoluteTxPosition) MarshalTo(dAtA []byte) (int, error) {
+func (m *AccessTypeParam) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *AbsoluteTxPosition) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *AccessTypeParam) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.TxIndex != 0 {
-		i = encodeVarintTypes(dAtA, i, uint64(m.TxIndex))
-		i--
-		dAtA[i] = 0x10
-	}
-	if m.BlockHeight != 0 {
-		i = encodeVarintTypes(dAtA, i, uint64(m.BlockHeight))
+	if m.Value != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Value))
 		i--
 		dAtA[i] = 0x8
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *Model) Marshal() (dAtA []byte, err error) {
+func (m *AccessConfig) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -1122,214 +1725,2782 @@ func (m *Model) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Model) MarshalTo(dAtA []byte) (int, error) {
+func (m *AccessConfig) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Model) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *AccessConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Value) > 0 {
-		i -= len(m.Value)
-		copy(dAtA[i:], m.Value)
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Value)))
+	if len(m.Address) > 0 {
+		i -= len(m.Address)
+		copy(dAtA[i:], m.Address)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Address)))
 		i--
 		dAtA[i] = 0x12
 	}
-	if len(m.Key) > 0 {
-		i -= len(m.Key)
-		copy(dAtA[i:], m.Key)
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Key)))
+	if m.Permission != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Permission))
 		i--
-		dAtA[i] = 0xa
+		dAtA[i] = 0x8
 	}
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
-	offset -= sovTypes(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *Params) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return dAtA[:n], nil
 }
-func (m *AccessTypeParam) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Value != 0 {
-		n += 1 + sovTypes(uint64(m.Value))
-	}
-	return n
+
+func (m *Params) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *AccessConfig) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *Params) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Permission != 0 {
-		n += 1 + sovTypes(uint64(m.Permission))
+	if m.StoreCodeDepositRefundBlocks != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.StoreCodeDepositRefundBlocks))
+		i--
+		dAtA[i] = 0x50
+	}
+	if len(m.StoreCodeDeposit) > 0 {
+		for iNdEx := len(m.StoreCodeDeposit) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.StoreCodeDeposit[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x4a
+		}
 	}
-	l = len(m.Address)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	if m.MaxResultDataSize != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.MaxResultDataSize))
+		i--
+		dAtA[i] = 0x40
 	}
-	return n
-}
-
-func (m *Params) Size() (n int) {
-	if m == nil {
-		return 0
+	if m.MaxContractGas != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.MaxContractGas))
+		i--
+		dAtA[i] = 0x38
+	}
+	if len(m.ExecuteAllowlist) > 0 {
+		for iNdEx := len(m.ExecuteAllowlist) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ExecuteAllowlist[iNdEx])
+			copy(dAtA[i:], m.ExecuteAllowlist[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.ExecuteAllowlist[iNdEx])))
+			i--
+			dAtA[i] = 0x32
+		}
 	}
-	var l int
-	_ = l
-	l = m.CodeUploadAccess.Size()
-	n += 1 + l + sovTypes(uint64(l))
-	if m.InstantiateDefaultPermission != 0 {
-		n += 1 + sovTypes(uint64(m.InstantiateDefaultPermission))
+	if m.ExecuteAllowlistEnabled {
+		i--
+		if m.ExecuteAllowlistEnabled {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.IBCPacketGasLimit != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.IBCPacketGasLimit))
+		i--
+		dAtA[i] = 0x20
 	}
 	if m.MaxWasmCodeSize != 0 {
-		n += 1 + sovTypes(uint64(m.MaxWasmCodeSize))
+		i = encodeVarintTypes(dAtA, i, uint64(m.MaxWasmCodeSize))
+		i--
+		dAtA[i] = 0x18
 	}
-	return n
+	if m.InstantiateDefaultPermission != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.InstantiateDefaultPermission))
+		i--
+		dAtA[i] = 0x10
+	}
+	{
+		size, err := m.CodeUploadAccess.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
 }
 
-func (m *CodeInfo) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *CodeInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *CodeInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CodeInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.CodeHash)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	if len(m.RequiredFeatures) > 0 {
+		for iNdEx := len(m.RequiredFeatures) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.RequiredFeatures[iNdEx])
+			copy(dAtA[i:], m.RequiredFeatures[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.RequiredFeatures[iNdEx])))
+			i--
+			dAtA[i] = 0x32
+		}
 	}
-	l = len(m.Creator)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	{
+		size, err := m.InstantiateConfig.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
 	}
-	l = len(m.Source)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	i--
+	dAtA[i] = 0x2a
+	if len(m.Builder) > 0 {
+		i -= len(m.Builder)
+		copy(dAtA[i:], m.Builder)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Builder)))
+		i--
+		dAtA[i] = 0x22
 	}
-	l = len(m.Builder)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	if len(m.Source) > 0 {
+		i -= len(m.Source)
+		copy(dAtA[i:], m.Source)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Source)))
+		i--
+		dAtA[i] = 0x1a
 	}
-	l = m.InstantiateConfig.Size()
-	n += 1 + l + sovTypes(uint64(l))
-	return n
+	if len(m.Creator) > 0 {
+		i -= len(m.Creator)
+		copy(dAtA[i:], m.Creator)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Creator)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.CodeHash) > 0 {
+		i -= len(m.CodeHash)
+		copy(dAtA[i:], m.CodeHash)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.CodeHash)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *ContractInfo) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ContractInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ContractInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ContractInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.CodeID != 0 {
-		n += 1 + sovTypes(uint64(m.CodeID))
-	}
-	l = len(m.Creator)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	if m.ReentrancyGuard {
+		i--
+		if m.ReentrancyGuard {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x40
 	}
-	l = len(m.Admin)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	if m.Extension != nil {
+		{
+			size, err := m.Extension.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
 	}
-	l = len(m.Label)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	if len(m.IBCPortID) > 0 {
+		i -= len(m.IBCPortID)
+		copy(dAtA[i:], m.IBCPortID)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.IBCPortID)))
+		i--
+		dAtA[i] = 0x32
 	}
 	if m.Created != nil {
-		l = m.Created.Size()
-		n += 1 + l + sovTypes(uint64(l))
+		{
+			size, err := m.Created.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
 	}
-	l = len(m.IBCPortID)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	if len(m.Label) > 0 {
+		i -= len(m.Label)
+		copy(dAtA[i:], m.Label)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Label)))
+		i--
+		dAtA[i] = 0x22
 	}
-	if m.Extension != nil {
-		l = m.Extension.Size()
-		n += 1 + l + sovTypes(uint64(l))
+	if len(m.Admin) > 0 {
+		i -= len(m.Admin)
+		copy(dAtA[i:], m.Admin)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Admin)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Creator) > 0 {
+		i -= len(m.Creator)
+		copy(dAtA[i:], m.Creator)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Creator)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.CodeID != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.CodeID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ContractCodeHistoryEntry) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ContractCodeHistoryEntry) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ContractCodeHistoryEntry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MsgEncoding != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.MsgEncoding))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Msg) > 0 {
+		i -= len(m.Msg)
+		copy(dAtA[i:], m.Msg)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Msg)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Updated != nil {
+		{
+			size, err := m.Updated.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.CodeID != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.CodeID))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Operation != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Operation))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AbsoluteTxPosition) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AbsoluteTxPosition) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AbsoluteTxPosition) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.TxIndex != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.TxIndex))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.BlockHeight != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.BlockHeight))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Model) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Model) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Model) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PacketReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PacketReply) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PacketReply) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ReplyOn) > 0 {
+		i -= len(m.ReplyOn)
+		copy(dAtA[i:], m.ReplyOn)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.ReplyOn)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.ReplyID != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.ReplyID))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.ContractAddress) > 0 {
+		i -= len(m.ContractAddress)
+		copy(dAtA[i:], m.ContractAddress)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.ContractAddress)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CodeExecutionStats) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CodeExecutionStats) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CodeExecutionStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.GasUsed != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.GasUsed))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Executions != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Executions))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Epoch != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Epoch))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StoreCodeDeposit) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StoreCodeDeposit) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StoreCodeDeposit) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.RefundHeight != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.RefundHeight))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Amount) > 0 {
+		for iNdEx := len(m.Amount) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Amount[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Depositor) > 0 {
+		i -= len(m.Depositor)
+		copy(dAtA[i:], m.Depositor)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Depositor)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.CodeID != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.CodeID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *InterchainQueryKey) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *InterchainQueryKey) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *InterchainQueryKey) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Path) > 0 {
+		i -= len(m.Path)
+		copy(dAtA[i:], m.Path)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Path)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *InterchainQuery) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *InterchainQuery) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *InterchainQuery) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.LastRemoteRevisionHeight != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.LastRemoteRevisionHeight))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.LastRemoteRevisionNumber != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.LastRemoteRevisionNumber))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.LastSubmittedHeight != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.LastSubmittedHeight))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.UpdatePeriod != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.UpdatePeriod))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Keys) > 0 {
+		for iNdEx := len(m.Keys) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Keys[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.ConnectionID) > 0 {
+		i -= len(m.ConnectionID)
+		copy(dAtA[i:], m.ConnectionID)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.ConnectionID)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Owner) > 0 {
+		i -= len(m.Owner)
+		copy(dAtA[i:], m.Owner)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Owner)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ID != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.ID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *InterchainQueryResult) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *InterchainQueryResult) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *InterchainQueryResult) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Proof) > 0 {
+		i -= len(m.Proof)
+		copy(dAtA[i:], m.Proof)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Proof)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Job) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Job) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Job) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.CreatedHeight != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.CreatedHeight))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.StepsPerBlock != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.StepsPerBlock))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Payload) > 0 {
+		i -= len(m.Payload)
+		copy(dAtA[i:], m.Payload)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Payload)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Type) > 0 {
+		i -= len(m.Type)
+		copy(dAtA[i:], m.Type)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Type)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ID != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.ID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BulkMigration) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BulkMigration) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BulkMigration) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Failed != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Failed))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.Migrated != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Migrated))
+		i--
+		dAtA[i] = 0x30
+	}
+	if len(m.NextKey) > 0 {
+		i -= len(m.NextKey)
+		copy(dAtA[i:], m.NextKey)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.NextKey)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.MigrateMsg) > 0 {
+		i -= len(m.MigrateMsg)
+		copy(dAtA[i:], m.MigrateMsg)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.MigrateMsg)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.RunAs) > 0 {
+		i -= len(m.RunAs)
+		copy(dAtA[i:], m.RunAs)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.RunAs)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.NewCodeID != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.NewCodeID))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.CodeID != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.CodeID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CodeStatsPruning) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CodeStatsPruning) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CodeStatsPruning) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.NextKey) > 0 {
+		i -= len(m.NextKey)
+		copy(dAtA[i:], m.NextKey)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.NextKey)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.BeforeEpoch != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.BeforeEpoch))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
+	offset -= sovTypes(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *AccessTypeParam) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Value != 0 {
+		n += 1 + sovTypes(uint64(m.Value))
+	}
+	return n
+}
+
+func (m *AccessConfig) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Permission != 0 {
+		n += 1 + sovTypes(uint64(m.Permission))
+	}
+	l = len(m.Address)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *Params) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.CodeUploadAccess.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	if m.InstantiateDefaultPermission != 0 {
+		n += 1 + sovTypes(uint64(m.InstantiateDefaultPermission))
+	}
+	if m.MaxWasmCodeSize != 0 {
+		n += 1 + sovTypes(uint64(m.MaxWasmCodeSize))
+	}
+	if m.IBCPacketGasLimit != 0 {
+		n += 1 + sovTypes(uint64(m.IBCPacketGasLimit))
+	}
+	if m.ExecuteAllowlistEnabled {
+		n += 2
+	}
+	if len(m.ExecuteAllowlist) > 0 {
+		for _, s := range m.ExecuteAllowlist {
+			l = len(s)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if m.MaxContractGas != 0 {
+		n += 1 + sovTypes(uint64(m.MaxContractGas))
+	}
+	if m.MaxResultDataSize != 0 {
+		n += 1 + sovTypes(uint64(m.MaxResultDataSize))
+	}
+	if len(m.StoreCodeDeposit) > 0 {
+		for _, e := range m.StoreCodeDeposit {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if m.StoreCodeDepositRefundBlocks != 0 {
+		n += 1 + sovTypes(uint64(m.StoreCodeDepositRefundBlocks))
+	}
+	return n
+}
+
+func (m *CodeInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.CodeHash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Creator)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Source)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Builder)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = m.InstantiateConfig.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	if len(m.RequiredFeatures) > 0 {
+		for _, s := range m.RequiredFeatures {
+			l = len(s)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ContractInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CodeID != 0 {
+		n += 1 + sovTypes(uint64(m.CodeID))
+	}
+	l = len(m.Creator)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Admin)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Label)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Created != nil {
+		l = m.Created.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.IBCPortID)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Extension != nil {
+		l = m.Extension.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.ReentrancyGuard {
+		n += 2
+	}
+	return n
+}
+
+func (m *ContractCodeHistoryEntry) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Operation != 0 {
+		n += 1 + sovTypes(uint64(m.Operation))
+	}
+	if m.CodeID != 0 {
+		n += 1 + sovTypes(uint64(m.CodeID))
+	}
+	if m.Updated != nil {
+		l = m.Updated.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Msg)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.MsgEncoding != 0 {
+		n += 1 + sovTypes(uint64(m.MsgEncoding))
+	}
+	return n
+}
+
+func (m *AbsoluteTxPosition) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.BlockHeight != 0 {
+		n += 1 + sovTypes(uint64(m.BlockHeight))
+	}
+	if m.TxIndex != 0 {
+		n += 1 + sovTypes(uint64(m.TxIndex))
+	}
+	return n
+}
+
+func (m *Model) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *PacketReply) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ContractAddress)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.ReplyID != 0 {
+		n += 1 + sovTypes(uint64(m.ReplyID))
+	}
+	l = len(m.ReplyOn)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *CodeExecutionStats) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Epoch != 0 {
+		n += 1 + sovTypes(uint64(m.Epoch))
+	}
+	if m.Executions != 0 {
+		n += 1 + sovTypes(uint64(m.Executions))
+	}
+	if m.GasUsed != 0 {
+		n += 1 + sovTypes(uint64(m.GasUsed))
+	}
+	return n
+}
+
+func (m *StoreCodeDeposit) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CodeID != 0 {
+		n += 1 + sovTypes(uint64(m.CodeID))
+	}
+	l = len(m.Depositor)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if len(m.Amount) > 0 {
+		for _, e := range m.Amount {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if m.RefundHeight != 0 {
+		n += 1 + sovTypes(uint64(m.RefundHeight))
+	}
+	return n
+}
+
+func (m *InterchainQueryKey) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *InterchainQuery) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ID != 0 {
+		n += 1 + sovTypes(uint64(m.ID))
+	}
+	l = len(m.Owner)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.ConnectionID)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if len(m.Keys) > 0 {
+		for _, e := range m.Keys {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if m.UpdatePeriod != 0 {
+		n += 1 + sovTypes(uint64(m.UpdatePeriod))
+	}
+	if m.LastSubmittedHeight != 0 {
+		n += 1 + sovTypes(uint64(m.LastSubmittedHeight))
+	}
+	if m.LastRemoteRevisionNumber != 0 {
+		n += 1 + sovTypes(uint64(m.LastRemoteRevisionNumber))
+	}
+	if m.LastRemoteRevisionHeight != 0 {
+		n += 1 + sovTypes(uint64(m.LastRemoteRevisionHeight))
+	}
+	return n
+}
+
+func (m *InterchainQueryResult) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Proof)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *Job) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ID != 0 {
+		n += 1 + sovTypes(uint64(m.ID))
+	}
+	l = len(m.Type)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Payload)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.StepsPerBlock != 0 {
+		n += 1 + sovTypes(uint64(m.StepsPerBlock))
+	}
+	if m.CreatedHeight != 0 {
+		n += 1 + sovTypes(uint64(m.CreatedHeight))
+	}
+	return n
+}
+
+func (m *BulkMigration) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CodeID != 0 {
+		n += 1 + sovTypes(uint64(m.CodeID))
+	}
+	if m.NewCodeID != 0 {
+		n += 1 + sovTypes(uint64(m.NewCodeID))
+	}
+	l = len(m.RunAs)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.MigrateMsg)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.NextKey)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Migrated != 0 {
+		n += 1 + sovTypes(uint64(m.Migrated))
+	}
+	if m.Failed != 0 {
+		n += 1 + sovTypes(uint64(m.Failed))
+	}
+	return n
+}
+
+func (m *CodeStatsPruning) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.BeforeEpoch != 0 {
+		n += 1 + sovTypes(uint64(m.BeforeEpoch))
+	}
+	l = len(m.NextKey)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func sovTypes(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozTypes(x uint64) (n int) {
+	return sovTypes(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *AccessTypeParam) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AccessTypeParam: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AccessTypeParam: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			m.Value = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Value |= AccessType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AccessConfig) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AccessConfig: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AccessConfig: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Permission", wireType)
+			}
+			m.Permission = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Permission |= AccessType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Address = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Params) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Params: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Params: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CodeUploadAccess", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.CodeUploadAccess.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InstantiateDefaultPermission", wireType)
+			}
+			m.InstantiateDefaultPermission = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.InstantiateDefaultPermission |= AccessType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxWasmCodeSize", wireType)
+			}
+			m.MaxWasmCodeSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxWasmCodeSize |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IBCPacketGasLimit", wireType)
+			}
+			m.IBCPacketGasLimit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.IBCPacketGasLimit |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExecuteAllowlistEnabled", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ExecuteAllowlistEnabled = bool(v != 0)
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExecuteAllowlist", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ExecuteAllowlist = append(m.ExecuteAllowlist, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxContractGas", wireType)
+			}
+			m.MaxContractGas = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxContractGas |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxResultDataSize", wireType)
+			}
+			m.MaxResultDataSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxResultDataSize |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StoreCodeDeposit", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.StoreCodeDeposit = append(m.StoreCodeDeposit, types.Coin{})
+			if err := m.StoreCodeDeposit[len(m.StoreCodeDeposit)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StoreCodeDepositRefundBlocks", wireType)
+			}
+			m.StoreCodeDepositRefundBlocks = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StoreCodeDepositRefundBlocks |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CodeInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CodeInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CodeInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CodeHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CodeHash = append(m.CodeHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.CodeHash == nil {
+				m.CodeHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Creator", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Creator = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Source", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Source = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Builder", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Builder = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InstantiateConfig", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.InstantiateConfig.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequiredFeatures", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RequiredFeatures = append(m.RequiredFeatures, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ContractInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ContractInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ContractInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CodeID", wireType)
+			}
+			m.CodeID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CodeID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Creator", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Creator = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Admin", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Admin = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Label", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Label = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Created", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Created == nil {
+				m.Created = &AbsoluteTxPosition{}
+			}
+			if err := m.Created.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IBCPortID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IBCPortID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Extension", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Extension == nil {
+				m.Extension = &types1.Any{}
+			}
+			if err := m.Extension.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReentrancyGuard", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ReentrancyGuard = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ContractCodeHistoryEntry) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ContractCodeHistoryEntry: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ContractCodeHistoryEntry: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Operation", wireType)
+			}
+			m.Operation = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Operation |= ContractCodeHistoryOperationType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CodeID", wireType)
+			}
+			m.CodeID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CodeID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Updated", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Updated == nil {
+				m.Updated = &AbsoluteTxPosition{}
+			}
+			if err := m.Updated.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Msg", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Msg = append(m.Msg[:0], dAtA[iNdEx:postIndex]...)
+			if m.Msg == nil {
+				m.Msg = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MsgEncoding", wireType)
+			}
+			m.MsgEncoding = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MsgEncoding |= MessageEncoding(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AbsoluteTxPosition) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AbsoluteTxPosition: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AbsoluteTxPosition: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockHeight", wireType)
+			}
+			m.BlockHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BlockHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TxIndex", wireType)
+			}
+			m.TxIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TxIndex |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Model) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Model: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Model: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
 	}
-	return n
-}
 
-func (m *ContractCodeHistoryEntry) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Operation != 0 {
-		n += 1 + sovTypes(uint64(m.Operation))
-	}
-	if m.CodeID != 0 {
-		n += 1 + sovTypes(uint64(m.CodeID))
-	}
-	if m.Updated != nil {
-		l = m.Updated.Size()
-		n += 1 + l + sovTypes(uint64(l))
-	}
-	l = len(m.Msg)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
+	return nil
 }
-
-func (m *AbsoluteTxPosition) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.BlockHeight != 0 {
-		n += 1 + sovTypes(uint64(m.BlockHeight))
-	}
-	if m.TxIndex != 0 {
-		n += 1 + sovTypes(uint64(m.TxIndex))
+func (m *PacketReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PacketReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PacketReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContractAddress", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContractAddress = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReplyID", wireType)
+			}
+			m.ReplyID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ReplyID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReplyOn", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ReplyOn = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
 	}
-	return n
-}
 
-func (m *Model) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Key)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
-	}
-	l = len(m.Value)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
-}
-
-func sovTypes(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozTypes(x uint64) (n int) {
-	return sovTypes(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	return nil
 }
-func (m *AccessTypeParam) Unmarshal(dAtA []byte) error {
+func (m *CodeExecutionStats) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1352,17 +4523,17 @@ func (m *AccessTypeParam) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AccessTypeParam: wiretype end group for non-group")
+			return fmt.Errorf("proto: CodeExecutionStats: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AccessTypeParam: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CodeExecutionStats: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Epoch", wireType)
 			}
-			m.Value = 0
+			m.Epoch = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -1372,7 +4543,45 @@ func (m *AccessTypeParam) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Value |= AccessType(b&0x7F) << shift
+				m.Epoch |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Executions", wireType)
+			}
+			m.Executions = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Executions |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GasUsed", wireType)
+			}
+			m.GasUsed = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GasUsed |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -1398,7 +4607,7 @@ func (m *AccessTypeParam) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AccessConfig) Unmarshal(dAtA []byte) error {
+func (m *StoreCodeDeposit) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1421,17 +4630,36 @@ func (m *AccessConfig) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AccessConfig: wiretype end group for non-group")
+			return fmt.Errorf("proto: StoreCodeDeposit: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AccessConfig: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: StoreCodeDeposit: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Permission", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CodeID", wireType)
+			}
+			m.CodeID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CodeID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Depositor", wireType)
 			}
-			m.Permission = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -1441,16 +4669,29 @@ func (m *AccessConfig) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Permission |= AccessType(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Depositor = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Amount", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -1460,24 +4701,45 @@ func (m *AccessConfig) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Address = string(dAtA[iNdEx:postIndex])
+			m.Amount = append(m.Amount, types.Coin{})
+			if err := m.Amount[len(m.Amount)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RefundHeight", wireType)
+			}
+			m.RefundHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RefundHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -1499,7 +4761,7 @@ func (m *AccessConfig) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Params) Unmarshal(dAtA []byte) error {
+func (m *InterchainQueryKey) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1522,17 +4784,17 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Params: wiretype end group for non-group")
+			return fmt.Errorf("proto: InterchainQueryKey: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Params: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: InterchainQueryKey: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CodeUploadAccess", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -1542,30 +4804,29 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.CodeUploadAccess.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Path = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InstantiateDefaultPermission", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
 			}
-			m.InstantiateDefaultPermission = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -1575,30 +4836,26 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.InstantiateDefaultPermission |= AccessType(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MaxWasmCodeSize", wireType)
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
 			}
-			m.MaxWasmCodeSize = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.MaxWasmCodeSize |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
 			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -1620,7 +4877,7 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CodeInfo) Unmarshal(dAtA []byte) error {
+func (m *InterchainQuery) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1643,17 +4900,17 @@ func (m *CodeInfo) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CodeInfo: wiretype end group for non-group")
+			return fmt.Errorf("proto: InterchainQuery: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CodeInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: InterchainQuery: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CodeHash", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
 			}
-			var byteLen int
+			m.ID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -1663,29 +4920,14 @@ func (m *CodeInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				m.ID |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.CodeHash = append(m.CodeHash[:0], dAtA[iNdEx:postIndex]...)
-			if m.CodeHash == nil {
-				m.CodeHash = []byte{}
-			}
-			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Creator", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Owner", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1713,11 +4955,11 @@ func (m *CodeInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Creator = string(dAtA[iNdEx:postIndex])
+			m.Owner = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Source", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ConnectionID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1745,13 +4987,13 @@ func (m *CodeInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Source = string(dAtA[iNdEx:postIndex])
+			m.ConnectionID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Builder", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Keys", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -1761,29 +5003,31 @@ func (m *CodeInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Builder = string(dAtA[iNdEx:postIndex])
+			m.Keys = append(m.Keys, InterchainQueryKey{})
+			if err := m.Keys[len(m.Keys)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InstantiateConfig", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UpdatePeriod", wireType)
 			}
-			var msglen int
+			m.UpdatePeriod = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -1793,25 +5037,68 @@ func (m *CodeInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.UpdatePeriod |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastSubmittedHeight", wireType)
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
+			m.LastSubmittedHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastSubmittedHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastRemoteRevisionNumber", wireType)
 			}
-			if err := m.InstantiateConfig.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.LastRemoteRevisionNumber = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastRemoteRevisionNumber |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastRemoteRevisionHeight", wireType)
+			}
+			m.LastRemoteRevisionHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastRemoteRevisionHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -1833,7 +5120,7 @@ func (m *CodeInfo) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ContractInfo) Unmarshal(dAtA []byte) error {
+func (m *InterchainQueryResult) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1856,17 +5143,17 @@ func (m *ContractInfo) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ContractInfo: wiretype end group for non-group")
+			return fmt.Errorf("proto: InterchainQueryResult: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ContractInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: InterchainQueryResult: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CodeID", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
 			}
-			m.CodeID = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -1876,16 +5163,31 @@ func (m *ContractInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.CodeID |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
+			}
+			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Creator", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Proof", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -1895,29 +5197,81 @@ func (m *ContractInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Proof = append(m.Proof[:0], dAtA[iNdEx:postIndex]...)
+			if m.Proof == nil {
+				m.Proof = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Job) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
 			}
-			if postIndex > l {
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Creator = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Admin", wireType)
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			var stringLen uint64
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Job: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Job: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -1927,27 +5281,14 @@ func (m *ContractInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.ID |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Admin = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 4:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Label", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1975,13 +5316,13 @@ func (m *ContractInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Label = string(dAtA[iNdEx:postIndex])
+			m.Type = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 5:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Created", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -1991,33 +5332,31 @@ func (m *ContractInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Created == nil {
-				m.Created = &AbsoluteTxPosition{}
-			}
-			if err := m.Created.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
+			if m.Payload == nil {
+				m.Payload = []byte{}
 			}
 			iNdEx = postIndex
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IBCPortID", wireType)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StepsPerBlock", wireType)
 			}
-			var stringLen uint64
+			m.StepsPerBlock = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2027,29 +5366,16 @@ func (m *ContractInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.StepsPerBlock |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.IBCPortID = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 7:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Extension", wireType)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreatedHeight", wireType)
 			}
-			var msglen int
+			m.CreatedHeight = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2059,28 +5385,11 @@ func (m *ContractInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.CreatedHeight |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Extension == nil {
-				m.Extension = &types.Any{}
-			}
-			if err := m.Extension.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -2102,7 +5411,7 @@ func (m *ContractInfo) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ContractCodeHistoryEntry) Unmarshal(dAtA []byte) error {
+func (m *BulkMigration) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2125,17 +5434,17 @@ func (m *ContractCodeHistoryEntry) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ContractCodeHistoryEntry: wiretype end group for non-group")
+			return fmt.Errorf("proto: BulkMigration: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ContractCodeHistoryEntry: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BulkMigration: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Operation", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CodeID", wireType)
 			}
-			m.Operation = 0
+			m.CodeID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2145,16 +5454,16 @@ func (m *ContractCodeHistoryEntry) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Operation |= ContractCodeHistoryOperationType(b&0x7F) << shift
+				m.CodeID |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CodeID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NewCodeID", wireType)
 			}
-			m.CodeID = 0
+			m.NewCodeID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2164,16 +5473,16 @@ func (m *ContractCodeHistoryEntry) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.CodeID |= uint64(b&0x7F) << shift
+				m.NewCodeID |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Updated", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RunAs", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2183,31 +5492,27 @@ func (m *ContractCodeHistoryEntry) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Updated == nil {
-				m.Updated = &AbsoluteTxPosition{}
-			}
-			if err := m.Updated.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.RunAs = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Msg", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MigrateMsg", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -2234,66 +5539,50 @@ func (m *ContractCodeHistoryEntry) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Msg = append(m.Msg[:0], dAtA[iNdEx:postIndex]...)
-			if m.Msg == nil {
-				m.Msg = []byte{}
+			m.MigrateMsg = append(m.MigrateMsg[:0], dAtA[iNdEx:postIndex]...)
+			if m.MigrateMsg == nil {
+				m.MigrateMsg = []byte{}
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NextKey", wireType)
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthTypes
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *AbsoluteTxPosition) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowTypes
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
 			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.NextKey = append(m.NextKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.NextKey == nil {
+				m.NextKey = []byte{}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: AbsoluteTxPosition: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AbsoluteTxPosition: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 6:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BlockHeight", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Migrated", wireType)
 			}
-			m.BlockHeight = 0
+			m.Migrated = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2303,16 +5592,16 @@ func (m *AbsoluteTxPosition) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.BlockHeight |= uint64(b&0x7F) << shift
+				m.Migrated |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
+		case 7:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TxIndex", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Failed", wireType)
 			}
-			m.TxIndex = 0
+			m.Failed = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2322,7 +5611,7 @@ func (m *AbsoluteTxPosition) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.TxIndex |= uint64(b&0x7F) << shift
+				m.Failed |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2348,7 +5637,7 @@ func (m *AbsoluteTxPosition) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Model) Unmarshal(dAtA []byte) error {
+func (m *CodeStatsPruning) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2371,17 +5660,17 @@ func (m *Model) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Model: wiretype end group for non-group")
+			return fmt.Errorf("proto: CodeStatsPruning: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Model: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CodeStatsPruning: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BeforeEpoch", wireType)
 			}
-			var byteLen int
+			m.BeforeEpoch = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2391,29 +5680,14 @@ func (m *Model) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				m.BeforeEpoch |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
-			if m.Key == nil {
-				m.Key = []byte{}
-			}
-			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NextKey", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -2440,9 +5714,9 @@ func (m *Model) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
-			if m.Value == nil {
-				m.Value = []byte{}
+			m.NextKey = append(m.NextKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.NextKey == nil {
+				m.NextKey = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -232,6 +232,42 @@ func TestContractInfoMarshalUnmarshal(t *testing.T) {
 	assert.Equal(t, destExt.GetTitle(), "bar")
 }
 
+func TestContractInfoInitialHistory(t *testing.T) {
+	var anyPos = AbsoluteTxPosition{BlockHeight: 1, TxIndex: 2}
+	specs := map[string]struct {
+		srcMsg      []byte
+		expMsg      string
+		expEncoding MessageEncoding
+	}{
+		"json msg": {
+			srcMsg:      []byte(`{"foo":"bar"}`),
+			expMsg:      `{"foo":"bar"}`,
+			expEncoding: MessageEncodingJSON,
+		},
+		"binary msg": {
+			srcMsg:      []byte{0x81, 0xa3, 0x66, 0x6f, 0x6f},
+			expMsg:      `"gaNmb28="`,
+			expEncoding: MessageEncodingBinary,
+		},
+		"empty msg": {
+			expEncoding: MessageEncodingJSON,
+		},
+	}
+	marshaler := codec.NewProtoCodec(types.NewInterfaceRegistry())
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var myAddr sdk.AccAddress = rand.Bytes(sdk.AddrLen)
+			src := NewContractInfo(1, myAddr, nil, "bar", &anyPos)
+			entry := src.InitialHistory(spec.srcMsg)
+			assert.Equal(t, spec.expMsg, string(entry.Msg))
+			assert.Equal(t, spec.expEncoding, entry.MsgEncoding)
+			// history entries must always be json serializable
+			_, err := marshaler.MarshalJSON(&entry)
+			require.NoError(t, err)
+		})
+	}
+}
+
 func TestContractInfoReadExtension(t *testing.T) {
 	anyTime := time.Now().UTC()
 	myExtension, err := govtypes.NewProposal(&govtypes.TextProposal{Title: "foo"}, 1, anyTime, anyTime)
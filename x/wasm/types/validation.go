@@ -1,9 +1,11 @@
 package types
 
 import (
+	"encoding/json"
 	"net/url"
 	"regexp"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 )
 
@@ -27,6 +29,11 @@ const (
 	BuildTagRegexp = "^[a-z0-9][a-z0-9._-]*[a-z0-9](/[a-z0-9][a-z0-9._-]*[a-z0-9])+:[a-zA-Z0-9_][a-zA-Z0-9_.-]*$"
 
 	MaxBuildTagSize = 128
+
+	// MaxContractStateKeySize and MaxContractStateValueSize are the limits for the state that is imported into a
+	// contract store. Same as the wasm VM limits so that all imported state can be read by the contract.
+	MaxContractStateKeySize   = 64 * 1024
+	MaxContractStateValueSize = 128 * 1024
 )
 
 func validateSourceURL(source string) error {
@@ -78,3 +85,32 @@ func validateLabel(label string) error {
 	}
 	return nil
 }
+
+// validateMsgPayload checks the message for the given encoding. Binary payloads are not interpreted by the chain
+// and only must not be empty.
+func validateMsgPayload(msg []byte, encoding MessageEncoding) error {
+	switch encoding {
+	case MessageEncodingJSON:
+		if !json.Valid(msg) {
+			return sdkerrors.Wrap(ErrInvalid, "json")
+		}
+	case MessageEncodingBinary:
+		if len(msg) == 0 {
+			return sdkerrors.Wrap(ErrEmpty, "binary")
+		}
+	default:
+		return sdkerrors.Wrapf(ErrInvalid, "encoding %d", encoding)
+	}
+	return nil
+}
+
+// validateCoins is the sdk coins validation with a check for unset amounts. Coins that are decoded from the wire can
+// have an unset amount that makes the sdk validation panic.
+func validateCoins(coins sdk.Coins) error {
+	for _, c := range coins {
+		if c.Amount.IsNil() {
+			return sdkerrors.Wrapf(sdkerrors.ErrInvalidCoins, "amount of %q not set", c.Denom)
+		}
+	}
+	return coins.Validate()
+}
@@ -0,0 +1,97 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	vestingexported "github.com/cosmos/cosmos-sdk/x/auth/vesting/exported"
+	vestingtypes "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
+)
+
+// ValidateBasic validates the schedule without the block time
+func (s ContractVestingSchedule) ValidateBasic() error {
+	if s.Amount.Empty() {
+		return sdkerrors.Wrap(ErrEmpty, "amount")
+	}
+	if validateCoins(s.Amount) != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "amount")
+	}
+	switch s.Type {
+	case ContractVestingTypeContinuous:
+		if s.StartTime <= 0 {
+			return sdkerrors.Wrap(ErrInvalid, "start time")
+		}
+		if s.EndTime <= s.StartTime {
+			return sdkerrors.Wrap(ErrInvalid, "end time must be after start time")
+		}
+		if len(s.Periods) != 0 {
+			return sdkerrors.Wrap(ErrInvalid, "periods not supported for continuous vesting")
+		}
+	case ContractVestingTypeDelayed:
+		if s.StartTime != 0 {
+			return sdkerrors.Wrap(ErrInvalid, "start time not supported for delayed vesting")
+		}
+		if s.EndTime <= 0 {
+			return sdkerrors.Wrap(ErrInvalid, "end time")
+		}
+		if len(s.Periods) != 0 {
+			return sdkerrors.Wrap(ErrInvalid, "periods not supported for delayed vesting")
+		}
+	case ContractVestingTypePeriodic:
+		if s.StartTime <= 0 {
+			return sdkerrors.Wrap(ErrInvalid, "start time")
+		}
+		if s.EndTime != 0 {
+			return sdkerrors.Wrap(ErrInvalid, "end time not supported for periodic vesting")
+		}
+		if len(s.Periods) == 0 {
+			return sdkerrors.Wrap(ErrEmpty, "periods")
+		}
+		total := sdk.NewCoins()
+		for i, p := range s.Periods {
+			if p.Length <= 0 {
+				return sdkerrors.Wrapf(ErrInvalid, "length of period %d", i)
+			}
+			if p.Amount.Empty() || validateCoins(p.Amount) != nil {
+				return sdkerrors.Wrapf(sdkerrors.ErrInvalidCoins, "amount of period %d", i)
+			}
+			total = total.Add(p.Amount...)
+		}
+		if !total.IsEqual(s.Amount) {
+			return sdkerrors.Wrapf(ErrInvalid, "period amounts %s do not add up to %s", total, s.Amount)
+		}
+	default:
+		return sdkerrors.Wrapf(ErrInvalid, "type: %s", s.Type)
+	}
+	return nil
+}
+
+// VestingEndTime returns the unix time in seconds when all funds are vested
+func (s ContractVestingSchedule) VestingEndTime() int64 {
+	if s.Type != ContractVestingTypePeriodic {
+		return s.EndTime
+	}
+	end := s.StartTime
+	for _, p := range s.Periods {
+		end += p.Length
+	}
+	return end
+}
+
+// NewVestingAccount returns a vesting account with the schedule for the given account. The schedule must be valid.
+func (s ContractVestingSchedule) NewVestingAccount(acc *authtypes.BaseAccount) vestingexported.VestingAccount {
+	switch s.Type {
+	case ContractVestingTypeContinuous:
+		return vestingtypes.NewContinuousVestingAccount(acc, s.Amount, s.StartTime, s.EndTime)
+	case ContractVestingTypeDelayed:
+		return vestingtypes.NewDelayedVestingAccount(acc, s.Amount, s.EndTime)
+	case ContractVestingTypePeriodic:
+		periods := make(vestingtypes.Periods, len(s.Periods))
+		for i, p := range s.Periods {
+			periods[i] = vestingtypes.Period{Length: p.Length, Amount: p.Amount}
+		}
+		return vestingtypes.NewPeriodicVestingAccount(acc, s.Amount, s.StartTime, periods)
+	default:
+		panic(sdkerrors.Wrapf(ErrInvalid, "type: %s", s.Type))
+	}
+}